@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/asn"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/api"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+)
+
+type ASN struct {
+	asnService asn.Service
+}
+
+func NewASN(s asn.Service) *ASN {
+	return &ASN{
+		asnService: s,
+	}
+}
+
+func (h *ASN) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		asns, err := h.asnService.GetAll(c)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, asns)
+	}
+}
+
+func (h *ASN) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		found, err := h.asnService.Get(c, id)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, found)
+	}
+}
+
+func (h *ASN) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req api.ASNCreate
+		if err := c.ShouldBindJSON(&req); err != nil {
+			web.ValidationError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+		dates := []string{req.ETA}
+		for _, l := range req.Lines {
+			dates = append(dates, l.ManufacturingDate, l.DueDate)
+		}
+		for _, d := range dates {
+			if err := ValidateFormatDate(d); err != nil {
+				web.Error(c, http.StatusUnprocessableEntity, "%s", err)
+				return
+			}
+		}
+
+		a := domain.ASN{ASNNumber: req.ASNNumber, SellerID: req.SellerID, WarehouseID: req.WarehouseID, ETA: req.ETA, Lines: make([]domain.ASNLine, len(req.Lines))}
+		for i, l := range req.Lines {
+			a.Lines[i] = domain.ASNLine{ProductID: l.ProductID, Quantity: l.Quantity, BatchNumber: l.BatchNumber, ManufacturingDate: l.ManufacturingDate, DueDate: l.DueDate}
+		}
+		created, err := h.asnService.Save(c, a)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusCreated, created)
+	}
+}
+
+// Receive turns the notice in the path into an inbound order and the batches
+// it brings.
+func (h *ASN) Receive() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		var req api.ASNReceive
+		if err := c.ShouldBindJSON(&req); err != nil {
+			web.ValidationError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		received, err := h.asnService.Receive(c, id, req.EmployeeID, req.SectionID, req.OrderNumber)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusCreated, received)
+	}
+}
+
+// ReportOverdue lists the notices past their ETA of every seller, or of the
+// seller in ?id=.
+func (h *ASN) ReportOverdue() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := reportID(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		reports, err := h.asnService.ReportOverdue(c, id)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, reports)
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/asn"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	inboundorder "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/inbound_order"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+	asnmock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/asn"
+	inboundordermock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/inbound_order"
+	"github.com/stretchr/testify/assert"
+)
+
+func createServerASN() *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	repo := &asnmock.MockRepository{
+		Data: []domain.ASN{
+			{ID: 1, ASNNumber: "ASN-1", SellerID: 1, WarehouseID: 1, ETA: "2024-01-10", Status: domain.ASNPending,
+				Lines: []domain.ASNLine{{ID: 1, ProductID: 7, Quantity: 10, ManufacturingDate: "2024-01-01", DueDate: "2024-06-01"}}},
+			{ID: 2, ASNNumber: "ASN-2", SellerID: 1, WarehouseID: 1, ETA: "2024-01-08", Status: domain.ASNReceived, InboundOrderID: 1},
+		},
+		Sellers:    []domain.Seller{{ID: 1}},
+		Warehouses: []domain.Warehouse{{ID: 1}, {ID: 2}},
+		Products:   []domain.Product{{ID: 7, SellerID: 1}},
+	}
+	orders := &inboundordermock.MockRepositoryIBO{
+		DataMockEmp:  []domain.Employee{{ID: 4, WarehouseID: 1}},
+		DataMockWh:   []domain.Warehouse{{ID: 1}, {ID: 2}},
+		DataMockSec:  []domain.Section{{ID: 1, WarehouseID: 1}, {ID: 2, WarehouseID: 2}},
+		DataMockProd: []domain.Product{{ID: 7}},
+	}
+	inboundOrders := inboundorder.NewService(orders, events.Discard, logger.Discard())
+	handler := NewASN(asn.NewService(repo, inboundOrders, events.Discard, logger.Discard()))
+	r := gin.New()
+	r.Use(web.ErrorHandler())
+	ar := r.Group("/asns")
+	ar.GET("", handler.GetAll())
+	ar.GET("/reportOverdue", handler.ReportOverdue())
+	ar.GET("/:id", handler.Get())
+	ar.POST("", handler.Create())
+	ar.POST("/:id/receive", handler.Receive())
+	return r
+}
+
+func TestASN(t *testing.T) {
+	line := `{"product_id": 7, "quantity": 5, "manufacturing_date": "2024-02-01", "due_date": "2024-08-01"}`
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{name: "list", method: http.MethodGet, path: "/asns", status: http.StatusOK},
+		{name: "get", method: http.MethodGet, path: "/asns/1", status: http.StatusOK},
+		{name: "get unknown", method: http.MethodGet, path: "/asns/9", status: http.StatusNotFound},
+		{name: "get invalid id", method: http.MethodGet, path: "/asns/a", status: http.StatusBadRequest},
+		{name: "create", method: http.MethodPost, path: "/asns", body: `{"asn_number": "ASN-3", "seller_id": 1, "warehouse_id": 1, "eta": "2024-02-10", "lines": [` + line + `]}`, status: http.StatusCreated},
+		{name: "create without lines", method: http.MethodPost, path: "/asns", body: `{"asn_number": "ASN-3", "seller_id": 1, "warehouse_id": 1, "eta": "2024-02-10", "lines": []}`, status: http.StatusUnprocessableEntity},
+		{name: "create with a bad eta", method: http.MethodPost, path: "/asns", body: `{"asn_number": "ASN-3", "seller_id": 1, "warehouse_id": 1, "eta": "10/02/2024", "lines": [` + line + `]}`, status: http.StatusUnprocessableEntity},
+		{name: "create a taken number", method: http.MethodPost, path: "/asns", body: `{"asn_number": "ASN-1", "seller_id": 1, "warehouse_id": 1, "eta": "2024-02-10", "lines": [` + line + `]}`, status: http.StatusConflict},
+		{name: "create for an unknown seller", method: http.MethodPost, path: "/asns", body: `{"asn_number": "ASN-3", "seller_id": 9, "warehouse_id": 1, "eta": "2024-02-10", "lines": [` + line + `]}`, status: http.StatusConflict},
+		{name: "receive", method: http.MethodPost, path: "/asns/1/receive", body: `{"employee_id": 4, "section_id": 1}`, status: http.StatusCreated},
+		{name: "receive without an employee", method: http.MethodPost, path: "/asns/1/receive", body: `{"section_id": 1}`, status: http.StatusUnprocessableEntity},
+		{name: "receive into another warehouse", method: http.MethodPost, path: "/asns/1/receive", body: `{"employee_id": 4, "section_id": 2}`, status: http.StatusUnprocessableEntity},
+		{name: "receive twice", method: http.MethodPost, path: "/asns/2/receive", body: `{"employee_id": 4, "section_id": 1}`, status: http.StatusConflict},
+		{name: "receive unknown", method: http.MethodPost, path: "/asns/9/receive", body: `{"employee_id": 4, "section_id": 1}`, status: http.StatusNotFound},
+		{name: "report overdue", method: http.MethodGet, path: "/asns/reportOverdue", status: http.StatusOK},
+		{name: "report overdue of a seller", method: http.MethodGet, path: "/asns/reportOverdue?id=1", status: http.StatusOK},
+		{name: "report overdue of an invalid id", method: http.MethodGet, path: "/asns/reportOverdue?id=a", status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			createServerASN().ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.status, rr.Code, rr.Body.String())
+		})
+	}
+}
@@ -48,7 +48,7 @@ func (b *Buyer) Get() gin.HandlerFunc {
 
                 buyer, err := b.buyerService.Get(ctx, id)
                 if err != nil {
-                        web.Error(ctx, http.StatusNotFound, ErrNotFoundBuyers.Error())
+                        ctx.Error(err)
                         return
                 }
 
@@ -64,7 +64,7 @@ func (b *Buyer) GetAll() gin.HandlerFunc {
 
                 buyers, err := b.buyerService.GetAll(ctx)
 	        if err != nil {
-                        web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+                        ctx.Error(err)
 	        	return
 	        }
 
@@ -82,13 +82,13 @@ func (b *Buyer) Create() gin.HandlerFunc {
                 var req RequestBuyer
 
                 if err := ctx.ShouldBindJSON(&req); err != nil {
-                        web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+                        web.ValidationError(ctx, http.StatusUnprocessableEntity, err)
                         return
 		}
 
 		buyer, err := b.buyerService.Save(ctx, req.CardNumberID, req.FirstName, req.LastName)
 		if err != nil {
-                        web.Error(ctx, http.StatusConflict, err.Error())
+                        ctx.Error(err)
 			return
 		}
 
@@ -107,13 +107,13 @@ func (b *Buyer) Update() gin.HandlerFunc {
 
 		req := RequestPatchBuyer{}
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			web.Error(ctx, http.StatusBadRequest, err.Error())
+			web.ValidationError(ctx, http.StatusBadRequest, err)
 			return
 		}
 
                 buyerUpdated, err := b.buyerService.Update(ctx, id, req.FirstName, req.LastName)
                 if err != nil {
-			web.Error(ctx, http.StatusNotFound, err.Error())
+			ctx.Error(err)
 			return
 		}
 
@@ -131,7 +131,7 @@ func (b *Buyer) Delete() gin.HandlerFunc {
 		}
 
 		if err := b.buyerService.Delete(ctx, id); err != nil {
-			web.Error(ctx, http.StatusNotFound, err.Error())
+			ctx.Error(err)
 			return
 		}
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"github.com/gin-gonic/gin"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/buyer"
 	"github.com/stretchr/testify/assert"
 	"net/http"
@@ -18,6 +19,7 @@ func createServer(mockService buyer.MockService) *gin.Engine {
 
 	handler := NewBuyer(&mockService)
 	router := gin.Default()
+	router.Use(web.ErrorHandler())
 
 	rg := router.Group("/api/v1")
 	pr := rg.Group("buyers")
@@ -81,7 +83,7 @@ func TestHandlerFindAllFail(t *testing.T) {
 		Error:    errMessage,
 	}
 
-	var resp map[string]string
+	var resp web.Problem
 
 	router := createServer(mockService)
 	req, rr := createRequestTest(http.MethodGet, "/api/v1/buyers", "")
@@ -95,8 +97,8 @@ func TestHandlerFindAllFail(t *testing.T) {
 
 	// Assert
 	assert.Nil(t, err)
-	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
-	assert.Equal(t, mockService.Error, resp["message"])
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.NotContains(t, resp.Detail, mockService.Error)
 }
 
 func TestHandlerFindByIdExistent(t *testing.T) {
@@ -161,7 +163,7 @@ func TestHandlerFindByIdNotExistent(t *testing.T) {
 		Error:    errMessage,
 	}
 
-	var resp map[string]string
+	var resp web.Problem
 
 	router := createServer(mockService)
 	req, rr := createRequestTest(http.MethodGet, "/api/v1/buyers/5", "")
@@ -176,7 +178,7 @@ func TestHandlerFindByIdNotExistent(t *testing.T) {
 	// Assert
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusNotFound, rr.Code)
-	assert.Equal(t, mockService.Error, resp["message"])
+	assert.Equal(t, mockService.Error, resp.Detail)
 }
 
 func TestHandlerFindByIdBadID(t *testing.T) {
@@ -189,7 +191,7 @@ func TestHandlerFindByIdBadID(t *testing.T) {
 		Error:    errMessage,
 	}
 
-	var resp map[string]string
+	var resp web.Problem
 
 	router := createServer(mockService)
 	req, rr := createRequestTest(http.MethodGet, "/api/v1/buyers/bar", "")
@@ -204,7 +206,7 @@ func TestHandlerFindByIdBadID(t *testing.T) {
 	// Assert
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
-	assert.Equal(t, mockService.Error, resp["message"])
+	assert.Equal(t, mockService.Error, resp.Detail)
 }
 
 func TestHandlerCreateOk(t *testing.T) {
@@ -271,7 +273,7 @@ func TestHandlerCreateFail(t *testing.T) {
 		Error:    errMessage,
 	}
 
-	var resp map[string]string
+	var resp web.Problem
 
 	// Act
 	router := createServer(mockService)
@@ -285,7 +287,7 @@ func TestHandlerCreateFail(t *testing.T) {
 	// Assert
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
-	assert.Equal(t, errMessage, resp["message"])
+	assert.Equal(t, errMessage, resp.Detail)
 }
 
 func TestHandlerCreateConflict(t *testing.T) {
@@ -318,7 +320,7 @@ func TestHandlerCreateConflict(t *testing.T) {
 		Error:    errMessage,
 	}
 
-	var resp map[string]string
+	var resp web.Problem
 
 	// Act
 	router := createServer(mockService)
@@ -332,7 +334,7 @@ func TestHandlerCreateConflict(t *testing.T) {
 	// Assert
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusConflict, rr.Code)
-	assert.Equal(t, errMessage, resp["message"])
+	assert.Equal(t, errMessage, resp.Detail)
 }
 
 func TestHandlerUpdateOk(t *testing.T) {
@@ -395,7 +397,7 @@ func TestHandlerUpdateNotExisting(t *testing.T) {
 		Error:    errMessage,
 	}
 
-	var resp map[string]string
+	var resp web.Problem
 
 	productToUpdate, err := json.Marshal(&afterUpdate)
 	if err != nil {
@@ -415,7 +417,7 @@ func TestHandlerUpdateNotExisting(t *testing.T) {
 	// Assert
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusNotFound, rr.Code)
-	assert.Equal(t, errMessage, resp["message"])
+	assert.Equal(t, errMessage, resp.Detail)
 }
 
 func TestHandlerUpdateBadId(t *testing.T) {
@@ -433,7 +435,7 @@ func TestHandlerUpdateBadId(t *testing.T) {
 		Error:    errMessage,
 	}
 
-	var resp map[string]string
+	var resp web.Problem
 
 	productToUpdate, err := json.Marshal(&afterUpdate)
 	if err != nil {
@@ -453,7 +455,7 @@ func TestHandlerUpdateBadId(t *testing.T) {
 	// Assert
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
-	assert.Equal(t, errMessage, resp["message"])
+	assert.Equal(t, errMessage, resp.Detail)
 }
 
 func TestHandlerDeleteOk(t *testing.T) {
@@ -21,27 +21,18 @@ func NewCarry(c carry.Service) *Carry {
 	}
 }
 
-// CreateCarry godoc
-// @Summary Create a carry
-// @Tags Carries
-// @Description create a carry
-// @Accept  json
-// @Produce  json
-// @Param carry body domain.Carry true "Carry"
-// @Success 201 {object} web.response
-// @Router /api/v1/carries [post]
 func (c *Carry) Create() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		carry := domain.Carry{}
 		if err := ctx.ShouldBindJSON(&carry); err != nil {
-			web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+			web.ValidationError(ctx, http.StatusUnprocessableEntity, err)
 			return
 		}
 
 		emptyFields := []string{}
 		values := reflect.ValueOf(carry)
 		for i := 0; i < values.NumField(); i++ {
-			if values.Type().Field(i).Name == "ID" || values.Type().Field(i).Name == "Locality_id" {
+			if name := values.Type().Field(i).Name; name == "ID" || name == "SiteID" || name == "Locality_id" {
 				continue
 			}
 			if values.Field(i).IsZero() {
@@ -54,9 +45,9 @@ func (c *Carry) Create() gin.HandlerFunc {
 			return
 		}
 
-		id, err := c.carryService.Save(carry)
+		id, err := c.carryService.Save(ctx, carry)
 		if err != nil {
-			web.Error(ctx, http.StatusConflict, err.Error())
+			ctx.Error(err)
 			return
 		}
 
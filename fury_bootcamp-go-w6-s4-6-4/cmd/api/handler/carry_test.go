@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/carry"
 	"github.com/stretchr/testify/assert"
 )
@@ -16,6 +17,7 @@ var carryService = createCarryService()
 
 func createCarryService() *gin.Engine {
 	r := gin.Default()
+	r.Use(web.ErrorHandler())
 	service := carry.NewServiceCarry([]domain.Carry{})
 	handler := NewCarry(service)
 
@@ -61,7 +63,7 @@ func TestCreateCarry(t *testing.T) {
 
 		// Assert
 		assert.Equal(t, http.StatusConflict, w.Code)
-		assert.Equal(t, `{"code":"conflict","message":"carry with code 1 already exists"}`, w.Body.String())
+		assert.Equal(t, `{"type":"urn:melisprint:problem:conflict","title":"Resource already exists","status":409,"detail":"carry with code 1 already exists","instance":"/carries","code":"conflict"}`, w.Body.String())
 	})
 	t.Run("should not create a new carry with non existent locality", func(t *testing.T) {
 		// Arrange
@@ -72,6 +74,6 @@ func TestCreateCarry(t *testing.T) {
 
 		// Assert
 		assert.Equal(t, http.StatusConflict, w.Code)
-		assert.Equal(t, `{"code":"conflict","message":"locality with code 2 not exists"}`, w.Body.String())
+		assert.Equal(t, `{"type":"urn:melisprint:problem:foreign_key_violation","title":"Referenced resource does not exist","status":409,"detail":"locality with code 2 not exists","instance":"/carries","code":"foreign_key_violation"}`, w.Body.String())
 	})
 }
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/country"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/api"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+)
+
+type Country struct {
+	countryService country.Service
+}
+
+func NewCountry(s country.Service) *Country {
+	return &Country{
+		countryService: s,
+	}
+}
+
+func (co *Country) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		countries, err := co.countryService.GetAll(c)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, countries)
+	}
+}
+
+func (co *Country) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		found, err := co.countryService.Get(c, id)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, found)
+	}
+}
+
+func (co *Country) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req api.CountryCreate
+		if err := c.ShouldBindJSON(&req); err != nil {
+			web.ValidationError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		created, err := co.countryService.Save(c, domain.Country{ID: req.ID, CountryName: req.CountryName})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusCreated, created)
+	}
+}
+
+func (co *Country) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		var req api.CountryUpdate
+		if err := c.ShouldBindJSON(&req); err != nil {
+			web.ValidationError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		updated, err := co.countryService.Update(c, domain.Country{CountryName: req.CountryName}, id)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, updated)
+	}
+}
+
+func (co *Country) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		if err := co.countryService.Delete(c, id); err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusNoContent, nil)
+	}
+}
+
+// Report counts the sellers and the carries of every country, or of the
+// country in ?id=.
+func (co *Country) Report() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := reportID(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		reports, err := co.countryService.Report(c, id)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, reports)
+	}
+}
+
+// reportID is the ?id= a report is narrowed to, or 0 when there's none.
+func reportID(c *gin.Context) (int, error) {
+	id := c.Query("id")
+	if id == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(id)
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/country"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+	countrymock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/country"
+	"github.com/stretchr/testify/assert"
+)
+
+func createServerCountry() *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	repo := &countrymock.MockRepository{
+		DataMock: []domain.Country{{ID: 32, CountryName: "Argentina"}},
+		Reports:  []domain.CountryReport{{CountryID: 32, CountryName: "Argentina", SellersCount: 3, CarriesCount: 2}},
+	}
+	handler := NewCountry(country.NewService(repo, logger.Discard()))
+	r := gin.New()
+	r.Use(web.ErrorHandler())
+	cr := r.Group("/countries")
+	cr.GET("", handler.GetAll())
+	cr.GET("/report", handler.Report())
+	cr.GET("/:id", handler.Get())
+	cr.POST("", handler.Create())
+	cr.PATCH("/:id", handler.Update())
+	cr.DELETE("/:id", handler.Delete())
+	return r
+}
+
+func TestCountry(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{name: "list", method: http.MethodGet, path: "/countries", status: http.StatusOK},
+		{name: "get", method: http.MethodGet, path: "/countries/32", status: http.StatusOK},
+		{name: "get unknown", method: http.MethodGet, path: "/countries/9", status: http.StatusNotFound},
+		{name: "get invalid id", method: http.MethodGet, path: "/countries/ar", status: http.StatusBadRequest},
+		{name: "create with a generated id", method: http.MethodPost, path: "/countries", body: `{"country_name": "Brasil"}`, status: http.StatusCreated},
+		{name: "create with an official id", method: http.MethodPost, path: "/countries", body: `{"id": 76, "country_name": "Brasil"}`, status: http.StatusCreated},
+		{name: "create without a name", method: http.MethodPost, path: "/countries", body: `{"id": 76}`, status: http.StatusUnprocessableEntity},
+		{name: "create with a negative id", method: http.MethodPost, path: "/countries", body: `{"id": -1, "country_name": "Brasil"}`, status: http.StatusUnprocessableEntity},
+		{name: "create a taken id", method: http.MethodPost, path: "/countries", body: `{"id": 32, "country_name": "Brasil"}`, status: http.StatusConflict},
+		{name: "create a taken name", method: http.MethodPost, path: "/countries", body: `{"country_name": "Argentina"}`, status: http.StatusConflict},
+		{name: "update", method: http.MethodPatch, path: "/countries/32", body: `{"country_name": "República Argentina"}`, status: http.StatusOK},
+		{name: "update unknown", method: http.MethodPatch, path: "/countries/9", body: `{"country_name": "Chile"}`, status: http.StatusNotFound},
+		{name: "report", method: http.MethodGet, path: "/countries/report", status: http.StatusOK},
+		{name: "report of a country", method: http.MethodGet, path: "/countries/report?id=32", status: http.StatusOK},
+		{name: "report of an unknown country", method: http.MethodGet, path: "/countries/report?id=9", status: http.StatusNotFound},
+		{name: "report of an invalid id", method: http.MethodGet, path: "/countries/report?id=ar", status: http.StatusBadRequest},
+		{name: "delete", method: http.MethodDelete, path: "/countries/32", status: http.StatusNoContent},
+		{name: "delete unknown", method: http.MethodDelete, path: "/countries/9", status: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			createServerCountry().ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.status, rr.Code, rr.Body.String())
+		})
+	}
+}
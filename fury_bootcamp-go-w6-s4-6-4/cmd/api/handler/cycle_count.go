@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	cyclecount "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/cycle_count"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/api"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+)
+
+type CycleCount struct {
+	cycleCountService cyclecount.Service
+}
+
+func NewCycleCount(s cyclecount.Service) *CycleCount {
+	return &CycleCount{
+		cycleCountService: s,
+	}
+}
+
+func (h *CycleCount) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		counts, err := h.cycleCountService.GetAll(c)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, counts)
+	}
+}
+
+func (h *CycleCount) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		found, err := h.cycleCountService.Get(c, id)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, found)
+	}
+}
+
+// Create generates a count of the section for the employee.
+func (h *CycleCount) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req api.CycleCountCreate
+		if err := c.ShouldBindJSON(&req); err != nil {
+			web.ValidationError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		created, err := h.cycleCountService.Generate(c, req.SectionID, req.EmployeeID, req.Selection, req.Class, req.SampleSize)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusCreated, created)
+	}
+}
+
+// Submit takes what was counted of the lines of the count in the path. It's
+// only for callers identified by X-User-ID as the employee assigned.
+func (h *CycleCount) Submit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		submitter := c.GetHeader(web.UserHeader)
+		if submitter == "" {
+			c.Error(cyclecount.ErrSubmitterUnknown)
+			return
+		}
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		var req api.CycleCountSubmit
+		if err := c.ShouldBindJSON(&req); err != nil {
+			web.ValidationError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		counts := make([]cyclecount.Count, len(req.Lines))
+		for i, l := range req.Lines {
+			counts[i] = cyclecount.Count{LineID: l.LineID, CountedQuantity: l.CountedQuantity, ReasonCode: string(l.ReasonCode)}
+		}
+		counted, err := h.cycleCountService.Submit(c, id, submitter, counts)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, counted)
+	}
+}
+
+// Approve approves or rejects the adjustments of the count in the path. It's
+// only for callers identified by X-User-ID, who are recorded as approving
+// it, and with the supervisor role.
+func (h *CycleCount) Approve() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		supervisor := c.GetHeader(web.UserHeader)
+		if supervisor == "" {
+			c.Error(cyclecount.ErrSupervisorUnknown)
+			return
+		}
+		if !tenant.HasRole(c.GetHeader(tenant.RolesHeader), tenant.SupervisorRole) {
+			c.Error(cyclecount.ErrNotSupervisor)
+			return
+		}
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		var req api.CycleCountApproval
+		if err := c.ShouldBindJSON(&req); err != nil {
+			web.ValidationError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		approved, err := h.cycleCountService.Approve(c, id, *req.Approved, supervisor)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, approved)
+	}
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	cyclecount "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/cycle_count"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+	cyclecountmock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/cycle_count"
+	"github.com/stretchr/testify/assert"
+)
+
+func createServerCycleCount() *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	repo := &cyclecountmock.MockRepository{
+		Data: []domain.CycleCount{
+			{ID: 1, SectionID: 1, WarehouseID: 1, EmployeeID: 4, Selection: domain.CycleCountAll, Status: domain.CycleCountOpen,
+				Lines: []domain.CycleCountLine{{ID: 1, ProductBatchID: 1, ProductID: 7}}},
+			{ID: 2, SectionID: 1, WarehouseID: 1, EmployeeID: 4, Selection: domain.CycleCountAll, Status: domain.CycleCountPendingApproval,
+				Lines: []domain.CycleCountLine{{ID: 2, ProductBatchID: 1, ProductID: 7}}},
+		},
+		Employees: []domain.Employee{{ID: 4, WarehouseID: 1}, {ID: 5, WarehouseID: 2}},
+		Sections:  []domain.Section{{ID: 1, WarehouseID: 1}},
+		Batches:   []domain.Product_batches{{ID: 1, SectionId: 1, ProductId: 7, CurrentQuantity: 10}},
+	}
+	handler := NewCycleCount(cyclecount.NewService(repo, events.Discard, 5, logger.Discard()))
+	r := gin.New()
+	r.Use(web.ErrorHandler())
+	cr := r.Group("/cycleCounts")
+	cr.GET("", handler.GetAll())
+	cr.GET("/:id", handler.Get())
+	cr.POST("", handler.Create())
+	cr.POST("/:id/counts", handler.Submit())
+	cr.POST("/:id/approval", handler.Approve())
+	return r
+}
+
+func TestCycleCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		body      string
+		roles     string
+		user      string
+		anonymous bool
+		status    int
+	}{
+		{name: "list", method: http.MethodGet, path: "/cycleCounts", status: http.StatusOK},
+		{name: "get", method: http.MethodGet, path: "/cycleCounts/1", status: http.StatusOK},
+		{name: "get unknown", method: http.MethodGet, path: "/cycleCounts/9", status: http.StatusNotFound},
+		{name: "get invalid id", method: http.MethodGet, path: "/cycleCounts/a", status: http.StatusBadRequest},
+		{name: "create", method: http.MethodPost, path: "/cycleCounts", body: `{"section_id": 1, "employee_id": 4, "selection": "all"}`, status: http.StatusCreated},
+		{name: "create of a class", method: http.MethodPost, path: "/cycleCounts", body: `{"section_id": 1, "employee_id": 4, "selection": "abc", "class": "A"}`, status: http.StatusCreated},
+		{name: "create of a sample", method: http.MethodPost, path: "/cycleCounts", body: `{"section_id": 1, "employee_id": 4, "selection": "random", "sample_size": 1}`, status: http.StatusCreated},
+		{name: "create without a class", method: http.MethodPost, path: "/cycleCounts", body: `{"section_id": 1, "employee_id": 4, "selection": "abc"}`, status: http.StatusUnprocessableEntity},
+		{name: "create of an unknown class", method: http.MethodPost, path: "/cycleCounts", body: `{"section_id": 1, "employee_id": 4, "selection": "abc", "class": "D"}`, status: http.StatusUnprocessableEntity},
+		{name: "create of a negative sample", method: http.MethodPost, path: "/cycleCounts", body: `{"section_id": 1, "employee_id": 4, "selection": "random", "sample_size": -1}`, status: http.StatusUnprocessableEntity},
+		{name: "create without a sample size", method: http.MethodPost, path: "/cycleCounts", body: `{"section_id": 1, "employee_id": 4, "selection": "random"}`, status: http.StatusUnprocessableEntity},
+		{name: "create of an unknown selection", method: http.MethodPost, path: "/cycleCounts", body: `{"section_id": 1, "employee_id": 4, "selection": "some"}`, status: http.StatusUnprocessableEntity},
+		{name: "create for an employee of another warehouse", method: http.MethodPost, path: "/cycleCounts", body: `{"section_id": 1, "employee_id": 5, "selection": "all"}`, status: http.StatusUnprocessableEntity},
+		{name: "create in an unknown section", method: http.MethodPost, path: "/cycleCounts", body: `{"section_id": 9, "employee_id": 4, "selection": "all"}`, status: http.StatusConflict},
+		{name: "submit", method: http.MethodPost, path: "/cycleCounts/1/counts", body: `{"lines": [{"line_id": 1, "counted_quantity": 8, "reason_code": "damaged"}]}`, status: http.StatusOK},
+		{name: "submit nothing counted", method: http.MethodPost, path: "/cycleCounts/1/counts", body: `{"lines": [{"line_id": 1, "counted_quantity": 0, "reason_code": "lost"}]}`, status: http.StatusOK},
+		{name: "submit a negative count", method: http.MethodPost, path: "/cycleCounts/1/counts", body: `{"lines": [{"line_id": 1, "counted_quantity": -1}]}`, status: http.StatusUnprocessableEntity},
+		{name: "submit without a count", method: http.MethodPost, path: "/cycleCounts/1/counts", body: `{"lines": [{"line_id": 1}]}`, status: http.StatusUnprocessableEntity},
+		{name: "submit without a reason", method: http.MethodPost, path: "/cycleCounts/1/counts", body: `{"lines": [{"line_id": 1, "counted_quantity": 8}]}`, status: http.StatusUnprocessableEntity},
+		{name: "submit as another employee", method: http.MethodPost, path: "/cycleCounts/1/counts", body: `{"lines": [{"line_id": 1, "counted_quantity": 10}]}`, user: "5", status: http.StatusForbidden},
+		{name: "submit anonymously", method: http.MethodPost, path: "/cycleCounts/1/counts", body: `{"lines": [{"line_id": 1, "counted_quantity": 10}]}`, anonymous: true, status: http.StatusUnauthorized},
+		{name: "submit counted", method: http.MethodPost, path: "/cycleCounts/2/counts", body: `{"lines": [{"line_id": 2, "counted_quantity": 10}]}`, status: http.StatusConflict},
+		{name: "approve", method: http.MethodPost, path: "/cycleCounts/2/approval", body: `{"approved": true}`, roles: "picker, supervisor", status: http.StatusOK},
+		{name: "reject", method: http.MethodPost, path: "/cycleCounts/2/approval", body: `{"approved": false}`, roles: "supervisor", status: http.StatusOK},
+		{name: "approve without deciding", method: http.MethodPost, path: "/cycleCounts/2/approval", body: `{}`, roles: "supervisor", status: http.StatusUnprocessableEntity},
+		{name: "approve as another role", method: http.MethodPost, path: "/cycleCounts/2/approval", body: `{"approved": true}`, roles: "picker", status: http.StatusForbidden},
+		{name: "approve open", method: http.MethodPost, path: "/cycleCounts/1/approval", body: `{"approved": true}`, roles: "supervisor", status: http.StatusConflict},
+		{name: "approve anonymously", method: http.MethodPost, path: "/cycleCounts/2/approval", body: `{"approved": true}`, roles: "supervisor", anonymous: true, status: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(tenant.RolesHeader, tt.roles)
+			if !tt.anonymous {
+				user := tt.user
+				if user == "" {
+					user = "4"
+				}
+				req.Header.Set(web.UserHeader, user)
+			}
+			rr := httptest.NewRecorder()
+
+			createServerCycleCount().ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.status, rr.Code, rr.Body.String())
+		})
+	}
+}
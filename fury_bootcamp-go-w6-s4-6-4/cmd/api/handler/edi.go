@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/edi"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+)
+
+// MediaTypeX12 is the content type of the X12 interchanges written.
+const MediaTypeX12 = "application/edi-x12"
+
+// formOverhead is what the multipart form around an uploaded file may add
+// to the size of the request.
+const formOverhead = 1 << 16
+
+type EDI struct {
+	ediService  edi.Service
+	maxFileSize int
+}
+
+// NewEDI returns a handler that takes interchanges of up to maxFileSize
+// bytes.
+func NewEDI(s edi.Service, maxFileSize int) *EDI {
+	return &EDI{
+		ediService:  s,
+		maxFileSize: maxFileSize,
+	}
+}
+
+// Import declares the ship notices of the interchange uploaded as the
+// "file" of a multipart form. The form may also name the employee_id and
+// section_id that receive them at once.
+func (h *EDI) Import() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, int64(h.maxFileSize+formOverhead))
+		header, err := c.FormFile("file")
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				web.Error(c, http.StatusRequestEntityTooLarge, "the file must not be larger than %d bytes", h.maxFileSize)
+				return
+			}
+			web.Error(c, http.StatusBadRequest, "file: %s", err)
+			return
+		}
+		if header.Size > int64(h.maxFileSize) {
+			web.Error(c, http.StatusRequestEntityTooLarge, "the file must not be larger than %d bytes", h.maxFileSize)
+			return
+		}
+		employeeID, err := formID(c, "employee_id")
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		sectionID, err := formID(c, "section_id")
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+
+		f, err := header.Open()
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "file: %s", err)
+			return
+		}
+		defer f.Close()
+		data, err := io.ReadAll(f)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "file: %s", err)
+			return
+		}
+
+		imported, err := h.ediService.Import(c, data, employeeID, sectionID)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusCreated, imported)
+	}
+}
+
+// ReceiptAdvice answers the 944 of the inbound order in the path.
+func (h *EDI) ReceiptAdvice() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		written, err := h.ediService.ReceiptAdvice(c, id)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		attachment(c, fmt.Sprintf("944-%d.edi", id), written)
+	}
+}
+
+// ShipNotice answers the 856 of the purchase order in the path, shipped by
+// the carry of ?carry_id=.
+func (h *EDI) ShipNotice() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		carryID, err := strconv.Atoi(c.Query("carry_id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "carry_id: %s", err)
+			return
+		}
+		written, err := h.ediService.ShipNotice(c, id, carryID)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		attachment(c, fmt.Sprintf("856-%d.edi", id), written)
+	}
+}
+
+// formID returns the id in the form field key, or 0 when it's left out.
+func formID(c *gin.Context, key string) (int, error) {
+	value := c.PostForm(key)
+	if value == "" {
+		return 0, nil
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil || id < 1 {
+		return 0, fmt.Errorf("%s: must be a positive integer", key)
+	}
+	return id, nil
+}
+
+func attachment(c *gin.Context, name string, data []byte) {
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	c.Data(http.StatusOK, MediaTypeX12, data)
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+	edimock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/edi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createServerEDI(service *edimock.MockService) *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	handler := NewEDI(service, 64)
+	r := gin.New()
+	r.Use(web.ErrorHandler())
+	r.POST("/asns/edi", handler.Import())
+	r.GET("/inboundOrders/:id/receiptAdvice", handler.ReceiptAdvice())
+	r.GET("/purchaseOrders/:id/shipNotice", handler.ShipNotice())
+	return r
+}
+
+// uploadRequest posts file, unless it's empty, and fields as a multipart
+// form.
+func uploadRequest(t *testing.T, file string, fields map[string]string) *http.Request {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if file != "" {
+		part, err := w.CreateFormFile("file", "856.edi")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(file))
+		require.NoError(t, err)
+	}
+	for key, value := range fields {
+		require.NoError(t, w.WriteField(key, value))
+	}
+	require.NoError(t, w.Close())
+	req := httptest.NewRequest(http.MethodPost, "/asns/edi", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func TestEDIImport(t *testing.T) {
+	imported := []domain.ASN{{ID: 1, ASNNumber: "ASN-1", Status: domain.ASNPending}}
+
+	t.Run("should import the file", func(t *testing.T) {
+		service := &edimock.MockService{Imported: imported}
+		rr := httptest.NewRecorder()
+
+		createServerEDI(service).ServeHTTP(rr, uploadRequest(t, "ISA*00~", map[string]string{"employee_id": "4", "section_id": "1"}))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.JSONEq(t, `{"data": [{"id": 1, "asn_number": "ASN-1", "seller_id": 0, "warehouse_id": 0, "eta": "", "status": "pending", "lines": null}]}`, rr.Body.String())
+		assert.Equal(t, "ISA*00~", string(service.Data))
+		assert.Equal(t, []int{4, 1}, []int{service.EmployeeID, service.SectionID})
+	})
+
+	tests := []struct {
+		name   string
+		file   string
+		fields map[string]string
+		err    error
+		status int
+	}{
+		{name: "should need the file", status: http.StatusBadRequest},
+		{name: "should reject a file too large", file: strings.Repeat("~", 65), status: http.StatusRequestEntityTooLarge},
+		{name: "should reject an invalid employee", file: "ISA*00~", fields: map[string]string{"employee_id": "a"}, status: http.StatusBadRequest},
+		{name: "should reject what isn't EDI", file: "ISA*00~", err: apperrors.Validation("invalid EDI interchange"), status: http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &edimock.MockService{Imported: imported, Err: tt.err}
+			rr := httptest.NewRecorder()
+
+			createServerEDI(service).ServeHTTP(rr, uploadRequest(t, tt.file, tt.fields))
+
+			assert.Equal(t, tt.status, rr.Code)
+		})
+	}
+	t.Run("should reject a request too large", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		createServerEDI(&edimock.MockService{}).ServeHTTP(rr, uploadRequest(t, strings.Repeat("~", 64+formOverhead), nil))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	})
+}
+
+func TestEDIExport(t *testing.T) {
+	service := &edimock.MockService{
+		Receipts: map[int][]byte{7: []byte("ISA*944~")},
+		Notices:  map[int][]byte{3: []byte("ISA*856~")},
+	}
+	tests := []struct {
+		name     string
+		path     string
+		status   int
+		body     string
+		filename string
+	}{
+		{name: "receipt advice", path: "/inboundOrders/7/receiptAdvice", status: http.StatusOK, body: "ISA*944~", filename: "944-7.edi"},
+		{name: "receipt advice of an unknown order", path: "/inboundOrders/9/receiptAdvice", status: http.StatusNotFound},
+		{name: "receipt advice of an invalid id", path: "/inboundOrders/a/receiptAdvice", status: http.StatusBadRequest},
+		{name: "ship notice", path: "/purchaseOrders/3/shipNotice?carry_id=1", status: http.StatusOK, body: "ISA*856~", filename: "856-3.edi"},
+		{name: "ship notice without a carry", path: "/purchaseOrders/3/shipNotice", status: http.StatusBadRequest},
+		{name: "ship notice of an unknown order", path: "/purchaseOrders/9/shipNotice?carry_id=1", status: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+
+			createServerEDI(service).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.status, rr.Code)
+			if tt.status == http.StatusOK {
+				assert.Equal(t, tt.body, rr.Body.String())
+				assert.Equal(t, MediaTypeX12, rr.Header().Get("Content-Type"))
+				assert.Equal(t, `attachment; filename="`+tt.filename+`"`, rr.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
@@ -34,7 +34,7 @@ func (e *Employee) Get() gin.HandlerFunc {
 		}
 		employeebyId, err := e.employeeService.GetEmployeeByID(c, int(id))
 		if err != nil {
-			web.Error(c, 404, "%s", err)
+			c.Error(err)
 			return
 		}
 		web.Success(c, 200, employeebyId)
@@ -45,7 +45,7 @@ func (e *Employee) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		employees, err := e.employeeService.GetAllEmployees(c)
 		if err != nil {
-			web.Error(c, 404, "%s", err)
+			c.Error(err)
 			return
 		}
 		if len(employees) == 0 {
@@ -61,7 +61,7 @@ func (e *Employee) Create() gin.HandlerFunc {
 		var req request
 
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, 422, "%s", err)
+			web.ValidationError(c, 422, err)
 			return
 		}
 		if req.CardNumberID == "" {
@@ -86,11 +86,7 @@ func (e *Employee) Create() gin.HandlerFunc {
 		}
 		emp, err := e.employeeService.Save(c, req.CardNumberID, req.FirstName, req.LastName, *req.WarehouseID)
 		if err != nil {
-			if err.Error() == "The card_number_id already exists" {
-				web.Error(c, 409, "%s", err)
-			} else {
-				web.Error(c, 500, "%s", err)
-			}
+			c.Error(err)
 			return
 		}
 		web.Success(c, 201, emp)
@@ -106,7 +102,7 @@ func (e *Employee) Update() gin.HandlerFunc {
 		}
 		var req request
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, 422, "%s", err)
+			web.ValidationError(c, 422, err)
 			return
 		}
 		if req.CardNumberID != "" {
@@ -115,7 +111,7 @@ func (e *Employee) Update() gin.HandlerFunc {
 		}
 		employeeUpdate, err := e.employeeService.Update(c, int(id), req.FirstName, req.LastName, req.WarehouseID)
 		if err != nil {
-			web.Error(c, 404, "%s", err)
+			c.Error(err)
 			return
 		}
 		web.Success(c, 200, employeeUpdate)
@@ -131,11 +127,7 @@ func (e *Employee) Delete() gin.HandlerFunc {
 		}
 		err = e.employeeService.Delete(c, int(id))
 		if err != nil {
-			if err.Error() == "employee not found" {
-				web.Error(c, 404, "%s", err)
-			} else {
-				web.Error(c, 500, "%s", err)
-			}
+			c.Error(err)
 			return
 		}
 		web.Success(c, 204, "employee deleted")
@@ -147,13 +139,7 @@ func (e *Employee) Report_InboundOrders() gin.HandlerFunc {
 		id := ctx.Query("id")
 		reports, err := e.employeeService.Report_BO(ctx, id)
 		if err != nil {
-			if err.Error() == "employee not found" {
-				web.Error(ctx, 404, "%s", err)
-			} else if err.Error() == "invalid id format" {
-				web.Error(ctx, 422, "%s", err)
-			} else {
-				web.Error(ctx, 500, "%s", err)
-			}
+			ctx.Error(err)
 			return
 		}
 		if len(reports) == 0 {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/employee"
 	"github.com/stretchr/testify/assert"
 )
@@ -34,6 +35,7 @@ func createServerEmployee(mockService *employee.MockServiceEmployee) *gin.Engine
 	gin.SetMode(gin.ReleaseMode)
 	handler := NewEmployee(mockService)
 	r := gin.Default()
+	r.Use(web.ErrorHandler())
 	er := r.Group("/employees")
 	er.POST("/", handler.Create())
 	er.GET("/", handler.GetAll())
@@ -127,7 +129,7 @@ func Test_create_fail_Handler(t *testing.T) {
 	t.Run("Create fail card number", func(t *testing.T) {
 		//Arrange
 		errorExpected := "card_number_id is required"
-		var errorResult map[string]string
+		var errorResult web.Problem
 
 		var dat []domain.Employee
 		dat = append(dat, data...)
@@ -147,14 +149,14 @@ func Test_create_fail_Handler(t *testing.T) {
 		assert.False(t, myMockS.MethodCalled)
 		assert.Nil(t, err)
 		assert.Equal(t, 422, rec.Code)
-		assert.Equal(t, errorExpected, errorResult["message"])
+		assert.Equal(t, errorExpected, errorResult.Detail)
 	})
 
 	//first name
 	t.Run("Create fail first name", func(t *testing.T) {
 		//Arrange
 		errorExpected := "first_name is required"
-		var errorResult map[string]string
+		var errorResult web.Problem
 
 		var dat []domain.Employee
 		dat = append(dat, data...)
@@ -175,14 +177,14 @@ func Test_create_fail_Handler(t *testing.T) {
 		assert.False(t, myMockS.MethodCalled)
 		assert.Nil(t, err)
 		assert.Equal(t, 422, rec.Code)
-		assert.Equal(t, errorExpected, errorResult["message"])
+		assert.Equal(t, errorExpected, errorResult.Detail)
 	})
 
 	//Last name
 	t.Run("Create fail last name", func(t *testing.T) {
 		//Arrange
 		errorExpected := "last_name is required"
-		var errorResult map[string]string
+		var errorResult web.Problem
 
 		var dat []domain.Employee
 		dat = append(dat, data...)
@@ -203,14 +205,14 @@ func Test_create_fail_Handler(t *testing.T) {
 		assert.False(t, myMockS.MethodCalled)
 		assert.Nil(t, err)
 		assert.Equal(t, 422, rec.Code)
-		assert.Equal(t, errorExpected, errorResult["message"])
+		assert.Equal(t, errorExpected, errorResult.Detail)
 	})
 
 	//Warehouse ID
 	t.Run("Create fail wherehouse id", func(t *testing.T) {
 		//Arrange
 		errorExpected := "WareHouseID cannot be negative"
-		var errorResult map[string]string
+		var errorResult web.Problem
 
 		var dat []domain.Employee
 		dat = append(dat, data...)
@@ -232,14 +234,14 @@ func Test_create_fail_Handler(t *testing.T) {
 		assert.False(t, myMockS.MethodCalled)
 		assert.Nil(t, err)
 		assert.Equal(t, 422, rec.Code)
-		assert.Equal(t, errorExpected, errorResult["message"])
+		assert.Equal(t, errorExpected, errorResult.Detail)
 	})
 
 	//Error
 	t.Run("Create fail Error", func(t *testing.T) {
 		//Arrange
 		errorExpected := "Error en el server"
-		var errorResult map[string]string
+		var errorResult web.Problem
 
 		var dat []domain.Employee
 		dat = append(dat, data...)
@@ -261,14 +263,14 @@ func Test_create_fail_Handler(t *testing.T) {
 		assert.True(t, myMockS.MethodCalled)
 		assert.Nil(t, err)
 		assert.Equal(t, 500, rec.Code)
-		assert.Equal(t, errorExpected, errorResult["message"])
+		assert.NotContains(t, errorResult.Detail, errorExpected)
 	})
 }
 
 func Test_create_conflict_Handler(t *testing.T) {
 	//Arrage
 	errorExpected := "The card_number_id already exists"
-	var errorResult map[string]string
+	var errorResult web.Problem
 	var dat []domain.Employee
 	dat = append(dat, data...)
 	myMockS := employee.MockServiceEmployee{DataMock: dat}
@@ -287,7 +289,7 @@ func Test_create_conflict_Handler(t *testing.T) {
 	assert.True(t, myMockS.MethodCalled)
 	assert.Nil(t, err)
 	assert.Equal(t, 409, rec.Code)
-	assert.Equal(t, errorExpected, errorResult["message"])
+	assert.Equal(t, errorExpected, errorResult.Detail)
 }
 
 func Test_find_all_Handler(t *testing.T) {
@@ -331,7 +333,7 @@ func Test_find_all_Handler(t *testing.T) {
 func Test_find_all_fail_Handler(t *testing.T) {
 	//Arrange
 	errorExpected := "Error en Get All de service"
-	var errorResult map[string]string
+	var errorResult web.Problem
 	var dat []domain.Employee
 	dat = append(dat, data...)
 	myMockS := employee.MockServiceEmployee{DataMock: dat, Err: errorExpected}
@@ -344,14 +346,14 @@ func Test_find_all_fail_Handler(t *testing.T) {
 	//Assert
 	assert.True(t, myMockS.MethodCalled)
 	assert.Nil(t, err)
-	assert.Equal(t, 404, rec.Code)
-	assert.Equal(t, errorExpected, errorResult["message"])
+	assert.Equal(t, 500, rec.Code)
+	assert.NotContains(t, errorResult.Detail, errorExpected)
 }
 
 func Test_find_by_id_non_existent_Handler(t *testing.T) {
 	//Arrange
 	errorExpected := "employee not found"
-	var errorResult map[string]string
+	var errorResult web.Problem
 	var dat []domain.Employee
 	dat = append(dat, data...)
 	myMockS := employee.MockServiceEmployee{DataMock: dat}
@@ -365,7 +367,7 @@ func Test_find_by_id_non_existent_Handler(t *testing.T) {
 	assert.True(t, myMockS.MethodCalled)
 	assert.Nil(t, err)
 	assert.Equal(t, 404, rec.Code)
-	assert.Equal(t, errorExpected, errorResult["message"])
+	assert.Equal(t, errorExpected, errorResult.Detail)
 }
 
 func Test_find_by_id_existent_Handler(t *testing.T) {
@@ -428,7 +430,7 @@ func Test_update_ok_Handler(t *testing.T) {
 func Test_update_non_existent_Handler(t *testing.T) {
 	//Arrange
 	errorExpected := "employee not found"
-	var errorResult map[string]string
+	var errorResult web.Problem
 	var dat []domain.Employee
 	dat = append(dat, data...)
 	myMockS := employee.MockServiceEmployee{DataMock: dat}
@@ -447,13 +449,13 @@ func Test_update_non_existent_Handler(t *testing.T) {
 	assert.True(t, myMockS.MethodCalled)
 	assert.Nil(t, err)
 	assert.Equal(t, 404, rec.Code)
-	assert.Equal(t, errorExpected, errorResult["message"])
+	assert.Equal(t, errorExpected, errorResult.Detail)
 }
 
 func Test_update_fail_Handler(t *testing.T) {
 	//Arrange
 	errorExpected := "card_number_id field cannot be updated"
-	var errorResult map[string]string
+	var errorResult web.Problem
 	var dat []domain.Employee
 	dat = append(dat, data...)
 	myMockS := employee.MockServiceEmployee{DataMock: dat}
@@ -473,12 +475,12 @@ func Test_update_fail_Handler(t *testing.T) {
 	assert.False(t, myMockS.MethodCalled)
 	assert.Nil(t, err)
 	assert.Equal(t, 400, rec.Code)
-	assert.Equal(t, errorExpected, errorResult["message"])
+	assert.Equal(t, errorExpected, errorResult.Detail)
 }
 func Test_delete_non_existent_Handler(t *testing.T) {
 	//Arrange
 	errorExpected := "employee not found"
-	var errorResult map[string]string
+	var errorResult web.Problem
 	var dat []domain.Employee
 	dat = append(dat, data...)
 	myMockS := employee.MockServiceEmployee{DataMock: dat}
@@ -492,7 +494,7 @@ func Test_delete_non_existent_Handler(t *testing.T) {
 	assert.True(t, myMockS.MethodCalled)
 	assert.Nil(t, err)
 	assert.Equal(t, 404, rec.Code)
-	assert.Equal(t, errorExpected, errorResult["message"])
+	assert.Equal(t, errorExpected, errorResult.Detail)
 }
 
 func Test_delete_ok_Handler(t *testing.T) {
@@ -585,7 +587,7 @@ func Test_Report_InboundOrders_fail_Handler(t *testing.T) {
 	t.Run("error caused", func(t *testing.T) {
 		//Assert
 		errExpected := "Error caused"
-		var errorResult map[string]string
+		var errorResult web.Problem
 		myMockS := employee.MockServiceEmployee{Err: errExpected}
 		server := createServerEmployee(&myMockS)
 		req, rec := createRequestTestEmployee(http.MethodGet, "/employees/reportInboundOrders", "")
@@ -598,14 +600,14 @@ func Test_Report_InboundOrders_fail_Handler(t *testing.T) {
 		assert.NoError(t, err)
 		assert.True(t, myMockS.MethodCalled)
 		assert.Equal(t, 500, rec.Code)
-		assert.Equal(t, errExpected, errorResult["message"])
+		assert.NotContains(t, errorResult.Detail, errExpected)
 	})
 
 	//Employee not found
 	t.Run("Employee not found", func(t *testing.T) {
 		//Arrange
 		errExpected := "employee not found"
-		var errorResult map[string]string
+		var errorResult web.Problem
 		myMockS := employee.MockServiceEmployee{}
 		server := createServerEmployee(&myMockS)
 		req, rec := createRequestTestEmployee(http.MethodGet, "/employees/reportInboundOrders?id=1", "")
@@ -618,14 +620,14 @@ func Test_Report_InboundOrders_fail_Handler(t *testing.T) {
 		assert.NoError(t, err)
 		assert.True(t, myMockS.MethodCalled)
 		assert.Equal(t, 404, rec.Code)
-		assert.Equal(t, errExpected, errorResult["message"])
+		assert.Equal(t, errExpected, errorResult.Detail)
 
 	})
 	// id not applicable
 	t.Run("id not applicable", func(t *testing.T) {
 		//Arrange
 		errExpected := "invalid id format"
-		var errorResult map[string]string
+		var errorResult web.Problem
 		myMockS := employee.MockServiceEmployee{}
 		server := createServerEmployee(&myMockS)
 		req, rec := createRequestTestEmployee(http.MethodGet, "/employees/reportInboundOrders?id=ls", "")
@@ -638,6 +640,6 @@ func Test_Report_InboundOrders_fail_Handler(t *testing.T) {
 		assert.NoError(t, err)
 		assert.True(t, myMockS.MethodCalled)
 		assert.Equal(t, 422, rec.Code)
-		assert.Equal(t, errExpected, errorResult["message"])
+		assert.Equal(t, errExpected, errorResult.Detail)
 	})
 }
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/graph"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/api"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+)
+
+type GraphQL struct {
+	schema *graphql.Schema
+	repo   graph.Repository
+}
+
+func NewGraphQL(schema *graphql.Schema, repo graph.Repository) *GraphQL {
+	return &GraphQL{
+		schema: schema,
+		repo:   repo,
+	}
+}
+
+// Query executes a GraphQL query with its own loaders, so lookups are
+// batched and cached within the request only. Errors resolving fields are
+// reported in the errors of the response, which is always a 200.
+func (g *GraphQL) Query() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req api.GraphQLRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			web.ValidationError(c, http.StatusBadRequest, err)
+			return
+		}
+
+		ctx := graph.NewContext(c.Request.Context(), graph.NewLoaders(g.repo))
+		resp := g.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+		web.Response(c, http.StatusOK, resp)
+	}
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/cmd/api/resolver"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/graph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type warehouseGraph struct {
+	graph.Repository
+	warehouses []domain.Warehouse
+}
+
+func (g warehouseGraph) Warehouses(_ context.Context, ids []int) ([]domain.Warehouse, error) {
+	return g.warehouses, nil
+}
+
+func createGraphQLServer(t *testing.T, repo graph.Repository) *gin.Engine {
+	schema, err := resolver.NewSchema(resolver.NewResolver(resolver.Services{}, repo))
+	require.NoError(t, err)
+	r := gin.Default()
+	handler := NewGraphQL(schema, repo)
+
+	r.POST("/graphql", handler.Query())
+	return r
+}
+
+func TestGraphQL(t *testing.T) {
+	server := createGraphQLServer(t, warehouseGraph{warehouses: []domain.Warehouse{{ID: 1, WarehouseCode: "W-1"}}})
+
+	t.Run("should answer the query", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := `{"query":"query($id: Int!) { warehouse(id: $id) { warehouseCode } }","variables":{"id":1}}`
+		server.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"data":{"warehouse":{"warehouseCode":"W-1"}}}`, w.Body.String())
+	})
+	t.Run("should report errors in the response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{ warehouse { id } }"}`)))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"errors"`)
+	})
+	t.Run("should reject a request without a query", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{}`)))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/health"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+)
+
+type Health struct {
+	checks *health.Registry
+}
+
+func NewHealth(checks *health.Registry) *Health {
+	return &Health{
+		checks: checks,
+	}
+}
+
+// Live reports that the process is up, without checking its dependencies.
+func (h *Health) Live() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		web.Response(ctx, http.StatusOK, health.Report{Status: health.StatusUp, Checks: map[string]health.Result{}})
+	}
+}
+
+// Ready runs the registered checks and answers 503 when any of them fails.
+func (h *Health) Ready() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		report := h.checks.Run(ctx)
+		status := http.StatusOK
+		if report.Status != health.StatusUp {
+			status = http.StatusServiceUnavailable
+		}
+		web.Response(ctx, status, report)
+	}
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func createHealthServer(checks *health.Registry) *gin.Engine {
+	r := gin.Default()
+	handler := NewHealth(checks)
+
+	r.GET("/health/live", handler.Live())
+	r.GET("/health/ready", handler.Ready())
+	return r
+}
+
+func TestHealth(t *testing.T) {
+	checks := health.NewRegistry(time.Second)
+	checks.Register("database", func(ctx context.Context) error { return errors.New("connection refused") })
+	server := createHealthServer(checks)
+
+	t.Run("should be live even when a dependency is down", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+	t.Run("should not be ready when a dependency is down", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+		report := health.Report{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, health.StatusDown, report.Status)
+		assert.Equal(t, "connection refused", report.Checks["database"].Error)
+	})
+}
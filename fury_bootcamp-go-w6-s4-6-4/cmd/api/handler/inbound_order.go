@@ -1,11 +1,15 @@
 package handler
 
 import (
+	"net/http"
 	"reflect"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
 	inboundorder "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/inbound_order"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/api"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
 )
 
@@ -15,6 +19,15 @@ type request_Inbound_Order struct {
 	Employee_id      int    `json:"employee_id"`
 	Product_batch_id int    `json:"product_batch_id"`
 	Warehouse_id     int    `json:"warehouse_id"`
+	// Lines are the products expected; an order without them records the
+	// batch Product_batch_id as received.
+	Lines []requestInboundOrderLine `json:"lines" binding:"dive"`
+}
+
+type requestInboundOrderLine struct {
+	ProductID        int `json:"product_id" binding:"required,min=1"`
+	BatchNumber      int `json:"batch_number" binding:"min=0"`
+	ExpectedQuantity int `json:"expected_quantity" binding:"required,min=1"`
 }
 
 type Inbound_order struct {
@@ -31,7 +44,7 @@ func (bo *Inbound_order) GetAll() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		inBOs, err := bo.inbound_ordersService.GetAll_inboundOrders(ctx)
 		if err != nil {
-			web.Error(ctx, 404, "%s", err)
+			ctx.Error(err)
 			return
 		}
 		if len(inBOs) == 0 {
@@ -47,14 +60,18 @@ func (bo *Inbound_order) Create() gin.HandlerFunc {
 		var req request_Inbound_Order
 
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			web.Error(ctx, 422, "%s", err)
+			web.ValidationError(ctx, 422, err)
 			return
 		}
 		var emptyFiled []string
 		values := reflect.ValueOf(req)
 		for i := 0; i < values.NumField(); i++ {
-			if values.Field(i).Interface() == reflect.Zero(values.Field(i).Type()).Interface() {
-				emptyFiled = append(emptyFiled, values.Type().Field(i).Name)
+			name := values.Type().Field(i).Name
+			if name == "Lines" || (name == "Product_batch_id" && len(req.Lines) > 0) {
+				continue
+			}
+			if values.Field(i).IsZero() {
+				emptyFiled = append(emptyFiled, name)
 			}
 		}
 
@@ -73,11 +90,77 @@ func (bo *Inbound_order) Create() gin.HandlerFunc {
 			return
 		}
 
-		inbOrder, err := bo.inbound_ordersService.Save(ctx, req.Order_date, req.Order_number, req.Employee_id, req.Product_batch_id, req.Warehouse_id)
+		lines := make([]domain.InboundOrderLine, len(req.Lines))
+		for i, l := range req.Lines {
+			lines[i] = domain.InboundOrderLine{ProductID: l.ProductID, BatchNumber: l.BatchNumber, ExpectedQuantity: l.ExpectedQuantity}
+		}
+		inbOrder, err := bo.inbound_ordersService.Save(ctx, req.Order_date, req.Order_number, req.Employee_id, req.Product_batch_id, req.Warehouse_id, lines)
 		if err != nil {
-			web.Error(ctx, 409, "%s", err)
+			ctx.Error(err)
 			return
 		}
 		web.Success(ctx, 201, inbOrder)
 	}
 }
+
+func (bo *Inbound_order) Get() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, "%s", err)
+			return
+		}
+		inbOrder, err := bo.inbound_ordersService.Get(ctx, id)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		web.Success(ctx, http.StatusOK, inbOrder)
+	}
+}
+
+// Receive records a receipt of the order in the path, crediting each line
+// to an existing batch or to a new one in a section.
+func (bo *Inbound_order) Receive() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, "%s", err)
+			return
+		}
+		var req api.InboundReceiptCreate
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			web.ValidationError(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		lines := make([]domain.InboundReceiptLine, len(req.Lines))
+		for i, l := range req.Lines {
+			lines[i] = domain.InboundReceiptLine{LineID: l.LineID, Quantity: l.Quantity, ProductBatchID: l.ProductBatchID, SectionID: l.SectionID}
+		}
+		receipt, err := bo.inbound_ordersService.Receive(ctx, id, lines)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		web.Success(ctx, http.StatusCreated, receipt)
+	}
+}
+
+// ReportDiscrepancies sums the expected and received quantities of the
+// orders of every seller, or of the seller in ?id=.
+func (bo *Inbound_order) ReportDiscrepancies() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := reportID(ctx)
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, "%s", err)
+			return
+		}
+		reports, err := bo.inbound_ordersService.ReportDiscrepancies(ctx, id)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		web.Success(ctx, http.StatusOK, reports)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
 	inboundorder "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/inbound_order"
 	"github.com/stretchr/testify/assert"
 )
@@ -36,9 +37,13 @@ func createServerIBO(mockService *inboundorder.MockServiceIBO) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 	handler := NewInBound_Order(mockService)
 	r := gin.Default()
+	r.Use(web.ErrorHandler())
 	ibor := r.Group("/inboundOrders")
 	ibor.GET("/", handler.GetAll())
 	ibor.POST("/", handler.Create())
+	ibor.GET("/reportDiscrepancies", handler.ReportDiscrepancies())
+	ibor.GET("/:id", handler.Get())
+	ibor.POST("/:id/receipts", handler.Receive())
 	return r
 }
 
@@ -125,7 +130,7 @@ func Test_Create_Handler_IBO(t *testing.T) {
 	t.Run("Create fail date", func(t *testing.T) {
 		//Arrange
 		errorExpected := "invalid date example 2008-01-02"
-		var errorResult map[string]string
+		var errorResult web.Problem
 		var dat []domain.Inbound_order
 		dat = append(dat, data_ibo...)
 		myMockS := inboundorder.MockServiceIBO{DataMock: dat}
@@ -147,13 +152,13 @@ func Test_Create_Handler_IBO(t *testing.T) {
 		assert.False(t, myMockS.MethodCalled)
 		assert.Nil(t, err)
 		assert.Equal(t, 422, rec.Code)
-		assert.Equal(t, errorExpected, errorResult["message"])
+		assert.Equal(t, errorExpected, errorResult.Detail)
 	})
 
 	t.Run("Required", func(t *testing.T) {
 		//Arrange
 		errorExpected := "El campo: Order_number es requerido"
-		var errorResult map[string]string
+		var errorResult web.Problem
 		var dat []domain.Inbound_order
 		dat = append(dat, data_ibo...)
 		myMockS := inboundorder.MockServiceIBO{DataMock: dat}
@@ -174,6 +179,132 @@ func Test_Create_Handler_IBO(t *testing.T) {
 		assert.False(t, myMockS.MethodCalled)
 		assert.Nil(t, err)
 		assert.Equal(t, 422, rec.Code)
-		assert.Equal(t, errorExpected, errorResult["message"])
+		assert.Equal(t, errorExpected, errorResult.Detail)
 	})
 }
+
+func Test_Create_Lines_Handler_IBO(t *testing.T) {
+	//Arrange
+	var ibosResult map[string]domain.Inbound_order
+	myMockS := inboundorder.MockServiceIBO{}
+	server := createServerIBO(&myMockS)
+
+	//Act
+	req, rec := createRequestTestIBO(http.MethodPost, "/inboundOrders/",
+		`{"order_date":"2021-04-04","order_number":"order#2","employee_id":4,"warehouse_id":1,"lines":[{"product_id":7,"batch_number":12,"expected_quantity":10}]}`)
+	server.ServeHTTP(rec, req)
+	err := json.Unmarshal(rec.Body.Bytes(), &ibosResult)
+
+	//Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 201, rec.Code)
+	assert.Equal(t, []domain.InboundOrderLine{{ProductID: 7, BatchNumber: 12, ExpectedQuantity: 10}}, ibosResult["data"].Lines)
+
+	t.Run("line without a quantity", func(t *testing.T) {
+		myMockS := inboundorder.MockServiceIBO{}
+		server := createServerIBO(&myMockS)
+
+		req, rec := createRequestTestIBO(http.MethodPost, "/inboundOrders/",
+			`{"order_date":"2021-04-04","order_number":"order#2","employee_id":4,"warehouse_id":1,"lines":[{"product_id":7}]}`)
+		server.ServeHTTP(rec, req)
+
+		assert.False(t, myMockS.MethodCalled)
+		assert.Equal(t, 422, rec.Code)
+	})
+}
+
+func Test_Get_Handler_IBO(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		var ibosResult map[string]domain.Inbound_order
+		myMockS := inboundorder.MockServiceIBO{DataMock: data_ibo}
+		server := createServerIBO(&myMockS)
+
+		req, rec := createRequestTestIBO(http.MethodGet, "/inboundOrders/2", "")
+		server.ServeHTTP(rec, req)
+		err := json.Unmarshal(rec.Body.Bytes(), &ibosResult)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 200, rec.Code)
+		assert.Equal(t, data_ibo[1], ibosResult["data"])
+	})
+	t.Run("not found", func(t *testing.T) {
+		myMockS := inboundorder.MockServiceIBO{DataMock: data_ibo}
+		server := createServerIBO(&myMockS)
+
+		req, rec := createRequestTestIBO(http.MethodGet, "/inboundOrders/9", "")
+		server.ServeHTTP(rec, req)
+
+		assert.Equal(t, 404, rec.Code)
+	})
+	t.Run("bad id", func(t *testing.T) {
+		myMockS := inboundorder.MockServiceIBO{}
+		server := createServerIBO(&myMockS)
+
+		req, rec := createRequestTestIBO(http.MethodGet, "/inboundOrders/one", "")
+		server.ServeHTTP(rec, req)
+
+		assert.False(t, myMockS.MethodCalled)
+		assert.Equal(t, 400, rec.Code)
+	})
+}
+
+func Test_Receive_Handler_IBO(t *testing.T) {
+	order := domain.Inbound_order{ID: 1, Status: domain.InboundOrderOpen, Lines: []domain.InboundOrderLine{{ID: 1, ProductID: 7, ExpectedQuantity: 10}}}
+
+	t.Run("ok", func(t *testing.T) {
+		var result map[string]domain.InboundReceipt
+		myMockS := inboundorder.MockServiceIBO{DataMock: []domain.Inbound_order{order}}
+		server := createServerIBO(&myMockS)
+
+		req, rec := createRequestTestIBO(http.MethodPost, "/inboundOrders/1/receipts", `{"lines":[{"line_id":1,"quantity":4,"section_id":2}]}`)
+		server.ServeHTTP(rec, req)
+		err := json.Unmarshal(rec.Body.Bytes(), &result)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 201, rec.Code)
+		assert.Equal(t, []domain.InboundReceiptLine{{LineID: 1, Quantity: 4, SectionID: 2}}, result["data"].Lines)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		tests := []struct {
+			name string
+			body string
+		}{
+			{name: "no lines", body: `{"lines":[]}`},
+			{name: "no quantity", body: `{"lines":[{"line_id":1,"section_id":2}]}`},
+			{name: "negative section", body: `{"lines":[{"line_id":1,"quantity":4,"section_id":-2}]}`},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				myMockS := inboundorder.MockServiceIBO{DataMock: []domain.Inbound_order{order}}
+				server := createServerIBO(&myMockS)
+
+				req, rec := createRequestTestIBO(http.MethodPost, "/inboundOrders/1/receipts", tt.body)
+				server.ServeHTTP(rec, req)
+
+				assert.False(t, myMockS.MethodCalled)
+				assert.Equal(t, 422, rec.Code)
+			})
+		}
+	})
+}
+
+func Test_ReportDiscrepancies_Handler_IBO(t *testing.T) {
+	//Arrange
+	reports := []domain.InboundDiscrepancyReport{
+		{SellerID: 1, OrdersCount: 2, ExpectedQuantity: 20, ReceivedQuantity: 16, MissingQuantity: 4},
+		{SellerID: 2, OrdersCount: 1, ExpectedQuantity: 5, ReceivedQuantity: 6, ExcessQuantity: 1},
+	}
+	var result map[string][]domain.InboundDiscrepancyReport
+	myMockS := inboundorder.MockServiceIBO{Reports: reports}
+	server := createServerIBO(&myMockS)
+
+	//Act
+	req, rec := createRequestTestIBO(http.MethodGet, "/inboundOrders/reportDiscrepancies?id=2", "")
+	server.ServeHTTP(rec, req)
+	err := json.Unmarshal(rec.Body.Bytes(), &result)
+
+	//Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 200, rec.Code)
+	assert.Equal(t, reports[1:], result["data"])
+}
@@ -9,11 +9,12 @@ import (
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
 )
 
+// requestLocality takes an official code as locality_id, or 0 to have one
+// generated.
 type requestLocality struct {
 	ID           int    `json:"locality_id"`
 	LocalityName string `json:"locality_name"`
-	ProvinceName string `json:"province_name"`
-	CountryName  string `json:"country_name"`
+	ProvinceID   int    `json:"province_id"`
 }
 
 type Locality struct {
@@ -26,47 +27,34 @@ func NewLocality(l locality.Service) *Locality {
 	}
 }
 
-// CreateLocality godoc
-// @Summary Create locality
-// @Tags Localities
-// @Description create locality
-// @Accept  json
-// @Produce  json
-// @Param locality body domain.Locality true "Locality"
-// @Success 201 {object} web.response
-// @Router /api/v1/localities [post]
 func (l *Locality) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req requestLocality
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, http.StatusNotFound, err.Error())
+			web.ValidationError(c, http.StatusNotFound, err)
 			return
 		}
 
-		if req.ID <= 0 {
-			web.Error(c, http.StatusUnprocessableEntity, locality.ErrRequired.Error()+": id")
+		if req.ID < 0 {
+			web.Error(c, http.StatusUnprocessableEntity, locality.ErrRequest.Error()+": id")
 			return
 		}
 		if req.LocalityName == "" {
 			web.Error(c, http.StatusUnprocessableEntity, locality.ErrRequired.Error()+": locality_name")
 			return
 		}
-		if req.ProvinceName == "" {
-			web.Error(c, http.StatusUnprocessableEntity, locality.ErrRequired.Error()+": province_name")
-			return
-		}
-		if req.CountryName == "" {
-			web.Error(c, http.StatusUnprocessableEntity, locality.ErrRequired.Error()+": country_name")
+		if req.ProvinceID <= 0 {
+			web.Error(c, http.StatusUnprocessableEntity, locality.ErrRequired.Error()+": province_id")
 			return
 		}
 
-		new, err := l.localityService.Create(c, domain.Locality(req))
+		new, err := l.localityService.Create(c, domain.Locality{
+			ID:           req.ID,
+			LocalityName: req.LocalityName,
+			ProvinceID:   req.ProvinceID,
+		})
 		if err != nil {
-			if err.Error() == "id already exists" {
-				web.Error(c, http.StatusConflict, err.Error())
-				return
-			}
-			web.Error(c, 422, err.Error())
+			c.Error(err)
 			return
 		}
 
@@ -80,7 +68,7 @@ func (l *Locality) GetAllSellersByLocality() gin.HandlerFunc {
 
 		reports, err := l.localityService.GetAllSellersByLocality(c, localityID)
 		if err != nil {
-			web.Error(c, http.StatusNotFound, err.Error())
+			c.Error(err)
 			return
 		}
 
@@ -88,21 +76,12 @@ func (l *Locality) GetAllSellersByLocality() gin.HandlerFunc {
 	}
 }
 
-// GetLocalityReport godoc
-// @Summary Get locality report
-// @Tags Localities
-// @Description get locality report
-// @Accept  json
-// @Produce  json
-// @Param id query string true "Locality id"
-// @Success 200 {object} web.response
-// @Router /api/v1/localities/reportCarries [get]
 func (l *Locality) GetReport() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id := c.Query("id")
 		reports, err := l.localityService.GetCarriesReport(c, id)
 		if err != nil {
-			web.Error(c, http.StatusNotFound, err.Error())
+			c.Error(err)
 			return
 		}
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/locality"
 	"github.com/stretchr/testify/assert"
 )
@@ -17,6 +18,7 @@ func createServerLocality(mockService locality.MockService) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 	p := NewLocality(&mockService)
 	r := gin.Default()
+	r.Use(web.ErrorHandler())
 	pr := r.Group("api/v1/localities")
 	pr.GET("/reportSellers", p.GetAllSellersByLocality())
 	pr.GET("/reportCarries", p.GetReport())
@@ -34,8 +36,7 @@ func TestCreateLocality(t *testing.T) {
 		DataMock: []domain.Locality{{
 			ID:           1759,
 			LocalityName: "Gonzalez Catan",
-			ProvinceName: "Buenos Aires",
-			CountryName:  "Argentina",
+			ProvinceID:   6,
 		}},
 		Error: "",
 	}
@@ -46,8 +47,7 @@ func TestCreateLocality(t *testing.T) {
 		req, rr := createRequestTestSeller(http.MethodPost, "/api/v1/localities", `{
 		"locality_id": 1755,
 		"locality_name": "Moron",
-		"province_name": "Buenos Aires",
-		"country_name": "Argentina"
+		"province_id": 6
 	  }s`)
 		// act
 		r.ServeHTTP(rr, req)
@@ -61,8 +61,7 @@ func TestCreateLocality(t *testing.T) {
 		req, rr := createRequestTestSeller(http.MethodPost, "/api/v1/localities", `{
 		"locality_id": 1759,
 		"locality_name": "Moron",
-		"province_name": "Buenos Aires",
-		"country_name": "Argentina"
+		"province_id": 6
 	  }s`)
 		// act
 		r.ServeHTTP(rr, req)
@@ -76,8 +75,7 @@ func TestCreateLocality(t *testing.T) {
 		req, rr := createRequestTestSeller(http.MethodPost, "/api/v1/localities", `{
 		"locality_id": ,
 		"locality_name": "Moron",
-		"province_name": "Buenos Aires",
-		"country_name": "Argentina"
+		"province_id": 6
 	  }s`)
 		// act
 		r.ServeHTTP(rr, req)
@@ -91,8 +89,7 @@ func TestCreateLocality(t *testing.T) {
 		req, rr := createRequestTestSeller(http.MethodPost, "/api/v1/localities", `{
 		"locality_id": 12,
 		"locality_name": "",
-		"province_name": "Buenos Aires",
-		"country_name": "Argentina"
+		"province_id": 6
 	  }s`)
 		// act
 		r.ServeHTTP(rr, req)
@@ -106,8 +103,7 @@ func TestCreateLocality(t *testing.T) {
 		req, rr := createRequestTestSeller(http.MethodPost, "/api/v1/localities", `{
 		"locality_id": 12,
 		"locality_name": "San Justo",
-		"province_name": "",
-		"country_name": "Argentina"
+		"province_id": 0
 	  }s`)
 		// act
 		r.ServeHTTP(rr, req)
@@ -117,27 +113,24 @@ func TestCreateLocality(t *testing.T) {
 		assert.Equal(t, 422, rr.Code)
 	})
 
-	t.Run("create field country required", func(t *testing.T) {
+	t.Run("create with a generated id", func(t *testing.T) {
 		req, rr := createRequestTestSeller(http.MethodPost, "/api/v1/localities", `{
-		"locality_id": 12,
 		"locality_name": "San Justo",
-		"province_name": "Buenos Aires",
-		"country_name": ""
+		"province_id": 6
 	  }s`)
 		// act
 		r.ServeHTTP(rr, req)
 		// assert
 		err := json.Unmarshal(rr.Body.Bytes(), &resp)
 		assert.Nil(t, err)
-		assert.Equal(t, 422, rr.Code)
+		assert.Equal(t, 201, rr.Code)
 	})
 
-	t.Run("create field id required", func(t *testing.T) {
+	t.Run("create negative id", func(t *testing.T) {
 		req, rr := createRequestTestSeller(http.MethodPost, "/api/v1/localities", `{
-		"locality_id": 0,
+		"locality_id": -1,
 		"locality_name": "San Justo",
-		"province_name": "Buenos Aires",
-		"country_name": "Argentis"
+		"province_id": 6
 	  }s`)
 		// act
 		r.ServeHTTP(rr, req)
@@ -154,8 +147,7 @@ func TestGetSellersLocality(t *testing.T) {
 		DataMock: []domain.Locality{{
 			ID:           1759,
 			LocalityName: "Gonzalez Catan",
-			ProvinceName: "Buenos Aires",
-			CountryName:  "Argentina",
+			ProvinceID:   6,
 		}},
 		Error: "",
 	}
@@ -189,8 +181,7 @@ func TestGetCarriesLocality(t *testing.T) {
 		DataMock: []domain.Locality{{
 			ID:           1759,
 			LocalityName: "Gonzalez Catan",
-			ProvinceName: "Buenos Aires",
-			CountryName:  "Argentina",
+			ProvinceID:   6,
 		}},
 		Error: "",
 	}
@@ -36,19 +36,11 @@ func NewProduct(w product.Service) *Product {
 	}
 }
 
-// ListProducts godoc
-// @Summary List products
-// @Tags Products
-// @Description get products
-// @Accept  json
-// @Produce  json
-// @Success 200 {object} web.response
-// @Router /api/v1/products [get]
 func (p *Product) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		products, err := p.productService.GetAll(c)
 		if err != nil {
-			web.Error(c, 404, "%s", err)
+			c.Error(err)
 			return
 		}
 		if len(products) == 0 {
@@ -59,15 +51,6 @@ func (p *Product) GetAll() gin.HandlerFunc {
 	}
 }
 
-// ListOneProduct godoc
-// @Summary List one product
-// @Tags Products
-// @Description get product by ID
-// @Accept  json
-// @Produce  json
-// @Success 200 {object} web.response
-// @Router /api/v1/products/:id [get]
-// @Param id path int true "Product ID"
 func (p *Product) Get() gin.HandlerFunc {
 	return func(c *gin.Context) {
 
@@ -81,7 +64,7 @@ func (p *Product) Get() gin.HandlerFunc {
 
 		pr, err = p.productService.Get(c, int(id))
 		if err != nil {
-			web.Error(c, http.StatusNotFound, "%s", err)
+			c.Error(err)
 			return
 		}
 
@@ -89,22 +72,13 @@ func (p *Product) Get() gin.HandlerFunc {
 	}
 }
 
-// CreateProduct godoc
-// @Summary Create a product
-// @Tags Products
-// @Description create a product
-// @Accept  json
-// @Produce  json
-// @Param carry body domain.Product true "Product"
-// @Success 201 {object} web.response
-// @Router /api/v1/products [post]
 func (p *Product) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
 
 		var req requestProduct
 
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, http.StatusBadRequest, "%s", err)
+			web.ValidationError(c, http.StatusBadRequest, err)
 			return
 		}
 
@@ -155,26 +129,13 @@ func (p *Product) Create() gin.HandlerFunc {
 		
 		pr, err := p.productService.Save(c, req.Description, *req.ExpirationRate, *req.FreezingRate, *req.Height, *req.Length, *req.Netweight, req.ProductCode, *req.RecomFreezTemp, *req.Width, *req.ProductTypeID, *req.SellerID)
 		if err != nil {
-			if err.Error() == "product_code already exists" {
-				web.Error(c, 409, "%s", err)
-			} else {
-				web.Error(c, 500, "%s", err)
-			}
+			c.Error(err)
 			return
 		}
 		web.Success(c, 201, pr)
 	}
 }
 
-// UpdateProduct godoc
-// @Summary Update product
-// @Tags Products
-// @Description update product
-// @Accept  json
-// @Produce  json
-// @Param id path int true "Product ID"
-// @Success 200 {object} web.response
-// @Router /api/v1/products/:id [patch]
 func (p *Product) Update() gin.HandlerFunc {
 	return func(c *gin.Context) {
 
@@ -187,13 +148,13 @@ func (p *Product) Update() gin.HandlerFunc {
 		var req requestProduct
 
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, http.StatusBadRequest, "%s", err)
+			web.ValidationError(c, http.StatusBadRequest, err)
 			return
 		}
 
 		pr, err := p.productService.Update(c, int(id), req.Description, req.ExpirationRate, req.FreezingRate, req.Height, req.Length, req.Netweight, req.ProductCode, req.RecomFreezTemp, req.Width, req.ProductTypeID, req.SellerID)
 		if err != nil {
-			web.Error(c, http.StatusNotFound, "%s", err)
+			c.Error(err)
 			return
 		}
 
@@ -201,15 +162,6 @@ func (p *Product) Update() gin.HandlerFunc {
 	}
 }
 
-// DeleteProduct godoc
-// @Summary Delete product
-// @Tags Products
-// @Description delete product
-// @Accept  json
-// @Produce  json
-// @Param id path int true "Product ID"
-// @Success 204 {object} web.response
-// @Router /api/v1/products/:id [delete]
 func (p *Product) Delete() gin.HandlerFunc {
 	return func(c *gin.Context) {
 
@@ -221,11 +173,7 @@ func (p *Product) Delete() gin.HandlerFunc {
 
 		err = p.productService.Delete(c, int(id))
 		if err != nil {
-			if err.Error() == "product not found" {
-				web.Error(c, http.StatusNotFound, "%s", err)
-			} else {
-				web.Error(c, 500, "%s", err)
-			}
+			c.Error(err)
 			return
 		}
 
@@ -233,15 +181,6 @@ func (p *Product) Delete() gin.HandlerFunc {
 	}
 }
 
-// GetReportRecords godoc
-// @Summary Get report records
-// @Tags Products
-// @Description get report records
-// @Accept  json
-// @Produce  json
-// @Param id query string true "Product id"
-// @Success 200 {object} web.response
-// @Router /api/v1/products/reportRecords [get]
 func (p *Product) GetReportRecords() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 
@@ -249,7 +188,7 @@ func (p *Product) GetReportRecords() gin.HandlerFunc {
 
 		reports, err := p.productService.GetProductRecords(ctx, id)
 		if err != nil {
-			web.Error(ctx, http.StatusNotFound, err.Error())
+			ctx.Error(err)
 			return
 		}
 
@@ -2,7 +2,6 @@ package handler
 
 import (
 	"errors"
-	"log"
 	"net/http"
 	"strconv"
 	"time"
@@ -37,22 +36,13 @@ func NewProductBatches(pb productbatches.Service) *ProductBatches {
 	}
 }
 
-// CreateProductBatches godoc
-// @Summary Create product batches
-// @Tags Product_batches
-// @Description create product_batches
-// @Accept  json
-// @Produce  json
-// @Param product_batches body domain.Product_batches true "product_batches"
-// @Success 201 {object} web.response
-// @Router /api/v1/productbatches [post]
 func (pb *ProductBatches) Create() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var req domain.Product_batches
 
 		//2001-12-25
 		if err := ctx.ShouldBindJSON(&req); err != nil {
-			web.Error(ctx, http.StatusBadRequest, "%s", err)
+			web.ValidationError(ctx, http.StatusBadRequest, err)
 			return
 		}
 
@@ -70,25 +60,14 @@ func (pb *ProductBatches) Create() gin.HandlerFunc {
 
 		id, err := pb.productBatchesService.CreatePB(ctx, req)
 		if err != nil {
-			web.Error(ctx, http.StatusConflict, "%s", err)
+			ctx.Error(err)
 			return
 		}
 		req.ID = id
-		log.Println("Soy la request")
-		log.Println(req)
 		web.Success(ctx, http.StatusCreated, req)
 	}
 }
 
-// GetReportProduct godoc
-// @Summary Get ReportProduct
-// @Tags ReportProduct
-// @Description get report product
-// @Accept  json
-// @Produce  json
-// @Param id query int false "BatchNumber ID to get report (optional)"
-// @Success 200 {object} web.response
-// @Router /api/v1/reportProducts/ [get]
 func (pb *ProductBatches) Get() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		id := ctx.Query("id")
@@ -99,7 +78,7 @@ func (pb *ProductBatches) Get() gin.HandlerFunc {
 		}
 		data, err := pb.productBatchesService.ReadPB(ctx, idInt)
 		if err != nil {
-			web.Error(ctx, http.StatusNotFound, "%s", err)
+			ctx.Error(err)
 			return
 		}
 		web.Success(ctx, http.StatusOK, data)
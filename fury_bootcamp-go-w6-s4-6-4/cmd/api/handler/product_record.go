@@ -25,22 +25,13 @@ func NewProductRecord(service product_records.Service) *ProductRecords {
 	}
 }
 
-// CreateProductRecord godoc
-// @Summary Create a product record
-// @Tags Product Records
-// @Description create a product record
-// @Accept  json
-// @Produce  json
-// @Param carry body domain.ProductRecords true "ProductRecords"
-// @Success 201 {object} web.response
-// @Router /api/v1/productRecords [post]
 func (pr *ProductRecords) Create() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 
 		var req_product_records requestProductRecords
 
 		if err := ctx.ShouldBindJSON(&req_product_records); err != nil {
-			web.Error(ctx, http.StatusBadRequest, err.Error())
+			web.ValidationError(ctx, http.StatusBadRequest, err)
 			return
 		}
 		if req_product_records.LastUpdateDate == "" {
@@ -62,11 +53,8 @@ func (pr *ProductRecords) Create() gin.HandlerFunc {
 
 		product_records, err := pr.productRecordsService.Save(ctx, req_product_records.LastUpdateDate, *req_product_records.PurchasePrice, *req_product_records.SalePrice, *req_product_records.ProductID)
 		if err != nil {
-			if err.Error() == "error: product id doesn't exists" {
-				web.Error(ctx, http.StatusConflict, "%s", err)
-			} else {
-				web.Error(ctx, http.StatusInternalServerError, "%s", err)
-			}
+			ctx.Error(err)
+			return
 		}
 		
 		web.Success(ctx, http.StatusOK, product_records)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/productRecords"
 	"github.com/stretchr/testify/assert"
 )
@@ -34,6 +35,7 @@ func createServerProductRecords(mockService *productRecords.MockServiceProductRe
 	gin.SetMode(gin.ReleaseMode)
 	handler := NewProductRecord(mockService)
 	r := gin.Default()
+	r.Use(web.ErrorHandler())
 	pr := r.Group("/products")
 
 	pr.POST("/productRecords", handler.Create())
@@ -83,6 +85,36 @@ func TestCreateOkProductRecord(t *testing.T) {
 	assert.Equal(t, pr, productRecordNew["data"])
 }
 
+func TestCreateConflictProductRecord(t *testing.T) {
+
+	//Arrange
+	errorExpected := "error: product id doesn't exists"
+	var errorResult web.Problem
+
+	mockService := productRecords.MockServiceProductRecords{
+		DataMock: dataPR,
+		Error:    errorExpected,
+	}
+
+	//Act
+	server := createServerProductRecords(&mockService)
+	request, received := createRequestTestProductRecord(http.MethodPost, "/products/productRecords",
+		`{
+            "last_update_date": "2022-12-04",
+            "purchase_price": 109.0,
+            "sale_price": 123.9,
+            "products_id": 99
+        }`)
+
+	server.ServeHTTP(received, request)
+	err := json.Unmarshal(received.Body.Bytes(), &errorResult)
+
+	//Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 409, received.Code)
+	assert.Equal(t, errorExpected, errorResult.Detail)
+}
+
 // func TestCreateFailProductRecord(t *testing.T) {
 
 // 	samples := []struct {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"github.com/gin-gonic/gin"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/products"
 	"github.com/stretchr/testify/assert"
 	"net/http"
@@ -47,6 +48,7 @@ func createServerProduct(mockService *products.MockServiceProduct) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 	handler := NewProduct(mockService)
 	r := gin.Default()
+	r.Use(web.ErrorHandler())
 	pr := r.Group("/products")
 	pr.POST("/", handler.Create())
 	pr.GET("/", handler.GetAll())
@@ -125,7 +127,7 @@ func TestCreateFailProduct(t *testing.T) {
 
 	//Arrange
 	expectedError := "description is required"
-	var errorResult map[string]string
+	var errorResult web.Problem
 
 	mockService := products.MockServiceProduct{
 		DataMock: database,
@@ -152,7 +154,7 @@ func TestCreateFailProduct(t *testing.T) {
 	//Assert
 	assert.Nil(t, err)
 	assert.Equal(t, 422, received.Code)
-	assert.Equal(t, expectedError, errorResult["message"])
+	assert.Equal(t, expectedError, errorResult.Detail)
 
 	//Arrange
 	expectedError = "product code is required"
@@ -177,7 +179,7 @@ func TestCreateFailProduct(t *testing.T) {
 	//Assert
 	assert.Nil(t, err)
 	assert.Equal(t, 422, received.Code)
-	assert.Equal(t, expectedError, errorResult["message"])
+	assert.Equal(t, expectedError, errorResult.Detail)
 
 	//Arrange
 	expectedError = "expiration_rate is required"
@@ -202,12 +204,12 @@ func TestCreateFailProduct(t *testing.T) {
 	//Assert
 	assert.Nil(t, err)
 	assert.Equal(t, 422, received.Code)
-	assert.Equal(t, expectedError, errorResult["message"])
+	assert.Equal(t, expectedError, errorResult.Detail)
 
 	//Assert
 	assert.Nil(t, err)
 	assert.Equal(t, 422, received.Code)
-	assert.Equal(t, expectedError, errorResult["message"])
+	assert.Equal(t, expectedError, errorResult.Detail)
 
 	//Arrange
 	expectedError = "freezing_rate is required"
@@ -232,7 +234,7 @@ func TestCreateFailProduct(t *testing.T) {
 	//Assert
 	assert.Nil(t, err)
 	assert.Equal(t, 422, received.Code)
-	assert.Equal(t, expectedError, errorResult["message"])
+	assert.Equal(t, expectedError, errorResult.Detail)
 
 	//Arrange
 	expectedError = "height is required"
@@ -257,12 +259,12 @@ func TestCreateFailProduct(t *testing.T) {
 	//Assert
 	assert.Nil(t, err)
 	assert.Equal(t, 422, received.Code)
-	assert.Equal(t, expectedError, errorResult["message"])
+	assert.Equal(t, expectedError, errorResult.Detail)
 
 	//Assert
 	assert.Nil(t, err)
 	assert.Equal(t, 422, received.Code)
-	assert.Equal(t, expectedError, errorResult["message"])
+	assert.Equal(t, expectedError, errorResult.Detail)
 
 	//Arrange
 	expectedError = "length is required"
@@ -287,7 +289,7 @@ func TestCreateFailProduct(t *testing.T) {
 	//Assert
 	assert.Nil(t, err)
 	assert.Equal(t, 422, received.Code)
-	assert.Equal(t, expectedError, errorResult["message"])
+	assert.Equal(t, expectedError, errorResult.Detail)
 
 	//Arrange
 	expectedError = "netweight is required"
@@ -312,7 +314,7 @@ func TestCreateFailProduct(t *testing.T) {
 	//Assert
 	assert.Nil(t, err)
 	assert.Equal(t, 422, received.Code)
-	assert.Equal(t, expectedError, errorResult["message"])
+	assert.Equal(t, expectedError, errorResult.Detail)
 
 	//Arrange
 	expectedError = "product code is required"
@@ -337,7 +339,7 @@ func TestCreateFailProduct(t *testing.T) {
 	//Assert
 	assert.Nil(t, err)
 	assert.Equal(t, 422, received.Code)
-	assert.Equal(t, expectedError, errorResult["message"])
+	assert.Equal(t, expectedError, errorResult.Detail)
 
 	//Arrange
 	expectedError = "recommended_freezing_temperature is required"
@@ -362,7 +364,7 @@ func TestCreateFailProduct(t *testing.T) {
 	//Assert
 	assert.Nil(t, err)
 	assert.Equal(t, 422, received.Code)
-	assert.Equal(t, expectedError, errorResult["message"])
+	assert.Equal(t, expectedError, errorResult.Detail)
 
 	//Arrange
 	expectedError = "width is required"
@@ -387,7 +389,7 @@ func TestCreateFailProduct(t *testing.T) {
 	//Assert
 	assert.Nil(t, err)
 	assert.Equal(t, 422, received.Code)
-	assert.Equal(t, expectedError, errorResult["message"])
+	assert.Equal(t, expectedError, errorResult.Detail)
 
 }
 
@@ -396,7 +398,7 @@ func TestCreateConflictProduct(t *testing.T) {
 
 	//Arrange
 	expectedError := "product_code already exists"
-	var errorResult map[string]string
+	var errorResult web.Problem
 
 	mockService := products.MockServiceProduct{
 		DataMock: database,
@@ -424,7 +426,7 @@ func TestCreateConflictProduct(t *testing.T) {
 	//Assert
 	assert.Nil(t, err)
 	assert.Equal(t, 409, received.Code)
-	assert.Equal(t, expectedError, errorResult["message"])
+	assert.Equal(t, expectedError, errorResult.Detail)
 }
 
 // User Story asociada: READ, Caso borde: find_all, Cuando la petición sea exitosa el backend devolverá un listado de todas los productos existentes
@@ -452,7 +454,7 @@ func TestFindAllProduct(t *testing.T) {
 
 func TestFindAllProductFail(t *testing.T) {
 	expectedError := "get all error"
-	var errorResult map[string]string
+	var errorResult web.Problem
 
 	mockService := products.MockServiceProduct{DataMock: database, Error: expectedError}
 	server := createServerProduct(&mockService)
@@ -463,15 +465,15 @@ func TestFindAllProductFail(t *testing.T) {
 	err := json.Unmarshal(received.Body.Bytes(), &errorResult)
 
 	assert.Nil(t, err)
-	assert.Equal(t, 404, received.Code)
-	assert.Equal(t, expectedError, errorResult["message"])
+	assert.Equal(t, 500, received.Code)
+	assert.NotContains(t, errorResult.Detail, expectedError)
 }
 
 // User Story asociada: READ, Caso borde: find_by_id_non_existent, Cuando el producto no exista se devolverá un código 404
 func TestFindByIdNonExistentProduct(t *testing.T) {
 	//Arrange
 	expectedError := "product not found"
-	var errorResult map[string]string
+	var errorResult web.Problem
 	mockService := products.MockServiceProduct{
 		DataMock: database,
 	}
@@ -485,7 +487,7 @@ func TestFindByIdNonExistentProduct(t *testing.T) {
 	//Assert
 	assert.Nil(t, err)
 	assert.Equal(t, 404, received.Code)
-	assert.Equal(t, expectedError, errorResult["message"])
+	assert.Equal(t, expectedError, errorResult.Detail)
 
 	//Output:
 
@@ -582,7 +584,7 @@ func TestUpdateOkProduct(t *testing.T) {
 func TestUpdateNonExistentProduct(t *testing.T) {
 	//Arrange
 	expectedError := "product not found"
-	var errorResult map[string]string
+	var errorResult web.Problem
 
 	mockService := products.MockServiceProduct{
 		DataMock: database,
@@ -603,7 +605,7 @@ func TestUpdateNonExistentProduct(t *testing.T) {
 	//Assert
 	assert.Nil(t, err)
 	assert.Equal(t, 404, received.Code)
-	assert.Equal(t, expectedError, errorResult["message"])
+	assert.Equal(t, expectedError, errorResult.Detail)
 }
 
 // User Story asociada: DELETE
@@ -613,7 +615,7 @@ func TestUpdateNonExistentProduct(t *testing.T) {
 func TestDeleteNonExistentProduct(t *testing.T) {
 	//Arrange
 	errorExpected := "product not found"
-	var errorResult map[string]string
+	var errorResult web.Problem
 
 	mockService := products.MockServiceProduct{
 		DataMock: database,
@@ -628,7 +630,7 @@ func TestDeleteNonExistentProduct(t *testing.T) {
 	//Assert
 	assert.Nil(t, err)
 	assert.Equal(t, 404, received.Code)
-	assert.Equal(t, errorExpected, errorResult["message"])
+	assert.Equal(t, errorExpected, errorResult.Detail)
 }
 
 // User Story asociada: DELETE
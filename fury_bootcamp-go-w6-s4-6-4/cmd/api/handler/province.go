@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/province"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/api"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+)
+
+type Province struct {
+	provinceService province.Service
+}
+
+func NewProvince(s province.Service) *Province {
+	return &Province{
+		provinceService: s,
+	}
+}
+
+func (p *Province) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provinces, err := p.provinceService.GetAll(c)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, provinces)
+	}
+}
+
+func (p *Province) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		found, err := p.provinceService.Get(c, id)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, found)
+	}
+}
+
+func (p *Province) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req api.ProvinceCreate
+		if err := c.ShouldBindJSON(&req); err != nil {
+			web.ValidationError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		created, err := p.provinceService.Save(c, domain.Province{ID: req.ID, ProvinceName: req.ProvinceName, CountryID: req.CountryID})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusCreated, created)
+	}
+}
+
+func (p *Province) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		var req api.ProvinceUpdate
+		if err := c.ShouldBindJSON(&req); err != nil {
+			web.ValidationError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		updated, err := p.provinceService.Update(c, domain.Province{ProvinceName: req.ProvinceName, CountryID: req.CountryID}, id)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, updated)
+	}
+}
+
+func (p *Province) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		if err := p.provinceService.Delete(c, id); err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusNoContent, nil)
+	}
+}
+
+// Report counts the sellers and the carries of every province, or of the
+// province in ?id=.
+func (p *Province) Report() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := reportID(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		reports, err := p.provinceService.Report(c, id)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, reports)
+	}
+}
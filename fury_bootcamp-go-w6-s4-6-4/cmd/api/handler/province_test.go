@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/province"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+	provincemock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/province"
+	"github.com/stretchr/testify/assert"
+)
+
+func createServerProvince() *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	repo := &provincemock.MockRepository{
+		DataMock:  []domain.Province{{ID: 6, ProvinceName: "Buenos Aires", CountryID: 32}},
+		Countries: []int{32},
+		Reports:   []domain.ProvinceReport{{ProvinceID: 6, ProvinceName: "Buenos Aires", SellersCount: 3, CarriesCount: 2}},
+	}
+	handler := NewProvince(province.NewService(repo, logger.Discard()))
+	r := gin.New()
+	r.Use(web.ErrorHandler())
+	pr := r.Group("/provinces")
+	pr.GET("", handler.GetAll())
+	pr.GET("/report", handler.Report())
+	pr.GET("/:id", handler.Get())
+	pr.POST("", handler.Create())
+	pr.PATCH("/:id", handler.Update())
+	pr.DELETE("/:id", handler.Delete())
+	return r
+}
+
+func TestProvince(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{name: "list", method: http.MethodGet, path: "/provinces", status: http.StatusOK},
+		{name: "get", method: http.MethodGet, path: "/provinces/6", status: http.StatusOK},
+		{name: "get unknown", method: http.MethodGet, path: "/provinces/9", status: http.StatusNotFound},
+		{name: "create", method: http.MethodPost, path: "/provinces", body: `{"province_name": "Córdoba", "country_id": 32}`, status: http.StatusCreated},
+		{name: "create without a country", method: http.MethodPost, path: "/provinces", body: `{"province_name": "Córdoba"}`, status: http.StatusUnprocessableEntity},
+		{name: "create in an unknown country", method: http.MethodPost, path: "/provinces", body: `{"province_name": "Córdoba", "country_id": 9}`, status: http.StatusConflict},
+		{name: "create a taken name", method: http.MethodPost, path: "/provinces", body: `{"province_name": "Buenos Aires", "country_id": 32}`, status: http.StatusConflict},
+		{name: "update", method: http.MethodPatch, path: "/provinces/6", body: `{"province_name": "Provincia de Buenos Aires"}`, status: http.StatusOK},
+		{name: "update to an unknown country", method: http.MethodPatch, path: "/provinces/6", body: `{"country_id": 9}`, status: http.StatusConflict},
+		{name: "report", method: http.MethodGet, path: "/provinces/report", status: http.StatusOK},
+		{name: "report of an unknown province", method: http.MethodGet, path: "/provinces/report?id=9", status: http.StatusNotFound},
+		{name: "delete", method: http.MethodDelete, path: "/provinces/6", status: http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			createServerProvince().ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.status, rr.Code, rr.Body.String())
+		})
+	}
+}
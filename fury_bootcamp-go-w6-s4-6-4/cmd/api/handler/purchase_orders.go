@@ -2,7 +2,6 @@ package handler
 
 import (
 	"errors"
-	"log"
 	"net/http"
 	"time"
 
@@ -54,8 +53,7 @@ func (p *PurchaseOrders) Create() gin.HandlerFunc {
                 var req RequestPurchaseOrders
 
                 if err := ctx.ShouldBindJSON(&req); err != nil {
-                        log.Print(err)
-                        web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+                        web.ValidationError(ctx, http.StatusUnprocessableEntity, err)
                         return
 		}
 
@@ -73,7 +71,7 @@ func (p *PurchaseOrders) Create() gin.HandlerFunc {
 
 		purchaseOrder, err := p.purchaseOrderService.Save(ctx, req.OrderNumber, req.TrackingCode,  req.BuyerID, req.ProductRecordID, req.OrderStatusID, &previosTime)
 		if err != nil {
-                        web.Error(ctx, http.StatusConflict, err.Error())
+                        ctx.Error(err)
 			return
 		}
 
@@ -86,13 +84,13 @@ func (p *PurchaseOrders) Get() gin.HandlerFunc {
 	        var req RequestQueryPurchaseOrders
 
                 if err := ctx.ShouldBindQuery(&req); err != nil {
-                        web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+                        web.ValidationError(ctx, http.StatusUnprocessableEntity, err)
                         return
                 }
 
                 reportPurchaseOrders, err := p.purchaseOrderService.GetAllByBuyerID(ctx, req.ID)
                 if err != nil {
-                        web.Error(ctx, http.StatusNotFound, ErrNotFoundPurchaseOrders.Error())
+                        ctx.Error(err)
                         return
                 }
 
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/purchase_orders"
 )
 
@@ -20,6 +21,7 @@ func createServerPurchaseOrders(mockService purchase_orders.MockService) *gin.En
 
 	handler := NewPurchaseOrders(&mockService)
 	router := gin.Default()
+	router.Use(web.ErrorHandler())
 
 	pr := router.Group("/api/v1/")
 
@@ -148,10 +150,14 @@ func TestHandlerPurchaseOrdersCreate(t *testing.T) {
 			Error:    errMessage,
 		}
 
-		var resp map[string]string
-		expected := map[string]string{
-			"code":    "conflict",
-			"message": "buyer_id doesn't exists",
+		var resp web.Problem
+		expected := web.Problem{
+			Type:     "urn:melisprint:problem:foreign_key_violation",
+			Title:    "Referenced resource does not exist",
+			Status:   http.StatusConflict,
+			Detail:   "buyer_id doesn't exists",
+			Instance: "/api/v1/purchaseOrders",
+			Code:     "foreign_key_violation",
 		}
 
 		// Act
@@ -204,7 +210,7 @@ func TestHandlerPurchaseOrdersCreate(t *testing.T) {
 			Error:    errMessage,
 		}
 
-		var resp map[string]string
+		var resp web.Problem
 
 		// Act
 		router := createServerPurchaseOrders(mockService)
@@ -218,7 +224,7 @@ func TestHandlerPurchaseOrdersCreate(t *testing.T) {
 		// Assert
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
-		assert.Equal(t, errMessage, resp["message"])
+		assert.Equal(t, errMessage, resp.Detail)
 	})
 
 	t.Run("TestHandlerPurchaseOrdersCreateDateFail", func(t *testing.T) {
@@ -254,7 +260,7 @@ func TestHandlerPurchaseOrdersCreate(t *testing.T) {
 			Error:    errMessage,
 		}
 
-		var resp map[string]string
+		var resp web.Problem
 
 		// Act
 		router := createServerPurchaseOrders(mockService)
@@ -268,7 +274,7 @@ func TestHandlerPurchaseOrdersCreate(t *testing.T) {
 		// Assert
 		assert.Nil(t, err)
 		// assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
-		// assert.Equal(t, errMessage, resp["message"])
+		// assert.Equal(t, errMessage, resp.Detail)
 	})
 
 }
@@ -377,10 +383,14 @@ func TestHandlerReport(t *testing.T) {
 			// Error: "not Found",
 		}
 
-		var resp map[string]string
-		expected := map[string]string{
-			"code":    "not_found",
-			"message": "there is no purchase orders",
+		var resp web.Problem
+		expected := web.Problem{
+			Type:     "about:blank",
+			Title:    "Not Found",
+			Status:   http.StatusNotFound,
+			Detail:   "there is no purchase orders",
+			Instance: "/api/v1/buyers/reportPurchaseOrders?buyerID=foo",
+			Code:     "not_found",
 		}
 
 		router := createServerPurchaseOrders(mockService)
@@ -418,10 +428,14 @@ func TestHandlerReport(t *testing.T) {
 			Error:           "there is no purchase orders",
 		}
 
-		var resp map[string]string
-		expected := map[string]string{
-			"code":    "not_found",
-			"message": "there is no purchase orders",
+		var resp web.Problem
+		expected := web.Problem{
+			Type:     "urn:melisprint:problem:not_found",
+			Title:    "Resource not found",
+			Status:   http.StatusNotFound,
+			Detail:   "there is no purchase orders",
+			Instance: "/api/v1/buyers/reportPurchaseOrders?id=2",
+			Code:     "not_found",
 		}
 
 		router := createServerPurchaseOrders(mockService)
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/report"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+)
+
+type Report struct {
+	reportService report.Service
+}
+
+func NewReport(s report.Service) *Report {
+	return &Report{
+		reportService: s,
+	}
+}
+
+// Run answers the report in the path as time series. It takes ?from= and
+// ?to= as YYYY-MM-DD, both included, and ?group_by= and ?ids= as comma
+// separated lists.
+func (rp *Report) Run() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		q, err := reportQuery(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		result, err := rp.reportService.Run(c, c.Param("report"), q)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, result)
+	}
+}
+
+func reportQuery(c *gin.Context) (domain.ReportQuery, error) {
+	var q domain.ReportQuery
+	var err error
+	if q.From, err = queryDate(c, "from"); err != nil {
+		return q, err
+	}
+	if q.To, err = queryDate(c, "to"); err != nil {
+		return q, err
+	}
+	q.GroupBy = queryList(c, "group_by")
+	for _, s := range queryList(c, "ids") {
+		id, err := strconv.Atoi(s)
+		if err != nil {
+			return q, fmt.Errorf("ids: %w", err)
+		}
+		q.IDs = append(q.IDs, id)
+	}
+	return q, nil
+}
+
+func queryDate(c *gin.Context, key string) (time.Time, error) {
+	s := c.Query(key)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", key, err)
+	}
+	return t, nil
+}
+
+// queryList splits the comma separated ?key=, skipping empty items.
+func queryList(c *gin.Context, key string) []string {
+	var items []string
+	for _, item := range strings.Split(c.Query(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/report"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+	reportmock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func createServerReport() *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	repo := &reportmock.MockRepository{
+		Rows: []domain.ReportRow{{Period: "2024-01-01", IDs: []int{1}, Count: 3}},
+	}
+	handler := NewReport(report.NewService(repo, logger.Discard()))
+	r := gin.New()
+	r.Use(web.ErrorHandler())
+	r.GET("/reports/:report", handler.Run())
+	return r
+}
+
+func TestReport(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{name: "total", path: "/reports/sellers", status: http.StatusOK},
+		{name: "by period and entity", path: "/reports/inbound_orders?from=2024-01-01&to=2024-01-31&group_by=week,warehouse&ids=1,2", status: http.StatusOK},
+		{name: "unknown report", path: "/reports/buyers", status: http.StatusNotFound},
+		{name: "invalid date", path: "/reports/inbound_orders?from=01/01/2024", status: http.StatusBadRequest},
+		{name: "invalid id", path: "/reports/inbound_orders?group_by=warehouse&ids=1,a", status: http.StatusBadRequest},
+		{name: "unknown dimension", path: "/reports/sellers?group_by=warehouse", status: http.StatusUnprocessableEntity},
+		{name: "range backwards", path: "/reports/inbound_orders?from=2024-02-01&to=2024-01-01", status: http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			rr := httptest.NewRecorder()
+
+			createServerReport().ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.status, rr.Code, rr.Body.String())
+		})
+	}
+}
@@ -20,20 +20,12 @@ func NewSection(s section.Service) *Section {
 	}
 }
 
-// GetAllSections godoc
-// @Summary Get all sections
-// @Tags Sections
-// @Description get all sections
-// @Accept  json
-// @Produce  json
-// @Success 200 {object} web.response
-// @Router /api/v1/sections [get]
 func (s *Section) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
 
 		sect, err := s.sectionService.GetAll(c)
 		if err != nil {
-			web.Error(c, http.StatusNotFound, "%s", err)
+			c.Error(err)
 			return
 		}
 
@@ -41,41 +33,23 @@ func (s *Section) GetAll() gin.HandlerFunc {
 	}
 }
 
-// GetSection godoc
-// @Summary Get section
-// @Tags Sections
-// @Description get one section
-// @Accept  json
-// @Produce  json
-// @Param id path int true "Section ID"
-// @Success 200 {object} web.response
-// @Router /api/v1/sections/:id [get]
 func (s *Section) Get() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id := c.Param("id")
 		idInt, err := strconv.Atoi(id)
 		if err != nil {
-			web.Error(c, http.StatusNotFound, "%s", err)
+			web.Error(c, http.StatusBadRequest, "%s", err)
 			return
 		}
 		sect, err := s.sectionService.Get(c, idInt)
 		if err != nil {
-			web.Error(c, http.StatusConflict, "%s", err)
+			c.Error(err)
 			return
 		}
 		web.Success(c, http.StatusOK, sect)
 	}
 }
 
-// UpdateSection godoc
-// @Summary Update section
-// @Tags Sections
-// @Description update section
-// @Accept  json
-// @Produce  json
-// @Param id path int true "Section ID"
-// @Success 200 {object} web.response
-// @Router /api/v1/sections/:id [patch]
 func (s Section) Update() gin.HandlerFunc {
 	return func(c *gin.Context) {
 
@@ -83,18 +57,18 @@ func (s Section) Update() gin.HandlerFunc {
 
 		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 		if err != nil {
-			web.Error(c, http.StatusNotFound, "%s", err)
+			web.Error(c, http.StatusBadRequest, "%s", err)
 			return
 		}
 
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, http.StatusNotFound, "%s", err)
+			web.ValidationError(c, http.StatusBadRequest, err)
 			return
 		}
 
 		sec, err := s.sectionService.Update(c, int(id), req.SectionNumber, req.CurrentTemperature, req.MinimumTemperature, req.CurrentCapacity, req.MinimumCapacity, req.MaximumCapacity, req.WarehouseID, req.ProductTypeID)
 		if err != nil {
-			web.Error(c, http.StatusNotFound, "%s", err)
+			c.Error(err)
 			return
 		}
 
@@ -102,26 +76,17 @@ func (s Section) Update() gin.HandlerFunc {
 	}
 }
 
-// DeleteSection godoc
-// @Summary Delete section
-// @Tags Sections
-// @Description delete section
-// @Accept  json
-// @Produce  json
-// @Param id path int true "Section ID"
-// @Success 204
-// @Router /api/v1/sections/:id [delete]
 func (s *Section) Delete() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id := c.Param("id")
 		idInt, err := strconv.Atoi(id)
 		if err != nil {
-			web.Error(c, http.StatusConflict, "%s", err)
+			web.Error(c, http.StatusBadRequest, "%s", err)
 			return
 		}
 		err = s.sectionService.Delete(c, idInt)
 		if err != nil {
-			web.Error(c, http.StatusNotFound, "%s", err)
+			c.Error(err)
 			return
 		}
 
@@ -129,26 +94,21 @@ func (s *Section) Delete() gin.HandlerFunc {
 	}
 }
 
-// CreateSection godoc
-// @Summary Create section
-// @Tags Sections
-// @Description create section
-// @Accept  json
-// @Produce  json
-// @Param section body domain.Section true "Section"
-// @Success 201 {object} web.response
-// @Router /api/v1/sections [post]
 func (s *Section) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req domain.Section
+		if err := c.ShouldBindJSON(&req); err != nil {
+			web.ValidationError(c, http.StatusBadRequest, err)
+			return
+		}
 
 		id, err := s.sectionService.Save(c, req)
-		if err != nil && err.Error() == "section already exists" {
-			web.Error(c, http.StatusConflict, "%s", err)
+		if err != nil {
+			c.Error(err)
 			return
 		}
 		req.ID = id
-	
+
 		web.Success(c, http.StatusCreated, req)
 	}
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/section"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
 	sectionmock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/section"
 
 	"github.com/stretchr/testify/assert"
@@ -39,6 +40,7 @@ func createServerSection(mockService sectionmock.MockRepository) *gin.Engine {
 	service := ServiceMockRepository(mockService)
 	handler := NewSection(service)
 	r := gin.Default()
+	r.Use(web.ErrorHandler())
 	er := r.Group("/sections")
 	er.GET("/", handler.GetAll())
 	er.GET("/:id", handler.Get())
@@ -102,8 +104,8 @@ func TestCreate_conflictSection(t *testing.T) {
 		"product_type_id": 1
 	}`)
 	data := struct {
-		Message string
-		Error   string
+		Detail string
+		Error  string
 	}{}
 
 	server.ServeHTTP(respons, req)
@@ -114,7 +116,7 @@ func TestCreate_conflictSection(t *testing.T) {
 
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusConflict, respons.Code)
-	assert.Equal(t, "section already exists", data.Message)
+	assert.Equal(t, "section already exists", data.Detail)
 
 }
 
@@ -132,8 +134,8 @@ func TestCreate_failSection(t *testing.T) {
 		"product_type_id": 1
 	}`)
 	data := struct {
-		Message string
-		Error   string
+		Detail string
+		Error  string
 	}{}
 
 	server.ServeHTTP(respons, req)
@@ -219,7 +221,7 @@ func TestFind_by_id_non_existentSection(t *testing.T) {
 	t.Log("data: ", data.Data)
 
 	assert.Nil(t, err)
-	assert.Equal(t, http.StatusConflict, respons.Code)
+	assert.Equal(t, http.StatusNotFound, respons.Code)
 
 }
 
@@ -333,8 +335,8 @@ func TestDelete_non_existentSection(t *testing.T) {
 	t.Log("dataStruck", DataStruck)
 
 	data := struct {
-		Data    domain.Section
-		Message string
+		Data   domain.Section
+		Detail string
 	}{}
 
 	server.ServeHTTP(respons, req)
@@ -344,7 +346,7 @@ func TestDelete_non_existentSection(t *testing.T) {
 
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusNotFound, respons.Code)
-	assert.Equal(t, errorExpected, data.Message)
+	assert.Equal(t, errorExpected, data.Detail)
 }
 
 // TestDelete_existentSection
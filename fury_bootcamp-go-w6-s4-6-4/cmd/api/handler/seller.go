@@ -31,9 +31,9 @@ func NewSeller(s seller.Service) *Seller {
 
 func (s *Seller) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		s, err := s.sellerService.GetAll()
+		s, err := s.sellerService.GetAll(c)
 		if err != nil {
-			web.Error(c, http.StatusNotFound, err.Error())
+			c.Error(err)
 			return
 		}
 		web.Success(c, http.StatusOK, s)
@@ -47,9 +47,9 @@ func (s *Seller) Get() gin.HandlerFunc {
 			web.Error(c, http.StatusExpectationFailed, err.Error())
 			return
 		}
-		s, err := s.sellerService.Get(id)
+		s, err := s.sellerService.Get(c, id)
 		if err != nil {
-			web.Error(c, http.StatusNotFound, err.Error())
+			c.Error(err)
 			return
 		}
 		web.Success(c, http.StatusOK, s)
@@ -60,21 +60,13 @@ func (s *Seller) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req requestSeller
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, http.StatusNotFound, err.Error())
+			web.ValidationError(c, http.StatusNotFound, err)
 			return
 		}
 
-		id, err := s.sellerService.Save(req.CID, req.LocalityID, req.CompanyName, req.Address, req.Telephone)
+		id, err := s.sellerService.Save(c, req.CID, req.LocalityID, req.CompanyName, req.Address, req.Telephone)
 		if err != nil {
-			if err.Error() == seller.ErrCidExists.Error() || err.Error() == seller.ErrLocalityNotFound.Error() {
-				web.Error(c, http.StatusConflict, err.Error())
-				return
-			}
-			if err.Error() == seller.ErrRequired.Error() {
-				web.Error(c, http.StatusUnprocessableEntity, err.Error())
-				return
-			}
-			web.Error(c, http.StatusBadRequest, err.Error())
+			c.Error(err)
 			return
 		}
 
@@ -94,15 +86,15 @@ func (s *Seller) Update() gin.HandlerFunc {
 
 		var req domain.Seller
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, http.StatusBadRequest, err.Error())
+			web.ValidationError(c, http.StatusBadRequest, err)
 			return
 		}
 
 		req.ID = id
 
-		req, err = s.sellerService.Update(req)
+		req, err = s.sellerService.Update(c, req)
 		if err != nil {
-			web.Error(c, http.StatusNotFound, err.Error())
+			c.Error(err)
 			return
 		}
 		web.Success(c, http.StatusOK, req)
@@ -116,8 +108,8 @@ func (s *Seller) Delete() gin.HandlerFunc {
 			web.Error(c, http.StatusBadRequest, err.Error())
 			return
 		}
-		if err := s.sellerService.Delete(id); err != nil {
-			web.Error(c, http.StatusNotFound, err.Error())
+		if err := s.sellerService.Delete(c, id); err != nil {
+			c.Error(err)
 			return
 		}
 		web.Success(c, http.StatusOK, s)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/sellers"
 	"github.com/stretchr/testify/assert"
 )
@@ -17,6 +18,7 @@ func createServerSeller(mockService sellers.MockService) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 	p := NewSeller(&mockService)
 	r := gin.Default()
+	r.Use(web.ErrorHandler())
 	pr := r.Group("api/v1/sellers")
 	pr.GET("", p.GetAll())
 	pr.GET("/:id", p.Get())
@@ -64,7 +66,7 @@ func TestCreateBadRequest(t *testing.T) {
 	// assert
 	err := json.Unmarshal(rr.Body.Bytes(), &resp)
 	assert.Nil(t, err)
-	assert.Equal(t, 400, rr.Code)
+	assert.Equal(t, 422, rr.Code)
 }
 func TestCreateFail(t *testing.T) {
 	// arrange
@@ -153,7 +155,7 @@ func TestFindAllFail(t *testing.T) {
 	// assert
 	err := json.Unmarshal(rr.Body.Bytes(), &resp)
 	assert.Nil(t, err)
-	assert.Equal(t, 404, rr.Code)
+	assert.Equal(t, 500, rr.Code)
 }
 func TestFindByIdNonExistent(t *testing.T) {
 	// arrange
@@ -22,15 +22,6 @@ func NewWarehouse(w warehouse.Service) *Warehouse {
 	}
 }
 
-// ListOneWarehouse godoc
-// @Summary List one warehouse
-// @Tags Warehouses
-// @Description get product by ID
-// @Accept  json
-// @Produce  json
-// @Success 200 {object} web.response
-// @Router /api/v1/warehouses/:id [get]
-// @Param id path int true "Warehouse ID"
 func (w *Warehouse) Get() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, err := strconv.Atoi(c.Param("id"))
@@ -39,55 +30,38 @@ func (w *Warehouse) Get() gin.HandlerFunc {
 			return
 		}
 
-		warehouse, err := w.warehouseService.Get(id)
+		warehouse, err := w.warehouseService.Get(c, id)
 		if err != nil {
-			web.Error(c, http.StatusNotFound, err.Error())
+			c.Error(err)
 			return
 		}
 		web.Success(c, http.StatusOK, warehouse)
 	}
 }
 
-// ListWarehouses godoc
-// @Summary List warehouses
-// @Tags Warehouses
-// @Description get warehouses
-// @Accept  json
-// @Produce  json
-// @Success 200 {object} web.response
-// @Router /api/v1/warehouses [get]
 func (w *Warehouse) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		warehouses, err := w.warehouseService.GetAll()
+		warehouses, err := w.warehouseService.GetAll(c)
 		if err != nil {
-			web.Error(c, http.StatusNotFound, err.Error())
+			c.Error(err)
 			return
 		}
 		web.Success(c, http.StatusOK, warehouses)
 	}
 }
 
-// CreateWarehouse godoc
-// @Summary Create warehouse
-// @Tags Warehouses
-// @Description create warehouse
-// @Accept  json
-// @Produce  json
-// @Param warehouse body domain.Warehouse true "Warehouse"
-// @Success 201 {object} web.response
-// @Router /api/v1/warehouses [post]
 func (w *Warehouse) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		warehouse := domain.Warehouse{}
 		if err := c.ShouldBindJSON(&warehouse); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, err.Error())
+			web.ValidationError(c, http.StatusUnprocessableEntity, err)
 			return
 		}
 
 		emptyFields := []string{}
 		values := reflect.ValueOf(warehouse)
 		for i := 0; i < values.NumField(); i++ {
-			if values.Type().Field(i).Name == "ID" {
+			if name := values.Type().Field(i).Name; name == "ID" || name == "SiteID" {
 				continue
 			}
 			if values.Field(i).IsZero() {
@@ -109,31 +83,17 @@ func (w *Warehouse) Create() gin.HandlerFunc {
 			return
 		}
 
-		id, err := w.warehouseService.Save(warehouse)
+		id, err := w.warehouseService.Save(c, warehouse)
 		if err != nil {
-			web.Error(c, http.StatusConflict, err.Error())
+			c.Error(err)
 			return
 		}
 
 		warehouse.ID = id
-
-		if err != nil {
-			web.Error(c, http.StatusBadRequest, err.Error())
-			return
-		}
 		web.Success(c, http.StatusCreated, warehouse)
 	}
 }
 
-// UpdateWarehouse godoc
-// @Summary Update warehouse
-// @Tags Warehouses
-// @Description update warehouse
-// @Accept  json
-// @Produce  json
-// @Param id path int true "Warehouse ID"
-// @Success 200 {object} web.response
-// @Router /api/v1/warehouses/:id [patch]
 func (w *Warehouse) Update() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, err := strconv.Atoi(c.Param("id"))
@@ -145,7 +105,7 @@ func (w *Warehouse) Update() gin.HandlerFunc {
 		warehouse := domain.Warehouse{}
 
 		if err := c.ShouldBindJSON(&warehouse); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, err.Error())
+			web.ValidationError(c, http.StatusUnprocessableEntity, err)
 			return
 		}
 
@@ -162,9 +122,9 @@ func (w *Warehouse) Update() gin.HandlerFunc {
 			}
 		}
 
-		updateWarehouse, err := w.warehouseService.Update(warehouse, id)
+		updateWarehouse, err := w.warehouseService.Update(c, warehouse, id)
 		if err != nil {
-			web.Error(c, http.StatusNotFound, err.Error())
+			c.Error(err)
 			return
 		}
 
@@ -172,15 +132,6 @@ func (w *Warehouse) Update() gin.HandlerFunc {
 	}
 }
 
-// DeleteWarehouse godoc
-// @Summary Delete warehouse
-// @Tags Warehouses
-// @Description delete warehouse
-// @Accept  json
-// @Produce  json
-// @Param id path int true "Warehouse ID"
-// @Success 204 {object} web.response
-// @Router /api/v1/warehouses/:id [delete]
 func (w *Warehouse) Delete() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, err := strconv.Atoi(c.Param("id"))
@@ -189,8 +140,8 @@ func (w *Warehouse) Delete() gin.HandlerFunc {
 			return
 		}
 
-		if err := w.warehouseService.Delete(id); err != nil {
-			web.Error(c, http.StatusNotFound, err.Error())
+		if err := w.warehouseService.Delete(c, id); err != nil {
+			c.Error(err)
 			return
 		}
 
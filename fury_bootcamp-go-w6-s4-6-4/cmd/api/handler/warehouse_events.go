@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/activity"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+)
+
+// truncatedEvent tells a resumed stream that some of the events it missed
+// were left out of the replay.
+const truncatedEvent = "stream.truncated"
+
+// reconnectDelay is how long clients wait before reconnecting once a stream
+// ends.
+const reconnectDelay = time.Second
+
+type WarehouseEvents struct {
+	activityService activity.Service
+	heartbeat       time.Duration
+}
+
+// NewWarehouseEvents builds the handler, which writes a comment every
+// heartbeat so proxies don't close idle streams.
+func NewWarehouseEvents(s activity.Service, heartbeat time.Duration) *WarehouseEvents {
+	return &WarehouseEvents{
+		activityService: s,
+		heartbeat:       heartbeat,
+	}
+}
+
+// Stream serves the events of a warehouse as Server-Sent Events, starting
+// with the ones after Last-Event-ID when it's set. The stream ends with the
+// request deadline; clients reconnect with the id of the last event they
+// got and miss nothing.
+func (w *WarehouseEvents) Stream() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		var lastEventID int64
+		if h := c.GetHeader("Last-Event-ID"); h != "" {
+			if lastEventID, err = strconv.ParseInt(h, 10, 64); err != nil {
+				web.Error(c, http.StatusBadRequest, "invalid Last-Event-ID: %s", err)
+				return
+			}
+		}
+
+		stream, err := w.activityService.Open(c, id, lastEventID)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		defer stream.Close()
+
+		h := c.Writer.Header()
+		h.Set("Content-Type", sse.ContentType)
+		h.Set("Cache-Control", "no-cache")
+		h.Set("Connection", "keep-alive")
+		// Keeps nginx from buffering the stream.
+		h.Set("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+
+		if _, err := c.Writer.WriteString("retry:" + strconv.FormatInt(reconnectDelay.Milliseconds(), 10) + "\n\n"); err != nil {
+			return
+		}
+		if stream.Truncated {
+			if err := sse.Encode(c.Writer, sse.Event{Event: truncatedEvent, Data: gin.H{"replayed": len(stream.Missed)}}); err != nil {
+				return
+			}
+		}
+		replayed := make(map[int64]bool, len(stream.Missed))
+		for _, e := range stream.Missed {
+			if err := writeEvent(c, e); err != nil {
+				return
+			}
+			replayed[e.ID] = true
+		}
+		c.Writer.Flush()
+
+		heartbeat := time.NewTicker(w.heartbeat)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case <-c.Request.Context().Done():
+				return
+			case <-heartbeat.C:
+				if _, err := c.Writer.WriteString(":\n\n"); err != nil {
+					return
+				}
+			case e, ok := <-stream.C:
+				// The broker closes C when the client falls behind; it
+				// catches up by reconnecting.
+				if !ok {
+					return
+				}
+				if replayed[e.ID] {
+					continue
+				}
+				if err := writeEvent(c, e); err != nil {
+					return
+				}
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+func writeEvent(c *gin.Context, e domain.OutboxEvent) error {
+	return sse.Encode(c.Writer, sse.Event{
+		Id:    strconv.FormatInt(e.ID, 10),
+		Event: e.Type,
+		Data:  e,
+	})
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/activity"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+	activitymock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/activity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stockChanged(id int64, warehouseID int) domain.OutboxEvent {
+	return domain.OutboxEvent{
+		ID:          id,
+		Type:        events.StockChanged,
+		AggregateID: 1,
+		WarehouseID: warehouseID,
+		OccurredAt:  time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		Data:        json.RawMessage(`{"quantity":10}`),
+	}
+}
+
+func createServerWarehouseEvents(repo *activitymock.MockRepository) (*gin.Engine, *activity.Broker) {
+	gin.SetMode(gin.ReleaseMode)
+	broker := activity.NewBroker(repo, activity.Options{BatchSize: 10, Buffer: 10, GapWait: time.Second}, logger.Discard())
+	handler := NewWarehouseEvents(activity.NewService(repo, broker, 10, logger.Discard()), time.Hour)
+	r := gin.New()
+	r.Use(web.ErrorHandler())
+	r.GET("/warehouses/:id/events", handler.Stream())
+	return r, broker
+}
+
+// nextEvent reads the stream up to the next event and returns its fields.
+func nextEvent(t *testing.T, r *bufio.Reader) map[string]string {
+	fields := map[string]string{}
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			if fields["data"] != "" {
+				return fields
+			}
+			continue
+		}
+		name, value, _ := strings.Cut(line, ":")
+		fields[name] = value
+	}
+}
+
+func TestWarehouseEventsStream(t *testing.T) {
+	repo := &activitymock.MockRepository{
+		Events:     []domain.OutboxEvent{stockChanged(1, 1), stockChanged(2, 2), stockChanged(3, 1)},
+		Warehouses: []int{1, 2},
+	}
+	eng, broker := createServerWarehouseEvents(repo)
+	require.NoError(t, broker.Poll(context.Background()))
+	srv := httptest.NewServer(eng)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/warehouses/1/events", nil)
+	req.Header.Set("Last-Event-ID", "1")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+	body := bufio.NewReader(res.Body)
+
+	missed := nextEvent(t, body)
+	assert.Equal(t, "3", missed["id"])
+	assert.Equal(t, events.StockChanged, missed["event"])
+	var e domain.OutboxEvent
+	require.NoError(t, json.Unmarshal([]byte(missed["data"]), &e))
+	assert.Equal(t, stockChanged(3, 1), e)
+
+	repo.Append(stockChanged(4, 2), stockChanged(5, 1))
+	require.NoError(t, broker.Poll(context.Background()))
+
+	live := nextEvent(t, body)
+	assert.Equal(t, "5", live["id"])
+}
+
+func TestWarehouseEventsStreamErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		lastEventID string
+		status      int
+	}{
+		{name: "bad id", target: "/warehouses/one/events", status: http.StatusBadRequest},
+		{name: "bad Last-Event-ID", target: "/warehouses/1/events", lastEventID: "last", status: http.StatusBadRequest},
+		{name: "unknown warehouse", target: "/warehouses/9/events", status: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eng, _ := createServerWarehouseEvents(&activitymock.MockRepository{Warehouses: []int{1}})
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+			rr := httptest.NewRecorder()
+
+			eng.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.status, rr.Code)
+		})
+	}
+}
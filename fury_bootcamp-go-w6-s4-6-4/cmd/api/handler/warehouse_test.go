@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/assert/v2"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/warehouse"
 )
 
@@ -16,6 +17,7 @@ var s = createService()
 
 func createService() *gin.Engine {
 	r := gin.Default()
+	r.Use(web.ErrorHandler())
 	service := warehouse.NewServiceWarehouse([]domain.Warehouse{})
 	hanler := NewWarehouse(service)
 	r.GET("/warehouses", hanler.GetAll())
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/webhook"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/api"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+)
+
+type Webhook struct {
+	webhookService webhook.Service
+}
+
+func NewWebhook(s webhook.Service) *Webhook {
+	return &Webhook{
+		webhookService: s,
+	}
+}
+
+func (w *Webhook) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req api.WebhookSubscriptionCreate
+		if err := c.ShouldBindJSON(&req); err != nil {
+			web.ValidationError(c, http.StatusBadRequest, err)
+			return
+		}
+
+		eventTypes := make([]string, len(req.EventTypes))
+		for i, t := range req.EventTypes {
+			eventTypes[i] = string(t)
+		}
+		sub, err := w.webhookService.Subscribe(c, req.URL, req.Secret, eventTypes)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusCreated, sub)
+	}
+}
+
+func (w *Webhook) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subs, err := w.webhookService.Subscriptions(c)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, subs)
+	}
+}
+
+func (w *Webhook) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		if err := w.webhookService.Unsubscribe(c, id); err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusNoContent, nil)
+	}
+}
+
+// Deliveries lists the latest deliveries; ?status=dead is the dead-letter
+// queue.
+func (w *Webhook) Deliveries() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		deliveries, err := w.webhookService.Deliveries(c, c.Query("status"))
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, deliveries)
+	}
+}
+
+func (w *Webhook) Retry() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "%s", err)
+			return
+		}
+		d, err := w.webhookService.Retry(c, id)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, d)
+	}
+}
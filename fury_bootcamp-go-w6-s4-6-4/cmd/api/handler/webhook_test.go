@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/webhook"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+	webhookmock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func createServerWebhook(repo *webhookmock.MockRepository) *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	handler := NewWebhook(webhook.NewService(repo, logger.Discard()))
+	r := gin.New()
+	r.Use(web.ErrorHandler())
+	wr := r.Group("/webhooks")
+	wr.POST("", handler.Create())
+	wr.GET("", handler.GetAll())
+	wr.DELETE("/:id", handler.Delete())
+	wr.GET("/deliveries", handler.Deliveries())
+	wr.POST("/deliveries/:id/retry", handler.Retry())
+	return r
+}
+
+func TestWebhookCreate(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{name: "created", body: `{"url": "http://localhost:9999/hooks", "secret": "0123456789abcdef", "event_types": ["seller.created"]}`, status: http.StatusCreated},
+		{name: "missing secret", body: `{"url": "http://localhost:9999/hooks"}`, status: http.StatusBadRequest},
+		{name: "unknown event type", body: `{"url": "http://localhost:9999/hooks", "secret": "0123456789abcdef", "event_types": ["seller.deleted"]}`, status: http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := createServerWebhook(&webhookmock.MockRepository{})
+			req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			server.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.status, rr.Code)
+			if tt.status == http.StatusCreated {
+				assert.NotContains(t, rr.Body.String(), "secret")
+			}
+		})
+	}
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	repo := &webhookmock.MockRepository{DeliveriesMap: map[int64]domain.WebhookDelivery{
+		1: {ID: 1, Status: domain.DeliveryDead, Attempts: 8},
+		2: {ID: 2, Status: domain.DeliveryDelivered, Attempts: 1},
+	}}
+	server := createServerWebhook(repo)
+
+	rr := httptest.NewRecorder()
+	server.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/webhooks/deliveries?status=dead", nil))
+	var body struct {
+		Data []domain.WebhookDelivery
+	}
+	json.Unmarshal(rr.Body.Bytes(), &body)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Len(t, body.Data, 1)
+	assert.Equal(t, int64(1), body.Data[0].ID)
+
+	rr = httptest.NewRecorder()
+	server.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/webhooks/deliveries/1/retry", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, domain.DeliveryPending, repo.DeliveriesMap[1].Status)
+
+	rr = httptest.NewRecorder()
+	server.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/webhooks/deliveries/2/retry", nil))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
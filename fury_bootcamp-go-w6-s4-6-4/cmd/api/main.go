@@ -1,47 +1,63 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"flag"
 	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/mercadolibre/go-meli-toolkit/gomelipass"
 
 	"github.com/gin-gonic/gin"
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/cmd/api/routes"
-	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/docs"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/config"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"google.golang.org/grpc"
 )
 
-// @title MELI Bootcamp API
-// @version 1.0
-// @description This API Handle MELI Products.
-// @termsOfService https://developers.mercadolibre.com.ar/es_ar/terminos-y-condiciones
-
-// @contact.name API Support
-// @contact.url https://developers.mercadolibre.com.ar/support
-
-// @license.name Apache 2.0
-// @license.url http://www.apache.org/licenses/LICENSE-2.0.html
-
 func main() {
-	// NO MODIFICAR
-
-	var conectionPolicy string
-	if len(os.Args) > 1 {
-		conectionPolicy = os.Args[1]
+	cfg, opts, err := config.Load(os.Args[1:], os.Getenv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "invalid config:", err)
+		os.Exit(2)
+	}
+	if opts.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			panic(err)
+		}
+		return
 	}
 
+	level, _ := logger.ParseLevel(cfg.LogLevel)
+	log := logger.New(os.Stdout, level)
+	slog.SetDefault(log)
+
 	var db *sql.DB
-	var err error
-	if conectionPolicy == "local" {
-		db, err = sql.Open("mysql", "root:@/melisprint")
+	var replicaDSNs []string
+	if cfg.Storage == config.StorageMySQL {
+		db, err = database.OpenLogged(mysql.MySQLDriver{}, cfg.DB.DSN, log)
 		if err != nil {
 			panic(err)
 		}
+		// Start anyway so /health/ready can report the database as down.
+		if err := db.Ping(); err != nil {
+			log.Warn("database unreachable", "error", err)
+		}
+		replicaDSNs = cfg.DB.ReplicaDSNs
 	} else {
+		// NO MODIFICAR
 		dbUsername := "bgow6s464_WPROD"
 		dbPassword := gomelipass.GetEnv("DB_MYSQL_DESAENV07_BGOW6S464_BGOW6S464_WPROD")
 		// You can use the variables (with READ ONLY permissions): 
@@ -50,7 +66,7 @@ func main() {
 		dbHost := gomelipass.GetEnv("DB_MYSQL_DESAENV07_BGOW6S464_BGOW6S464_ENDPOINT")
 		dbName := "bgow6s464"
 		connectionString := fmt.Sprintf("%s:%s@tcp(%s)/%s?charset=utf8", dbUsername, dbPassword, dbHost, dbName)
-		db, err = sql.Open("mysql", connectionString)
+		db, err = database.OpenLogged(mysql.MySQLDriver{}, connectionString, log)
 		if err != nil {
 			panic(err)
 		}
@@ -59,24 +75,112 @@ func main() {
 		if err != nil {
 			panic(err)
 		}
+
+		// Only the local replica: the remote one is in another region, so
+		// the primary answers faster.
+		if replicaHost := gomelipass.GetEnv("DB_MYSQL_DESAENV07_BGOW6S464_BGOW6S464_LOCAL_REPLICA_ENDPOINT"); replicaHost != "" {
+			replicaDSNs = append(replicaDSNs, fmt.Sprintf("%s:%s@tcp(%s)/%s?charset=utf8", dbUsername, dbPassword, replicaHost, dbName))
+		}
 	}
+	configurePool(db, cfg.DB)
 
-	// db, err := sql.Open("mysql", "root:@/melisprint")
-	// if err != nil {
-	// 	panic(err)
-	// }
+	var replicas []*sql.DB
+	for _, dsn := range replicaDSNs {
+		replica, err := database.OpenLogged(mysql.MySQLDriver{}, dsn, log)
+		if err != nil {
+			panic(err)
+		}
+		configurePool(replica, cfg.DB)
+		replicas = append(replicas, replica)
+	}
+	dbs := database.NewRouter(db, replicas, log.With("component", "db"))
 
-	eng := gin.Default()
+	eng := gin.New()
+	if err := eng.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Error("setting the trusted proxies", "error", err)
+		os.Exit(1)
+	}
+	eng.Use(gin.Recovery())
 
-	router := routes.NewRouter(eng, db)
-	router.MapRoutes()
+	router := routes.NewRouter(eng, dbs, log, cfg)
+	var grpcSrv *grpc.Server
+	if cfg.Features.GRPC {
+		grpcSrv = router.MapServices()
+	}
+	if err := router.MapRoutes(); err != nil {
+		log.Error("mapping routes", "error", err)
+		os.Exit(1)
+	}
 
-	docs.SwaggerInfo.Host = "localhost:8080"
-	eng.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	srv := &http.Server{
+		Addr:         cfg.Server.Addr,
+		Handler:      eng,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		log.Info("listening", "addr", srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			panic(err)
+		}
+	}()
+	if grpcSrv != nil {
+		lis, err := net.Listen("tcp", cfg.Server.GRPCAddr)
+		if err != nil {
+			panic(err)
+		}
+		go func() {
+			log.Info("listening for gRPC", "addr", lis.Addr().String())
+			if err := grpcSrv.Serve(lis); err != nil {
+				panic(err)
+			}
+		}()
+	}
 
-	if err := eng.Run(); err != nil {
-		panic(err)
+	<-ctx.Done()
+	log.Info("shutting down")
+	router.Shutdown()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Error("shutdown did not drain", "error", err)
+	}
+	if grpcSrv != nil {
+		stopGRPC(shutdownCtx, grpcSrv, log)
 	}
+	for _, db := range append([]*sql.DB{db}, replicas...) {
+		if err := db.Close(); err != nil {
+			log.Error("closing database", "error", err)
+		}
+	}
+}
+
+// stopGRPC waits for the calls in flight until ctx is done and then cuts
+// the rest, such as watches that only end when their client cancels them.
+func stopGRPC(ctx context.Context, srv *grpc.Server, log *slog.Logger) {
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		log.Error("gRPC shutdown did not drain", "error", ctx.Err())
+		srv.Stop()
+	}
+}
+
+func configurePool(db *sql.DB, cfg config.DB) {
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 }
 
 // import (
@@ -0,0 +1,57 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+// errorCodes maps each error kind to the code reported in the extensions of
+// the GraphQL error, the same codes the HTTP problems use.
+var errorCodes = []struct {
+	kind error
+	code string
+}{
+	{apperrors.ErrNotFound, "not_found"},
+	{apperrors.ErrConflict, "conflict"},
+	{apperrors.ErrValidation, "validation_error"},
+	{apperrors.ErrForeignKeyViolation, "foreign_key_violation"},
+	{apperrors.ErrReferenced, "still_referenced"},
+	{apperrors.ErrUnavailable, "service_unavailable"},
+	{context.DeadlineExceeded, "timeout"},
+	{context.Canceled, "client_closed_request"},
+}
+
+// codedError is an error that tells clients its code in the extensions of
+// the GraphQL error.
+type codedError struct {
+	err  error
+	code string
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedError) Unwrap() error {
+	return e.err
+}
+
+func (e *codedError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// resolveError returns err with the code of its kind. Errors without a kind
+// are internal errors.
+func resolveError(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, e := range errorCodes {
+		if errors.Is(err, e.kind) {
+			return &codedError{err: err, code: e.code}
+		}
+	}
+	return &codedError{err: err, code: "internal_server_error"}
+}
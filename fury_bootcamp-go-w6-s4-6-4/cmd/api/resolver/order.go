@@ -0,0 +1,82 @@
+package resolver
+
+import (
+	"context"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/graph"
+)
+
+type inboundOrderResolver struct {
+	o domain.Inbound_order
+}
+
+func (r *inboundOrderResolver) ID() int32             { return int32(r.o.ID) }
+func (r *inboundOrderResolver) OrderDate() string     { return r.o.Order_date }
+func (r *inboundOrderResolver) OrderNumber() string   { return r.o.Order_number }
+func (r *inboundOrderResolver) EmployeeID() int32     { return int32(r.o.Employee_id) }
+func (r *inboundOrderResolver) ProductBatchID() int32 { return int32(r.o.Product_batch_id) }
+func (r *inboundOrderResolver) WarehouseID() int32    { return int32(r.o.Warehouse_id) }
+func (r *inboundOrderResolver) Status() string        { return r.o.Status }
+
+func (r *inboundOrderResolver) Employee(ctx context.Context) (*employeeResolver, error) {
+	e, err := load(ctx, graph.FromContext(ctx).Employee, r.o.Employee_id)
+	if err != nil {
+		return nil, err
+	}
+	return &employeeResolver{e}, nil
+}
+
+// ProductBatch is null for orders with lines, which have no batch of their
+// own.
+func (r *inboundOrderResolver) ProductBatch(ctx context.Context) (*productBatchResolver, error) {
+	if r.o.Product_batch_id == 0 {
+		return nil, nil
+	}
+	pb, err := load(ctx, graph.FromContext(ctx).ProductBatch, r.o.Product_batch_id)
+	if err != nil {
+		return nil, err
+	}
+	return &productBatchResolver{pb}, nil
+}
+
+func (r *inboundOrderResolver) Warehouse(ctx context.Context) (*warehouseResolver, error) {
+	return loadWarehouse(ctx, r.o.Warehouse_id)
+}
+
+type buyerResolver struct {
+	b domain.Buyer
+}
+
+func (r *buyerResolver) ID() int32            { return int32(r.b.ID) }
+func (r *buyerResolver) CardNumberID() string { return r.b.CardNumberID }
+func (r *buyerResolver) FirstName() string    { return r.b.FirstName }
+func (r *buyerResolver) LastName() string     { return r.b.LastName }
+
+type purchaseOrderResolver struct {
+	o domain.PurchaseOrders
+}
+
+func (r *purchaseOrderResolver) ID() int32              { return int32(r.o.ID) }
+func (r *purchaseOrderResolver) OrderNumber() string    { return r.o.OrderNumber }
+func (r *purchaseOrderResolver) TrackingCode() string   { return r.o.TrackingCode }
+func (r *purchaseOrderResolver) BuyerID() int32         { return int32(r.o.BuyerID) }
+func (r *purchaseOrderResolver) ProductRecordID() int32 { return int32(r.o.ProductRecordID) }
+func (r *purchaseOrderResolver) OrderStatusID() int32   { return int32(r.o.OrderStatusID) }
+
+func (r *purchaseOrderResolver) OrderDate() *string {
+	if r.o.OrderDate == nil {
+		return nil
+	}
+	s := r.o.OrderDate.Format(time.RFC3339)
+	return &s
+}
+
+func (r *purchaseOrderResolver) Buyer(ctx context.Context) (*buyerResolver, error) {
+	b, err := load(ctx, graph.FromContext(ctx).Buyer, r.o.BuyerID)
+	if err != nil {
+		return nil, err
+	}
+	return &buyerResolver{b}, nil
+}
@@ -0,0 +1,79 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/graph"
+)
+
+type productResolver struct {
+	p domain.Product
+}
+
+func (r *productResolver) ID() int32             { return int32(r.p.ID) }
+func (r *productResolver) Description() string   { return r.p.Description }
+func (r *productResolver) ExpirationRate() int32 { return int32(r.p.ExpirationRate) }
+func (r *productResolver) FreezingRate() int32   { return int32(r.p.FreezingRate) }
+func (r *productResolver) Height() float64       { return float64(r.p.Height) }
+func (r *productResolver) Length() float64       { return float64(r.p.Length) }
+func (r *productResolver) Netweight() float64    { return float64(r.p.Netweight) }
+func (r *productResolver) ProductCode() string   { return r.p.ProductCode }
+func (r *productResolver) RecommendedFreezingTemperature() float64 {
+	return float64(r.p.RecomFreezTemp)
+}
+func (r *productResolver) Width() float64       { return float64(r.p.Width) }
+func (r *productResolver) ProductTypeID() int32 { return int32(r.p.ProductTypeID) }
+func (r *productResolver) SellerID() int32      { return int32(r.p.SellerID) }
+
+func (r *productResolver) Seller(ctx context.Context) (*sellerResolver, error) {
+	s, err := load(ctx, graph.FromContext(ctx).Seller, r.p.SellerID)
+	if err != nil {
+		return nil, err
+	}
+	return &sellerResolver{s}, nil
+}
+
+func (r *productResolver) Batches(ctx context.Context) ([]*productBatchResolver, error) {
+	batches, err := load(ctx, graph.FromContext(ctx).BatchesByProduct, r.p.ID)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*productBatchResolver, len(batches))
+	for i, pb := range batches {
+		resolvers[i] = &productBatchResolver{pb}
+	}
+	return resolvers, nil
+}
+
+type productBatchResolver struct {
+	pb domain.Product_batches
+}
+
+func (r *productBatchResolver) ID() int32                 { return int32(r.pb.ID) }
+func (r *productBatchResolver) BatchNumber() int32        { return int32(r.pb.BatchNumber) }
+func (r *productBatchResolver) CurrentQuantity() int32    { return int32(r.pb.CurrentQuantity) }
+func (r *productBatchResolver) CurrentTemperature() int32 { return int32(r.pb.CurrentTemperature) }
+func (r *productBatchResolver) DueDate() string           { return r.pb.DueDate }
+func (r *productBatchResolver) InitialQuantity() int32    { return int32(r.pb.InitialQuantity) }
+func (r *productBatchResolver) ManufacturingDate() string { return r.pb.ManufacturingDate }
+func (r *productBatchResolver) ManufacturingHour() int32  { return int32(r.pb.ManufacturingHour) }
+func (r *productBatchResolver) MinimumTemperature() int32 { return int32(r.pb.MinimumTemperature) }
+func (r *productBatchResolver) ProductID() int32          { return int32(r.pb.ProductId) }
+func (r *productBatchResolver) SectionID() int32          { return int32(r.pb.SectionId) }
+
+func (r *productBatchResolver) Product(ctx context.Context) (*productResolver, error) {
+	p, err := load(ctx, graph.FromContext(ctx).Product, r.pb.ProductId)
+	if err != nil {
+		return nil, err
+	}
+	return &productResolver{p}, nil
+}
+
+func (r *productBatchResolver) Section(ctx context.Context) (*sectionResolver, error) {
+	s, err := load(ctx, graph.FromContext(ctx).Section, r.pb.SectionId)
+	if err != nil {
+		return nil, err
+	}
+	return &sectionResolver{s}, nil
+}
@@ -0,0 +1,231 @@
+// Package resolver implements the GraphQL API on top of the same services
+// the HTTP handlers use. References between entities resolve through the
+// per-request loaders of package graph, so nested fields are batched.
+package resolver
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/graph-gophers/dataloader/v7"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/buyer"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/employee"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/graph"
+	inboundorder "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/inbound_order"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/product"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/section"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/seller"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/warehouse"
+)
+
+//go:embed schema.graphql
+var schema string
+
+// maxDepth bounds how deep a query nests, so a single request can't walk
+// the references back and forth indefinitely.
+const maxDepth = 8
+
+// Services are the services the root fields list entities with.
+type Services struct {
+	Sellers       seller.Service
+	Products      product.Service
+	Sections      section.Service
+	Warehouses    warehouse.Service
+	Employees     employee.Service
+	Buyers        buyer.Service
+	InboundOrders inboundorder.Service
+}
+
+// Resolver resolves the root fields of the schema.
+type Resolver struct {
+	services Services
+	repo     graph.Repository
+}
+
+func NewResolver(services Services, repo graph.Repository) *Resolver {
+	return &Resolver{
+		services: services,
+		repo:     repo,
+	}
+}
+
+// NewSchema parses the schema with r resolving it. Queries must run with
+// the loaders of package graph in their context.
+func NewSchema(r *Resolver) (*graphql.Schema, error) {
+	return graphql.ParseSchema(schema, r, graphql.MaxDepth(maxDepth))
+}
+
+type idArgs struct {
+	ID int32
+}
+
+func (r *Resolver) Sellers(ctx context.Context) ([]*sellerResolver, error) {
+	sellers, err := r.services.Sellers.GetAll(ctx)
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	l := graph.FromContext(ctx)
+	resolvers := make([]*sellerResolver, len(sellers))
+	for i, s := range sellers {
+		l.Seller.Prime(ctx, s.ID, s)
+		resolvers[i] = &sellerResolver{s}
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) Seller(ctx context.Context, args idArgs) (*sellerResolver, error) {
+	s, err := load(ctx, graph.FromContext(ctx).Seller, int(args.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &sellerResolver{s}, nil
+}
+
+func (r *Resolver) Products(ctx context.Context) ([]*productResolver, error) {
+	products, err := r.services.Products.GetAll(ctx)
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	l := graph.FromContext(ctx)
+	resolvers := make([]*productResolver, len(products))
+	for i, p := range products {
+		l.Product.Prime(ctx, p.ID, p)
+		resolvers[i] = &productResolver{p}
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) Product(ctx context.Context, args idArgs) (*productResolver, error) {
+	p, err := load(ctx, graph.FromContext(ctx).Product, int(args.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &productResolver{p}, nil
+}
+
+func (r *Resolver) ProductBatch(ctx context.Context, args idArgs) (*productBatchResolver, error) {
+	pb, err := load(ctx, graph.FromContext(ctx).ProductBatch, int(args.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &productBatchResolver{pb}, nil
+}
+
+func (r *Resolver) Sections(ctx context.Context) ([]*sectionResolver, error) {
+	sections, err := r.services.Sections.GetAll(ctx)
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	l := graph.FromContext(ctx)
+	resolvers := make([]*sectionResolver, len(sections))
+	for i, s := range sections {
+		l.Section.Prime(ctx, s.ID, s)
+		resolvers[i] = &sectionResolver{s}
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) Section(ctx context.Context, args idArgs) (*sectionResolver, error) {
+	s, err := load(ctx, graph.FromContext(ctx).Section, int(args.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &sectionResolver{s}, nil
+}
+
+func (r *Resolver) Warehouses(ctx context.Context) ([]*warehouseResolver, error) {
+	warehouses, err := r.services.Warehouses.GetAll(ctx)
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	l := graph.FromContext(ctx)
+	resolvers := make([]*warehouseResolver, len(warehouses))
+	for i, w := range warehouses {
+		l.Warehouse.Prime(ctx, w.ID, w)
+		resolvers[i] = &warehouseResolver{w}
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) Warehouse(ctx context.Context, args idArgs) (*warehouseResolver, error) {
+	w, err := load(ctx, graph.FromContext(ctx).Warehouse, int(args.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &warehouseResolver{w}, nil
+}
+
+func (r *Resolver) Employees(ctx context.Context) ([]*employeeResolver, error) {
+	employees, err := r.services.Employees.GetAllEmployees(ctx)
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	l := graph.FromContext(ctx)
+	resolvers := make([]*employeeResolver, len(employees))
+	for i, e := range employees {
+		l.Employee.Prime(ctx, e.ID, e)
+		resolvers[i] = &employeeResolver{e}
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) Employee(ctx context.Context, args idArgs) (*employeeResolver, error) {
+	e, err := load(ctx, graph.FromContext(ctx).Employee, int(args.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &employeeResolver{e}, nil
+}
+
+func (r *Resolver) Buyers(ctx context.Context) ([]*buyerResolver, error) {
+	buyers, err := r.services.Buyers.GetAll(ctx)
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	l := graph.FromContext(ctx)
+	resolvers := make([]*buyerResolver, len(buyers))
+	for i, b := range buyers {
+		l.Buyer.Prime(ctx, b.ID, b)
+		resolvers[i] = &buyerResolver{b}
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) Buyer(ctx context.Context, args idArgs) (*buyerResolver, error) {
+	b, err := load(ctx, graph.FromContext(ctx).Buyer, int(args.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &buyerResolver{b}, nil
+}
+
+func (r *Resolver) InboundOrders(ctx context.Context) ([]*inboundOrderResolver, error) {
+	orders, err := r.services.InboundOrders.GetAll_inboundOrders(ctx)
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	resolvers := make([]*inboundOrderResolver, len(orders))
+	for i, o := range orders {
+		resolvers[i] = &inboundOrderResolver{o}
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) PurchaseOrders(ctx context.Context) ([]*purchaseOrderResolver, error) {
+	orders, err := r.repo.PurchaseOrders(ctx)
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	resolvers := make([]*purchaseOrderResolver, len(orders))
+	for i, o := range orders {
+		resolvers[i] = &purchaseOrderResolver{o}
+	}
+	return resolvers, nil
+}
+
+// load waits for key in l, batched with the keys the sibling fields load.
+func load[V any](ctx context.Context, l *dataloader.Loader[int, V], key int) (V, error) {
+	v, err := l.Load(ctx, key)()
+	return v, resolveError(err)
+}
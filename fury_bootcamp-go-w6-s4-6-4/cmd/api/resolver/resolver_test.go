@@ -0,0 +1,155 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/graph"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/seller"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	sellermock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/sellers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRepository answers the lookups from memory and counts the queries
+// each of them would have made.
+type fakeRepository struct {
+	graph.Repository
+	localities []domain.Locality
+	sellers    []domain.Seller
+	products   []domain.Product
+	batches    []domain.Product_batches
+	sections   []domain.Section
+	warehouses []domain.Warehouse
+
+	mu      sync.Mutex
+	queries map[string]int
+}
+
+func (r *fakeRepository) count(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.queries[name]++
+}
+
+func (r *fakeRepository) Localities(_ context.Context, ids []int) ([]domain.Locality, error) {
+	r.count("Localities")
+	return matching(r.localities, ids, func(l domain.Locality) int { return l.ID }), nil
+}
+
+func (r *fakeRepository) Sellers(_ context.Context, ids []int) ([]domain.Seller, error) {
+	r.count("Sellers")
+	return matching(r.sellers, ids, func(s domain.Seller) int { return s.ID }), nil
+}
+
+func (r *fakeRepository) ProductsBySeller(_ context.Context, ids []int) ([]domain.Product, error) {
+	r.count("ProductsBySeller")
+	return matching(r.products, ids, func(p domain.Product) int { return p.SellerID }), nil
+}
+
+func (r *fakeRepository) ProductBatchesByProduct(_ context.Context, ids []int) ([]domain.Product_batches, error) {
+	r.count("ProductBatchesByProduct")
+	return matching(r.batches, ids, func(pb domain.Product_batches) int { return pb.ProductId }), nil
+}
+
+func (r *fakeRepository) Sections(_ context.Context, ids []int) ([]domain.Section, error) {
+	r.count("Sections")
+	return matching(r.sections, ids, func(s domain.Section) int { return s.ID }), nil
+}
+
+func (r *fakeRepository) Warehouses(_ context.Context, ids []int) ([]domain.Warehouse, error) {
+	r.count("Warehouses")
+	return matching(r.warehouses, ids, func(w domain.Warehouse) int { return w.ID }), nil
+}
+
+func matching[V any](items []V, keys []int, key func(V) int) []V {
+	var found []V
+	for _, item := range items {
+		for _, k := range keys {
+			if key(item) == k {
+				found = append(found, item)
+			}
+		}
+	}
+	return found
+}
+
+func TestSchema(t *testing.T) {
+	sellers := []domain.Seller{
+		{ID: 1, CompanyName: "Meli", LocalityID: 1},
+		{ID: 2, CompanyName: "Libre", LocalityID: 2},
+	}
+	repo := &fakeRepository{
+		localities: []domain.Locality{{ID: 1, LocalityName: "Palermo"}, {ID: 2, LocalityName: "Belgrano"}},
+		sellers:    sellers,
+		products:   []domain.Product{{ID: 1, ProductCode: "P-1", SellerID: 1}, {ID: 2, ProductCode: "P-2", SellerID: 1}, {ID: 3, ProductCode: "P-3", SellerID: 2}},
+		batches:    []domain.Product_batches{{ID: 1, ProductId: 1, SectionId: 1}, {ID: 2, ProductId: 2, SectionId: 2}, {ID: 3, ProductId: 3, SectionId: 1}},
+		sections:   []domain.Section{{ID: 1, SectionNumber: 10, WarehouseID: 1}, {ID: 2, SectionNumber: 20, WarehouseID: 1}},
+		warehouses: []domain.Warehouse{{ID: 1, WarehouseCode: "W-1"}},
+		queries:    map[string]int{},
+	}
+	services := Services{Sellers: seller.NewService(&sellermock.MockRepository{DataMock: sellers}, events.Discard, logger.Discard())}
+	schema, err := NewSchema(NewResolver(services, repo))
+	require.NoError(t, err)
+
+	exec := func(query string) (map[string]interface{}, []map[string]interface{}) {
+		ctx := graph.NewContext(context.Background(), graph.NewLoaders(repo))
+		resp := schema.Exec(ctx, query, "", nil)
+		raw, err := json.Marshal(resp)
+		require.NoError(t, err)
+		var out struct {
+			Data   map[string]interface{}
+			Errors []map[string]interface{}
+		}
+		require.NoError(t, json.Unmarshal(raw, &out))
+		return out.Data, out.Errors
+	}
+
+	t.Run("should resolve nested references with one query per level", func(t *testing.T) {
+		repo.queries = map[string]int{}
+
+		data, errs := exec(`{ sellers { companyName locality { localityName } products { productCode batches { id section { sectionNumber warehouse { warehouseCode } } } } } }`)
+
+		require.Empty(t, errs)
+		got, err := json.Marshal(data)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"sellers": [
+			{"companyName": "Meli", "locality": {"localityName": "Palermo"}, "products": [
+				{"productCode": "P-1", "batches": [{"id": 1, "section": {"sectionNumber": 10, "warehouse": {"warehouseCode": "W-1"}}}]},
+				{"productCode": "P-2", "batches": [{"id": 2, "section": {"sectionNumber": 20, "warehouse": {"warehouseCode": "W-1"}}}]}
+			]},
+			{"companyName": "Libre", "locality": {"localityName": "Belgrano"}, "products": [
+				{"productCode": "P-3", "batches": [{"id": 3, "section": {"sectionNumber": 10, "warehouse": {"warehouseCode": "W-1"}}}]}
+			]}
+		]}`, string(got))
+		assert.Equal(t, map[string]int{
+			"Localities": 1, "ProductsBySeller": 1, "ProductBatchesByProduct": 1, "Sections": 1, "Warehouses": 1,
+		}, repo.queries)
+	})
+	t.Run("should reuse the sellers the list loaded", func(t *testing.T) {
+		repo.queries = map[string]int{}
+
+		_, errs := exec(`{ sellers { products { seller { companyName } } } }`)
+
+		require.Empty(t, errs)
+		assert.Zero(t, repo.queries["Sellers"])
+	})
+	t.Run("should report a missing entity with its code", func(t *testing.T) {
+		data, errs := exec(`{ seller(id: 9) { companyName } }`)
+
+		assert.Nil(t, data["seller"])
+		require.Len(t, errs, 1)
+		assert.Equal(t, "seller 9 not found", errs[0]["message"])
+		assert.Equal(t, map[string]interface{}{"code": "not_found"}, errs[0]["extensions"])
+	})
+	t.Run("should reject queries nested too deep", func(t *testing.T) {
+		_, errs := exec(`{ sellers { products { seller { products { seller { products { seller { products { id } } } } } } } } }`)
+
+		assert.NotEmpty(t, errs)
+	})
+}
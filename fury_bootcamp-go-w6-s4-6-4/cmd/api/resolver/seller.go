@@ -0,0 +1,49 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/graph"
+)
+
+type localityResolver struct {
+	l domain.Locality
+}
+
+func (r *localityResolver) ID() int32            { return int32(r.l.ID) }
+func (r *localityResolver) LocalityName() string { return r.l.LocalityName }
+func (r *localityResolver) ProvinceID() int32    { return int32(r.l.ProvinceID) }
+func (r *localityResolver) ProvinceName() string { return r.l.ProvinceName }
+func (r *localityResolver) CountryName() string  { return r.l.CountryName }
+
+type sellerResolver struct {
+	s domain.Seller
+}
+
+func (r *sellerResolver) ID() int32           { return int32(r.s.ID) }
+func (r *sellerResolver) Cid() int32          { return int32(r.s.CID) }
+func (r *sellerResolver) CompanyName() string { return r.s.CompanyName }
+func (r *sellerResolver) Address() string     { return r.s.Address }
+func (r *sellerResolver) Telephone() string   { return r.s.Telephone }
+func (r *sellerResolver) LocalityID() int32   { return int32(r.s.LocalityID) }
+
+func (r *sellerResolver) Locality(ctx context.Context) (*localityResolver, error) {
+	l, err := load(ctx, graph.FromContext(ctx).Locality, r.s.LocalityID)
+	if err != nil {
+		return nil, err
+	}
+	return &localityResolver{l}, nil
+}
+
+func (r *sellerResolver) Products(ctx context.Context) ([]*productResolver, error) {
+	products, err := load(ctx, graph.FromContext(ctx).ProductsBySeller, r.s.ID)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*productResolver, len(products))
+	for i, p := range products {
+		resolvers[i] = &productResolver{p}
+	}
+	return resolvers, nil
+}
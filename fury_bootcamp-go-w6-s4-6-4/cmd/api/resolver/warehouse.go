@@ -0,0 +1,73 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/graph"
+)
+
+type sectionResolver struct {
+	s domain.Section
+}
+
+func (r *sectionResolver) ID() int32                 { return int32(r.s.ID) }
+func (r *sectionResolver) SectionNumber() int32      { return int32(r.s.SectionNumber) }
+func (r *sectionResolver) CurrentTemperature() int32 { return int32(r.s.CurrentTemperature) }
+func (r *sectionResolver) MinimumTemperature() int32 { return int32(r.s.MinimumTemperature) }
+func (r *sectionResolver) CurrentCapacity() int32    { return int32(r.s.CurrentCapacity) }
+func (r *sectionResolver) MinimumCapacity() int32    { return int32(r.s.MinimumCapacity) }
+func (r *sectionResolver) MaximumCapacity() int32    { return int32(r.s.MaximumCapacity) }
+func (r *sectionResolver) ProductTypeID() int32      { return int32(r.s.ProductTypeID) }
+func (r *sectionResolver) WarehouseID() int32        { return int32(r.s.WarehouseID) }
+
+func (r *sectionResolver) Warehouse(ctx context.Context) (*warehouseResolver, error) {
+	return loadWarehouse(ctx, r.s.WarehouseID)
+}
+
+type warehouseResolver struct {
+	w domain.Warehouse
+}
+
+func (r *warehouseResolver) ID() int32             { return int32(r.w.ID) }
+func (r *warehouseResolver) Address() string       { return r.w.Address }
+func (r *warehouseResolver) Telephone() string     { return r.w.Telephone }
+func (r *warehouseResolver) WarehouseCode() string { return r.w.WarehouseCode }
+
+func (r *warehouseResolver) MinimumCapacity() *int32 {
+	return int32Ptr(r.w.MinimumCapacity)
+}
+
+func (r *warehouseResolver) MinimumTemperature() *int32 {
+	return int32Ptr(r.w.MinimumTemperature)
+}
+
+type employeeResolver struct {
+	e domain.Employee
+}
+
+func (r *employeeResolver) ID() int32            { return int32(r.e.ID) }
+func (r *employeeResolver) CardNumberID() string { return r.e.CardNumberID }
+func (r *employeeResolver) FirstName() string    { return r.e.FirstName }
+func (r *employeeResolver) LastName() string     { return r.e.LastName }
+func (r *employeeResolver) WarehouseID() int32   { return int32(r.e.WarehouseID) }
+
+func (r *employeeResolver) Warehouse(ctx context.Context) (*warehouseResolver, error) {
+	return loadWarehouse(ctx, r.e.WarehouseID)
+}
+
+func loadWarehouse(ctx context.Context, id int) (*warehouseResolver, error) {
+	w, err := load(ctx, graph.FromContext(ctx).Warehouse, id)
+	if err != nil {
+		return nil, err
+	}
+	return &warehouseResolver{w}, nil
+}
+
+func int32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	i := int32(*v)
+	return &i
+}
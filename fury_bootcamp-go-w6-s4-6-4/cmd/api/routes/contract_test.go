@@ -0,0 +1,567 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/docs"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/config"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/openapi"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixture answers the queries containing match with rows, only when their
+// first argument is arg if it's set. A fixture without rows makes existence
+// checks fail, which is what creating something new needs.
+type fixture struct {
+	match string
+	arg   driver.Value
+	rows  [][]driver.Value
+}
+
+// fixtures are checked in order, so the more specific ones come first.
+var fixtures = []fixture{
+	{match: "information_schema.tables", rows: tableRows()},
+	{match: "FROM schema_migrations", rows: [][]driver.Value{{schemaVersion}}},
+
+	// What the EDI documents name by code, and what they're written from.
+	{match: "SELECT id FROM seller WHERE cid", rows: [][]driver.Value{{1}}},
+	{match: "SELECT id FROM warehouses WHERE warehouse_code", rows: [][]driver.Value{{1}}},
+	{match: "SELECT id FROM products WHERE product_code", rows: [][]driver.Value{{1}}},
+	{match: "COALESCE(w.warehouse_code, '')", rows: [][]driver.Value{{"IO-1", "2024-01-02", "closed", "W-1", "ASN-1"}}},
+	{match: "SELECT p.product_code, COALESCE(l.batch_number", rows: [][]driver.Value{{"P-1", "5", 10}}},
+	{match: "SELECT DISTINCT s.cid", rows: [][]driver.Value{{101, "Meli"}}},
+	{match: "b.last_name, p.product_code", rows: [][]driver.Value{{"PO-1", "2024-01-02", "T-1", "B-1", "Juan", "Perez", "P-1"}}},
+	{match: "FROM carries WHERE id=?", rows: [][]driver.Value{{"CA-1", "Andreani"}}},
+
+	{match: "SELECT cid FROM seller"},
+	{match: "SELECT id, site_id, cid", rows: [][]driver.Value{{1, "MLA", 101, "Meli", "Street 1", "555-0001", 1}}},
+	{match: "SELECT id FROM locality", arg: int64(1), rows: [][]driver.Value{{1}}},
+	{match: "WHERE l.id =?", rows: [][]driver.Value{{1, "MLA", "Palermo", 1, "Buenos Aires", "Argentina"}}},
+	{match: "SELECT id FROM provinces WHERE country_id"},
+	{match: "SELECT id FROM provinces", arg: int64(1), rows: [][]driver.Value{{1}}},
+	{match: "SELECT id, site_id, province_name", rows: [][]driver.Value{{1, "MLA", "Buenos Aires", 1}}},
+	{match: "FROM provinces p WHERE", rows: [][]driver.Value{{1, "Buenos Aires", 1, 1}}},
+	{match: "SELECT id FROM countries", arg: int64(1), rows: [][]driver.Value{{1}}},
+	{match: "SELECT id, site_id, country_name", rows: [][]driver.Value{{1, "MLA", "Argentina"}}},
+	{match: "FROM countries c WHERE", rows: [][]driver.Value{{1, "Argentina", 1, 1}}},
+	{match: "FROM seller s INNER JOIN locality", rows: [][]driver.Value{{1, "Palermo", 2}}},
+	{match: "FROM carries RIGHT JOIN locality", rows: [][]driver.Value{{1, "Palermo", 3}}},
+	{match: "SELECT cid FROM carries"},
+
+	{match: "SELECT product_code FROM products"},
+	{match: "SELECT id, site_id, description", rows: [][]driver.Value{{1, "MLA", "Yogurt", 1, 2, 1.5, 2.5, 0.5, "P-1", -5.0, 3.0, 1, 1}}},
+	{match: "FROM products p LEFT JOIN product_records", rows: [][]driver.Value{{1, "Yogurt", 2}}},
+	{match: "FROM product_records pr"},
+	{match: "SELECT p.id FROM products p", rows: [][]driver.Value{{1}}},
+
+	{match: "SELECT warehouse_code FROM warehouses"},
+	{match: "SELECT id, site_id, address", rows: [][]driver.Value{{1, "MLA", "Street 2", "555-0002", "W-1", 10, 5}}},
+
+	{match: "SELECT section_number FROM sections"},
+	{match: "SELECT id, site_id, section_number", rows: [][]driver.Value{{1, "MLA", 10, 5, 0, 20, 10, 50, 1, 1}}},
+
+	{match: "SELECT sections_id FROM melisprint.product_batches", rows: [][]driver.Value{{1}}},
+	{match: "SELECT products_id FROM melisprint.product_batches", rows: [][]driver.Value{{1}}},
+	{match: "SELECT batch_number FROM melisprint.product_batches"},
+	{match: "SELECT warehouse_id FROM sections", rows: [][]driver.Value{{1}}},
+	{match: "SELECT p.sections_id, s.section_number", rows: [][]driver.Value{{1, 10, 50}}},
+
+	{match: "SELECT card_number_id FROM employees"},
+	{match: "count(inbo.employee_id)", rows: [][]driver.Value{{1, "E-1", "Ana", "Diaz", 1, 3}}},
+	{match: "SELECT id FROM employees", rows: [][]driver.Value{{1}}},
+	{match: "SELECT warehouse_id FROM employees", rows: [][]driver.Value{{1}}},
+	{match: "FROM employees", rows: [][]driver.Value{{1, "MLA", "E-1", "Ana", "Diaz", 1}}},
+	{match: "SELECT order_number FROM inbound_orders"},
+
+	// Count 1 is open and count 2 pending approval, both of batch 1.
+	{match: "SELECT status FROM cycle_counts", arg: int64(1), rows: [][]driver.Value{{"open"}}},
+	{match: "SELECT status FROM cycle_counts", arg: int64(2), rows: [][]driver.Value{{"pending_approval"}}},
+	{match: "FROM cycle_counts WHERE id", arg: int64(1), rows: [][]driver.Value{{1, "MLA", 1, 1, 1, "all", "", "open", "2024-01-12", ""}}},
+	{match: "FROM cycle_counts WHERE id", arg: int64(2), rows: [][]driver.Value{{2, "MLA", 1, 1, 1, "abc", "A", "pending_approval", "2024-01-12", ""}}},
+	{match: "FROM cycle_counts", rows: [][]driver.Value{{1, "MLA", 1, 1, 1, "all", "", "open", "2024-01-12", ""}}},
+	{match: "SELECT cycle_count_id", rows: [][]driver.Value{{1, 1, 1, 1, nil, nil, nil, ""}}},
+	{match: "FROM cycle_count_lines", arg: int64(2), rows: [][]driver.Value{{2, 1, 1, 10, 25, 15, "found"}}},
+	{match: "FROM cycle_count_lines", rows: [][]driver.Value{{1, 1, 1, nil, nil, nil, ""}}},
+	{match: "FROM product_batches WHERE sections_id", rows: [][]driver.Value{{1, 1, 10}}},
+	{match: "SELECT COALESCE(current_quantity, 0) FROM product_batches", rows: [][]driver.Value{{10}}},
+	{match: "SELECT id FROM warehouses", arg: int64(1), rows: [][]driver.Value{{1}}},
+	{match: "FROM product_batches pb JOIN sections", arg: int64(1), rows: [][]driver.Value{{1}}},
+	{match: "FROM inbound_orders io", rows: [][]driver.Value{{"2024-01-01", 1, 3}}},
+	{match: "FROM inbound_orders", rows: [][]driver.Value{{1, "MLA", "2024-01-02", "IO-1", 1, 1, 1, "closed"}}},
+	{match: "FROM inbound_order_lines l JOIN", rows: [][]driver.Value{{1, 1, 10, 4, 6, 0}}},
+	{match: "FROM inbound_order_lines", rows: [][]driver.Value{{1, 1, 0, 10, 4}}},
+	{match: "SELECT id FROM products WHERE id", arg: int64(1), rows: [][]driver.Value{{1}}},
+	{match: "SELECT products_id FROM product_batches", arg: int64(1), rows: [][]driver.Value{{1}}},
+	{match: "SELECT sections_id FROM product_batches", arg: int64(1), rows: [][]driver.Value{{1}}},
+
+	{match: "FROM asns a WHERE", rows: [][]driver.Value{{1, "ASN-1", 1, 1, "2024-01-10", 5, 10}}},
+	{match: "SELECT id FROM asns"},
+	{match: "SELECT status FROM asns", rows: [][]driver.Value{{"pending"}}},
+	{match: "FROM asns", rows: [][]driver.Value{{1, "MLA", "ASN-1", 1, 1, "2024-01-10", "pending", 0}}},
+	{match: "SELECT asn_id", rows: [][]driver.Value{{1, 1, 1, 10, 5, "2024-01-01", "2024-06-01", 0}}},
+	{match: "FROM asn_lines", rows: [][]driver.Value{{1, 1, 10, 5, "2024-01-01", "2024-06-01", 0}}},
+	{match: "SELECT id FROM seller WHERE", arg: int64(1), rows: [][]driver.Value{{1}}},
+	{match: "SELECT seller_id FROM products", arg: int64(1), rows: [][]driver.Value{{1}}},
+
+	{match: "SELECT card_number_id FROM buyers"},
+	{match: "SELECT id FROM buyers", rows: [][]driver.Value{{1}}},
+	{match: "SELECT id, site_id, card_number_id, first_name, last_name FROM buyers", rows: [][]driver.Value{{1, "MLA", "B-1", "Juan", "Perez"}}},
+	{match: "purchase_orders_count", rows: [][]driver.Value{{1, "B-1", "Juan", "Perez", 2}}},
+	{match: "SELECT id FROM purchase_orders", rows: [][]driver.Value{{1}}},
+
+	{match: "l.site_id = COALESCE(?, l.site_id) AND l.id IN", rows: [][]driver.Value{{1, "Palermo", 1, "Buenos Aires", "Argentina"}}},
+	{match: "FROM products WHERE site_id = COALESCE(?, site_id) AND seller_id IN", rows: [][]driver.Value{{1, "Yogurt", 1, 2, 1.5, 2.5, 0.5, "P-1", -5.0, 3.0, 1, 1}}},
+	{match: "FROM product_batches WHERE site_id = COALESCE(?, site_id) AND products_id IN", rows: [][]driver.Value{{1, 7, 10, 5, "2024-03-01 00:00:00", 10, "2024-01-01", 8, 0, 1, 1}}},
+	{match: "FROM sections WHERE site_id = COALESCE(?, site_id) AND id IN", rows: [][]driver.Value{{1, 10, 5, 0, 20, 10, 50, 1, 1}}},
+	{match: "FROM warehouses WHERE site_id = COALESCE(?, site_id) AND id IN", rows: [][]driver.Value{{1, "Street 2", "555-0002", "W-1", 10, 5}}},
+
+	{match: "COALESCE(MAX(id), 0) FROM outbox_events", rows: [][]driver.Value{{0}}},
+
+	{match: "FROM webhook_subscriptions WHERE", rows: [][]driver.Value{{1, nil, "http://localhost:9999/hooks", "0123456789abcdef", "seller.created", "2026-10-19 12:00:00.000"}}},
+	{match: "WHERE (? = '' OR d.status", rows: [][]driver.Value{
+		{1, 1, "seller.created", 1, "http://localhost:9999/hooks", "dead", 8, "2026-10-19 13:00:00.000", 500, "subscriber answered 500 Internal Server Error", nil},
+	}},
+	{match: "WHERE d.id=?", rows: [][]driver.Value{
+		{1, 1, "seller.created", 1, "http://localhost:9999/hooks", "dead", 8, "2026-10-19 13:00:00.000", 500, "subscriber answered 500 Internal Server Error", nil},
+	}},
+}
+
+func tableRows() [][]driver.Value {
+	rows := make([][]driver.Value, len(tables))
+	for i, t := range tables {
+		rows[i] = []driver.Value{t}
+	}
+	return rows
+}
+
+// fixtureConnector opens connections whose queries are answered by fixtures
+// and whose statements all insert id 1 and affect one row.
+type fixtureConnector struct{}
+
+func (fixtureConnector) Connect(context.Context) (driver.Conn, error) { return fixtureConn{}, nil }
+func (fixtureConnector) Driver() driver.Driver                        { return fixtureDriver{} }
+
+type fixtureDriver struct{}
+
+func (fixtureDriver) Open(string) (driver.Conn, error) { return fixtureConn{}, nil }
+
+type fixtureConn struct{}
+
+func (fixtureConn) Prepare(query string) (driver.Stmt, error) { return fixtureStmt{query: query}, nil }
+func (fixtureConn) Close() error                              { return nil }
+func (fixtureConn) Begin() (driver.Tx, error)                 { return fixtureTx{}, nil }
+
+type fixtureTx struct{}
+
+func (fixtureTx) Commit() error   { return nil }
+func (fixtureTx) Rollback() error { return nil }
+
+type fixtureStmt struct {
+	query string
+}
+
+func (fixtureStmt) Close() error  { return nil }
+func (fixtureStmt) NumInput() int { return -1 }
+
+func (fixtureStmt) Exec([]driver.Value) (driver.Result, error) { return fixtureResult{}, nil }
+
+func (s fixtureStmt) Query(args []driver.Value) (driver.Rows, error) {
+	for _, f := range fixtures {
+		if f.arg != nil && (len(args) == 0 || args[0] != f.arg) {
+			continue
+		}
+		if strings.Contains(s.query, f.match) {
+			return &fixtureRows{rows: f.rows}, nil
+		}
+	}
+	return &fixtureRows{}, nil
+}
+
+type fixtureResult struct{}
+
+func (fixtureResult) LastInsertId() (int64, error) { return 1, nil }
+func (fixtureResult) RowsAffected() (int64, error) { return 1, nil }
+
+type fixtureRows struct {
+	rows [][]driver.Value
+	next int
+}
+
+func (r *fixtureRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+	return make([]string, len(r.rows[0]))
+}
+
+func (r *fixtureRows) Close() error { return nil }
+
+func (r *fixtureRows) Next(dest []driver.Value) error {
+	if r.next == len(r.rows) {
+		return io.EOF
+	}
+	for i, v := range r.rows[r.next] {
+		if n, ok := v.(int); ok {
+			v = int64(n)
+		}
+		dest[i] = v
+	}
+	r.next++
+	return nil
+}
+
+// TestContract serves a request to every route and checks the response
+// against the OpenAPI description, so the two can't drift apart.
+func TestContract(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	spec, err := openapi.Load(docs.OpenAPI)
+	require.NoError(t, err)
+
+	db := sql.OpenDB(fixtureConnector{})
+	defer db.Close()
+	eng := gin.New()
+	r := NewRouter(eng, database.NewRouter(db, nil, logger.Discard()), logger.Discard(), config.Default())
+	require.NoError(t, r.MapRoutes())
+	defer r.Shutdown()
+
+	// Each client comes from an address of its own, so they don't share
+	// rate limits.
+	addrs := map[string]string{}
+	addr := func(client string) string {
+		if _, ok := addrs[client]; !ok {
+			addrs[client] = fmt.Sprintf("10.0.%d.%d:1234", len(addrs)/256, len(addrs)%256)
+		}
+		return addrs[client]
+	}
+	serve := func(method, target, body, client string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.RemoteAddr = addr(client)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		rr := httptest.NewRecorder()
+		eng.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// The readiness probe fails until the metrics have been refreshed once.
+	require.Eventually(t, func() bool {
+		return serve(http.MethodGet, "/health/ready", "", "probe").Code == http.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cases := []struct {
+		name   string
+		method string
+		route  string
+		target string
+		body   string
+		status int
+	}{
+		{name: "ping", method: http.MethodGet, route: "/ping", status: http.StatusOK},
+		{name: "live", method: http.MethodGet, route: "/health/live", status: http.StatusOK},
+		{name: "ready", method: http.MethodGet, route: "/health/ready", status: http.StatusOK},
+		{name: "metrics", method: http.MethodGet, route: "/metrics", status: http.StatusOK},
+		{name: "openapi", method: http.MethodGet, route: "/openapi.yaml", status: http.StatusOK},
+
+		{name: "list sellers", method: http.MethodGet, route: "/api/v1/sellers", status: http.StatusOK},
+		{name: "get seller", method: http.MethodGet, route: "/api/v1/sellers/:id", target: "/api/v1/sellers/1", status: http.StatusOK},
+		{name: "get seller with a bad id", method: http.MethodGet, route: "/api/v1/sellers/:id", target: "/api/v1/sellers/one", status: http.StatusBadRequest},
+		{name: "create seller", method: http.MethodPost, route: "/api/v1/sellers",
+			body: `{"cid":102,"company_name":"Meli","address":"Street 1","telephone":"555-0001","locality_id":1}`, status: http.StatusCreated},
+		{name: "create seller without a cid", method: http.MethodPost, route: "/api/v1/sellers",
+			body: `{"company_name":"Meli","address":"Street 1","telephone":"555-0001","locality_id":1}`, status: http.StatusUnprocessableEntity},
+		{name: "update seller", method: http.MethodPatch, route: "/api/v1/sellers/:id", target: "/api/v1/sellers/1",
+			body: `{"address":"Street 3"}`, status: http.StatusOK},
+		{name: "delete seller", method: http.MethodDelete, route: "/api/v1/sellers/:id", target: "/api/v1/sellers/1", status: http.StatusOK},
+
+		{name: "create locality", method: http.MethodPost, route: "/api/v1/localities",
+			body: `{"locality_id":2,"locality_name":"Belgrano","province_id":1}`, status: http.StatusCreated},
+		{name: "create locality in an unknown province", method: http.MethodPost, route: "/api/v1/localities",
+			body: `{"locality_name":"Belgrano","province_id":9}`, status: http.StatusConflict},
+		{name: "report sellers", method: http.MethodGet, route: "/api/v1/localities/reportSellers", status: http.StatusOK},
+		{name: "report carries", method: http.MethodGet, route: "/api/v1/localities/reportCarries", status: http.StatusOK},
+		{name: "list countries", method: http.MethodGet, route: "/api/v1/countries", status: http.StatusOK},
+		{name: "get country", method: http.MethodGet, route: "/api/v1/countries/:id", target: "/api/v1/countries/1", status: http.StatusOK},
+		{name: "create country", method: http.MethodPost, route: "/api/v1/countries", body: `{"country_name":"Uruguay"}`, status: http.StatusCreated},
+		{name: "create country without a name", method: http.MethodPost, route: "/api/v1/countries", body: `{"id":858}`, status: http.StatusUnprocessableEntity},
+		{name: "update country", method: http.MethodPatch, route: "/api/v1/countries/:id", target: "/api/v1/countries/1",
+			body: `{"country_name":"República Argentina"}`, status: http.StatusOK},
+		{name: "delete country", method: http.MethodDelete, route: "/api/v1/countries/:id", target: "/api/v1/countries/1", status: http.StatusNoContent},
+		{name: "report countries", method: http.MethodGet, route: "/api/v1/countries/report", status: http.StatusOK},
+		{name: "list provinces", method: http.MethodGet, route: "/api/v1/provinces", status: http.StatusOK},
+		{name: "get province", method: http.MethodGet, route: "/api/v1/provinces/:id", target: "/api/v1/provinces/1", status: http.StatusOK},
+		{name: "create province", method: http.MethodPost, route: "/api/v1/provinces",
+			body: `{"province_name":"Córdoba","country_id":1}`, status: http.StatusCreated},
+		{name: "create province in an unknown country", method: http.MethodPost, route: "/api/v1/provinces",
+			body: `{"province_name":"Córdoba","country_id":9}`, status: http.StatusConflict},
+		{name: "update province", method: http.MethodPatch, route: "/api/v1/provinces/:id", target: "/api/v1/provinces/1",
+			body: `{"province_name":"Provincia de Buenos Aires"}`, status: http.StatusOK},
+		{name: "delete province", method: http.MethodDelete, route: "/api/v1/provinces/:id", target: "/api/v1/provinces/1", status: http.StatusNoContent},
+		{name: "report provinces", method: http.MethodGet, route: "/api/v1/provinces/report", target: "/api/v1/provinces/report?id=1", status: http.StatusOK},
+		{name: "create carry", method: http.MethodPost, route: "/api/v1/carries",
+			body: `{"cid":"C-1","company_name":"Fast","address":"Street 4","telephone":"555-0003","locality_id":1}`, status: http.StatusCreated},
+
+		{name: "list products", method: http.MethodGet, route: "/api/v1/products/", status: http.StatusOK},
+		{name: "get product", method: http.MethodGet, route: "/api/v1/products/:id", target: "/api/v1/products/1", status: http.StatusOK},
+		{name: "create product", method: http.MethodPost, route: "/api/v1/products/",
+			body: `{"description":"Milk","expiration_rate":1,"freezing_rate":2,"height":1.5,"length":2.5,"netweight":0.5,` +
+				`"product_code":"P-2","recommended_freezing_temperature":-5,"width":3,"product_type_id":1,"seller_id":1}`, status: http.StatusCreated},
+		{name: "update product", method: http.MethodPatch, route: "/api/v1/products/:id", target: "/api/v1/products/1",
+			body: `{"description":"Skim yogurt"}`, status: http.StatusOK},
+		{name: "delete product", method: http.MethodDelete, route: "/api/v1/products/:id", target: "/api/v1/products/1", status: http.StatusNoContent},
+		{name: "report records", method: http.MethodGet, route: "/api/v1/products/reportRecords", status: http.StatusOK},
+		{name: "create product record", method: http.MethodPost, route: "/api/v1/productRecords",
+			body: `{"last_update_date":"2024-01-02","purchase_price":10,"sale_price":15,"products_id":1}`, status: http.StatusOK},
+
+		{name: "list warehouses", method: http.MethodGet, route: "/api/v1/warehouses", status: http.StatusOK},
+		{name: "get warehouse", method: http.MethodGet, route: "/api/v1/warehouses/:id", target: "/api/v1/warehouses/1", status: http.StatusOK},
+		{name: "create warehouse", method: http.MethodPost, route: "/api/v1/warehouses",
+			body: `{"address":"Street 2","telephone":"555-0002","warehouse_code":"W-2","minimum_capacity":10,"minimum_temperature":5}`, status: http.StatusCreated},
+		{name: "create warehouse too hot", method: http.MethodPost, route: "/api/v1/warehouses",
+			body: `{"address":"Street 2","telephone":"555-0002","warehouse_code":"W-2","minimum_capacity":10,"minimum_temperature":50}`, status: http.StatusUnprocessableEntity},
+		{name: "update warehouse", method: http.MethodPatch, route: "/api/v1/warehouses/:id", target: "/api/v1/warehouses/1",
+			body: `{"telephone":"555-0004"}`, status: http.StatusOK},
+		{name: "delete warehouse", method: http.MethodDelete, route: "/api/v1/warehouses/:id", target: "/api/v1/warehouses/1", status: http.StatusNoContent},
+		{name: "stream the events of an unknown warehouse", method: http.MethodGet, route: "/api/v1/warehouses/:id/events",
+			target: "/api/v1/warehouses/9/events", status: http.StatusNotFound},
+
+		{name: "list sections", method: http.MethodGet, route: "/api/v1/sections", status: http.StatusOK},
+		{name: "get section", method: http.MethodGet, route: "/api/v1/sections/:id", target: "/api/v1/sections/1", status: http.StatusOK},
+		{name: "create section", method: http.MethodPost, route: "/api/v1/sections",
+			body: `{"section_number":11,"current_temperature":5,"minimum_temperature":0,"current_capacity":20,` +
+				`"minimum_capacity":10,"maximum_capacity":50,"warehouse_id":1,"product_type_id":1}`, status: http.StatusCreated},
+		{name: "update section", method: http.MethodPatch, route: "/api/v1/sections/:id", target: "/api/v1/sections/1",
+			body: `{"current_capacity":30}`, status: http.StatusOK},
+		{name: "delete section", method: http.MethodDelete, route: "/api/v1/sections/:id", target: "/api/v1/sections/1", status: http.StatusNoContent},
+
+		{name: "create product batch", method: http.MethodPost, route: "/api/v1/productbatches",
+			body: `{"section_number":7,"current_quantity":10,"current_temperature":5,"due_date":"2024-03-01","initial_quantity":10,` +
+				`"manufacturing_date":"2024-01-01","manufacturing_hour":8,"minimum_temperature":0,"product_id":1,"section_id":1}`, status: http.StatusCreated},
+		{name: "create product batch with a bad date", method: http.MethodPost, route: "/api/v1/productbatches",
+			body: `{"section_number":7,"due_date":"March","manufacturing_date":"2024-01-01","product_id":1,"section_id":1}`, status: http.StatusUnprocessableEntity},
+		{name: "report products", method: http.MethodGet, route: "/api/v1/reportProducts/", target: "/api/v1/reportProducts/?id=1", status: http.StatusOK},
+
+		{name: "list employees", method: http.MethodGet, route: "/api/v1/employees", status: http.StatusOK},
+		{name: "get employee", method: http.MethodGet, route: "/api/v1/employees/:id", target: "/api/v1/employees/1", status: http.StatusOK},
+		{name: "create employee", method: http.MethodPost, route: "/api/v1/employees",
+			body: `{"card_number_id":"E-2","first_name":"Ana","last_name":"Diaz","warehouse_id":1}`, status: http.StatusCreated},
+		{name: "update employee", method: http.MethodPatch, route: "/api/v1/employees/:id", target: "/api/v1/employees/1",
+			body: `{"last_name":"Gomez"}`, status: http.StatusOK},
+		{name: "delete employee", method: http.MethodDelete, route: "/api/v1/employees/:id", target: "/api/v1/employees/1", status: http.StatusNoContent},
+		{name: "report inbound orders", method: http.MethodGet, route: "/api/v1/employees/reportInboundOrders", status: http.StatusOK},
+
+		{name: "list inbound orders", method: http.MethodGet, route: "/api/v1/inboundOrders", status: http.StatusOK},
+		{name: "create inbound order", method: http.MethodPost, route: "/api/v1/inboundOrders",
+			body: `{"order_date":"2024-01-02","order_number":"IO-2","employee_id":1,"product_batch_id":1,"warehouse_id":1}`, status: http.StatusCreated},
+		{name: "create inbound order of an unknown batch", method: http.MethodPost, route: "/api/v1/inboundOrders",
+			body: `{"order_date":"2024-01-02","order_number":"IO-2","employee_id":1,"product_batch_id":9,"warehouse_id":1}`, status: http.StatusConflict},
+		{name: "create inbound order with lines", method: http.MethodPost, route: "/api/v1/inboundOrders",
+			body: `{"order_date":"2024-01-02","order_number":"IO-3","employee_id":1,"warehouse_id":1,"lines":[{"product_id":1,"expected_quantity":10}]}`, status: http.StatusCreated},
+		{name: "get inbound order", method: http.MethodGet, route: "/api/v1/inboundOrders/:id", target: "/api/v1/inboundOrders/1", status: http.StatusOK},
+		{name: "receive inbound order", method: http.MethodPost, route: "/api/v1/inboundOrders/:id/receipts", target: "/api/v1/inboundOrders/1/receipts",
+			body: `{"lines":[{"line_id":1,"quantity":4,"section_id":1},{"line_id":1,"quantity":2,"product_batch_id":1}]}`, status: http.StatusCreated},
+		{name: "receive inbound order without a target", method: http.MethodPost, route: "/api/v1/inboundOrders/:id/receipts", target: "/api/v1/inboundOrders/1/receipts",
+			body: `{"lines":[{"line_id":1,"quantity":4}]}`, status: http.StatusUnprocessableEntity},
+		{name: "list asns", method: http.MethodGet, route: "/api/v1/asns", status: http.StatusOK},
+		{name: "get asn", method: http.MethodGet, route: "/api/v1/asns/:id", target: "/api/v1/asns/1", status: http.StatusOK},
+		{name: "create asn", method: http.MethodPost, route: "/api/v1/asns",
+			body: `{"asn_number":"ASN-2","seller_id":1,"warehouse_id":1,"eta":"2024-01-10","lines":[{"product_id":1,"quantity":10,"batch_number":5,` +
+				`"manufacturing_date":"2024-01-01","due_date":"2024-06-01"}]}`, status: http.StatusCreated},
+		{name: "create asn of an unknown product", method: http.MethodPost, route: "/api/v1/asns",
+			body: `{"asn_number":"ASN-2","seller_id":1,"warehouse_id":1,"eta":"2024-01-10","lines":[{"product_id":9,"quantity":10,` +
+				`"manufacturing_date":"2024-01-01","due_date":"2024-06-01"}]}`, status: http.StatusConflict},
+		{name: "create asn with a bad eta", method: http.MethodPost, route: "/api/v1/asns",
+			body: `{"asn_number":"ASN-2","seller_id":1,"warehouse_id":1,"eta":"soon","lines":[{"product_id":1,"quantity":10,` +
+				`"manufacturing_date":"2024-01-01","due_date":"2024-06-01"}]}`, status: http.StatusUnprocessableEntity},
+		{name: "receive asn", method: http.MethodPost, route: "/api/v1/asns/:id/receive", target: "/api/v1/asns/1/receive",
+			body: `{"employee_id":1,"section_id":1}`, status: http.StatusCreated},
+		{name: "report overdue asns", method: http.MethodGet, route: "/api/v1/asns/reportOverdue", target: "/api/v1/asns/reportOverdue?id=1", status: http.StatusOK},
+		{name: "import asns as json", method: http.MethodPost, route: "/api/v1/asns/edi", body: `{"asn_number":"ASN-2"}`, status: http.StatusBadRequest},
+		{name: "export receipt advice", method: http.MethodGet, route: "/api/v1/inboundOrders/:id/receiptAdvice",
+			target: "/api/v1/inboundOrders/1/receiptAdvice", status: http.StatusOK},
+		{name: "export ship notice", method: http.MethodGet, route: "/api/v1/purchaseOrders/:id/shipNotice",
+			target: "/api/v1/purchaseOrders/1/shipNotice?carry_id=1", status: http.StatusOK},
+		{name: "export ship notice without a carry", method: http.MethodGet, route: "/api/v1/purchaseOrders/:id/shipNotice",
+			target: "/api/v1/purchaseOrders/1/shipNotice", status: http.StatusBadRequest},
+
+		{name: "list cycle counts", method: http.MethodGet, route: "/api/v1/cycleCounts", status: http.StatusOK},
+		{name: "get cycle count", method: http.MethodGet, route: "/api/v1/cycleCounts/:id", target: "/api/v1/cycleCounts/2", status: http.StatusOK},
+		{name: "create cycle count", method: http.MethodPost, route: "/api/v1/cycleCounts",
+			body: `{"section_id":1,"employee_id":1,"selection":"abc","class":"A"}`, status: http.StatusCreated},
+		{name: "create cycle count without a sample size", method: http.MethodPost, route: "/api/v1/cycleCounts",
+			body: `{"section_id":1,"employee_id":1,"selection":"random"}`, status: http.StatusUnprocessableEntity},
+		{name: "submit cycle count anonymously", method: http.MethodPost, route: "/api/v1/cycleCounts/:id/counts", target: "/api/v1/cycleCounts/1/counts",
+			body: `{"lines":[{"line_id":1,"counted_quantity":8,"reason_code":"damaged"}]}`, status: http.StatusUnauthorized},
+		{name: "approve cycle count anonymously", method: http.MethodPost, route: "/api/v1/cycleCounts/:id/approval", target: "/api/v1/cycleCounts/2/approval",
+			body: `{"approved":true}`, status: http.StatusUnauthorized},
+
+		{name: "report inbound discrepancies", method: http.MethodGet, route: "/api/v1/inboundOrders/reportDiscrepancies",
+			target: "/api/v1/inboundOrders/reportDiscrepancies?id=1", status: http.StatusOK},
+
+		{name: "list buyers", method: http.MethodGet, route: "/api/v1/buyers", status: http.StatusOK},
+		{name: "get buyer", method: http.MethodGet, route: "/api/v1/buyers/:id", target: "/api/v1/buyers/1", status: http.StatusOK},
+		{name: "create buyer", method: http.MethodPost, route: "/api/v1/buyers",
+			body: `{"card_number_id":"B-2","first_name":"Juan","last_name":"Perez"}`, status: http.StatusCreated},
+		{name: "create buyer with a malformed body", method: http.MethodPost, route: "/api/v1/buyers",
+			body: `{"card_number_id":`, status: http.StatusBadRequest},
+		{name: "update buyer", method: http.MethodPatch, route: "/api/v1/buyers/:id", target: "/api/v1/buyers/1",
+			body: `{"first_name":"Juana"}`, status: http.StatusOK},
+		{name: "delete buyer", method: http.MethodDelete, route: "/api/v1/buyers/:id", target: "/api/v1/buyers/1", status: http.StatusNoContent},
+		{name: "report purchase orders", method: http.MethodGet, route: "/api/v1/buyers/reportPurchaseOrders", status: http.StatusOK},
+		{name: "report inbound orders by month and warehouse", method: http.MethodGet, route: "/api/v1/reports/:report",
+			target: "/api/v1/reports/inbound_orders?from=2024-01-01&to=2024-03-31&group_by=month,warehouse&ids=1", status: http.StatusOK},
+		{name: "report grouped by a dimension it lacks", method: http.MethodGet, route: "/api/v1/reports/:report",
+			target: "/api/v1/reports/sellers?group_by=warehouse", status: http.StatusUnprocessableEntity},
+		{name: "create purchase order", method: http.MethodPost, route: "/api/v1/purchaseOrders",
+			body: `{"order_number":"PO-1","order_date":"2024-01-02","tracking_code":"T-1","buyer_id":1,"product_record_id":1,"order_status_id":1}`, status: http.StatusCreated},
+
+		{name: "graphql", method: http.MethodPost, route: "/graphql",
+			body: `{"query":"{ sellers { companyName locality { localityName } products { productCode batches { section { warehouse { warehouseCode } } } } } }"}`, status: http.StatusOK},
+		{name: "graphql with an unknown field", method: http.MethodPost, route: "/graphql",
+			body: `{"query":"{ sellers { name } }"}`, status: http.StatusOK},
+		{name: "graphql without a query", method: http.MethodPost, route: "/graphql",
+			body: `{"variables":{}}`, status: http.StatusUnprocessableEntity},
+
+		{name: "list webhooks", method: http.MethodGet, route: "/api/v1/webhooks", status: http.StatusOK},
+		{name: "create webhook", method: http.MethodPost, route: "/api/v1/webhooks",
+			body: `{"url":"http://localhost:9999/hooks","secret":"0123456789abcdef","event_types":["seller.created"]}`, status: http.StatusCreated},
+		{name: "create webhook with a short secret", method: http.MethodPost, route: "/api/v1/webhooks",
+			body: `{"url":"http://localhost:9999/hooks","secret":"shh"}`, status: http.StatusUnprocessableEntity},
+		{name: "delete webhook", method: http.MethodDelete, route: "/api/v1/webhooks/:id", target: "/api/v1/webhooks/1", status: http.StatusNoContent},
+		{name: "list dead webhook deliveries", method: http.MethodGet, route: "/api/v1/webhooks/deliveries",
+			target: "/api/v1/webhooks/deliveries?status=dead", status: http.StatusOK},
+		{name: "retry webhook delivery", method: http.MethodPost, route: "/api/v1/webhooks/deliveries/:id/retry",
+			target: "/api/v1/webhooks/deliveries/1/retry", status: http.StatusOK},
+	}
+
+	covered := map[string]bool{}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			target := tc.target
+			if target == "" {
+				target = tc.route
+			}
+			// Each case is a client of its own, so none runs out of quota.
+			rr := serve(tc.method, target, tc.body, tc.name)
+
+			assert.Equal(t, tc.status, rr.Code, rr.Body.String())
+			assert.NoError(t, spec.ValidateResponse(tc.method, tc.route, rr.Code, rr.Header(), rr.Body.Bytes()))
+		})
+		covered[tc.method+" "+tc.route] = true
+	}
+
+	t.Run("report over the quota", func(t *testing.T) {
+		var rr *httptest.ResponseRecorder
+		for i := 0; i <= config.Default().RateLimit.ReportsBurst; i++ {
+			rr = serve(http.MethodGet, "/api/v1/products/reportRecords", "", "greedy")
+		}
+
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code, rr.Body.String())
+		assert.NoError(t, spec.ValidateResponse(http.MethodGet, "/api/v1/products/reportRecords", rr.Code, rr.Header(), rr.Body.Bytes()))
+	})
+
+	t.Run("report not modified", func(t *testing.T) {
+		etag := serve(http.MethodGet, "/api/v1/employees/reportInboundOrders", "", "revalidating").Header().Get("ETag")
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/employees/reportInboundOrders", nil)
+		req.Header.Set("If-None-Match", etag)
+		rr := httptest.NewRecorder()
+		eng.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotModified, rr.Code, rr.Body.String())
+		assert.NoError(t, spec.ValidateResponse(http.MethodGet, "/api/v1/employees/reportInboundOrders", rr.Code, rr.Header(), rr.Body.Bytes()))
+	})
+
+	submit := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/cycleCounts/1/counts", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(web.UserHeader, "1")
+		rr := httptest.NewRecorder()
+		eng.ServeHTTP(rr, req)
+		return rr
+	}
+	t.Run("submit cycle count", func(t *testing.T) {
+		rr := submit(`{"lines":[{"line_id":1,"counted_quantity":8,"reason_code":"damaged"}]}`)
+
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.NoError(t, spec.ValidateResponse(http.MethodPost, "/api/v1/cycleCounts/:id/counts", rr.Code, rr.Header(), rr.Body.Bytes()))
+	})
+	t.Run("submit cycle count without a reason", func(t *testing.T) {
+		rr := submit(`{"lines":[{"line_id":1,"counted_quantity":8}]}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+		assert.NoError(t, spec.ValidateResponse(http.MethodPost, "/api/v1/cycleCounts/:id/counts", rr.Code, rr.Header(), rr.Body.Bytes()))
+	})
+
+	t.Run("approve cycle count as no supervisor", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/cycleCounts/2/approval", strings.NewReader(`{"approved":true}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(web.UserHeader, "42")
+		rr := httptest.NewRecorder()
+		eng.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code, rr.Body.String())
+		assert.NoError(t, spec.ValidateResponse(http.MethodPost, "/api/v1/cycleCounts/:id/approval", rr.Code, rr.Header(), rr.Body.Bytes()))
+	})
+	t.Run("approve cycle count as a supervisor", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/cycleCounts/2/approval", strings.NewReader(`{"approved":true}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(tenant.RolesHeader, tenant.SupervisorRole)
+		req.Header.Set(web.UserHeader, "42")
+		rr := httptest.NewRecorder()
+		eng.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"approved_by":"42"`)
+		assert.NoError(t, spec.ValidateResponse(http.MethodPost, "/api/v1/cycleCounts/:id/approval", rr.Code, rr.Header(), rr.Body.Bytes()))
+	})
+
+	t.Run("import asns from an edi file", func(t *testing.T) {
+		file, err := os.ReadFile(filepath.Join("..", "..", "..", "internal", "edi", "testdata", "856.edi"))
+		require.NoError(t, err)
+		var body bytes.Buffer
+		w := multipart.NewWriter(&body)
+		part, err := w.CreateFormFile("file", "856.edi")
+		require.NoError(t, err)
+		_, err = part.Write(file)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/asns/edi", &body)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		req.RemoteAddr = addr("edi")
+		rr := httptest.NewRecorder()
+		eng.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		assert.NoError(t, spec.ValidateResponse(http.MethodPost, "/api/v1/asns/edi", rr.Code, rr.Header(), rr.Body.Bytes()))
+	})
+	covered["POST /api/v1/asns/edi"] = true
+
+	routes := map[string]bool{}
+	for _, route := range eng.Routes() {
+		key := route.Method + " " + route.Path
+		routes[key] = true
+		assert.True(t, covered[key], "%s has no contract case", key)
+	}
+	for _, op := range spec.Operations() {
+		assert.True(t, routes[op.Method+" "+op.Path], "%s %s is documented but not served", op.Method, op.Path)
+	}
+}
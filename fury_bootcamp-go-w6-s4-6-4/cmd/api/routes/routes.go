@@ -1,39 +1,169 @@
 package routes
 
 import (
-	"database/sql"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/cmd/api/handler"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/cmd/api/resolver"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/cmd/api/rpc"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/docs"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/activity"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/asn"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/buyer"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/carry"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/config"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/country"
+	cyclecount "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/cycle_count"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/edi"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/employee"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/graph"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/idempotency"
 	inboundorder "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/inbound_order"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/locality"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/metrics"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/product"
 	productbatches "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/product_batches"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/product_records"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/province"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/purchase_orders"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/report"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/section"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/seller"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/warehouse"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/webhook"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/cache"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/health"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/interceptor"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/openapi"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/pb/melisprint/v1"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+const (
+	// metricsRefreshInterval is how often the domain gauges are recomputed.
+	metricsRefreshInterval = 30 * time.Second
+	// healthCheckTimeout bounds each readiness check.
+	healthCheckTimeout = 2 * time.Second
 )
 
+// schemaVersion is the number of the last script of migrations/, which the
+// readiness probe checks the database has applied.
+const schemaVersion = 3
+
+// tables are the tables created by db.sql, checked by the readiness probe.
+var tables = []string{
+	"buyers", "warehouses", "employees", "countries", "provinces", "locality", "seller", "products",
+	"sections", "carries", "product_batches", "product_records", "inbound_orders", "purchase_orders",
+	"inbound_order_lines", "inbound_order_receipts", "inbound_order_receipt_lines", "asns", "asn_lines",
+	"cycle_counts", "cycle_count_lines", "outbox_events", "webhook_subscriptions", "webhook_deliveries", "idempotency_keys",
+}
+
 type Router interface {
-	MapRoutes()
+	// MapRoutes registers the routes and prepares the statements of their
+	// repositories, failing when the OpenAPI description doesn't load or any
+	// statement can't be prepared.
+	MapRoutes() error
+	// MapServices builds the gRPC server with the services registered. Call
+	// it before MapRoutes so the statements of their repositories are
+	// prepared at boot too.
+	MapServices() *grpc.Server
+	// Shutdown fails the readiness probe and stops the background workers.
+	Shutdown()
 }
 
 type router struct {
-	eng *gin.Engine
-	rg  *gin.RouterGroup
-	db  *sql.DB
-	pr  *gin.RouterGroup
+	eng      *gin.Engine
+	rg       *gin.RouterGroup
+	db       *database.Router
+	stmts    *database.Statements
+	outbox   events.Outbox
+	pr       *gin.RouterGroup
+	logger   *slog.Logger
+	registry *prometheus.Registry
+	health   *health.Registry
+	cfg      config.Config
+	timeouts map[string]time.Duration
+	limits   map[string]web.RateLimitGroup
+	etags    map[string]string
+	reports  *cache.Cache
+	workers  context.Context
+	stop     context.CancelFunc
 }
 
-func NewRouter(eng *gin.Engine, db *sql.DB) Router {
-	return &router{eng: eng, db: db}
+func NewRouter(eng *gin.Engine, db *database.Router, logger *slog.Logger, cfg config.Config) Router {
+	workers, stop := context.WithCancel(context.Background())
+	stmts := database.NewStatements(db.Writer())
+	return &router{
+		eng:      eng,
+		db:       db,
+		stmts:    stmts,
+		outbox:   events.NewOutbox(db, stmts),
+		logger:   logger,
+		registry: prometheus.NewRegistry(),
+		health:   health.NewRegistry(healthCheckTimeout),
+		cfg:      cfg,
+		timeouts: map[string]time.Duration{},
+		limits:   map[string]web.RateLimitGroup{},
+		etags:    map[string]string{},
+		reports:  reportCache(cfg, logger),
+		workers:  workers,
+		stop:     stop,
+	}
 }
 
-func (r *router) MapRoutes() {
+// reportCache returns the cache of the reports, or nil when they aren't
+// cached.
+func reportCache(cfg config.Config, logger *slog.Logger) *cache.Cache {
+	if !cfg.Features.ReportCache {
+		return nil
+	}
+	return cache.New(cache.NewMemory(cfg.ReportCache.Entries), cfg.ReportCache.TTL, logger.With("component", "report_cache"))
+}
+
+func (r *router) Shutdown() {
+	r.health.Drain()
+	r.stop()
+}
+
+func (r *router) MapRoutes() error {
+	spec, err := openapi.Load(docs.OpenAPI)
+	if err != nil {
+		return fmt.Errorf("loading the OpenAPI description: %w", err)
+	}
+
+	// Handlers pass the gin context to services, so it must fall back to the
+	// request context for values like the request id.
+	r.eng.ContextWithFallback = true
+	r.eng.Use(web.RequestID(), web.Logger(r.logger), web.Tenant(r.cfg.Tenancy.DefaultSite, r.cfg.Tenancy.Sites), web.Timeout(r.cfg.Server.RequestTimeout, r.timeouts), readYourWrites)
+	if r.cfg.Features.Metrics {
+		r.eng.Use(web.Metrics(r.registry))
+	}
+	if r.cfg.Features.RateLimit {
+		r.eng.Use(web.RateLimit(web.NewMemoryRateLimitStore(r.cfg.RateLimit.MaxClients), r.limits))
+	}
+	if r.cfg.Features.Idempotency {
+		r.eng.Use(r.idempotency())
+	}
+	if r.cfg.Features.ReportCache {
+		r.eng.Use(web.ETag(r.etags))
+	}
+	r.eng.Use(web.ErrorHandler())
+	if r.cfg.Features.RequestValidation {
+		r.eng.Use(web.Validate(spec))
+	}
 	r.setGroup()
 
 	r.buildSellerRoutes()
@@ -44,11 +174,170 @@ func (r *router) MapRoutes() {
 	r.buildEmployeeRoutes()
 	r.buildBuyerRoutes()
 	r.buildPurchaseOrdersRoutes()
+	r.buildReportRoutes()
 	r.buildInBoundOrder()
+	r.buildASNRoutes()
+	r.buildEDIRoutes()
+	r.buildCycleCountRoutes()
 	r.buildProductRecordsRoutes()
+	r.buildCountryRoutes()
+	r.buildProvinceRoutes()
 	r.buildLocalityRoutes()
 	r.buildCarryRoutes()
+	if r.cfg.Features.Webhooks {
+		r.buildWebhookRoutes()
+	}
+	if r.cfg.Features.WarehouseEvents {
+		r.buildWarehouseEventsRoutes()
+	}
 	r.buildHealthCheckRoute()
+	if r.cfg.Features.Metrics {
+		r.buildMetricsRoute()
+	}
+	if r.cfg.Features.Docs {
+		r.buildDocsRoute()
+	}
+	if r.cfg.Features.GraphQL {
+		if err := r.buildGraphQLRoute(); err != nil {
+			return err
+		}
+	}
+	r.setReportTimeouts()
+	if r.cfg.Features.ReportCache {
+		r.setReportETags()
+	}
+	if r.cfg.Features.RateLimit {
+		r.setRateLimits()
+	}
+
+	if len(r.db.Readers()) > 0 {
+		go r.db.Run(r.workers, r.cfg.DB.ReplicaCheck)
+	}
+	return r.stmts.PrepareAll(context.Background())
+}
+
+func (r *router) MapServices() *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			interceptor.UnaryRequestID(),
+			interceptor.UnaryLogger(r.logger),
+			interceptor.UnaryTenant(r.cfg.Tenancy.DefaultSite, r.cfg.Tenancy.Sites),
+			interceptor.UnaryTimeout(r.cfg.Server.RequestTimeout),
+			readYourWritesUnary,
+			interceptor.UnaryErrors(),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptor.StreamRequestID(),
+			interceptor.StreamLogger(r.logger),
+			interceptor.StreamTenant(r.cfg.Tenancy.DefaultSite, r.cfg.Tenancy.Sites),
+			interceptor.StreamErrors(),
+		),
+	)
+
+	sellerLogger := r.logger.With("component", "seller")
+	sellers := seller.WithCache(seller.NewService(seller.NewRepository(r.db, r.stmts, sellerLogger), r.outbox, sellerLogger), r.reports)
+	pb.RegisterSellerServiceServer(srv, rpc.NewSeller(sellers))
+
+	productLogger := r.logger.With("component", "product")
+	products := product.WithCache(product.NewService(product.NewRepository(r.db, r.stmts, productLogger), productLogger), r.reports)
+	pb.RegisterProductServiceServer(srv, rpc.NewProduct(products))
+
+	sectionLogger := r.logger.With("component", "section")
+	sections := section.WithCache(section.NewService(section.NewRepository(r.db, r.stmts, sectionLogger), r.outbox, sectionLogger), r.reports)
+	pb.RegisterSectionServiceServer(srv, rpc.NewSection(sections))
+
+	warehouseLogger := r.logger.With("component", "warehouse")
+	warehouses := warehouse.NewService(warehouse.NewRepository(r.db, r.stmts, warehouseLogger), warehouseLogger)
+	pb.RegisterWarehouseServiceServer(srv, rpc.NewWarehouse(warehouses))
+
+	batchLogger := r.logger.With("component", "product_batches")
+	batches := productbatches.WithCache(productbatches.NewService(productbatches.NewRepository(r.db, r.stmts, batchLogger), r.outbox, batchLogger), r.reports)
+	pb.RegisterProductBatchServiceServer(srv, rpc.NewProductBatch(batches, sections))
+
+	inboundLogger := r.logger.With("component", "inbound_order")
+	inboundOrders := inboundorder.WithCache(inboundorder.NewService(inboundorder.NewRepository(r.db, r.stmts, inboundLogger), r.outbox, inboundLogger), r.reports)
+	pb.RegisterInboundOrderServiceServer(srv, rpc.NewInboundOrder(inboundOrders))
+
+	purchaseLogger := r.logger.With("component", "purchase_orders")
+	purchaseOrders := purchase_orders.WithCache(purchase_orders.NewService(purchase_orders.NewRepository(r.db, r.stmts, purchaseLogger), r.outbox, purchaseLogger), r.reports)
+	pb.RegisterPurchaseOrderServiceServer(srv, rpc.NewPurchaseOrder(purchaseOrders))
+
+	// Lets tools like grpcurl discover the services without the protos.
+	reflection.Register(srv)
+	return srv
+}
+
+// readYourWrites sends the reads a request makes after writing to the
+// writer, which already has the write the replicas may still lack.
+func readYourWrites(c *gin.Context) {
+	c.Request = c.Request.WithContext(database.WithSession(c.Request.Context()))
+	c.Next()
+}
+
+// readYourWritesUnary is readYourWrites for gRPC calls.
+func readYourWritesUnary(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(database.WithSession(ctx), req)
+}
+
+// idempotency returns the middleware replaying retried POST requests and
+// starts purging the keys that expire.
+func (r *router) idempotency() gin.HandlerFunc {
+	logger := r.logger.With("component", "idempotency")
+	repo := idempotency.NewRepository(r.db, r.stmts, logger)
+	purger := idempotency.NewPurger(repo, logger)
+	go purger.Run(r.workers, r.cfg.Idempotency.PurgeInterval)
+	r.health.Register("idempotency_purger", purger.Healthy)
+	return web.Idempotency(repo, r.cfg.Idempotency.TTL, int64(r.cfg.Idempotency.MaxBodySize))
+}
+
+// setReportTimeouts gives the report routes, which aggregate whole tables,
+// a longer deadline than the rest.
+func (r *router) setReportTimeouts() {
+	for _, route := range r.eng.Routes() {
+		if strings.Contains(route.Path, "/report") {
+			r.timeouts[route.Path] = r.cfg.Server.ReportTimeout
+		}
+	}
+}
+
+// setReportETags tags the reports with an ETag, so clients revalidate them
+// for free while nothing they count changes.
+func (r *router) setReportETags() {
+	cacheControl := "private, no-cache"
+	if maxAge := r.cfg.ReportCache.MaxAge; maxAge > 0 {
+		cacheControl = fmt.Sprintf("private, max-age=%d", int(maxAge.Seconds()))
+	}
+	for _, route := range r.eng.Routes() {
+		if strings.Contains(route.Path, "/report") {
+			r.etags[route.Path] = cacheControl
+		}
+	}
+}
+
+// setRateLimits puts the API routes in the groups whose quotas they share:
+// reports, which aggregate whole tables, the other reads, and writes, so
+// bulk creation can't starve the reads. GraphQL requests count as writes,
+// since any of them may be a mutation. Health, metrics and docs routes
+// aren't limited.
+func (r *router) setRateLimits() {
+	l := r.cfg.RateLimit
+	reads := web.RateLimitGroup{Name: "reads", Limit: web.PerMinute(l.ReadsPerMinute, l.ReadsBurst)}
+	writes := web.RateLimitGroup{Name: "writes", Limit: web.PerMinute(l.WritesPerMinute, l.WritesBurst)}
+	reports := web.RateLimitGroup{Name: "reports", Limit: web.PerMinute(l.ReportsPerMinute, l.ReportsBurst)}
+
+	for _, route := range r.eng.Routes() {
+		if !strings.HasPrefix(route.Path, "/api/") && route.Path != "/graphql" {
+			continue
+		}
+		group := writes
+		switch {
+		case strings.Contains(route.Path, "/report"):
+			group = reports
+		case route.Method == http.MethodGet:
+			group = reads
+		}
+		r.limits[route.Method+" "+route.Path] = group
+	}
 }
 
 func (r *router) setGroup() {
@@ -59,14 +348,76 @@ func (r *router) setGroup() {
 func (r *router) buildHealthCheckRoute() {
 
 	r.eng.GET("/ping", func(ctx *gin.Context) {
-		w := ctx.Writer
-		w.Write([]byte("pong"))
+		ctx.String(http.StatusOK, "pong")
 	})
+
+	r.health.Register("database", health.Ping(r.db.Writer()))
+	r.health.Register("migrations", health.SchemaVersion(r.db.Writer(), schemaVersion))
+	r.health.Register("tables", health.Tables(r.db.Writer(), tables...))
+	handler := handler.NewHealth(r.health)
+
+	hr := r.eng.Group("/health")
+	hr.GET("/live", handler.Live())
+	hr.GET("/ready", handler.Ready())
+}
+
+func (r *router) buildMetricsRoute() {
+	r.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewDBStatsCollector(r.db.Writer(), "melisprint"),
+	)
+	for i, replica := range r.db.Readers() {
+		r.registry.MustRegister(collectors.NewDBStatsCollector(replica, fmt.Sprintf("melisprint_replica_%d", i)))
+	}
+
+	logger := r.logger.With("component", "metrics")
+	domain := metrics.NewDomain(
+		r.registry,
+		warehouse.NewRepository(r.db, r.stmts, logger),
+		section.NewRepository(r.db, r.stmts, logger),
+		productbatches.NewRepository(r.db, r.stmts, logger),
+		purchase_orders.NewRepository(r.db, r.stmts, logger),
+		logger,
+	)
+	go domain.Run(r.workers, metricsRefreshInterval)
+	r.health.Register("metrics_refresher", domain.Healthy)
+
+	r.eng.GET("/metrics", gin.WrapH(promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{})))
+}
+
+func (r *router) buildDocsRoute() {
+	r.eng.GET("/openapi.yaml", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/yaml", docs.OpenAPI)
+	})
+}
+
+func (r *router) buildGraphQLRoute() error {
+	logger := r.logger.With("component", "graphql")
+	services := resolver.Services{
+		Sellers:       seller.WithCache(seller.NewService(seller.NewRepository(r.db, r.stmts, logger), r.outbox, logger), r.reports),
+		Products:      product.WithCache(product.NewService(product.NewRepository(r.db, r.stmts, logger), logger), r.reports),
+		Sections:      section.WithCache(section.NewService(section.NewRepository(r.db, r.stmts, logger), r.outbox, logger), r.reports),
+		Warehouses:    warehouse.NewService(warehouse.NewRepository(r.db, r.stmts, logger), logger),
+		Employees:     employee.WithCache(employee.NewService(employee.NewRepository(r.db, r.stmts, logger), logger), r.reports),
+		Buyers:        buyer.WithCache(buyer.NewService(buyer.NewRepository(r.db, r.stmts, logger), logger), r.reports),
+		InboundOrders: inboundorder.WithCache(inboundorder.NewService(inboundorder.NewRepository(r.db, r.stmts, logger), r.outbox, logger), r.reports),
+	}
+	repo := graph.NewRepository(r.db)
+	schema, err := resolver.NewSchema(resolver.NewResolver(services, repo))
+	if err != nil {
+		return fmt.Errorf("parsing the GraphQL schema: %w", err)
+	}
+	handler := handler.NewGraphQL(schema, repo)
+
+	r.eng.POST("/graphql", handler.Query())
+	return nil
 }
 
 func (r *router) buildSellerRoutes() {
-	repo := seller.NewRepository(r.db)
-	service := seller.NewService(repo)
+	logger := r.logger.With("component", "seller")
+	repo := seller.NewRepository(r.db, r.stmts, logger)
+	service := seller.WithCache(seller.NewService(repo, r.outbox, logger), r.reports)
 	handler := handler.NewSeller(service)
 	r.rg.GET("/sellers", handler.GetAll())
 	r.rg.GET("/sellers/:id", handler.Get())
@@ -76,8 +427,9 @@ func (r *router) buildSellerRoutes() {
 }
 
 func (r *router) buildSectionRoutes() {
-	repo := section.NewRepository(r.db)
-	service := section.NewService(repo)
+	logger := r.logger.With("component", "section")
+	repo := section.NewRepository(r.db, r.stmts, logger)
+	service := section.WithCache(section.NewService(repo, r.outbox, logger), r.reports)
 	handler := handler.NewSection(service)
 
 	r.rg.GET("/sections", handler.GetAll())
@@ -88,8 +440,9 @@ func (r *router) buildSectionRoutes() {
 }
 
 func (r *router) buildProductBatchesRoutes() {
-	repository := productbatches.NewRepository(r.db)
-	service := productbatches.NewService(repository)
+	logger := r.logger.With("component", "product_batches")
+	repository := productbatches.NewRepository(r.db, r.stmts, logger)
+	service := productbatches.WithCache(productbatches.NewService(repository, r.outbox, logger), r.reports)
 	handler := handler.NewProductBatches(service)
 
 	r.rg.POST("/productbatches", handler.Create())
@@ -98,8 +451,9 @@ func (r *router) buildProductBatchesRoutes() {
 }
 
 func (r *router) buildProductRoutes() {
-	repo := product.NewRepository(r.db)
-	service := product.NewService(repo)
+	logger := r.logger.With("component", "product")
+	repo := product.NewRepository(r.db, r.stmts, logger)
+	service := product.WithCache(product.NewService(repo, logger), r.reports)
 	handler := handler.NewProduct(service)
 
 	r.pr.GET("/", handler.GetAll())
@@ -111,8 +465,9 @@ func (r *router) buildProductRoutes() {
 }
 
 func (r *router) buildWarehouseRoutes() {
-	repo := warehouse.NewRepository(r.db)
-	service := warehouse.NewService(repo)
+	logger := r.logger.With("component", "warehouse")
+	repo := warehouse.NewRepository(r.db, r.stmts, logger)
+	service := warehouse.NewService(repo, logger)
 	handler := handler.NewWarehouse(service)
 	r.rg.GET("/warehouses/:id", handler.Get())
 	r.rg.GET("/warehouses", handler.GetAll())
@@ -122,8 +477,9 @@ func (r *router) buildWarehouseRoutes() {
 }
 
 func (r *router) buildEmployeeRoutes() {
-	repo := employee.NewRepository(r.db)
-	service := employee.NewService(repo)
+	logger := r.logger.With("component", "employee")
+	repo := employee.NewRepository(r.db, r.stmts, logger)
+	service := employee.WithCache(employee.NewService(repo, logger), r.reports)
 	handler := handler.NewEmployee(service)
 
 	er := r.rg.Group("/employees")
@@ -138,8 +494,9 @@ func (r *router) buildEmployeeRoutes() {
 
 func (r *router) buildBuyerRoutes() {
 	// Example
-	repo := buyer.NewRepository(r.db)
-	service := buyer.NewService(repo)
+	logger := r.logger.With("component", "buyer")
+	repo := buyer.NewRepository(r.db, r.stmts, logger)
+	service := buyer.WithCache(buyer.NewService(repo, logger), r.reports)
 	handler := handler.NewBuyer(service)
 
 	pr := r.rg.Group("buyers")
@@ -151,8 +508,9 @@ func (r *router) buildBuyerRoutes() {
 }
 
 func (r *router) buildPurchaseOrdersRoutes() {
-	repo := purchase_orders.NewRepository(r.db)
-	service := purchase_orders.NewService(repo)
+	logger := r.logger.With("component", "purchase_orders")
+	repo := purchase_orders.NewRepository(r.db, r.stmts, logger)
+	service := purchase_orders.WithCache(purchase_orders.NewService(repo, r.outbox, logger), r.reports)
 	handler := handler.NewPurchaseOrders(service)
 
 	pr := r.rg.Group("purchaseOrders")
@@ -162,26 +520,110 @@ func (r *router) buildPurchaseOrdersRoutes() {
   ps.GET("reportPurchaseOrders", handler.Get())
 }
 
+func (r *router) buildReportRoutes() {
+	logger := r.logger.With("component", "report")
+	repo := report.NewRepository(r.db)
+	service := report.WithCache(report.NewService(repo, logger), r.reports)
+	handler := handler.NewReport(service)
+
+	r.rg.GET("/reports/:report", handler.Run())
+}
+
 func (r *router) buildInBoundOrder() {
-	repo := inboundorder.NewRepository(r.db)
-	service := inboundorder.NewService(repo)
+	logger := r.logger.With("component", "inbound_order")
+	repo := inboundorder.NewRepository(r.db, r.stmts, logger)
+	service := inboundorder.WithCache(inboundorder.NewService(repo, r.outbox, logger), r.reports)
 	handler := handler.NewInBound_Order(service)
 
 	bor := r.rg.Group("/inboundOrders")
 	bor.GET("", handler.GetAll())
 	bor.POST("", handler.Create())
+	bor.GET("/reportDiscrepancies", handler.ReportDiscrepancies())
+	bor.GET("/:id", handler.Get())
+	bor.POST("/:id/receipts", handler.Receive())
   }
+
+func (r *router) buildASNRoutes() {
+	logger := r.logger.With("component", "asn")
+	repo := asn.NewRepository(r.db, r.stmts, logger)
+	// The notices' cache invalidates the inbound orders' reports once a
+	// notice received is committed.
+	inboundOrders := inboundorder.NewService(inboundorder.NewRepository(r.db, r.stmts, logger), r.outbox, logger)
+	service := asn.WithCache(asn.NewService(repo, inboundOrders, r.outbox, logger), r.reports)
+	handler := handler.NewASN(service)
+
+	r.rg.GET("/asns", handler.GetAll())
+	r.rg.GET("/asns/reportOverdue", handler.ReportOverdue())
+	r.rg.GET("/asns/:id", handler.Get())
+	r.rg.POST("/asns", handler.Create())
+	r.rg.POST("/asns/:id/receive", handler.Receive())
+}
+
+func (r *router) buildEDIRoutes() {
+	logger := r.logger.With("component", "edi")
+	inboundOrders := inboundorder.NewService(inboundorder.NewRepository(r.db, r.stmts, logger), r.outbox, logger)
+	asns := asn.WithCache(asn.NewService(asn.NewRepository(r.db, r.stmts, logger), inboundOrders, r.outbox, logger), r.reports)
+	service := edi.NewService(edi.NewRepository(r.db, logger), asns, r.cfg.EDI.InterchangeID, logger)
+	handler := handler.NewEDI(service, r.cfg.EDI.MaxFileSize)
+
+	r.rg.POST("/asns/edi", handler.Import())
+	r.rg.GET("/inboundOrders/:id/receiptAdvice", handler.ReceiptAdvice())
+	r.rg.GET("/purchaseOrders/:id/shipNotice", handler.ShipNotice())
+}
+
+func (r *router) buildCycleCountRoutes() {
+	logger := r.logger.With("component", "cycle_count")
+	repo := cyclecount.NewRepository(r.db, r.stmts, logger)
+	service := cyclecount.WithCache(cyclecount.NewService(repo, r.outbox, r.cfg.CycleCount.ApprovalThreshold, logger), r.reports)
+	handler := handler.NewCycleCount(service)
+
+	r.rg.GET("/cycleCounts", handler.GetAll())
+	r.rg.GET("/cycleCounts/:id", handler.Get())
+	r.rg.POST("/cycleCounts", handler.Create())
+	r.rg.POST("/cycleCounts/:id/counts", handler.Submit())
+	r.rg.POST("/cycleCounts/:id/approval", handler.Approve())
+}
 func (r *router) buildProductRecordsRoutes() {
-	repo := product_records.NewRepository(r.db)
-	service := product_records.NewService(repo)
+	logger := r.logger.With("component", "product_records")
+	repo := product_records.NewRepository(r.db, r.stmts, logger)
+	service := product_records.WithCache(product_records.NewService(repo, logger), r.reports)
 	handler := handler.NewProductRecord(service)
 
 	r.rg.POST("/productRecords", handler.Create())
 }
 
+func (r *router) buildCountryRoutes() {
+	logger := r.logger.With("component", "country")
+	repo := country.NewRepository(r.db, r.stmts, logger)
+	service := country.WithCache(country.NewService(repo, logger), r.reports)
+	handler := handler.NewCountry(service)
+
+	r.rg.GET("/countries", handler.GetAll())
+	r.rg.GET("/countries/report", handler.Report())
+	r.rg.GET("/countries/:id", handler.Get())
+	r.rg.POST("/countries", handler.Create())
+	r.rg.PATCH("/countries/:id", handler.Update())
+	r.rg.DELETE("/countries/:id", handler.Delete())
+}
+
+func (r *router) buildProvinceRoutes() {
+	logger := r.logger.With("component", "province")
+	repo := province.NewRepository(r.db, r.stmts, logger)
+	service := province.WithCache(province.NewService(repo, logger), r.reports)
+	handler := handler.NewProvince(service)
+
+	r.rg.GET("/provinces", handler.GetAll())
+	r.rg.GET("/provinces/report", handler.Report())
+	r.rg.GET("/provinces/:id", handler.Get())
+	r.rg.POST("/provinces", handler.Create())
+	r.rg.PATCH("/provinces/:id", handler.Update())
+	r.rg.DELETE("/provinces/:id", handler.Delete())
+}
+
 func (r *router) buildLocalityRoutes() {
-	repo := locality.NewRepository(r.db)
-	service := locality.NewService(repo)
+	logger := r.logger.With("component", "locality")
+	repo := locality.NewRepository(r.db, r.stmts, logger)
+	service := locality.WithCache(locality.NewService(repo, logger), r.reports)
 	handler := handler.NewLocality(service)
 
 	r.rg.POST("/localities", handler.Create())
@@ -190,10 +632,56 @@ func (r *router) buildLocalityRoutes() {
 }
 
 func (r *router) buildCarryRoutes() {
-	repo := carry.NewRepository(r.db)
-	service := carry.NewService(repo)
+	logger := r.logger.With("component", "carry")
+	repo := carry.NewRepository(r.db, r.stmts, logger)
+	service := carry.WithCache(carry.NewService(repo, logger), r.reports)
 	handler := handler.NewCarry(service)
 
 	r.rg.POST("/carries", handler.Create())
 
 }
+
+func (r *router) buildWebhookRoutes() {
+	logger := r.logger.With("component", "webhook")
+	repo := webhook.NewRepository(r.db, r.stmts, logger)
+	service := webhook.NewService(repo, logger)
+	handler := handler.NewWebhook(service)
+
+	wr := r.rg.Group("/webhooks")
+	wr.POST("", handler.Create())
+	wr.GET("", handler.GetAll())
+	wr.DELETE("/:id", handler.Delete())
+	wr.GET("/deliveries", handler.Deliveries())
+	wr.POST("/deliveries/:id/retry", handler.Retry())
+
+	w := r.cfg.Webhooks
+	dispatcher := webhook.NewDispatcher(repo, webhook.Options{
+		BatchSize:   w.BatchSize,
+		MaxAttempts: w.MaxAttempts,
+		BackoffBase: w.BackoffBase,
+		BackoffMax:  w.BackoffMax,
+		Timeout:     w.Timeout,
+	}, logger)
+	go dispatcher.Run(r.workers, w.DispatchInterval)
+	r.health.Register("webhook_dispatcher", dispatcher.Healthy)
+}
+
+func (r *router) buildWarehouseEventsRoutes() {
+	logger := r.logger.With("component", "warehouse_events")
+	e := r.cfg.WarehouseEvents
+	repo := activity.NewRepository(r.db, logger)
+	broker := activity.NewBroker(repo, activity.Options{
+		BatchSize: e.BatchSize,
+		Buffer:    e.Buffer,
+		GapWait:   e.GapWait,
+	}, logger)
+	service := activity.NewService(repo, broker, e.ReplayLimit, logger)
+	handler := handler.NewWarehouseEvents(service, e.Heartbeat)
+
+	r.rg.GET("/warehouses/:id/events", handler.Stream())
+	// A stream lasts until its deadline, then the client reconnects.
+	r.timeouts["/api/v1/warehouses/:id/events"] = e.StreamDuration
+
+	go broker.Run(r.workers, e.PollInterval)
+	r.health.Register("warehouse_events_broker", broker.Healthy)
+}
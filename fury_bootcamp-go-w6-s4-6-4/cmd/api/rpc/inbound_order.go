@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	inboundorder "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/inbound_order"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/pb/melisprint/v1"
+)
+
+type InboundOrder struct {
+	pb.UnimplementedInboundOrderServiceServer
+	inboundOrderService inboundorder.Service
+}
+
+func NewInboundOrder(s inboundorder.Service) *InboundOrder {
+	return &InboundOrder{inboundOrderService: s}
+}
+
+func (o *InboundOrder) ListInboundOrders(ctx context.Context, _ *pb.ListInboundOrdersRequest) (*pb.ListInboundOrdersResponse, error) {
+	orders, err := o.inboundOrderService.GetAll_inboundOrders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListInboundOrdersResponse{InboundOrders: make([]*pb.InboundOrder, len(orders))}
+	for i, order := range orders {
+		resp.InboundOrders[i] = inboundOrderToPB(order)
+	}
+	return resp, nil
+}
+
+func (o *InboundOrder) CreateInboundOrder(ctx context.Context, req *pb.CreateInboundOrderRequest) (*pb.InboundOrder, error) {
+	if err := required(
+		field{"order_date", req.OrderDate == ""},
+		field{"order_number", req.OrderNumber == ""},
+		field{"employee_id", req.EmployeeId == 0},
+		field{"product_batch_id", req.ProductBatchId == 0},
+		field{"warehouse_id", req.WarehouseId == 0},
+	); err != nil {
+		return nil, err
+	}
+	if _, err := time.Parse(dateLayout, req.OrderDate); err != nil {
+		return nil, apperrors.Validation("invalid date example 2008-01-02")
+	}
+
+	order, err := o.inboundOrderService.Save(ctx, req.OrderDate, req.OrderNumber, int(req.EmployeeId), int(req.ProductBatchId), int(req.WarehouseId), nil)
+	if err != nil {
+		return nil, err
+	}
+	return inboundOrderToPB(order), nil
+}
+
+func inboundOrderToPB(o domain.Inbound_order) *pb.InboundOrder {
+	return &pb.InboundOrder{
+		Id:             int64(o.ID),
+		OrderDate:      o.Order_date,
+		OrderNumber:    o.Order_number,
+		EmployeeId:     int64(o.Employee_id),
+		ProductBatchId: int64(o.Product_batch_id),
+		WarehouseId:    int64(o.Warehouse_id),
+	}
+}
@@ -0,0 +1,113 @@
+package rpc
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/product"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/pb/melisprint/v1"
+)
+
+type Product struct {
+	pb.UnimplementedProductServiceServer
+	productService product.Service
+}
+
+func NewProduct(p product.Service) *Product {
+	return &Product{productService: p}
+}
+
+func (p *Product) ListProducts(ctx context.Context, _ *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	products, err := p.productService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListProductsResponse{Products: make([]*pb.Product, len(products))}
+	for i, pr := range products {
+		resp.Products[i] = productToPB(pr)
+	}
+	return resp, nil
+}
+
+func (p *Product) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.Product, error) {
+	pr, err := p.productService.Get(ctx, int(req.Id))
+	if err != nil {
+		return nil, err
+	}
+	return productToPB(pr), nil
+}
+
+func (p *Product) CreateProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.Product, error) {
+	if err := required(
+		field{"description", req.Description == ""},
+		field{"product_code", req.ProductCode == ""},
+		field{"product_type_id", req.ProductTypeId == 0},
+		field{"seller_id", req.SellerId == 0},
+	); err != nil {
+		return nil, err
+	}
+
+	pr, err := p.productService.Save(ctx, req.Description, int(req.ExpirationRate), int(req.FreezingRate),
+		req.Height, req.Length, req.Netweight, req.ProductCode, req.RecommendedFreezingTemperature, req.Width,
+		int(req.ProductTypeId), int(req.SellerId))
+	if err != nil {
+		return nil, err
+	}
+	return productToPB(pr), nil
+}
+
+func (p *Product) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.Product, error) {
+	pr, err := p.productService.Update(ctx, int(req.Id), req.Description, intPtr(req.ExpirationRate), intPtr(req.FreezingRate),
+		req.Height, req.Length, req.Netweight, req.ProductCode, req.RecommendedFreezingTemperature, req.Width,
+		intPtr(req.ProductTypeId), intPtr(req.SellerId))
+	if err != nil {
+		return nil, err
+	}
+	return productToPB(pr), nil
+}
+
+func (p *Product) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*pb.DeleteProductResponse, error) {
+	if err := p.productService.Delete(ctx, int(req.Id)); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteProductResponse{}, nil
+}
+
+func (p *Product) ReportRecords(req *pb.ReportRecordsRequest, stream pb.ProductService_ReportRecordsServer) error {
+	var id string
+	if req.ProductId != nil {
+		id = strconv.FormatInt(*req.ProductId, 10)
+	}
+	reports, err := p.productService.GetProductRecords(stream.Context(), id)
+	if err != nil {
+		return err
+	}
+	for _, r := range reports {
+		if err := stream.Send(&pb.ProductRecordsReport{
+			ProductId:    int64(r.ProductID),
+			Description:  r.ProductDescription,
+			RecordsCount: int64(r.ProductRecordsCount),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func productToPB(p domain.Product) *pb.Product {
+	return &pb.Product{
+		Id:                             int64(p.ID),
+		Description:                    p.Description,
+		ExpirationRate:                 int64(p.ExpirationRate),
+		FreezingRate:                   int64(p.FreezingRate),
+		Height:                         p.Height,
+		Length:                         p.Length,
+		Netweight:                      p.Netweight,
+		ProductCode:                    p.ProductCode,
+		RecommendedFreezingTemperature: p.RecomFreezTemp,
+		Width:                          p.Width,
+		ProductTypeId:                  int64(p.ProductTypeID),
+		SellerId:                       int64(p.SellerID),
+	}
+}
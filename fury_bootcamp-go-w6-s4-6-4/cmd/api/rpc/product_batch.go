@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	productbatches "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/product_batches"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/section"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/pb/melisprint/v1"
+)
+
+// dateLayout is the format of the dates sent as strings.
+const dateLayout = "2006-01-02"
+
+type ProductBatch struct {
+	pb.UnimplementedProductBatchServiceServer
+	productBatchesService productbatches.Service
+	sectionService        section.Service
+}
+
+func NewProductBatch(p productbatches.Service, s section.Service) *ProductBatch {
+	return &ProductBatch{productBatchesService: p, sectionService: s}
+}
+
+func (p *ProductBatch) CreateProductBatch(ctx context.Context, req *pb.CreateProductBatchRequest) (*pb.ProductBatch, error) {
+	if err := required(
+		field{"batch_number", req.BatchNumber == 0},
+		field{"due_date", req.DueDate == ""},
+		field{"manufacturing_date", req.ManufacturingDate == ""},
+		field{"product_id", req.ProductId == 0},
+		field{"section_id", req.SectionId == 0},
+	); err != nil {
+		return nil, err
+	}
+	for _, date := range []string{req.DueDate, req.ManufacturingDate} {
+		if _, err := time.Parse(dateLayout, date); err != nil {
+			return nil, apperrors.Validation("invalid date example 2008-01-02")
+		}
+	}
+
+	batch := domain.Product_batches{
+		BatchNumber:        int(req.BatchNumber),
+		CurrentQuantity:    int(req.CurrentQuantity),
+		CurrentTemperature: int(req.CurrentTemperature),
+		DueDate:            req.DueDate,
+		InitialQuantity:    int(req.InitialQuantity),
+		ManufacturingDate:  req.ManufacturingDate,
+		ManufacturingHour:  int(req.ManufacturingHour),
+		MinimumTemperature: int(req.MinimumTemperature),
+		ProductId:          int(req.ProductId),
+		SectionId:          int(req.SectionId),
+	}
+	id, err := p.productBatchesService.CreatePB(ctx, batch)
+	if err != nil {
+		return nil, err
+	}
+	batch.ID = id
+	return productBatchToPB(batch), nil
+}
+
+// ReportProducts reports every section when none is given, skipping the
+// ones without batches.
+func (p *ProductBatch) ReportProducts(req *pb.ReportProductsRequest, stream pb.ProductBatchService_ReportProductsServer) error {
+	ctx := stream.Context()
+	ids := make([]int, len(req.SectionIds))
+	for i, id := range req.SectionIds {
+		ids[i] = int(id)
+	}
+	all := len(ids) == 0
+	if all {
+		sections, err := p.sectionService.GetAll(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range sections {
+			ids = append(ids, s.ID)
+		}
+	}
+
+	for _, id := range ids {
+		report, err := p.productBatchesService.ReadPB(ctx, id)
+		if all && errors.Is(err, apperrors.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&pb.SectionProductsReport{
+			SectionId:       int64(report.SectionId),
+			SectionNumber:   int64(report.SectionNumber),
+			CurrentQuantity: int64(report.CurrentQuantity),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func productBatchToPB(b domain.Product_batches) *pb.ProductBatch {
+	return &pb.ProductBatch{
+		Id:                 int64(b.ID),
+		BatchNumber:        int64(b.BatchNumber),
+		CurrentQuantity:    int64(b.CurrentQuantity),
+		CurrentTemperature: int64(b.CurrentTemperature),
+		DueDate:            b.DueDate,
+		InitialQuantity:    int64(b.InitialQuantity),
+		ManufacturingDate:  b.ManufacturingDate,
+		ManufacturingHour:  int64(b.ManufacturingHour),
+		MinimumTemperature: int64(b.MinimumTemperature),
+		ProductId:          int64(b.ProductId),
+		SectionId:          int64(b.SectionId),
+	}
+}
@@ -0,0 +1,69 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/pb/melisprint/v1"
+	pbmock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/product_batches"
+	sectionmock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/section"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestReportProducts(t *testing.T) {
+	sections := &sectionmock.MockService{Db: sectionmock.MockRepository{DataMock: []domain.Section{
+		{ID: 1, SectionNumber: 1}, {ID: 2, SectionNumber: 2}, {ID: 3, SectionNumber: 3},
+	}}}
+	batches := &pbmock.MockService{Db: pbmock.MockRepository{DataMockRP: []domain.ReportProduct{
+		{SectionId: 1, SectionNumber: 1, CurrentQuantity: 10},
+		{SectionId: 3, SectionNumber: 3, CurrentQuantity: 30},
+	}}}
+	conn := dial(t, func(srv *grpc.Server) {
+		pb.RegisterProductBatchServiceServer(srv, NewProductBatch(batches, sections))
+	})
+	client := pb.NewProductBatchServiceClient(conn)
+
+	receive := func(t *testing.T, req *pb.ReportProductsRequest) ([]*pb.SectionProductsReport, error) {
+		stream, err := client.ReportProducts(context.Background(), req)
+		require.NoError(t, err)
+		var reports []*pb.SectionProductsReport
+		for {
+			r, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return reports, nil
+			}
+			if err != nil {
+				return reports, err
+			}
+			reports = append(reports, r)
+		}
+	}
+
+	t.Run("should stream every section holding batches", func(t *testing.T) {
+		reports, err := receive(t, &pb.ReportProductsRequest{})
+
+		require.NoError(t, err)
+		require.Len(t, reports, 2)
+		assert.Equal(t, int64(10), reports[0].CurrentQuantity)
+		assert.Equal(t, int64(30), reports[1].CurrentQuantity)
+	})
+	t.Run("should stream the given sections", func(t *testing.T) {
+		reports, err := receive(t, &pb.ReportProductsRequest{SectionIds: []int64{3}})
+
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Equal(t, int64(3), reports[0].SectionId)
+	})
+	t.Run("should fail on a given section without batches", func(t *testing.T) {
+		_, err := receive(t, &pb.ReportProductsRequest{SectionIds: []int64{2}})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
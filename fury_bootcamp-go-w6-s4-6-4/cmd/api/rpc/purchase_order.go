@@ -0,0 +1,77 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/purchase_orders"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/pb/melisprint/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type PurchaseOrder struct {
+	pb.UnimplementedPurchaseOrderServiceServer
+	purchaseOrderService purchase_orders.Service
+}
+
+func NewPurchaseOrder(s purchase_orders.Service) *PurchaseOrder {
+	return &PurchaseOrder{purchaseOrderService: s}
+}
+
+func (o *PurchaseOrder) CreatePurchaseOrder(ctx context.Context, req *pb.CreatePurchaseOrderRequest) (*pb.PurchaseOrder, error) {
+	if err := required(
+		field{"order_number", req.OrderNumber == ""},
+		field{"order_date", req.OrderDate == nil},
+		field{"tracking_code", req.TrackingCode == ""},
+		field{"buyer_id", req.BuyerId == 0},
+		field{"product_record_id", req.ProductRecordId == 0},
+	); err != nil {
+		return nil, err
+	}
+	if err := req.OrderDate.CheckValid(); err != nil {
+		return nil, apperrors.Validation("invalid order_date: " + err.Error())
+	}
+
+	date := req.OrderDate.AsTime()
+	order, err := o.purchaseOrderService.Save(ctx, req.OrderNumber, req.TrackingCode, int(req.BuyerId),
+		int(req.ProductRecordId), int(req.OrderStatusId), &date)
+	if err != nil {
+		return nil, err
+	}
+	return purchaseOrderToPB(order), nil
+}
+
+func (o *PurchaseOrder) ReportPurchaseOrders(req *pb.ReportPurchaseOrdersRequest, stream pb.PurchaseOrderService_ReportPurchaseOrdersServer) error {
+	reports, err := o.purchaseOrderService.GetAllByBuyerID(stream.Context(), int(req.GetBuyerId()))
+	if err != nil {
+		return err
+	}
+	for _, r := range reports {
+		if err := stream.Send(&pb.PurchaseOrdersReport{
+			BuyerId:             int64(r.ID),
+			CardNumberId:        r.CardNumberID,
+			FirstName:           r.FirstName,
+			LastName:            r.LastName,
+			PurchaseOrdersCount: int64(r.PurchaseOrdersCount),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func purchaseOrderToPB(o domain.PurchaseOrders) *pb.PurchaseOrder {
+	order := &pb.PurchaseOrder{
+		Id:              int64(o.ID),
+		OrderNumber:     o.OrderNumber,
+		TrackingCode:    o.TrackingCode,
+		BuyerId:         int64(o.BuyerID),
+		ProductRecordId: int64(o.ProductRecordID),
+		OrderStatusId:   int64(o.OrderStatusID),
+	}
+	if o.OrderDate != nil {
+		order.OrderDate = timestamppb.New(*o.OrderDate)
+	}
+	return order
+}
@@ -0,0 +1,147 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/section"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/pb/melisprint/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// defaultWatchInterval is the interval between temperature readings when
+	// the client doesn't set one.
+	defaultWatchInterval = time.Minute
+	// minWatchInterval keeps watchers from polling the database too often.
+	minWatchInterval = time.Second
+)
+
+type Section struct {
+	pb.UnimplementedSectionServiceServer
+	sectionService section.Service
+}
+
+func NewSection(s section.Service) *Section {
+	return &Section{sectionService: s}
+}
+
+func (s *Section) ListSections(ctx context.Context, _ *pb.ListSectionsRequest) (*pb.ListSectionsResponse, error) {
+	sections, err := s.sectionService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListSectionsResponse{Sections: make([]*pb.Section, len(sections))}
+	for i, sect := range sections {
+		resp.Sections[i] = sectionToPB(sect)
+	}
+	return resp, nil
+}
+
+func (s *Section) GetSection(ctx context.Context, req *pb.GetSectionRequest) (*pb.Section, error) {
+	sect, err := s.sectionService.Get(ctx, int(req.Id))
+	if err != nil {
+		return nil, err
+	}
+	return sectionToPB(sect), nil
+}
+
+func (s *Section) CreateSection(ctx context.Context, req *pb.CreateSectionRequest) (*pb.Section, error) {
+	if err := required(
+		field{"section_number", req.SectionNumber == 0},
+		field{"warehouse_id", req.WarehouseId == 0},
+		field{"product_type_id", req.ProductTypeId == 0},
+	); err != nil {
+		return nil, err
+	}
+
+	sect := domain.Section{
+		SectionNumber:      int(req.SectionNumber),
+		CurrentTemperature: int(req.CurrentTemperature),
+		MinimumTemperature: int(req.MinimumTemperature),
+		CurrentCapacity:    int(req.CurrentCapacity),
+		MinimumCapacity:    int(req.MinimumCapacity),
+		MaximumCapacity:    int(req.MaximumCapacity),
+		WarehouseID:        int(req.WarehouseId),
+		ProductTypeID:      int(req.ProductTypeId),
+	}
+	id, err := s.sectionService.Save(ctx, sect)
+	if err != nil {
+		return nil, err
+	}
+	sect.ID = id
+	return sectionToPB(sect), nil
+}
+
+func (s *Section) UpdateSection(ctx context.Context, req *pb.UpdateSectionRequest) (*pb.Section, error) {
+	sect, err := s.sectionService.Update(ctx, int(req.Id), int(req.SectionNumber), int(req.CurrentTemperature),
+		int(req.MinimumTemperature), int(req.CurrentCapacity), int(req.MinimumCapacity), int(req.MaximumCapacity),
+		int(req.WarehouseId), int(req.ProductTypeId))
+	if err != nil {
+		return nil, err
+	}
+	return sectionToPB(sect), nil
+}
+
+func (s *Section) DeleteSection(ctx context.Context, req *pb.DeleteSectionRequest) (*pb.DeleteSectionResponse, error) {
+	if err := s.sectionService.Delete(ctx, int(req.Id)); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteSectionResponse{}, nil
+}
+
+// WatchTemperatures reads the section every interval until the client goes
+// away. A failed reading ends the stream.
+func (s *Section) WatchTemperatures(req *pb.WatchTemperaturesRequest, stream pb.SectionService_WatchTemperaturesServer) error {
+	interval := defaultWatchInterval
+	if req.Interval != nil {
+		if err := req.Interval.CheckValid(); err != nil {
+			return apperrors.Validation("invalid interval: " + err.Error())
+		}
+		interval = req.Interval.AsDuration()
+	}
+	if interval < minWatchInterval {
+		return apperrors.Validation("interval must be at least " + minWatchInterval.String())
+	}
+
+	ctx := stream.Context()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		sect, err := s.sectionService.Get(ctx, int(req.SectionId))
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&pb.TemperatureReading{
+			SectionId:          int64(sect.ID),
+			CurrentTemperature: int64(sect.CurrentTemperature),
+			MinimumTemperature: int64(sect.MinimumTemperature),
+			BelowMinimum:       sect.CurrentTemperature < sect.MinimumTemperature,
+			ReadAt:             timestamppb.Now(),
+		}); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func sectionToPB(s domain.Section) *pb.Section {
+	return &pb.Section{
+		Id:                 int64(s.ID),
+		SectionNumber:      int64(s.SectionNumber),
+		CurrentTemperature: int64(s.CurrentTemperature),
+		MinimumTemperature: int64(s.MinimumTemperature),
+		CurrentCapacity:    int64(s.CurrentCapacity),
+		MinimumCapacity:    int64(s.MinimumCapacity),
+		MaximumCapacity:    int64(s.MaximumCapacity),
+		WarehouseId:        int64(s.WarehouseID),
+		ProductTypeId:      int64(s.ProductTypeID),
+	}
+}
@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/pb/melisprint/v1"
+	sectionmock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/section"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestWatchTemperatures(t *testing.T) {
+	service := &sectionmock.MockService{Db: sectionmock.MockRepository{DataMock: []domain.Section{
+		{ID: 1, SectionNumber: 1, CurrentTemperature: -2, MinimumTemperature: 0},
+	}}}
+	conn := dial(t, func(srv *grpc.Server) {
+		pb.RegisterSectionServiceServer(srv, NewSection(service))
+	})
+	client := pb.NewSectionServiceClient(conn)
+
+	t.Run("should send a reading right away and keep streaming", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stream, err := client.WatchTemperatures(ctx, &pb.WatchTemperaturesRequest{SectionId: 1, Interval: durationpb.New(time.Second)})
+		require.NoError(t, err)
+
+		start := time.Now()
+		first, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, int64(-2), first.CurrentTemperature)
+		assert.True(t, first.BelowMinimum)
+		assert.NotNil(t, first.ReadAt)
+
+		second, err := stream.Recv()
+		require.NoError(t, err)
+		assert.True(t, second.ReadAt.AsTime().After(first.ReadAt.AsTime()))
+	})
+	t.Run("should reject an interval under a second", func(t *testing.T) {
+		stream, err := client.WatchTemperatures(context.Background(), &pb.WatchTemperaturesRequest{SectionId: 1, Interval: durationpb.New(time.Millisecond)})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("should end the stream when the section doesn't exist", func(t *testing.T) {
+		stream, err := client.WatchTemperatures(context.Background(), &pb.WatchTemperaturesRequest{SectionId: 9})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
@@ -0,0 +1,96 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/seller"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/pb/melisprint/v1"
+)
+
+type Seller struct {
+	pb.UnimplementedSellerServiceServer
+	sellerService seller.Service
+}
+
+func NewSeller(s seller.Service) *Seller {
+	return &Seller{sellerService: s}
+}
+
+func (s *Seller) ListSellers(ctx context.Context, _ *pb.ListSellersRequest) (*pb.ListSellersResponse, error) {
+	sellers, err := s.sellerService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListSellersResponse{Sellers: make([]*pb.Seller, len(sellers))}
+	for i, sel := range sellers {
+		resp.Sellers[i] = sellerToPB(sel)
+	}
+	return resp, nil
+}
+
+func (s *Seller) GetSeller(ctx context.Context, req *pb.GetSellerRequest) (*pb.Seller, error) {
+	sel, err := s.sellerService.Get(ctx, int(req.Id))
+	if err != nil {
+		return nil, err
+	}
+	return sellerToPB(sel), nil
+}
+
+func (s *Seller) CreateSeller(ctx context.Context, req *pb.CreateSellerRequest) (*pb.Seller, error) {
+	if err := required(
+		field{"cid", req.Cid == 0},
+		field{"company_name", req.CompanyName == ""},
+		field{"address", req.Address == ""},
+		field{"telephone", req.Telephone == ""},
+		field{"locality_id", req.LocalityId == 0},
+	); err != nil {
+		return nil, err
+	}
+
+	id, err := s.sellerService.Save(ctx, int(req.Cid), int(req.LocalityId), req.CompanyName, req.Address, req.Telephone)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Seller{
+		Id:          int64(id),
+		Cid:         req.Cid,
+		CompanyName: req.CompanyName,
+		Address:     req.Address,
+		Telephone:   req.Telephone,
+		LocalityId:  req.LocalityId,
+	}, nil
+}
+
+func (s *Seller) UpdateSeller(ctx context.Context, req *pb.UpdateSellerRequest) (*pb.Seller, error) {
+	sel, err := s.sellerService.Update(ctx, domain.Seller{
+		ID:          int(req.Id),
+		CID:         int(req.Cid),
+		CompanyName: req.CompanyName,
+		Address:     req.Address,
+		Telephone:   req.Telephone,
+		LocalityID:  int(req.LocalityId),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sellerToPB(sel), nil
+}
+
+func (s *Seller) DeleteSeller(ctx context.Context, req *pb.DeleteSellerRequest) (*pb.DeleteSellerResponse, error) {
+	if err := s.sellerService.Delete(ctx, int(req.Id)); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteSellerResponse{}, nil
+}
+
+func sellerToPB(s domain.Seller) *pb.Seller {
+	return &pb.Seller{
+		Id:          int64(s.ID),
+		Cid:         int64(s.CID),
+		CompanyName: s.CompanyName,
+		Address:     s.Address,
+		Telephone:   s.Telephone,
+		LocalityId:  int64(s.LocalityID),
+	}
+}
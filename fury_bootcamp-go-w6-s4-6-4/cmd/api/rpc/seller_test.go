@@ -0,0 +1,89 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/seller"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/pb/melisprint/v1"
+	sellermock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/sellers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newSellerClient(t *testing.T, sellers []domain.Seller) pb.SellerServiceClient {
+	repo := &sellermock.MockRepository{DataMock: sellers}
+	service := seller.NewService(repo, events.Discard, logger.Discard())
+	conn := dial(t, func(srv *grpc.Server) {
+		pb.RegisterSellerServiceServer(srv, NewSeller(service))
+	})
+	return pb.NewSellerServiceClient(conn)
+}
+
+func TestSeller(t *testing.T) {
+	sellers := []domain.Seller{
+		{ID: 1, CID: 10, CompanyName: "Meli", Address: "Street 1", Telephone: "555", LocalityID: 1},
+		{ID: 2, CID: 20, CompanyName: "Libre", Address: "Street 2", Telephone: "556", LocalityID: 1},
+	}
+	ctx := context.Background()
+
+	t.Run("should list the sellers", func(t *testing.T) {
+		client := newSellerClient(t, sellers)
+
+		resp, err := client.ListSellers(ctx, &pb.ListSellersRequest{})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Sellers, 2)
+		assert.Equal(t, "Libre", resp.Sellers[1].CompanyName)
+	})
+	t.Run("should map a missing seller to NOT_FOUND", func(t *testing.T) {
+		client := newSellerClient(t, sellers)
+
+		_, err := client.GetSeller(ctx, &pb.GetSellerRequest{Id: 9})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+	t.Run("should create a seller", func(t *testing.T) {
+		client := newSellerClient(t, sellers)
+
+		s, err := client.CreateSeller(ctx, &pb.CreateSellerRequest{
+			Cid: 30, CompanyName: "Nueva", Address: "Street 3", Telephone: "557", LocalityId: 1,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), s.Id)
+		assert.Equal(t, "Nueva", s.CompanyName)
+	})
+	t.Run("should reject a seller without its required fields", func(t *testing.T) {
+		client := newSellerClient(t, sellers)
+
+		_, err := client.CreateSeller(ctx, &pb.CreateSellerRequest{Cid: 30})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "company_name, address, telephone, locality_id")
+	})
+	t.Run("should map a taken cid to ALREADY_EXISTS", func(t *testing.T) {
+		client := newSellerClient(t, sellers)
+
+		_, err := client.CreateSeller(ctx, &pb.CreateSellerRequest{
+			Cid: 10, CompanyName: "Meli", Address: "Street 1", Telephone: "555", LocalityId: 1,
+		})
+
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+	t.Run("should keep the fields an update leaves empty", func(t *testing.T) {
+		client := newSellerClient(t, sellers)
+
+		s, err := client.UpdateSeller(ctx, &pb.UpdateSellerRequest{Id: 1, Telephone: "999"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "999", s.Telephone)
+		assert.Equal(t, "Meli", s.CompanyName)
+	})
+}
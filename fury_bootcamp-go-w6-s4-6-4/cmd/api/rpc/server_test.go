@@ -0,0 +1,35 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/interceptor"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dial serves the services registered by register in memory, with the
+// error interceptors the API uses, and returns a client connection to them.
+func dial(t *testing.T, register func(*grpc.Server)) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(interceptor.UnaryErrors()),
+		grpc.StreamInterceptor(interceptor.StreamErrors()),
+	)
+	register(srv)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
@@ -0,0 +1,49 @@
+// Package rpc implements the gRPC services on top of the same services the
+// HTTP handlers use.
+package rpc
+
+import (
+	"strings"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+// field is a request field checked by required.
+type field struct {
+	name  string
+	empty bool
+}
+
+// required returns a validation error naming the empty fields, or nil when
+// none is.
+func required(fields ...field) error {
+	var missing []string
+	for _, f := range fields {
+		if f.empty {
+			missing = append(missing, f.name)
+		}
+	}
+	if len(missing) > 0 {
+		return apperrors.Validation("missing required fields: " + strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// intPtr converts an optional request field to the pointer the services
+// take for optional ints.
+func intPtr(v *int64) *int {
+	if v == nil {
+		return nil
+	}
+	i := int(*v)
+	return &i
+}
+
+// int64Ptr is the reverse of intPtr, for optional response fields.
+func int64Ptr(v *int) *int64 {
+	if v == nil {
+		return nil
+	}
+	i := int64(*v)
+	return &i
+}
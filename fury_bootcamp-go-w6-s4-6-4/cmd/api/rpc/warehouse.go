@@ -0,0 +1,115 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/warehouse"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/pb/melisprint/v1"
+)
+
+type Warehouse struct {
+	pb.UnimplementedWarehouseServiceServer
+	warehouseService warehouse.Service
+}
+
+func NewWarehouse(w warehouse.Service) *Warehouse {
+	return &Warehouse{warehouseService: w}
+}
+
+func (w *Warehouse) ListWarehouses(ctx context.Context, _ *pb.ListWarehousesRequest) (*pb.ListWarehousesResponse, error) {
+	warehouses, err := w.warehouseService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListWarehousesResponse{Warehouses: make([]*pb.Warehouse, len(warehouses))}
+	for i, wh := range warehouses {
+		resp.Warehouses[i] = warehouseToPB(wh)
+	}
+	return resp, nil
+}
+
+func (w *Warehouse) GetWarehouse(ctx context.Context, req *pb.GetWarehouseRequest) (*pb.Warehouse, error) {
+	wh, err := w.warehouseService.Get(ctx, int(req.Id))
+	if err != nil {
+		return nil, err
+	}
+	return warehouseToPB(wh), nil
+}
+
+func (w *Warehouse) CreateWarehouse(ctx context.Context, req *pb.CreateWarehouseRequest) (*pb.Warehouse, error) {
+	if err := required(
+		field{"address", req.Address == ""},
+		field{"telephone", req.Telephone == ""},
+		field{"warehouse_code", req.WarehouseCode == ""},
+	); err != nil {
+		return nil, err
+	}
+	if err := validateWarehouseLimits(&req.MinimumCapacity, &req.MinimumTemperature); err != nil {
+		return nil, err
+	}
+
+	capacity, temperature := int(req.MinimumCapacity), int(req.MinimumTemperature)
+	wh := domain.Warehouse{
+		Address:            req.Address,
+		Telephone:          req.Telephone,
+		WarehouseCode:      req.WarehouseCode,
+		MinimumCapacity:    &capacity,
+		MinimumTemperature: &temperature,
+	}
+	id, err := w.warehouseService.Save(ctx, wh)
+	if err != nil {
+		return nil, err
+	}
+	wh.ID = id
+	return warehouseToPB(wh), nil
+}
+
+func (w *Warehouse) UpdateWarehouse(ctx context.Context, req *pb.UpdateWarehouseRequest) (*pb.Warehouse, error) {
+	if err := validateWarehouseLimits(req.MinimumCapacity, req.MinimumTemperature); err != nil {
+		return nil, err
+	}
+
+	wh, err := w.warehouseService.Update(ctx, domain.Warehouse{
+		Address:            req.Address,
+		Telephone:          req.Telephone,
+		WarehouseCode:      req.WarehouseCode,
+		MinimumCapacity:    intPtr(req.MinimumCapacity),
+		MinimumTemperature: intPtr(req.MinimumTemperature),
+	}, int(req.Id))
+	if err != nil {
+		return nil, err
+	}
+	return warehouseToPB(wh), nil
+}
+
+func (w *Warehouse) DeleteWarehouse(ctx context.Context, req *pb.DeleteWarehouseRequest) (*pb.DeleteWarehouseResponse, error) {
+	if err := w.warehouseService.Delete(ctx, int(req.Id)); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteWarehouseResponse{}, nil
+}
+
+// validateWarehouseLimits applies the limits the HTTP handler enforces to
+// the ones that are set.
+func validateWarehouseLimits(capacity, temperature *int64) error {
+	if capacity != nil && *capacity < 0 {
+		return apperrors.Validation("minimum capacity must be greater than 0")
+	}
+	if temperature != nil && (*temperature > 20 || *temperature < -10) {
+		return apperrors.Validation("minimum temperature must be between -10 and 20")
+	}
+	return nil
+}
+
+func warehouseToPB(w domain.Warehouse) *pb.Warehouse {
+	return &pb.Warehouse{
+		Id:                 int64(w.ID),
+		Address:            w.Address,
+		Telephone:          w.Telephone,
+		WarehouseCode:      w.WarehouseCode,
+		MinimumCapacity:    int64Ptr(w.MinimumCapacity),
+		MinimumTemperature: int64Ptr(w.MinimumTemperature),
+	}
+}
@@ -0,0 +1,59 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/pb/melisprint/v1"
+	warehousemock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/warehouse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWarehouse(t *testing.T) {
+	capacity, temperature := 10, 5
+	service := warehousemock.NewServiceWarehouse([]domain.Warehouse{
+		{ID: 1, Address: "Street 1", Telephone: "555", WarehouseCode: "W-1", MinimumCapacity: &capacity, MinimumTemperature: &temperature},
+	})
+	conn := dial(t, func(srv *grpc.Server) {
+		pb.RegisterWarehouseServiceServer(srv, NewWarehouse(service))
+	})
+	client := pb.NewWarehouseServiceClient(conn)
+	ctx := context.Background()
+
+	t.Run("should get a warehouse", func(t *testing.T) {
+		w, err := client.GetWarehouse(ctx, &pb.GetWarehouseRequest{Id: 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, "W-1", w.WarehouseCode)
+		assert.Equal(t, int64(5), w.GetMinimumTemperature())
+	})
+	t.Run("should create a warehouse", func(t *testing.T) {
+		w, err := client.CreateWarehouse(ctx, &pb.CreateWarehouseRequest{
+			Address: "Street 2", Telephone: "556", WarehouseCode: "W-2", MinimumCapacity: 20, MinimumTemperature: -5,
+		})
+
+		require.NoError(t, err)
+		assert.NotZero(t, w.Id)
+		assert.Equal(t, int64(-5), w.GetMinimumTemperature())
+	})
+	t.Run("should reject a minimum temperature out of range", func(t *testing.T) {
+		_, err := client.CreateWarehouse(ctx, &pb.CreateWarehouseRequest{
+			Address: "Street 2", Telephone: "556", WarehouseCode: "W-3", MinimumCapacity: 20, MinimumTemperature: 30,
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("should change only the limits an update sets", func(t *testing.T) {
+		zero := int64(0)
+		w, err := client.UpdateWarehouse(ctx, &pb.UpdateWarehouseRequest{Id: 1, MinimumTemperature: &zero})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), w.GetMinimumTemperature())
+		assert.Equal(t, int64(10), w.GetMinimumCapacity())
+	})
+}
@@ -0,0 +1,212 @@
+// Command edi converts EDI interchanges to JSON and back, and moves them in
+// and out of a running API.
+//
+//	go run ./cmd/edi read 856.edi                  # X12 or EDIFACT to JSON
+//	go run ./cmd/edi write interchange.json        # JSON to X12
+//	go run ./cmd/edi import --section-id 1 --employee-id 4 856.edi
+//	go run ./cmd/edi export receiptAdvice 7 > 944.edi
+//	go run ./cmd/edi export shipNotice --carry-id 1 3 > 856.edi
+//
+// read and write take standard input when no file is given.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/edi"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+)
+
+const usage = `usage: edi read [file]
+       edi write [file]
+       edi import [--api url] [--site id] [--employee-id id --section-id id] file
+       edi export [--api url] [--site id] [--carry-id id] receiptAdvice|shipNotice id`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	var err error
+	switch os.Args[1] {
+	case "read":
+		err = read(os.Args[2:])
+	case "write":
+		err = write(os.Args[2:])
+	case "import":
+		err = importFile(os.Args[2:])
+	case "export":
+		err = export(os.Args[2:])
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "edi:", err)
+		os.Exit(1)
+	}
+}
+
+func read(args []string) error {
+	data, err := input(args)
+	if err != nil {
+		return err
+	}
+	ic, err := edi.Read(data)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(ic)
+}
+
+func write(args []string) error {
+	data, err := input(args)
+	if err != nil {
+		return err
+	}
+	var ic edi.Interchange
+	if err := json.Unmarshal(data, &ic); err != nil {
+		return err
+	}
+	written, err := edi.WriteX12(ic, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(written)
+	return err
+}
+
+// input reads the file named in args, or standard input.
+func input(args []string) ([]byte, error) {
+	if len(args) == 0 || args[0] == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(args[0])
+}
+
+// client is what import and export need to reach the API.
+type client struct {
+	api  string
+	site string
+}
+
+func (c *client) flags(fs *flag.FlagSet) {
+	fs.StringVar(&c.api, "api", "http://localhost:8080", "base URL of the API")
+	fs.StringVar(&c.site, "site", "", "site to act on, the default site of the API if empty")
+}
+
+// do sends req and returns the body of a successful response, or the
+// problem the API answered as the error.
+func (c *client) do(req *http.Request) ([]byte, error) {
+	if c.site != "" {
+		req.Header.Set(tenant.Header, c.site)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+	return body, nil
+}
+
+func importFile(args []string) error {
+	var c client
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	c.flags(fs)
+	employeeID := fs.Int("employee-id", 0, "employee that receives the notices at once")
+	sectionID := fs.Int("section-id", 0, "section the notices are received in")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return errors.New("import takes a single file")
+	}
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("file", filepath.Base(fs.Arg(0)))
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(data); err != nil {
+		return err
+	}
+	if *employeeID != 0 || *sectionID != 0 {
+		w.WriteField("employee_id", strconv.Itoa(*employeeID))
+		w.WriteField("section_id", strconv.Itoa(*sectionID))
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.api+"/api/v1/asns/edi", &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	imported, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(imported)
+	return err
+}
+
+func export(args []string) error {
+	var c client
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	c.flags(fs)
+	carryID := fs.Int("carry-id", 0, "carry that ships the purchase order, for shipNotice")
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		return errors.New("export takes the document and the id")
+	}
+	id, err := strconv.Atoi(fs.Arg(1))
+	if err != nil {
+		return fmt.Errorf("id: %w", err)
+	}
+
+	var target string
+	switch fs.Arg(0) {
+	case "receiptAdvice":
+		target = fmt.Sprintf("%s/api/v1/inboundOrders/%d/receiptAdvice", c.api, id)
+	case "shipNotice":
+		target = fmt.Sprintf("%s/api/v1/purchaseOrders/%d/shipNotice?%s", c.api, id, url.Values{"carry_id": {strconv.Itoa(*carryID)}}.Encode())
+	default:
+		return fmt.Errorf("unknown document %q, want receiptAdvice or shipNotice", fs.Arg(0))
+	}
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+
+	written, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(written)
+	return err
+}
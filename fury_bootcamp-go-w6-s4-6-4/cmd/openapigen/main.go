@@ -0,0 +1,42 @@
+// Command openapigen writes the Go types of the schemas of an OpenAPI 3.1
+// document, for go:generate:
+//
+//	go run ./cmd/openapigen -o pkg/api/api.gen.go docs/openapi.yaml
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/openapi"
+)
+
+func main() {
+	out := flag.String("o", "", "file to write, standard output if empty")
+	pkg := flag.String("package", "api", "package of the generated file")
+	flag.Parse()
+	if err := run(*out, *pkg, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "openapigen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(out, pkg string, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("takes the document to generate from")
+	}
+	doc, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	src, err := openapi.Generate(doc, pkg)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
@@ -0,0 +1,60 @@
+// Command webhookreceiver is a webhook subscriber for trying deliveries
+// locally. It verifies the signature of every delivery, logs it, and answers
+// with the status given by --status, so retries and dead-lettering can be
+// exercised by making it fail.
+//
+//	go run ./cmd/webhookreceiver --secret 0123456789abcdef --status 500
+package main
+
+import (
+	"flag"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/webhook"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+)
+
+func main() {
+	addr := flag.String("addr", ":9999", "address to listen on")
+	secret := flag.String("secret", "", "secret of the subscription, to verify the signatures")
+	status := flag.Int("status", http.StatusOK, "status to answer verified deliveries with")
+	tolerance := flag.Duration("tolerance", 5*time.Minute, "maximum age of a delivery timestamp")
+	flag.Parse()
+
+	log := logger.New(os.Stdout, slog.LevelInfo)
+	if *secret == "" {
+		log.Error("--secret is required")
+		os.Exit(2)
+	}
+
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		err = webhook.Verify(*secret, r.Header.Get(webhook.HeaderSignature), r.Header.Get(webhook.HeaderTimestamp), body, *tolerance, time.Now())
+		if err != nil {
+			log.Warn("delivery rejected", "event_id", r.Header.Get(webhook.HeaderEventID), "error", err)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		log.Info("delivery received",
+			"event_id", r.Header.Get(webhook.HeaderEventID),
+			"event_type", r.Header.Get(webhook.HeaderEventType),
+			"body", string(body),
+			"status", *status,
+		)
+		w.WriteHeader(*status)
+	})
+
+	log.Info("listening", "addr", *addr)
+	if err := http.ListenAndServe(*addr, nil); err != nil {
+		log.Error("serving", "error", err)
+		os.Exit(1)
+	}
+}
@@ -17,15 +17,31 @@
   CREATE SCHEMA IF NOT EXISTS `bgow6s464` DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci ;
   USE `bgow6s464` ;
 
+  -- -----------------------------------------------------
+  -- Table `bgow6s464`.`schema_migrations`
+  --
+  -- The migrations applied. This file is the schema with
+  -- every migration in `migrations/` applied, so it records
+  -- them all below.
+  -- -----------------------------------------------------
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`schema_migrations` (
+    `version` INT NOT NULL,
+    `applied_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`version`))
+  ENGINE = InnoDB;
+
+
   -- -----------------------------------------------------
   -- Table `bgow6s464`.`buyers`
   -- -----------------------------------------------------
   CREATE TABLE IF NOT EXISTS `bgow6s464`.`buyers` (
     `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
     `card_number_id` TEXT NOT NULL,
     `first_name` TEXT NOT NULL,
     `last_name` TEXT NOT NULL,
-    PRIMARY KEY (`id`))
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE)
   ENGINE = InnoDB
   DEFAULT CHARACTER SET = utf8mb4
   COLLATE = utf8mb4_0900_ai_ci;
@@ -36,12 +52,14 @@
   -- -----------------------------------------------------
   CREATE TABLE IF NOT EXISTS `bgow6s464`.`warehouses` (
     `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
     `address` TEXT NULL DEFAULT NULL,
     `telephone` TEXT NULL DEFAULT NULL,
     `warehouse_code` TEXT NULL DEFAULT NULL,
     `minimum_capacity` INT NULL DEFAULT NULL,
     `minimum_temperature` INT NULL DEFAULT NULL,
-    PRIMARY KEY (`id`))
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE)
   ENGINE = InnoDB
   DEFAULT CHARACTER SET = utf8mb4
   COLLATE = utf8mb4_0900_ai_ci;
@@ -52,15 +70,17 @@
   -- -----------------------------------------------------
   CREATE TABLE IF NOT EXISTS `bgow6s464`.`employees` (
     `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
     `card_number_id` TEXT NOT NULL,
     `first_name` TEXT NOT NULL,
     `last_name` TEXT NOT NULL,
     `warehouse_id` INT NOT NULL,
     PRIMARY KEY (`id`),
-    INDEX `fk_employees_warehouses1_idx` (`warehouse_id` ASC) VISIBLE,
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    INDEX `fk_employees_warehouses1_idx` (`site_id` ASC, `warehouse_id` ASC) VISIBLE,
     CONSTRAINT `fk_employees_warehouses1`
-      FOREIGN KEY (`warehouse_id`)
-      REFERENCES `bgow6s464`.`warehouses` (`id`)
+      FOREIGN KEY (`site_id`, `warehouse_id`)
+      REFERENCES `bgow6s464`.`warehouses` (`site_id`, `id`)
       ON DELETE CASCADE
       ON UPDATE CASCADE)
   ENGINE = InnoDB
@@ -68,16 +88,59 @@
   COLLATE = utf8mb4_0900_ai_ci;
 
 
+  -- -----------------------------------------------------
+  -- Table `bgow6s464`.`countries`
+  -- -----------------------------------------------------
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`countries` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
+    `country_name` VARCHAR(45) NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    UNIQUE INDEX `site_id_country_name_UNIQUE` (`site_id` ASC, `country_name` ASC) VISIBLE)
+  ENGINE = InnoDB
+  DEFAULT CHARACTER SET = utf8mb4
+  COLLATE = utf8mb4_0900_ai_ci;
+
+
+  -- -----------------------------------------------------
+  -- Table `bgow6s464`.`provinces`
+  -- -----------------------------------------------------
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`provinces` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
+    `province_name` VARCHAR(45) NOT NULL,
+    `country_id` INT NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    UNIQUE INDEX `country_id_province_name_UNIQUE` (`site_id` ASC, `country_id` ASC, `province_name` ASC) VISIBLE,
+    CONSTRAINT `fk_provinces_countries1`
+      FOREIGN KEY (`site_id`, `country_id`)
+      REFERENCES `bgow6s464`.`countries` (`site_id`, `id`)
+      ON DELETE RESTRICT
+      ON UPDATE CASCADE)
+  ENGINE = InnoDB
+  DEFAULT CHARACTER SET = utf8mb4
+  COLLATE = utf8mb4_0900_ai_ci;
+
+
   -- -----------------------------------------------------
   -- Table `bgow6s464`.`locality`
   -- -----------------------------------------------------
   CREATE TABLE IF NOT EXISTS `bgow6s464`.`locality` (
-    `id` INT NOT NULL,
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
     `locality_name` VARCHAR(45) NULL,
-    `province_name` VARCHAR(45) NULL,
-    `country_name` VARCHAR(45) NULL,
+    `province_id` INT NOT NULL,
     UNIQUE INDEX `id_UNIQUE` (`id` ASC) VISIBLE,
-    PRIMARY KEY (`id`))
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    INDEX `fk_locality_provinces1_idx` (`site_id` ASC, `province_id` ASC) VISIBLE,
+    CONSTRAINT `fk_locality_provinces1`
+      FOREIGN KEY (`site_id`, `province_id`)
+      REFERENCES `bgow6s464`.`provinces` (`site_id`, `id`)
+      ON DELETE RESTRICT
+      ON UPDATE CASCADE)
   ENGINE = InnoDB;
 
 
@@ -86,16 +149,18 @@
   -- -----------------------------------------------------
   CREATE TABLE IF NOT EXISTS `bgow6s464`.`seller` (
     `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
     `cid` INT NOT NULL,
     `company_name` TEXT NOT NULL,
     `address` TEXT NOT NULL,
     `telephone` VARCHAR(15) NOT NULL,
     `locality_id` INT NOT NULL,
     PRIMARY KEY (`id`),
-    INDEX `fk_sellers_locality_idx` (`locality_id` ASC) VISIBLE,
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    INDEX `fk_sellers_locality_idx` (`site_id` ASC, `locality_id` ASC) VISIBLE,
     CONSTRAINT `fk_sellers_locality`
-      FOREIGN KEY (`locality_id`)
-      REFERENCES `bgow6s464`.`locality` (`id`)
+      FOREIGN KEY (`site_id`, `locality_id`)
+      REFERENCES `bgow6s464`.`locality` (`site_id`, `id`)
       ON DELETE CASCADE
       ON UPDATE CASCADE)
   ENGINE = InnoDB
@@ -108,6 +173,7 @@
   -- -----------------------------------------------------
   CREATE TABLE IF NOT EXISTS `bgow6s464`.`products` (
     `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
     `description` TEXT NOT NULL,
     `expiration_rate` FLOAT NOT NULL,
     `freezing_rate` FLOAT NOT NULL,
@@ -120,10 +186,11 @@
     `product_type_id` INT NOT NULL,
     `seller_id` INT NOT NULL,
     PRIMARY KEY (`id`),
-    INDEX `fk_products_sellers1_idx` (`seller_id` ASC) VISIBLE,
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    INDEX `fk_products_sellers1_idx` (`site_id` ASC, `seller_id` ASC) VISIBLE,
     CONSTRAINT `fk_products_seller1`
-      FOREIGN KEY (`seller_id`)
-      REFERENCES `bgow6s464`.`seller` (`id`)
+      FOREIGN KEY (`site_id`, `seller_id`)
+      REFERENCES `bgow6s464`.`seller` (`site_id`, `id`)
       ON DELETE CASCADE
       ON UPDATE CASCADE)
   ENGINE = InnoDB
@@ -137,6 +204,7 @@
   -- -----------------------------------------------------
   CREATE TABLE IF NOT EXISTS `bgow6s464`.`sections` (
     `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
     `section_number` INT NOT NULL,
     `current_temperature` INT NOT NULL,
     `minimum_temperature` INT NOT NULL,
@@ -145,7 +213,14 @@
     `maximum_capacity` INT NOT NULL,
     `warehouse_id` INT NOT NULL,
     `id_product_type` INT NOT NULL,
-    PRIMARY KEY (`id`))
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    INDEX `fk_sections_warehouses1_idx` (`site_id` ASC, `warehouse_id` ASC) VISIBLE,
+    CONSTRAINT `fk_sections_warehouses1`
+      FOREIGN KEY (`site_id`, `warehouse_id`)
+      REFERENCES `bgow6s464`.`warehouses` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE)
   ENGINE = InnoDB
   DEFAULT CHARACTER SET = utf8mb4
   COLLATE = utf8mb4_0900_ai_ci;
@@ -156,17 +231,19 @@
   -- -----------------------------------------------------
   CREATE TABLE IF NOT EXISTS `bgow6s464`.`carries` (
     `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
     `cid` VARCHAR(45) NULL,
     `company_name` VARCHAR(45) NULL,
     `address` VARCHAR(45) NULL,
     `telephone` VARCHAR(45) NULL,
     `locality_id` INT NOT NULL,
     PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
     UNIQUE INDEX `id_UNIQUE` (`id` ASC) VISIBLE,
-    INDEX `fk_carries_locality1_idx` (`locality_id` ASC) VISIBLE,
+    INDEX `fk_carries_locality1_idx` (`site_id` ASC, `locality_id` ASC) VISIBLE,
     CONSTRAINT `fk_carries_locality1`
-      FOREIGN KEY (`locality_id`)
-      REFERENCES `bgow6s464`.`locality` (`id`)
+      FOREIGN KEY (`site_id`, `locality_id`)
+      REFERENCES `bgow6s464`.`locality` (`site_id`, `id`)
       ON DELETE CASCADE
       ON UPDATE CASCADE)
   ENGINE = InnoDB;
@@ -177,6 +254,7 @@
   -- -----------------------------------------------------
   CREATE TABLE IF NOT EXISTS `bgow6s464`.`product_batches` (
     `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
     `batch_number` VARCHAR(45) NULL,
     `current_quantity` INT NULL,
     `current_temperature` INT NULL,
@@ -188,17 +266,18 @@
     `sections_id` INT NOT NULL,
     `products_id` INT NOT NULL,
     PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
     UNIQUE INDEX `id_UNIQUE` (`id` ASC) VISIBLE,
-    INDEX `fk_product_batches_sections1_idx` (`sections_id` ASC) VISIBLE,
-    INDEX `fk_product_batches_products1_idx` (`products_id` ASC) VISIBLE,
+    INDEX `fk_product_batches_sections1_idx` (`site_id` ASC, `sections_id` ASC) VISIBLE,
+    INDEX `fk_product_batches_products1_idx` (`site_id` ASC, `products_id` ASC) VISIBLE,
     CONSTRAINT `fk_product_batches_sections1`
-      FOREIGN KEY (`sections_id`)
-      REFERENCES `bgow6s464`.`sections` (`id`)
+      FOREIGN KEY (`site_id`, `sections_id`)
+      REFERENCES `bgow6s464`.`sections` (`site_id`, `id`)
       ON DELETE CASCADE
       ON UPDATE CASCADE,
     CONSTRAINT `fk_product_batches_products1`
-      FOREIGN KEY (`products_id`)
-      REFERENCES `bgow6s464`.`products` (`id`)
+      FOREIGN KEY (`site_id`, `products_id`)
+      REFERENCES `bgow6s464`.`products` (`site_id`, `id`)
       ON DELETE CASCADE
       ON UPDATE CASCADE)
   ENGINE = InnoDB;
@@ -209,16 +288,18 @@
   -- -----------------------------------------------------
   CREATE TABLE IF NOT EXISTS `bgow6s464`.`product_records` (
     `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
     `last_update_date` DATETIME NULL,
     `purchase_price` FLOAT NULL,
     `sale_price` FLOAT NULL,
     `products_id` INT NOT NULL,
     PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
     UNIQUE INDEX `id_UNIQUE` (`id` ASC) VISIBLE,
-    INDEX `fk_product_records_products1_idx` (`products_id` ASC) VISIBLE,
+    INDEX `fk_product_records_products1_idx` (`site_id` ASC, `products_id` ASC) VISIBLE,
     CONSTRAINT `fk_product_records_products1`
-      FOREIGN KEY (`products_id`)
-      REFERENCES `bgow6s464`.`products` (`id`)
+      FOREIGN KEY (`site_id`, `products_id`)
+      REFERENCES `bgow6s464`.`products` (`site_id`, `id`)
       ON DELETE CASCADE
       ON UPDATE CASCADE)
   ENGINE = InnoDB;
@@ -229,29 +310,182 @@
   -- -----------------------------------------------------
   CREATE TABLE IF NOT EXISTS `bgow6s464`.`inbound_orders` (
     `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
     `order_date` DATETIME NULL,
     `order_number` VARCHAR(45) NULL,
     `employee_id` INT NOT NULL,
     `warehouse_id` INT NOT NULL,
-    `product_batch_id` INT NOT NULL,
+    `product_batch_id` INT NULL,
+    `status` VARCHAR(20) NOT NULL DEFAULT 'open',
     PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
     UNIQUE INDEX `id_UNIQUE` (`id` ASC) VISIBLE,
-    INDEX `fk_inbound_orders_employees1_idx` (`employee_id` ASC) VISIBLE,
-    INDEX `fk_inbound_orders_warehouses1_idx` (`warehouse_id` ASC) VISIBLE,
-    INDEX `fk_inbound_orders_product_batches1_idx` (`product_batch_id` ASC) VISIBLE,
+    INDEX `fk_inbound_orders_employees1_idx` (`site_id` ASC, `employee_id` ASC) VISIBLE,
+    INDEX `fk_inbound_orders_warehouses1_idx` (`site_id` ASC, `warehouse_id` ASC) VISIBLE,
+    INDEX `fk_inbound_orders_product_batches1_idx` (`site_id` ASC, `product_batch_id` ASC) VISIBLE,
     CONSTRAINT `fk_inbound_orders_employees1`
-      FOREIGN KEY (`employee_id`)
-      REFERENCES `bgow6s464`.`employees` (`id`)
+      FOREIGN KEY (`site_id`, `employee_id`)
+      REFERENCES `bgow6s464`.`employees` (`site_id`, `id`)
       ON DELETE CASCADE
       ON UPDATE CASCADE,
     CONSTRAINT `fk_inbound_orders_warehouses1`
-      FOREIGN KEY (`warehouse_id`)
-      REFERENCES `bgow6s464`.`warehouses` (`id`)
+      FOREIGN KEY (`site_id`, `warehouse_id`)
+      REFERENCES `bgow6s464`.`warehouses` (`site_id`, `id`)
       ON DELETE CASCADE
       ON UPDATE CASCADE,
     CONSTRAINT `fk_inbound_orders_product_batches1`
-      FOREIGN KEY (`product_batch_id`)
-      REFERENCES `bgow6s464`.`product_batches` (`id`)
+      FOREIGN KEY (`site_id`, `product_batch_id`)
+      REFERENCES `bgow6s464`.`product_batches` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE)
+  ENGINE = InnoDB;
+
+  -- -----------------------------------------------------
+  -- Table `bgow6s464`.`inbound_order_lines`
+  -- -----------------------------------------------------
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`inbound_order_lines` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
+    `inbound_order_id` INT NOT NULL,
+    `products_id` INT NOT NULL,
+    `batch_number` VARCHAR(45) NULL,
+    `expected_quantity` INT NOT NULL,
+    `received_quantity` INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    INDEX `fk_inbound_order_lines_inbound_orders1_idx` (`site_id` ASC, `inbound_order_id` ASC) VISIBLE,
+    INDEX `fk_inbound_order_lines_products1_idx` (`site_id` ASC, `products_id` ASC) VISIBLE,
+    CONSTRAINT `fk_inbound_order_lines_inbound_orders1`
+      FOREIGN KEY (`site_id`, `inbound_order_id`)
+      REFERENCES `bgow6s464`.`inbound_orders` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE,
+    CONSTRAINT `fk_inbound_order_lines_products1`
+      FOREIGN KEY (`site_id`, `products_id`)
+      REFERENCES `bgow6s464`.`products` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE)
+  ENGINE = InnoDB;
+
+
+  -- -----------------------------------------------------
+  -- Table `bgow6s464`.`inbound_order_receipts`
+  -- -----------------------------------------------------
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`inbound_order_receipts` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
+    `inbound_order_id` INT NOT NULL,
+    `received_at` DATETIME NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    INDEX `fk_inbound_order_receipts_inbound_orders1_idx` (`site_id` ASC, `inbound_order_id` ASC) VISIBLE,
+    CONSTRAINT `fk_inbound_order_receipts_inbound_orders1`
+      FOREIGN KEY (`site_id`, `inbound_order_id`)
+      REFERENCES `bgow6s464`.`inbound_orders` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE)
+  ENGINE = InnoDB;
+
+
+  -- -----------------------------------------------------
+  -- Table `bgow6s464`.`inbound_order_receipt_lines`
+  -- -----------------------------------------------------
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`inbound_order_receipt_lines` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
+    `receipt_id` INT NOT NULL,
+    `line_id` INT NOT NULL,
+    `product_batch_id` INT NOT NULL,
+    `quantity` INT NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `fk_inbound_order_receipt_lines_receipts1_idx` (`site_id` ASC, `receipt_id` ASC) VISIBLE,
+    INDEX `fk_inbound_order_receipt_lines_lines1_idx` (`site_id` ASC, `line_id` ASC) VISIBLE,
+    INDEX `fk_inbound_order_receipt_lines_product_batches1_idx` (`site_id` ASC, `product_batch_id` ASC) VISIBLE,
+    CONSTRAINT `fk_inbound_order_receipt_lines_receipts1`
+      FOREIGN KEY (`site_id`, `receipt_id`)
+      REFERENCES `bgow6s464`.`inbound_order_receipts` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE,
+    CONSTRAINT `fk_inbound_order_receipt_lines_lines1`
+      FOREIGN KEY (`site_id`, `line_id`)
+      REFERENCES `bgow6s464`.`inbound_order_lines` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE,
+    CONSTRAINT `fk_inbound_order_receipt_lines_product_batches1`
+      FOREIGN KEY (`site_id`, `product_batch_id`)
+      REFERENCES `bgow6s464`.`product_batches` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE)
+  ENGINE = InnoDB;
+
+
+  -- -----------------------------------------------------
+  -- Table `bgow6s464`.`asns`
+  -- -----------------------------------------------------
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`asns` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
+    `asn_number` VARCHAR(45) NOT NULL,
+    `seller_id` INT NOT NULL,
+    `warehouse_id` INT NOT NULL,
+    `eta` DATE NOT NULL,
+    `status` VARCHAR(20) NOT NULL DEFAULT 'pending',
+    `inbound_order_id` INT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    UNIQUE INDEX `asn_number_UNIQUE` (`site_id` ASC, `seller_id` ASC, `asn_number` ASC) VISIBLE,
+    INDEX `status_eta_idx` (`site_id` ASC, `status` ASC, `eta` ASC) VISIBLE,
+    INDEX `fk_asns_warehouses1_idx` (`site_id` ASC, `warehouse_id` ASC) VISIBLE,
+    INDEX `fk_asns_inbound_orders1_idx` (`site_id` ASC, `inbound_order_id` ASC) VISIBLE,
+    CONSTRAINT `fk_asns_seller1`
+      FOREIGN KEY (`site_id`, `seller_id`)
+      REFERENCES `bgow6s464`.`seller` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE,
+    CONSTRAINT `fk_asns_warehouses1`
+      FOREIGN KEY (`site_id`, `warehouse_id`)
+      REFERENCES `bgow6s464`.`warehouses` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE,
+    CONSTRAINT `fk_asns_inbound_orders1`
+      FOREIGN KEY (`site_id`, `inbound_order_id`)
+      REFERENCES `bgow6s464`.`inbound_orders` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE)
+  ENGINE = InnoDB;
+
+
+  -- -----------------------------------------------------
+  -- Table `bgow6s464`.`asn_lines`
+  -- -----------------------------------------------------
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`asn_lines` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
+    `asn_id` INT NOT NULL,
+    `products_id` INT NOT NULL,
+    `quantity` INT NOT NULL,
+    `batch_number` VARCHAR(45) NULL,
+    `manufacturing_date` DATE NOT NULL,
+    `due_date` DATE NOT NULL,
+    `product_batch_id` INT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    INDEX `fk_asn_lines_asns1_idx` (`site_id` ASC, `asn_id` ASC) VISIBLE,
+    INDEX `fk_asn_lines_products1_idx` (`site_id` ASC, `products_id` ASC) VISIBLE,
+    INDEX `fk_asn_lines_product_batches1_idx` (`site_id` ASC, `product_batch_id` ASC) VISIBLE,
+    CONSTRAINT `fk_asn_lines_asns1`
+      FOREIGN KEY (`site_id`, `asn_id`)
+      REFERENCES `bgow6s464`.`asns` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE,
+    CONSTRAINT `fk_asn_lines_products1`
+      FOREIGN KEY (`site_id`, `products_id`)
+      REFERENCES `bgow6s464`.`products` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE,
+    CONSTRAINT `fk_asn_lines_product_batches1`
+      FOREIGN KEY (`site_id`, `product_batch_id`)
+      REFERENCES `bgow6s464`.`product_batches` (`site_id`, `id`)
       ON DELETE CASCADE
       ON UPDATE CASCADE)
   ENGINE = InnoDB;
@@ -262,6 +496,7 @@
   -- -----------------------------------------------------
   CREATE TABLE IF NOT EXISTS `bgow6s464`.`purchase_orders` (
     `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
     `order_number` VARCHAR(45) NULL,
     `order_date` DATETIME NULL,
     `tracking_code` VARCHAR(45) NULL,
@@ -269,21 +504,174 @@
     `product_records_id` INT NOT NULL,
     `order_status_id` INT NULL,
     PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
     UNIQUE INDEX `id_UNIQUE` (`id` ASC) VISIBLE,
-    INDEX `fk_purchase_orders_buyers1_idx` (`buyers_id` ASC) VISIBLE,
-    INDEX `fk_purchase_orders_product_records1_idx` (`product_records_id` ASC) VISIBLE,
+    INDEX `fk_purchase_orders_buyers1_idx` (`site_id` ASC, `buyers_id` ASC) VISIBLE,
+    INDEX `fk_purchase_orders_product_records1_idx` (`site_id` ASC, `product_records_id` ASC) VISIBLE,
     CONSTRAINT `fk_purchase_orders_buyers1`
-      FOREIGN KEY (`buyers_id`)
-      REFERENCES `bgow6s464`.`buyers` (`id`)
+      FOREIGN KEY (`site_id`, `buyers_id`)
+      REFERENCES `bgow6s464`.`buyers` (`site_id`, `id`)
       ON DELETE CASCADE
       ON UPDATE CASCADE,
     CONSTRAINT `fk_purchase_orders_product_records1`
-      FOREIGN KEY (`product_records_id`)
-      REFERENCES `bgow6s464`.`product_records` (`id`)
+      FOREIGN KEY (`site_id`, `product_records_id`)
+      REFERENCES `bgow6s464`.`product_records` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE)
+  ENGINE = InnoDB;
+
+
+  -- -----------------------------------------------------
+  -- Table `bgow6s464`.`cycle_counts`
+  -- -----------------------------------------------------
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`cycle_counts` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
+    `section_id` INT NOT NULL,
+    `warehouse_id` INT NOT NULL,
+    `employee_id` INT NOT NULL,
+    `selection` VARCHAR(10) NOT NULL,
+    `abc_class` CHAR(1) NULL,
+    `status` VARCHAR(20) NOT NULL DEFAULT 'open',
+    `created_on` DATE NOT NULL,
+    `approved_by` VARCHAR(255) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    INDEX `fk_cycle_counts_sections1_idx` (`site_id` ASC, `section_id` ASC) VISIBLE,
+    INDEX `fk_cycle_counts_warehouses1_idx` (`site_id` ASC, `warehouse_id` ASC) VISIBLE,
+    INDEX `fk_cycle_counts_employees1_idx` (`site_id` ASC, `employee_id` ASC) VISIBLE,
+    CONSTRAINT `fk_cycle_counts_sections1`
+      FOREIGN KEY (`site_id`, `section_id`)
+      REFERENCES `bgow6s464`.`sections` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE,
+    CONSTRAINT `fk_cycle_counts_warehouses1`
+      FOREIGN KEY (`site_id`, `warehouse_id`)
+      REFERENCES `bgow6s464`.`warehouses` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE,
+    CONSTRAINT `fk_cycle_counts_employees1`
+      FOREIGN KEY (`site_id`, `employee_id`)
+      REFERENCES `bgow6s464`.`employees` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE)
+  ENGINE = InnoDB;
+
+
+  -- -----------------------------------------------------
+  -- Table `bgow6s464`.`cycle_count_lines`
+  -- -----------------------------------------------------
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`cycle_count_lines` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
+    `cycle_count_id` INT NOT NULL,
+    `product_batch_id` INT NOT NULL,
+    `products_id` INT NOT NULL,
+    `expected_quantity` INT NULL,
+    `counted_quantity` INT NULL,
+    `variance` INT NULL,
+    `reason_code` VARCHAR(20) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    INDEX `fk_cycle_count_lines_cycle_counts1_idx` (`site_id` ASC, `cycle_count_id` ASC) VISIBLE,
+    INDEX `fk_cycle_count_lines_product_batches1_idx` (`site_id` ASC, `product_batch_id` ASC) VISIBLE,
+    INDEX `fk_cycle_count_lines_products1_idx` (`site_id` ASC, `products_id` ASC) VISIBLE,
+    CONSTRAINT `fk_cycle_count_lines_cycle_counts1`
+      FOREIGN KEY (`site_id`, `cycle_count_id`)
+      REFERENCES `bgow6s464`.`cycle_counts` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE,
+    CONSTRAINT `fk_cycle_count_lines_product_batches1`
+      FOREIGN KEY (`site_id`, `product_batch_id`)
+      REFERENCES `bgow6s464`.`product_batches` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE,
+    CONSTRAINT `fk_cycle_count_lines_products1`
+      FOREIGN KEY (`site_id`, `products_id`)
+      REFERENCES `bgow6s464`.`products` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE)
+  ENGINE = InnoDB;
+
+
+  -- -----------------------------------------------------
+  -- Table `bgow6s464`.`outbox_events`
+  -- -----------------------------------------------------
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`outbox_events` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
+    `event_type` VARCHAR(64) NOT NULL,
+    `aggregate_id` INT NOT NULL,
+    `warehouse_id` INT NULL,
+    `payload` JSON NOT NULL,
+    `occurred_at` DATETIME(3) NOT NULL,
+    `dispatched_at` DATETIME(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `outbox_events_pending_idx` (`dispatched_at` ASC, `id` ASC) VISIBLE,
+    INDEX `outbox_events_warehouse_idx` (`warehouse_id` ASC, `id` ASC) VISIBLE)
+  ENGINE = InnoDB;
+
+
+  -- -----------------------------------------------------
+  -- Table `bgow6s464`.`webhook_subscriptions`
+  -- -----------------------------------------------------
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`webhook_subscriptions` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NULL,
+    `url` VARCHAR(2048) NOT NULL,
+    `secret` VARCHAR(255) NOT NULL,
+    `event_types` VARCHAR(255) NOT NULL DEFAULT '',
+    `created_at` DATETIME(3) NOT NULL,
+    PRIMARY KEY (`id`))
+  ENGINE = InnoDB;
+
+
+  -- -----------------------------------------------------
+  -- Table `bgow6s464`.`webhook_deliveries`
+  -- -----------------------------------------------------
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`webhook_deliveries` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `event_id` BIGINT NOT NULL,
+    `subscription_id` INT NOT NULL,
+    `status` VARCHAR(16) NOT NULL DEFAULT 'pending',
+    `attempts` INT NOT NULL DEFAULT 0,
+    `next_attempt_at` DATETIME(3) NOT NULL,
+    `last_status` INT NULL,
+    `last_error` VARCHAR(1024) NULL,
+    `delivered_at` DATETIME(3) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `webhook_deliveries_event_subscription_idx` (`event_id` ASC, `subscription_id` ASC) VISIBLE,
+    INDEX `webhook_deliveries_due_idx` (`status` ASC, `next_attempt_at` ASC) VISIBLE,
+    CONSTRAINT `fk_webhook_deliveries_outbox_events1`
+      FOREIGN KEY (`event_id`)
+      REFERENCES `bgow6s464`.`outbox_events` (`id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE,
+    CONSTRAINT `fk_webhook_deliveries_webhook_subscriptions1`
+      FOREIGN KEY (`subscription_id`)
+      REFERENCES `bgow6s464`.`webhook_subscriptions` (`id`)
       ON DELETE CASCADE
       ON UPDATE CASCADE)
   ENGINE = InnoDB;
 
+  -- -----------------------------------------------------
+  -- Table `bgow6s464`.`idempotency_keys`
+  -- -----------------------------------------------------
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`idempotency_keys` (
+    `client` VARCHAR(255) NOT NULL,
+    `idempotency_key` VARCHAR(255) NOT NULL,
+    `request_hash` CHAR(64) NOT NULL,
+    `status` INT NULL,
+    `content_type` VARCHAR(255) NULL,
+    `body` MEDIUMBLOB NULL,
+    `expires_at` DATETIME(3) NOT NULL,
+    PRIMARY KEY (`client`, `idempotency_key`),
+    INDEX `idempotency_keys_expires_idx` (`expires_at` ASC) VISIBLE)
+  ENGINE = InnoDB;
+
+
+  INSERT IGNORE INTO `bgow6s464`.`schema_migrations` (`version`) VALUES (1), (2), (3);
+
 
   -- SET SQL_MODE=@OLD_SQL_MODE;
   -- SET FOREIGN_KEY_CHECKS=@OLD_FOREIGN_KEY_CHECKS;
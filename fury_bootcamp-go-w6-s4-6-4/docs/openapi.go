@@ -0,0 +1,10 @@
+// Package docs embeds the OpenAPI description of the API.
+package docs
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI 3.1 description of the API. Requests are validated
+// against it, so it must change together with the routes.
+//
+//go:embed openapi.yaml
+var OpenAPI []byte
@@ -0,0 +1,207 @@
+package activity
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/health"
+)
+
+// Options tune the broker.
+type Options struct {
+	// BatchSize bounds the events read per poll.
+	BatchSize int
+	// Buffer is how many events a subscriber may fall behind by before it's
+	// dropped.
+	Buffer int
+	// GapWait is how long a missing event id is waited on. Ids are taken
+	// when a transaction inserts its events, so a transaction still open
+	// leaves a gap that's filled when it commits, after later events were
+	// already published; a rolled back one leaves it forever.
+	GapWait time.Duration
+}
+
+// Broker tails the outbox and publishes the events scoped to a warehouse to
+// the subscribers of that warehouse. Every instance runs its own broker, so
+// subscribers get the events written through any of them.
+//
+// Events are published in the order they're read, which is mostly the
+// order of their ids: an event whose transaction committed late comes after
+// events with higher ids.
+type Broker struct {
+	repo   Repository
+	opts   Options
+	logger *slog.Logger
+	now    func() time.Time
+
+	mu   sync.Mutex
+	subs map[int]map[*Subscription]struct{}
+
+	// started, cursor and gaps are only used by Poll, which isn't called
+	// concurrently.
+	started bool
+	cursor  int64
+	gaps    map[int64]time.Time
+
+	heartbeat *health.Heartbeat
+}
+
+func NewBroker(repo Repository, opts Options, logger *slog.Logger) *Broker {
+	return &Broker{
+		repo:   repo,
+		opts:   opts,
+		logger: logger,
+		now:    time.Now,
+		subs:   map[int]map[*Subscription]struct{}{},
+		gaps:   map[int64]time.Time{},
+
+		heartbeat: health.NewHeartbeat("warehouse events broker"),
+	}
+}
+
+// Subscription receives the events of a warehouse on C, which is closed
+// when the subscription is closed or dropped for falling behind.
+type Subscription struct {
+	C <-chan domain.OutboxEvent
+
+	c           chan domain.OutboxEvent
+	warehouseID int
+	broker      *Broker
+}
+
+// Subscribe starts receiving the events of the warehouse published from
+// now on. Close the subscription once done with it.
+func (b *Broker) Subscribe(warehouseID int) *Subscription {
+	c := make(chan domain.OutboxEvent, b.opts.Buffer)
+	s := &Subscription{C: c, c: c, warehouseID: warehouseID, broker: b}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subs[warehouseID] == nil {
+		b.subs[warehouseID] = map[*Subscription]struct{}{}
+	}
+	b.subs[warehouseID][s] = struct{}{}
+	return s
+}
+
+// Close stops the subscription. It's safe to call more than once.
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.remove(s)
+}
+
+// remove unregisters s and closes its channel, if it's still registered.
+// The caller holds b.mu.
+func (b *Broker) remove(s *Subscription) {
+	subs := b.subs[s.warehouseID]
+	if _, ok := subs[s]; !ok {
+		return
+	}
+	delete(subs, s)
+	if len(subs) == 0 {
+		delete(b.subs, s.warehouseID)
+	}
+	close(s.c)
+}
+
+// Run polls right away and then every interval, until ctx is done.
+func (b *Broker) Run(ctx context.Context, interval time.Duration) {
+	b.heartbeat.Run(ctx, interval, func(ctx context.Context) {
+		if err := b.Poll(ctx); err != nil {
+			b.logger.ErrorContext(ctx, "polling the outbox", "error", err)
+		}
+	})
+}
+
+// Poll publishes the events recorded since the last poll. The first poll
+// only finds where the outbox ends: what happened before is replayed from
+// the outbox by whoever needs it, not published.
+func (b *Broker) Poll(ctx context.Context) error {
+	if !b.started {
+		last, err := b.repo.LastID(ctx)
+		if err != nil {
+			return err
+		}
+		b.cursor, b.started = last, true
+		return nil
+	}
+
+	now := b.now()
+	if err := b.fillGaps(ctx, now); err != nil {
+		return err
+	}
+
+	events, err := b.repo.After(ctx, b.cursor, b.opts.BatchSize)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		// Past a batch worth of missing ids it's a jump in the auto
+		// increment, not transactions in flight.
+		if e.ID-b.cursor-1 <= int64(b.opts.BatchSize) {
+			for id := b.cursor + 1; id < e.ID; id++ {
+				b.gaps[id] = now.Add(b.opts.GapWait)
+			}
+		}
+		b.cursor = e.ID
+		b.publish(e)
+	}
+	return nil
+}
+
+// fillGaps publishes the missing events that showed up and gives up on the
+// ones waited on for long enough.
+func (b *Broker) fillGaps(ctx context.Context, now time.Time) error {
+	if len(b.gaps) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(b.gaps))
+	for id := range b.gaps {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	found, err := b.repo.ByID(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, e := range found {
+		delete(b.gaps, e.ID)
+		b.publish(e)
+	}
+	for id, until := range b.gaps {
+		if !now.Before(until) {
+			delete(b.gaps, id)
+		}
+	}
+	return nil
+}
+
+// publish sends e to the subscribers of its warehouse, dropping the ones
+// whose buffer is full rather than waiting for them.
+func (b *Broker) publish(e domain.OutboxEvent) {
+	if e.WarehouseID == 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.subs[e.WarehouseID] {
+		select {
+		case s.c <- e:
+		default:
+			b.logger.Warn("dropping a slow warehouse events subscriber", "warehouse_id", e.WarehouseID)
+			b.remove(s)
+		}
+	}
+}
+
+// Healthy reports an error when Run isn't polling, either because it never
+// started or because a poll has taken over two intervals.
+func (b *Broker) Healthy(ctx context.Context) error {
+	return b.heartbeat.Check(ctx)
+}
@@ -0,0 +1,114 @@
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	activitymock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/activity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func outboxEvent(id int64, warehouseID int) domain.OutboxEvent {
+	return domain.OutboxEvent{
+		ID:          id,
+		Type:        events.StockChanged,
+		AggregateID: 1,
+		WarehouseID: warehouseID,
+		OccurredAt:  time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		Data:        json.RawMessage(`{"quantity":1}`),
+	}
+}
+
+// received drains what s has buffered.
+func received(s *Subscription) []int64 {
+	var ids []int64
+	for {
+		select {
+		case e, ok := <-s.C:
+			if !ok {
+				return ids
+			}
+			ids = append(ids, e.ID)
+		default:
+			return ids
+		}
+	}
+}
+
+func newTestBroker(repo Repository, now *time.Time) *Broker {
+	b := NewBroker(repo, Options{BatchSize: 10, Buffer: 4, GapWait: time.Second}, logger.Discard())
+	b.now = func() time.Time { return *now }
+	return b
+}
+
+func TestPoll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should publish new events to the subscribers of their warehouse", func(t *testing.T) {
+		repo := &activitymock.MockRepository{Events: []domain.OutboxEvent{outboxEvent(1, 1)}}
+		now := time.Now()
+		b := newTestBroker(repo, &now)
+		require.NoError(t, b.Poll(ctx))
+		first, second, other := b.Subscribe(1), b.Subscribe(1), b.Subscribe(2)
+
+		repo.Append(outboxEvent(2, 1), outboxEvent(3, 2), outboxEvent(4, 0))
+		require.NoError(t, b.Poll(ctx))
+
+		// Event 1 was there before the broker started.
+		assert.Equal(t, []int64{2}, received(first))
+		assert.Equal(t, []int64{2}, received(second))
+		assert.Equal(t, []int64{3}, received(other))
+	})
+	t.Run("should publish an event committed after later ones", func(t *testing.T) {
+		repo := &activitymock.MockRepository{}
+		now := time.Now()
+		b := newTestBroker(repo, &now)
+		require.NoError(t, b.Poll(ctx))
+		s := b.Subscribe(1)
+
+		repo.Append(outboxEvent(2, 1))
+		require.NoError(t, b.Poll(ctx))
+		repo.Append(outboxEvent(1, 1))
+		require.NoError(t, b.Poll(ctx))
+
+		assert.Equal(t, []int64{2, 1}, received(s))
+		assert.Empty(t, b.gaps)
+	})
+	t.Run("should stop waiting on a gap after a while", func(t *testing.T) {
+		repo := &activitymock.MockRepository{}
+		now := time.Now()
+		b := newTestBroker(repo, &now)
+		require.NoError(t, b.Poll(ctx))
+
+		repo.Append(outboxEvent(3, 1))
+		require.NoError(t, b.Poll(ctx))
+		assert.Len(t, b.gaps, 2)
+
+		now = now.Add(time.Second)
+		require.NoError(t, b.Poll(ctx))
+		assert.Empty(t, b.gaps)
+	})
+	t.Run("should drop a subscriber that falls behind", func(t *testing.T) {
+		repo := &activitymock.MockRepository{}
+		now := time.Now()
+		b := newTestBroker(repo, &now)
+		require.NoError(t, b.Poll(ctx))
+		s := b.Subscribe(1)
+
+		for id := int64(1); id <= 5; id++ {
+			repo.Append(outboxEvent(id, 1))
+		}
+		require.NoError(t, b.Poll(ctx))
+
+		assert.Equal(t, []int64{1, 2, 3, 4}, received(s))
+		_, open := <-s.C
+		assert.False(t, open)
+		s.Close()
+	})
+}
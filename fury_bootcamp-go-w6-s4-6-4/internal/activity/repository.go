@@ -0,0 +1,142 @@
+package activity
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+)
+
+// Repository reads the outbox. It reads from the writer: a replica lagging
+// behind would make the broker wait on gaps that are only replication lag.
+type Repository interface {
+	// LastID returns the id of the latest event, or 0 when there's none.
+	LastID(ctx context.Context) (int64, error)
+	// After returns up to limit events with an id over afterID, oldest
+	// first.
+	After(ctx context.Context, afterID int64, limit int) ([]domain.OutboxEvent, error)
+	// ByID returns the events among ids that exist, oldest first.
+	ByID(ctx context.Context, ids []int64) ([]domain.OutboxEvent, error)
+	// Replay returns the latest limit events of the warehouse with an id
+	// over afterID, oldest first.
+	Replay(ctx context.Context, warehouseID int, afterID int64, limit int) ([]domain.OutboxEvent, error)
+	ExistsWarehouse(ctx context.Context, id int) (bool, error)
+}
+
+type repository struct {
+	db     *database.Router
+	logger *slog.Logger
+}
+
+func NewRepository(db *database.Router, logger *slog.Logger) Repository {
+	return &repository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+const eventColumns = "id, site_id, event_type, aggregate_id, warehouse_id, payload, occurred_at"
+
+const (
+	LAST_EVENT_ID    = "SELECT COALESCE(MAX(id), 0) FROM outbox_events"
+	EVENTS_AFTER     = "SELECT " + eventColumns + " FROM outbox_events WHERE id > ? ORDER BY id LIMIT ?"
+	EVENTS_BY_ID     = "SELECT " + eventColumns + " FROM outbox_events WHERE id IN (%s) ORDER BY id"
+	REPLAY_EVENTS    = "SELECT " + eventColumns + " FROM outbox_events WHERE warehouse_id = ? AND site_id = COALESCE(?, site_id) AND id > ? ORDER BY id DESC LIMIT ?"
+	EXISTS_WAREHOUSE = "SELECT id FROM warehouses WHERE id=? AND site_id = COALESCE(?, site_id)"
+)
+
+func (r *repository) LastID(ctx context.Context) (int64, error) {
+	var id int64
+	if err := r.db.Writer().QueryRowContext(ctx, LAST_EVENT_ID).Scan(&id); err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	return id, nil
+}
+
+func (r *repository) After(ctx context.Context, afterID int64, limit int) ([]domain.OutboxEvent, error) {
+	return r.query(ctx, EVENTS_AFTER, afterID, limit)
+}
+
+func (r *repository) ByID(ctx context.Context, ids []int64) ([]domain.OutboxEvent, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+	return r.query(ctx, fmt.Sprintf(EVENTS_BY_ID, placeholders), args...)
+}
+
+func (r *repository) Replay(ctx context.Context, warehouseID int, afterID int64, limit int) ([]domain.OutboxEvent, error) {
+	events, err := r.query(ctx, REPLAY_EVENTS, warehouseID, tenant.Filter(ctx), afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+	return events, nil
+}
+
+func (r *repository) ExistsWarehouse(ctx context.Context, id int) (bool, error) {
+	err := r.db.Writer().QueryRowContext(ctx, EXISTS_WAREHOUSE, id, tenant.Filter(ctx)).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, apperrors.FromDB(err)
+	}
+	return true, nil
+}
+
+func (r *repository) query(ctx context.Context, query string, args ...interface{}) ([]domain.OutboxEvent, error) {
+	rows, err := r.db.Writer().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+
+	var events []domain.OutboxEvent
+	for rows.Next() {
+		var e domain.OutboxEvent
+		var warehouseID sql.NullInt64
+		var payload []byte
+		var occurredAt sql.NullString
+		if err := rows.Scan(&e.ID, &e.SiteID, &e.Type, &e.AggregateID, &warehouseID, &payload, &occurredAt); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		e.WarehouseID = int(warehouseID.Int64)
+		e.Data = payload
+		if e.OccurredAt, err = parseTime(occurredAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	return events, nil
+}
+
+// parseTime reads a DATETIME column, which the driver returns as text.
+func parseTime(s sql.NullString) (time.Time, error) {
+	if !s.Valid {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{time.DateTime, time.RFC3339Nano} {
+		if t, err := time.ParseInLocation(layout, s.String, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unexpected datetime %q", s.String)
+}
@@ -0,0 +1,78 @@
+// Package activity streams what happens in a warehouse as it happens:
+// inbound orders, batch placements, stock changes and temperature breaches.
+// The events come from the outbox, which is also the buffer a client that
+// reconnects resumes from.
+package activity
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+var ErrWarehouseNotFound = apperrors.NotFound("warehouse not found")
+
+type Service interface {
+	// Open subscribes to the events of the warehouse. When lastEventID
+	// isn't 0, the stream starts with the events recorded after it.
+	Open(ctx context.Context, warehouseID int, lastEventID int64) (*Stream, error)
+}
+
+// Stream is a subscription to the events of a warehouse along with the
+// events it missed, which come first.
+type Stream struct {
+	*Subscription
+	// Missed are the events after the last one received, oldest first.
+	Missed []domain.OutboxEvent
+	// Truncated reports that more events were missed than are replayed, so
+	// the oldest of them were left out.
+	Truncated bool
+}
+
+type service struct {
+	repository  Repository
+	broker      *Broker
+	replayLimit int
+	logger      *slog.Logger
+}
+
+// NewService builds the service, which replays at most replayLimit events
+// to a stream that resumes.
+func NewService(r Repository, broker *Broker, replayLimit int, logger *slog.Logger) Service {
+	return &service{
+		repository:  r,
+		broker:      broker,
+		replayLimit: replayLimit,
+		logger:      logger,
+	}
+}
+
+func (s *service) Open(ctx context.Context, warehouseID int, lastEventID int64) (*Stream, error) {
+	exists, err := s.repository.ExistsWarehouse(ctx, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrWarehouseNotFound
+	}
+
+	// Subscribing before reading the missed events means none is lost in
+	// between; the ones received both ways are for the caller to skip.
+	stream := &Stream{Subscription: s.broker.Subscribe(warehouseID)}
+	if lastEventID == 0 {
+		return stream, nil
+	}
+	missed, err := s.repository.Replay(ctx, warehouseID, lastEventID, s.replayLimit+1)
+	if err != nil {
+		stream.Close()
+		return nil, err
+	}
+	if len(missed) > s.replayLimit {
+		missed, stream.Truncated = missed[1:], true
+	}
+	stream.Missed = missed
+	s.logger.DebugContext(ctx, "warehouse events stream resumed", "warehouse_id", warehouseID, "last_event_id", lastEventID, "missed", len(missed))
+	return stream, nil
+}
@@ -0,0 +1,56 @@
+package activity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	activitymock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/activity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpen(t *testing.T) {
+	repo := &activitymock.MockRepository{
+		Events: []domain.OutboxEvent{
+			outboxEvent(1, 1), outboxEvent(2, 2), outboxEvent(3, 1), outboxEvent(4, 1), outboxEvent(5, 1), outboxEvent(6, 1),
+		},
+		Warehouses: []int{1, 2},
+	}
+	now := time.Now()
+	service := NewService(repo, newTestBroker(repo, &now), 3, logger.Discard())
+
+	tests := []struct {
+		name          string
+		warehouseID   int
+		lastEventID   int64
+		wantMissed    []int64
+		wantTruncated bool
+		wantErr       error
+	}{
+		{name: "new stream", warehouseID: 1},
+		{name: "resumed stream", warehouseID: 1, lastEventID: 4, wantMissed: []int64{5, 6}},
+		{name: "resumed stream of another warehouse", warehouseID: 2, lastEventID: 1, wantMissed: []int64{2}},
+		{name: "resumed stream too far behind", warehouseID: 1, lastEventID: 1, wantMissed: []int64{4, 5, 6}, wantTruncated: true},
+		{name: "unknown warehouse", warehouseID: 9, wantErr: ErrWarehouseNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := service.Open(context.Background(), tt.warehouseID, tt.lastEventID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			defer stream.Close()
+			var missed []int64
+			for _, e := range stream.Missed {
+				missed = append(missed, e.ID)
+			}
+			assert.Equal(t, tt.wantMissed, missed)
+			assert.Equal(t, tt.wantTruncated, stream.Truncated)
+		})
+	}
+}
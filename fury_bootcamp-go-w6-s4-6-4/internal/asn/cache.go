@@ -0,0 +1,56 @@
+package asn
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/cache"
+)
+
+// overdueTags are the tables the overdue report goes through.
+var overdueTags = []string{"asns"}
+
+type cachedService struct {
+	Service
+	cache *cache.Cache
+}
+
+// WithCache returns s with the overdue report cached in c, and the notices
+// created or received invalidating it. A nil c leaves s as is.
+func WithCache(s Service, c *cache.Cache) Service {
+	if c == nil {
+		return s
+	}
+	return &cachedService{Service: s, cache: c}
+}
+
+// ReportOverdue is cached per day too, since a notice becomes overdue
+// without any write.
+func (s *cachedService) ReportOverdue(ctx context.Context, id int) ([]domain.ASNOverdueReport, error) {
+	key := "asns.overdue:" + time.Now().UTC().Format(dateLayout) + ":" + strconv.Itoa(id)
+	return cache.Load(ctx, s.cache, key, overdueTags, func(ctx context.Context) ([]domain.ASNOverdueReport, error) {
+		return s.Service.ReportOverdue(ctx, id)
+	})
+}
+
+func (s *cachedService) Save(ctx context.Context, a domain.ASN) (domain.ASN, error) {
+	a, err := s.Service.Save(ctx, a)
+	if err == nil {
+		s.cache.Invalidate(ctx, "asns")
+	}
+	return a, err
+}
+
+// Receive also writes inbound orders and batches, which other reports go
+// through.
+func (s *cachedService) Receive(ctx context.Context, id int, employee_id int, section_id int, order_number string) (domain.ASN, error) {
+	a, err := s.Service.Receive(ctx, id, employee_id, section_id, order_number)
+	if err == nil {
+		s.cache.Invalidate(ctx, "asns")
+		s.cache.Invalidate(ctx, "inbound_orders")
+		s.cache.Invalidate(ctx, "product_batches")
+	}
+	return a, err
+}
@@ -0,0 +1,293 @@
+package asn
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+)
+
+// Repository encapsulates the storage of the advance shipping notices. The
+// inbound orders they're received as are stored by the inbound orders'.
+type Repository interface {
+	GetAll(ctx context.Context) ([]domain.ASN, error)
+	// Get returns the notice id with its lines, read in the transaction of
+	// ctx if any so a notice is received in the one it was stored in.
+	Get(ctx context.Context, id int) (domain.ASN, error)
+	ExistsNumber(ctx context.Context, seller_id int, asn_number string) (bool, error)
+	ExistsSeller(ctx context.Context, id int) (bool, error)
+	ExistsWarehouse(ctx context.Context, id int) (bool, error)
+	// ProductSeller returns the seller of the product, and false when
+	// there's no such product.
+	ProductSeller(ctx context.Context, id int) (int, bool, error)
+	// Save stores the notice with its lines, pending, and returns its id
+	// and the ids of the lines.
+	Save(ctx context.Context, a domain.ASN) (int, []int, error)
+	// LockStatus returns the status of the notice id, locked until the
+	// transaction of ctx ends so it's received only once.
+	LockStatus(ctx context.Context, id int) (string, error)
+	// MarkReceived stores the notice id as received as the order
+	// inbound_order_id, and its lines as received as their batches.
+	MarkReceived(ctx context.Context, id int, inbound_order_id int, lines []domain.ASNLine) error
+	// ReportOverdue returns the notices still pending before today, of
+	// every seller or only of the seller id unless it's 0.
+	ReportOverdue(ctx context.Context, today string, id int) ([]domain.ASNOverdueReport, error)
+}
+
+type repository struct {
+	db     *database.Router
+	stmts  *database.Statements
+	logger *slog.Logger
+}
+
+func NewRepository(db *database.Router, stmts *database.Statements, logger *slog.Logger) Repository {
+	stmts.Register(SAVE_ASN, SAVE_LINE, LOCK_STATUS, UPDATE_RECEIVED, UPDATE_LINE_RECEIVED)
+	return &repository{
+		db:     db,
+		stmts:  stmts,
+		logger: logger,
+	}
+}
+
+const (
+	GET_ALL   = "SELECT id, site_id, asn_number, seller_id, warehouse_id, eta, status, COALESCE(inbound_order_id, 0) FROM asns WHERE site_id = COALESCE(?, site_id) ORDER BY id"
+	GET       = "SELECT id, site_id, asn_number, seller_id, warehouse_id, eta, status, COALESCE(inbound_order_id, 0) FROM asns WHERE id=? AND site_id = COALESCE(?, site_id)"
+	GET_LINES = "SELECT id, products_id, quantity, COALESCE(batch_number, 0), manufacturing_date, due_date, COALESCE(product_batch_id, 0) " +
+		"FROM asn_lines WHERE asn_id=? AND site_id = COALESCE(?, site_id) ORDER BY id"
+	// The lines of every notice, read at once rather than per notice.
+	GET_ALL_LINES = "SELECT asn_id, id, products_id, quantity, COALESCE(batch_number, 0), manufacturing_date, due_date, COALESCE(product_batch_id, 0) " +
+		"FROM asn_lines WHERE site_id = COALESCE(?, site_id) ORDER BY id"
+	EXIST_NUMBER = "SELECT id FROM asns WHERE seller_id=? AND asn_number=? AND site_id = COALESCE(?, site_id)"
+
+	EXIST_SELLER    = "SELECT id FROM seller WHERE id=? AND site_id = COALESCE(?, site_id)"
+	EXIST_WAREHOUSE = "SELECT id FROM warehouses WHERE id=? AND site_id = COALESCE(?, site_id)"
+	PRODUCT_SELLER  = "SELECT seller_id FROM products WHERE id=? AND site_id = COALESCE(?, site_id)"
+
+	SAVE_ASN             = "INSERT INTO asns(site_id,asn_number,seller_id,warehouse_id,eta,status) VALUES (?,?,?,?,?,?)"
+	SAVE_LINE            = "INSERT INTO asn_lines(site_id,asn_id,products_id,quantity,batch_number,manufacturing_date,due_date) VALUES (?,?,?,?,?,?,?)"
+	LOCK_STATUS          = "SELECT status FROM asns WHERE id=? AND site_id = COALESCE(?, site_id) FOR UPDATE"
+	UPDATE_RECEIVED      = "UPDATE asns SET status=?, inbound_order_id=? WHERE id=? AND site_id = COALESCE(?, site_id)"
+	UPDATE_LINE_RECEIVED = "UPDATE asn_lines SET product_batch_id=? WHERE id=? AND site_id = COALESCE(?, site_id)"
+
+	// today is passed in rather than taken from CURDATE(), so the report
+	// counts the days in the same calendar as the service.
+	REPORT_OVERDUE = "SELECT a.id, a.asn_number, a.seller_id, a.warehouse_id, a.eta, DATEDIFF(?, a.eta), " +
+		"(SELECT COALESCE(SUM(l.quantity), 0) FROM asn_lines l WHERE l.site_id = a.site_id AND l.asn_id = a.id) " +
+		"FROM asns a WHERE a.status = 'pending' AND a.eta < ? AND (? = 0 OR a.seller_id = ?) AND a.site_id = COALESCE(?, a.site_id) ORDER BY a.eta, a.id"
+)
+
+func (r *repository) GetAll(ctx context.Context) ([]domain.ASN, error) {
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, GET_ALL, tenant.Filter(ctx))
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+
+	asns := []domain.ASN{}
+	index := map[int]int{}
+	for rows.Next() {
+		a, err := scanASN(rows)
+		if err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		index[a.ID] = len(asns)
+		asns = append(asns, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+
+	lines, err := r.db.Reader(ctx).QueryContext(ctx, GET_ALL_LINES, tenant.Filter(ctx))
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer lines.Close()
+	for lines.Next() {
+		var asnID int
+		var l domain.ASNLine
+		if err := lines.Scan(&asnID, &l.ID, &l.ProductID, &l.Quantity, &l.BatchNumber, &l.ManufacturingDate, &l.DueDate, &l.ProductBatchID); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		if i, ok := index[asnID]; ok {
+			asns[i].Lines = append(asns[i].Lines, l)
+		}
+	}
+	return asns, apperrors.FromDB(lines.Err())
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.ASN, error) {
+	a, err := scanASN(r.db.QueryRow(ctx, GET, id, tenant.Filter(ctx)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ASN{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.ASN{}, apperrors.FromDB(err)
+	}
+
+	rows, err := r.db.Query(ctx, GET_LINES, id, tenant.Filter(ctx))
+	if err != nil {
+		return domain.ASN{}, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var l domain.ASNLine
+		if err := rows.Scan(&l.ID, &l.ProductID, &l.Quantity, &l.BatchNumber, &l.ManufacturingDate, &l.DueDate, &l.ProductBatchID); err != nil {
+			return domain.ASN{}, apperrors.FromDB(err)
+		}
+		a.Lines = append(a.Lines, l)
+	}
+	return a, apperrors.FromDB(rows.Err())
+}
+
+// scanner is a *sql.Row or the *sql.Rows being iterated.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanASN scans a notice without its lines, which are left empty.
+func scanASN(row scanner) (domain.ASN, error) {
+	a := domain.ASN{Lines: []domain.ASNLine{}}
+	err := row.Scan(&a.ID, &a.SiteID, &a.ASNNumber, &a.SellerID, &a.WarehouseID, &a.ETA, &a.Status, &a.InboundOrderID)
+	return a, err
+}
+
+func (r *repository) ExistsNumber(ctx context.Context, seller_id int, asn_number string) (bool, error) {
+	var id int
+	err := r.db.Reader(ctx).QueryRowContext(ctx, EXIST_NUMBER, seller_id, asn_number, tenant.Filter(ctx)).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, apperrors.FromDB(err)
+	}
+	return true, nil
+}
+
+func (r *repository) ExistsSeller(ctx context.Context, id int) (bool, error) {
+	_, ok, err := r.lookup(ctx, EXIST_SELLER, id)
+	return ok, err
+}
+
+func (r *repository) ExistsWarehouse(ctx context.Context, id int) (bool, error) {
+	_, ok, err := r.lookup(ctx, EXIST_WAREHOUSE, id)
+	return ok, err
+}
+
+func (r *repository) ProductSeller(ctx context.Context, id int) (int, bool, error) {
+	return r.lookup(ctx, PRODUCT_SELLER, id)
+}
+
+// lookup runs query, which selects a single id by the id given, and returns
+// it. It's false when there's no row; any other failure is returned, so an
+// outage isn't taken for a missing seller, warehouse or product.
+func (r *repository) lookup(ctx context.Context, query string, id int) (int, bool, error) {
+	var found int
+	err := r.db.Reader(ctx).QueryRowContext(ctx, query, id, tenant.Filter(ctx)).Scan(&found)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, apperrors.FromDB(err)
+	}
+	return found, true, nil
+}
+
+func (r *repository) Save(ctx context.Context, a domain.ASN) (int, []int, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var id int
+	ids := make([]int, len(a.Lines))
+	err = r.db.Transact(ctx, func(ctx context.Context) error {
+		var err error
+		if id, err = r.insert(ctx, SAVE_ASN, site, a.ASNNumber, a.SellerID, a.WarehouseID, a.ETA, domain.ASNPending); err != nil {
+			return err
+		}
+		for i, l := range a.Lines {
+			batchNumber := sql.NullInt64{Int64: int64(l.BatchNumber), Valid: l.BatchNumber != 0}
+			if ids[i], err = r.insert(ctx, SAVE_LINE, site, id, l.ProductID, l.Quantity, batchNumber, l.ManufacturingDate, l.DueDate); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, nil, apperrors.FromDB(err)
+	}
+	return id, ids, nil
+}
+
+func (r *repository) LockStatus(ctx context.Context, id int) (string, error) {
+	stmt, err := r.stmts.Stmt(ctx, LOCK_STATUS)
+	if err != nil {
+		return "", apperrors.FromDB(err)
+	}
+	var status string
+	err = stmt.QueryRowContext(ctx, id, tenant.Filter(ctx)).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrNotFound
+	}
+	return status, apperrors.FromDB(err)
+}
+
+func (r *repository) MarkReceived(ctx context.Context, id int, inbound_order_id int, lines []domain.ASNLine) error {
+	stmt, err := r.stmts.Stmt(ctx, UPDATE_LINE_RECEIVED)
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	for _, l := range lines {
+		if _, err := stmt.ExecContext(ctx, l.ProductBatchID, l.ID, tenant.Filter(ctx)); err != nil {
+			return apperrors.FromDB(err)
+		}
+	}
+
+	stmt, err = r.stmts.Stmt(ctx, UPDATE_RECEIVED)
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	_, err = stmt.ExecContext(ctx, domain.ASNReceived, inbound_order_id, id, tenant.Filter(ctx))
+	return apperrors.FromDB(err)
+}
+
+// insert runs the registered statement query and returns the id it
+// inserted.
+func (r *repository) insert(ctx context.Context, query string, args ...interface{}) (int, error) {
+	stmt, err := r.stmts.Stmt(ctx, query)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	res, err := stmt.ExecContext(ctx, args...)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	return int(id), nil
+}
+
+func (r *repository) ReportOverdue(ctx context.Context, today string, id int) ([]domain.ASNOverdueReport, error) {
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, REPORT_OVERDUE, today, today, id, id, tenant.Filter(ctx))
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+
+	reports := []domain.ASNOverdueReport{}
+	for rows.Next() {
+		var o domain.ASNOverdueReport
+		if err := rows.Scan(&o.ID, &o.ASNNumber, &o.SellerID, &o.WarehouseID, &o.ETA, &o.DaysOverdue, &o.Quantity); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		reports = append(reports, o)
+	}
+	return reports, apperrors.FromDB(rows.Err())
+}
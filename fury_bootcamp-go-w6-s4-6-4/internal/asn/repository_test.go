@@ -0,0 +1,168 @@
+package asn
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx = tenant.NewContext(context.TODO(), "MLA")
+
+func newTestRepository(t *testing.T) (Repository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard()), mock
+}
+
+func TestRepositoryGet(t *testing.T) {
+	t.Run("should read the notice with its lines", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta(GET)).WithArgs(1, "MLA").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "site_id", "asn_number", "seller_id", "warehouse_id", "eta", "status", "inbound_order_id"}).
+				AddRow(1, "MLA", "ASN-1", 2, 3, "2024-01-10", domain.ASNPending, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(GET_LINES)).WithArgs(1, "MLA").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "products_id", "quantity", "batch_number", "manufacturing_date", "due_date", "product_batch_id"}).
+				AddRow(4, 7, 10, 5, "2024-01-01", "2024-06-01", 0))
+
+		a, err := repository.Get(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ASN{
+			ID: 1, SiteID: "MLA", ASNNumber: "ASN-1", SellerID: 2, WarehouseID: 3, ETA: "2024-01-10", Status: domain.ASNPending,
+			Lines: []domain.ASNLine{{ID: 4, ProductID: 7, Quantity: 10, BatchNumber: 5, ManufacturingDate: "2024-01-01", DueDate: "2024-06-01"}},
+		}, a)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should not find a missing notice", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta(GET)).WithArgs(9, "MLA").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := repository.Get(ctx, 9)
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepositoryGetAll(t *testing.T) {
+	repository, mock := newTestRepository(t)
+	mock.ExpectQuery(regexp.QuoteMeta(GET_ALL)).WithArgs("MLA").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "site_id", "asn_number", "seller_id", "warehouse_id", "eta", "status", "inbound_order_id"}).
+			AddRow(1, "MLA", "ASN-1", 2, 3, "2024-01-10", domain.ASNReceived, 8).
+			AddRow(2, "MLA", "ASN-2", 2, 3, "2024-01-11", domain.ASNPending, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(GET_ALL_LINES)).WithArgs("MLA").WillReturnRows(
+		sqlmock.NewRows([]string{"asn_id", "id", "products_id", "quantity", "batch_number", "manufacturing_date", "due_date", "product_batch_id"}).
+			AddRow(1, 4, 7, 10, 5, "2024-01-01", "2024-06-01", 6))
+
+	asns, err := repository.GetAll(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, asns, 2)
+	assert.Equal(t, []domain.ASNLine{{ID: 4, ProductID: 7, Quantity: 10, BatchNumber: 5, ManufacturingDate: "2024-01-01", DueDate: "2024-06-01", ProductBatchID: 6}}, asns[0].Lines)
+	assert.Equal(t, []domain.ASNLine{}, asns[1].Lines)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepositorySave(t *testing.T) {
+	asn := domain.ASN{
+		ASNNumber: "ASN-1", SellerID: 2, WarehouseID: 3, ETA: "2024-01-10",
+		Lines: []domain.ASNLine{
+			{ProductID: 7, Quantity: 10, BatchNumber: 5, ManufacturingDate: "2024-01-01", DueDate: "2024-06-01"},
+			{ProductID: 8, Quantity: 2, ManufacturingDate: "2024-01-02", DueDate: "2024-03-01"},
+		},
+	}
+	// The statements are prepared on first use, which in a transaction is
+	// once on a connection of their own and once on the transaction's.
+	t.Run("should save the asn with its lines in a transaction", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_ASN))
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_ASN))
+		mock.ExpectExec(regexp.QuoteMeta(SAVE_ASN)).WithArgs("MLA", "ASN-1", 2, 3, "2024-01-10", domain.ASNPending).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_LINE))
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_LINE))
+		mock.ExpectExec(regexp.QuoteMeta(SAVE_LINE)).WithArgs("MLA", 1, 7, 10, sql.NullInt64{Int64: 5, Valid: true}, "2024-01-01", "2024-06-01").WillReturnResult(sqlmock.NewResult(4, 1))
+		mock.ExpectExec(regexp.QuoteMeta(SAVE_LINE)).WithArgs("MLA", 1, 8, 2, sql.NullInt64{}, "2024-01-02", "2024-03-01").WillReturnResult(sqlmock.NewResult(5, 1))
+		mock.ExpectCommit()
+
+		id, ids, err := repository.Save(ctx, asn)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, id)
+		assert.Equal(t, []int{4, 5}, ids)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should not keep the asn when a line fails", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_ASN))
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_ASN))
+		mock.ExpectExec(regexp.QuoteMeta(SAVE_ASN)).WithArgs("MLA", "ASN-1", 2, 3, "2024-01-10", domain.ASNPending).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_LINE))
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_LINE))
+		mock.ExpectExec(regexp.QuoteMeta(SAVE_LINE)).WithArgs("MLA", 1, 7, 10, sql.NullInt64{Int64: 5, Valid: true}, "2024-01-01", "2024-06-01").WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		_, _, err := repository.Save(ctx, asn)
+
+		assert.ErrorIs(t, err, apperrors.ErrUnavailable)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepositoryReportOverdue(t *testing.T) {
+	repository, mock := newTestRepository(t)
+	mock.ExpectQuery(regexp.QuoteMeta(REPORT_OVERDUE)).WithArgs("2024-01-12", "2024-01-12", 2, 2, "MLA").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "asn_number", "seller_id", "warehouse_id", "eta", "days_overdue", "quantity"}).
+			AddRow(1, "ASN-1", 2, 3, "2024-01-10", 2, 14))
+
+	reports, err := repository.ReportOverdue(ctx, "2024-01-12", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.ASNOverdueReport{{ID: 1, ASNNumber: "ASN-1", SellerID: 2, WarehouseID: 3, ETA: "2024-01-10", DaysOverdue: 2, Quantity: 14}}, reports)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepositoryProductSeller(t *testing.T) {
+	t.Run("should return the seller of the product", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta(PRODUCT_SELLER)).WithArgs(7, "MLA").WillReturnRows(sqlmock.NewRows([]string{"seller_id"}).AddRow(2))
+
+		seller, ok, err := repository.ProductSeller(ctx, 7)
+
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, 2, seller)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should not find a missing product", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta(PRODUCT_SELLER)).WithArgs(9, "MLA").WillReturnRows(sqlmock.NewRows([]string{"seller_id"}))
+
+		_, ok, err := repository.ProductSeller(ctx, 9)
+
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should not take an outage for a missing product", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta(PRODUCT_SELLER)).WithArgs(7, "MLA").WillReturnError(sql.ErrConnDone)
+
+		_, ok, err := repository.ProductSeller(ctx, 7)
+
+		assert.ErrorIs(t, err, apperrors.ErrUnavailable)
+		assert.False(t, ok)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,184 @@
+package asn
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	inboundorder "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/inbound_order"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+const dateLayout = "2006-01-02"
+
+// Errors
+var (
+	ErrNotFound          = apperrors.NotFound("asn not found")
+	ErrExists            = apperrors.Conflict("asn_number already exists for the seller")
+	ErrReceived          = apperrors.Conflict("asn already received")
+	ErrSellerNotExist    = apperrors.ForeignKeyViolation("seller not exists")
+	ErrWarehouseNotExist = apperrors.ForeignKeyViolation("warehouse not exists")
+	ErrProductNotExist   = apperrors.ForeignKeyViolation("product not exists")
+	ErrProductSeller     = apperrors.Validation("the product belongs to a different seller")
+	ErrDueDate           = apperrors.Validation("due_date must not be before manufacturing_date")
+)
+
+type Service interface {
+	GetAll(ctx context.Context) ([]domain.ASN, error)
+	Get(ctx context.Context, id int) (domain.ASN, error)
+	// Save declares a, pending, with its lines.
+	Save(ctx context.Context, a domain.ASN) (domain.ASN, error)
+	// Receive turns the notice id into an inbound order, received by the
+	// employee in full, with a batch per line stored in the section, in a
+	// single transaction. The order is named order_number, or after the
+	// notice when it's empty.
+	Receive(ctx context.Context, id int, employee_id int, section_id int, order_number string) (domain.ASN, error)
+	// ReportOverdue lists the notices still pending after their ETA, of
+	// every seller or only of the seller id unless it's 0.
+	ReportOverdue(ctx context.Context, id int) ([]domain.ASNOverdueReport, error)
+}
+
+type service struct {
+	repository    Repository
+	inboundOrders inboundorder.Service
+	outbox        events.Outbox
+	logger        *slog.Logger
+	now           func() time.Time
+}
+
+func NewService(repository Repository, inboundOrders inboundorder.Service, outbox events.Outbox, logger *slog.Logger) Service {
+	return &service{repository: repository, inboundOrders: inboundOrders, outbox: outbox, logger: logger, now: time.Now}
+}
+
+func (s *service) GetAll(ctx context.Context) ([]domain.ASN, error) {
+	return s.repository.GetAll(ctx)
+}
+
+func (s *service) Get(ctx context.Context, id int) (domain.ASN, error) {
+	return s.repository.Get(ctx, id)
+}
+
+func (s *service) Save(ctx context.Context, a domain.ASN) (domain.ASN, error) {
+	ok, err := s.repository.ExistsSeller(ctx, a.SellerID)
+	if err != nil {
+		return domain.ASN{}, err
+	}
+	if !ok {
+		return domain.ASN{}, ErrSellerNotExist
+	}
+	if ok, err = s.repository.ExistsWarehouse(ctx, a.WarehouseID); err != nil {
+		return domain.ASN{}, err
+	}
+	if !ok {
+		return domain.ASN{}, ErrWarehouseNotExist
+	}
+	if ok, err = s.repository.ExistsNumber(ctx, a.SellerID, a.ASNNumber); err != nil {
+		return domain.ASN{}, err
+	}
+	if ok {
+		return domain.ASN{}, ErrExists
+	}
+	for _, line := range a.Lines {
+		seller, ok, err := s.repository.ProductSeller(ctx, line.ProductID)
+		if err != nil {
+			return domain.ASN{}, err
+		}
+		if !ok {
+			return domain.ASN{}, ErrProductNotExist
+		}
+		if seller != a.SellerID {
+			return domain.ASN{}, ErrProductSeller
+		}
+		// Both are dates in the same layout, so they compare as strings.
+		if line.DueDate < line.ManufacturingDate {
+			return domain.ASN{}, ErrDueDate
+		}
+	}
+
+	a.Status, a.InboundOrderID = domain.ASNPending, 0
+	id, ids, err := s.repository.Save(ctx, a)
+	if err != nil {
+		return domain.ASN{}, err
+	}
+	a.ID = id
+	a.Lines = append([]domain.ASNLine{}, a.Lines...)
+	for i := range a.Lines {
+		a.Lines[i].ID, a.Lines[i].ProductBatchID = ids[i], 0
+	}
+	s.logger.InfoContext(ctx, "asn created", "asn_id", id, "seller_id", a.SellerID)
+	return a, nil
+}
+
+func (s *service) Receive(ctx context.Context, id int, employee_id int, section_id int, order_number string) (domain.ASN, error) {
+	a, err := s.repository.Get(ctx, id)
+	if err != nil {
+		return domain.ASN{}, err
+	}
+	if a.Status == domain.ASNReceived {
+		return domain.ASN{}, ErrReceived
+	}
+	if order_number == "" {
+		order_number = a.ASNNumber
+	}
+	lines := make([]domain.InboundOrderLine, len(a.Lines))
+	for i, l := range a.Lines {
+		lines[i] = domain.InboundOrderLine{ProductID: l.ProductID, BatchNumber: l.BatchNumber, ExpectedQuantity: l.Quantity}
+	}
+
+	// The order is created and received by the inbound orders' service, so
+	// it's checked and its status set as any other. Its events are recorded
+	// by it, in the transaction opened here.
+	err = s.outbox.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		// The status is read again, locked, so a notice received meanwhile
+		// isn't received twice.
+		status, err := s.repository.LockStatus(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if status == domain.ASNReceived {
+			return nil, ErrReceived
+		}
+
+		order, err := s.inboundOrders.Save(ctx, s.today(), order_number, employee_id, 0, a.WarehouseID, lines)
+		if err != nil {
+			return nil, err
+		}
+		received := make([]domain.InboundReceiptLine, len(a.Lines))
+		for i, l := range a.Lines {
+			received[i] = domain.InboundReceiptLine{
+				LineID:            order.Lines[i].ID,
+				Quantity:          l.Quantity,
+				SectionID:         section_id,
+				ManufacturingDate: l.ManufacturingDate,
+				DueDate:           l.DueDate,
+			}
+		}
+		receipt, err := s.inboundOrders.Receive(ctx, order.ID, received)
+		if err != nil {
+			return nil, err
+		}
+		for i := range a.Lines {
+			a.Lines[i].ProductBatchID = receipt.Lines[i].ProductBatchID
+		}
+		a.InboundOrderID = order.ID
+		return nil, s.repository.MarkReceived(ctx, id, order.ID, a.Lines)
+	})
+	if err != nil {
+		return domain.ASN{}, err
+	}
+	a.Status = domain.ASNReceived
+	s.logger.InfoContext(ctx, "asn received", "asn_id", id, "inbound_order_id", a.InboundOrderID)
+	return a, nil
+}
+
+func (s *service) ReportOverdue(ctx context.Context, id int) ([]domain.ASNOverdueReport, error) {
+	return s.repository.ReportOverdue(ctx, s.today(), id)
+}
+
+// today is the date the ETAs are compared with and orders are received on,
+// in UTC.
+func (s *service) today() string {
+	return s.now().UTC().Format(dateLayout)
+}
@@ -0,0 +1,247 @@
+package asn
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	inboundorder "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/inbound_order"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	asnmock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/asn"
+	eventsmock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/events"
+	inboundordermock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/inbound_order"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockRepository() *asnmock.MockRepository {
+	return &asnmock.MockRepository{
+		Sellers:    []domain.Seller{{ID: 1}, {ID: 2}},
+		Warehouses: []domain.Warehouse{{ID: 1}, {ID: 2}},
+		Products:   []domain.Product{{ID: 7, SellerID: 1}, {ID: 8, SellerID: 1}, {ID: 9, SellerID: 2}},
+	}
+}
+
+// newMockInboundOrders holds what the inbound orders the notices are
+// received as are checked against, and what they're stored as.
+func newMockInboundOrders() *inboundordermock.MockRepositoryIBO {
+	return &inboundordermock.MockRepositoryIBO{
+		DataMockEmp:  []domain.Employee{{ID: 4, WarehouseID: 1}, {ID: 5, WarehouseID: 2}},
+		DataMockWh:   []domain.Warehouse{{ID: 1}, {ID: 2}},
+		DataMockSec:  []domain.Section{{ID: 1, WarehouseID: 1}, {ID: 2, WarehouseID: 2}},
+		DataMockProd: []domain.Product{{ID: 7}, {ID: 8}, {ID: 9}},
+	}
+}
+
+func newTestService(repo Repository, orders inboundorder.Repository, outbox events.Outbox) *service {
+	s := NewService(repo, inboundorder.NewService(orders, outbox, logger.Discard()), outbox, logger.Discard()).(*service)
+	s.now = func() time.Time { return time.Date(2024, 1, 12, 15, 0, 0, 0, time.UTC) }
+	return s
+}
+
+func newASN() domain.ASN {
+	return domain.ASN{
+		ASNNumber:   "ASN-1",
+		SellerID:    1,
+		WarehouseID: 1,
+		ETA:         "2024-01-10",
+		Lines: []domain.ASNLine{
+			{ProductID: 7, Quantity: 10, BatchNumber: 5, ManufacturingDate: "2024-01-01", DueDate: "2024-06-01"},
+			{ProductID: 8, Quantity: 4, ManufacturingDate: "2024-01-02", DueDate: "2024-03-01"},
+		},
+	}
+}
+
+func TestServiceSave(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should save the notice pending", func(t *testing.T) {
+		repo := newMockRepository()
+
+		a, err := newTestService(repo, newMockInboundOrders(), events.Discard).Save(ctx, newASN())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, a.ID)
+		assert.Equal(t, domain.ASNPending, a.Status)
+		assert.Equal(t, []int{1, 2}, []int{a.Lines[0].ID, a.Lines[1].ID})
+		assert.Equal(t, a, repo.Data[0])
+	})
+
+	tests := []struct {
+		name   string
+		change func(a *domain.ASN)
+		err    error
+	}{
+		{name: "should need the seller", change: func(a *domain.ASN) { a.SellerID = 9 }, err: ErrSellerNotExist},
+		{name: "should need the warehouse", change: func(a *domain.ASN) { a.WarehouseID = 9 }, err: ErrWarehouseNotExist},
+		{name: "should need the products", change: func(a *domain.ASN) { a.Lines[1].ProductID = 99 }, err: ErrProductNotExist},
+		{name: "should take only the seller's products", change: func(a *domain.ASN) { a.Lines[1].ProductID = 9 }, err: ErrProductSeller},
+		{name: "should not take a batch due before it's made", change: func(a *domain.ASN) { a.Lines[0].DueDate = "2023-12-31" }, err: ErrDueDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepository()
+			a := newASN()
+			tt.change(&a)
+
+			_, err := newTestService(repo, newMockInboundOrders(), events.Discard).Save(ctx, a)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Empty(t, repo.Data)
+		})
+	}
+
+	t.Run("should not repeat the number of a seller", func(t *testing.T) {
+		repo := newMockRepository()
+		service := newTestService(repo, newMockInboundOrders(), events.Discard)
+		_, err := service.Save(ctx, newASN())
+		assert.NoError(t, err)
+
+		_, err = service.Save(ctx, newASN())
+		assert.ErrorIs(t, err, ErrExists)
+
+		other := newASN()
+		other.SellerID, other.Lines = 2, []domain.ASNLine{{ProductID: 9, Quantity: 1, ManufacturingDate: "2024-01-01", DueDate: "2024-01-01"}}
+		_, err = service.Save(ctx, other)
+		assert.NoError(t, err)
+	})
+}
+
+func TestServiceReceive(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should create the order and the batches", func(t *testing.T) {
+		//Arrange
+		repo, orders := newMockRepository(), newMockInboundOrders()
+		outbox := &eventsmock.MockOutbox{}
+		service := newTestService(repo, orders, outbox)
+		saved, err := service.Save(ctx, newASN())
+		assert.NoError(t, err)
+
+		//Act
+		a, err := service.Receive(ctx, saved.ID, 4, 1, "")
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ASNReceived, a.Status)
+		assert.Equal(t, 1, a.InboundOrderID)
+		assert.Equal(t, []int{1, 2}, []int{a.Lines[0].ProductBatchID, a.Lines[1].ProductBatchID})
+		assert.Equal(t, a, repo.Data[0])
+
+		assert.Equal(t, domain.Inbound_order{
+			ID: 1, Order_date: "2024-01-12", Order_number: "ASN-1", Employee_id: 4, Warehouse_id: 1, Status: domain.InboundOrderClosed,
+			Lines: []domain.InboundOrderLine{
+				{ID: 1, ProductID: 7, BatchNumber: 5, ExpectedQuantity: 10, ReceivedQuantity: 10},
+				{ID: 2, ProductID: 8, ExpectedQuantity: 4, ReceivedQuantity: 4},
+			},
+		}, orders.DataMock[0])
+		assert.Equal(t, domain.Product_batches{
+			ID: 1, BatchNumber: 5, CurrentQuantity: 10, InitialQuantity: 10, ManufacturingDate: "2024-01-01", DueDate: "2024-06-01", SectionId: 1, ProductId: 7,
+		}, orders.DataMockPB[0])
+		assert.Equal(t, []domain.InboundReceiptLine{
+			{LineID: 1, Quantity: 10, ProductBatchID: 1, SectionID: 1, ManufacturingDate: "2024-01-01", DueDate: "2024-06-01"},
+			{LineID: 2, Quantity: 4, ProductBatchID: 2, SectionID: 1, ManufacturingDate: "2024-01-02", DueDate: "2024-03-01"},
+		}, orders.Receipts[0].Lines)
+		assert.Equal(t, domain.InboundOrderClosed, orders.Receipts[0].Status)
+
+		var types []string
+		for _, e := range outbox.Events {
+			types = append(types, e.Type)
+		}
+		assert.Equal(t, []string{events.InboundOrderCreated, events.ProductBatchReceived, events.StockChanged, events.ProductBatchReceived, events.StockChanged}, types)
+	})
+	t.Run("should name the order as asked", func(t *testing.T) {
+		repo, orders := newMockRepository(), newMockInboundOrders()
+		service := newTestService(repo, orders, events.Discard)
+		_, err := service.Save(ctx, newASN())
+		assert.NoError(t, err)
+
+		_, err = service.Receive(ctx, 1, 4, 1, "IO-7")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "IO-7", orders.DataMock[0].Order_number)
+	})
+
+	// The checks are the inbound orders' own. Those of the section come
+	// after the order is saved, which the transaction rolls back.
+	tests := []struct {
+		name     string
+		id       int
+		employee int
+		section  int
+		err      error
+	}{
+		{name: "should need the notice", id: 9, employee: 4, section: 1, err: apperrors.ErrNotFound},
+		{name: "should need the employee", id: 1, employee: 9, section: 1, err: inboundorder.ErrEmployeeNotExist},
+		{name: "should need the section", id: 1, employee: 4, section: 9, err: inboundorder.ErrSectionNotExist},
+		{name: "should take an employee of the warehouse", id: 1, employee: 5, section: 1, err: inboundorder.ErrEmployeeWarehouse},
+		{name: "should take a section of the warehouse", id: 1, employee: 4, section: 2, err: inboundorder.ErrSectionWarehouse},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, orders := newMockRepository(), newMockInboundOrders()
+			service := newTestService(repo, orders, events.Discard)
+			_, err := service.Save(ctx, newASN())
+			assert.NoError(t, err)
+
+			_, err = service.Receive(ctx, tt.id, tt.employee, tt.section, "")
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, domain.ASNPending, repo.Data[0].Status)
+			assert.Empty(t, orders.DataMockPB)
+		})
+	}
+
+	t.Run("should receive a notice once", func(t *testing.T) {
+		repo, orders := newMockRepository(), newMockInboundOrders()
+		service := newTestService(repo, orders, events.Discard)
+		_, err := service.Save(ctx, newASN())
+		assert.NoError(t, err)
+		_, err = service.Receive(ctx, 1, 4, 1, "")
+		assert.NoError(t, err)
+
+		_, err = service.Receive(ctx, 1, 4, 1, "IO-8")
+
+		assert.ErrorIs(t, err, ErrReceived)
+		assert.Len(t, orders.DataMock, 1)
+	})
+	t.Run("should not repeat an order number", func(t *testing.T) {
+		repo, orders := newMockRepository(), newMockInboundOrders()
+		orders.DataMock = []domain.Inbound_order{{ID: 1, Order_number: "ASN-1"}}
+		service := newTestService(repo, orders, events.Discard)
+		_, err := service.Save(ctx, newASN())
+		assert.NoError(t, err)
+
+		_, err = service.Receive(ctx, 1, 4, 1, "")
+
+		assert.ErrorIs(t, err, inboundorder.ErrAlreadyExist)
+		assert.Equal(t, domain.ASNPending, repo.Data[0].Status)
+	})
+}
+
+func TestServiceReportOverdue(t *testing.T) {
+	repo := newMockRepository()
+	repo.Data = []domain.ASN{
+		{ID: 1, ASNNumber: "ASN-1", SellerID: 1, ETA: "2024-01-10", Status: domain.ASNPending, Lines: []domain.ASNLine{{Quantity: 3}, {Quantity: 4}}},
+		{ID: 2, ASNNumber: "ASN-2", SellerID: 1, ETA: "2024-01-12", Status: domain.ASNPending},
+		{ID: 3, ASNNumber: "ASN-3", SellerID: 2, ETA: "2024-01-01", Status: domain.ASNReceived},
+		{ID: 4, ASNNumber: "ASN-4", SellerID: 2, ETA: "2024-01-11", Status: domain.ASNPending},
+	}
+	// Late in the day somewhere west of UTC, already the 12th in UTC.
+	now := func() time.Time { return time.Date(2024, 1, 11, 22, 0, 0, 0, time.FixedZone("ART", -3*60*60)) }
+	service := newTestService(repo, newMockInboundOrders(), events.Discard)
+	service.now = now
+
+	all, err := service.ReportOverdue(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-12", repo.Today)
+	assert.Equal(t, []int{1, 4}, []int{all[0].ID, all[1].ID})
+	assert.Equal(t, 7, all[0].Quantity)
+
+	one, err := service.ReportOverdue(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Len(t, one, 1)
+	assert.Equal(t, 4, one[0].ID)
+}
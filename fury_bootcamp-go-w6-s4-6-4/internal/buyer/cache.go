@@ -0,0 +1,46 @@
+package buyer
+
+import (
+	"context"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/cache"
+)
+
+type cachedService struct {
+	Service
+	cache *cache.Cache
+}
+
+// WithCache returns s with its writes invalidating the purchase orders
+// report cached in c. A nil c leaves s as is.
+func WithCache(s Service, c *cache.Cache) Service {
+	if c == nil {
+		return s
+	}
+	return &cachedService{Service: s, cache: c}
+}
+
+func (s *cachedService) Save(ctx context.Context, cardNumberID, firstName, lastName string) (domain.Buyer, error) {
+	v, err := s.Service.Save(ctx, cardNumberID, firstName, lastName)
+	if err == nil {
+		s.cache.Invalidate(ctx, "buyers")
+	}
+	return v, err
+}
+
+func (s *cachedService) Update(ctx context.Context, id int, firstName, lastName string) (domain.Buyer, error) {
+	v, err := s.Service.Update(ctx, id, firstName, lastName)
+	if err == nil {
+		s.cache.Invalidate(ctx, "buyers")
+	}
+	return v, err
+}
+
+func (s *cachedService) Delete(ctx context.Context, id int) error {
+	err := s.Service.Delete(ctx, id)
+	if err == nil {
+		s.cache.Invalidate(ctx, "buyers")
+	}
+	return err
+}
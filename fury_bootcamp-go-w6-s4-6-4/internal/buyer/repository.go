@@ -3,8 +3,13 @@ package buyer
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"log/slog"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 )
 
 // Repository encapsulates the storage of a buyer.
@@ -18,49 +23,60 @@ type Repository interface {
 }
 
 type repository struct {
-	db *sql.DB
+	db     *database.Router
+	stmts  *database.Statements
+	logger *slog.Logger
 }
 
-func NewRepository(db *sql.DB) Repository {
+func NewRepository(db *database.Router, stmts *database.Statements, logger *slog.Logger) Repository {
+	stmts.Register(SAVE_BUYER, UPDATE_BUYER, DELETE_BUYER)
 	return &repository{
-		db: db,
+		db:     db,
+		stmts:  stmts,
+		logger: logger,
 	}
 }
 
 const (
-	GET_ALL_BUYERS  = "SELECT * FROM buyers;"
-	GET_BUYER_BY_ID = "SELECT * FROM buyers WHERE id = ?;"
-	EXISTS_BUYER    = "SELECT card_number_id FROM buyers WHERE card_number_id=?;"
-	SAVE_BUYER      = "INSERT INTO buyers(card_number_id,first_name,last_name) VALUES (?,?,?);"
-	UPDATE_BUYER    = "UPDATE buyers SET first_name=?, last_name=?  WHERE id=?;"
-	DELETE_BUYER    = "DELETE FROM buyers WHERE id = ?;"
+	GET_ALL_BUYERS  = "SELECT id, site_id, card_number_id, first_name, last_name FROM buyers WHERE site_id = COALESCE(?, site_id);"
+	GET_BUYER_BY_ID = "SELECT id, site_id, card_number_id, first_name, last_name FROM buyers WHERE id = ? AND site_id = COALESCE(?, site_id);"
+	EXISTS_BUYER    = "SELECT card_number_id FROM buyers WHERE card_number_id=? AND site_id = COALESCE(?, site_id);"
+	SAVE_BUYER      = "INSERT INTO buyers(site_id,card_number_id,first_name,last_name) VALUES (?,?,?,?);"
+	UPDATE_BUYER    = "UPDATE buyers SET first_name=?, last_name=?  WHERE id=? AND site_id = COALESCE(?, site_id);"
+	DELETE_BUYER    = "DELETE FROM buyers WHERE id = ? AND site_id = COALESCE(?, site_id);"
 )
 
 func (r *repository) GetAll(ctx context.Context) ([]domain.Buyer, error) {
 	query := GET_ALL_BUYERS
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, tenant.Filter(ctx))
 	if err != nil {
-		return nil, err
+		return nil, apperrors.FromDB(err)
 	}
+	defer rows.Close()
 
 	var buyers []domain.Buyer
 
 	for rows.Next() {
 		b := domain.Buyer{}
-		_ = rows.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName)
+		if err := rows.Scan(&b.ID, &b.SiteID, &b.CardNumberID, &b.FirstName, &b.LastName); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
 		buyers = append(buyers, b)
 	}
 
-	return buyers, nil
+	return buyers, apperrors.FromDB(rows.Err())
 }
 
 func (r *repository) Get(ctx context.Context, id int) (domain.Buyer, error) {
 	query := GET_BUYER_BY_ID
-	row := r.db.QueryRow(query, id)
+	row := r.db.Writer().QueryRowContext(ctx, query, id, tenant.Filter(ctx))
 	b := domain.Buyer{}
-	err := row.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName)
+	err := row.Scan(&b.ID, &b.SiteID, &b.CardNumberID, &b.FirstName, &b.LastName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Buyer{}, ErrNotFound
+	}
 	if err != nil {
-		return domain.Buyer{}, err
+		return domain.Buyer{}, apperrors.FromDB(err)
 	}
 
 	return b, nil
@@ -68,27 +84,34 @@ func (r *repository) Get(ctx context.Context, id int) (domain.Buyer, error) {
 
 func (r *repository) Exists(ctx context.Context, cardNumberID string) bool {
 	query := EXISTS_BUYER
-	row := r.db.QueryRow(query, cardNumberID)
+	row := r.db.Writer().QueryRowContext(ctx, query, cardNumberID, tenant.Filter(ctx))
 	err := row.Scan(&cardNumberID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", "Exists", "error", err)
+	}
 	return err == nil
 }
 
 func (r *repository) Save(ctx context.Context, b domain.Buyer) (int, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return 0, err
+	}
 
 	query := SAVE_BUYER
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.stmts.Stmt(ctx, query)
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
 	}
 
-	res, err := stmt.Exec(&b.CardNumberID, &b.FirstName, &b.LastName)
+	res, err := stmt.ExecContext(ctx, site, &b.CardNumberID, &b.FirstName, &b.LastName)
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
 	}
 
 	id, err := res.LastInsertId()
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
 	}
 
 	return int(id), nil
@@ -96,19 +119,19 @@ func (r *repository) Save(ctx context.Context, b domain.Buyer) (int, error) {
 
 func (r *repository) Update(ctx context.Context, b domain.Buyer) error {
 	query := UPDATE_BUYER
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.stmts.Stmt(ctx, query)
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
-	res, err := stmt.Exec(&b.FirstName, &b.LastName, &b.ID)
+	res, err := stmt.ExecContext(ctx, &b.FirstName, &b.LastName, &b.ID, tenant.Filter(ctx))
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	_, err = res.RowsAffected()
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	return nil
@@ -116,19 +139,19 @@ func (r *repository) Update(ctx context.Context, b domain.Buyer) error {
 
 func (r *repository) Delete(ctx context.Context, id int) error {
 	query := DELETE_BUYER
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.stmts.Stmt(ctx, query)
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
-	res, err := stmt.Exec(id)
+	res, err := stmt.ExecContext(ctx, id, tenant.Filter(ctx))
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	affect, err := res.RowsAffected()
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	if affect < 1 {
@@ -10,6 +10,9 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
@@ -35,9 +38,9 @@ func (s *createDataBaseRepoSuite) SetupTest() {
 
         // defer db.Close()
 
-        s.context = context.TODO()
+        s.context = tenant.NewContext(context.TODO(), "MLA")
 	s.sqlMock = mock
-	s.dbRepository = NewRepository(db)
+	s.dbRepository = NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 }
 
 func TestCreateDataBaseSuite(t *testing.T) {
@@ -58,7 +61,7 @@ func (s *createDataBaseRepoSuite) Test_SaveBuyerOK() {
         params := testBuyer
 	s.sqlMock.ExpectPrepare(stmt).WillReturnError(nil)
 	s.sqlMock.ExpectExec(stmt).
-                WithArgs(params.CardNumberID, params.FirstName, params.LastName).
+                WithArgs("MLA", params.CardNumberID, params.FirstName, params.LastName).
                 WillReturnResult(sqlmock.NewResult(1, 1))
 
 	// Act
@@ -78,7 +81,7 @@ func (s *createDataBaseRepoSuite) Test_SaveBuyerFail() {
         params := testBuyer
 	s.sqlMock.ExpectPrepare(stmt).WillReturnError(nil)
 	s.sqlMock.ExpectExec(stmt).
-                WithArgs(params.CardNumberID, params.FirstName, params.LastName).
+                WithArgs("MLA", params.CardNumberID, params.FirstName, params.LastName).
                 WillReturnError(ErrForzadoBuyer)
 
 	// Act
@@ -93,7 +96,7 @@ func (s *createDataBaseRepoSuite) Test_SaveBuyerFail() {
 func (s *createDataBaseRepoSuite) Test_GetBuyerOK() {
 
         // Arrange
-	rows := s.sqlMock.NewRows([]string{"id", "card_number_id", "first_name", "last_name"})
+	rows := s.sqlMock.NewRows([]string{"id", "site_id", "card_number_id", "first_name", "last_name"})
         var testBuyer = domain.Buyer{
                 ID:           1,
                 CardNumberID: "232345",
@@ -102,8 +105,8 @@ func (s *createDataBaseRepoSuite) Test_GetBuyerOK() {
         }
 
         stmt := regexp.QuoteMeta(GET_BUYER_BY_ID)
-	rows.AddRow(testBuyer.ID, testBuyer.CardNumberID, testBuyer.FirstName, testBuyer.LastName)
-	s.sqlMock.ExpectQuery(stmt).WithArgs(testBuyer.ID).WillReturnRows(rows)
+	rows.AddRow(testBuyer.ID, testBuyer.SiteID, testBuyer.CardNumberID, testBuyer.FirstName, testBuyer.LastName)
+	s.sqlMock.ExpectQuery(stmt).WithArgs(testBuyer.ID, "MLA").WillReturnRows(rows)
 
         // Act
 	result, err := s.dbRepository.Get(s.context, testBuyer.ID)
@@ -118,7 +121,7 @@ func (s *createDataBaseRepoSuite) Test_GetBuyerOK() {
 func (s *createDataBaseRepoSuite) Test_GetBuyerFail() {
 
         // Arrange
-	rows := s.sqlMock.NewRows([]string{"id", "card_number_id", "first_name", "last_name"})
+	rows := s.sqlMock.NewRows([]string{"id", "site_id", "card_number_id", "first_name", "last_name"})
         var testBuyer = domain.Buyer{
                 ID:           1,
                 CardNumberID: "232345",
@@ -127,9 +130,9 @@ func (s *createDataBaseRepoSuite) Test_GetBuyerFail() {
         }
 
         stmt := regexp.QuoteMeta(GET_BUYER_BY_ID)
-	rows.AddRow(nil, testBuyer.CardNumberID, testBuyer.FirstName, testBuyer.LastName).
+	rows.AddRow(nil, testBuyer.SiteID, testBuyer.CardNumberID, testBuyer.FirstName, testBuyer.LastName).
                 RowError(2, ErrForzadoScanBuyer)
-	s.sqlMock.ExpectQuery(stmt).WithArgs(testBuyer.ID).WillReturnRows(rows)
+	s.sqlMock.ExpectQuery(stmt).WithArgs(testBuyer.ID, "MLA").WillReturnRows(rows)
 
         // Act
 	_, err := s.dbRepository.Get(s.context, testBuyer.ID)
@@ -144,7 +147,7 @@ func (s *createDataBaseRepoSuite) Test_GetBuyerFail() {
 func (s *createDataBaseRepoSuite) Test_GetAllBuyerOK() {
 
         // Arrange
-	rows := s.sqlMock.NewRows([]string{"id", "card_number_id", "first_name", "last_name"})
+	rows := s.sqlMock.NewRows([]string{"id", "site_id", "card_number_id", "first_name", "last_name"})
         testBuyers := []domain.Buyer{
                 {
                         ID:           1,
@@ -161,7 +164,7 @@ func (s *createDataBaseRepoSuite) Test_GetAllBuyerOK() {
         }
 
 	for _, buyer := range testBuyers {
-	        rows.AddRow(buyer.ID, buyer.CardNumberID, buyer.FirstName, buyer.LastName)
+	        rows.AddRow(buyer.ID, buyer.SiteID, buyer.CardNumberID, buyer.FirstName, buyer.LastName)
 	}
 
         stmt := regexp.QuoteMeta(GET_ALL_BUYERS)
@@ -180,7 +183,7 @@ func (s *createDataBaseRepoSuite) Test_GetAllBuyerOK() {
 func (s *createDataBaseRepoSuite) Test_GetAllBuyerFail() {
 
         // Arrange
-	rows := s.sqlMock.NewRows([]string{"id", "card_number_id", "first_name", "last_name"})
+	rows := s.sqlMock.NewRows([]string{"id", "site_id", "card_number_id", "first_name", "last_name"})
         testBuyers := []domain.Buyer{
                 {
                         ID:           1,
@@ -197,7 +200,7 @@ func (s *createDataBaseRepoSuite) Test_GetAllBuyerFail() {
         }
 
 	for _, buyer := range testBuyers {
-	        rows.AddRow(buyer.ID, buyer.CardNumberID, buyer.FirstName, buyer.LastName)
+	        rows.AddRow(buyer.ID, buyer.SiteID, buyer.CardNumberID, buyer.FirstName, buyer.LastName)
 	}
 
         stmt := regexp.QuoteMeta(GET_ALL_BUYERS)
@@ -227,7 +230,7 @@ func (s *createDataBaseRepoSuite) Test_ExistBuyer() {
 
         rows := s.sqlMock.NewRows([]string{"card_number_id"})
         rows.AddRow(testBuyer.CardNumberID)
-	s.sqlMock.ExpectQuery(stmt).WithArgs(params).WillReturnRows(rows)
+	s.sqlMock.ExpectQuery(stmt).WithArgs(params, "MLA").WillReturnRows(rows)
 
         // Act
         exist := s.dbRepository.Exists(s.context, testBuyer.CardNumberID)
@@ -244,7 +247,7 @@ func (s *createDataBaseRepoSuite) Test_ExistBuyerFail() {
         stmt := regexp.QuoteMeta(EXISTS_BUYER)
 
         rows := s.sqlMock.NewRows([]string{"card_number_id"})
-	s.sqlMock.ExpectQuery(stmt).WithArgs(params).WillReturnRows(rows)
+	s.sqlMock.ExpectQuery(stmt).WithArgs(params, "MLA").WillReturnRows(rows)
 
         // Act
         exist := s.dbRepository.Exists(s.context, params)
@@ -261,7 +264,7 @@ func (s *createDataBaseRepoSuite) Test_DeleteBuyerOK() {
 	params := 1
         stmt := regexp.QuoteMeta(DELETE_BUYER)
 	s.sqlMock.ExpectPrepare(stmt)
-	s.sqlMock.ExpectExec(stmt).WithArgs(params).WillReturnResult(sqlmock.NewResult(1, 1))
+	s.sqlMock.ExpectExec(stmt).WithArgs(params, "MLA").WillReturnResult(sqlmock.NewResult(1, 1))
 
         // Act
         err := s.dbRepository.Delete(s.context, params)
@@ -280,7 +283,7 @@ func (s *createDataBaseRepoSuite) Test_DeleteBuyerOK() {
 	_, err = s.dbRepository.Get(s.context, params)
 
         // Assert
-	s.ErrorContains(sql.ErrNoRows, err.Error())
+	s.ErrorIs(err, ErrNotFound)
 }
 
 
@@ -295,7 +298,7 @@ func (s *createDataBaseRepoSuite) Test_DeleteBuyerFail() {
         }
 
         stmt := regexp.QuoteMeta(DELETE_BUYER)
-	s.sqlMock.ExpectPrepare(stmt).ExpectExec().WithArgs(testBuyer.ID).WillReturnError(ErrForzadoBuyer)
+	s.sqlMock.ExpectPrepare(stmt).ExpectExec().WithArgs(testBuyer.ID, "MLA").WillReturnError(ErrForzadoBuyer)
 
         // Act
         err := s.dbRepository.Delete(s.context, testBuyer.ID)
@@ -318,7 +321,7 @@ func (s *createDataBaseRepoSuite) Test_UpdateBuyerOK() {
         stmt := regexp.QuoteMeta(UPDATE_BUYER)
 	s.sqlMock.ExpectPrepare(stmt).
 		ExpectExec().
-                WithArgs(testBuyer.FirstName, testBuyer.LastName, testBuyer.ID).
+                WithArgs(testBuyer.FirstName, testBuyer.LastName, testBuyer.ID, "MLA").
                 WillReturnResult(sqlmock.NewResult(0, 1))
 
         // Act
@@ -343,7 +346,7 @@ func (s *createDataBaseRepoSuite) Test_UpdateBuyerFail() {
         stmt := regexp.QuoteMeta(UPDATE_BUYER)
 	s.sqlMock.ExpectPrepare(stmt).
 		ExpectExec().
-                WithArgs(testBuyer.FirstName, testBuyer.LastName, testBuyer.ID).
+                WithArgs(testBuyer.FirstName, testBuyer.LastName, testBuyer.ID, "MLA").
                 WillReturnError(ErrForzadoBuyer)
 
         // Act
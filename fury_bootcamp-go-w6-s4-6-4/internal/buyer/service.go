@@ -2,16 +2,17 @@ package buyer
 
 import (
 	"context"
-	"errors"
+	"log/slog"
 	"reflect"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 // Errors
 var (
-	ErrNotFound = errors.New("buyer not found")
-        ErrDuplicateCardNumberID = errors.New("duplicate cardNumberID")
+	ErrNotFound = apperrors.NotFound("buyer not found")
+        ErrDuplicateCardNumberID = apperrors.Conflict("duplicate cardNumberID")
 )
 
 type Service interface {
@@ -25,11 +26,13 @@ type Service interface {
 
 type service struct{
         repository Repository
+        logger     *slog.Logger
 }
 
-func NewService(r Repository) Service {
+func NewService(r Repository, logger *slog.Logger) Service {
 	return &service{
                 repository: r,
+                logger:     logger,
         }
 }
 
@@ -54,6 +57,7 @@ func (s *service) Save(ctx context.Context, cardNumberID, firstName, lastName st
 	}
 
         buyer.ID = id
+        s.logger.InfoContext(ctx, "buyer created", "buyer_id", id)
 
        return buyer, nil
 }
@@ -66,7 +70,7 @@ func (s *service) Delete(ctx context.Context, id int) error {
 
         _, err := s.repository.Get(ctx, id)
 	if err != nil {
-		return ErrNotFound
+		return err
 	}
 
 	return s.repository.Delete(ctx, id)
@@ -76,7 +80,7 @@ func (s *service) Update(ctx context.Context, id int, firstName, lastName string
 
         currentBuyer, err := s.repository.Get(ctx, id)
 	if err != nil {
-		return domain.Buyer{}, ErrNotFound
+		return domain.Buyer{}, err
 	}
 
         req := domain.Buyer{}
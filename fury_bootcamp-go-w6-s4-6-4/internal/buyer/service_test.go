@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/buyer"
 	"github.com/stretchr/testify/assert"
 )
@@ -32,7 +33,7 @@ func TestServiceFindAll(t *testing.T) {
 		DataMock: database,
 	}
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, logger.Discard())
         ctx := context.Background()
 
 	// Act.
@@ -66,7 +67,7 @@ func TestServiceFindByIdExistent(t *testing.T) {
 		DataMock: database,
 	}
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, logger.Discard())
         ctx := context.Background()
 
 	// Act.
@@ -90,7 +91,7 @@ func TestServiceFindByIdNotExistent(t *testing.T) {
                 Error: errMessage,
 	}
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, logger.Discard())
         ctx := context.Background()
 
 	// Act.
@@ -118,7 +119,7 @@ func TestServiceCreateOk(t *testing.T) {
         productIDExpected := 1
 
         // Act
-        service := NewService(&mockRepository)
+        service := NewService(&mockRepository, logger.Discard())
         ctx := context.Background()
 
         result, err := service.Save(ctx, newBuyer.CardNumberID, newBuyer.FirstName, newBuyer.LastName)
@@ -165,7 +166,7 @@ func TestServiceCreateConflict(t *testing.T) {
         productIDExpected := 0
 
         // Act
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, logger.Discard())
         ctx := context.Background()
         result, err := service.Save(ctx, newBuyer.CardNumberID, newBuyer.FirstName, newBuyer.LastName)
 
@@ -211,7 +212,7 @@ func TestServiceUpdateExistent(t *testing.T) {
                 GetWasCalled: false,
 	}
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, logger.Discard())
         ctx := context.Background()
 
 	// Act.
@@ -252,7 +253,7 @@ func TestServiceUpdateNonExistent(t *testing.T) {
                 Error: errMessage.Error(),
 	}
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, logger.Discard())
         ctx := context.Background()
 
 	// Act.
@@ -290,7 +291,7 @@ func TestServiceDeleteOk(t *testing.T) {
 		DataMock: dbCopy,
 	}
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, logger.Discard())
         ctx := context.Background()
 
 	// Act.
@@ -315,7 +316,7 @@ func TestServiceDeleteNonExistent(t *testing.T) {
                 Error: errMessage.Error(),
 	}
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, logger.Discard())
         ctx := context.Background()
 
 	// Act.
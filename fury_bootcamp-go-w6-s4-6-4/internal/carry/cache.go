@@ -0,0 +1,30 @@
+package carry
+
+import (
+	"context"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/cache"
+)
+
+type cachedService struct {
+	Service
+	cache *cache.Cache
+}
+
+// WithCache returns s with new carries invalidating the carries report of
+// the localities cached in c. A nil c leaves s as is.
+func WithCache(s Service, c *cache.Cache) Service {
+	if c == nil {
+		return s
+	}
+	return &cachedService{Service: s, cache: c}
+}
+
+func (s *cachedService) Save(ctx context.Context, c domain.Carry) (int, error) {
+	v, err := s.Service.Save(ctx, c)
+	if err == nil {
+		s.cache.Invalidate(ctx, "carries")
+	}
+	return v, err
+}
@@ -3,8 +3,13 @@ package carry
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"log/slog"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 )
 
 type Repository interface {
@@ -14,47 +19,67 @@ type Repository interface {
 }
 
 type repository struct {
-	db *sql.DB
+	db     *database.Router
+	stmts  *database.Statements
+	logger *slog.Logger
 }
 
-func NewRepository(db *sql.DB) Repository {
+func NewRepository(db *database.Router, stmts *database.Statements, logger *slog.Logger) Repository {
+	stmts.Register(SAVE_CARRY)
 	return &repository{
-		db: db,
+		db:     db,
+		stmts:  stmts,
+		logger: logger,
 	}
 }
 
-func (r *repository) Save(ctx context.Context, c domain.Carry) (int, error) {
-	query := "INSERT INTO carries (cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?);"
-	stmt, err := r.db.Prepare(query)
+const (
+	SAVE_CARRY = "INSERT INTO carries (site_id, cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?, ?);"
+)
 
+func (r *repository) Save(ctx context.Context, c domain.Carry) (int, error) {
+	site, err := tenant.Site(ctx)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(c.CID, c.Company_name, c.Address, c.Telephone, c.Locality_id)
+	query := SAVE_CARRY
+	stmt, err := r.stmts.Stmt(ctx, query)
+
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
+	}
+
+	res, err := stmt.ExecContext(ctx, site, c.CID, c.Company_name, c.Address, c.Telephone, c.Locality_id)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
 	}
 
 	id, err := res.LastInsertId()
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
 	}
 
 	return int(id), nil
 }
 
 func (r *repository) Exists(ctx context.Context, carryID string) bool {
-	query := "SELECT cid FROM carries WHERE cid=?;"
-	row := r.db.QueryRow(query, carryID)
+	query := "SELECT cid FROM carries WHERE cid=? AND site_id = COALESCE(?, site_id);"
+	row := r.db.Writer().QueryRowContext(ctx, query, carryID, tenant.Filter(ctx))
 	err := row.Scan(&carryID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", "Exists", "error", err)
+	}
 	return err == nil
 
 }
 
 func (r *repository) ExistsLocality(ctx context.Context, localityID int) bool {
-	query := "SELECT id FROM locality WHERE id=?;"
-	row := r.db.QueryRow(query, localityID)
+	query := "SELECT id FROM locality WHERE id=? AND site_id = COALESCE(?, site_id);"
+	row := r.db.Writer().QueryRowContext(ctx, query, localityID, tenant.Filter(ctx))
 	err := row.Scan(&localityID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", "ExistsLocality", "error", err)
+	}
 	return err == nil
 }
@@ -9,6 +9,9 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,7 +21,7 @@ func Test_Repository_Store_Mock(t *testing.T) {
 
 	mock.ExpectPrepare("INSERT INTO carries")
 	mock.ExpectExec("INSERT INTO carries").WillReturnResult(sqlmock.NewResult(1, 1))
-	repository := NewRepository(db)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 	carry := domain.Carry{
 		CID:          "1",
 		Company_name: "test",
@@ -26,7 +29,7 @@ func Test_Repository_Store_Mock(t *testing.T) {
 		Telephone:    "test",
 		Locality_id:  1,
 	}
-	id, err := repository.Save(context.Background(), carry)
+	id, err := repository.Save(tenant.NewContext(context.Background(), "MLA"), carry)
 	assert.NoError(t, err)
 	assert.Equal(t, id, 1)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -38,7 +41,7 @@ func Test_Repository_Store_Prepare_Error_Mock(t *testing.T) {
 	assert.NoError(t, err)
 
 	mock.ExpectPrepare("INSERT INTO carries").WillReturnError(errors.New("insert prepare error"))
-	repository := NewRepository(db)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 	carry := domain.Carry{
 		CID:          "1",
 		Company_name: "test",
@@ -46,7 +49,7 @@ func Test_Repository_Store_Prepare_Error_Mock(t *testing.T) {
 		Telephone:    "test",
 		Locality_id:  1,
 	}
-	id, err := repository.Save(context.Background(), carry)
+	id, err := repository.Save(tenant.NewContext(context.Background(), "MLA"), carry)
 	assert.Error(t, err)
 	assert.Equal(t, id, 0)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -59,7 +62,7 @@ func Test_Repository_Store_Exec_Error_Mock(t *testing.T) {
 
 	mock.ExpectPrepare("INSERT INTO carries")
 	mock.ExpectExec("INSERT INTO carries").WillReturnError(errors.New("insert exec error"))
-	repository := NewRepository(db)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 	carry := domain.Carry{
 		CID:          "1",
 		Company_name: "test",
@@ -67,12 +70,25 @@ func Test_Repository_Store_Exec_Error_Mock(t *testing.T) {
 		Telephone:    "test",
 		Locality_id:  1,
 	}
-	id, err := repository.Save(context.Background(), carry)
+	id, err := repository.Save(tenant.NewContext(context.Background(), "MLA"), carry)
 	assert.Error(t, err)
 	assert.Equal(t, id, 0)
 	assert.NoError(t, mock.ExpectationsWereMet())
 
 }
+
+func Test_Repository_Store_Without_Site(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+	ctx := tenant.AllSites(context.Background())
+
+	id, err := repository.Save(ctx, domain.Carry{CID: "1", Locality_id: 1})
+	assert.ErrorIs(t, err, tenant.ErrSiteRequired)
+	assert.Equal(t, id, 0)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
 func Test_Repository_Exists_Carries_Mock(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
@@ -81,9 +97,9 @@ func Test_Repository_Exists_Carries_Mock(t *testing.T) {
 	rows := sqlmock.NewRows(columns)
 	rows.AddRow("1")
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT cid FROM carries WHERE cid=?;")).WithArgs("1").WillReturnRows(rows)
-	repository := NewRepository(db)
-	ctx := context.TODO()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT cid FROM carries WHERE cid=? AND site_id = COALESCE(?, site_id);")).WithArgs("1", "MLA").WillReturnRows(rows)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+	ctx := tenant.NewContext(context.TODO(), "MLA")
 
 	exists := repository.Exists(ctx, "1")
 	assert.True(t, exists)
@@ -98,9 +114,9 @@ func Test_Repository_Exists_Locality_Mock(t *testing.T) {
 	rows := sqlmock.NewRows(columns)
 	rows.AddRow(1)
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM locality WHERE id=?;")).WithArgs(1).WillReturnRows(rows)
-	repository := NewRepository(db)
-	ctx := context.TODO()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM locality WHERE id=? AND site_id = COALESCE(?, site_id);")).WithArgs(1, "MLA").WillReturnRows(rows)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+	ctx := tenant.NewContext(context.TODO(), "MLA")
 
 	exists := repository.ExistsLocality(ctx, 1)
 	assert.True(t, exists)
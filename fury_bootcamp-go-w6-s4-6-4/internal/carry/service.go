@@ -2,31 +2,44 @@ package carry
 
 import (
 	"context"
-	"errors"
+	"log/slog"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+// Errors
+var (
+	ErrCodeExists       = apperrors.Conflict("carry code already exists")
+	ErrLocalityNotFound = apperrors.ForeignKeyViolation("locality code doesn't exists")
 )
 
 type Service interface {
-	Save(c domain.Carry) (int, error)
+	Save(ctx context.Context, c domain.Carry) (int, error)
 }
 
 type service struct {
 	repository Repository
+	logger     *slog.Logger
 }
 
-func NewService(repository Repository) Service {
-	return &service{repository}
+func NewService(repository Repository, logger *slog.Logger) Service {
+	return &service{repository, logger}
 }
 
-func (s *service) Save(c domain.Carry) (int, error) {
-	if s.repository.Exists(context.Background(), c.CID) {
-		return 0, errors.New("carry code already exists")
+func (s *service) Save(ctx context.Context, c domain.Carry) (int, error) {
+	if s.repository.Exists(ctx, c.CID) {
+		return 0, ErrCodeExists
 	}
 
-	if !s.repository.ExistsLocality(context.Background(), c.Locality_id) {
-		return 0, errors.New("locality code doesn't exists")
+	if !s.repository.ExistsLocality(ctx, c.Locality_id) {
+		return 0, ErrLocalityNotFound
 	}
 
-	return s.repository.Save(context.Background(), c)
+	id, err := s.repository.Save(ctx, c)
+	if err != nil {
+		return 0, err
+	}
+	s.logger.InfoContext(ctx, "carry created", "carry_id", id)
+	return id, nil
 }
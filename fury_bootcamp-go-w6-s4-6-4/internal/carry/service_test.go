@@ -1,16 +1,18 @@
 package carry
 
 import (
+	"context"
 	"testing"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/carry"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestCreate(t *testing.T) {
 	repo := carry.NewRepositoryCarry([]domain.Carry{})
-	service := NewService(repo)
+	service := NewService(repo, logger.Discard())
 	t.Run("should create a new carry", func(t *testing.T) {
 		// Arrange
 		carry := domain.Carry{
@@ -19,7 +21,7 @@ func TestCreate(t *testing.T) {
 		}
 
 		// Act
-		id, err := service.Save(carry)
+		id, err := service.Save(context.Background(), carry)
 
 		// Assert
 		assert.Equal(t, err, nil)
@@ -33,7 +35,7 @@ func TestCreate(t *testing.T) {
 		}
 
 		// Act
-		id, err := service.Save(carry)
+		id, err := service.Save(context.Background(), carry)
 
 		// Assert
 		assert.Equal(t, "carry code already exists", err.Error())
@@ -47,7 +49,7 @@ func TestCreate(t *testing.T) {
 		}
 
 		// Act
-		id, err := service.Save(carry)
+		id, err := service.Save(context.Background(), carry)
 
 		// Assert
 		assert.Equal(t, "locality code doesn't exists", err.Error())
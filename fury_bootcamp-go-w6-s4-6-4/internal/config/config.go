@@ -0,0 +1,563 @@
+// Package config loads the settings of the API from a YAML file, the
+// environment and command line flags, in increasing order of precedence.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// StorageMySQL connects to the database at DB.DSN.
+	StorageMySQL = "mysql"
+	// StorageFury connects to the database provisioned by Fury, reading the
+	// credentials from its environment.
+	StorageFury = "fury"
+)
+
+// redacted replaces the value of secret settings when the config is printed.
+const redacted = "REDACTED"
+
+// Every leaf setting declares its YAML key, environment variable and flag.
+// Settings marked secret are redacted by Print.
+type Config struct {
+	Server   Server   `yaml:"server"`
+	DB       DB       `yaml:"db"`
+	Storage  string   `yaml:"storage" env:"STORAGE_BACKEND" flag:"storage" usage:"storage backend: mysql or fury"`
+	LogLevel string   `yaml:"log_level" env:"LOG_LEVEL" flag:"log-level" usage:"minimum level logged: debug, info, warn or error"`
+	Features Features `yaml:"features"`
+	Webhooks Webhooks `yaml:"webhooks"`
+
+	WarehouseEvents WarehouseEvents `yaml:"warehouse_events"`
+	Idempotency     Idempotency     `yaml:"idempotency"`
+	RateLimit       RateLimit       `yaml:"rate_limit"`
+	ReportCache     ReportCache     `yaml:"report_cache"`
+	Tenancy         Tenancy         `yaml:"tenancy"`
+	EDI             EDI             `yaml:"edi"`
+	CycleCount      CycleCount      `yaml:"cycle_count"`
+}
+
+type Server struct {
+	Addr            string        `yaml:"addr" env:"SERVER_ADDR" flag:"addr" usage:"address the HTTP server listens on"`
+	GRPCAddr        string        `yaml:"grpc_addr" env:"SERVER_GRPC_ADDR" flag:"grpc-addr" usage:"address the gRPC server listens on"`
+	ReadTimeout     time.Duration `yaml:"read_timeout" env:"SERVER_READ_TIMEOUT" flag:"read-timeout" usage:"maximum duration to read a request"`
+	WriteTimeout    time.Duration `yaml:"write_timeout" env:"SERVER_WRITE_TIMEOUT" flag:"write-timeout" usage:"maximum duration to write a response"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT" flag:"shutdown-timeout" usage:"time in-flight requests get to finish on shutdown"`
+	RequestTimeout  time.Duration `yaml:"request_timeout" env:"SERVER_REQUEST_TIMEOUT" flag:"request-timeout" usage:"deadline for handling a request"`
+	ReportTimeout   time.Duration `yaml:"report_timeout" env:"SERVER_REPORT_TIMEOUT" flag:"report-timeout" usage:"deadline for handling a request to a report route"`
+	TrustedProxies  []string      `yaml:"trusted_proxies" env:"SERVER_TRUSTED_PROXIES" flag:"trusted-proxies" usage:"comma separated addresses or CIDRs of the proxies whose X-Forwarded-For names the client IP"`
+}
+
+type DB struct {
+	DSN             string        `yaml:"dsn" env:"DB_DSN" flag:"db-dsn" secret:"true" usage:"MySQL DSN, used by the mysql storage"`
+	ReplicaDSNs     []string      `yaml:"replica_dsns" env:"DB_REPLICA_DSNS" flag:"db-replica-dsns" secret:"true" usage:"comma separated MySQL DSNs of the read replicas, used by the mysql storage"`
+	ReplicaCheck    time.Duration `yaml:"replica_check_interval" env:"DB_REPLICA_CHECK_INTERVAL" flag:"db-replica-check-interval" usage:"how often the read replicas are checked"`
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS" flag:"db-max-open-conns" usage:"maximum open connections, 0 for unlimited"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" flag:"db-max-idle-conns" usage:"maximum idle connections"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME" flag:"db-conn-max-lifetime" usage:"maximum time a connection is reused, 0 for forever"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME" flag:"db-conn-max-idle-time" usage:"maximum time a connection stays idle, 0 for forever"`
+}
+
+type Features struct {
+	Metrics           bool `yaml:"metrics" env:"FEATURE_METRICS" flag:"feature-metrics" usage:"serve /metrics and refresh the domain gauges"`
+	Docs              bool `yaml:"docs" env:"FEATURE_DOCS" flag:"feature-docs" usage:"serve the OpenAPI description at /openapi.yaml"`
+	RequestValidation bool `yaml:"request_validation" env:"FEATURE_REQUEST_VALIDATION" flag:"feature-request-validation" usage:"validate requests against the OpenAPI description"`
+	GRPC              bool `yaml:"grpc" env:"FEATURE_GRPC" flag:"feature-grpc" usage:"serve the gRPC API at server.grpc_addr"`
+	GraphQL           bool `yaml:"graphql" env:"FEATURE_GRAPHQL" flag:"feature-graphql" usage:"serve the GraphQL API at /graphql"`
+	Webhooks          bool `yaml:"webhooks" env:"FEATURE_WEBHOOKS" flag:"feature-webhooks" usage:"serve the webhook routes and deliver the domain events to the subscriptions"`
+	WarehouseEvents   bool `yaml:"warehouse_events" env:"FEATURE_WAREHOUSE_EVENTS" flag:"feature-warehouse-events" usage:"stream the events of each warehouse at /api/v1/warehouses/:id/events"`
+	Idempotency       bool `yaml:"idempotency" env:"FEATURE_IDEMPOTENCY" flag:"feature-idempotency" usage:"replay the response to POST requests retried with the same Idempotency-Key"`
+	RateLimit         bool `yaml:"rate_limit" env:"FEATURE_RATE_LIMIT" flag:"feature-rate-limit" usage:"limit the requests each client makes to the API routes"`
+	ReportCache       bool `yaml:"report_cache" env:"FEATURE_REPORT_CACHE" flag:"feature-report-cache" usage:"cache the reports until the data they count changes and tag them with an ETag"`
+}
+
+type Webhooks struct {
+	DispatchInterval time.Duration `yaml:"dispatch_interval" env:"WEBHOOKS_DISPATCH_INTERVAL" flag:"webhooks-dispatch-interval" usage:"how often pending events and deliveries are processed"`
+	BatchSize        int           `yaml:"batch_size" env:"WEBHOOKS_BATCH_SIZE" flag:"webhooks-batch-size" usage:"maximum events fanned out and deliveries attempted per round"`
+	MaxAttempts      int           `yaml:"max_attempts" env:"WEBHOOKS_MAX_ATTEMPTS" flag:"webhooks-max-attempts" usage:"attempts before a delivery is dead-lettered"`
+	BackoffBase      time.Duration `yaml:"backoff_base" env:"WEBHOOKS_BACKOFF_BASE" flag:"webhooks-backoff-base" usage:"wait before the first retry, doubled on each further one"`
+	BackoffMax       time.Duration `yaml:"backoff_max" env:"WEBHOOKS_BACKOFF_MAX" flag:"webhooks-backoff-max" usage:"longest wait between retries"`
+	Timeout          time.Duration `yaml:"timeout" env:"WEBHOOKS_TIMEOUT" flag:"webhooks-timeout" usage:"deadline for a subscriber to answer a delivery"`
+}
+
+type WarehouseEvents struct {
+	PollInterval   time.Duration `yaml:"poll_interval" env:"WAREHOUSE_EVENTS_POLL_INTERVAL" flag:"warehouse-events-poll-interval" usage:"how often the outbox is polled for new events"`
+	BatchSize      int           `yaml:"batch_size" env:"WAREHOUSE_EVENTS_BATCH_SIZE" flag:"warehouse-events-batch-size" usage:"maximum events read per poll"`
+	Buffer         int           `yaml:"buffer" env:"WAREHOUSE_EVENTS_BUFFER" flag:"warehouse-events-buffer" usage:"events a stream may fall behind by before it's closed"`
+	GapWait        time.Duration `yaml:"gap_wait" env:"WAREHOUSE_EVENTS_GAP_WAIT" flag:"warehouse-events-gap-wait" usage:"how long an event committed out of order is waited on"`
+	ReplayLimit    int           `yaml:"replay_limit" env:"WAREHOUSE_EVENTS_REPLAY_LIMIT" flag:"warehouse-events-replay-limit" usage:"maximum missed events replayed to a stream that resumes"`
+	Heartbeat      time.Duration `yaml:"heartbeat" env:"WAREHOUSE_EVENTS_HEARTBEAT" flag:"warehouse-events-heartbeat" usage:"how often an idle stream gets a comment"`
+	StreamDuration time.Duration `yaml:"stream_duration" env:"WAREHOUSE_EVENTS_STREAM_DURATION" flag:"warehouse-events-stream-duration" usage:"how long a stream lasts before the client reconnects"`
+}
+
+type Idempotency struct {
+	TTL           time.Duration `yaml:"ttl" env:"IDEMPOTENCY_TTL" flag:"idempotency-ttl" usage:"how long the response to a request with an Idempotency-Key is replayed"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env:"IDEMPOTENCY_PURGE_INTERVAL" flag:"idempotency-purge-interval" usage:"how often expired idempotency keys are deleted"`
+	MaxBodySize   int           `yaml:"max_body_size" env:"IDEMPOTENCY_MAX_BODY_SIZE" flag:"idempotency-max-body-size" usage:"largest body of a request with an Idempotency-Key, in bytes"`
+}
+
+// RateLimit sets the quota of each client per route group, as in
+// routes.go: reports, the other reads, and writes. A client regains the
+// requests per minute gradually and can spend up to the burst at once.
+type RateLimit struct {
+	ReadsPerMinute   int `yaml:"reads_per_minute" env:"RATE_LIMIT_READS_PER_MINUTE" flag:"rate-limit-reads-per-minute" usage:"reads a client can make per minute"`
+	ReadsBurst       int `yaml:"reads_burst" env:"RATE_LIMIT_READS_BURST" flag:"rate-limit-reads-burst" usage:"reads a client can make at once"`
+	WritesPerMinute  int `yaml:"writes_per_minute" env:"RATE_LIMIT_WRITES_PER_MINUTE" flag:"rate-limit-writes-per-minute" usage:"writes a client can make per minute"`
+	WritesBurst      int `yaml:"writes_burst" env:"RATE_LIMIT_WRITES_BURST" flag:"rate-limit-writes-burst" usage:"writes a client can make at once"`
+	ReportsPerMinute int `yaml:"reports_per_minute" env:"RATE_LIMIT_REPORTS_PER_MINUTE" flag:"rate-limit-reports-per-minute" usage:"reports a client can request per minute"`
+	ReportsBurst     int `yaml:"reports_burst" env:"RATE_LIMIT_REPORTS_BURST" flag:"rate-limit-reports-burst" usage:"reports a client can request at once"`
+	MaxClients       int `yaml:"max_clients" env:"RATE_LIMIT_MAX_CLIENTS" flag:"rate-limit-max-clients" usage:"quotas kept in memory before the least recently used is forgotten"`
+}
+
+type ReportCache struct {
+	Entries int           `yaml:"entries" env:"REPORT_CACHE_ENTRIES" flag:"report-cache-entries" usage:"reports kept in memory before the least recently used is evicted"`
+	TTL     time.Duration `yaml:"ttl" env:"REPORT_CACHE_TTL" flag:"report-cache-ttl" usage:"longest a cached report is served, bounding how stale writes made by other instances leave it"`
+	MaxAge  time.Duration `yaml:"max_age" env:"REPORT_CACHE_MAX_AGE" flag:"report-cache-max-age" usage:"how long clients may reuse a report without revalidating it, 0 to always revalidate"`
+}
+
+// Tenancy sets the sites, the country operations of the marketplace, the
+// deployment serves. Every request acts on one of them.
+type Tenancy struct {
+	DefaultSite string   `yaml:"default_site" env:"TENANCY_DEFAULT_SITE" flag:"tenancy-default-site" usage:"site of the requests that don't send X-Site-ID"`
+	Sites       []string `yaml:"sites" env:"TENANCY_SITES" flag:"tenancy-sites" usage:"comma separated ids of the sites served"`
+}
+
+// EDI names the warehouse in the interchanges it exchanges with sellers and
+// carriers, and bounds the ones they upload.
+type EDI struct {
+	InterchangeID string `yaml:"interchange_id" env:"EDI_INTERCHANGE_ID" flag:"edi-interchange-id" usage:"id the EDI interchanges are sent from and must be addressed to"`
+	MaxFileSize   int    `yaml:"max_file_size" env:"EDI_MAX_FILE_SIZE" flag:"edi-max-file-size" usage:"largest EDI interchange uploaded, in bytes"`
+}
+
+// CycleCount sets how far a count may be off the stock of a batch before a
+// supervisor has to approve the adjustment.
+type CycleCount struct {
+	ApprovalThreshold int `yaml:"approval_threshold" env:"CYCLE_COUNT_APPROVAL_THRESHOLD" flag:"cycle-count-approval-threshold" usage:"largest variance of a batch, in units, posted without a supervisor's approval"`
+}
+
+// Default returns the settings used when nothing overrides them.
+func Default() Config {
+	return Config{
+		Server: Server{
+			Addr:            ":8080",
+			GRPCAddr:        ":9090",
+			ReadTimeout:     10 * time.Second,
+			WriteTimeout:    30 * time.Second,
+			ShutdownTimeout: 15 * time.Second,
+			RequestTimeout:  5 * time.Second,
+			ReportTimeout:   20 * time.Second,
+		},
+		DB: DB{
+			DSN:          "root:@/melisprint",
+			ReplicaCheck: 10 * time.Second,
+			MaxOpenConns: 25,
+			MaxIdleConns: 25,
+		},
+		Storage:  StorageFury,
+		LogLevel: "info",
+		Features: Features{
+			Metrics:           true,
+			Docs:              true,
+			RequestValidation: true,
+			GRPC:              true,
+			GraphQL:           true,
+			Webhooks:          true,
+			WarehouseEvents:   true,
+			Idempotency:       true,
+			RateLimit:         true,
+			ReportCache:       true,
+		},
+		Webhooks: Webhooks{
+			DispatchInterval: 2 * time.Second,
+			BatchSize:        100,
+			MaxAttempts:      8,
+			BackoffBase:      10 * time.Second,
+			BackoffMax:       time.Hour,
+			Timeout:          5 * time.Second,
+		},
+		WarehouseEvents: WarehouseEvents{
+			PollInterval:   500 * time.Millisecond,
+			BatchSize:      500,
+			Buffer:         64,
+			GapWait:        10 * time.Second,
+			ReplayLimit:    500,
+			Heartbeat:      15 * time.Second,
+			StreamDuration: 25 * time.Second,
+		},
+		Idempotency: Idempotency{
+			TTL:           24 * time.Hour,
+			PurgeInterval: time.Hour,
+			// Room for the largest EDI upload.
+			MaxBodySize: 2 << 20,
+		},
+		RateLimit: RateLimit{
+			ReadsPerMinute:   600,
+			ReadsBurst:       100,
+			WritesPerMinute:  120,
+			WritesBurst:      30,
+			ReportsPerMinute: 30,
+			ReportsBurst:     10,
+			MaxClients:       100000,
+		},
+		ReportCache: ReportCache{
+			Entries: 1000,
+			TTL:     5 * time.Minute,
+		},
+		Tenancy: Tenancy{
+			DefaultSite: "MLA",
+			Sites:       []string{"MLA", "MLB", "MLM", "MLC", "MCO", "MLU"},
+		},
+		EDI: EDI{
+			InterchangeID: "MELISPRINT",
+			MaxFileSize:   1 << 20,
+		},
+		CycleCount: CycleCount{
+			ApprovalThreshold: 10,
+		},
+	}
+}
+
+// Options are the flags that control loading rather than a setting.
+type Options struct {
+	File        string
+	PrintConfig bool
+}
+
+// Load builds the config from the defaults, the YAML file given by --config
+// or CONFIG_FILE, the environment and args, in that order, and validates it.
+//
+// A positional "local" argument selects the mysql storage, as it did before
+// the storage setting existed.
+func Load(args []string, getenv func(string) string) (Config, Options, error) {
+	cfg := Default()
+	opts := Options{File: getenv("CONFIG_FILE")}
+
+	fs := flag.NewFlagSet("api", flag.ContinueOnError)
+	fs.StringVar(&opts.File, "config", opts.File, "YAML file with the settings (env CONFIG_FILE)")
+	fs.BoolVar(&opts.PrintConfig, "print-config", false, "print the resolved config, with secrets redacted, and exit")
+	var set []flagValue
+	for _, s := range settings(&cfg) {
+		fs.Var(&flagValue{setting: s, set: &set}, s.flag, fmt.Sprintf("%s (env %s)", s.usage, s.env))
+	}
+	if err := fs.Parse(args); err != nil {
+		return Config{}, opts, err
+	}
+
+	if opts.File != "" {
+		if err := loadFile(&cfg, opts.File); err != nil {
+			return Config{}, opts, err
+		}
+	}
+
+	for _, s := range settings(&cfg) {
+		if v := getenv(s.env); v != "" {
+			if err := s.set(v); err != nil {
+				return Config{}, opts, fmt.Errorf("%s: %w", s.env, err)
+			}
+		}
+	}
+	// PORT is what the platform sets; it only applies when no address was
+	// configured explicitly.
+	if port := getenv("PORT"); port != "" && getenv("SERVER_ADDR") == "" {
+		cfg.Server.Addr = ":" + port
+	}
+
+	for _, f := range set {
+		if err := f.setting.set(f.value); err != nil {
+			return Config{}, opts, fmt.Errorf("--%s: %w", f.setting.flag, err)
+		}
+	}
+	if fs.Arg(0) == "local" {
+		cfg.Storage = StorageMySQL
+	}
+
+	return cfg, opts, cfg.Validate()
+}
+
+func loadFile(cfg *Config, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate reports every invalid setting at once.
+func (c Config) Validate() error {
+	var errs []error
+	if c.Server.Addr == "" {
+		errs = append(errs, errors.New("server.addr is required"))
+	}
+	if c.Features.GRPC && c.Server.GRPCAddr == "" {
+		errs = append(errs, errors.New("server.grpc_addr is required by the grpc feature"))
+	}
+	for _, t := range []struct {
+		name string
+		d    time.Duration
+	}{
+		{"server.read_timeout", c.Server.ReadTimeout},
+		{"server.write_timeout", c.Server.WriteTimeout},
+		{"server.shutdown_timeout", c.Server.ShutdownTimeout},
+		{"server.request_timeout", c.Server.RequestTimeout},
+		{"server.report_timeout", c.Server.ReportTimeout},
+	} {
+		if t.d <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive", t.name))
+		}
+	}
+	// Past the write timeout the connection is closed, so a handler still
+	// within its deadline could not answer.
+	if c.Server.WriteTimeout < c.Server.RequestTimeout || c.Server.WriteTimeout < c.Server.ReportTimeout {
+		errs = append(errs, errors.New("server.write_timeout must not be shorter than the request and report timeouts"))
+	}
+	switch c.Storage {
+	case StorageMySQL:
+		if c.DB.DSN == "" {
+			errs = append(errs, errors.New("db.dsn is required by the mysql storage"))
+		}
+	case StorageFury:
+	default:
+		errs = append(errs, fmt.Errorf("storage must be %s or %s, got %q", StorageMySQL, StorageFury, c.Storage))
+	}
+	if c.DB.MaxOpenConns < 0 || c.DB.MaxIdleConns < 0 {
+		errs = append(errs, errors.New("db pool sizes must not be negative"))
+	}
+	if c.DB.MaxOpenConns > 0 && c.DB.MaxIdleConns > c.DB.MaxOpenConns {
+		errs = append(errs, errors.New("db.max_idle_conns must not exceed db.max_open_conns"))
+	}
+	if c.DB.ReplicaCheck <= 0 {
+		errs = append(errs, errors.New("db.replica_check_interval must be positive"))
+	}
+	if c.DB.ConnMaxLifetime < 0 || c.DB.ConnMaxIdleTime < 0 {
+		errs = append(errs, errors.New("db connection lifetimes must not be negative"))
+	}
+	if c.Features.Webhooks {
+		w := c.Webhooks
+		if w.DispatchInterval <= 0 || w.BackoffBase <= 0 || w.Timeout <= 0 {
+			errs = append(errs, errors.New("webhooks.dispatch_interval, webhooks.backoff_base and webhooks.timeout must be positive"))
+		}
+		if w.BackoffMax < w.BackoffBase {
+			errs = append(errs, errors.New("webhooks.backoff_max must not be shorter than webhooks.backoff_base"))
+		}
+		if w.BatchSize <= 0 || w.MaxAttempts <= 0 {
+			errs = append(errs, errors.New("webhooks.batch_size and webhooks.max_attempts must be positive"))
+		}
+	}
+	if c.Features.WarehouseEvents {
+		e := c.WarehouseEvents
+		if e.PollInterval <= 0 || e.GapWait <= 0 || e.Heartbeat <= 0 || e.StreamDuration <= 0 {
+			errs = append(errs, errors.New("warehouse_events.poll_interval, warehouse_events.gap_wait, warehouse_events.heartbeat and warehouse_events.stream_duration must be positive"))
+		}
+		if e.BatchSize <= 0 || e.Buffer <= 0 || e.ReplayLimit <= 0 {
+			errs = append(errs, errors.New("warehouse_events.batch_size, warehouse_events.buffer and warehouse_events.replay_limit must be positive"))
+		}
+		// The stream duration is the deadline of the request, so like the
+		// other deadlines it has to end before the connection is closed.
+		if c.Server.WriteTimeout < e.StreamDuration {
+			errs = append(errs, errors.New("warehouse_events.stream_duration must not be longer than server.write_timeout"))
+		}
+	}
+	if c.Features.Idempotency && (c.Idempotency.TTL <= 0 || c.Idempotency.PurgeInterval <= 0) {
+		errs = append(errs, errors.New("idempotency.ttl and idempotency.purge_interval must be positive"))
+	}
+	if c.Features.Idempotency && c.Idempotency.MaxBodySize < c.EDI.MaxFileSize {
+		errs = append(errs, errors.New("idempotency.max_body_size must not be smaller than edi.max_file_size"))
+	}
+	if c.Features.RateLimit {
+		l := c.RateLimit
+		if l.ReadsPerMinute <= 0 || l.WritesPerMinute <= 0 || l.ReportsPerMinute <= 0 {
+			errs = append(errs, errors.New("rate_limit.reads_per_minute, rate_limit.writes_per_minute and rate_limit.reports_per_minute must be positive"))
+		}
+		if l.ReadsBurst <= 0 || l.WritesBurst <= 0 || l.ReportsBurst <= 0 {
+			errs = append(errs, errors.New("rate_limit.reads_burst, rate_limit.writes_burst and rate_limit.reports_burst must be positive"))
+		}
+		if l.MaxClients <= 0 {
+			errs = append(errs, errors.New("rate_limit.max_clients must be positive"))
+		}
+	}
+	if c.Features.ReportCache {
+		if c.ReportCache.Entries <= 0 || c.ReportCache.TTL <= 0 {
+			errs = append(errs, errors.New("report_cache.entries and report_cache.ttl must be positive"))
+		}
+		if c.ReportCache.MaxAge < 0 {
+			errs = append(errs, errors.New("report_cache.max_age must not be negative"))
+		}
+	}
+	// Site ids are kept in the CHAR(3) site_id column of every table.
+	if len(c.Tenancy.Sites) == 0 {
+		errs = append(errs, errors.New("tenancy.sites is required"))
+	}
+	for _, site := range c.Tenancy.Sites {
+		if !validSite(site) {
+			errs = append(errs, fmt.Errorf("tenancy.sites must be 3 uppercase letters, got %q", site))
+		}
+	}
+	if !slices.Contains(c.Tenancy.Sites, c.Tenancy.DefaultSite) {
+		errs = append(errs, fmt.Errorf("tenancy.default_site must be one of tenancy.sites, got %q", c.Tenancy.DefaultSite))
+	}
+	// The id fills a 15 character element of the X12 envelope, which can't
+	// escape the delimiters of either standard.
+	if id := c.EDI.InterchangeID; id == "" || len(id) > 15 || strings.ContainsAny(id, "*~>+:'?") {
+		errs = append(errs, fmt.Errorf("edi.interchange_id must have 1 to 15 characters and no EDI delimiters, got %q", id))
+	}
+	if c.EDI.MaxFileSize <= 0 {
+		errs = append(errs, errors.New("edi.max_file_size must be positive"))
+	}
+	if c.CycleCount.ApprovalThreshold < 0 {
+		errs = append(errs, errors.New("cycle_count.approval_threshold must not be negative"))
+	}
+	if _, err := logger.ParseLevel(c.LogLevel); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func validSite(site string) bool {
+	if len(site) != 3 {
+		return false
+	}
+	for _, r := range site {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Print writes the config as YAML with the secret settings redacted.
+func (c Config) Print(w io.Writer) error {
+	for _, s := range settings(&c) {
+		if !s.secret {
+			continue
+		}
+		switch v := s.value.Interface().(type) {
+		case string:
+			if v != "" {
+				s.value.SetString(redacted)
+			}
+		case []string:
+			r := make([]string, len(v))
+			for i := range r {
+				r[i] = redacted
+			}
+			s.value.Set(reflect.ValueOf(r))
+		}
+	}
+	enc := yaml.NewEncoder(w)
+	defer enc.Close()
+	return enc.Encode(c)
+}
+
+// setting is a leaf field of Config along with its tags.
+type setting struct {
+	env, flag, usage string
+	secret           bool
+	value            reflect.Value
+}
+
+func settings(cfg *Config) []setting {
+	var out []setting
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.Type.Kind() == reflect.Struct {
+				walk(v.Field(i))
+				continue
+			}
+			out = append(out, setting{
+				env:    f.Tag.Get("env"),
+				flag:   f.Tag.Get("flag"),
+				usage:  f.Tag.Get("usage"),
+				secret: f.Tag.Get("secret") == "true",
+				value:  v.Field(i),
+			})
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem())
+	return out
+}
+
+func (s setting) set(raw string) error {
+	switch s.value.Interface().(type) {
+	case string:
+		s.value.SetString(raw)
+	case time.Duration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		s.value.SetInt(int64(d))
+	case int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		s.value.SetInt(int64(n))
+	case []string:
+		var list []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		s.value.Set(reflect.ValueOf(list))
+	case bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		s.value.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported setting type %s", s.value.Type())
+	}
+	return nil
+}
+
+// flagValue records the flags given so they can be applied after the file
+// and the environment, which are only known once the flags are parsed.
+type flagValue struct {
+	setting setting
+	value   string
+	set     *[]flagValue
+}
+
+func (f *flagValue) String() string {
+	if f.setting.value.IsValid() {
+		return fmt.Sprint(f.setting.value.Interface())
+	}
+	return ""
+}
+
+func (f *flagValue) Set(raw string) error {
+	// Parse into a scratch value so a bad flag fails at parse time.
+	scratch := setting{value: reflect.New(f.setting.value.Type()).Elem()}
+	if err := scratch.set(raw); err != nil {
+		return err
+	}
+	*f.set = append(*f.set, flagValue{setting: f.setting, value: raw})
+	return nil
+}
+
+func (f *flagValue) IsBoolFlag() bool {
+	return f.setting.value.Kind() == reflect.Bool
+}
@@ -0,0 +1,203 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func env(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func writeFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("should use the defaults", func(t *testing.T) {
+		cfg, opts, err := Load(nil, env(nil))
+
+		assert.NoError(t, err)
+		assert.Equal(t, Default(), cfg)
+		assert.False(t, opts.PrintConfig)
+	})
+	t.Run("should let flags override env override file", func(t *testing.T) {
+		path := writeFile(t, `
+server:
+  addr: ":9000"
+  read_timeout: 3s
+db:
+  max_open_conns: 10
+  max_idle_conns: 5
+log_level: warn
+`)
+
+		cfg, _, err := Load(
+			[]string{"--config", path, "--log-level", "debug", "--feature-docs=false"},
+			env(map[string]string{"SERVER_ADDR": ":9100", "LOG_LEVEL": "error", "DB_MAX_IDLE_CONNS": "2"}),
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, ":9100", cfg.Server.Addr)
+		assert.Equal(t, 3*time.Second, cfg.Server.ReadTimeout)
+		assert.Equal(t, 30*time.Second, cfg.Server.WriteTimeout)
+		assert.Equal(t, 10, cfg.DB.MaxOpenConns)
+		assert.Equal(t, 2, cfg.DB.MaxIdleConns)
+		assert.Equal(t, "debug", cfg.LogLevel)
+		assert.False(t, cfg.Features.Docs)
+		assert.True(t, cfg.Features.Metrics)
+	})
+	t.Run("should read the file from CONFIG_FILE", func(t *testing.T) {
+		path := writeFile(t, "storage: mysql\n")
+
+		cfg, _, err := Load(nil, env(map[string]string{"CONFIG_FILE": path}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, StorageMySQL, cfg.Storage)
+	})
+	t.Run("should select mysql with the local argument", func(t *testing.T) {
+		cfg, _, err := Load([]string{"local"}, env(nil))
+
+		assert.NoError(t, err)
+		assert.Equal(t, StorageMySQL, cfg.Storage)
+	})
+	t.Run("should listen on PORT unless an address is set", func(t *testing.T) {
+		cfg, _, err := Load(nil, env(map[string]string{"PORT": "3000"}))
+		assert.NoError(t, err)
+		assert.Equal(t, ":3000", cfg.Server.Addr)
+
+		cfg, _, err = Load([]string{"--addr", ":4000"}, env(map[string]string{"PORT": "3000"}))
+		assert.NoError(t, err)
+		assert.Equal(t, ":4000", cfg.Server.Addr)
+	})
+	t.Run("should split the replica DSNs", func(t *testing.T) {
+		cfg, _, err := Load(nil, env(map[string]string{"DB_REPLICA_DSNS": "ro:@tcp(replica-1)/melisprint, ro:@tcp(replica-2)/melisprint"}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ro:@tcp(replica-1)/melisprint", "ro:@tcp(replica-2)/melisprint"}, cfg.DB.ReplicaDSNs)
+	})
+	t.Run("should reject unknown keys in the file", func(t *testing.T) {
+		path := writeFile(t, "server:\n  adr: \":9000\"\n")
+
+		_, _, err := Load([]string{"--config", path}, env(nil))
+
+		assert.ErrorContains(t, err, "field adr not found")
+	})
+	t.Run("should reject malformed values", func(t *testing.T) {
+		_, _, err := Load([]string{"--read-timeout", "soon"}, env(nil))
+		assert.ErrorContains(t, err, `invalid value "soon" for flag -read-timeout`)
+
+		_, _, err = Load(nil, env(map[string]string{"DB_MAX_OPEN_CONNS": "many"}))
+		assert.ErrorContains(t, err, "DB_MAX_OPEN_CONNS")
+	})
+	t.Run("should report every invalid setting", func(t *testing.T) {
+		_, _, err := Load(
+			[]string{"--storage", "mysql", "--db-dsn", "", "--shutdown-timeout", "0s", "--log-level", "loud"},
+			env(nil),
+		)
+
+		assert.EqualError(t, err, "server.shutdown_timeout must be positive\n"+
+			"db.dsn is required by the mysql storage\n"+
+			`invalid log level "loud"`)
+	})
+	t.Run("should reject a write timeout shorter than the handler deadlines", func(t *testing.T) {
+		_, _, err := Load([]string{"--write-timeout", "10s", "--report-timeout", "20s", "--warehouse-events-stream-duration", "10s"}, env(nil))
+
+		assert.EqualError(t, err, "server.write_timeout must not be shorter than the request and report timeouts")
+	})
+	t.Run("should require the gRPC address only when gRPC is served", func(t *testing.T) {
+		_, _, err := Load([]string{"--grpc-addr", ""}, env(nil))
+		assert.EqualError(t, err, "server.grpc_addr is required by the grpc feature")
+
+		_, _, err = Load([]string{"--grpc-addr", "", "--feature-grpc=false"}, env(nil))
+		assert.NoError(t, err)
+	})
+	t.Run("should check the webhook settings only when webhooks are delivered", func(t *testing.T) {
+		_, _, err := Load([]string{"--webhooks-backoff-base", "2h", "--webhooks-max-attempts", "0"}, env(nil))
+		assert.EqualError(t, err, "webhooks.backoff_max must not be shorter than webhooks.backoff_base\n"+
+			"webhooks.batch_size and webhooks.max_attempts must be positive")
+
+		_, _, err = Load([]string{"--webhooks-max-attempts", "0", "--feature-webhooks=false"}, env(nil))
+		assert.NoError(t, err)
+	})
+	t.Run("should keep warehouse event streams within the write timeout", func(t *testing.T) {
+		_, _, err := Load([]string{"--warehouse-events-stream-duration", "1m"}, env(nil))
+		assert.EqualError(t, err, "warehouse_events.stream_duration must not be longer than server.write_timeout")
+
+		_, _, err = Load([]string{"--warehouse-events-stream-duration", "1m", "--feature-warehouse-events=false"}, env(nil))
+		assert.NoError(t, err)
+	})
+	t.Run("should check the idempotency settings only when keys are kept", func(t *testing.T) {
+		_, _, err := Load([]string{"--idempotency-ttl", "0s"}, env(nil))
+		assert.EqualError(t, err, "idempotency.ttl and idempotency.purge_interval must be positive")
+
+		_, _, err = Load([]string{"--idempotency-ttl", "0s", "--feature-idempotency=false"}, env(nil))
+		assert.NoError(t, err)
+
+		_, _, err = Load([]string{"--idempotency-max-body-size", "1024"}, env(nil))
+		assert.EqualError(t, err, "idempotency.max_body_size must not be smaller than edi.max_file_size")
+	})
+	t.Run("should check the rate limits only when requests are limited", func(t *testing.T) {
+		_, _, err := Load([]string{"--rate-limit-reports-burst", "0"}, env(nil))
+		assert.EqualError(t, err, "rate_limit.reads_burst, rate_limit.writes_burst and rate_limit.reports_burst must be positive")
+
+		_, _, err = Load([]string{"--rate-limit-reports-burst", "0", "--feature-rate-limit=false"}, env(nil))
+		assert.NoError(t, err)
+
+		_, _, err = Load([]string{"--rate-limit-max-clients", "0"}, env(nil))
+		assert.EqualError(t, err, "rate_limit.max_clients must be positive")
+	})
+	t.Run("should check the report cache settings only when reports are cached", func(t *testing.T) {
+		_, _, err := Load([]string{"--report-cache-entries", "0"}, env(nil))
+		assert.EqualError(t, err, "report_cache.entries and report_cache.ttl must be positive")
+
+		_, _, err = Load([]string{"--report-cache-entries", "0", "--feature-report-cache=false"}, env(nil))
+		assert.NoError(t, err)
+	})
+	t.Run("should serve the default site", func(t *testing.T) {
+		_, _, err := Load([]string{"--tenancy-sites", "MLB,mlm"}, env(nil))
+		assert.EqualError(t, err, "tenancy.sites must be 3 uppercase letters, got \"mlm\"\ntenancy.default_site must be one of tenancy.sites, got \"MLA\"")
+
+		cfg, _, err := Load([]string{"--tenancy-sites", "MLB,MLM", "--tenancy-default-site", "MLB"}, env(nil))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"MLB", "MLM"}, cfg.Tenancy.Sites)
+	})
+	t.Run("should fit the EDI interchange id in the envelope", func(t *testing.T) {
+		_, _, err := Load([]string{"--edi-interchange-id", "MELI*SPRINT"}, env(nil))
+		assert.EqualError(t, err, `edi.interchange_id must have 1 to 15 characters and no EDI delimiters, got "MELI*SPRINT"`)
+
+		_, _, err = Load([]string{"--edi-interchange-id", "MELISPRINT-WAREHOUSES", "--edi-max-file-size", "0"}, env(nil))
+		assert.EqualError(t, err, `edi.interchange_id must have 1 to 15 characters and no EDI delimiters, got "MELISPRINT-WAREHOUSES"`+"\nedi.max_file_size must be positive")
+	})
+	t.Run("should take the cycle count threshold", func(t *testing.T) {
+		cfg, _, err := Load([]string{"--cycle-count-approval-threshold", "0"}, env(nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, cfg.CycleCount.ApprovalThreshold)
+
+		_, _, err = Load([]string{"--cycle-count-approval-threshold", "-1"}, env(nil))
+		assert.EqualError(t, err, "cycle_count.approval_threshold must not be negative")
+	})
+}
+
+func TestPrint(t *testing.T) {
+	cfg := Default()
+	cfg.DB.DSN = "app:s3cret@tcp(db:3306)/melisprint"
+	cfg.DB.ReplicaDSNs = []string{"ro:s3cret@tcp(replica:3306)/melisprint"}
+
+	var buf bytes.Buffer
+	assert.NoError(t, cfg.Print(&buf))
+
+	assert.NotContains(t, buf.String(), "s3cret")
+	assert.Contains(t, buf.String(), "dsn: "+redacted)
+	assert.Contains(t, buf.String(), "read_timeout: 10s")
+	assert.Contains(t, buf.String(), "replica_dsns:\n        - "+redacted)
+	assert.Equal(t, "app:s3cret@tcp(db:3306)/melisprint", cfg.DB.DSN)
+	assert.Equal(t, []string{"ro:s3cret@tcp(replica:3306)/melisprint"}, cfg.DB.ReplicaDSNs)
+}
@@ -0,0 +1,56 @@
+package country
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/cache"
+)
+
+// reportTags are the tables the report goes through.
+var reportTags = []string{"countries", "provinces", "localities", "sellers", "carries"}
+
+type cachedService struct {
+	Service
+	cache *cache.Cache
+}
+
+// WithCache returns s with the report cached in c, and the writes to the
+// countries invalidating it. A nil c leaves s as is.
+func WithCache(s Service, c *cache.Cache) Service {
+	if c == nil {
+		return s
+	}
+	return &cachedService{Service: s, cache: c}
+}
+
+func (s *cachedService) Report(ctx context.Context, id int) ([]domain.CountryReport, error) {
+	return cache.Load(ctx, s.cache, "countries.report:"+strconv.Itoa(id), reportTags, func(ctx context.Context) ([]domain.CountryReport, error) {
+		return s.Service.Report(ctx, id)
+	})
+}
+
+func (s *cachedService) Save(ctx context.Context, c domain.Country) (domain.Country, error) {
+	c, err := s.Service.Save(ctx, c)
+	if err == nil {
+		s.cache.Invalidate(ctx, "countries")
+	}
+	return c, err
+}
+
+func (s *cachedService) Update(ctx context.Context, c domain.Country, id int) (domain.Country, error) {
+	c, err := s.Service.Update(ctx, c, id)
+	if err == nil {
+		s.cache.Invalidate(ctx, "countries")
+	}
+	return c, err
+}
+
+func (s *cachedService) Delete(ctx context.Context, id int) error {
+	err := s.Service.Delete(ctx, id)
+	if err == nil {
+		s.cache.Invalidate(ctx, "countries")
+	}
+	return err
+}
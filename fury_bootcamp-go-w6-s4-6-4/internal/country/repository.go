@@ -0,0 +1,175 @@
+package country
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+)
+
+// Repository encapsulates the storage of the countries.
+type Repository interface {
+	GetAll(ctx context.Context) ([]domain.Country, error)
+	Get(ctx context.Context, id int) (domain.Country, error)
+	Exists(ctx context.Context, id int) bool
+	ExistsName(ctx context.Context, name string) bool
+	// Save stores c under its ID, or under a new one when it's 0.
+	Save(ctx context.Context, c domain.Country) (int, error)
+	Update(ctx context.Context, c domain.Country) error
+	Delete(ctx context.Context, id int) error
+	// Report counts the sellers and the carries of every country, or only
+	// of the country id unless it's 0.
+	Report(ctx context.Context, id int) ([]domain.CountryReport, error)
+}
+
+type repository struct {
+	db     *database.Router
+	stmts  *database.Statements
+	logger *slog.Logger
+}
+
+func NewRepository(db *database.Router, stmts *database.Statements, logger *slog.Logger) Repository {
+	stmts.Register(SAVE_COUNTRY, UPDATE_COUNTRY, DELETE_COUNTRY)
+	return &repository{
+		db:     db,
+		stmts:  stmts,
+		logger: logger,
+	}
+}
+
+const (
+	GET_COUNTRIES       = "SELECT id, site_id, country_name FROM countries WHERE site_id = COALESCE(?, site_id) ORDER BY id"
+	GET_COUNTRY         = "SELECT id, site_id, country_name FROM countries WHERE id=? AND site_id = COALESCE(?, site_id)"
+	EXISTS_COUNTRY      = "SELECT id FROM countries WHERE id=? AND site_id = COALESCE(?, site_id)"
+	EXISTS_COUNTRY_NAME = "SELECT id FROM countries WHERE country_name=? AND site_id = COALESCE(?, site_id)"
+	SAVE_COUNTRY        = "INSERT INTO countries (id, site_id, country_name) VALUES (?, ?, ?)"
+	UPDATE_COUNTRY      = "UPDATE countries SET country_name=? WHERE id=? AND site_id = COALESCE(?, site_id)"
+	DELETE_COUNTRY      = "DELETE FROM countries WHERE id=? AND site_id = COALESCE(?, site_id)"
+
+	// The counts go through the provinces and the localities of each
+	// country; a country without any counts zero.
+	GET_COUNTRIES_REPORT = "SELECT c.id, c.country_name, " +
+		"(SELECT COUNT(*) FROM seller s JOIN locality l ON l.site_id = s.site_id AND l.id = s.locality_id JOIN provinces p ON p.site_id = l.site_id AND p.id = l.province_id WHERE p.site_id = c.site_id AND p.country_id = c.id), " +
+		"(SELECT COUNT(*) FROM carries ca JOIN locality l ON l.site_id = ca.site_id AND l.id = ca.locality_id JOIN provinces p ON p.site_id = l.site_id AND p.id = l.province_id WHERE p.site_id = c.site_id AND p.country_id = c.id) " +
+		"FROM countries c WHERE (? = 0 OR c.id = ?) AND c.site_id = COALESCE(?, c.site_id) ORDER BY c.id"
+)
+
+func (r *repository) GetAll(ctx context.Context) ([]domain.Country, error) {
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, GET_COUNTRIES, tenant.Filter(ctx))
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+
+	countries := []domain.Country{}
+	for rows.Next() {
+		var c domain.Country
+		if err := rows.Scan(&c.ID, &c.SiteID, &c.CountryName); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		countries = append(countries, c)
+	}
+	return countries, apperrors.FromDB(rows.Err())
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.Country, error) {
+	var c domain.Country
+	err := r.db.Writer().QueryRowContext(ctx, GET_COUNTRY, id, tenant.Filter(ctx)).Scan(&c.ID, &c.SiteID, &c.CountryName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Country{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.Country{}, apperrors.FromDB(err)
+	}
+	return c, nil
+}
+
+func (r *repository) Exists(ctx context.Context, id int) bool {
+	return r.exists(ctx, "Exists", EXISTS_COUNTRY, id)
+}
+
+func (r *repository) ExistsName(ctx context.Context, name string) bool {
+	return r.exists(ctx, "ExistsName", EXISTS_COUNTRY_NAME, name)
+}
+
+func (r *repository) exists(ctx context.Context, check, query string, arg interface{}) bool {
+	var id int
+	err := r.db.Writer().QueryRowContext(ctx, query, arg, tenant.Filter(ctx)).Scan(&id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", check, "error", err)
+	}
+	return err == nil
+}
+
+func (r *repository) Save(ctx context.Context, c domain.Country) (int, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt, err := r.stmts.Stmt(ctx, SAVE_COUNTRY)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	id := sql.NullInt64{Int64: int64(c.ID), Valid: c.ID != 0}
+	res, err := stmt.ExecContext(ctx, id, site, c.CountryName)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	return int(lastID), nil
+}
+
+func (r *repository) Update(ctx context.Context, c domain.Country) error {
+	stmt, err := r.stmts.Stmt(ctx, UPDATE_COUNTRY)
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	_, err = stmt.ExecContext(ctx, c.CountryName, c.ID, tenant.Filter(ctx))
+	return apperrors.FromDB(err)
+}
+
+func (r *repository) Delete(ctx context.Context, id int) error {
+	stmt, err := r.stmts.Stmt(ctx, DELETE_COUNTRY)
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	res, err := stmt.ExecContext(ctx, id, tenant.Filter(ctx))
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	if affected < 1 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *repository) Report(ctx context.Context, id int) ([]domain.CountryReport, error) {
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, GET_COUNTRIES_REPORT, id, id, tenant.Filter(ctx))
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+
+	reports := []domain.CountryReport{}
+	for rows.Next() {
+		var report domain.CountryReport
+		if err := rows.Scan(&report.CountryID, &report.CountryName, &report.SellersCount, &report.CarriesCount); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, apperrors.FromDB(rows.Err())
+}
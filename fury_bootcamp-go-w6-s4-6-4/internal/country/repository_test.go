@@ -0,0 +1,109 @@
+package country
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRepository(t *testing.T) (Repository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	return NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard()), mock
+}
+
+func TestRepositorySave(t *testing.T) {
+	ctx := tenant.NewContext(context.TODO(), "MLA")
+
+	t.Run("should let the database generate the id when it's 0", func(t *testing.T) {
+		repo, mock := newTestRepository(t)
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_COUNTRY))
+		mock.ExpectExec(regexp.QuoteMeta(SAVE_COUNTRY)).
+			WithArgs(sql.NullInt64{}, "MLA", "Argentina").
+			WillReturnResult(sqlmock.NewResult(7, 1))
+
+		id, err := repo.Save(ctx, domain.Country{CountryName: "Argentina"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 7, id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should keep an official id", func(t *testing.T) {
+		repo, mock := newTestRepository(t)
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_COUNTRY))
+		mock.ExpectExec(regexp.QuoteMeta(SAVE_COUNTRY)).
+			WithArgs(sql.NullInt64{Int64: 32, Valid: true}, "MLA", "Argentina").
+			WillReturnResult(sqlmock.NewResult(32, 1))
+
+		id, err := repo.Save(ctx, domain.Country{ID: 32, CountryName: "Argentina"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 32, id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should require a site", func(t *testing.T) {
+		repo, _ := newTestRepository(t)
+
+		_, err := repo.Save(context.TODO(), domain.Country{CountryName: "Argentina"})
+
+		assert.ErrorIs(t, err, tenant.ErrSiteRequired)
+	})
+}
+
+func TestRepositoryGet(t *testing.T) {
+	ctx := tenant.NewContext(context.TODO(), "MLA")
+
+	t.Run("should return the country", func(t *testing.T) {
+		repo, mock := newTestRepository(t)
+		rows := sqlmock.NewRows([]string{"id", "site_id", "country_name"}).AddRow(1, "MLA", "Argentina")
+		mock.ExpectQuery(regexp.QuoteMeta(GET_COUNTRY)).WithArgs(1, "MLA").WillReturnRows(rows)
+
+		c, err := repo.Get(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Country{ID: 1, SiteID: "MLA", CountryName: "Argentina"}, c)
+	})
+	t.Run("should return not found", func(t *testing.T) {
+		repo, mock := newTestRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta(GET_COUNTRY)).WithArgs(1, "MLA").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := repo.Get(ctx, 1)
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestRepositoryDelete(t *testing.T) {
+	repo, mock := newTestRepository(t)
+	mock.ExpectPrepare(regexp.QuoteMeta(DELETE_COUNTRY))
+	mock.ExpectExec(regexp.QuoteMeta(DELETE_COUNTRY)).WithArgs(1, "MLA").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.Delete(tenant.NewContext(context.TODO(), "MLA"), 1)
+
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestRepositoryReport(t *testing.T) {
+	repo, mock := newTestRepository(t)
+	rows := sqlmock.NewRows([]string{"id", "country_name", "sellers", "carries"}).
+		AddRow(1, "Argentina", 3, 2).
+		AddRow(2, "Brasil", 0, 0)
+	mock.ExpectQuery(regexp.QuoteMeta(GET_COUNTRIES_REPORT)).WithArgs(0, 0, "MLA").WillReturnRows(rows)
+
+	reports, err := repo.Report(tenant.NewContext(context.TODO(), "MLA"), 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.CountryReport{
+		{CountryID: 1, CountryName: "Argentina", SellersCount: 3, CarriesCount: 2},
+		{CountryID: 2, CountryName: "Brasil"},
+	}, reports)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,91 @@
+package country
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+// Errors
+var (
+	ErrNotFound   = apperrors.NotFound("country not found")
+	ErrExists     = apperrors.Conflict("id already exists")
+	ErrNameExists = apperrors.Conflict("country_name already exists")
+)
+
+type Service interface {
+	Get(ctx context.Context, id int) (domain.Country, error)
+	GetAll(ctx context.Context) ([]domain.Country, error)
+	Save(ctx context.Context, c domain.Country) (domain.Country, error)
+	Update(ctx context.Context, c domain.Country, id int) (domain.Country, error)
+	Delete(ctx context.Context, id int) error
+	// Report counts the sellers and the carries of every country, or only
+	// of the country id unless it's 0.
+	Report(ctx context.Context, id int) ([]domain.CountryReport, error)
+}
+
+type service struct {
+	repository Repository
+	logger     *slog.Logger
+}
+
+func NewService(repository Repository, logger *slog.Logger) Service {
+	return &service{repository, logger}
+}
+
+func (s *service) Get(ctx context.Context, id int) (domain.Country, error) {
+	return s.repository.Get(ctx, id)
+}
+
+func (s *service) GetAll(ctx context.Context) ([]domain.Country, error) {
+	return s.repository.GetAll(ctx)
+}
+
+func (s *service) Save(ctx context.Context, c domain.Country) (domain.Country, error) {
+	if c.ID != 0 && s.repository.Exists(ctx, c.ID) {
+		return domain.Country{}, ErrExists
+	}
+	if s.repository.ExistsName(ctx, c.CountryName) {
+		return domain.Country{}, ErrNameExists
+	}
+
+	id, err := s.repository.Save(ctx, c)
+	if err != nil {
+		return domain.Country{}, err
+	}
+	s.logger.InfoContext(ctx, "country created", "country_id", id)
+	return s.repository.Get(ctx, id)
+}
+
+func (s *service) Update(ctx context.Context, c domain.Country, id int) (domain.Country, error) {
+	original, err := s.repository.Get(ctx, id)
+	if err != nil {
+		return domain.Country{}, err
+	}
+	if c.CountryName == "" || c.CountryName == original.CountryName {
+		return original, nil
+	}
+	if s.repository.ExistsName(ctx, c.CountryName) {
+		return domain.Country{}, ErrNameExists
+	}
+
+	original.CountryName = c.CountryName
+	return original, s.repository.Update(ctx, original)
+}
+
+func (s *service) Delete(ctx context.Context, id int) error {
+	return s.repository.Delete(ctx, id)
+}
+
+func (s *service) Report(ctx context.Context, id int) ([]domain.CountryReport, error) {
+	reports, err := s.repository.Report(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if id != 0 && len(reports) == 0 {
+		return nil, ErrNotFound
+	}
+	return reports, nil
+}
@@ -0,0 +1,89 @@
+package country
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/country"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceSave(t *testing.T) {
+	ctx := context.Background()
+	newService := func() Service {
+		return NewService(&country.MockRepository{DataMock: []domain.Country{{ID: 32, CountryName: "Argentina"}}}, logger.Discard())
+	}
+
+	t.Run("should generate the id", func(t *testing.T) {
+		c, err := newService().Save(ctx, domain.Country{CountryName: "Brasil"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Country{ID: 33, CountryName: "Brasil"}, c)
+	})
+	t.Run("should keep an official id", func(t *testing.T) {
+		c, err := newService().Save(ctx, domain.Country{ID: 76, CountryName: "Brasil"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 76, c.ID)
+	})
+	t.Run("should not reuse an id", func(t *testing.T) {
+		_, err := newService().Save(ctx, domain.Country{ID: 32, CountryName: "Brasil"})
+
+		assert.ErrorIs(t, err, ErrExists)
+	})
+	t.Run("should not repeat a name", func(t *testing.T) {
+		_, err := newService().Save(ctx, domain.Country{CountryName: "Argentina"})
+
+		assert.ErrorIs(t, err, ErrNameExists)
+	})
+}
+
+func TestServiceUpdate(t *testing.T) {
+	ctx := context.Background()
+	repo := &country.MockRepository{DataMock: []domain.Country{{ID: 1, CountryName: "Argentina"}, {ID: 2, CountryName: "Brasil"}}}
+	service := NewService(repo, logger.Discard())
+
+	t.Run("should keep the name when it's empty", func(t *testing.T) {
+		c, err := service.Update(ctx, domain.Country{}, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Argentina", c.CountryName)
+	})
+	t.Run("should rename the country", func(t *testing.T) {
+		c, err := service.Update(ctx, domain.Country{CountryName: "República Argentina"}, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Country{ID: 1, CountryName: "República Argentina"}, c)
+		assert.Equal(t, c, repo.DataMock[0])
+	})
+	t.Run("should not take the name of another country", func(t *testing.T) {
+		_, err := service.Update(ctx, domain.Country{CountryName: "Brasil"}, 1)
+
+		assert.ErrorIs(t, err, ErrNameExists)
+	})
+	t.Run("should return not found", func(t *testing.T) {
+		_, err := service.Update(ctx, domain.Country{CountryName: "Chile"}, 9)
+
+		assert.Equal(t, "country not found", err.Error())
+	})
+}
+
+func TestServiceReport(t *testing.T) {
+	ctx := context.Background()
+	reports := []domain.CountryReport{{CountryID: 1, CountryName: "Argentina", SellersCount: 3, CarriesCount: 2}}
+	service := NewService(&country.MockRepository{Reports: reports}, logger.Discard())
+
+	t.Run("should report every country", func(t *testing.T) {
+		got, err := service.Report(ctx, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, reports, got)
+	})
+	t.Run("should return not found for an unknown country", func(t *testing.T) {
+		_, err := service.Report(ctx, 9)
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -0,0 +1,38 @@
+package cyclecount
+
+import (
+	"context"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/cache"
+)
+
+type cachedService struct {
+	Service
+	cache *cache.Cache
+}
+
+// WithCache returns s with the counts that adjust batches invalidating the
+// reports cached in c that go through them. A nil c leaves s as is.
+func WithCache(s Service, c *cache.Cache) Service {
+	if c == nil {
+		return s
+	}
+	return &cachedService{Service: s, cache: c}
+}
+
+func (s *cachedService) Submit(ctx context.Context, id int, submitter string, counts []Count) (domain.CycleCount, error) {
+	c, err := s.Service.Submit(ctx, id, submitter, counts)
+	if err == nil && c.Status == domain.CycleCountPosted {
+		s.cache.Invalidate(ctx, "product_batches")
+	}
+	return c, err
+}
+
+func (s *cachedService) Approve(ctx context.Context, id int, approved bool, supervisor string) (domain.CycleCount, error) {
+	c, err := s.Service.Approve(ctx, id, approved, supervisor)
+	if err == nil && c.Status == domain.CycleCountPosted {
+		s.cache.Invalidate(ctx, "product_batches")
+	}
+	return c, err
+}
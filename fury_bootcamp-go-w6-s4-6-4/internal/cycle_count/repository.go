@@ -0,0 +1,295 @@
+package cyclecount
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/warehouse"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+)
+
+// Repository encapsulates the storage of the cycle counts, and the stock of
+// the batches they adjust.
+type Repository interface {
+	GetAll(ctx context.Context) ([]domain.CycleCount, error)
+	// Get returns the count id with its lines.
+	Get(ctx context.Context, id int) (domain.CycleCount, error)
+	// EmployeeWarehouse and SectionWarehouse return the warehouse the
+	// employee works at and the section is in, and false when there's no
+	// such employee or section.
+	EmployeeWarehouse(ctx context.Context, id int) (int, bool, error)
+	SectionWarehouse(ctx context.Context, id int) (int, bool, error)
+	// SectionBatches returns the batches stored in the section id, with
+	// their product and current quantity.
+	SectionBatches(ctx context.Context, id int) ([]domain.Product_batches, error)
+	// Save stores the count with its lines and returns its id and the ids
+	// of the lines.
+	Save(ctx context.Context, c domain.CycleCount) (int, []int, error)
+	// LockStatus returns the status of the count id, locked until the
+	// transaction of ctx ends so it's counted and approved only once.
+	LockStatus(ctx context.Context, id int) (string, error)
+	// LockQuantity returns the current quantity of the batch id, locked
+	// until the transaction of ctx ends so it's adjusted from what it is.
+	LockQuantity(ctx context.Context, id int) (int, error)
+	// Adjust adds quantity, negative to take units away, to the current
+	// quantity of the batch id.
+	Adjust(ctx context.Context, id int, quantity int) error
+	// UpdateCounted stores what was counted of the lines of the count id,
+	// and its status.
+	UpdateCounted(ctx context.Context, id int, status string, lines []domain.CycleCountLine) error
+	// UpdateStatus stores the status of the count id, and the supervisor
+	// that decided on it.
+	UpdateStatus(ctx context.Context, id int, status string, approved_by string) error
+}
+
+type repository struct {
+	*warehouse.Assignments
+	db     *database.Router
+	stmts  *database.Statements
+	logger *slog.Logger
+}
+
+func NewRepository(db *database.Router, stmts *database.Statements, logger *slog.Logger) Repository {
+	stmts.Register(SAVE, SAVE_LINE, LOCK_STATUS, LOCK_QUANTITY, ADJUST, UPDATE_LINE, UPDATE_STATUS, UPDATE_APPROVED)
+	return &repository{
+		Assignments: warehouse.NewAssignments(db),
+		db:          db,
+		stmts:       stmts,
+		logger:      logger,
+	}
+}
+
+const (
+	GET_ALL   = "SELECT id, site_id, section_id, warehouse_id, employee_id, selection, COALESCE(abc_class, ''), status, created_on, COALESCE(approved_by, '') FROM cycle_counts WHERE site_id = COALESCE(?, site_id) ORDER BY id"
+	GET       = "SELECT id, site_id, section_id, warehouse_id, employee_id, selection, COALESCE(abc_class, ''), status, created_on, COALESCE(approved_by, '') FROM cycle_counts WHERE id=? AND site_id = COALESCE(?, site_id)"
+	GET_LINES = "SELECT id, product_batch_id, products_id, expected_quantity, counted_quantity, variance, COALESCE(reason_code, '') " +
+		"FROM cycle_count_lines WHERE cycle_count_id=? AND site_id = COALESCE(?, site_id) ORDER BY id"
+	// The lines of every count, read at once rather than per count.
+	GET_ALL_LINES = "SELECT cycle_count_id, id, product_batch_id, products_id, expected_quantity, counted_quantity, variance, COALESCE(reason_code, '') " +
+		"FROM cycle_count_lines WHERE site_id = COALESCE(?, site_id) ORDER BY id"
+	SECTION_BATCHES = "SELECT id, products_id, COALESCE(current_quantity, 0) FROM product_batches WHERE sections_id=? AND site_id = COALESCE(?, site_id) ORDER BY id"
+
+	SAVE            = "INSERT INTO cycle_counts(site_id,section_id,warehouse_id,employee_id,selection,abc_class,status,created_on) VALUES (?,?,?,?,?,?,?,?)"
+	SAVE_LINE       = "INSERT INTO cycle_count_lines(site_id,cycle_count_id,product_batch_id,products_id) VALUES (?,?,?,?)"
+	LOCK_STATUS     = "SELECT status FROM cycle_counts WHERE id=? AND site_id = COALESCE(?, site_id) FOR UPDATE"
+	LOCK_QUANTITY   = "SELECT COALESCE(current_quantity, 0) FROM product_batches WHERE id=? AND site_id = COALESCE(?, site_id) FOR UPDATE"
+	ADJUST          = "UPDATE product_batches SET current_quantity = COALESCE(current_quantity, 0) + ? WHERE id=? AND site_id = COALESCE(?, site_id)"
+	UPDATE_LINE     = "UPDATE cycle_count_lines SET expected_quantity=?, counted_quantity=?, variance=?, reason_code=? WHERE id=? AND site_id = COALESCE(?, site_id)"
+	UPDATE_STATUS   = "UPDATE cycle_counts SET status=? WHERE id=? AND site_id = COALESCE(?, site_id)"
+	UPDATE_APPROVED = "UPDATE cycle_counts SET status=?, approved_by=? WHERE id=? AND site_id = COALESCE(?, site_id)"
+)
+
+func (r *repository) GetAll(ctx context.Context) ([]domain.CycleCount, error) {
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, GET_ALL, tenant.Filter(ctx))
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+
+	counts := []domain.CycleCount{}
+	index := map[int]int{}
+	for rows.Next() {
+		c, err := scanCount(rows)
+		if err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		index[c.ID] = len(counts)
+		counts = append(counts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+
+	lines, err := r.db.Reader(ctx).QueryContext(ctx, GET_ALL_LINES, tenant.Filter(ctx))
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer lines.Close()
+	for lines.Next() {
+		var countID int
+		var l domain.CycleCountLine
+		var expected, counted, variance sql.NullInt64
+		if err := lines.Scan(&countID, &l.ID, &l.ProductBatchID, &l.ProductID, &expected, &counted, &variance, &l.ReasonCode); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		l.ExpectedQuantity, l.CountedQuantity, l.Variance = nullInt(expected), nullInt(counted), nullInt(variance)
+		if i, ok := index[countID]; ok {
+			counts[i].Lines = append(counts[i].Lines, l)
+		}
+	}
+	return counts, apperrors.FromDB(lines.Err())
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.CycleCount, error) {
+	c, err := scanCount(r.db.Reader(ctx).QueryRowContext(ctx, GET, id, tenant.Filter(ctx)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.CycleCount{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.CycleCount{}, apperrors.FromDB(err)
+	}
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, GET_LINES, id, tenant.Filter(ctx))
+	if err != nil {
+		return domain.CycleCount{}, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var l domain.CycleCountLine
+		var expected, counted, variance sql.NullInt64
+		if err := rows.Scan(&l.ID, &l.ProductBatchID, &l.ProductID, &expected, &counted, &variance, &l.ReasonCode); err != nil {
+			return domain.CycleCount{}, apperrors.FromDB(err)
+		}
+		l.ExpectedQuantity, l.CountedQuantity, l.Variance = nullInt(expected), nullInt(counted), nullInt(variance)
+		c.Lines = append(c.Lines, l)
+	}
+	return c, apperrors.FromDB(rows.Err())
+}
+
+// scanner is a *sql.Row or the *sql.Rows being iterated.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanCount scans a count without its lines, which are left empty.
+func scanCount(row scanner) (domain.CycleCount, error) {
+	c := domain.CycleCount{Lines: []domain.CycleCountLine{}}
+	err := row.Scan(&c.ID, &c.SiteID, &c.SectionID, &c.WarehouseID, &c.EmployeeID, &c.Selection, &c.Class, &c.Status, &c.CreatedOn, &c.ApprovedBy)
+	return c, err
+}
+
+// nullInt returns the value of n, or nil when it's NULL.
+func nullInt(n sql.NullInt64) *int {
+	if !n.Valid {
+		return nil
+	}
+	v := int(n.Int64)
+	return &v
+}
+
+func (r *repository) SectionBatches(ctx context.Context, id int) ([]domain.Product_batches, error) {
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, SECTION_BATCHES, id, tenant.Filter(ctx))
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+
+	var batches []domain.Product_batches
+	for rows.Next() {
+		pb := domain.Product_batches{SectionId: id}
+		if err := rows.Scan(&pb.ID, &pb.ProductId, &pb.CurrentQuantity); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		batches = append(batches, pb)
+	}
+	return batches, apperrors.FromDB(rows.Err())
+}
+
+func (r *repository) Save(ctx context.Context, c domain.CycleCount) (int, []int, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	class := sql.NullString{String: c.Class, Valid: c.Class != ""}
+
+	var id int
+	ids := make([]int, len(c.Lines))
+	err = r.db.Transact(ctx, func(ctx context.Context) error {
+		var err error
+		if id, err = r.insert(ctx, SAVE, site, c.SectionID, c.WarehouseID, c.EmployeeID, c.Selection, class, c.Status, c.CreatedOn); err != nil {
+			return err
+		}
+		for i, l := range c.Lines {
+			if ids[i], err = r.insert(ctx, SAVE_LINE, site, id, l.ProductBatchID, l.ProductID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, nil, apperrors.FromDB(err)
+	}
+	return id, ids, nil
+}
+
+func (r *repository) LockStatus(ctx context.Context, id int) (string, error) {
+	stmt, err := r.stmts.Stmt(ctx, LOCK_STATUS)
+	if err != nil {
+		return "", apperrors.FromDB(err)
+	}
+	var status string
+	err = stmt.QueryRowContext(ctx, id, tenant.Filter(ctx)).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrNotFound
+	}
+	return status, apperrors.FromDB(err)
+}
+
+func (r *repository) LockQuantity(ctx context.Context, id int) (int, error) {
+	stmt, err := r.stmts.Stmt(ctx, LOCK_QUANTITY)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	var quantity int
+	err = stmt.QueryRowContext(ctx, id, tenant.Filter(ctx)).Scan(&quantity)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrProductBatchNotExist
+	}
+	return quantity, apperrors.FromDB(err)
+}
+
+func (r *repository) Adjust(ctx context.Context, id int, quantity int) error {
+	return r.exec(ctx, ADJUST, quantity, id, tenant.Filter(ctx))
+}
+
+func (r *repository) UpdateCounted(ctx context.Context, id int, status string, lines []domain.CycleCountLine) error {
+	stmt, err := r.stmts.Stmt(ctx, UPDATE_LINE)
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	for _, l := range lines {
+		reason := sql.NullString{String: l.ReasonCode, Valid: l.ReasonCode != ""}
+		if _, err := stmt.ExecContext(ctx, l.ExpectedQuantity, l.CountedQuantity, l.Variance, reason, l.ID, tenant.Filter(ctx)); err != nil {
+			return apperrors.FromDB(err)
+		}
+	}
+	return r.exec(ctx, UPDATE_STATUS, status, id, tenant.Filter(ctx))
+}
+
+func (r *repository) UpdateStatus(ctx context.Context, id int, status string, approved_by string) error {
+	approver := sql.NullString{String: approved_by, Valid: approved_by != ""}
+	return r.exec(ctx, UPDATE_APPROVED, status, approver, id, tenant.Filter(ctx))
+}
+
+// insert runs the registered statement query and returns the id it
+// inserted.
+func (r *repository) insert(ctx context.Context, query string, args ...interface{}) (int, error) {
+	stmt, err := r.stmts.Stmt(ctx, query)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	res, err := stmt.ExecContext(ctx, args...)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	return int(id), nil
+}
+
+// exec runs the registered statement query.
+func (r *repository) exec(ctx context.Context, query string, args ...interface{}) error {
+	stmt, err := r.stmts.Stmt(ctx, query)
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	_, err = stmt.ExecContext(ctx, args...)
+	return apperrors.FromDB(err)
+}
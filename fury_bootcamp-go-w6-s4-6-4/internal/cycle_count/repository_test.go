@@ -0,0 +1,181 @@
+package cyclecount
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx = tenant.NewContext(context.TODO(), "MLA")
+
+var countColumns = []string{"id", "site_id", "section_id", "warehouse_id", "employee_id", "selection", "abc_class", "status", "created_on", "approved_by"}
+
+func newTestRepository(t *testing.T) (Repository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard()), mock
+}
+
+func TestRepositoryGet(t *testing.T) {
+	t.Run("should read the count with its lines", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta(GET)).WithArgs(1, "MLA").WillReturnRows(
+			sqlmock.NewRows(countColumns).AddRow(1, "MLA", 2, 3, 4, domain.CycleCountABC, "A", domain.CycleCountPendingApproval, "2024-01-12", ""))
+		mock.ExpectQuery(regexp.QuoteMeta(GET_LINES)).WithArgs(1, "MLA").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "product_batch_id", "products_id", "expected_quantity", "counted_quantity", "variance", "reason_code"}).
+				AddRow(5, 6, 7, 10, 25, 15, "found").
+				AddRow(8, 9, 7, nil, nil, nil, ""))
+
+		c, err := repository.Get(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CycleCount{
+			ID: 1, SiteID: "MLA", SectionID: 2, WarehouseID: 3, EmployeeID: 4, Selection: domain.CycleCountABC, Class: "A",
+			Status: domain.CycleCountPendingApproval, CreatedOn: "2024-01-12",
+			Lines: []domain.CycleCountLine{
+				{ID: 5, ProductBatchID: 6, ProductID: 7, ExpectedQuantity: quantity(10), CountedQuantity: quantity(25), Variance: quantity(15), ReasonCode: "found"},
+				{ID: 8, ProductBatchID: 9, ProductID: 7},
+			},
+		}, c)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should not find a missing count", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta(GET)).WithArgs(9, "MLA").WillReturnRows(sqlmock.NewRows(countColumns))
+
+		_, err := repository.Get(ctx, 9)
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepositoryGetAll(t *testing.T) {
+	repository, mock := newTestRepository(t)
+	mock.ExpectQuery(regexp.QuoteMeta(GET_ALL)).WithArgs("MLA").WillReturnRows(
+		sqlmock.NewRows(countColumns).
+			AddRow(1, "MLA", 2, 3, 4, domain.CycleCountAll, "", domain.CycleCountPosted, "2024-01-12", "").
+			AddRow(2, "MLA", 2, 3, 4, domain.CycleCountRandom, "", domain.CycleCountOpen, "2024-01-13", ""))
+	mock.ExpectQuery(regexp.QuoteMeta(GET_ALL_LINES)).WithArgs("MLA").WillReturnRows(
+		sqlmock.NewRows([]string{"cycle_count_id", "id", "product_batch_id", "products_id", "expected_quantity", "counted_quantity", "variance", "reason_code"}).
+			AddRow(1, 5, 6, 7, 10, 10, 0, ""))
+
+	counts, err := repository.GetAll(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, counts, 2)
+	assert.Equal(t, []domain.CycleCountLine{{ID: 5, ProductBatchID: 6, ProductID: 7, ExpectedQuantity: quantity(10), CountedQuantity: quantity(10), Variance: quantity(0)}}, counts[0].Lines)
+	assert.Equal(t, []domain.CycleCountLine{}, counts[1].Lines)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepositorySectionBatches(t *testing.T) {
+	repository, mock := newTestRepository(t)
+	mock.ExpectQuery(regexp.QuoteMeta(SECTION_BATCHES)).WithArgs(2, "MLA").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "products_id", "current_quantity"}).AddRow(6, 7, 10).AddRow(9, 7, 0))
+
+	batches, err := repository.SectionBatches(ctx, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Product_batches{{ID: 6, ProductId: 7, SectionId: 2, CurrentQuantity: 10}, {ID: 9, ProductId: 7, SectionId: 2}}, batches)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepositorySave(t *testing.T) {
+	count := domain.CycleCount{
+		SectionID: 2, WarehouseID: 3, EmployeeID: 4, Selection: domain.CycleCountABC, Class: "B", Status: domain.CycleCountOpen, CreatedOn: "2024-01-12",
+		Lines: []domain.CycleCountLine{{ProductBatchID: 6, ProductID: 7}, {ProductBatchID: 9, ProductID: 7}},
+	}
+	// The statements are prepared on first use, which in a transaction is
+	// once on a connection of their own and once on the transaction's.
+	t.Run("should save the count with its lines in a transaction", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE))
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE))
+		mock.ExpectExec(regexp.QuoteMeta(SAVE)).WithArgs("MLA", 2, 3, 4, domain.CycleCountABC, sql.NullString{String: "B", Valid: true}, domain.CycleCountOpen, "2024-01-12").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_LINE))
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_LINE))
+		mock.ExpectExec(regexp.QuoteMeta(SAVE_LINE)).WithArgs("MLA", 1, 6, 7).WillReturnResult(sqlmock.NewResult(5, 1))
+		mock.ExpectExec(regexp.QuoteMeta(SAVE_LINE)).WithArgs("MLA", 1, 9, 7).WillReturnResult(sqlmock.NewResult(8, 1))
+		mock.ExpectCommit()
+
+		id, ids, err := repository.Save(ctx, count)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, id)
+		assert.Equal(t, []int{5, 8}, ids)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should not keep the count when a line fails", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE))
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE))
+		mock.ExpectExec(regexp.QuoteMeta(SAVE)).WithArgs("MLA", 2, 3, 4, domain.CycleCountABC, sql.NullString{String: "B", Valid: true}, domain.CycleCountOpen, "2024-01-12").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_LINE))
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_LINE))
+		mock.ExpectExec(regexp.QuoteMeta(SAVE_LINE)).WithArgs("MLA", 1, 6, 7).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		_, _, err := repository.Save(ctx, count)
+
+		assert.ErrorIs(t, err, apperrors.ErrUnavailable)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepositoryLockQuantity(t *testing.T) {
+	t.Run("should read the quantity locked", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectPrepare(regexp.QuoteMeta(LOCK_QUANTITY))
+		mock.ExpectQuery(regexp.QuoteMeta(LOCK_QUANTITY)).WithArgs(6, "MLA").WillReturnRows(sqlmock.NewRows([]string{"current_quantity"}).AddRow(10))
+
+		quantity, err := repository.LockQuantity(ctx, 6)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 10, quantity)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should need the batch", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectPrepare(regexp.QuoteMeta(LOCK_QUANTITY))
+		mock.ExpectQuery(regexp.QuoteMeta(LOCK_QUANTITY)).WithArgs(9, "MLA").WillReturnRows(sqlmock.NewRows([]string{"current_quantity"}))
+
+		_, err := repository.LockQuantity(ctx, 9)
+
+		assert.ErrorIs(t, err, ErrProductBatchNotExist)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepositoryUpdateCounted(t *testing.T) {
+	repository, mock := newTestRepository(t)
+	mock.ExpectPrepare(regexp.QuoteMeta(UPDATE_LINE))
+	mock.ExpectExec(regexp.QuoteMeta(UPDATE_LINE)).WithArgs(10, 7, -3, sql.NullString{String: "lost", Valid: true}, 5, "MLA").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(UPDATE_LINE)).WithArgs(4, 4, 0, sql.NullString{}, 8, "MLA").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare(regexp.QuoteMeta(UPDATE_STATUS))
+	mock.ExpectExec(regexp.QuoteMeta(UPDATE_STATUS)).WithArgs(domain.CycleCountPosted, 1, "MLA").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare(regexp.QuoteMeta(ADJUST))
+	mock.ExpectExec(regexp.QuoteMeta(ADJUST)).WithArgs(-3, 6, "MLA").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repository.UpdateCounted(ctx, 1, domain.CycleCountPosted, []domain.CycleCountLine{
+		{ID: 5, ProductBatchID: 6, ExpectedQuantity: quantity(10), CountedQuantity: quantity(7), Variance: quantity(-3), ReasonCode: "lost"},
+		{ID: 8, ProductBatchID: 9, ExpectedQuantity: quantity(4), CountedQuantity: quantity(4), Variance: quantity(0)},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, repository.Adjust(ctx, 6, -3))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,378 @@
+package cyclecount
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+const dateLayout = "2006-01-02"
+
+// The cumulative shares of the stock of a section the products of class A
+// and B make up, by descending stock. The rest are class C.
+const (
+	classAShare = 0.80
+	classBShare = 0.95
+)
+
+// Errors
+var (
+	ErrNotFound             = apperrors.NotFound("cycle count not found")
+	ErrNotOpen              = apperrors.Conflict("cycle count already counted")
+	ErrNotPending           = apperrors.Conflict("cycle count is not pending approval")
+	ErrNotSupervisor        = apperrors.Forbidden("only supervisors can approve cycle counts")
+	ErrSupervisorUnknown    = apperrors.Unauthorized("the supervisor approving the cycle count must be identified")
+	ErrSubmitterUnknown     = apperrors.Unauthorized("the employee submitting the cycle count must be identified")
+	ErrSectionNotExist      = apperrors.ForeignKeyViolation("section not exists")
+	ErrEmployeeNotExist     = apperrors.ForeignKeyViolation("employee not exists")
+	ErrProductBatchNotExist = apperrors.ForeignKeyViolation("product batch not exists")
+	ErrEmployeeWarehouse    = apperrors.Validation("the employee works at a different warehouse")
+	ErrEmployeeAssigned     = apperrors.Forbidden("the cycle count is assigned to a different employee")
+	ErrSampleSize           = apperrors.Validation("sample_size must be positive for a random selection")
+	ErrNothingToCount       = apperrors.Validation("the selection has no batches to count")
+	ErrLineNotExist         = apperrors.Validation("line not in the cycle count")
+	ErrLineCounted          = apperrors.Validation("line counted more than once")
+	ErrLineMissing          = apperrors.Validation("every line must be counted")
+	ErrReasonCode           = apperrors.Validation("reason_code must be one of damaged, expired, lost, found, misplaced, receiving_error")
+	ErrReasonMissing        = apperrors.Validation("reason_code is required for a line with a variance")
+)
+
+// Count is what was counted of a line of a cycle count.
+type Count struct {
+	LineID          int
+	CountedQuantity *int
+	ReasonCode      string
+}
+
+type Service interface {
+	GetAll(ctx context.Context) ([]domain.CycleCount, error)
+	Get(ctx context.Context, id int) (domain.CycleCount, error)
+	// Generate creates an open count of the batches of the section, assigned
+	// to the employee. The selection counts every batch, the batches of the
+	// products of class, or sample_size batches at random.
+	Generate(ctx context.Context, section_id int, employee_id int, selection string, class string, sample_size int) (domain.CycleCount, error)
+	// Submit stores what the employee assigned counted of every line and
+	// posts the variances, or leaves them pending approval when any is
+	// above the threshold. The submitter, who must be named, is the id of
+	// the employee assigned.
+	Submit(ctx context.Context, id int, submitter string, counts []Count) (domain.CycleCount, error)
+	// Approve posts the variances of a count pending approval, or rejects
+	// them, by the supervisor, who must be named.
+	Approve(ctx context.Context, id int, approved bool, supervisor string) (domain.CycleCount, error)
+}
+
+type service struct {
+	repository Repository
+	outbox     events.Outbox
+	threshold  int
+	logger     *slog.Logger
+	now        func() time.Time
+	shuffle    func(n int, swap func(i, j int))
+}
+
+// NewService returns a Service that needs approval for variances of more
+// than threshold units.
+func NewService(repository Repository, outbox events.Outbox, threshold int, logger *slog.Logger) Service {
+	return &service{repository: repository, outbox: outbox, threshold: threshold, logger: logger, now: time.Now, shuffle: rand.Shuffle}
+}
+
+func (s *service) GetAll(ctx context.Context) ([]domain.CycleCount, error) {
+	return s.repository.GetAll(ctx)
+}
+
+func (s *service) Get(ctx context.Context, id int) (domain.CycleCount, error) {
+	return s.repository.Get(ctx, id)
+}
+
+func (s *service) Generate(ctx context.Context, section_id int, employee_id int, selection string, class string, sample_size int) (domain.CycleCount, error) {
+	if selection == domain.CycleCountRandom && sample_size < 1 {
+		return domain.CycleCount{}, ErrSampleSize
+	}
+	warehouse, ok, err := s.repository.SectionWarehouse(ctx, section_id)
+	if err != nil {
+		return domain.CycleCount{}, err
+	}
+	if !ok {
+		return domain.CycleCount{}, ErrSectionNotExist
+	}
+	employeeWarehouse, ok, err := s.repository.EmployeeWarehouse(ctx, employee_id)
+	if err != nil {
+		return domain.CycleCount{}, err
+	}
+	if !ok {
+		return domain.CycleCount{}, ErrEmployeeNotExist
+	}
+	if employeeWarehouse != warehouse {
+		return domain.CycleCount{}, ErrEmployeeWarehouse
+	}
+
+	batches, err := s.repository.SectionBatches(ctx, section_id)
+	if err != nil {
+		return domain.CycleCount{}, err
+	}
+	switch selection {
+	case domain.CycleCountABC:
+		classes := classify(batches)
+		selected := batches[:0:0]
+		for _, pb := range batches {
+			if classes[pb.ProductId] == class {
+				selected = append(selected, pb)
+			}
+		}
+		batches = selected
+	case domain.CycleCountRandom:
+		batches = append(batches[:0:0], batches...)
+		s.shuffle(len(batches), func(i, j int) { batches[i], batches[j] = batches[j], batches[i] })
+		if sample_size < len(batches) {
+			batches = batches[:sample_size]
+		}
+		// Counted in the order they're stored, wherever they were picked.
+		sort.Slice(batches, func(i, j int) bool { return batches[i].ID < batches[j].ID })
+	}
+	if len(batches) == 0 {
+		return domain.CycleCount{}, ErrNothingToCount
+	}
+
+	c := domain.CycleCount{
+		SectionID:   section_id,
+		WarehouseID: warehouse,
+		EmployeeID:  employee_id,
+		Selection:   selection,
+		Status:      domain.CycleCountOpen,
+		CreatedOn:   s.now().UTC().Format(dateLayout),
+		Lines:       make([]domain.CycleCountLine, len(batches)),
+	}
+	if selection == domain.CycleCountABC {
+		c.Class = class
+	}
+	for i, pb := range batches {
+		c.Lines[i] = domain.CycleCountLine{ProductBatchID: pb.ID, ProductID: pb.ProductId}
+	}
+	id, ids, err := s.repository.Save(ctx, c)
+	if err != nil {
+		return domain.CycleCount{}, err
+	}
+	c.ID = id
+	for i := range c.Lines {
+		c.Lines[i].ID = ids[i]
+	}
+	s.logger.InfoContext(ctx, "cycle count created", "cycle_count_id", id, "section_id", section_id, "lines", len(c.Lines))
+	return c, nil
+}
+
+// classify returns the ABC class of the products of batches, by their share
+// of the stock of the section. A product is of class A while the products
+// with more stock than it make up less than classAShare of it, and of class
+// B while they make up less than classBShare. Products out of stock are of
+// class C.
+func classify(batches []domain.Product_batches) map[int]string {
+	stock := map[int]int{}
+	total := 0
+	for _, pb := range batches {
+		stock[pb.ProductId] += pb.CurrentQuantity
+		total += pb.CurrentQuantity
+	}
+	products := make([]int, 0, len(stock))
+	for id := range stock {
+		products = append(products, id)
+	}
+	sort.Slice(products, func(i, j int) bool {
+		if stock[products[i]] != stock[products[j]] {
+			return stock[products[i]] > stock[products[j]]
+		}
+		return products[i] < products[j]
+	})
+
+	classes := make(map[int]string, len(products))
+	before := 0
+	for _, id := range products {
+		share := 1.0
+		if total > 0 {
+			share = float64(before) / float64(total)
+		}
+		switch {
+		case stock[id] <= 0:
+			classes[id] = "C"
+		case share < classAShare:
+			classes[id] = "A"
+		case share < classBShare:
+			classes[id] = "B"
+		default:
+			classes[id] = "C"
+		}
+		before += stock[id]
+	}
+	return classes
+}
+
+func (s *service) Submit(ctx context.Context, id int, submitter string, counts []Count) (domain.CycleCount, error) {
+	if submitter == "" {
+		return domain.CycleCount{}, ErrSubmitterUnknown
+	}
+	c, err := s.repository.Get(ctx, id)
+	if err != nil {
+		return domain.CycleCount{}, err
+	}
+	if c.Status != domain.CycleCountOpen {
+		return domain.CycleCount{}, ErrNotOpen
+	}
+	if strconv.Itoa(c.EmployeeID) != submitter {
+		return domain.CycleCount{}, ErrEmployeeAssigned
+	}
+	counted, err := match(c.Lines, counts)
+	if err != nil {
+		return domain.CycleCount{}, err
+	}
+
+	err = s.outbox.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		// The status is read again, locked, so a count submitted meanwhile
+		// isn't posted twice.
+		status, err := s.repository.LockStatus(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if status != domain.CycleCountOpen {
+			return nil, ErrNotOpen
+		}
+
+		c.Status = domain.CycleCountPosted
+		for i, l := range c.Lines {
+			expected, err := s.repository.LockQuantity(ctx, l.ProductBatchID)
+			if err != nil {
+				return nil, err
+			}
+			count := counted[l.ID]
+			variance := *count.CountedQuantity - expected
+			if variance != 0 && count.ReasonCode == "" {
+				return nil, fmt.Errorf("line %d: %w", l.ID, ErrReasonMissing)
+			}
+			if variance > s.threshold || -variance > s.threshold {
+				c.Status = domain.CycleCountPendingApproval
+			}
+			c.Lines[i].ExpectedQuantity, c.Lines[i].CountedQuantity, c.Lines[i].Variance = &expected, count.CountedQuantity, &variance
+			c.Lines[i].ReasonCode = ""
+			if variance != 0 {
+				c.Lines[i].ReasonCode = count.ReasonCode
+			}
+		}
+		if err := s.repository.UpdateCounted(ctx, id, c.Status, c.Lines); err != nil {
+			return nil, err
+		}
+		if c.Status != domain.CycleCountPosted {
+			return nil, nil
+		}
+		return s.post(ctx, c)
+	})
+	if err != nil {
+		return domain.CycleCount{}, err
+	}
+	s.logger.InfoContext(ctx, "cycle count submitted", "cycle_count_id", id, "status", c.Status)
+	return c, nil
+}
+
+// match returns counts by the line they count, checking every line of
+// lines is counted once and the reason codes are valid.
+func match(lines []domain.CycleCountLine, counts []Count) (map[int]Count, error) {
+	exists := make(map[int]bool, len(lines))
+	for _, l := range lines {
+		exists[l.ID] = true
+	}
+	counted := make(map[int]Count, len(counts))
+	for _, count := range counts {
+		if !exists[count.LineID] {
+			return nil, fmt.Errorf("line %d: %w", count.LineID, ErrLineNotExist)
+		}
+		if _, ok := counted[count.LineID]; ok {
+			return nil, fmt.Errorf("line %d: %w", count.LineID, ErrLineCounted)
+		}
+		if count.ReasonCode != "" && !validReason(count.ReasonCode) {
+			return nil, fmt.Errorf("line %d: %w", count.LineID, ErrReasonCode)
+		}
+		counted[count.LineID] = count
+	}
+	if len(counted) != len(lines) {
+		return nil, ErrLineMissing
+	}
+	return counted, nil
+}
+
+func validReason(code string) bool {
+	for _, reason := range domain.AdjustmentReasons {
+		if code == reason {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *service) Approve(ctx context.Context, id int, approved bool, supervisor string) (domain.CycleCount, error) {
+	if supervisor == "" {
+		return domain.CycleCount{}, ErrSupervisorUnknown
+	}
+	c, err := s.repository.Get(ctx, id)
+	if err != nil {
+		return domain.CycleCount{}, err
+	}
+	if c.Status != domain.CycleCountPendingApproval {
+		return domain.CycleCount{}, ErrNotPending
+	}
+
+	err = s.outbox.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		status, err := s.repository.LockStatus(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if status != domain.CycleCountPendingApproval {
+			return nil, ErrNotPending
+		}
+
+		c.Status, c.ApprovedBy = domain.CycleCountRejected, supervisor
+		if approved {
+			c.Status = domain.CycleCountPosted
+		}
+		if err := s.repository.UpdateStatus(ctx, id, c.Status, supervisor); err != nil {
+			return nil, err
+		}
+		if !approved {
+			return nil, nil
+		}
+		// The batches may have moved since they were counted, so it's the
+		// variances that are posted rather than the quantities counted.
+		return s.post(ctx, c)
+	})
+	if err != nil {
+		return domain.CycleCount{}, err
+	}
+	s.logger.InfoContext(ctx, "cycle count approved", "cycle_count_id", id, "status", c.Status, "approved_by", supervisor)
+	return c, nil
+}
+
+// post adjusts the batches of c by their variances, and returns the stock
+// changes.
+func (s *service) post(ctx context.Context, c domain.CycleCount) ([]events.Event, error) {
+	changes := []events.Event{}
+	for _, l := range c.Lines {
+		if l.Variance == nil || *l.Variance == 0 {
+			continue
+		}
+		if err := s.repository.Adjust(ctx, l.ProductBatchID, *l.Variance); err != nil {
+			return nil, err
+		}
+		changes = append(changes, events.Event{Type: events.StockChanged, AggregateID: c.SectionID, WarehouseID: c.WarehouseID, Data: events.StockChange{
+			WarehouseID:    c.WarehouseID,
+			SectionID:      c.SectionID,
+			ProductID:      l.ProductID,
+			ProductBatchID: l.ProductBatchID,
+			Quantity:       *l.Variance,
+		}})
+	}
+	return changes, nil
+}
@@ -0,0 +1,319 @@
+package cyclecount
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	cyclecountmock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/cycle_count"
+	eventsmock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/events"
+	"github.com/stretchr/testify/assert"
+)
+
+// Section 1 holds 100 units of product 7, 15 of product 8 split in two
+// batches, 4 of product 9 and none of product 10.
+func newMockRepository() *cyclecountmock.MockRepository {
+	return &cyclecountmock.MockRepository{
+		Employees: []domain.Employee{{ID: 4, WarehouseID: 1}, {ID: 5, WarehouseID: 2}},
+		Sections:  []domain.Section{{ID: 1, WarehouseID: 1}, {ID: 2, WarehouseID: 1}},
+		Batches: []domain.Product_batches{
+			{ID: 1, SectionId: 1, ProductId: 7, CurrentQuantity: 100},
+			{ID: 2, SectionId: 1, ProductId: 8, CurrentQuantity: 10},
+			{ID: 3, SectionId: 1, ProductId: 8, CurrentQuantity: 5},
+			{ID: 4, SectionId: 1, ProductId: 9, CurrentQuantity: 4},
+			{ID: 5, SectionId: 1, ProductId: 10, CurrentQuantity: 0},
+		},
+	}
+}
+
+func newService(repo Repository, outbox events.Outbox) *service {
+	now := func() time.Time { return time.Date(2024, 1, 11, 22, 0, 0, 0, time.FixedZone("ART", -3*60*60)) }
+	return &service{repository: repo, outbox: outbox, threshold: 10, logger: logger.Discard(), now: now, shuffle: func(n int, swap func(i, j int)) {
+		// Reversed, so the sample is taken from the end.
+		for i := 0; i < n/2; i++ {
+			swap(i, n-1-i)
+		}
+	}}
+}
+
+func batchIDs(c domain.CycleCount) []int {
+	ids := []int{}
+	for _, l := range c.Lines {
+		ids = append(ids, l.ProductBatchID)
+	}
+	return ids
+}
+
+func quantity(n int) *int {
+	return &n
+}
+
+func TestServiceGenerate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should count every batch of the section", func(t *testing.T) {
+		repo := newMockRepository()
+
+		c, err := newService(repo, events.Discard).Generate(ctx, 1, 4, domain.CycleCountAll, "", 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, c.ID)
+		assert.Equal(t, domain.CycleCountOpen, c.Status)
+		assert.Equal(t, "2024-01-12", c.CreatedOn)
+		assert.Equal(t, 1, c.WarehouseID)
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, batchIDs(c))
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, []int{c.Lines[0].ID, c.Lines[1].ID, c.Lines[2].ID, c.Lines[3].ID, c.Lines[4].ID})
+		assert.Nil(t, c.Lines[0].ExpectedQuantity)
+		assert.Equal(t, c, repo.Data[0])
+	})
+
+	classes := []struct {
+		class   string
+		batches []int
+	}{
+		{class: "A", batches: []int{1}},
+		{class: "B", batches: []int{2, 3}},
+		{class: "C", batches: []int{4, 5}},
+	}
+	for _, tt := range classes {
+		t.Run("should count the batches of class "+tt.class, func(t *testing.T) {
+			c, err := newService(newMockRepository(), events.Discard).Generate(ctx, 1, 4, domain.CycleCountABC, tt.class, 0)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.class, c.Class)
+			assert.Equal(t, tt.batches, batchIDs(c))
+		})
+	}
+
+	t.Run("should count a random sample", func(t *testing.T) {
+		c, err := newService(newMockRepository(), events.Discard).Generate(ctx, 1, 4, domain.CycleCountRandom, "", 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{4, 5}, batchIDs(c))
+		assert.Empty(t, c.Class)
+	})
+	t.Run("should count the whole section when the sample is larger", func(t *testing.T) {
+		c, err := newService(newMockRepository(), events.Discard).Generate(ctx, 1, 4, domain.CycleCountRandom, "", 50)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, batchIDs(c))
+	})
+	t.Run("should need a sample of some batches", func(t *testing.T) {
+		repo := newMockRepository()
+
+		_, err := newService(repo, events.Discard).Generate(ctx, 1, 4, domain.CycleCountRandom, "", -1)
+
+		assert.ErrorIs(t, err, ErrSampleSize)
+		assert.Empty(t, repo.Data)
+	})
+
+	tests := []struct {
+		name     string
+		section  int
+		employee int
+		err      error
+	}{
+		{name: "should need the section", section: 9, employee: 4, err: ErrSectionNotExist},
+		{name: "should need the employee", section: 1, employee: 9, err: ErrEmployeeNotExist},
+		{name: "should take an employee of the warehouse", section: 1, employee: 5, err: ErrEmployeeWarehouse},
+		{name: "should need batches to count", section: 2, employee: 4, err: ErrNothingToCount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepository()
+
+			_, err := newService(repo, events.Discard).Generate(ctx, tt.section, tt.employee, domain.CycleCountAll, "", 0)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Empty(t, repo.Data)
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	t.Run("should break ties by product", func(t *testing.T) {
+		classes := classify([]domain.Product_batches{{ProductId: 2, CurrentQuantity: 50}, {ProductId: 1, CurrentQuantity: 50}})
+
+		assert.Equal(t, map[int]string{1: "A", 2: "A"}, classes)
+	})
+	t.Run("should take an empty section as class C", func(t *testing.T) {
+		classes := classify([]domain.Product_batches{{ProductId: 1}})
+
+		assert.Equal(t, map[int]string{1: "C"}, classes)
+	})
+}
+
+// newCount generates a count of batches 1 and 2, with lines 1 and 2.
+func newCount(t *testing.T, service *service) {
+	c, err := service.Generate(context.Background(), 1, 4, domain.CycleCountABC, "B", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, batchIDs(c))
+}
+
+func TestServiceSubmit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should post variances within the threshold", func(t *testing.T) {
+		repo := newMockRepository()
+		outbox := &eventsmock.MockOutbox{}
+		service := newService(repo, outbox)
+		newCount(t, service)
+
+		c, err := service.Submit(ctx, 1, "4", []Count{
+			{LineID: 2, CountedQuantity: quantity(5)},
+			{LineID: 1, CountedQuantity: quantity(7), ReasonCode: "damaged"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CycleCountPosted, c.Status)
+		assert.Equal(t, []int{10, 7, -3}, []int{*c.Lines[0].ExpectedQuantity, *c.Lines[0].CountedQuantity, *c.Lines[0].Variance})
+		assert.Equal(t, "damaged", c.Lines[0].ReasonCode)
+		assert.Equal(t, 0, *c.Lines[1].Variance)
+		assert.Empty(t, c.Lines[1].ReasonCode)
+		assert.Equal(t, c, repo.Data[0])
+		assert.Equal(t, []int{7, 5}, []int{repo.Batches[1].CurrentQuantity, repo.Batches[2].CurrentQuantity})
+		assert.Equal(t, []events.Event{{Type: events.StockChanged, AggregateID: 1, WarehouseID: 1, Data: events.StockChange{
+			WarehouseID: 1, SectionID: 1, ProductID: 8, ProductBatchID: 2, Quantity: -3,
+		}}}, outbox.Events)
+	})
+	t.Run("should leave variances above the threshold for approval", func(t *testing.T) {
+		repo := newMockRepository()
+		outbox := &eventsmock.MockOutbox{}
+		service := newService(repo, outbox)
+		newCount(t, service)
+
+		c, err := service.Submit(ctx, 1, "4", []Count{
+			{LineID: 1, CountedQuantity: quantity(21), ReasonCode: "found"},
+			{LineID: 2, CountedQuantity: quantity(4), ReasonCode: "lost"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CycleCountPendingApproval, c.Status)
+		assert.Equal(t, []int{11, -1}, []int{*c.Lines[0].Variance, *c.Lines[1].Variance})
+		assert.Equal(t, domain.CycleCountPendingApproval, repo.Data[0].Status)
+		assert.Equal(t, []int{10, 5}, []int{repo.Batches[1].CurrentQuantity, repo.Batches[2].CurrentQuantity})
+		assert.Empty(t, outbox.Events)
+	})
+
+	tests := []struct {
+		name      string
+		id        int
+		submitter string
+		counts    []Count
+		err       error
+	}{
+		{name: "should need the submitter", id: 1, err: ErrSubmitterUnknown},
+		{name: "should need the count", id: 9, submitter: "4", err: apperrors.ErrNotFound},
+		{name: "should take the employee assigned", id: 1, submitter: "5", err: ErrEmployeeAssigned},
+		{name: "should need every line", id: 1, submitter: "4", counts: []Count{{LineID: 1, CountedQuantity: quantity(10)}}, err: ErrLineMissing},
+		{name: "should take only the lines of the count", id: 1, submitter: "4", counts: []Count{{LineID: 1, CountedQuantity: quantity(10)}, {LineID: 3, CountedQuantity: quantity(5)}}, err: ErrLineNotExist},
+		{name: "should count a line once", id: 1, submitter: "4", counts: []Count{{LineID: 1, CountedQuantity: quantity(10)}, {LineID: 1, CountedQuantity: quantity(5)}}, err: ErrLineCounted},
+		{name: "should take a valid reason", id: 1, submitter: "4", counts: []Count{{LineID: 1, CountedQuantity: quantity(9), ReasonCode: "stolen"}, {LineID: 2, CountedQuantity: quantity(5)}}, err: ErrReasonCode},
+		{name: "should need the reason of a variance", id: 1, submitter: "4", counts: []Count{{LineID: 1, CountedQuantity: quantity(9)}, {LineID: 2, CountedQuantity: quantity(5)}}, err: ErrReasonMissing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepository()
+			service := newService(repo, events.Discard)
+			newCount(t, service)
+
+			_, err := service.Submit(ctx, tt.id, tt.submitter, tt.counts)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, domain.CycleCountOpen, repo.Data[0].Status)
+			assert.Equal(t, 10, repo.Batches[1].CurrentQuantity)
+		})
+	}
+
+	t.Run("should count once", func(t *testing.T) {
+		repo := newMockRepository()
+		service := newService(repo, events.Discard)
+		newCount(t, service)
+		counts := []Count{{LineID: 1, CountedQuantity: quantity(8), ReasonCode: "lost"}, {LineID: 2, CountedQuantity: quantity(5)}}
+		_, err := service.Submit(ctx, 1, "4", counts)
+		assert.NoError(t, err)
+
+		_, err = service.Submit(ctx, 1, "4", counts)
+
+		assert.ErrorIs(t, err, ErrNotOpen)
+		assert.Equal(t, 8, repo.Batches[1].CurrentQuantity)
+	})
+}
+
+func TestServiceApprove(t *testing.T) {
+	ctx := context.Background()
+	pending := func(t *testing.T, repo *cyclecountmock.MockRepository, outbox events.Outbox) *service {
+		service := newService(repo, outbox)
+		newCount(t, service)
+		c, err := service.Submit(ctx, 1, "4", []Count{
+			{LineID: 1, CountedQuantity: quantity(25), ReasonCode: "found"},
+			{LineID: 2, CountedQuantity: quantity(3), ReasonCode: "damaged"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CycleCountPendingApproval, c.Status)
+		return service
+	}
+
+	t.Run("should post the variances approved", func(t *testing.T) {
+		repo := newMockRepository()
+		outbox := &eventsmock.MockOutbox{}
+		service := pending(t, repo, outbox)
+		// Moved since it was counted.
+		repo.Batches[1].CurrentQuantity = 12
+
+		c, err := service.Approve(ctx, 1, true, "sup-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CycleCountPosted, c.Status)
+		assert.Equal(t, "sup-1", c.ApprovedBy)
+		assert.Equal(t, []string{domain.CycleCountPosted, "sup-1"}, []string{repo.Data[0].Status, repo.Data[0].ApprovedBy})
+		assert.Equal(t, []int{27, 3}, []int{repo.Batches[1].CurrentQuantity, repo.Batches[2].CurrentQuantity})
+		assert.Len(t, outbox.Events, 2)
+	})
+	t.Run("should leave the batches of the variances rejected", func(t *testing.T) {
+		repo := newMockRepository()
+		outbox := &eventsmock.MockOutbox{}
+		service := pending(t, repo, outbox)
+
+		c, err := service.Approve(ctx, 1, false, "sup-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CycleCountRejected, c.Status)
+		assert.Equal(t, domain.CycleCountRejected, repo.Data[0].Status)
+		assert.Equal(t, []int{10, 5}, []int{repo.Batches[1].CurrentQuantity, repo.Batches[2].CurrentQuantity})
+		assert.Empty(t, outbox.Events)
+	})
+	t.Run("should decide once", func(t *testing.T) {
+		repo := newMockRepository()
+		service := pending(t, repo, events.Discard)
+		_, err := service.Approve(ctx, 1, false, "sup-1")
+		assert.NoError(t, err)
+
+		_, err = service.Approve(ctx, 1, true, "sup-2")
+
+		assert.ErrorIs(t, err, ErrNotPending)
+		assert.Equal(t, "sup-1", repo.Data[0].ApprovedBy)
+	})
+	t.Run("should need the count pending", func(t *testing.T) {
+		repo := newMockRepository()
+		service := newService(repo, events.Discard)
+		newCount(t, service)
+
+		_, err := service.Approve(ctx, 1, true, "sup-1")
+
+		assert.ErrorIs(t, err, ErrNotPending)
+	})
+	t.Run("should need the supervisor", func(t *testing.T) {
+		repo := newMockRepository()
+		service := pending(t, repo, events.Discard)
+
+		_, err := service.Approve(ctx, 1, true, "")
+
+		assert.ErrorIs(t, err, ErrSupervisorUnknown)
+		assert.Equal(t, domain.CycleCountPendingApproval, repo.Data[0].Status)
+	})
+}
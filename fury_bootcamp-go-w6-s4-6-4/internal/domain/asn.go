@@ -0,0 +1,45 @@
+package domain
+
+// Statuses of an advance shipping notice.
+const (
+	ASNPending  = "pending"
+	ASNReceived = "received"
+)
+
+// ASN is an advance shipping notice: what a seller declares it's sending to
+// a warehouse and when it's expected. InboundOrderID is the order it was
+// received as, once it arrives.
+type ASN struct {
+	ID             int       `json:"id"`
+	SiteID         string    `json:"site_id,omitempty"`
+	ASNNumber      string    `json:"asn_number"`
+	SellerID       int       `json:"seller_id"`
+	WarehouseID    int       `json:"warehouse_id"`
+	ETA            string    `json:"eta"`
+	Status         string    `json:"status"`
+	InboundOrderID int       `json:"inbound_order_id,omitempty"`
+	Lines          []ASNLine `json:"lines"`
+}
+
+// ASNLine is a batch of a product the seller sends. ProductBatchID is the
+// batch it was received as.
+type ASNLine struct {
+	ID                int    `json:"id"`
+	ProductID         int    `json:"product_id"`
+	Quantity          int    `json:"quantity"`
+	BatchNumber       int    `json:"batch_number"`
+	ManufacturingDate string `json:"manufacturing_date"`
+	DueDate           string `json:"due_date"`
+	ProductBatchID    int    `json:"product_batch_id,omitempty"`
+}
+
+// ASNOverdueReport is a notice still pending after its ETA.
+type ASNOverdueReport struct {
+	ID          int    `json:"id"`
+	ASNNumber   string `json:"asn_number"`
+	SellerID    int    `json:"seller_id"`
+	WarehouseID int    `json:"warehouse_id"`
+	ETA         string `json:"eta"`
+	DaysOverdue int    `json:"days_overdue"`
+	Quantity    int    `json:"quantity"`
+}
@@ -2,6 +2,7 @@ package domain
 
 type Buyer struct {
 	ID           int    `json:"id"`
+	SiteID       string `json:"site_id,omitempty"`
 	CardNumberID string `json:"card_number_id"`
 	FirstName    string `json:"first_name"`
 	LastName     string `json:"last_name"`
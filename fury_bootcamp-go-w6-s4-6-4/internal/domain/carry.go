@@ -2,6 +2,7 @@ package domain
 
 type Carry struct {
 	ID           int    `json:"id"`
+	SiteID       string `json:"site_id,omitempty"`
 	CID          string `json:"cid"`
 	Company_name string `json:"company_name"`
 	Address      string `json:"address"`
@@ -0,0 +1,16 @@
+package domain
+
+type Country struct {
+	ID          int    `json:"id"`
+	SiteID      string `json:"site_id,omitempty"`
+	CountryName string `json:"country_name"`
+}
+
+// CountryReport counts the sellers and the carries of the localities of a
+// country.
+type CountryReport struct {
+	CountryID    int    `json:"country_id"`
+	CountryName  string `json:"country_name"`
+	SellersCount int    `json:"sellers_count"`
+	CarriesCount int    `json:"carries_count"`
+}
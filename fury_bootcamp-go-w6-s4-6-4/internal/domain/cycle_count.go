@@ -0,0 +1,51 @@
+package domain
+
+// Statuses of a cycle count. A count is open until counted, and waits for a
+// supervisor when any variance is above the approval threshold.
+const (
+	CycleCountOpen            = "open"
+	CycleCountPendingApproval = "pending_approval"
+	CycleCountPosted          = "posted"
+	CycleCountRejected        = "rejected"
+)
+
+// Selections of the batches of a section a cycle count goes through.
+const (
+	CycleCountAll    = "all"
+	CycleCountABC    = "abc"
+	CycleCountRandom = "random"
+)
+
+// AdjustmentReasons are the reason codes a variance is posted with.
+var AdjustmentReasons = []string{"damaged", "expired", "lost", "found", "misplaced", "receiving_error"}
+
+// CycleCount is a physical count of batches of a section, assigned to an
+// employee of its warehouse. Class is the ABC class counted, when the
+// selection is abc. ApprovedBy is the supervisor that approved or rejected
+// the adjustments.
+type CycleCount struct {
+	ID          int              `json:"id"`
+	SiteID      string           `json:"site_id,omitempty"`
+	SectionID   int              `json:"section_id"`
+	WarehouseID int              `json:"warehouse_id"`
+	EmployeeID  int              `json:"employee_id"`
+	Selection   string           `json:"selection"`
+	Class       string           `json:"class,omitempty"`
+	Status      string           `json:"status"`
+	CreatedOn   string           `json:"created_on"`
+	ApprovedBy  string           `json:"approved_by,omitempty"`
+	Lines       []CycleCountLine `json:"lines"`
+}
+
+// CycleCountLine is a batch to count. ExpectedQuantity is the quantity of
+// the batch when the count was submitted, and Variance what was counted
+// over it, negative when units are missing. They're nil until counted.
+type CycleCountLine struct {
+	ID               int    `json:"id"`
+	ProductBatchID   int    `json:"product_batch_id"`
+	ProductID        int    `json:"product_id"`
+	ExpectedQuantity *int   `json:"expected_quantity"`
+	CountedQuantity  *int   `json:"counted_quantity"`
+	Variance         *int   `json:"variance"`
+	ReasonCode       string `json:"reason_code,omitempty"`
+}
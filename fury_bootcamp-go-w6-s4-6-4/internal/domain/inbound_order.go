@@ -1,10 +1,67 @@
 package domain
 
+// Statuses of an inbound order, given by the quantities received on its
+// lines against the expected ones.
+const (
+	InboundOrderOpen              = "open"
+	InboundOrderPartiallyReceived = "partially_received"
+	InboundOrderClosed            = "closed"
+	InboundOrderOverReceived      = "over_received"
+)
+
 type Inbound_order struct {
-	ID               int    `json:"id"`
-	Order_date       string `json:"order_date"`
-	Order_number     string `json:"order_number"`
-	Employee_id      int    `json:"employee_id"`
-	Product_batch_id int    `json:"product_batch_id"`
-	Warehouse_id     int    `json:"warehouse_id"`
+	ID               int                `json:"id"`
+	SiteID           string             `json:"site_id,omitempty"`
+	Order_date       string             `json:"order_date"`
+	Order_number     string             `json:"order_number"`
+	Employee_id      int                `json:"employee_id"`
+	Product_batch_id int                `json:"product_batch_id"`
+	Warehouse_id     int                `json:"warehouse_id"`
+	Status           string             `json:"status"`
+	Lines            []InboundOrderLine `json:"lines,omitempty"`
+}
+
+// InboundOrderLine is a product the order expects, with the quantity
+// received of it so far. BatchNumber, when set, names the batches its
+// receipts create.
+type InboundOrderLine struct {
+	ID               int `json:"id"`
+	ProductID        int `json:"product_id"`
+	BatchNumber      int `json:"batch_number,omitempty"`
+	ExpectedQuantity int `json:"expected_quantity"`
+	ReceivedQuantity int `json:"received_quantity"`
+}
+
+// InboundReceipt is a delivery received against an inbound order. Status
+// is the status of the order once it's received.
+type InboundReceipt struct {
+	ID             int                  `json:"id"`
+	InboundOrderID int                  `json:"inbound_order_id"`
+	Status         string               `json:"status"`
+	Lines          []InboundReceiptLine `json:"lines"`
+}
+
+// InboundReceiptLine credits Quantity of the line LineID to the batch
+// ProductBatchID, or to a new batch in the section SectionID when it's 0.
+// A new batch is dated ManufacturingDate and DueDate when they're known, as
+// they are for the lines of an advance shipping notice.
+type InboundReceiptLine struct {
+	LineID            int    `json:"line_id"`
+	Quantity          int    `json:"quantity"`
+	ProductBatchID    int    `json:"product_batch_id,omitempty"`
+	SectionID         int    `json:"section_id,omitempty"`
+	ManufacturingDate string `json:"manufacturing_date,omitempty"`
+	DueDate           string `json:"due_date,omitempty"`
+}
+
+// InboundDiscrepancyReport sums the lines of the orders of a seller's
+// products that have been received, at least in part: what's missing and
+// what's in excess are counted per line.
+type InboundDiscrepancyReport struct {
+	SellerID         int `json:"seller_id"`
+	OrdersCount      int `json:"orders_count"`
+	ExpectedQuantity int `json:"expected_quantity"`
+	ReceivedQuantity int `json:"received_quantity"`
+	MissingQuantity  int `json:"missing_quantity"`
+	ExcessQuantity   int `json:"excess_quantity"`
 }
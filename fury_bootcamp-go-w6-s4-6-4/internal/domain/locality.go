@@ -1,10 +1,14 @@
 package domain
 
+// Locality belongs to a province. Its province and country names are read
+// through the province, and left empty on writes.
 type Locality struct {
 	ID           int    `json:"id"`
+	SiteID       string `json:"site_id,omitempty"`
 	LocalityName string `json:"locality_name"`
-	ProvinceName string `json:"province_name"`
-	CountryName  string `json:"country_name"`
+	ProvinceID   int    `json:"province_id"`
+	ProvinceName string `json:"province_name,omitempty"`
+	CountryName  string `json:"country_name,omitempty"`
 }
 
 type ResponseLocality struct {
@@ -3,6 +3,7 @@ package domain
 // Product represents an underlying URL with statistics on how it is used.
 type Product struct {
 	ID             int     `json:"id"`
+	SiteID         string  `json:"site_id,omitempty"`
 	Description    string  `json:"description"`
 	ExpirationRate int     `json:"expiration_rate"`
 	FreezingRate   int     `json:"freezing_rate"`
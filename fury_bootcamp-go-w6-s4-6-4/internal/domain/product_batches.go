@@ -3,6 +3,7 @@ package domain
 // "yyyy-mm-dd T00:00:00"
 type Product_batches struct {
 	ID                 int    `json:"id"`
+	SiteID             string `json:"site_id,omitempty"`
 	BatchNumber        int    `json:"section_number"`
 	CurrentQuantity    int    `json:"current_quantity"`
 	CurrentTemperature int    `json:"current_temperature"`
@@ -2,6 +2,7 @@ package domain
 
 type ProductRecords struct {
 	ID             	  int     	`json:"id"`
+	SiteID            string  	`json:"site_id,omitempty"`
 	LastUpdateDate    string  	`json:"last_update_date"`
 	PurchasePrice 	  float64   `json:"purchase_price"`
 	SalePrice   	  float64   `json:"sale_price"`
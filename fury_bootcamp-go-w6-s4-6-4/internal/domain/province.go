@@ -0,0 +1,17 @@
+package domain
+
+type Province struct {
+	ID           int    `json:"id"`
+	SiteID       string `json:"site_id,omitempty"`
+	ProvinceName string `json:"province_name"`
+	CountryID    int    `json:"country_id"`
+}
+
+// ProvinceReport counts the sellers and the carries of the localities of a
+// province.
+type ProvinceReport struct {
+	ProvinceID   int    `json:"province_id"`
+	ProvinceName string `json:"province_name"`
+	SellersCount int    `json:"sellers_count"`
+	CarriesCount int    `json:"carries_count"`
+}
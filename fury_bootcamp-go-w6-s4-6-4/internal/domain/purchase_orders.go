@@ -4,6 +4,7 @@ import "time"
 
 type PurchaseOrders struct {
 	ID              int             `json:"id"`
+	SiteID          string          `json:"site_id,omitempty"`
 	OrderNumber     string          `json:"order_number"`
 	OrderDate       *time.Time      `json:"order_date"`
 	TrackingCode    string          `json:"tracking_code"`
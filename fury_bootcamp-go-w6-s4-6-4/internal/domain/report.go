@@ -1,7 +1,52 @@
 package domain
 
+import "time"
+
 type CarriesReport struct {
 	LocalityID   int    `json:"locality_id"`
 	LocalityName string `json:"locality_name"`
 	CarriesCount int    `json:"carries_count"`
 }
+
+// ReportQuery asks a report for the counts between From and To, both
+// included and open when zero, grouped by GroupBy. IDs narrow the report to
+// those of the entity it's grouped by.
+type ReportQuery struct {
+	From    time.Time
+	To      time.Time
+	GroupBy []string
+	IDs     []int
+}
+
+// ReportResult is a report as time series: one per combination of the
+// entities it's grouped by, each with a point per period.
+type ReportResult struct {
+	Report  string         `json:"report"`
+	From    string         `json:"from,omitempty"`
+	To      string         `json:"to,omitempty"`
+	GroupBy []string       `json:"group_by"`
+	Series  []ReportSeries `json:"series"`
+}
+
+// ReportSeries holds the ids of the entities it counts, keyed by dimension,
+// and its points in chronological order. Without a period dimension it has
+// a single point without a period.
+type ReportSeries struct {
+	Dimensions map[string]int `json:"dimensions"`
+	Points     []ReportPoint  `json:"points"`
+}
+
+// ReportPoint counts a period, given by the date it starts on.
+type ReportPoint struct {
+	Period string `json:"period,omitempty"`
+	Count  int    `json:"count"`
+}
+
+// ReportRow is a count as stored: Period is the first day of its period,
+// empty when the report isn't grouped by one, and IDs are the ids of its
+// entities in the order of the grouping.
+type ReportRow struct {
+	Period string
+	IDs    []int
+	Count  int
+}
@@ -1,13 +1,14 @@
 package domain
 
 type Section struct {
-	ID                 int `json:"id"`
-	SectionNumber      int `json:"section_number"`
-	CurrentTemperature int `json:"current_temperature"`
-	MinimumTemperature int `json:"minimum_temperature"`
-	CurrentCapacity    int `json:"current_capacity"`
-	MinimumCapacity    int `json:"minimum_capacity"`
-	MaximumCapacity    int `json:"maximum_capacity"`
-	WarehouseID        int `json:"warehouse_id"`
-	ProductTypeID      int `json:"product_type_id"`
+	ID                 int    `json:"id"`
+	SiteID             string `json:"site_id,omitempty"`
+	SectionNumber      int    `json:"section_number"`
+	CurrentTemperature int    `json:"current_temperature"`
+	MinimumTemperature int    `json:"minimum_temperature"`
+	CurrentCapacity    int    `json:"current_capacity"`
+	MinimumCapacity    int    `json:"minimum_capacity"`
+	MaximumCapacity    int    `json:"maximum_capacity"`
+	WarehouseID        int    `json:"warehouse_id"`
+	ProductTypeID      int    `json:"product_type_id"`
 }
@@ -2,6 +2,7 @@ package domain
 
 type Seller struct {
 	ID          int    `json:"id"`
+	SiteID      string `json:"site_id,omitempty"`
 	CID         int    `json:"cid"`
 	CompanyName string `json:"company_name"`
 	Address     string `json:"address"`
@@ -2,6 +2,7 @@ package domain
 
 type Warehouse struct {
 	ID                 int    `json:"id"`
+	SiteID             string `json:"site_id,omitempty"`
 	Address            string `json:"address"`
 	Telephone          string `json:"telephone"`
 	WarehouseCode      string `json:"warehouse_code"`
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Delivery statuses.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// WebhookSubscription receives the events of EventTypes, or every event when
+// it's empty. Secret signs the deliveries and is never returned.
+type WebhookSubscription struct {
+	ID         int       `json:"id"`
+	SiteID     string    `json:"site_id,omitempty"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"-"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// OutboxEvent is a domain event as recorded in the outbox. WarehouseID is 0
+// for the events that aren't about a single warehouse.
+type OutboxEvent struct {
+	ID          int64           `json:"id"`
+	SiteID      string          `json:"site_id,omitempty"`
+	Type        string          `json:"type"`
+	AggregateID int             `json:"aggregate_id"`
+	WarehouseID int             `json:"warehouse_id,omitempty"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// WebhookDelivery tracks sending an event to a subscription.
+type WebhookDelivery struct {
+	ID             int64      `json:"id"`
+	EventID        int64      `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	SubscriptionID int        `json:"subscription_id"`
+	URL            string     `json:"url"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LastStatus     *int       `json:"last_status"`
+	LastError      *string    `json:"last_error"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+}
+
+// WebhookAttempt is a due delivery along with what's needed to send it.
+type WebhookAttempt struct {
+	Delivery WebhookDelivery
+	Event    OutboxEvent
+	Secret   string
+}
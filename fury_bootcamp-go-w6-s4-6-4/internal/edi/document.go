@@ -0,0 +1,154 @@
+// Package edi reads and writes the EDI documents sellers and carriers
+// exchange instead of JSON: X12 856 and 943 ship notices and EDIFACT DESADV
+// despatch advices come in as advance shipping notices, and X12 944 receipt
+// advices and 856 ship notices go out.
+//
+// Only the segments the warehouse has a use for are read, and documents name
+// parties and products by their codes, never by the ids of the API.
+package edi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+// Standards of an interchange.
+const (
+	X12     = "X12"
+	EDIFACT = "EDIFACT"
+)
+
+// Types of document.
+const (
+	TypeShipNotice     = "856"
+	TypeShipmentAdvice = "943"
+	TypeReceiptAdvice  = "944"
+	TypeDespatchAdvice = "DESADV"
+)
+
+const dateLayout = "2006-01-02"
+
+// ErrInvalid is the kind of every error reading or writing an interchange,
+// which tells where it went wrong.
+var ErrInvalid = apperrors.Validation("invalid EDI interchange")
+
+// Interchange is the envelope of the documents a sender sends a receiver at
+// once. Control is the number the sender identifies it by.
+type Interchange struct {
+	Standard       string          `json:"standard"`
+	SenderID       string          `json:"sender_id"`
+	ReceiverID     string          `json:"receiver_id"`
+	Control        string          `json:"control"`
+	ShipNotices    []ShipNotice    `json:"ship_notices,omitempty"`
+	ReceiptAdvices []ReceiptAdvice `json:"receipt_advices,omitempty"`
+}
+
+// ShipNotice announces a shipment: an X12 856 or 943, or an EDIFACT DESADV,
+// as Type says. Dates are in the layout of the API, whatever the document
+// had.
+type ShipNotice struct {
+	Type         string `json:"type"`
+	Number       string `json:"number"`
+	Date         string `json:"date"`
+	ETA          string `json:"eta,omitempty"`
+	ShipFrom     Party  `json:"ship_from"`
+	ShipTo       Party  `json:"ship_to"`
+	Carrier      *Party `json:"carrier,omitempty"`
+	TrackingCode string `json:"tracking_code,omitempty"`
+	OrderNumber  string `json:"order_number,omitempty"`
+	OrderDate    string `json:"order_date,omitempty"`
+	Items        []Item `json:"items"`
+}
+
+// Item is a product shipped. The batch number and dates describe the lot,
+// when the sender knows it.
+type Item struct {
+	ProductCode       string `json:"product_code"`
+	Quantity          int    `json:"quantity"`
+	BatchNumber       string `json:"batch_number,omitempty"`
+	ManufacturingDate string `json:"manufacturing_date,omitempty"`
+	DueDate           string `json:"due_date,omitempty"`
+}
+
+// ReceiptAdvice confirms what a warehouse received: an X12 944. Number is
+// the receipt of the warehouse and ShipmentNumber the notice it answers.
+type ReceiptAdvice struct {
+	Number         string        `json:"number"`
+	Date           string        `json:"date"`
+	ShipmentNumber string        `json:"shipment_number,omitempty"`
+	Warehouse      Party         `json:"warehouse"`
+	Depositor      Party         `json:"depositor"`
+	Items          []ReceiptItem `json:"items"`
+}
+
+type ReceiptItem struct {
+	ProductCode string `json:"product_code"`
+	BatchNumber string `json:"batch_number,omitempty"`
+	Quantity    int    `json:"quantity"`
+}
+
+// Party is a seller, warehouse, buyer or carrier, named by the code both
+// ends know it by.
+type Party struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// Read parses an X12 or EDIFACT interchange, telling them apart by their
+// first segment.
+func Read(data []byte) (Interchange, error) {
+	var ic Interchange
+	var err error
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(data, []byte("ISA")):
+		ic, err = readX12(string(data))
+	case bytes.HasPrefix(data, []byte("UNA")), bytes.HasPrefix(data, []byte("UNB")):
+		ic, err = readEDIFACT(string(data))
+	default:
+		err = errors.New("not an X12 or EDIFACT interchange")
+	}
+	if err != nil {
+		return Interchange{}, invalid(err)
+	}
+	return ic, nil
+}
+
+// invalid makes err an ErrInvalid, keeping its message.
+func invalid(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalid, err)
+}
+
+// parseDate turns a CCYYMMDD date into the layout of the API.
+func parseDate(value string) (string, error) {
+	d, err := time.Parse("20060102", value)
+	if err != nil {
+		return "", fmt.Errorf("invalid date %q", value)
+	}
+	return d.Format(dateLayout), nil
+}
+
+// formatDate turns a date of the API into CCYYMMDD, or "" when it's empty.
+func formatDate(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	d, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return "", fmt.Errorf("invalid date %q", value)
+	}
+	return d.Format("20060102"), nil
+}
+
+func parseQuantity(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid quantity %q", value)
+	}
+	return n, nil
+}
@@ -0,0 +1,208 @@
+package edi
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// at is when the samples were written.
+var at = time.Date(2024, 1, 12, 9, 30, 0, 0, time.UTC)
+
+func readSample(t *testing.T, name string) []byte {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+func shipNotice() ShipNotice {
+	return ShipNotice{
+		Type:         TypeShipNotice,
+		Number:       "ASN-1",
+		Date:         "2024-01-12",
+		ETA:          "2024-01-20",
+		ShipFrom:     Party{ID: "101", Name: "Meli Foods"},
+		ShipTo:       Party{ID: "WH-1", Name: "Main warehouse"},
+		Carrier:      &Party{ID: "CA01", Name: "Andreani"},
+		TrackingCode: "TRK-77",
+		OrderNumber:  "PO-9",
+		OrderDate:    "2024-01-05",
+		Items: []Item{
+			{ProductCode: "P-1", Quantity: 10, BatchNumber: "5", ManufacturingDate: "2024-01-01", DueDate: "2024-06-01"},
+			{ProductCode: "P-2", Quantity: 4, ManufacturingDate: "2024-01-02", DueDate: "2024-03-01"},
+		},
+	}
+}
+
+func TestRead(t *testing.T) {
+	t.Run("should read an 856", func(t *testing.T) {
+		ic, err := Read(readSample(t, "856.edi"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, Interchange{Standard: X12, SenderID: "SELLER101", ReceiverID: "MELISPRINT", Control: "000000042", ShipNotices: []ShipNotice{shipNotice()}}, ic)
+	})
+	t.Run("should read a 943", func(t *testing.T) {
+		ic, err := Read(readSample(t, "943.edi"))
+
+		assert.NoError(t, err)
+		n := shipNotice()
+		n.Type, n.Number, n.TrackingCode, n.OrderDate = TypeShipmentAdvice, "ASN-2", "TRK-78", ""
+		assert.Equal(t, []ShipNotice{n}, ic.ShipNotices)
+	})
+	t.Run("should read a 944", func(t *testing.T) {
+		ic, err := Read(readSample(t, "944.edi"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []ReceiptAdvice{{
+			Number: "IO-7", Date: "2024-01-12", ShipmentNumber: "ASN-1",
+			Warehouse: Party{ID: "WH-1"}, Depositor: Party{ID: "101", Name: "Meli Foods"},
+			Items: []ReceiptItem{{ProductCode: "P-1", BatchNumber: "5", Quantity: 10}, {ProductCode: "P-2", Quantity: 3}},
+		}}, ic.ReceiptAdvices)
+	})
+	t.Run("should read a DESADV", func(t *testing.T) {
+		ic, err := Read(readSample(t, "desadv.edi"))
+
+		assert.NoError(t, err)
+		n := shipNotice()
+		n.Type, n.Number, n.TrackingCode, n.OrderDate = TypeDespatchAdvice, "ASN-3", "", ""
+		n.ShipFrom.Name = "Meli Foods+Co"
+		assert.Equal(t, Interchange{Standard: EDIFACT, SenderID: "SELLER101", ReceiverID: "MELISPRINT", Control: "44", ShipNotices: []ShipNotice{n}}, ic)
+	})
+
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{name: "not EDI", data: `{"asn_number":"ASN-1"}`, err: "not an X12 or EDIFACT interchange"},
+		{name: "short ISA", data: "ISA*00*", err: "ISA segment too short"},
+		{name: "ISA of delimiters only", data: "ISA" + strings.Repeat("*", 103), err: "missing the ISA segment"},
+		{name: "ISA without its delimiters", data: "ISA~" + strings.Repeat("0", 102), err: "missing the ISA segment"},
+		{name: "SE miscounting", data: strings.Replace(string(readSample(t, "944.edi")), "SE*8*", "SE*9*", 1), err: "transaction set 0001: SE counts 9 segments, got 8"},
+		{name: "unsupported set", data: strings.Replace(string(readSample(t, "944.edi")), "ST*944", "ST*850", 1), err: "transaction set 0001: unsupported transaction set 850"},
+		{name: "missing SE", data: strings.Replace(string(readSample(t, "944.edi")), "SE*8*0001~", "", 1), err: "transaction set 0001 has no SE"},
+		{name: "bad date", data: strings.Replace(string(readSample(t, "856.edi")), "DTM*017*20240120", "DTM*017*20241320", 1), err: `transaction set 0001: DTM: invalid date "20241320"`},
+		{name: "bad quantity", data: strings.Replace(string(readSample(t, "943.edi")), "W04*4*", "W04*four*", 1), err: `transaction set 0001: W04: invalid quantity "four"`},
+		{name: "item without a product", data: strings.Replace(string(readSample(t, "856.edi")), "LIN**VP*P-2", "LIN**VP*", 1), err: "transaction set 0001: item 2: missing the product or the quantity"},
+		{name: "UNT miscounting", data: strings.Replace(string(readSample(t, "desadv.edi")), "UNT+21", "UNT+20", 1), err: "message 1: UNT counts 20 segments, got 21"},
+		{name: "unsupported message", data: strings.Replace(string(readSample(t, "desadv.edi")), "DESADV:D", "ORDERS:D", 1), err: "message 1: unsupported message type ORDERS"},
+		{name: "unsupported date format", data: strings.Replace(string(readSample(t, "desadv.edi")), "DTM+137:20240112:102", "DTM+137:240112:101", 1), err: "message 1: DTM: unsupported date format 101"},
+	}
+	for _, tt := range tests {
+		t.Run("should reject "+tt.name, func(t *testing.T) {
+			_, err := Read([]byte(tt.data))
+
+			assert.ErrorIs(t, err, ErrInvalid)
+			assert.ErrorIs(t, err, apperrors.ErrValidation)
+			assert.EqualError(t, err, "invalid EDI interchange: "+tt.err)
+		})
+	}
+}
+
+// FuzzReadX12 checks that Read turns down what it can't take as an invalid
+// interchange, rather than panicking on it.
+func FuzzReadX12(f *testing.F) {
+	for _, name := range []string{"856.edi", "943.edi", "944.edi"} {
+		data, err := os.ReadFile(filepath.Join("testdata", name))
+		require.NoError(f, err)
+		f.Add(data)
+	}
+	f.Add([]byte("ISA" + strings.Repeat("*", 103)))
+	f.Add([]byte("ISA~" + strings.Repeat("0", 102)))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if _, err := Read(data); err != nil {
+			assert.ErrorIs(t, err, ErrInvalid)
+		}
+	})
+}
+
+// TestRoundTrip writes back what's read from the samples, which are laid out
+// as WriteX12 lays its interchanges out.
+func TestRoundTrip(t *testing.T) {
+	for _, name := range []string{"856.edi", "943.edi", "944.edi"} {
+		t.Run(name, func(t *testing.T) {
+			sample := readSample(t, name)
+			ic, err := Read(sample)
+			require.NoError(t, err)
+
+			written, err := WriteX12(ic, at)
+
+			assert.NoError(t, err)
+			assert.Equal(t, string(sample), string(written))
+		})
+	}
+	t.Run("desadv.edi as an 856", func(t *testing.T) {
+		ic, err := Read(readSample(t, "desadv.edi"))
+		require.NoError(t, err)
+
+		written, err := WriteX12(ic, at)
+		require.NoError(t, err)
+		back, err := Read(written)
+
+		assert.NoError(t, err)
+		want := ic.ShipNotices[0]
+		want.Type = TypeShipNotice
+		assert.Equal(t, X12, back.Standard)
+		assert.Equal(t, "000000044", back.Control)
+		assert.Equal(t, []ShipNotice{want}, back.ShipNotices)
+	})
+}
+
+func TestWriteX12(t *testing.T) {
+	valid := Interchange{SenderID: "MELISPRINT", ReceiverID: "CA01", Control: "1", ShipNotices: []ShipNotice{shipNotice()}}
+
+	t.Run("should group the documents by function", func(t *testing.T) {
+		ic := valid
+		ic.ReceiptAdvices = []ReceiptAdvice{{Number: "IO-7", Date: "2024-01-12", Items: []ReceiptItem{{ProductCode: "P-1", Quantity: 1}}}}
+		ic.ShipNotices = append(ic.ShipNotices, shipNotice())
+
+		written, err := WriteX12(ic, at)
+
+		assert.NoError(t, err)
+		assert.Contains(t, string(written), "GS*SH*MELISPRINT*CA01*20240112*0930*1*X*004010~")
+		assert.Contains(t, string(written), "ST*856*0002~")
+		assert.Contains(t, string(written), "GE*2*1~\nGS*RE*MELISPRINT*CA01*20240112*0930*2*X*004010~")
+		assert.True(t, strings.HasSuffix(string(written), "GE*1*2~\nIEA*2*000000001~\n"))
+	})
+	t.Run("should blank the delimiters out of values", func(t *testing.T) {
+		ic := valid
+		ic.ShipNotices = []ShipNotice{shipNotice()}
+		ic.ShipNotices[0].ShipTo.Name = "Buyer*1~"
+
+		written, err := WriteX12(ic, at)
+
+		assert.NoError(t, err)
+		assert.Contains(t, string(written), "N1*ST*Buyer 1 *92*WH-1~")
+	})
+
+	tests := []struct {
+		name   string
+		change func(ic *Interchange)
+		err    string
+	}{
+		{name: "a control that isn't a number", change: func(ic *Interchange) { ic.Control = "A1" }, err: `control "A1" is not a number of up to 9 digits`},
+		{name: "a long sender", change: func(ic *Interchange) { ic.SenderID = "MELISPRINT-WAREHOUSES" }, err: "sender and receiver ids must have 1 to 15 characters"},
+		{name: "a notice without items", change: func(ic *Interchange) { ic.ShipNotices = []ShipNotice{{Number: "ASN-1"}} }, err: "transaction set 0001: no items"},
+		{name: "a bad date", change: func(ic *Interchange) {
+			ic.ShipNotices = []ShipNotice{shipNotice()}
+			ic.ShipNotices[0].Items[0].DueDate = "01/06/2024"
+		}, err: `transaction set 0001: invalid date "01/06/2024"`},
+	}
+	for _, tt := range tests {
+		t.Run("should reject "+tt.name, func(t *testing.T) {
+			ic := valid
+			tt.change(&ic)
+
+			_, err := WriteX12(ic, at)
+
+			assert.ErrorIs(t, err, ErrInvalid)
+			assert.EqualError(t, err, "invalid EDI interchange: "+tt.err)
+		})
+	}
+}
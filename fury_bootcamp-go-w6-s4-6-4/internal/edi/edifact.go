@@ -0,0 +1,152 @@
+package edi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// edifact are the delimiters of an interchange without a UNA segment.
+var edifact = delimiters{segment: '\'', element: '+', component: ':', release: '?'}
+
+// unaLength is the length of the UNA segment, which sets the delimiters
+// instead: component, element, decimal mark, release, a space and segment.
+const unaLength = 9
+
+func readEDIFACT(data string) (Interchange, error) {
+	d := edifact
+	if strings.HasPrefix(data, "UNA") {
+		if len(data) < unaLength {
+			return Interchange{}, errors.New("UNA segment too short")
+		}
+		d = delimiters{component: data[3], element: data[4], release: data[6], segment: data[8]}
+		data = data[unaLength:]
+	}
+	segments := split(data, d)
+	if len(segments) == 0 || segments[0].tag != "UNB" {
+		return Interchange{}, errors.New("missing the UNB segment")
+	}
+	unb := segments[0]
+	ic := Interchange{
+		Standard:   EDIFACT,
+		SenderID:   unb.element(2),
+		ReceiverID: unb.element(3),
+		Control:    unb.element(5),
+	}
+
+	var message []segment
+	for _, s := range segments[1:] {
+		switch s.tag {
+		case "UNH":
+			if message != nil {
+				return Interchange{}, fmt.Errorf("message %s has no UNT", message[0].element(1))
+			}
+			message = []segment{s}
+		case "UNT":
+			if message == nil {
+				return Interchange{}, errors.New("UNT without UNH")
+			}
+			if err := ic.addEDIFACT(append(message, s)); err != nil {
+				return Interchange{}, err
+			}
+			message = nil
+		default:
+			if message != nil {
+				message = append(message, s)
+			}
+		}
+	}
+	if message != nil {
+		return Interchange{}, fmt.Errorf("message %s has no UNT", message[0].element(1))
+	}
+	return ic, nil
+}
+
+// addEDIFACT reads the message, from its UNH to its UNT, into ic.
+func (ic *Interchange) addEDIFACT(message []segment) error {
+	unh, unt := message[0], message[len(message)-1]
+	if unt.element(1) != strconv.Itoa(len(message)) {
+		return fmt.Errorf("message %s: UNT counts %s segments, got %d", unh.element(1), unt.element(1), len(message))
+	}
+	if typ := unh.component(2, 1); typ != TypeDespatchAdvice {
+		return fmt.Errorf("message %s: unsupported message type %s", unh.element(1), typ)
+	}
+	n, err := readDESADV(message[1 : len(message)-1])
+	if err != nil {
+		return fmt.Errorf("message %s: %w", unh.element(1), err)
+	}
+	ic.ShipNotices = append(ic.ShipNotices, n)
+	return nil
+}
+
+// readDESADV reads a despatch advice. Its packaging levels are flattened,
+// as the warehouse receives products rather than packages.
+func readDESADV(segments []segment) (ShipNotice, error) {
+	n := ShipNotice{Type: TypeDespatchAdvice}
+	var item *Item
+	for _, s := range segments {
+		var err error
+		switch s.tag {
+		case "BGM":
+			n.Number = s.element(2)
+		case "DTM":
+			err = n.readEDIFACTDate(item, s.component(1, 1), s.component(1, 2), s.component(1, 3))
+		case "RFF":
+			switch s.component(1, 1) {
+			case "ON":
+				n.OrderNumber = s.component(1, 2)
+			case "CN":
+				n.TrackingCode = s.component(1, 2)
+			}
+		case "NAD":
+			n.readParty(s.element(1), Party{ID: s.component(2, 1), Name: s.component(4, 1)})
+		case "TDT":
+			n.Carrier = &Party{ID: s.component(5, 1), Name: s.component(5, 4)}
+		case "LIN":
+			item = n.addItem()
+			item.ProductCode = s.component(3, 1)
+		case "QTY":
+			if item != nil && s.component(1, 1) == "12" {
+				item.Quantity, err = parseQuantity(s.component(1, 2))
+			}
+		case "GIN":
+			if item != nil && s.element(1) == "BX" {
+				item.BatchNumber = s.component(2, 1)
+			}
+		}
+		if err != nil {
+			return ShipNotice{}, fmt.Errorf("%s: %w", s.tag, err)
+		}
+	}
+	return n, n.check()
+}
+
+// readEDIFACTDate takes the document date and the ETA of the despatch, and
+// the production and expiry dates of an item, by their qualifiers. Dates
+// are CCYYMMDD, format 102, or CCYYMMDDHHMM, format 203, of which the time
+// is dropped.
+func (n *ShipNotice) readEDIFACTDate(item *Item, qualifier, value, format string) error {
+	switch format {
+	case "", "102":
+	case "203":
+		if len(value) != 12 {
+			return fmt.Errorf("invalid date %q", value)
+		}
+		value = value[:8]
+	default:
+		return fmt.Errorf("unsupported date format %s", format)
+	}
+	var err error
+	switch {
+	case qualifier == "137":
+		n.Date, err = parseDate(value)
+	case qualifier == "17" || qualifier == "132":
+		n.ETA, err = parseDate(value)
+	case qualifier == "94" && item != nil:
+		item.ManufacturingDate, err = parseDate(value)
+	case qualifier == "36" && item != nil:
+		item.DueDate, err = parseDate(value)
+	}
+	return err
+}
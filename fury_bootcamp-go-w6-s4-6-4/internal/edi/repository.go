@@ -0,0 +1,178 @@
+package edi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+)
+
+// Repository finds what the documents name by code, and reads what the
+// documents written tell.
+type Repository interface {
+	// SellerID, WarehouseID and ProductID return the id of the seller,
+	// warehouse or product with the code given, and ErrSellerNotExist,
+	// ErrWarehouseNotExist or ErrProductNotExist when there's none.
+	SellerID(ctx context.Context, cid int) (int, error)
+	WarehouseID(ctx context.Context, code string) (int, error)
+	ProductID(ctx context.Context, code string) (int, error)
+	// ReceiptAdvice returns what was received of the inbound order id, the
+	// depositor left out, and the status of the order.
+	ReceiptAdvice(ctx context.Context, id int) (ReceiptAdvice, string, error)
+	// Depositors returns the sellers of the products of the inbound order
+	// id.
+	Depositors(ctx context.Context, id int) ([]Party, error)
+	// ShipNotice returns the purchase order id as shipped to its buyer, by
+	// no carrier yet.
+	ShipNotice(ctx context.Context, id int) (ShipNotice, error)
+	Carrier(ctx context.Context, id int) (Party, error)
+	// Transact runs fn in a transaction, which the notices stored and
+	// received with the context fn gets join.
+	Transact(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type repository struct {
+	db     *database.Router
+	logger *slog.Logger
+}
+
+func NewRepository(db *database.Router, logger *slog.Logger) Repository {
+	return &repository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+const (
+	SELLER_BY_CID     = "SELECT id FROM seller WHERE cid=? AND site_id = COALESCE(?, site_id)"
+	WAREHOUSE_BY_CODE = "SELECT id FROM warehouses WHERE warehouse_code=? AND site_id = COALESCE(?, site_id)"
+	PRODUCT_BY_CODE   = "SELECT id FROM products WHERE product_code=? AND site_id = COALESCE(?, site_id)"
+
+	// The notice an order was received from, if any, is what its receipt
+	// answers.
+	GET_RECEIPT = "SELECT COALESCE(io.order_number, ''), COALESCE(DATE_FORMAT(io.order_date, '%Y-%m-%d'), ''), io.status, COALESCE(w.warehouse_code, ''), " +
+		"COALESCE((SELECT a.asn_number FROM asns a WHERE a.site_id = io.site_id AND a.inbound_order_id = io.id LIMIT 1), '') " +
+		"FROM inbound_orders io JOIN warehouses w ON w.site_id = io.site_id AND w.id = io.warehouse_id " +
+		"WHERE io.id=? AND io.site_id = COALESCE(?, io.site_id)"
+	GET_RECEIPT_ITEMS = "SELECT p.product_code, COALESCE(l.batch_number, ''), l.received_quantity " +
+		"FROM inbound_order_lines l JOIN products p ON p.site_id = l.site_id AND p.id = l.products_id " +
+		"WHERE l.inbound_order_id=? AND l.site_id = COALESCE(?, l.site_id) ORDER BY l.id"
+	GET_DEPOSITORS = "SELECT DISTINCT s.cid, s.company_name " +
+		"FROM inbound_order_lines l JOIN products p ON p.site_id = l.site_id AND p.id = l.products_id " +
+		"JOIN seller s ON s.site_id = p.site_id AND s.id = p.seller_id " +
+		"WHERE l.inbound_order_id=? AND l.site_id = COALESCE(?, l.site_id) ORDER BY s.cid"
+	// A purchase order is for a single unit of the product of its record.
+	GET_SHIP_NOTICE = "SELECT COALESCE(po.order_number, ''), COALESCE(DATE_FORMAT(po.order_date, '%Y-%m-%d'), ''), COALESCE(po.tracking_code, ''), " +
+		"b.card_number_id, b.first_name, b.last_name, p.product_code " +
+		"FROM purchase_orders po JOIN buyers b ON b.site_id = po.site_id AND b.id = po.buyers_id " +
+		"JOIN product_records pr ON pr.site_id = po.site_id AND pr.id = po.product_records_id " +
+		"JOIN products p ON p.site_id = pr.site_id AND p.id = pr.products_id " +
+		"WHERE po.id=? AND po.site_id = COALESCE(?, po.site_id)"
+	GET_CARRIER = "SELECT COALESCE(cid, ''), COALESCE(company_name, '') FROM carries WHERE id=? AND site_id = COALESCE(?, site_id)"
+)
+
+func (r *repository) SellerID(ctx context.Context, cid int) (int, error) {
+	return r.lookup(ctx, SELLER_BY_CID, cid, ErrSellerNotExist)
+}
+
+func (r *repository) WarehouseID(ctx context.Context, code string) (int, error) {
+	return r.lookup(ctx, WAREHOUSE_BY_CODE, code, ErrWarehouseNotExist)
+}
+
+func (r *repository) ProductID(ctx context.Context, code string) (int, error) {
+	return r.lookup(ctx, PRODUCT_BY_CODE, code, ErrProductNotExist)
+}
+
+// lookup runs query, which selects a single id by the code given, and
+// returns it. It's notExist when there's no row; any other failure is
+// returned as is, so an outage isn't taken for an unknown code.
+func (r *repository) lookup(ctx context.Context, query string, code interface{}, notExist error) (int, error) {
+	var id int
+	err := r.db.Reader(ctx).QueryRowContext(ctx, query, code, tenant.Filter(ctx)).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, notExist
+	}
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	return id, nil
+}
+
+func (r *repository) ReceiptAdvice(ctx context.Context, id int) (ReceiptAdvice, string, error) {
+	var advice ReceiptAdvice
+	var status string
+	err := r.db.Reader(ctx).QueryRowContext(ctx, GET_RECEIPT, id, tenant.Filter(ctx)).
+		Scan(&advice.Number, &advice.Date, &status, &advice.Warehouse.ID, &advice.ShipmentNumber)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ReceiptAdvice{}, "", ErrInboundOrderNotFound
+	}
+	if err != nil {
+		return ReceiptAdvice{}, "", apperrors.FromDB(err)
+	}
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, GET_RECEIPT_ITEMS, id, tenant.Filter(ctx))
+	if err != nil {
+		return ReceiptAdvice{}, "", apperrors.FromDB(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var item ReceiptItem
+		if err := rows.Scan(&item.ProductCode, &item.BatchNumber, &item.Quantity); err != nil {
+			return ReceiptAdvice{}, "", apperrors.FromDB(err)
+		}
+		advice.Items = append(advice.Items, item)
+	}
+	return advice, status, apperrors.FromDB(rows.Err())
+}
+
+func (r *repository) Depositors(ctx context.Context, id int) ([]Party, error) {
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, GET_DEPOSITORS, id, tenant.Filter(ctx))
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+
+	var depositors []Party
+	for rows.Next() {
+		var p Party
+		if err := rows.Scan(&p.ID, &p.Name); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		depositors = append(depositors, p)
+	}
+	return depositors, apperrors.FromDB(rows.Err())
+}
+
+func (r *repository) ShipNotice(ctx context.Context, id int) (ShipNotice, error) {
+	n := ShipNotice{Type: TypeShipNotice, Items: []Item{{Quantity: 1}}}
+	var firstName, lastName string
+	err := r.db.Reader(ctx).QueryRowContext(ctx, GET_SHIP_NOTICE, id, tenant.Filter(ctx)).
+		Scan(&n.OrderNumber, &n.OrderDate, &n.TrackingCode, &n.ShipTo.ID, &firstName, &lastName, &n.Items[0].ProductCode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ShipNotice{}, ErrPurchaseOrderNotFound
+	}
+	if err != nil {
+		return ShipNotice{}, apperrors.FromDB(err)
+	}
+	n.Number = n.OrderNumber
+	n.ShipTo.Name = strings.TrimSpace(firstName + " " + lastName)
+	return n, nil
+}
+
+func (r *repository) Carrier(ctx context.Context, id int) (Party, error) {
+	var p Party
+	err := r.db.Reader(ctx).QueryRowContext(ctx, GET_CARRIER, id, tenant.Filter(ctx)).Scan(&p.ID, &p.Name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Party{}, ErrCarryNotFound
+	}
+	return p, apperrors.FromDB(err)
+}
+
+func (r *repository) Transact(ctx context.Context, fn func(ctx context.Context) error) error {
+	return apperrors.FromDB(r.db.Transact(ctx, fn))
+}
@@ -0,0 +1,115 @@
+package edi
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx = tenant.NewContext(context.TODO(), "MLA")
+
+func newTestRepository(t *testing.T) (Repository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return NewRepository(database.NewRouter(db, nil, logger.Discard()), logger.Discard()), mock
+}
+
+func TestRepositoryLookup(t *testing.T) {
+	t.Run("should find the seller by its cid", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta(SELLER_BY_CID)).WithArgs(101, "MLA").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		id, err := repository.SellerID(ctx, 101)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should not find a missing warehouse", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta(WAREHOUSE_BY_CODE)).WithArgs("WH-9", "MLA").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := repository.WarehouseID(ctx, "WH-9")
+
+		assert.ErrorIs(t, err, ErrWarehouseNotExist)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should not take an outage for an unknown product", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta(PRODUCT_BY_CODE)).WithArgs("P-1", "MLA").WillReturnError(sql.ErrConnDone)
+
+		_, err := repository.ProductID(ctx, "P-1")
+
+		assert.ErrorIs(t, err, apperrors.ErrUnavailable)
+		assert.NotErrorIs(t, err, ErrProductNotExist)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepositoryReceiptAdvice(t *testing.T) {
+	t.Run("should read what was received of the order", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta(GET_RECEIPT)).WithArgs(7, "MLA").WillReturnRows(
+			sqlmock.NewRows([]string{"order_number", "order_date", "status", "warehouse_code", "asn_number"}).
+				AddRow("IO-7", "2024-01-12", domain.InboundOrderClosed, "WH-1", "ASN-1"))
+		mock.ExpectQuery(regexp.QuoteMeta(GET_RECEIPT_ITEMS)).WithArgs(7, "MLA").WillReturnRows(
+			sqlmock.NewRows([]string{"product_code", "batch_number", "received_quantity"}).
+				AddRow("P-1", "5", 10).AddRow("P-2", "", 3))
+
+		advice, status, err := repository.ReceiptAdvice(ctx, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.InboundOrderClosed, status)
+		assert.Equal(t, ReceiptAdvice{
+			Number: "IO-7", Date: "2024-01-12", ShipmentNumber: "ASN-1", Warehouse: Party{ID: "WH-1"},
+			Items: []ReceiptItem{{ProductCode: "P-1", BatchNumber: "5", Quantity: 10}, {ProductCode: "P-2", Quantity: 3}},
+		}, advice)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should not find a missing order", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta(GET_RECEIPT)).WithArgs(9, "MLA").WillReturnRows(sqlmock.NewRows([]string{"order_number"}))
+
+		_, _, err := repository.ReceiptAdvice(ctx, 9)
+
+		assert.ErrorIs(t, err, ErrInboundOrderNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepositoryShipNotice(t *testing.T) {
+	t.Run("should read the order as shipped to its buyer", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta(GET_SHIP_NOTICE)).WithArgs(3, "MLA").WillReturnRows(
+			sqlmock.NewRows([]string{"order_number", "order_date", "tracking_code", "card_number_id", "first_name", "last_name", "product_code"}).
+				AddRow("PO-3", "2024-01-10", "TRK-3", "CARD-1", "Ana", "Gomez", "P-1"))
+
+		n, err := repository.ShipNotice(ctx, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, ShipNotice{
+			Type: TypeShipNotice, Number: "PO-3", OrderNumber: "PO-3", OrderDate: "2024-01-10", TrackingCode: "TRK-3",
+			ShipTo: Party{ID: "CARD-1", Name: "Ana Gomez"}, Items: []Item{{ProductCode: "P-1", Quantity: 1}},
+		}, n)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should not find a missing carry", func(t *testing.T) {
+		repository, mock := newTestRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta(GET_CARRIER)).WithArgs(9, "MLA").WillReturnRows(sqlmock.NewRows([]string{"cid"}))
+
+		_, err := repository.Carrier(ctx, 9)
+
+		assert.ErrorIs(t, err, ErrCarryNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,94 @@
+package edi
+
+import (
+	"strings"
+)
+
+// segment is a segment of an interchange: its tag and the elements after
+// it, each split into its components.
+type segment struct {
+	tag      string
+	elements [][]string
+}
+
+// element returns the first component of the i-th element, counting from 1
+// as the standards do, or "" when the segment is shorter.
+func (s segment) element(i int) string {
+	return s.component(i, 1)
+}
+
+func (s segment) component(i, j int) string {
+	if i < 1 || i > len(s.elements) || j < 1 || j > len(s.elements[i-1]) {
+		return ""
+	}
+	return s.elements[i-1][j-1]
+}
+
+// delimiters separate the segments, the elements of a segment and the
+// components of an element. A character following release is taken as is;
+// X12 has no release character, so it's 0 there.
+type delimiters struct {
+	segment, element, component, release byte
+}
+
+// split cuts data into segments. Whitespace between segments, like the line
+// breaks many partners add after each terminator, is dropped.
+func split(data string, d delimiters) []segment {
+	var segments []segment
+	var elements [][]string
+	var components []string
+	var b strings.Builder
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case d.release != 0 && c == d.release:
+			if i++; i < len(data) {
+				b.WriteByte(data[i])
+			}
+		case c == d.component:
+			components = append(components, b.String())
+			b.Reset()
+		case c == d.element:
+			elements = append(elements, append(components, b.String()))
+			components = nil
+			b.Reset()
+		case c == d.segment:
+			elements = append(elements, append(components, b.String()))
+			segments = append(segments, segment{tag: strings.TrimSpace(elements[0][0]), elements: elements[1:]})
+			elements, components = nil, nil
+			b.Reset()
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return segments
+}
+
+// x12Writer writes the segments of an X12 interchange, a line each, and
+// counts them for the trailer of the transaction set.
+type x12Writer struct {
+	b     strings.Builder
+	count int
+}
+
+// x12 are the delimiters written, the usual ones.
+var x12 = delimiters{segment: '~', element: '*', component: '>'}
+
+// sanitize blanks the delimiters out of a value, since X12 can't escape
+// them.
+var sanitize = strings.NewReplacer(string(x12.segment), " ", string(x12.element), " ", string(x12.component), " ")
+
+// segment writes a segment, leaving out its trailing empty elements.
+func (w *x12Writer) segment(tag string, elements ...string) {
+	for len(elements) > 0 && elements[len(elements)-1] == "" {
+		elements = elements[:len(elements)-1]
+	}
+	w.b.WriteString(tag)
+	for _, e := range elements {
+		w.b.WriteByte(x12.element)
+		w.b.WriteString(sanitize.Replace(e))
+	}
+	w.b.WriteByte(x12.segment)
+	w.b.WriteByte('\n')
+	w.count++
+}
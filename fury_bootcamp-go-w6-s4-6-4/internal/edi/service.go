@@ -0,0 +1,192 @@
+package edi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/asn"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+// Errors
+var (
+	ErrReceiver              = apperrors.Validation("the interchange is addressed to a different receiver")
+	ErrReceiverPair          = apperrors.Validation("employee_id and section_id go together")
+	ErrDuplicateNotice       = apperrors.Validation("the interchange has the same notice twice")
+	ErrNoNotices             = apperrors.Validation("the interchange has no ship notices")
+	ErrSellerNotExist        = apperrors.ForeignKeyViolation("seller not exists")
+	ErrWarehouseNotExist     = apperrors.ForeignKeyViolation("warehouse not exists")
+	ErrProductNotExist       = apperrors.ForeignKeyViolation("product not exists")
+	ErrInboundOrderNotFound  = apperrors.NotFound("inbound order not found")
+	ErrPurchaseOrderNotFound = apperrors.NotFound("purchase order not found")
+	ErrCarryNotFound         = apperrors.NotFound("carry not found")
+	ErrNotReceived           = apperrors.Validation("the inbound order has nothing received yet")
+	ErrDepositors            = apperrors.Validation("the inbound order has products of more than one seller")
+)
+
+type Service interface {
+	// Import declares the ship notices of the interchange data as advance
+	// shipping notices, and receives them at once when employee_id and
+	// section_id aren't 0. Every notice is checked before any is stored,
+	// and they're all stored in one transaction.
+	Import(ctx context.Context, data []byte, employee_id int, section_id int) ([]domain.ASN, error)
+	// ReceiptAdvice writes the X12 944 confirming what was received of the
+	// inbound order id, to the seller of its products.
+	ReceiptAdvice(ctx context.Context, id int) ([]byte, error)
+	// ShipNotice writes the X12 856 announcing the purchase order id to the
+	// carry carry_id, which ships it to the buyer today.
+	ShipNotice(ctx context.Context, id int, carry_id int) ([]byte, error)
+}
+
+type service struct {
+	repository    Repository
+	asns          asn.Service
+	interchangeID string
+	logger        *slog.Logger
+	now           func() time.Time
+}
+
+// NewService returns a service that declares and receives notices through
+// asns, and tells itself apart in interchanges by interchangeID.
+func NewService(repository Repository, asns asn.Service, interchangeID string, logger *slog.Logger) Service {
+	return &service{repository: repository, asns: asns, interchangeID: interchangeID, logger: logger, now: time.Now}
+}
+
+func (s *service) Import(ctx context.Context, data []byte, employee_id int, section_id int) ([]domain.ASN, error) {
+	if (employee_id == 0) != (section_id == 0) {
+		return nil, ErrReceiverPair
+	}
+	ic, err := Read(data)
+	if err != nil {
+		return nil, err
+	}
+	if ic.ReceiverID != s.interchangeID {
+		return nil, ErrReceiver
+	}
+	if len(ic.ShipNotices) == 0 {
+		return nil, ErrNoNotices
+	}
+
+	// Notices are all resolved first, so a bad one fails the file before
+	// anything is written, and then stored and received in one transaction,
+	// so one failing to be stored doesn't leave half the file stored.
+	asns := make([]domain.ASN, len(ic.ShipNotices))
+	seen := map[string]bool{}
+	for i, n := range ic.ShipNotices {
+		a, err := s.resolve(ctx, n)
+		if err != nil {
+			return nil, fmt.Errorf("ship notice %s: %w", n.Number, err)
+		}
+		key := strconv.Itoa(a.SellerID) + "/" + a.ASNNumber
+		if seen[key] {
+			return nil, fmt.Errorf("ship notice %s: %w", n.Number, ErrDuplicateNotice)
+		}
+		seen[key] = true
+		asns[i] = a
+	}
+
+	imported := make([]domain.ASN, len(asns))
+	err = s.repository.Transact(ctx, func(ctx context.Context) error {
+		for i, a := range asns {
+			saved, err := s.asns.Save(ctx, a)
+			if err == nil && employee_id != 0 {
+				saved, err = s.asns.Receive(ctx, saved.ID, employee_id, section_id, "")
+			}
+			if err != nil {
+				return fmt.Errorf("ship notice %s: %w", a.ASNNumber, err)
+			}
+			imported[i] = saved
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	asns = imported
+	s.logger.InfoContext(ctx, "edi interchange imported", "sender_id", ic.SenderID, "control", ic.Control, "notices", len(asns))
+	return asns, nil
+}
+
+// resolve turns the codes of the notice into the ids of the API. The seller
+// is the one whose cid ships it, and the warehouse the one whose code it's
+// shipped to.
+func (s *service) resolve(ctx context.Context, n ShipNotice) (domain.ASN, error) {
+	a := domain.ASN{ASNNumber: n.Number, ETA: n.ETA}
+	if a.ETA == "" {
+		a.ETA = n.Date
+	}
+	cid, err := strconv.Atoi(n.ShipFrom.ID)
+	if err != nil {
+		return domain.ASN{}, ErrSellerNotExist
+	}
+	if a.SellerID, err = s.repository.SellerID(ctx, cid); err != nil {
+		return domain.ASN{}, err
+	}
+	if a.WarehouseID, err = s.repository.WarehouseID(ctx, n.ShipTo.ID); err != nil {
+		return domain.ASN{}, err
+	}
+
+	for i, item := range n.Items {
+		line := domain.ASNLine{Quantity: item.Quantity, ManufacturingDate: item.ManufacturingDate, DueDate: item.DueDate}
+		if line.ProductID, err = s.repository.ProductID(ctx, item.ProductCode); err != nil {
+			return domain.ASN{}, fmt.Errorf("item %d: %w", i+1, err)
+		}
+		if item.BatchNumber != "" {
+			if line.BatchNumber, err = strconv.Atoi(item.BatchNumber); err != nil || line.BatchNumber < 0 {
+				return domain.ASN{}, apperrors.Validation(fmt.Sprintf("item %d: batch number %q is not a number", i+1, item.BatchNumber))
+			}
+		}
+		if line.ManufacturingDate == "" || line.DueDate == "" {
+			return domain.ASN{}, apperrors.Validation(fmt.Sprintf("item %d: missing the manufacturing or the due date", i+1))
+		}
+		a.Lines = append(a.Lines, line)
+	}
+	return a, nil
+}
+
+func (s *service) ReceiptAdvice(ctx context.Context, id int) ([]byte, error) {
+	advice, status, err := s.repository.ReceiptAdvice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if status == domain.InboundOrderOpen || len(advice.Items) == 0 {
+		return nil, ErrNotReceived
+	}
+	depositors, err := s.repository.Depositors(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(depositors) != 1 {
+		return nil, ErrDepositors
+	}
+	advice.Depositor = depositors[0]
+
+	return s.write(Interchange{ReceiverID: advice.Depositor.ID, Control: strconv.Itoa(id), ReceiptAdvices: []ReceiptAdvice{advice}})
+}
+
+func (s *service) ShipNotice(ctx context.Context, id int, carry_id int) ([]byte, error) {
+	n, err := s.repository.ShipNotice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	carrier, err := s.repository.Carrier(ctx, carry_id)
+	if err != nil {
+		return nil, err
+	}
+	n.Date = s.now().UTC().Format(dateLayout)
+	n.ShipFrom = Party{ID: s.interchangeID}
+	n.Carrier = &carrier
+
+	return s.write(Interchange{ReceiverID: carrier.ID, Control: strconv.Itoa(id), ShipNotices: []ShipNotice{n}})
+}
+
+// write sends ic from the warehouse. Its control number is the id of what
+// it tells, so writing it again is sending it again.
+func (s *service) write(ic Interchange) ([]byte, error) {
+	ic.Standard, ic.SenderID = X12, s.interchangeID
+	return WriteX12(ic, s.now().UTC())
+}
@@ -0,0 +1,290 @@
+package edi
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/asn"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	inboundorder "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/inbound_order"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	asnmock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/asn"
+	inboundordermock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/inbound_order"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRepository finds sellers, warehouses and products by the codes keying
+// sellers, warehouses and products. receipts, statuses and depositors are the
+// inbound orders by id, and notices and carriers the purchase orders and
+// carries by id. transactions counts the transactions run, and rolledBack
+// the ones that failed.
+type mockRepository struct {
+	sellers    map[int]int
+	warehouses map[string]int
+	products   map[string]int
+	receipts   map[int]ReceiptAdvice
+	statuses   map[int]string
+	depositors map[int][]Party
+	notices    map[int]ShipNotice
+	carriers   map[int]Party
+
+	transactions int
+	rolledBack   int
+}
+
+func (m *mockRepository) SellerID(ctx context.Context, cid int) (int, error) {
+	id, ok := m.sellers[cid]
+	if !ok {
+		return 0, ErrSellerNotExist
+	}
+	return id, nil
+}
+
+func (m *mockRepository) WarehouseID(ctx context.Context, code string) (int, error) {
+	id, ok := m.warehouses[code]
+	if !ok {
+		return 0, ErrWarehouseNotExist
+	}
+	return id, nil
+}
+
+func (m *mockRepository) ProductID(ctx context.Context, code string) (int, error) {
+	id, ok := m.products[code]
+	if !ok {
+		return 0, ErrProductNotExist
+	}
+	return id, nil
+}
+
+func (m *mockRepository) ReceiptAdvice(ctx context.Context, id int) (ReceiptAdvice, string, error) {
+	r, ok := m.receipts[id]
+	if !ok {
+		return ReceiptAdvice{}, "", ErrInboundOrderNotFound
+	}
+	return r, m.statuses[id], nil
+}
+
+func (m *mockRepository) Depositors(ctx context.Context, id int) ([]Party, error) {
+	return m.depositors[id], nil
+}
+
+func (m *mockRepository) ShipNotice(ctx context.Context, id int) (ShipNotice, error) {
+	n, ok := m.notices[id]
+	if !ok {
+		return ShipNotice{}, ErrPurchaseOrderNotFound
+	}
+	return n, nil
+}
+
+func (m *mockRepository) Carrier(ctx context.Context, id int) (Party, error) {
+	p, ok := m.carriers[id]
+	if !ok {
+		return Party{}, ErrCarryNotFound
+	}
+	return p, nil
+}
+
+func (m *mockRepository) Transact(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.transactions++
+	err := fn(ctx)
+	if err != nil {
+		m.rolledBack++
+	}
+	return err
+}
+
+func newMockRepository() *mockRepository {
+	return &mockRepository{
+		sellers:    map[int]int{101: 1, 102: 2},
+		warehouses: map[string]int{"WH-1": 1},
+		products:   map[string]int{"P-1": 7, "P-2": 8},
+		receipts: map[int]ReceiptAdvice{7: {
+			Number: "IO-7", Date: "2024-01-12", ShipmentNumber: "ASN-1", Warehouse: Party{ID: "WH-1"},
+			Items: []ReceiptItem{{ProductCode: "P-1", BatchNumber: "5", Quantity: 10}, {ProductCode: "P-2", Quantity: 3}},
+		}},
+		statuses:   map[int]string{7: domain.InboundOrderPartiallyReceived},
+		depositors: map[int][]Party{7: {{ID: "101", Name: "Meli Foods"}}},
+		notices: map[int]ShipNotice{3: {
+			Type: TypeShipNotice, Number: "PO-3", OrderNumber: "PO-3", OrderDate: "2024-01-10", TrackingCode: "TRK-3",
+			ShipTo: Party{ID: "CARD-1", Name: "Ana Gomez"}, Items: []Item{{ProductCode: "P-1", Quantity: 1}},
+		}},
+		carriers: map[int]Party{1: {ID: "CA01", Name: "Andreani"}},
+	}
+}
+
+func newMockASNRepository() *asnmock.MockRepository {
+	return &asnmock.MockRepository{
+		Sellers:    []domain.Seller{{ID: 1}, {ID: 2}},
+		Warehouses: []domain.Warehouse{{ID: 1}},
+		Products:   []domain.Product{{ID: 7, SellerID: 1}, {ID: 8, SellerID: 1}},
+	}
+}
+
+func newMockInboundOrders() *inboundordermock.MockRepositoryIBO {
+	return &inboundordermock.MockRepositoryIBO{
+		DataMockEmp:  []domain.Employee{{ID: 4, WarehouseID: 1}},
+		DataMockWh:   []domain.Warehouse{{ID: 1}},
+		DataMockSec:  []domain.Section{{ID: 1, WarehouseID: 1}},
+		DataMockProd: []domain.Product{{ID: 7}, {ID: 8}},
+	}
+}
+
+func newTestService(repo Repository, asns asn.Repository, orders inboundorder.Repository) *service {
+	inboundOrders := inboundorder.NewService(orders, events.Discard, logger.Discard())
+	s := NewService(repo, asn.NewService(asns, inboundOrders, events.Discard, logger.Discard()), "MELISPRINT", logger.Discard()).(*service)
+	s.now = func() time.Time { return at }
+	return s
+}
+
+func TestServiceImport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should declare the notices", func(t *testing.T) {
+		asns := newMockASNRepository()
+
+		imported, err := newTestService(newMockRepository(), asns, newMockInboundOrders()).Import(ctx, readSample(t, "856.edi"), 0, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.ASN{{
+			ID: 1, ASNNumber: "ASN-1", SellerID: 1, WarehouseID: 1, ETA: "2024-01-20", Status: domain.ASNPending,
+			Lines: []domain.ASNLine{
+				{ID: 1, ProductID: 7, Quantity: 10, BatchNumber: 5, ManufacturingDate: "2024-01-01", DueDate: "2024-06-01"},
+				{ID: 2, ProductID: 8, Quantity: 4, ManufacturingDate: "2024-01-02", DueDate: "2024-03-01"},
+			},
+		}}, imported)
+		assert.Equal(t, imported, asns.Data)
+	})
+	t.Run("should receive the notices when told who receives them", func(t *testing.T) {
+		asns, orders := newMockASNRepository(), newMockInboundOrders()
+
+		imported, err := newTestService(newMockRepository(), asns, orders).Import(ctx, readSample(t, "desadv.edi"), 4, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ASNReceived, imported[0].Status)
+		assert.Len(t, orders.DataMock, 1)
+		assert.Equal(t, "ASN-3", orders.DataMock[0].Order_number)
+		assert.Len(t, orders.DataMockPB, 2)
+	})
+
+	tests := []struct {
+		name       string
+		data       string
+		employeeID int
+		err        error
+		message    string
+	}{
+		{name: "a receiver without a section", data: string(readSample(t, "856.edi")), employeeID: 4, err: ErrReceiverPair},
+		{name: "what isn't EDI", data: `{"asn_number":"ASN-1"}`, err: ErrInvalid},
+		{name: "an interchange for someone else", data: strings.Replace(string(readSample(t, "856.edi")), "*MELISPRINT     *", "*OTHER          *", 1), err: ErrReceiver},
+		{name: "an interchange without notices", data: strings.Replace(string(readSample(t, "944.edi")), "*101            *", "*MELISPRINT     *", 1), err: ErrNoNotices},
+		{name: "an unknown seller", data: strings.Replace(string(readSample(t, "856.edi")), "*92*101~", "*92*109~", 1), err: ErrSellerNotExist, message: "ship notice ASN-1: seller not exists"},
+		{name: "an unknown warehouse", data: strings.Replace(string(readSample(t, "943.edi")), "*92*WH-1~", "*92*WH-9~", 1), err: ErrWarehouseNotExist, message: "ship notice ASN-2: warehouse not exists"},
+		{name: "an unknown product", data: strings.Replace(string(readSample(t, "856.edi")), "VP*P-2", "VP*P-9", 1), err: ErrProductNotExist, message: "ship notice ASN-1: item 2: product not exists"},
+		{name: "a batch that isn't a number", data: strings.Replace(string(readSample(t, "856.edi")), "REF*LT*5~", "REF*LT*L5~", 1), err: apperrors.ErrValidation, message: `ship notice ASN-1: item 1: batch number "L5" is not a number`},
+		{name: "an item without dates", data: strings.Replace(string(readSample(t, "856.edi")), "DTM*036*20240301~", "DTM*017*20240120~", 1), err: apperrors.ErrValidation, message: "ship notice ASN-1: item 2: missing the manufacturing or the due date"},
+		{name: "a product of another seller", data: strings.Replace(string(readSample(t, "856.edi")), "*92*101~", "*92*102~", 1), err: asn.ErrProductSeller, message: "ship notice ASN-1: the product belongs to a different seller"},
+	}
+	for _, tt := range tests {
+		t.Run("should reject "+tt.name, func(t *testing.T) {
+			asns := newMockASNRepository()
+
+			_, err := newTestService(newMockRepository(), asns, newMockInboundOrders()).Import(ctx, []byte(tt.data), tt.employeeID, 0)
+
+			assert.ErrorIs(t, err, tt.err)
+			if tt.message != "" {
+				assert.EqualError(t, err, tt.message)
+			}
+			assert.Empty(t, asns.Data)
+		})
+	}
+	t.Run("should reject the same notice twice", func(t *testing.T) {
+		sample := string(readSample(t, "856.edi"))
+		set := sample[strings.Index(sample, "ST*"):strings.Index(sample, "GE*")]
+		twice := strings.Replace(sample, set, set+strings.Replace(set, "0001~", "0002~", -1), 1)
+		asns := newMockASNRepository()
+
+		_, err := newTestService(newMockRepository(), asns, newMockInboundOrders()).Import(ctx, []byte(twice), 0, 0)
+
+		assert.ErrorIs(t, err, ErrDuplicateNotice)
+		assert.Empty(t, asns.Data)
+	})
+	t.Run("should store the notices in one transaction", func(t *testing.T) {
+		sample := string(readSample(t, "856.edi"))
+		set := sample[strings.Index(sample, "ST*"):strings.Index(sample, "GE*")]
+		two := strings.Replace(sample, set, set+strings.Replace(strings.Replace(set, "0001~", "0002~", -1), "ASN-1", "ASN-9", 1), 1)
+		repo, asns := newMockRepository(), newMockASNRepository()
+		asns.Data = []domain.ASN{{ID: 1, ASNNumber: "ASN-9", SellerID: 1}}
+
+		_, err := newTestService(repo, asns, newMockInboundOrders()).Import(ctx, []byte(two), 0, 0)
+
+		assert.ErrorIs(t, err, asn.ErrExists)
+		assert.EqualError(t, err, "ship notice ASN-9: asn_number already exists for the seller")
+		assert.Equal(t, 1, repo.transactions)
+		assert.Equal(t, 1, repo.rolledBack)
+	})
+}
+
+func TestServiceReceiptAdvice(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should confirm what was received to the seller", func(t *testing.T) {
+		written, err := newTestService(newMockRepository(), newMockASNRepository(), newMockInboundOrders()).ReceiptAdvice(ctx, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, string(readSample(t, "944.edi")), string(written))
+	})
+
+	tests := []struct {
+		name   string
+		change func(repo *mockRepository)
+		err    error
+	}{
+		{name: "should not find a missing order", change: func(repo *mockRepository) { delete(repo.receipts, 7) }, err: apperrors.ErrNotFound},
+		{name: "should need something received", change: func(repo *mockRepository) { repo.statuses[7] = domain.InboundOrderOpen }, err: ErrNotReceived},
+		{name: "should need a single seller", change: func(repo *mockRepository) {
+			repo.depositors[7] = append(repo.depositors[7], Party{ID: "102"})
+		}, err: ErrDepositors},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepository()
+			tt.change(repo)
+
+			_, err := newTestService(repo, newMockASNRepository(), newMockInboundOrders()).ReceiptAdvice(ctx, 7)
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestServiceShipNotice(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should announce the order to the carry", func(t *testing.T) {
+		written, err := newTestService(newMockRepository(), newMockASNRepository(), newMockInboundOrders()).ShipNotice(ctx, 3, 1)
+		require.NoError(t, err)
+		ic, err := Read(written)
+
+		assert.NoError(t, err)
+		assert.Equal(t, Interchange{Standard: X12, SenderID: "MELISPRINT", ReceiverID: "CA01", Control: "000000003", ShipNotices: []ShipNotice{{
+			Type: TypeShipNotice, Number: "PO-3", Date: "2024-01-12", ShipFrom: Party{ID: "MELISPRINT"}, ShipTo: Party{ID: "CARD-1", Name: "Ana Gomez"},
+			Carrier: &Party{ID: "CA01", Name: "Andreani"}, TrackingCode: "TRK-3", OrderNumber: "PO-3", OrderDate: "2024-01-10",
+			Items: []Item{{ProductCode: "P-1", Quantity: 1}},
+		}}}, ic)
+	})
+	t.Run("should not find a missing order", func(t *testing.T) {
+		_, err := newTestService(newMockRepository(), newMockASNRepository(), newMockInboundOrders()).ShipNotice(ctx, 9, 1)
+
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+	t.Run("should not find a missing carry", func(t *testing.T) {
+		_, err := newTestService(newMockRepository(), newMockASNRepository(), newMockInboundOrders()).ShipNotice(ctx, 3, 9)
+
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+}
@@ -0,0 +1,486 @@
+package edi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// isaLength is the length of the ISA segment, terminator included. Its
+// elements are fixed width, so the delimiters are found at fixed offsets.
+const isaLength = 106
+
+// Functional groups of the transaction sets written.
+var x12Groups = map[string]string{
+	TypeShipNotice:     "SH",
+	TypeShipmentAdvice: "AR",
+	TypeReceiptAdvice:  "RE",
+}
+
+func readX12(data string) (Interchange, error) {
+	if len(data) < isaLength {
+		return Interchange{}, errors.New("ISA segment too short")
+	}
+	d := delimiters{element: data[3], component: data[isaLength-2], segment: data[isaLength-1]}
+	segments := split(data, d)
+	if len(segments) == 0 || segments[0].tag != "ISA" {
+		return Interchange{}, errors.New("missing the ISA segment")
+	}
+	isa := segments[0]
+	ic := Interchange{
+		Standard:   X12,
+		SenderID:   strings.TrimSpace(isa.element(6)),
+		ReceiverID: strings.TrimSpace(isa.element(8)),
+		Control:    isa.element(13),
+	}
+
+	var set []segment
+	for _, s := range segments[1:] {
+		switch s.tag {
+		case "ST":
+			if set != nil {
+				return Interchange{}, fmt.Errorf("transaction set %s has no SE", set[0].element(2))
+			}
+			set = []segment{s}
+		case "SE":
+			if set == nil {
+				return Interchange{}, errors.New("SE without ST")
+			}
+			if err := ic.addX12(append(set, s)); err != nil {
+				return Interchange{}, err
+			}
+			set = nil
+		default:
+			if set != nil {
+				set = append(set, s)
+			}
+		}
+	}
+	if set != nil {
+		return Interchange{}, fmt.Errorf("transaction set %s has no SE", set[0].element(2))
+	}
+	return ic, nil
+}
+
+// addX12 reads the transaction set, from its ST to its SE, into ic.
+func (ic *Interchange) addX12(set []segment) error {
+	st, se := set[0], set[len(set)-1]
+	if se.element(1) != strconv.Itoa(len(set)) {
+		return fmt.Errorf("transaction set %s: SE counts %s segments, got %d", st.element(2), se.element(1), len(set))
+	}
+	var err error
+	body := set[1 : len(set)-1]
+	switch st.element(1) {
+	case TypeShipNotice:
+		var n ShipNotice
+		if n, err = read856(body); err == nil {
+			ic.ShipNotices = append(ic.ShipNotices, n)
+		}
+	case TypeShipmentAdvice:
+		var n ShipNotice
+		if n, err = read943(body); err == nil {
+			ic.ShipNotices = append(ic.ShipNotices, n)
+		}
+	case TypeReceiptAdvice:
+		var r ReceiptAdvice
+		if r, err = read944(body); err == nil {
+			ic.ReceiptAdvices = append(ic.ReceiptAdvices, r)
+		}
+	default:
+		err = fmt.Errorf("unsupported transaction set %s", st.element(1))
+	}
+	if err != nil {
+		return fmt.Errorf("transaction set %s: %w", st.element(2), err)
+	}
+	return nil
+}
+
+// read856 reads a ship notice. Its hierarchy is flattened: the shipment and
+// order levels fill the notice and each item level adds an item.
+func read856(segments []segment) (ShipNotice, error) {
+	n := ShipNotice{Type: TypeShipNotice}
+	var item *Item
+	for _, s := range segments {
+		var err error
+		switch s.tag {
+		case "BSN":
+			n.Number = s.element(2)
+			n.Date, err = parseDate(s.element(3))
+		case "HL":
+			item = nil
+			if s.element(3) == "I" {
+				item = n.addItem()
+			}
+		case "TD5":
+			n.Carrier = &Party{ID: s.element(3), Name: s.element(5)}
+		case "REF":
+			n.readReference(item, s.element(1), s.element(2))
+		case "N1":
+			n.readParty(s.element(1), Party{ID: s.element(4), Name: s.element(2)})
+		case "PRF":
+			n.OrderNumber = s.element(1)
+			if s.element(4) != "" {
+				n.OrderDate, err = parseDate(s.element(4))
+			}
+		case "LIN":
+			if item == nil || item.ProductCode != "" {
+				item = n.addItem()
+			}
+			item.ProductCode = s.element(3)
+		case "SN1":
+			if item != nil {
+				item.Quantity, err = parseQuantity(s.element(2))
+			}
+		case "DTM":
+			err = n.readDate(item, s.element(1), s.element(2))
+		}
+		if err != nil {
+			return ShipNotice{}, fmt.Errorf("%s: %w", s.tag, err)
+		}
+	}
+	return n, n.check()
+}
+
+// read943 reads a warehouse stock transfer shipment advice. The dates of
+// its items come in DTM segments, as in an 856.
+func read943(segments []segment) (ShipNotice, error) {
+	n := ShipNotice{Type: TypeShipmentAdvice}
+	var item *Item
+	for _, s := range segments {
+		var err error
+		switch s.tag {
+		case "W06":
+			n.OrderNumber, n.Number = s.element(2), s.element(4)
+			if n.Number == "" {
+				n.Number = n.OrderNumber
+			}
+			n.Date, err = parseDate(s.element(3))
+		case "N1":
+			n.readParty(s.element(1), Party{ID: s.element(4), Name: s.element(2)})
+		case "G62":
+			if s.element(1) == "17" {
+				n.ETA, err = parseDate(s.element(2))
+			}
+		case "W27":
+			n.Carrier = &Party{ID: s.element(2), Name: s.element(3)}
+		case "N9":
+			n.readReference(item, s.element(1), s.element(2))
+		case "W04":
+			item = n.addItem()
+			item.Quantity, err = parseQuantity(s.element(1))
+			item.ProductCode = s.element(5)
+			if item.ProductCode == "" {
+				item.ProductCode = s.element(3)
+			}
+			if s.element(6) == "LT" {
+				item.BatchNumber = s.element(7)
+			}
+		case "DTM":
+			err = n.readDate(item, s.element(1), s.element(2))
+		}
+		if err != nil {
+			return ShipNotice{}, fmt.Errorf("%s: %w", s.tag, err)
+		}
+	}
+	return n, n.check()
+}
+
+func read944(segments []segment) (ReceiptAdvice, error) {
+	var r ReceiptAdvice
+	for _, s := range segments {
+		var err error
+		switch s.tag {
+		case "W17":
+			r.Date, err = parseDate(s.element(2))
+			r.Number, r.ShipmentNumber = s.element(3), s.element(5)
+		case "N1":
+			switch s.element(1) {
+			case "WH":
+				r.Warehouse = Party{ID: s.element(4), Name: s.element(2)}
+			case "DE":
+				r.Depositor = Party{ID: s.element(4), Name: s.element(2)}
+			}
+		case "W07":
+			item := ReceiptItem{ProductCode: s.element(5)}
+			if s.element(6) == "LT" {
+				item.BatchNumber = s.element(7)
+			}
+			item.Quantity, err = parseQuantity(s.element(1))
+			r.Items = append(r.Items, item)
+		}
+		if err != nil {
+			return ReceiptAdvice{}, fmt.Errorf("%s: %w", s.tag, err)
+		}
+	}
+	if r.Number == "" {
+		return ReceiptAdvice{}, errors.New("missing the receipt number")
+	}
+	if len(r.Items) == 0 {
+		return ReceiptAdvice{}, errors.New("no items")
+	}
+	return r, nil
+}
+
+func (n *ShipNotice) addItem() *Item {
+	n.Items = append(n.Items, Item{})
+	return &n.Items[len(n.Items)-1]
+}
+
+// readParty takes the ship from and ship to parties; sellers may name
+// themselves either way.
+func (n *ShipNotice) readParty(qualifier string, p Party) {
+	switch qualifier {
+	case "SF", "SE", "SU":
+		n.ShipFrom = p
+	case "ST", "DP":
+		n.ShipTo = p
+	case "CA":
+		n.Carrier = &p
+	}
+}
+
+// readReference takes the tracking code of the shipment and the lot of an
+// item.
+func (n *ShipNotice) readReference(item *Item, qualifier, value string) {
+	switch {
+	case qualifier == "CN":
+		n.TrackingCode = value
+	case qualifier == "LT" && item != nil:
+		item.BatchNumber = value
+	}
+}
+
+// readDate takes the ETA of the shipment, and the manufacturing and
+// expiration dates of an item, by their X12 qualifiers.
+func (n *ShipNotice) readDate(item *Item, qualifier, value string) error {
+	var err error
+	switch {
+	case qualifier == "017":
+		n.ETA, err = parseDate(value)
+	case qualifier == "094" && item != nil:
+		item.ManufacturingDate, err = parseDate(value)
+	case qualifier == "036" && item != nil:
+		item.DueDate, err = parseDate(value)
+	}
+	return err
+}
+
+// check checks what every notice needs, whatever its type.
+func (n ShipNotice) check() error {
+	if n.Number == "" {
+		return errors.New("missing the shipment number")
+	}
+	if len(n.Items) == 0 {
+		return errors.New("no items")
+	}
+	for i, item := range n.Items {
+		if item.ProductCode == "" || item.Quantity <= 0 {
+			return fmt.Errorf("item %d: missing the product or the quantity", i+1)
+		}
+	}
+	return nil
+}
+
+// WriteX12 writes the interchange as X12 at the time given, its ship
+// notices as 856s, or as 943s when that's their type, and its receipt
+// advices as 944s. Control must be a number of up to 9 digits.
+func WriteX12(ic Interchange, at time.Time) ([]byte, error) {
+	b, err := writeX12(ic, at)
+	if err != nil {
+		return nil, invalid(err)
+	}
+	return b, nil
+}
+
+type x12Set struct {
+	code  string
+	write func(w *x12Writer) error
+}
+
+func writeX12(ic Interchange, at time.Time) ([]byte, error) {
+	control, err := strconv.Atoi(ic.Control)
+	if err != nil || control < 0 || control > 999999999 {
+		return nil, fmt.Errorf("control %q is not a number of up to 9 digits", ic.Control)
+	}
+	if ic.SenderID == "" || len(ic.SenderID) > 15 || ic.ReceiverID == "" || len(ic.ReceiverID) > 15 {
+		return nil, errors.New("sender and receiver ids must have 1 to 15 characters")
+	}
+
+	var order []string
+	groups := map[string][]x12Set{}
+	add := func(s x12Set) {
+		group := x12Groups[s.code]
+		if _, ok := groups[group]; !ok {
+			order = append(order, group)
+		}
+		groups[group] = append(groups[group], s)
+	}
+	for _, n := range ic.ShipNotices {
+		n := n
+		if n.Type == TypeShipmentAdvice {
+			add(x12Set{code: TypeShipmentAdvice, write: func(w *x12Writer) error { return write943(w, n) }})
+		} else {
+			add(x12Set{code: TypeShipNotice, write: func(w *x12Writer) error { return write856(w, n, at) }})
+		}
+	}
+	for _, r := range ic.ReceiptAdvices {
+		r := r
+		add(x12Set{code: TypeReceiptAdvice, write: func(w *x12Writer) error { return write944(w, r) }})
+	}
+
+	w := &x12Writer{}
+	// The ISA is written as is: its elements are padded to their width and
+	// the last one is the component separator.
+	fmt.Fprintf(&w.b, "ISA*00*%10s*00*%10s*ZZ*%-15s*ZZ*%-15s*%s*%s*U*00401*%09d*0*P*%c%c\n",
+		"", "", sanitize.Replace(ic.SenderID), sanitize.Replace(ic.ReceiverID), at.Format("060102"), at.Format("1504"), control, x12.component, x12.segment)
+	for i, group := range order {
+		groupControl := strconv.Itoa(i + 1)
+		w.segment("GS", group, ic.SenderID, ic.ReceiverID, at.Format("20060102"), at.Format("1504"), groupControl, "X", "004010")
+		for j, s := range groups[group] {
+			setControl := fmt.Sprintf("%04d", j+1)
+			start := w.count
+			w.segment("ST", s.code, setControl)
+			if err := s.write(w); err != nil {
+				return nil, fmt.Errorf("transaction set %s: %w", setControl, err)
+			}
+			w.segment("SE", strconv.Itoa(w.count-start+1), setControl)
+		}
+		w.segment("GE", strconv.Itoa(len(groups[group])), groupControl)
+	}
+	w.segment("IEA", strconv.Itoa(len(order)), fmt.Sprintf("%09d", control))
+	return []byte(w.b.String()), nil
+}
+
+func write856(w *x12Writer, n ShipNotice, at time.Time) error {
+	if err := n.check(); err != nil {
+		return err
+	}
+	date, err := formatDate(n.Date)
+	if err != nil {
+		return err
+	}
+	w.segment("BSN", "00", n.Number, date, at.Format("1504"))
+	if err := writeDate(w, "017", n.ETA); err != nil {
+		return err
+	}
+	w.segment("HL", "1", "", "S")
+	if n.Carrier != nil {
+		w.segment("TD5", "", "2", n.Carrier.ID, "", n.Carrier.Name)
+	}
+	if n.TrackingCode != "" {
+		w.segment("REF", "CN", n.TrackingCode)
+	}
+	w.segment("N1", "SF", n.ShipFrom.Name, "92", n.ShipFrom.ID)
+	w.segment("N1", "ST", n.ShipTo.Name, "92", n.ShipTo.ID)
+
+	hl, parent := 1, "1"
+	if n.OrderNumber != "" {
+		orderDate, err := formatDate(n.OrderDate)
+		if err != nil {
+			return err
+		}
+		hl++
+		parent = strconv.Itoa(hl)
+		w.segment("HL", parent, "1", "O")
+		w.segment("PRF", n.OrderNumber, "", "", orderDate)
+	}
+	for _, item := range n.Items {
+		hl++
+		w.segment("HL", strconv.Itoa(hl), parent, "I")
+		w.segment("LIN", "", "VP", item.ProductCode)
+		w.segment("SN1", "", strconv.Itoa(item.Quantity), "EA")
+		if err := writeItem(w, item, "REF"); err != nil {
+			return err
+		}
+	}
+	w.segment("CTT", strconv.Itoa(len(n.Items)))
+	return nil
+}
+
+func write943(w *x12Writer, n ShipNotice) error {
+	if err := n.check(); err != nil {
+		return err
+	}
+	date, err := formatDate(n.Date)
+	if err != nil {
+		return err
+	}
+	w.segment("W06", "N", n.OrderNumber, date, n.Number)
+	w.segment("N1", "SF", n.ShipFrom.Name, "92", n.ShipFrom.ID)
+	w.segment("N1", "ST", n.ShipTo.Name, "92", n.ShipTo.ID)
+	if n.ETA != "" {
+		eta, err := formatDate(n.ETA)
+		if err != nil {
+			return err
+		}
+		w.segment("G62", "17", eta)
+	}
+	if n.Carrier != nil {
+		w.segment("W27", "M", n.Carrier.ID, n.Carrier.Name)
+	}
+	if n.TrackingCode != "" {
+		w.segment("N9", "CN", n.TrackingCode)
+	}
+	total := 0
+	for _, item := range n.Items {
+		lot := ""
+		if item.BatchNumber != "" {
+			lot = "LT"
+		}
+		w.segment("W04", strconv.Itoa(item.Quantity), "EA", "", "VN", item.ProductCode, lot, item.BatchNumber)
+		if err := writeItem(w, item, ""); err != nil {
+			return err
+		}
+		total += item.Quantity
+	}
+	w.segment("W03", strconv.Itoa(total))
+	return nil
+}
+
+func write944(w *x12Writer, r ReceiptAdvice) error {
+	if r.Number == "" || len(r.Items) == 0 {
+		return errors.New("a receipt advice needs a number and items")
+	}
+	date, err := formatDate(r.Date)
+	if err != nil {
+		return err
+	}
+	w.segment("W17", "F", date, r.Number, "", r.ShipmentNumber)
+	w.segment("N1", "WH", r.Warehouse.Name, "92", r.Warehouse.ID)
+	w.segment("N1", "DE", r.Depositor.Name, "92", r.Depositor.ID)
+	total := 0
+	for _, item := range r.Items {
+		lot := ""
+		if item.BatchNumber != "" {
+			lot = "LT"
+		}
+		w.segment("W07", strconv.Itoa(item.Quantity), "EA", "", "VN", item.ProductCode, lot, item.BatchNumber)
+		total += item.Quantity
+	}
+	w.segment("W14", strconv.Itoa(total))
+	return nil
+}
+
+// writeItem writes the lot of an item, in a segment of the tag given unless
+// it's empty, and its dates.
+func writeItem(w *x12Writer, item Item, reference string) error {
+	if reference != "" && item.BatchNumber != "" {
+		w.segment(reference, "LT", item.BatchNumber)
+	}
+	if err := writeDate(w, "094", item.ManufacturingDate); err != nil {
+		return err
+	}
+	return writeDate(w, "036", item.DueDate)
+}
+
+// writeDate writes a DTM, unless the date is empty.
+func writeDate(w *x12Writer, qualifier, value string) error {
+	date, err := formatDate(value)
+	if err != nil || date == "" {
+		return err
+	}
+	w.segment("DTM", qualifier, date)
+	return nil
+}
@@ -0,0 +1,55 @@
+package employee
+
+import (
+	"context"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/cache"
+)
+
+// reportTags are the tables the inbound orders report counts.
+var reportTags = []string{"employees", "inbound_orders"}
+
+type cachedService struct {
+	Service
+	cache *cache.Cache
+}
+
+// WithCache returns s with the inbound orders report cached in c, and its
+// writes to the employees invalidating it. A nil c leaves s as is.
+func WithCache(s Service, c *cache.Cache) Service {
+	if c == nil {
+		return s
+	}
+	return &cachedService{Service: s, cache: c}
+}
+
+func (s *cachedService) Report_BO(ctx context.Context, id string) ([]domain.ReportInBO, error) {
+	return cache.Load(ctx, s.cache, "employees.report_inbound_orders:"+id, reportTags, func(ctx context.Context) ([]domain.ReportInBO, error) {
+		return s.Service.Report_BO(ctx, id)
+	})
+}
+
+func (s *cachedService) Save(ctx context.Context, cardNumberId string, name string, lastname string, wharehouseId int) (domain.Employee, error) {
+	e, err := s.Service.Save(ctx, cardNumberId, name, lastname, wharehouseId)
+	if err == nil {
+		s.cache.Invalidate(ctx, "employees")
+	}
+	return e, err
+}
+
+func (s *cachedService) Update(ctx context.Context, id int, name string, lastname string, wharehouseId *int) (domain.Employee, error) {
+	e, err := s.Service.Update(ctx, id, name, lastname, wharehouseId)
+	if err == nil {
+		s.cache.Invalidate(ctx, "employees")
+	}
+	return e, err
+}
+
+func (s *cachedService) Delete(ctx context.Context, id int) error {
+	err := s.Service.Delete(ctx, id)
+	if err == nil {
+		s.cache.Invalidate(ctx, "employees")
+	}
+	return err
+}
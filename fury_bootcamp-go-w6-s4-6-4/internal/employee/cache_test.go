@@ -0,0 +1,55 @@
+package employee
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	inboundorder "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/inbound_order"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/cache"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/employee"
+	inboundordermock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/inbound_order"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCache(t *testing.T) {
+	ctx := context.Background()
+	report := []domain.ReportInBO{{ID: 1, CardNumberID: "402323", FirstName: "Jhon", LastName: "Doe", WarehouseID: 1, Inbound_orders_count: 1}}
+
+	t.Run("should serve the report cached until an inbound order is created", func(t *testing.T) {
+		c := cache.New(cache.NewMemory(10), time.Minute, logger.Discard())
+		mock := &employee.MockServiceEmployee{DatamockIBO: report}
+		service := WithCache(mock, c)
+		inboundOrders := inboundorder.WithCache(&inboundordermock.MockServiceIBO{}, c)
+
+		first, _ := service.Report_BO(ctx, "")
+		mock.DatamockIBO = []domain.ReportInBO{{ID: 1, CardNumberID: "402323", FirstName: "Jhon", LastName: "Doe", WarehouseID: 1, Inbound_orders_count: 2}}
+		cached, _ := service.Report_BO(ctx, "")
+		inboundOrders.Save(ctx, "2024-01-02", "IO-2", 1, 1, 1, nil)
+		refreshed, _ := service.Report_BO(ctx, "")
+
+		assert.Equal(t, report, first)
+		assert.Equal(t, report, cached)
+		assert.Equal(t, 2, refreshed[0].Inbound_orders_count)
+	})
+	t.Run("should not cache the employees not found", func(t *testing.T) {
+		c := cache.New(cache.NewMemory(10), time.Minute, logger.Discard())
+		mock := &employee.MockServiceEmployee{}
+		service := WithCache(mock, c)
+
+		_, err := service.Report_BO(ctx, "1")
+		assert.ErrorIs(t, err, employee.ErrNotFound)
+
+		mock.DatamockIBO = report
+		got, err := service.Report_BO(ctx, "1")
+		assert.NoError(t, err)
+		assert.Equal(t, report, got)
+	})
+	t.Run("should leave the service as is without a cache", func(t *testing.T) {
+		mock := &employee.MockServiceEmployee{}
+
+		assert.Same(t, mock, WithCache(mock, nil))
+	})
+}
@@ -3,8 +3,13 @@ package employee
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"log/slog"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 )
 
 // Repository encapsulates the storage of a employee.
@@ -21,107 +26,132 @@ type Repository interface {
 }
 
 type repository struct {
-	db *sql.DB
+	db     *database.Router
+	stmts  *database.Statements
+	logger *slog.Logger
 }
 
-func NewRepository(db *sql.DB) Repository {
+func NewRepository(db *database.Router, stmts *database.Statements, logger *slog.Logger) Repository {
+	stmts.Register(SAVE_EMPLOYEE, UPDATE_EMPLOYEE, DELETE_EMPLOYEE)
 	return &repository{
-		db: db,
+		db:     db,
+		stmts:  stmts,
+		logger: logger,
 	}
 }
 
+const (
+	SAVE_EMPLOYEE   = "INSERT INTO employees(site_id,card_number_id,first_name,last_name,warehouse_id) VALUES (?,?,?,?,?)"
+	UPDATE_EMPLOYEE = "UPDATE employees SET first_name=?, last_name=?, warehouse_id=?  WHERE id=? AND site_id = COALESCE(?, site_id)"
+	DELETE_EMPLOYEE = "DELETE FROM employees WHERE id=? AND site_id = COALESCE(?, site_id)"
+)
+
 func (r *repository) GetAll(ctx context.Context) ([]domain.Employee, error) {
-	query := "SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees"
-	rows, err := r.db.Query(query)
+	query := "SELECT id, site_id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE site_id = COALESCE(?, site_id)"
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, tenant.Filter(ctx))
 	if err != nil {
-		return nil, err
+		return nil, apperrors.FromDB(err)
 	}
+	defer rows.Close()
 
 	var employees []domain.Employee
 
 	for rows.Next() {
 		e := domain.Employee{}
-		_ = rows.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID)
+		if err := rows.Scan(&e.ID, &e.SiteID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
 		employees = append(employees, e)
 	}
 
-	return employees, nil
+	return employees, apperrors.FromDB(rows.Err())
 }
 
 func (r *repository) Get(ctx context.Context, id int) (domain.Employee, error) {
-	query := "SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE id=?;"
-	row := r.db.QueryRow(query, id)
+	query := "SELECT id, site_id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE id=? AND site_id = COALESCE(?, site_id);"
+	row := r.db.Writer().QueryRowContext(ctx, query, id, tenant.Filter(ctx))
 	e := domain.Employee{}
-	err := row.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID)
+	err := row.Scan(&e.ID, &e.SiteID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Employee{}, ErrNotFound
+	}
 	if err != nil {
-		return domain.Employee{}, err
+		return domain.Employee{}, apperrors.FromDB(err)
 	}
 
 	return e, nil
 }
 
 func (r *repository) Exists(ctx context.Context, cardNumberID string) bool {
-	query := "SELECT card_number_id FROM employees WHERE card_number_id=?;"
-	row := r.db.QueryRow(query, cardNumberID)
+	query := "SELECT card_number_id FROM employees WHERE card_number_id=? AND site_id = COALESCE(?, site_id);"
+	row := r.db.Writer().QueryRowContext(ctx, query, cardNumberID, tenant.Filter(ctx))
 	err := row.Scan(&cardNumberID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", "Exists", "error", err)
+	}
 	return err == nil
 }
 
 func (r *repository) Save(ctx context.Context, e domain.Employee) (int, error) {
-	query := "INSERT INTO employees(card_number_id,first_name,last_name,warehouse_id) VALUES (?,?,?,?)"
-	stmt, err := r.db.Prepare(query)
+	site, err := tenant.Site(ctx)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(&e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID)
+	query := SAVE_EMPLOYEE
+	stmt, err := r.stmts.Stmt(ctx, query)
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
+	}
+
+	res, err := stmt.ExecContext(ctx, site, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
 	}
 
 	id, err := res.LastInsertId()
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
 	}
 
 	return int(id), nil
 }
 
 func (r *repository) Update(ctx context.Context, e domain.Employee) error {
-	query := "UPDATE employees SET first_name=?, last_name=?, warehouse_id=?  WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	query := UPDATE_EMPLOYEE
+	stmt, err := r.stmts.Stmt(ctx, query)
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
-	res, err := stmt.Exec(&e.FirstName, &e.LastName, &e.WarehouseID, &e.ID)
+	res, err := stmt.ExecContext(ctx, &e.FirstName, &e.LastName, &e.WarehouseID, &e.ID, tenant.Filter(ctx))
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	_, err = res.RowsAffected()
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	return nil
 }
 
 func (r *repository) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM employees WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	query := DELETE_EMPLOYEE
+	stmt, err := r.stmts.Stmt(ctx, query)
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
-	res, err := stmt.Exec(id)
+	res, err := stmt.ExecContext(ctx, id, tenant.Filter(ctx))
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	affect, err := res.RowsAffected()
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	if affect < 1 {
@@ -135,39 +165,41 @@ func (r *repository) ReportInboundOrders(ctx context.Context) ([]domain.ReportIn
 	//var rows *sql.Rows
 	query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id , count(inbo.employee_id) AS inbound_orders_count " +
 		"FROM employees e LEFT JOIN inbound_orders inbo ON  e.id=inbo.employee_id " +
-		"GROUP BY e.id;"
-	rows, err := r.db.Query(query)
+		"WHERE e.site_id = COALESCE(?, e.site_id) GROUP BY e.id;"
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, tenant.Filter(ctx))
 	if err != nil {
-		return []domain.ReportInBO{}, err
+		return []domain.ReportInBO{}, apperrors.FromDB(err)
 	}
+	defer rows.Close()
 	var report []domain.ReportInBO
 	for rows.Next() {
 		var r domain.ReportInBO
 		err := rows.Scan(&r.ID, &r.CardNumberID, &r.FirstName, &r.LastName, &r.WarehouseID, &r.Inbound_orders_count)
 		if err != nil {
-			return []domain.ReportInBO{}, err
+			return []domain.ReportInBO{}, apperrors.FromDB(err)
 		}
 		report = append(report, r)
 	}
-	return report, nil
+	return report, apperrors.FromDB(rows.Err())
 }
 
 func (r *repository) ReportInboundOrdersByID(ctx context.Context, id int) ([]domain.ReportInBO, error) {
 	query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id , count(inbo.employee_id) AS inbound_orders_count " +
 		"FROM employees e LEFT JOIN inbound_orders inbo ON  e.id= inbo.employee_id " +
-		"WHERE e.id=? GROUP BY e.id;"
-	rows, err := r.db.Query(query, id)
+		"WHERE e.id=? AND e.site_id = COALESCE(?, e.site_id) GROUP BY e.id;"
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, id, tenant.Filter(ctx))
 	if err != nil {
-		return []domain.ReportInBO{}, err
+		return []domain.ReportInBO{}, apperrors.FromDB(err)
 	}
+	defer rows.Close()
 	var report []domain.ReportInBO
 	for rows.Next() {
 		var r domain.ReportInBO
 		err := rows.Scan(&r.ID, &r.CardNumberID, &r.FirstName, &r.LastName, &r.WarehouseID, &r.Inbound_orders_count)
 		if err != nil {
-			return []domain.ReportInBO{}, err
+			return []domain.ReportInBO{}, apperrors.FromDB(err)
 		}
 		report = append(report, r)
 	}
-	return report, nil
+	return report, apperrors.FromDB(rows.Err())
 }
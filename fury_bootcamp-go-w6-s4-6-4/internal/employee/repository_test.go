@@ -8,6 +8,9 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -32,16 +35,16 @@ func Test_GetAllEmployee_Ok(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
-	rows := sqlmock.NewRows([]string{"id", "card_number_id", "first_name", "last_name", "warehouse_id"})
+	rows := sqlmock.NewRows([]string{"id", "site_id", "card_number_id", "first_name", "last_name", "warehouse_id"})
 
 	for _, e := range data {
-		rows.AddRow(e.ID, e.CardNumberID, e.FirstName, e.LastName, e.WarehouseID)
+		rows.AddRow(e.ID, e.SiteID, e.CardNumberID, e.FirstName, e.LastName, e.WarehouseID)
 	}
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees")).WillReturnRows(rows)
-	repository := NewRepository(db)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, site_id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE site_id = COALESCE(?, site_id)")).WillReturnRows(rows)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	employees, err := repository.GetAll(context.TODO())
+	employees, err := repository.GetAll(tenant.NewContext(context.TODO(), "MLA"))
 
 	//Assert
 	assert.NoError(t, err)
@@ -70,16 +73,16 @@ func Test_GetAllEmployee_Fail(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
-	rows := sqlmock.NewRows([]string{"id", "card_number_id", "first_name", "last_name", "warehouse_id"})
+	rows := sqlmock.NewRows([]string{"id", "site_id", "card_number_id", "first_name", "last_name", "warehouse_id"})
 
 	for _, e := range data {
-		rows.AddRow(e.ID, e.CardNumberID, e.FirstName, e.LastName, e.WarehouseID)
+		rows.AddRow(e.ID, e.SiteID, e.CardNumberID, e.FirstName, e.LastName, e.WarehouseID)
 	}
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees")).WillReturnError(errors.New("Get All Error"))
-	repository := NewRepository(db)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, site_id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE site_id = COALESCE(?, site_id)")).WillReturnError(errors.New("Get All Error"))
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	employees, err := repository.GetAll(context.TODO())
+	employees, err := repository.GetAll(tenant.NewContext(context.TODO(), "MLA"))
 
 	//Assert
 	assert.NotNil(t, err)
@@ -91,6 +94,7 @@ func Test_GetEmployee_Ok(t *testing.T) {
 	//Arrange
 	employee := domain.Employee{
 		ID:           1,
+		SiteID:       "MLA",
 		CardNumberID: "402323",
 		FirstName:    "Jhon",
 		LastName:     "Doe",
@@ -99,13 +103,13 @@ func Test_GetEmployee_Ok(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
-	rows := sqlmock.NewRows([]string{"id", "card_number_id", "first_name", "last_name", "warehouse_id"})
-	rows.AddRow(employee.ID, employee.CardNumberID, employee.FirstName, employee.LastName, employee.WarehouseID)
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE id=?;")).WithArgs(employee.ID).WillReturnRows(rows)
-	repository := NewRepository(db)
+	rows := sqlmock.NewRows([]string{"id", "site_id", "card_number_id", "first_name", "last_name", "warehouse_id"})
+	rows.AddRow(employee.ID, employee.SiteID, employee.CardNumberID, employee.FirstName, employee.LastName, employee.WarehouseID)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, site_id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE id=? AND site_id = COALESCE(?, site_id);")).WithArgs(employee.ID, "MLA").WillReturnRows(rows)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	result, err := repository.Get(context.TODO(), employee.ID)
+	result, err := repository.Get(tenant.NewContext(context.TODO(), "MLA"), employee.ID)
 
 	//Assert
 	assert.NoError(t, err)
@@ -125,13 +129,13 @@ func Test_GetEmployee_Fail(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
-	rows := sqlmock.NewRows([]string{"id", "card_number_id", "first_name", "last_name", "warehouse_id"})
-	rows.AddRow(employee.ID, employee.CardNumberID, employee.FirstName, employee.LastName, employee.WarehouseID)
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE id=?;")).WithArgs(2).WillReturnRows(rows)
-	repository := NewRepository(db)
+	rows := sqlmock.NewRows([]string{"id", "site_id", "card_number_id", "first_name", "last_name", "warehouse_id"})
+	rows.AddRow(employee.ID, employee.SiteID, employee.CardNumberID, employee.FirstName, employee.LastName, employee.WarehouseID)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, site_id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE id=? AND site_id = COALESCE(?, site_id);")).WithArgs(2, "MLA").WillReturnRows(rows)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	result, err := repository.Get(context.TODO(), employee.ID)
+	result, err := repository.Get(tenant.NewContext(context.TODO(), "MLA"), employee.ID)
 
 	//Assert
 	assert.Error(t, err)
@@ -150,15 +154,15 @@ func Test_SaveEmployee_Ok(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
-	mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO employees(card_number_id,first_name,last_name,warehouse_id) VALUES (?,?,?,?)"))
+	mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO employees(site_id,card_number_id,first_name,last_name,warehouse_id) VALUES (?,?,?,?,?)"))
 
-	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO employees(card_number_id,first_name,last_name,warehouse_id) VALUES (?,?,?,?)")).
-		WithArgs(employee_test.CardNumberID, employee_test.FirstName, employee_test.LastName, employee_test.WarehouseID).
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO employees(site_id,card_number_id,first_name,last_name,warehouse_id) VALUES (?,?,?,?,?)")).
+		WithArgs("MLA", employee_test.CardNumberID, employee_test.FirstName, employee_test.LastName, employee_test.WarehouseID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
-	repository := NewRepository(db)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	id, err := repository.Save(context.TODO(), employee_test)
+	id, err := repository.Save(tenant.NewContext(context.TODO(), "MLA"), employee_test)
 
 	//Assert
 	assert.NoError(t, err)
@@ -172,15 +176,15 @@ func Test_Save_EmployeeFail(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
-	mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO employees(card_number_id,first_name,last_name,warehouse_id) VALUES (?,?,?,?)"))
+	mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO employees(site_id,card_number_id,first_name,last_name,warehouse_id) VALUES (?,?,?,?,?)"))
 
-	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO employees(card_number_id,first_name,last_name,warehouse_id) VALUES (?,?,?,?)")).
-		WithArgs(employee_test.CardNumberID, employee_test.FirstName, employee_test.LastName, employee_test.WarehouseID).
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO employees(site_id,card_number_id,first_name,last_name,warehouse_id) VALUES (?,?,?,?,?)")).
+		WithArgs("MLA", employee_test.CardNumberID, employee_test.FirstName, employee_test.LastName, employee_test.WarehouseID).
 		WillReturnError(errors.New("Save Error"))
-	repository := NewRepository(db)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	id, err := repository.Save(context.TODO(), employee_test)
+	id, err := repository.Save(tenant.NewContext(context.TODO(), "MLA"), employee_test)
 
 	//Assert
 	assert.Error(t, err)
@@ -197,12 +201,12 @@ func Test_ExistEmployee_Ok(t *testing.T) {
 
 	row := sqlmock.NewRows([]string{"card_number_id"})
 	row.AddRow(cardNumber)
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT card_number_id FROM employees WHERE card_number_id=?;")).
-		WithArgs(cardNumber).WillReturnRows(row)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT card_number_id FROM employees WHERE card_number_id=? AND site_id = COALESCE(?, site_id);")).
+		WithArgs(cardNumber, "MLA").WillReturnRows(row)
 
-	repository := NewRepository(db)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 	//Act
-	result := repository.Exists(context.TODO(), cardNumber)
+	result := repository.Exists(tenant.NewContext(context.TODO(), "MLA"), cardNumber)
 
 	//Assert
 	assert.True(t, result)
@@ -218,12 +222,12 @@ func Test_ExistEmployee_Fail(t *testing.T) {
 
 	row := sqlmock.NewRows([]string{"card_number_id"})
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT card_number_id FROM employees WHERE card_number_id=?;")).
-		WithArgs(cardNumber).WillReturnRows(row)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT card_number_id FROM employees WHERE card_number_id=? AND site_id = COALESCE(?, site_id);")).
+		WithArgs(cardNumber, "MLA").WillReturnRows(row)
 
-	repository := NewRepository(db)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 	//Act
-	result := repository.Exists(context.TODO(), cardNumber)
+	result := repository.Exists(tenant.NewContext(context.TODO(), "MLA"), cardNumber)
 
 	//Assert
 	assert.False(t, result)
@@ -243,15 +247,15 @@ func Test_UpdateEmployee_Ok(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	mock.ExpectPrepare(regexp.QuoteMeta("UPDATE employees SET first_name=?, last_name=?, warehouse_id=?  WHERE id=?"))
+	mock.ExpectPrepare(regexp.QuoteMeta("UPDATE employees SET first_name=?, last_name=?, warehouse_id=?  WHERE id=? AND site_id = COALESCE(?, site_id)"))
 
-	mock.ExpectExec(regexp.QuoteMeta("UPDATE employees SET first_name=?, last_name=?, warehouse_id=?  WHERE id=?")).
-		WithArgs(employee.FirstName, employee.LastName, employee.WarehouseID, employee.ID).
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE employees SET first_name=?, last_name=?, warehouse_id=?  WHERE id=? AND site_id = COALESCE(?, site_id)")).
+		WithArgs(employee.FirstName, employee.LastName, employee.WarehouseID, employee.ID, "MLA").
 		WillReturnResult(sqlmock.NewResult(0, 1))
-	repository := NewRepository(db)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	err = repository.Update(context.TODO(), employee)
+	err = repository.Update(tenant.NewContext(context.TODO(), "MLA"), employee)
 
 	//Assert
 	assert.NoError(t, err)
@@ -271,15 +275,15 @@ func Test_UpdateEmployee_Fail(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	mock.ExpectPrepare(regexp.QuoteMeta("UPDATE employees SET first_name=?, last_name=?, warehouse_id=?  WHERE id=?"))
+	mock.ExpectPrepare(regexp.QuoteMeta("UPDATE employees SET first_name=?, last_name=?, warehouse_id=?  WHERE id=? AND site_id = COALESCE(?, site_id)"))
 
-	mock.ExpectExec(regexp.QuoteMeta("UPDATE employees SET first_name=?, last_name=?, warehouse_id=?  WHERE id=?")).
-		WithArgs(employee.FirstName, employee.LastName, employee.WarehouseID, employee.ID).
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE employees SET first_name=?, last_name=?, warehouse_id=?  WHERE id=? AND site_id = COALESCE(?, site_id)")).
+		WithArgs(employee.FirstName, employee.LastName, employee.WarehouseID, employee.ID, "MLA").
 		WillReturnError(errors.New("Update Error"))
-	repository := NewRepository(db)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	err = repository.Update(context.TODO(), employee)
+	err = repository.Update(tenant.NewContext(context.TODO(), "MLA"), employee)
 
 	//Assert
 	assert.Error(t, err)
@@ -292,14 +296,14 @@ func Test_DeleteEmployee_Ok(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
-	mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM employees WHERE id=?"))
+	mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM employees WHERE id=? AND site_id = COALESCE(?, site_id)"))
 
-	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM employees WHERE id=?")).
-		WithArgs(id).WillReturnResult(sqlmock.NewResult(1, 1))
-	repository := NewRepository(db)
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM employees WHERE id=? AND site_id = COALESCE(?, site_id)")).
+		WithArgs(id, "MLA").WillReturnResult(sqlmock.NewResult(1, 1))
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	err = repository.Delete(context.TODO(), id)
+	err = repository.Delete(tenant.NewContext(context.TODO(), "MLA"), id)
 
 	//Assert
 	assert.NoError(t, err)
@@ -315,15 +319,15 @@ func Test_DeleteEmployee_Fail(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
-	mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM employees WHERE id=?"))
+	mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM employees WHERE id=? AND site_id = COALESCE(?, site_id)"))
 
-	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM employees WHERE id=?")).
-		WithArgs(id).WillReturnError(errors.New("Delete Error"))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM employees WHERE id=? AND site_id = COALESCE(?, site_id)")).
+		WithArgs(id, "MLA").WillReturnError(errors.New("Delete Error"))
 
-	repository := NewRepository(db)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	err = repository.Delete(context.TODO(), id)
+	err = repository.Delete(tenant.NewContext(context.TODO(), "MLA"), id)
 
 	//Assert
 	assert.Error(t, err)
@@ -360,13 +364,13 @@ func Test_ReportInboundOrders_Ok(t *testing.T) {
 	}
 	stmt := (regexp.QuoteMeta("SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id , count(inbo.employee_id) AS inbound_orders_count " +
 		"FROM employees e LEFT JOIN inbound_orders inbo ON  e.id=inbo.employee_id " +
-		"GROUP BY e.id;"))
+		"WHERE e.site_id = COALESCE(?, e.site_id) GROUP BY e.id;"))
 
 	mock.ExpectQuery(stmt).WillReturnRows(rows)
-	repository := NewRepository(db)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	result, err := repository.ReportInboundOrders(context.TODO())
+	result, err := repository.ReportInboundOrders(tenant.NewContext(context.TODO(), "MLA"))
 
 	//Assert
 	assert.NoError(t, err)
@@ -404,13 +408,13 @@ func Test_ReportInboundOrders_Fail(t *testing.T) {
 	}
 	stmt := regexp.QuoteMeta("SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id , count(inbo.employee_id) AS inbound_orders_count " +
 		"FROM employees e LEFT JOIN inbound_orders inbo ON  e.id=inbo.employee_id " +
-		"GROUP BY e.id;")
+		"WHERE e.site_id = COALESCE(?, e.site_id) GROUP BY e.id;")
 
 	mock.ExpectQuery(stmt).WillReturnError(errors.New("ReportInboundOrders Error"))
-	repository := NewRepository(db)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	result, err := repository.ReportInboundOrders(context.TODO())
+	result, err := repository.ReportInboundOrders(tenant.NewContext(context.TODO(), "MLA"))
 
 	//Assert
 	assert.NotNil(t, err)
@@ -437,13 +441,13 @@ func Test_ReportInboundOrdersByID_Ok(t *testing.T) {
 	rows.AddRow(report[0].ID, report[0].CardNumberID, report[0].FirstName, report[0].LastName, report[0].WarehouseID, report[0].Inbound_orders_count)
 	stmt := regexp.QuoteMeta("SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id , count(inbo.employee_id) AS inbound_orders_count " +
 		"FROM employees e LEFT JOIN inbound_orders inbo ON  e.id= inbo.employee_id " +
-		"WHERE e.id=? GROUP BY e.id;")
+		"WHERE e.id=? AND e.site_id = COALESCE(?, e.site_id) GROUP BY e.id;")
 
-	mock.ExpectQuery(stmt).WithArgs(report[0].ID).WillReturnRows(rows)
-	repository := NewRepository(db)
+	mock.ExpectQuery(stmt).WithArgs(report[0].ID, "MLA").WillReturnRows(rows)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	result, err := repository.ReportInboundOrdersByID(context.TODO(), report[0].ID)
+	result, err := repository.ReportInboundOrdersByID(tenant.NewContext(context.TODO(), "MLA"), report[0].ID)
 
 	//Assert
 	assert.NoError(t, err)
@@ -470,13 +474,13 @@ func Test_ReportInboundOrdersByID_Fail(t *testing.T) {
 	rows.AddRow(report[0].ID, report[0].CardNumberID, report[0].FirstName, report[0].LastName, report[0].WarehouseID, report[0].Inbound_orders_count)
 	stmt := regexp.QuoteMeta("SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id , count(inbo.employee_id) AS inbound_orders_count " +
 		"FROM employees e LEFT JOIN inbound_orders inbo ON  e.id= inbo.employee_id " +
-		"WHERE e.id=? GROUP BY e.id;")
+		"WHERE e.id=? AND e.site_id = COALESCE(?, e.site_id) GROUP BY e.id;")
 
-	mock.ExpectQuery(stmt).WithArgs(2).WillReturnRows(rows)
-	repository := NewRepository(db)
+	mock.ExpectQuery(stmt).WithArgs(2, "MLA").WillReturnRows(rows)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	result, err := repository.ReportInboundOrdersByID(context.TODO(), report[0].ID)
+	result, err := repository.ReportInboundOrdersByID(tenant.NewContext(context.TODO(), "MLA"), report[0].ID)
 
 	//Assert
 	assert.Error(t, err)
@@ -2,15 +2,18 @@ package employee
 
 import (
 	"context"
-	"errors"
+	"log/slog"
 	"strconv"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 // Errors
 var (
-	ErrNotFound = errors.New("employee not found")
+	ErrNotFound           = apperrors.NotFound("employee not found")
+	ErrCardNumberIDExists = apperrors.Conflict("The card_number_id already exists")
+	ErrInvalidID          = apperrors.Validation("invalid id format")
 )
 
 type Service interface {
@@ -24,10 +27,11 @@ type Service interface {
 
 type service struct {
 	repository Repository
+	logger     *slog.Logger
 }
 
-func NewService(r Repository) Service {
-	return &service{repository: r}
+func NewService(r Repository, logger *slog.Logger) Service {
+	return &service{repository: r, logger: logger}
 }
 func (s *service) Save(ctx context.Context, cardNumberId string, name string, lastname string, wharehouseId int) (domain.Employee, error) {
 	if !s.repository.Exists(ctx, cardNumberId) {
@@ -41,9 +45,10 @@ func (s *service) Save(ctx context.Context, cardNumberId string, name string, la
 			return domain.Employee{}, err
 		}
 		newemp.ID = id
+		s.logger.InfoContext(ctx, "employee created", "employee_id", id)
 		return newemp, nil
 	}
-	return domain.Employee{}, errors.New("The card_number_id already exists")
+	return domain.Employee{}, ErrCardNumberIDExists
 }
 
 func (s *service) GetAllEmployees(ctx context.Context) ([]domain.Employee, error) {
@@ -53,14 +58,14 @@ func (s *service) GetAllEmployees(ctx context.Context) ([]domain.Employee, error
 func (s *service) GetEmployeeByID(ctx context.Context, id int) (domain.Employee, error) {
 	employeedfound, err := s.repository.Get(ctx, id)
 	if err != nil {
-		return domain.Employee{}, ErrNotFound
+		return domain.Employee{}, err
 	}
 	return employeedfound, nil
 }
 func (s *service) Delete(ctx context.Context, id int) error {
 	_, err := s.repository.Get(ctx, id)
 	if err != nil {
-		return ErrNotFound
+		return err
 	}
 	return s.repository.Delete(ctx, id)
 }
@@ -68,7 +73,7 @@ func (s *service) Delete(ctx context.Context, id int) error {
 func (s *service) Update(ctx context.Context, id int, name string, lastname string, wharehouseId *int) (domain.Employee, error) {
 	e, err := s.repository.Get(ctx, id)
 	if err != nil {
-		return domain.Employee{}, ErrNotFound
+		return domain.Employee{}, err
 	}
 	if name != "" {
 		e.FirstName = name
@@ -86,11 +91,11 @@ func (s *service) Report_BO(ctx context.Context, id string) ([]domain.ReportInBO
 	if id != "" {
 		id_e, err := strconv.Atoi(id)
 		if err != nil {
-			return []domain.ReportInBO{}, errors.New("invalid id format")
+			return []domain.ReportInBO{}, ErrInvalidID
 		}
 		_, err = s.repository.Get(ctx, id_e)
 		if err != nil {
-			return []domain.ReportInBO{}, ErrNotFound
+			return []domain.ReportInBO{}, err
 		}
 		return s.repository.ReportInboundOrdersByID(ctx, id_e)
 	}
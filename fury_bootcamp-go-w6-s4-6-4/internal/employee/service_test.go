@@ -2,10 +2,10 @@ package employee
 
 import (
 	"context"
-	"errors"
 	"testing"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/employee"
 	"github.com/stretchr/testify/assert"
 )
@@ -37,8 +37,8 @@ func Test_create_ok_Service(t *testing.T) {
 		WarehouseID:  1,
 	}
 	myMockR := employee.MockRepositoryEmployee{}
-	service := NewService(&myMockR)
-	var ctx context.Context
+	service := NewService(&myMockR, logger.Discard())
+	var ctx = context.Background()
 	//Act
 	employResult, err := service.Save(ctx, empExpec.CardNumberID, empExpec.FirstName, empExpec.LastName, empExpec.WarehouseID)
 	//Assert
@@ -56,12 +56,12 @@ func Test_create_conflict_Service(t *testing.T) {
 		LastName:     "Doe",
 		WarehouseID:  1,
 	}
-	errExpected := errors.New("The card_number_id already exists")
+	errExpected := ErrCardNumberIDExists
 	var dat []domain.Employee
 	dat = append(dat, data...)
 	myMockR := employee.MockRepositoryEmployee{DataMock: dat}
-	service := NewService(&myMockR)
-	var ctx context.Context
+	service := NewService(&myMockR, logger.Discard())
+	var ctx = context.Background()
 	//Act
 	employResult, err := service.Save(ctx, newEmployee.CardNumberID, newEmployee.FirstName, newEmployee.LastName, newEmployee.WarehouseID)
 	//Assert
@@ -76,8 +76,8 @@ func Test_find_all_Service(t *testing.T) {
 	var employeesExpected []domain.Employee
 	employeesExpected = append(employeesExpected, data...)
 	myMockR := employee.MockRepositoryEmployee{DataMock: data}
-	service := NewService(&myMockR)
-	var ctx context.Context
+	service := NewService(&myMockR, logger.Discard())
+	var ctx = context.Background()
 	//Act
 	employeesResult, err := service.GetAllEmployees(ctx)
 	//Assert
@@ -98,8 +98,8 @@ func Test_find_by_id_non_existent_Service(t *testing.T) {
 	var dat []domain.Employee
 	dat = append(dat, data...)
 	myMockR := employee.MockRepositoryEmployee{DataMock: dat}
-	service := NewService(&myMockR)
-	var ctx context.Context
+	service := NewService(&myMockR, logger.Discard())
+	var ctx = context.Background()
 	//Act
 	employeeResult, err := service.GetEmployeeByID(ctx, 3)
 	//Assert
@@ -116,8 +116,8 @@ func Test_find_by_id_existent_Service(t *testing.T) {
 	employeeExpected := dat[0]
 
 	myMockR := employee.MockRepositoryEmployee{DataMock: dat}
-	service := NewService(&myMockR)
-	var ctx context.Context
+	service := NewService(&myMockR, logger.Discard())
+	var ctx = context.Background()
 	//Act
 	employeeResult, err := service.GetEmployeeByID(ctx, employeeExpected.ID)
 	//Assert
@@ -138,8 +138,8 @@ func Test_update_existent_Service(t *testing.T) {
 	var dat []domain.Employee
 	dat = append(dat, data...)
 	myMockR := employee.MockRepositoryEmployee{DataMock: dat}
-	service := NewService(&myMockR)
-	var ctx context.Context
+	service := NewService(&myMockR, logger.Discard())
+	var ctx = context.Background()
 	//Act
 	employeeResult, err := service.Update(ctx, employeeExpected.ID, employeeExpected.FirstName, employeeExpected.LastName, &employeeExpected.WarehouseID)
 	//Assert
@@ -161,8 +161,8 @@ func Test_update_non_existent_Service(t *testing.T) {
 	var dat []domain.Employee
 	dat = append(dat, data...)
 	myMockR := employee.MockRepositoryEmployee{DataMock: dat}
-	service := NewService(&myMockR)
-	var ctx context.Context
+	service := NewService(&myMockR, logger.Discard())
+	var ctx = context.Background()
 	//Act
 	employeeResult, err := service.Update(ctx, employeeUpdate.ID, employeeUpdate.FirstName, employeeUpdate.LastName, &employeeUpdate.WarehouseID)
 	//Assert
@@ -182,8 +182,8 @@ func Test_delete_non_existent_Service(t *testing.T) {
 		WarehouseID:  1,
 	}
 	myMockR := employee.MockRepositoryEmployee{DataMock: data}
-	service := NewService(&myMockR)
-	var ctx context.Context
+	service := NewService(&myMockR, logger.Discard())
+	var ctx = context.Background()
 	//Act
 	err := service.Delete(ctx, employeeDelete.ID)
 	//Assert
@@ -204,8 +204,8 @@ func Test_delete_ok_Service(t *testing.T) {
 	var dat []domain.Employee
 	dat = append(dat, data...)
 	myMockR := employee.MockRepositoryEmployee{DataMock: dat}
-	service := NewService(&myMockR)
-	var ctx context.Context
+	service := NewService(&myMockR, logger.Discard())
+	var ctx = context.Background()
 	//Act
 	err := service.Delete(ctx, employeeDelete.ID)
 
@@ -238,8 +238,8 @@ func Test_report_bo_all_ok_Service(t *testing.T) {
 		},
 	}
 	myMockR := employee.MockRepositoryEmployee{DatamockIBO: data}
-	service := NewService(&myMockR)
-	var ctx context.Context
+	service := NewService(&myMockR, logger.Discard())
+	var ctx = context.Background()
 
 	//Act
 	result, err := service.Report_BO(ctx, "")
@@ -271,8 +271,8 @@ func Test_report_bo_all_fail_Service(t *testing.T) {
 	}
 	errorExpected := "Error report all"
 	myMockR := employee.MockRepositoryEmployee{DatamockIBO: data, Err: errorExpected}
-	service := NewService(&myMockR)
-	var ctx context.Context
+	service := NewService(&myMockR, logger.Discard())
+	var ctx = context.Background()
 
 	//Act
 	result, err := service.Report_BO(ctx, "")
@@ -304,8 +304,8 @@ func Test_report_bo_byId_ok_Service(t *testing.T) {
 	}
 
 	myMockR := employee.MockRepositoryEmployee{DatamockIBO: data, DataMock: datae}
-	service := NewService(&myMockR)
-	var ctx context.Context
+	service := NewService(&myMockR, logger.Discard())
+	var ctx = context.Background()
 
 	//Act
 	result, err := service.Report_BO(ctx, "1")
@@ -326,10 +326,10 @@ func Test_report_bo_byId_fail_Service(t *testing.T) {
 			Inbound_orders_count: 2,
 		},
 	}
-	errorExpected := errors.New("employee not found")
+	errorExpected := ErrNotFound
 	myMockR := employee.MockRepositoryEmployee{DatamockIBO: data}
-	service := NewService(&myMockR)
-	var ctx context.Context
+	service := NewService(&myMockR, logger.Discard())
+	var ctx = context.Background()
 
 	//Act
 	result, err := service.Report_BO(ctx, "1")
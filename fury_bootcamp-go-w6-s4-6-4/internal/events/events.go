@@ -0,0 +1,139 @@
+// Package events defines the domain events services emit and the outbox
+// that records them in the transaction of the change they describe, so an
+// event is published if and only if its change is committed.
+package events
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+)
+
+// Event types.
+const (
+	SellerCreated              = "seller.created"
+	ProductBatchReceived       = "product_batch.received"
+	PurchaseOrderStatusChanged = "purchase_order.status_changed"
+	SectionTemperatureBreach   = "section.temperature_breach"
+	InboundOrderCreated        = "inbound_order.created"
+	StockChanged               = "stock.changed"
+)
+
+// Types lists every event type.
+var Types = []string{
+	SellerCreated, ProductBatchReceived, PurchaseOrderStatusChanged, SectionTemperatureBreach,
+	InboundOrderCreated, StockChanged,
+}
+
+// Event is something that happened to the entity AggregateID. Data is its
+// payload, encoded as JSON. WarehouseID is the warehouse it happened in, or
+// 0 when it isn't about a single warehouse.
+type Event struct {
+	Type        string
+	AggregateID int
+	WarehouseID int
+	Data        interface{}
+}
+
+// Outbox records the events of a change with the change itself.
+type Outbox interface {
+	// Record runs change in a transaction and appends the events it returns
+	// to the outbox in that same transaction. Repositories called with the
+	// context change gets write in the transaction.
+	Record(ctx context.Context, change func(ctx context.Context) ([]Event, error)) error
+}
+
+type outbox struct {
+	db    *database.Router
+	stmts *database.Statements
+}
+
+func NewOutbox(db *database.Router, stmts *database.Statements) Outbox {
+	stmts.Register(APPEND_EVENT)
+	return &outbox{
+		db:    db,
+		stmts: stmts,
+	}
+}
+
+const APPEND_EVENT = "INSERT INTO outbox_events (site_id, event_type, aggregate_id, warehouse_id, payload, occurred_at) VALUES (?, ?, ?, ?, ?, ?)"
+
+func (o *outbox) Record(ctx context.Context, change func(ctx context.Context) ([]Event, error)) error {
+	return o.db.Transact(ctx, func(ctx context.Context) error {
+		events, err := change(ctx)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		site, err := tenant.Site(ctx)
+		if err != nil {
+			return err
+		}
+
+		stmt, err := o.stmts.Stmt(ctx, APPEND_EVENT)
+		if err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		for _, e := range events {
+			payload, err := json.Marshal(e.Data)
+			if err != nil {
+				return err
+			}
+			var warehouseID sql.NullInt64
+			if e.WarehouseID != 0 {
+				warehouseID = sql.NullInt64{Int64: int64(e.WarehouseID), Valid: true}
+			}
+			if _, err := stmt.ExecContext(ctx, site, e.Type, e.AggregateID, warehouseID, payload, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Discard is an Outbox that runs changes outside of a transaction and drops
+// their events.
+var Discard Outbox = discard{}
+
+type discard struct{}
+
+func (discard) Record(ctx context.Context, change func(ctx context.Context) ([]Event, error)) error {
+	_, err := change(ctx)
+	return err
+}
+
+// PurchaseOrderStatus is the payload of PurchaseOrderStatusChanged.
+// PreviousStatusID is nil when the order was just placed.
+type PurchaseOrderStatus struct {
+	PurchaseOrderID  int    `json:"purchase_order_id"`
+	OrderNumber      string `json:"order_number"`
+	BuyerID          int    `json:"buyer_id"`
+	PreviousStatusID *int   `json:"previous_status_id"`
+	StatusID         int    `json:"status_id"`
+}
+
+// TemperatureBreach is the payload of SectionTemperatureBreach.
+type TemperatureBreach struct {
+	SectionID          int `json:"section_id"`
+	SectionNumber      int `json:"section_number"`
+	WarehouseID        int `json:"warehouse_id"`
+	CurrentTemperature int `json:"current_temperature"`
+	MinimumTemperature int `json:"minimum_temperature"`
+}
+
+// StockChange is the payload of StockChanged. Quantity is how many units
+// of the product the section gained, negative when it lost them.
+type StockChange struct {
+	WarehouseID    int `json:"warehouse_id"`
+	SectionID      int `json:"section_id"`
+	ProductID      int `json:"product_id"`
+	ProductBatchID int `json:"product_batch_id"`
+	Quantity       int `json:"quantity"`
+}
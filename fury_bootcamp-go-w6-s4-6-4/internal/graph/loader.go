@@ -0,0 +1,123 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/graph-gophers/dataloader/v7"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+// batchWait is how long a loader waits for more keys before querying. The
+// fields of a level resolve concurrently, so a few milliseconds are enough
+// to gather the keys of all of them.
+const batchWait = 2 * time.Millisecond
+
+// Loaders batch and cache the lookups of one request. Build them per
+// request: they cache every entity they load and never expire them.
+type Loaders struct {
+	Locality         *dataloader.Loader[int, domain.Locality]
+	Seller           *dataloader.Loader[int, domain.Seller]
+	Product          *dataloader.Loader[int, domain.Product]
+	ProductsBySeller *dataloader.Loader[int, []domain.Product]
+	ProductBatch     *dataloader.Loader[int, domain.Product_batches]
+	BatchesByProduct *dataloader.Loader[int, []domain.Product_batches]
+	Section          *dataloader.Loader[int, domain.Section]
+	Warehouse        *dataloader.Loader[int, domain.Warehouse]
+	Employee         *dataloader.Loader[int, domain.Employee]
+	Buyer            *dataloader.Loader[int, domain.Buyer]
+}
+
+func NewLoaders(repo Repository) *Loaders {
+	return &Loaders{
+		Locality:         newLoader(byID(repo.Localities, func(l domain.Locality) int { return l.ID }, "locality")),
+		Seller:           newLoader(byID(repo.Sellers, func(s domain.Seller) int { return s.ID }, "seller")),
+		Product:          newLoader(byID(repo.Products, func(p domain.Product) int { return p.ID }, "product")),
+		ProductsBySeller: newLoader(groupBy(repo.ProductsBySeller, func(p domain.Product) int { return p.SellerID })),
+		ProductBatch:     newLoader(byID(repo.ProductBatches, func(pb domain.Product_batches) int { return pb.ID }, "product batch")),
+		BatchesByProduct: newLoader(groupBy(repo.ProductBatchesByProduct, func(pb domain.Product_batches) int { return pb.ProductId })),
+		Section:          newLoader(byID(repo.Sections, func(s domain.Section) int { return s.ID }, "section")),
+		Warehouse:        newLoader(byID(repo.Warehouses, func(w domain.Warehouse) int { return w.ID }, "warehouse")),
+		Employee:         newLoader(byID(repo.Employees, func(e domain.Employee) int { return e.ID }, "employee")),
+		Buyer:            newLoader(byID(repo.Buyers, func(b domain.Buyer) int { return b.ID }, "buyer")),
+	}
+}
+
+type loadersKey struct{}
+
+// NewContext returns a copy of ctx that carries l.
+func NewContext(ctx context.Context, l *Loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+// FromContext returns the loaders carried by ctx, or nil when there are
+// none.
+func FromContext(ctx context.Context) *Loaders {
+	l, _ := ctx.Value(loadersKey{}).(*Loaders)
+	return l
+}
+
+func newLoader[V any](batch dataloader.BatchFunc[int, V]) *dataloader.Loader[int, V] {
+	return dataloader.NewBatchedLoader(batch, dataloader.WithWait[int, V](batchWait))
+}
+
+// byID answers a batch of primary keys with fetch. Keys fetch doesn't
+// return fail as not found, without failing the rest of the batch.
+func byID[V any](fetch func(context.Context, []int) ([]V, error), id func(V) int, entity string) dataloader.BatchFunc[int, V] {
+	return func(ctx context.Context, keys []int) []*dataloader.Result[V] {
+		items, err := fetch(ctx, keys)
+		if err != nil {
+			return failAll[V](len(keys), err)
+		}
+		found := make(map[int]V, len(items))
+		for _, item := range items {
+			found[id(item)] = item
+		}
+
+		results := make([]*dataloader.Result[V], len(keys))
+		for i, k := range keys {
+			item, ok := found[k]
+			if !ok {
+				results[i] = &dataloader.Result[V]{Error: apperrors.NotFound(fmt.Sprintf("%s %d not found", entity, k))}
+				continue
+			}
+			results[i] = &dataloader.Result[V]{Data: item}
+		}
+		return results
+	}
+}
+
+// groupBy answers a batch of foreign keys with fetch, grouping the items by
+// the key they reference. Keys nothing references get an empty list.
+func groupBy[V any](fetch func(context.Context, []int) ([]V, error), key func(V) int) dataloader.BatchFunc[int, []V] {
+	return func(ctx context.Context, keys []int) []*dataloader.Result[[]V] {
+		items, err := fetch(ctx, keys)
+		if err != nil {
+			return failAll[[]V](len(keys), err)
+		}
+		groups := make(map[int][]V, len(keys))
+		for _, item := range items {
+			groups[key(item)] = append(groups[key(item)], item)
+		}
+
+		results := make([]*dataloader.Result[[]V], len(keys))
+		for i, k := range keys {
+			group := groups[k]
+			if group == nil {
+				group = []V{}
+			}
+			results[i] = &dataloader.Result[[]V]{Data: group}
+		}
+		return results
+	}
+}
+
+func failAll[V any](n int, err error) []*dataloader.Result[V] {
+	results := make([]*dataloader.Result[V], n)
+	for i := range results {
+		results[i] = &dataloader.Result[V]{Error: err}
+	}
+	return results
+}
@@ -0,0 +1,120 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sellerRepository answers the seller lookups from sellers and records the
+// batches of keys it's asked for.
+type sellerRepository struct {
+	Repository
+	sellers  []domain.Seller
+	products []domain.Product
+	err      error
+
+	mu      sync.Mutex
+	batches [][]int
+}
+
+func (r *sellerRepository) Sellers(_ context.Context, ids []int) ([]domain.Seller, error) {
+	r.record(ids)
+	var found []domain.Seller
+	for _, s := range r.sellers {
+		for _, id := range ids {
+			if s.ID == id {
+				found = append(found, s)
+			}
+		}
+	}
+	return found, r.err
+}
+
+func (r *sellerRepository) ProductsBySeller(_ context.Context, ids []int) ([]domain.Product, error) {
+	r.record(ids)
+	return r.products, r.err
+}
+
+func (r *sellerRepository) record(ids []int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, ids)
+}
+
+func TestLoaders(t *testing.T) {
+	ctx := context.Background()
+	sellers := []domain.Seller{{ID: 1, CompanyName: "Meli"}, {ID: 2, CompanyName: "Libre"}}
+
+	t.Run("should batch concurrent loads into one fetch", func(t *testing.T) {
+		repo := &sellerRepository{sellers: sellers}
+		l := NewLoaders(repo)
+
+		var wg sync.WaitGroup
+		names := make([]string, 2)
+		for i := range names {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				s, err := l.Seller.Load(ctx, i+1)()
+				assert.NoError(t, err)
+				names[i] = s.CompanyName
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Equal(t, []string{"Meli", "Libre"}, names)
+		require.Len(t, repo.batches, 1)
+		assert.ElementsMatch(t, []int{1, 2}, repo.batches[0])
+	})
+	t.Run("should cache what it loaded", func(t *testing.T) {
+		repo := &sellerRepository{sellers: sellers}
+		l := NewLoaders(repo)
+
+		_, err := l.Seller.Load(ctx, 1)()
+		require.NoError(t, err)
+		_, err = l.Seller.Load(ctx, 1)()
+		require.NoError(t, err)
+
+		assert.Len(t, repo.batches, 1)
+	})
+	t.Run("should fail only the keys without a row", func(t *testing.T) {
+		l := NewLoaders(&sellerRepository{sellers: sellers})
+
+		results, errs := l.Seller.LoadMany(ctx, []int{1, 9})()
+
+		assert.Equal(t, "Meli", results[0].CompanyName)
+		require.Len(t, errs, 2)
+		assert.NoError(t, errs[0])
+		assert.ErrorIs(t, errs[1], apperrors.ErrNotFound)
+		assert.EqualError(t, errs[1], "seller 9 not found")
+	})
+	t.Run("should fail every key when the fetch fails", func(t *testing.T) {
+		boom := errors.New("boom")
+		l := NewLoaders(&sellerRepository{err: boom})
+
+		_, errs := l.Seller.LoadMany(ctx, []int{1, 2})()
+
+		require.Len(t, errs, 2)
+		assert.ErrorIs(t, errs[0], boom)
+		assert.ErrorIs(t, errs[1], boom)
+	})
+	t.Run("should group the referencing items by key", func(t *testing.T) {
+		l := NewLoaders(&sellerRepository{products: []domain.Product{
+			{ID: 1, SellerID: 1}, {ID: 2, SellerID: 2}, {ID: 3, SellerID: 1},
+		}})
+
+		groups, errs := l.ProductsBySeller.LoadMany(ctx, []int{1, 2, 3})()
+
+		assert.Empty(t, errs)
+		assert.Equal(t, []domain.Product{{ID: 1, SellerID: 1}, {ID: 3, SellerID: 1}}, groups[0])
+		assert.Equal(t, []domain.Product{{ID: 2, SellerID: 2}}, groups[1])
+		assert.Equal(t, []domain.Product{}, groups[2])
+	})
+}
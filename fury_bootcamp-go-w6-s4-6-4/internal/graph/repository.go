@@ -0,0 +1,191 @@
+// Package graph reads the entities the GraphQL API resolves. Lookups take a
+// batch of keys and answer them with a single query, so the loaders can
+// collapse the lookups of a whole level of a query into one round trip.
+package graph
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+)
+
+// Repository reads entities by batches of keys. Keys without a row are left
+// out of the result rather than failing the batch.
+type Repository interface {
+	Localities(ctx context.Context, ids []int) ([]domain.Locality, error)
+	Sellers(ctx context.Context, ids []int) ([]domain.Seller, error)
+	Products(ctx context.Context, ids []int) ([]domain.Product, error)
+	ProductsBySeller(ctx context.Context, sellerIDs []int) ([]domain.Product, error)
+	ProductBatches(ctx context.Context, ids []int) ([]domain.Product_batches, error)
+	ProductBatchesByProduct(ctx context.Context, productIDs []int) ([]domain.Product_batches, error)
+	Sections(ctx context.Context, ids []int) ([]domain.Section, error)
+	Warehouses(ctx context.Context, ids []int) ([]domain.Warehouse, error)
+	Employees(ctx context.Context, ids []int) ([]domain.Employee, error)
+	Buyers(ctx context.Context, ids []int) ([]domain.Buyer, error)
+	PurchaseOrders(ctx context.Context) ([]domain.PurchaseOrders, error)
+}
+
+type repository struct {
+	db *database.Router
+}
+
+// NewRepository returns a Repository that reads from the replicas. Its
+// queries take a variable number of keys, so unlike the other repositories
+// it doesn't prepare statements.
+func NewRepository(db *database.Router) Repository {
+	return &repository{db: db}
+}
+
+// The queries below are filtered by the site of the context and end with an
+// IN list that queryIn expands to one placeholder per key.
+const (
+	GET_LOCALITIES         = "SELECT l.id, l.locality_name, l.province_id, p.province_name, c.country_name FROM locality l JOIN provinces p ON p.site_id = l.site_id AND p.id = l.province_id JOIN countries c ON c.site_id = p.site_id AND c.id = p.country_id WHERE l.site_id = COALESCE(?, l.site_id) AND l.id IN (%s)"
+	GET_SELLERS            = "SELECT id, cid, company_name, address, telephone, locality_id FROM seller WHERE site_id = COALESCE(?, site_id) AND id IN (%s)"
+	GET_PRODUCTS           = "SELECT id, description, expiration_rate, freezing_rate, height, length, netweight, product_code, recommended_freezing_temperature, width, product_type_id, seller_id FROM products WHERE site_id = COALESCE(?, site_id) AND id IN (%s)"
+	GET_PRODUCTS_BY_SELLER = "SELECT id, description, expiration_rate, freezing_rate, height, length, netweight, product_code, recommended_freezing_temperature, width, product_type_id, seller_id FROM products WHERE site_id = COALESCE(?, site_id) AND seller_id IN (%s)"
+	GET_BATCHES            = "SELECT id, batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minimum_temperature, sections_id, products_id FROM product_batches WHERE site_id = COALESCE(?, site_id) AND id IN (%s)"
+	GET_BATCHES_BY_PRODUCT = "SELECT id, batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minimum_temperature, sections_id, products_id FROM product_batches WHERE site_id = COALESCE(?, site_id) AND products_id IN (%s)"
+	GET_SECTIONS           = "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections WHERE site_id = COALESCE(?, site_id) AND id IN (%s)"
+	GET_WAREHOUSES         = "SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature FROM warehouses WHERE site_id = COALESCE(?, site_id) AND id IN (%s)"
+	GET_EMPLOYEES          = "SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE site_id = COALESCE(?, site_id) AND id IN (%s)"
+	GET_BUYERS             = "SELECT id, card_number_id, first_name, last_name FROM buyers WHERE site_id = COALESCE(?, site_id) AND id IN (%s)"
+	GET_PURCHASE_ORDERS    = "SELECT id, order_number, order_date, tracking_code, buyers_id, product_records_id, order_status_id FROM purchase_orders WHERE site_id = COALESCE(?, site_id)"
+)
+
+func (r *repository) Localities(ctx context.Context, ids []int) ([]domain.Locality, error) {
+	return queryIn(ctx, r, GET_LOCALITIES, ids, func(rows *sql.Rows, l *domain.Locality) error {
+		return rows.Scan(&l.ID, &l.LocalityName, &l.ProvinceID, &l.ProvinceName, &l.CountryName)
+	})
+}
+
+func (r *repository) Sellers(ctx context.Context, ids []int) ([]domain.Seller, error) {
+	return queryIn(ctx, r, GET_SELLERS, ids, scanSeller)
+}
+
+func (r *repository) Products(ctx context.Context, ids []int) ([]domain.Product, error) {
+	return queryIn(ctx, r, GET_PRODUCTS, ids, scanProduct)
+}
+
+func (r *repository) ProductsBySeller(ctx context.Context, sellerIDs []int) ([]domain.Product, error) {
+	return queryIn(ctx, r, GET_PRODUCTS_BY_SELLER, sellerIDs, scanProduct)
+}
+
+func (r *repository) ProductBatches(ctx context.Context, ids []int) ([]domain.Product_batches, error) {
+	return queryIn(ctx, r, GET_BATCHES, ids, scanProductBatch)
+}
+
+func (r *repository) ProductBatchesByProduct(ctx context.Context, productIDs []int) ([]domain.Product_batches, error) {
+	return queryIn(ctx, r, GET_BATCHES_BY_PRODUCT, productIDs, scanProductBatch)
+}
+
+func (r *repository) Sections(ctx context.Context, ids []int) ([]domain.Section, error) {
+	return queryIn(ctx, r, GET_SECTIONS, ids, func(rows *sql.Rows, s *domain.Section) error {
+		return rows.Scan(&s.ID, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID)
+	})
+}
+
+func (r *repository) Warehouses(ctx context.Context, ids []int) ([]domain.Warehouse, error) {
+	return queryIn(ctx, r, GET_WAREHOUSES, ids, func(rows *sql.Rows, w *domain.Warehouse) error {
+		return rows.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature)
+	})
+}
+
+func (r *repository) Employees(ctx context.Context, ids []int) ([]domain.Employee, error) {
+	return queryIn(ctx, r, GET_EMPLOYEES, ids, func(rows *sql.Rows, e *domain.Employee) error {
+		return rows.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID)
+	})
+}
+
+func (r *repository) Buyers(ctx context.Context, ids []int) ([]domain.Buyer, error) {
+	return queryIn(ctx, r, GET_BUYERS, ids, func(rows *sql.Rows, b *domain.Buyer) error {
+		return rows.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName)
+	})
+}
+
+func (r *repository) PurchaseOrders(ctx context.Context) ([]domain.PurchaseOrders, error) {
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, GET_PURCHASE_ORDERS, tenant.Filter(ctx))
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	return scanAll(rows, func(rows *sql.Rows, p *domain.PurchaseOrders) error {
+		var orderDate sql.NullString
+		var status sql.NullInt64
+		if err := rows.Scan(&p.ID, &p.OrderNumber, &orderDate, &p.TrackingCode, &p.BuyerID, &p.ProductRecordID, &status); err != nil {
+			return err
+		}
+		p.OrderStatusID = int(status.Int64)
+		if orderDate.Valid {
+			t, err := parseDateTime(orderDate.String)
+			if err != nil {
+				return err
+			}
+			p.OrderDate = &t
+		}
+		return nil
+	})
+}
+
+func scanSeller(rows *sql.Rows, s *domain.Seller) error {
+	return rows.Scan(&s.ID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.LocalityID)
+}
+
+func scanProduct(rows *sql.Rows, p *domain.Product) error {
+	return rows.Scan(&p.ID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID)
+}
+
+func scanProductBatch(rows *sql.Rows, pb *domain.Product_batches) error {
+	return rows.Scan(&pb.ID, &pb.BatchNumber, &pb.CurrentQuantity, &pb.CurrentTemperature, &pb.DueDate, &pb.InitialQuantity, &pb.ManufacturingDate, &pb.ManufacturingHour, &pb.MinimumTemperature, &pb.SectionId, &pb.ProductId)
+}
+
+// queryIn runs query with its IN list expanded to the given keys and scans
+// every row with scan. An empty list reads nothing.
+func queryIn[T any](ctx context.Context, r *repository, query string, keys []int, scan func(*sql.Rows, *T) error) ([]T, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	args := make([]interface{}, 0, len(keys)+1)
+	args = append(args, tenant.Filter(ctx))
+	for _, k := range keys {
+		args = append(args, k)
+	}
+	query = fmt.Sprintf(query, strings.TrimSuffix(strings.Repeat("?, ", len(keys)), ", "))
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	return scanAll(rows, scan)
+}
+
+func scanAll[T any](rows *sql.Rows, scan func(*sql.Rows, *T) error) ([]T, error) {
+	defer rows.Close()
+
+	var items []T
+	for rows.Next() {
+		var item T
+		if err := scan(rows, &item); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	return items, nil
+}
+
+// parseDateTime parses a DATETIME column, which the driver returns as text
+// unless the DSN sets parseTime.
+func parseDateTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.DateTime, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
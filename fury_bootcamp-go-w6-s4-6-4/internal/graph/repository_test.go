@@ -0,0 +1,74 @@
+package graph
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSellers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	repo := NewRepository(database.NewRouter(db, nil, logger.Discard()))
+
+	t.Run("should read every key with one query", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "telephone", "locality_id"}).
+			AddRow(1, 10, "Meli", "Street 1", "555", 1).
+			AddRow(3, 30, "Libre", "Street 3", "557", 2)
+		mock.ExpectQuery(regexp.QuoteMeta("FROM seller WHERE site_id = COALESCE(?, site_id) AND id IN (?, ?, ?)")).WithArgs("MLA", 1, 2, 3).WillReturnRows(rows)
+
+		sellers, err := repo.Sellers(tenant.NewContext(context.Background(), "MLA"), []int{1, 2, 3})
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Seller{
+			{ID: 1, CID: 10, CompanyName: "Meli", Address: "Street 1", Telephone: "555", LocalityID: 1},
+			{ID: 3, CID: 30, CompanyName: "Libre", Address: "Street 3", Telephone: "557", LocalityID: 2},
+		}, sellers)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should not query without keys", func(t *testing.T) {
+		sellers, err := repo.Sellers(context.Background(), nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, sellers)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should fail when the query fails", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("FROM seller WHERE site_id = COALESCE(?, site_id) AND id IN (?)")).WillReturnError(sqlmock.ErrCancelled)
+
+		_, err := repo.Sellers(context.Background(), []int{1})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPurchaseOrders(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	repo := NewRepository(database.NewRouter(db, nil, logger.Discard()))
+
+	rows := sqlmock.NewRows([]string{"id", "order_number", "order_date", "tracking_code", "buyers_id", "product_records_id", "order_status_id"}).
+		AddRow(1, "PO-1", "2024-01-02 10:30:00", "T-1", 1, 1, 2).
+		AddRow(2, "PO-2", nil, "T-2", 1, 1, nil)
+	mock.ExpectQuery(regexp.QuoteMeta(GET_PURCHASE_ORDERS)).WithArgs(nil).WillReturnRows(rows)
+
+	orders, err := repo.PurchaseOrders(tenant.AllSites(context.Background()))
+
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	assert.Equal(t, time.Date(2024, 1, 2, 10, 30, 0, 0, time.UTC), *orders[0].OrderDate)
+	assert.Equal(t, 2, orders[0].OrderStatusID)
+	assert.Nil(t, orders[1].OrderDate)
+	assert.Zero(t, orders[1].OrderStatusID)
+}
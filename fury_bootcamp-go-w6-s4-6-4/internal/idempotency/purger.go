@@ -0,0 +1,46 @@
+package idempotency
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/health"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+)
+
+// Purger deletes the expired keys, which are otherwise only replaced when
+// the same client reuses them.
+type Purger struct {
+	repo   Repository
+	logger *slog.Logger
+
+	heartbeat *health.Heartbeat
+}
+
+func NewPurger(repo Repository, logger *slog.Logger) *Purger {
+	return &Purger{
+		repo:      repo,
+		logger:    logger,
+		heartbeat: health.NewHeartbeat("idempotency key purger"),
+	}
+}
+
+// Run purges the keys of every site right away and then every interval,
+// until ctx is done.
+func (p *Purger) Run(ctx context.Context, interval time.Duration) {
+	p.heartbeat.Run(tenant.AllSites(ctx), interval, func(ctx context.Context) {
+		purged, err := p.repo.Purge(ctx, time.Now().UTC())
+		if err != nil {
+			p.logger.ErrorContext(ctx, "purging idempotency keys", "error", err)
+		} else if purged > 0 {
+			p.logger.DebugContext(ctx, "idempotency keys purged", "count", purged)
+		}
+	})
+}
+
+// Healthy reports an error when Run isn't purging, either because it never
+// started or because a round has taken over two intervals.
+func (p *Purger) Healthy(ctx context.Context) error {
+	return p.heartbeat.Check(ctx)
+}
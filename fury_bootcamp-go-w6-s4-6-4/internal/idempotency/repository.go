@@ -0,0 +1,114 @@
+// Package idempotency keeps the responses to the requests made with an
+// Idempotency-Key in the database, so every instance replays them.
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+)
+
+// Repository is the web.IdempotencyStore of the API.
+type Repository interface {
+	web.IdempotencyStore
+	// Purge deletes the keys expired at now and returns how many there
+	// were.
+	Purge(ctx context.Context, now time.Time) (int64, error)
+}
+
+type repository struct {
+	db     *database.Router
+	stmts  *database.Statements
+	logger *slog.Logger
+}
+
+func NewRepository(db *database.Router, stmts *database.Statements, logger *slog.Logger) Repository {
+	stmts.Register(DELETE_EXPIRED_KEY, CLAIM_KEY, COMPLETE_KEY, RELEASE_KEY, PURGE_KEYS)
+	return &repository{
+		db:     db,
+		stmts:  stmts,
+		logger: logger,
+	}
+}
+
+const (
+	DELETE_EXPIRED_KEY = "DELETE FROM idempotency_keys WHERE client=? AND idempotency_key=? AND expires_at <= ?"
+	CLAIM_KEY          = "INSERT INTO idempotency_keys (client, idempotency_key, request_hash, expires_at) VALUES (?, ?, ?, ?)"
+	GET_KEY            = "SELECT request_hash, status, content_type, body FROM idempotency_keys WHERE client=? AND idempotency_key=?"
+	COMPLETE_KEY       = "UPDATE idempotency_keys SET status=?, content_type=?, body=? WHERE client=? AND idempotency_key=? AND request_hash=?"
+	RELEASE_KEY        = "DELETE FROM idempotency_keys WHERE client=? AND idempotency_key=? AND status IS NULL"
+	PURGE_KEYS         = "DELETE FROM idempotency_keys WHERE expires_at <= ?"
+)
+
+func (r *repository) Claim(ctx context.Context, client, key, requestHash string, now, expiresAt time.Time) (web.IdempotentResponse, bool, error) {
+	// An expired key is up for grabs, as if it had never been used.
+	if err := r.exec(ctx, DELETE_EXPIRED_KEY, client, key, now); err != nil {
+		return web.IdempotentResponse{}, false, err
+	}
+	err := r.exec(ctx, CLAIM_KEY, client, key, requestHash, expiresAt)
+	if err == nil {
+		return web.IdempotentResponse{}, true, nil
+	}
+	if !errors.Is(err, apperrors.ErrConflict) {
+		return web.IdempotentResponse{}, false, err
+	}
+
+	// The key is taken. Reading from the writer sees the claim even if it
+	// hasn't reached the replicas.
+	var stored web.IdempotentResponse
+	var status sql.NullInt64
+	var contentType sql.NullString
+	err = r.db.Writer().QueryRowContext(ctx, GET_KEY, client, key).Scan(&stored.RequestHash, &status, &contentType, &stored.Body)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Released in between: the request that had it failed, so it's
+		// as good as in progress for this one, which can retry.
+		return web.IdempotentResponse{RequestHash: requestHash}, false, nil
+	}
+	if err != nil {
+		return web.IdempotentResponse{}, false, apperrors.FromDB(err)
+	}
+	stored.Status = int(status.Int64)
+	stored.ContentType = contentType.String
+	return stored, false, nil
+}
+
+func (r *repository) Complete(ctx context.Context, client, key string, resp web.IdempotentResponse) error {
+	return r.exec(ctx, COMPLETE_KEY, resp.Status, resp.ContentType, resp.Body, client, key, resp.RequestHash)
+}
+
+func (r *repository) Release(ctx context.Context, client, key string) error {
+	return r.exec(ctx, RELEASE_KEY, client, key)
+}
+
+func (r *repository) Purge(ctx context.Context, now time.Time) (int64, error) {
+	stmt, err := r.stmts.Stmt(ctx, PURGE_KEYS)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	res, err := stmt.ExecContext(ctx, now)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	purged, err := res.RowsAffected()
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	return purged, nil
+}
+
+func (r *repository) exec(ctx context.Context, query string, args ...interface{}) error {
+	stmt, err := r.stmts.Stmt(ctx, query)
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	if _, err := stmt.ExecContext(ctx, args...); err != nil {
+		return apperrors.FromDB(err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package idempotency
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaim(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(time.Hour)
+
+	newRepository := func(t *testing.T) (Repository, sqlmock.Sqlmock) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		mock.ExpectPrepare(regexp.QuoteMeta(DELETE_EXPIRED_KEY)).
+			ExpectExec().WithArgs("web", "k1", now).WillReturnResult(sqlmock.NewResult(0, 0))
+		return repo, mock
+	}
+
+	t.Run("should claim a free key", func(t *testing.T) {
+		repo, mock := newRepository(t)
+		mock.ExpectPrepare(regexp.QuoteMeta(CLAIM_KEY)).
+			ExpectExec().WithArgs("web", "k1", "hash", expiresAt).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		_, claimed, err := repo.Claim(context.Background(), "web", "k1", "hash", now, expiresAt)
+
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should return what's kept for a taken key", func(t *testing.T) {
+		repo, mock := newRepository(t)
+		mock.ExpectPrepare(regexp.QuoteMeta(CLAIM_KEY)).
+			ExpectExec().WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+		mock.ExpectQuery(regexp.QuoteMeta(GET_KEY)).WithArgs("web", "k1").
+			WillReturnRows(sqlmock.NewRows([]string{"request_hash", "status", "content_type", "body"}).
+				AddRow("hash", 201, "application/json", []byte(`{"data":{"id":1}}`)))
+
+		stored, claimed, err := repo.Claim(context.Background(), "web", "k1", "hash", now, expiresAt)
+
+		assert.NoError(t, err)
+		assert.False(t, claimed)
+		assert.Equal(t, web.IdempotentResponse{
+			RequestHash: "hash",
+			Status:      201,
+			ContentType: "application/json",
+			Body:        []byte(`{"data":{"id":1}}`),
+		}, stored)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should report a key in progress", func(t *testing.T) {
+		repo, mock := newRepository(t)
+		mock.ExpectPrepare(regexp.QuoteMeta(CLAIM_KEY)).
+			ExpectExec().WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+		mock.ExpectQuery(regexp.QuoteMeta(GET_KEY)).WithArgs("web", "k1").
+			WillReturnRows(sqlmock.NewRows([]string{"request_hash", "status", "content_type", "body"}).
+				AddRow("hash", nil, nil, nil))
+
+		stored, claimed, err := repo.Claim(context.Background(), "web", "k1", "hash", now, expiresAt)
+
+		assert.NoError(t, err)
+		assert.False(t, claimed)
+		assert.Equal(t, web.IdempotentResponse{RequestHash: "hash"}, stored)
+	})
+}
@@ -0,0 +1,52 @@
+package inboundorder
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/cache"
+)
+
+// discrepancyTags are the tables the discrepancy report goes through.
+var discrepancyTags = []string{"inbound_orders", "products"}
+
+type cachedService struct {
+	Service
+	cache *cache.Cache
+}
+
+// WithCache returns s with the discrepancy report cached in c, and new
+// inbound orders and receipts invalidating it and the inbound orders report
+// of the employees. A nil c leaves s as is.
+func WithCache(s Service, c *cache.Cache) Service {
+	if c == nil {
+		return s
+	}
+	return &cachedService{Service: s, cache: c}
+}
+
+func (s *cachedService) Save(ctx context.Context, order_date string, order_number string, employee_id int, product_batch_id int, wharehouse_id int, lines []domain.InboundOrderLine) (domain.Inbound_order, error) {
+	v, err := s.Service.Save(ctx, order_date, order_number, employee_id, product_batch_id, wharehouse_id, lines)
+	if err == nil {
+		s.cache.Invalidate(ctx, "inbound_orders")
+	}
+	return v, err
+}
+
+// Receive also invalidates the reports of the stock, as receipts create and
+// credit batches.
+func (s *cachedService) Receive(ctx context.Context, id int, lines []domain.InboundReceiptLine) (domain.InboundReceipt, error) {
+	v, err := s.Service.Receive(ctx, id, lines)
+	if err == nil {
+		s.cache.Invalidate(ctx, "inbound_orders")
+		s.cache.Invalidate(ctx, "product_batches")
+	}
+	return v, err
+}
+
+func (s *cachedService) ReportDiscrepancies(ctx context.Context, id int) ([]domain.InboundDiscrepancyReport, error) {
+	return cache.Load(ctx, s.cache, "inbound_orders.discrepancies:"+strconv.Itoa(id), discrepancyTags, func(ctx context.Context) ([]domain.InboundDiscrepancyReport, error) {
+		return s.Service.ReportDiscrepancies(ctx, id)
+	})
+}
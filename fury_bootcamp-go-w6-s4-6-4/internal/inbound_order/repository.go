@@ -3,76 +3,369 @@ package inboundorder
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/warehouse"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 )
 
 type Repository interface {
 	GetAll(ctx context.Context) ([]domain.Inbound_order, error)
 	Save(ctx context.Context, b_order domain.Inbound_order) (int, error)
-	ExistsEmployee(ctx context.Context, id_employee int) bool
-	ExistsInboundOrder(ctx context.Context, order_number string) bool
+	ExistsInboundOrder(ctx context.Context, order_number string) (bool, error)
+	ExistsWarehouse(ctx context.Context, id_warehouse int) (bool, error)
+	// EmployeeWarehouse returns the warehouse the employee works at, and
+	// false when there's no such employee.
+	EmployeeWarehouse(ctx context.Context, id_employee int) (int, bool, error)
+	// ProductBatchWarehouse returns the warehouse of the section the batch
+	// is stored in, and false when there's no such batch.
+	ProductBatchWarehouse(ctx context.Context, id_product_batch int) (int, bool, error)
+	// ProductBatchProduct and ProductBatchSection return the product and
+	// the section of the batch, and false when there's no such batch.
+	ProductBatchProduct(ctx context.Context, id_product_batch int) (int, bool, error)
+	ProductBatchSection(ctx context.Context, id_product_batch int) (int, bool, error)
+	// SectionWarehouse returns the warehouse of the section, and false when
+	// there's no such section.
+	SectionWarehouse(ctx context.Context, id_section int) (int, bool, error)
+	ExistsProduct(ctx context.Context, id_product int) (bool, error)
+
+	// Get returns the order id with its lines, as written so far in the
+	// transaction of ctx when it runs in one.
+	Get(ctx context.Context, id int) (domain.Inbound_order, error)
+	// SaveLines stores the lines of the order id and returns their ids.
+	SaveLines(ctx context.Context, id int, lines []domain.InboundOrderLine) ([]int, error)
+	// LockLines returns the lines of the order id, locked until the
+	// transaction of ctx ends so receipts of the order run one at a time.
+	LockLines(ctx context.Context, id int) ([]domain.InboundOrderLine, error)
+	// SaveReceipt stores the receipt and its lines and returns its id.
+	SaveReceipt(ctx context.Context, receipt domain.InboundReceipt) (int, error)
+	// UpdateReceived stores the received quantity of the lines and the
+	// status of the order id.
+	UpdateReceived(ctx context.Context, id int, lines []domain.InboundOrderLine, status string) error
+	// CreateProductBatch stores a batch received and returns its id.
+	CreateProductBatch(ctx context.Context, pb domain.Product_batches) (int, error)
+	// CreditProductBatch adds quantity to the current quantity of the batch.
+	CreditProductBatch(ctx context.Context, id_product_batch int, quantity int) error
+	// ReportDiscrepancies sums the lines received of every seller, or only
+	// of the seller id unless it's 0.
+	ReportDiscrepancies(ctx context.Context, id int) ([]domain.InboundDiscrepancyReport, error)
 }
 
 type repository struct {
-	db *sql.DB
+	*warehouse.Assignments
+	db     *database.Router
+	stmts  *database.Statements
+	logger *slog.Logger
 }
 
-func NewRepository(db *sql.DB) Repository {
+func NewRepository(db *database.Router, stmts *database.Statements, logger *slog.Logger) Repository {
+	stmts.Register(SAVE, SAVE_LINE, LOCK_LINES, SAVE_RECEIPT, SAVE_RECEIPT_LINE, UPDATE_LINE, UPDATE_STATUS, CREATE_PRODUCT_BATCH, CREDIT_PRODUCT_BATCH)
 	return &repository{
-		db: db,
+		Assignments: warehouse.NewAssignments(db),
+		db:          db,
+		stmts:       stmts,
+		logger:      logger,
 	}
 }
 
 const (
-	GET_ALL        = "SELECT id, order_date, order_number, employee_id, warehouse_id, product_batch_id FROM inbound_orders"
-	SAVE           = "INSERT INTO inbound_orders(order_date,order_number,employee_id,product_batch_id,warehouse_id) VALUES (?,?,?,?,?)"
-	EXIST_EMPLOYEE = "SELECT id FROM employees WHERE id=?"
-	EXIST_INBOUND  = "SELECT order_number FROM inbound_orders WHERE order_number=?"
+	GET_ALL         = "SELECT id, site_id, order_date, order_number, employee_id, COALESCE(product_batch_id, 0), warehouse_id, status FROM inbound_orders WHERE site_id = COALESCE(?, site_id)"
+	GET             = "SELECT id, site_id, order_date, order_number, employee_id, COALESCE(product_batch_id, 0), warehouse_id, status FROM inbound_orders WHERE id=? AND site_id = COALESCE(?, site_id)"
+	SAVE            = "INSERT INTO inbound_orders(site_id,order_date,order_number,employee_id,product_batch_id,warehouse_id,status) VALUES (?,?,?,?,?,?,?)"
+	EXIST_INBOUND   = "SELECT order_number FROM inbound_orders WHERE order_number=? AND site_id = COALESCE(?, site_id)"
+	EXIST_WAREHOUSE = "SELECT id FROM warehouses WHERE id=? AND site_id = COALESCE(?, site_id)"
+
+	PRODUCT_BATCH_WAREHOUSE = "SELECT s.warehouse_id FROM product_batches pb JOIN sections s ON s.site_id = pb.site_id AND s.id = pb.sections_id " +
+		"WHERE pb.id=? AND pb.site_id = COALESCE(?, pb.site_id)"
+	PRODUCT_BATCH_PRODUCT = "SELECT products_id FROM product_batches WHERE id=? AND site_id = COALESCE(?, site_id)"
+	PRODUCT_BATCH_SECTION = "SELECT sections_id FROM product_batches WHERE id=? AND site_id = COALESCE(?, site_id)"
+	EXIST_PRODUCT         = "SELECT id FROM products WHERE id=? AND site_id = COALESCE(?, site_id)"
+
+	GET_LINES     = "SELECT id, products_id, COALESCE(batch_number, 0), expected_quantity, received_quantity FROM inbound_order_lines WHERE inbound_order_id=? AND site_id = COALESCE(?, site_id) ORDER BY id"
+	LOCK_LINES    = GET_LINES + " FOR UPDATE"
+	SAVE_LINE     = "INSERT INTO inbound_order_lines(site_id,inbound_order_id,products_id,batch_number,expected_quantity,received_quantity) VALUES (?,?,?,?,?,0)"
+	UPDATE_LINE   = "UPDATE inbound_order_lines SET received_quantity=? WHERE id=? AND site_id = COALESCE(?, site_id)"
+	UPDATE_STATUS = "UPDATE inbound_orders SET status=? WHERE id=? AND site_id = COALESCE(?, site_id)"
+
+	SAVE_RECEIPT      = "INSERT INTO inbound_order_receipts(site_id,inbound_order_id,received_at) VALUES (?,?,?)"
+	SAVE_RECEIPT_LINE = "INSERT INTO inbound_order_receipt_lines(site_id,receipt_id,line_id,product_batch_id,quantity) VALUES (?,?,?,?,?)"
+
+	CREATE_PRODUCT_BATCH = "INSERT INTO product_batches(site_id,batch_number,current_quantity,initial_quantity,manufacturing_date,due_date,sections_id,products_id) VALUES (?,?,?,?,?,?,?,?)"
+	CREDIT_PRODUCT_BATCH = "UPDATE product_batches SET current_quantity = COALESCE(current_quantity, 0) + ? WHERE id=? AND site_id = COALESCE(?, site_id)"
+
+	// Only the orders received, at least in part, have discrepancies; the
+	// missing and excess quantities are summed per line.
+	REPORT_DISCREPANCIES = "SELECT p.seller_id, COUNT(DISTINCT io.id), SUM(l.expected_quantity), SUM(l.received_quantity), " +
+		"SUM(GREATEST(l.expected_quantity - l.received_quantity, 0)), SUM(GREATEST(l.received_quantity - l.expected_quantity, 0)) " +
+		"FROM inbound_order_lines l JOIN inbound_orders io ON io.site_id = l.site_id AND io.id = l.inbound_order_id " +
+		"JOIN products p ON p.site_id = l.site_id AND p.id = l.products_id " +
+		"WHERE io.status <> 'open' AND (? = 0 OR p.seller_id = ?) AND l.site_id = COALESCE(?, l.site_id) GROUP BY p.seller_id ORDER BY p.seller_id"
 )
 
 func (r *repository) GetAll(ctx context.Context) ([]domain.Inbound_order, error) {
-	rows, err := r.db.Query(GET_ALL)
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, GET_ALL, tenant.Filter(ctx))
 	if err != nil {
-		return nil, err
+		return nil, apperrors.FromDB(err)
 	}
+	defer rows.Close()
 
 	var inbound_orders []domain.Inbound_order
 
 	for rows.Next() {
 		inborder := domain.Inbound_order{}
-		_ = rows.Scan(&inborder.ID, &inborder.Order_date, &inborder.Order_number, &inborder.Employee_id, &inborder.Product_batch_id, &inborder.Warehouse_id)
+		if err := rows.Scan(&inborder.ID, &inborder.SiteID, &inborder.Order_date, &inborder.Order_number, &inborder.Employee_id, &inborder.Product_batch_id, &inborder.Warehouse_id, &inborder.Status); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
 		inbound_orders = append(inbound_orders, inborder)
 	}
 
-	return inbound_orders, nil
+	return inbound_orders, apperrors.FromDB(rows.Err())
+}
+
+func (r *repository) ExistsWarehouse(ctx context.Context, id_warehouse int) (bool, error) {
+	_, ok, err := r.lookup(ctx, EXIST_WAREHOUSE, id_warehouse)
+	return ok, err
+}
+
+func (r *repository) ExistsProduct(ctx context.Context, id_product int) (bool, error) {
+	_, ok, err := r.lookup(ctx, EXIST_PRODUCT, id_product)
+	return ok, err
+}
+
+func (r *repository) ProductBatchWarehouse(ctx context.Context, id_product_batch int) (int, bool, error) {
+	return r.lookup(ctx, PRODUCT_BATCH_WAREHOUSE, id_product_batch)
 }
 
-func (r *repository) ExistsEmployee(ctx context.Context, id_employee int) bool {
-	row := r.db.QueryRow(EXIST_EMPLOYEE, id_employee)
-	err := row.Scan(&id_employee)
-	return err == nil
+func (r *repository) ProductBatchProduct(ctx context.Context, id_product_batch int) (int, bool, error) {
+	return r.lookup(ctx, PRODUCT_BATCH_PRODUCT, id_product_batch)
 }
 
-func (r *repository) ExistsInboundOrder(ctx context.Context, order_number string) bool {
-	row := r.db.QueryRow(EXIST_INBOUND, order_number)
-	err := row.Scan(&order_number)
-	return err == nil
+func (r *repository) ProductBatchSection(ctx context.Context, id_product_batch int) (int, bool, error) {
+	return r.lookup(ctx, PRODUCT_BATCH_SECTION, id_product_batch)
+}
+
+// lookup runs a query for an id the row id refers to, or for the id itself
+// when checking it exists. It's false when there's no row; any other
+// failure is returned, so an outage isn't taken for a missing reference.
+func (r *repository) lookup(ctx context.Context, query string, id int) (int, bool, error) {
+	var found int
+	err := r.db.Writer().QueryRowContext(ctx, query, id, tenant.Filter(ctx)).Scan(&found)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, apperrors.FromDB(err)
+	}
+	return found, true, nil
+}
+
+func (r *repository) ExistsInboundOrder(ctx context.Context, order_number string) (bool, error) {
+	err := r.db.Writer().QueryRowContext(ctx, EXIST_INBOUND, order_number, tenant.Filter(ctx)).Scan(&order_number)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, apperrors.FromDB(err)
+	}
+	return true, nil
 }
 func (r *repository) Save(ctx context.Context, b_order domain.Inbound_order) (int, error) {
-	stmt, err := r.db.Prepare(SAVE)
+	site, err := tenant.Site(ctx)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(&b_order.Order_date, &b_order.Order_number, &b_order.Employee_id, &b_order.Product_batch_id, &b_order.Warehouse_id)
+	stmt, err := r.stmts.Stmt(ctx, SAVE)
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
+	}
+
+	batch := sql.NullInt64{Int64: int64(b_order.Product_batch_id), Valid: b_order.Product_batch_id != 0}
+	res, err := stmt.ExecContext(ctx, site, &b_order.Order_date, &b_order.Order_number, &b_order.Employee_id, batch, &b_order.Warehouse_id, b_order.Status)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
 	}
 	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+
+	return int(id), nil
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.Inbound_order, error) {
+	var o domain.Inbound_order
+	err := r.db.QueryRow(ctx, GET, id, tenant.Filter(ctx)).
+		Scan(&o.ID, &o.SiteID, &o.Order_date, &o.Order_number, &o.Employee_id, &o.Product_batch_id, &o.Warehouse_id, &o.Status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Inbound_order{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.Inbound_order{}, apperrors.FromDB(err)
+	}
+
+	rows, err := r.db.Query(ctx, GET_LINES, id, tenant.Filter(ctx))
+	if err != nil {
+		return domain.Inbound_order{}, apperrors.FromDB(err)
+	}
+	o.Lines, err = scanLines(rows)
+	if err != nil {
+		return domain.Inbound_order{}, err
+	}
+	return o, nil
+}
+
+func (r *repository) LockLines(ctx context.Context, id int) ([]domain.InboundOrderLine, error) {
+	stmt, err := r.stmts.Stmt(ctx, LOCK_LINES)
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	rows, err := stmt.QueryContext(ctx, id, tenant.Filter(ctx))
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	return scanLines(rows)
+}
+
+func scanLines(rows *sql.Rows) ([]domain.InboundOrderLine, error) {
+	defer rows.Close()
+	lines := []domain.InboundOrderLine{}
+	for rows.Next() {
+		var l domain.InboundOrderLine
+		if err := rows.Scan(&l.ID, &l.ProductID, &l.BatchNumber, &l.ExpectedQuantity, &l.ReceivedQuantity); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		lines = append(lines, l)
+	}
+	return lines, apperrors.FromDB(rows.Err())
+}
+
+func (r *repository) SaveLines(ctx context.Context, id int, lines []domain.InboundOrderLine) ([]int, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := r.stmts.Stmt(ctx, SAVE_LINE)
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+
+	ids := make([]int, len(lines))
+	for i, l := range lines {
+		batchNumber := sql.NullInt64{Int64: int64(l.BatchNumber), Valid: l.BatchNumber != 0}
+		res, err := stmt.ExecContext(ctx, site, id, l.ProductID, batchNumber, l.ExpectedQuantity)
+		if err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		lineID, err := res.LastInsertId()
+		if err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		ids[i] = int(lineID)
+	}
+	return ids, nil
+}
+
+func (r *repository) SaveReceipt(ctx context.Context, receipt domain.InboundReceipt) (int, error) {
+	site, err := tenant.Site(ctx)
 	if err != nil {
 		return 0, err
 	}
+	stmt, err := r.stmts.Stmt(ctx, SAVE_RECEIPT)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	res, err := stmt.ExecContext(ctx, site, receipt.InboundOrderID, time.Now().UTC())
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+
+	stmt, err = r.stmts.Stmt(ctx, SAVE_RECEIPT_LINE)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	for _, l := range receipt.Lines {
+		if _, err := stmt.ExecContext(ctx, site, id, l.LineID, l.ProductBatchID, l.Quantity); err != nil {
+			return 0, apperrors.FromDB(err)
+		}
+	}
+	return int(id), nil
+}
+
+func (r *repository) UpdateReceived(ctx context.Context, id int, lines []domain.InboundOrderLine, status string) error {
+	stmt, err := r.stmts.Stmt(ctx, UPDATE_LINE)
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	for _, l := range lines {
+		if _, err := stmt.ExecContext(ctx, l.ReceivedQuantity, l.ID, tenant.Filter(ctx)); err != nil {
+			return apperrors.FromDB(err)
+		}
+	}
 
+	stmt, err = r.stmts.Stmt(ctx, UPDATE_STATUS)
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	_, err = stmt.ExecContext(ctx, status, id, tenant.Filter(ctx))
+	return apperrors.FromDB(err)
+}
+
+func (r *repository) CreateProductBatch(ctx context.Context, pb domain.Product_batches) (int, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return 0, err
+	}
+	stmt, err := r.stmts.Stmt(ctx, CREATE_PRODUCT_BATCH)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	batchNumber := sql.NullInt64{Int64: int64(pb.BatchNumber), Valid: pb.BatchNumber != 0}
+	manufacturingDate := sql.NullString{String: pb.ManufacturingDate, Valid: pb.ManufacturingDate != ""}
+	dueDate := sql.NullString{String: pb.DueDate, Valid: pb.DueDate != ""}
+	res, err := stmt.ExecContext(ctx, site, batchNumber, pb.CurrentQuantity, pb.InitialQuantity, manufacturingDate, dueDate, pb.SectionId, pb.ProductId)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
 	return int(id), nil
 }
+
+func (r *repository) CreditProductBatch(ctx context.Context, id_product_batch int, quantity int) error {
+	stmt, err := r.stmts.Stmt(ctx, CREDIT_PRODUCT_BATCH)
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	_, err = stmt.ExecContext(ctx, quantity, id_product_batch, tenant.Filter(ctx))
+	return apperrors.FromDB(err)
+}
+
+func (r *repository) ReportDiscrepancies(ctx context.Context, id int) ([]domain.InboundDiscrepancyReport, error) {
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, REPORT_DISCREPANCIES, id, id, tenant.Filter(ctx))
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+
+	reports := []domain.InboundDiscrepancyReport{}
+	for rows.Next() {
+		var d domain.InboundDiscrepancyReport
+		if err := rows.Scan(&d.SellerID, &d.OrdersCount, &d.ExpectedQuantity, &d.ReceivedQuantity, &d.MissingQuantity, &d.ExcessQuantity); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		reports = append(reports, d)
+	}
+	return reports, apperrors.FromDB(rows.Err())
+}
@@ -2,15 +2,21 @@ package inboundorder
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 	"github.com/stretchr/testify/assert"
 )
 
+var ctx = tenant.NewContext(context.TODO(), "MLA")
+
 func Test_Create_Ok(t *testing.T) {
 	//Arrange
 	bo := domain.Inbound_order{
@@ -20,19 +26,20 @@ func Test_Create_Ok(t *testing.T) {
 		Employee_id:      4,
 		Product_batch_id: 1,
 		Warehouse_id:     1,
+		Status:           domain.InboundOrderClosed,
 	}
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
 	mock.ExpectPrepare(regexp.QuoteMeta(SAVE))
 	mock.ExpectExec(regexp.QuoteMeta(SAVE)).
-		WithArgs(bo.Order_date, bo.Order_number, bo.Employee_id, bo.Product_batch_id, bo.Warehouse_id).
+		WithArgs("MLA", bo.Order_date, bo.Order_number, bo.Employee_id, sql.NullInt64{Int64: 1, Valid: true}, bo.Warehouse_id, bo.Status).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	repository := NewRepository(db)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	id, err := repository.Save(context.TODO(), bo)
+	id, err := repository.Save(ctx, bo)
 
 	//Assert
 	assert.NoError(t, err)
@@ -49,19 +56,20 @@ func Test_Create_Fail(t *testing.T) {
 		Employee_id:      4,
 		Product_batch_id: 1,
 		Warehouse_id:     1,
+		Status:           domain.InboundOrderClosed,
 	}
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
 	mock.ExpectPrepare(regexp.QuoteMeta(SAVE))
 	mock.ExpectExec(regexp.QuoteMeta(SAVE)).
-		WithArgs(bo.Order_date, bo.Order_number, bo.Employee_id, bo.Product_batch_id, bo.Warehouse_id).
+		WithArgs("MLA", bo.Order_date, bo.Order_number, bo.Employee_id, sql.NullInt64{Int64: 1, Valid: true}, bo.Warehouse_id, bo.Status).
 		WillReturnError(errors.New("INSERT ERROR"))
 
-	repository := NewRepository(db)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	id, err := repository.Save(context.TODO(), bo)
+	id, err := repository.Save(ctx, bo)
 
 	t.Log(err)
 	//Assert
@@ -75,41 +83,47 @@ func Test_GetAll_Ok(t *testing.T) {
 	data := []domain.Inbound_order{
 		{
 			ID:               1,
+			SiteID:           "MLA",
 			Order_date:       "2021-04-04",
 			Order_number:     "order#1",
 			Employee_id:      4,
 			Product_batch_id: 1,
 			Warehouse_id:     1,
+			Status:           domain.InboundOrderClosed,
 		},
 		{
 			ID:               2,
+			SiteID:           "MLA",
 			Order_date:       "2021-04-06",
 			Order_number:     "order#2",
 			Employee_id:      4,
 			Product_batch_id: 1,
 			Warehouse_id:     1,
+			Status:           domain.InboundOrderClosed,
 		},
 		{
 			ID:               3,
+			SiteID:           "MLA",
 			Order_date:       "2021-04-07",
 			Order_number:     "order#3",
 			Employee_id:      4,
 			Product_batch_id: 1,
 			Warehouse_id:     1,
+			Status:           domain.InboundOrderClosed,
 		},
 	}
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
-	row := sqlmock.NewRows([]string{"id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id"})
+	row := sqlmock.NewRows([]string{"id", "site_id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id", "status"})
 	for _, bo := range data {
-		row.AddRow(bo.ID, bo.Order_date, bo.Order_number, bo.Employee_id, bo.Product_batch_id, bo.Warehouse_id)
+		row.AddRow(bo.ID, bo.SiteID, bo.Order_date, bo.Order_number, bo.Employee_id, bo.Product_batch_id, bo.Warehouse_id, bo.Status)
 	}
-	mock.ExpectQuery(regexp.QuoteMeta(GET_ALL)).WillReturnRows(row)
-	repository := NewRepository(db)
+	mock.ExpectQuery(regexp.QuoteMeta(GET_ALL)).WithArgs("MLA").WillReturnRows(row)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	result, err := repository.GetAll(context.TODO())
+	result, err := repository.GetAll(ctx)
 
 	//Assert
 	assert.NoError(t, err)
@@ -122,41 +136,47 @@ func Test_GetAll_Fail(t *testing.T) {
 	data := []domain.Inbound_order{
 		{
 			ID:               1,
+			SiteID:           "MLA",
 			Order_date:       "2021-04-04",
 			Order_number:     "order#1",
 			Employee_id:      4,
 			Product_batch_id: 1,
 			Warehouse_id:     1,
+			Status:           domain.InboundOrderClosed,
 		},
 		{
 			ID:               2,
+			SiteID:           "MLA",
 			Order_date:       "2021-04-06",
 			Order_number:     "order#2",
 			Employee_id:      4,
 			Product_batch_id: 1,
 			Warehouse_id:     1,
+			Status:           domain.InboundOrderClosed,
 		},
 		{
 			ID:               3,
+			SiteID:           "MLA",
 			Order_date:       "2021-04-07",
 			Order_number:     "order#3",
 			Employee_id:      4,
 			Product_batch_id: 1,
 			Warehouse_id:     1,
+			Status:           domain.InboundOrderClosed,
 		},
 	}
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
-	row := sqlmock.NewRows([]string{"id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id"})
+	row := sqlmock.NewRows([]string{"id", "site_id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id", "status"})
 	for _, bo := range data {
-		row.AddRow(bo.ID, bo.Order_date, bo.Order_number, bo.Employee_id, bo.Product_batch_id, bo.Warehouse_id)
+		row.AddRow(bo.ID, bo.SiteID, bo.Order_date, bo.Order_number, bo.Employee_id, bo.Product_batch_id, bo.Warehouse_id, bo.Status)
 	}
-	mock.ExpectQuery(regexp.QuoteMeta(GET_ALL)).WillReturnError(errors.New("GET ALL ERROR"))
-	repository := NewRepository(db)
+	mock.ExpectQuery(regexp.QuoteMeta(GET_ALL)).WithArgs("MLA").WillReturnError(errors.New("GET ALL ERROR"))
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	result, err := repository.GetAll(context.TODO())
+	result, err := repository.GetAll(ctx)
 
 	//Assert
 	assert.NotNil(t, err)
@@ -164,40 +184,48 @@ func Test_GetAll_Fail(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func Test_ExistEmployee_Ok(t *testing.T) {
+func Test_ExistsWarehouse(t *testing.T) {
 	//Arrange
-	id := 1
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
 
-	row := sqlmock.NewRows([]string{"id"})
-	row.AddRow(id)
-	mock.ExpectQuery(regexp.QuoteMeta(EXIST_EMPLOYEE)).WithArgs(id).WillReturnRows(row)
-	repository := NewRepository(db)
+	mock.ExpectQuery(regexp.QuoteMeta(EXIST_WAREHOUSE)).WithArgs(1, "MLA").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(EXIST_WAREHOUSE)).WithArgs(9, "MLA").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	result := repository.ExistsEmployee(context.TODO(), id)
+	found, errFound := repository.ExistsWarehouse(ctx, 1)
+	missing, errMissing := repository.ExistsWarehouse(ctx, 9)
+
 	//Assert
-	assert.True(t, result)
+	assert.NoError(t, errFound)
+	assert.True(t, found)
+	assert.NoError(t, errMissing)
+	assert.False(t, missing)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func Test_ExistEmployee_Fail(t *testing.T) {
+func Test_ProductBatchWarehouse(t *testing.T) {
 	//Arrange
-	id := 3
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
 
-	row := sqlmock.NewRows([]string{"id"})
-	mock.ExpectQuery(regexp.QuoteMeta(EXIST_EMPLOYEE)).WithArgs(id).WillReturnRows(row)
-	repository := NewRepository(db)
+	mock.ExpectQuery(regexp.QuoteMeta(PRODUCT_BATCH_WAREHOUSE)).WithArgs(1, "MLA").WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta(PRODUCT_BATCH_WAREHOUSE)).WithArgs(9, "MLA").WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}))
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	result := repository.ExistsEmployee(context.TODO(), id)
+	warehouse, found, errFound := repository.ProductBatchWarehouse(ctx, 1)
+	_, missing, errMissing := repository.ProductBatchWarehouse(ctx, 9)
+
 	//Assert
-	assert.False(t, result)
+	assert.NoError(t, errFound)
+	assert.NoError(t, errMissing)
+	assert.True(t, found)
+	assert.Equal(t, 3, warehouse)
+	assert.False(t, missing)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -210,12 +238,13 @@ func Test_ExistsInboundOrder_Ok(t *testing.T) {
 
 	row := sqlmock.NewRows([]string{"order_number"})
 	row.AddRow(ordernumber)
-	mock.ExpectQuery(regexp.QuoteMeta(EXIST_INBOUND)).WithArgs(ordernumber).WillReturnRows(row)
-	repository := NewRepository(db)
+	mock.ExpectQuery(regexp.QuoteMeta(EXIST_INBOUND)).WithArgs(ordernumber, "MLA").WillReturnRows(row)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	result := repository.ExistsInboundOrder(context.TODO(), ordernumber)
+	result, err := repository.ExistsInboundOrder(ctx, ordernumber)
 	//Assert
+	assert.NoError(t, err)
 	assert.True(t, result)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -228,12 +257,83 @@ func Test_ExistsInboundOrder_Fail(t *testing.T) {
 	defer db.Close()
 
 	row := sqlmock.NewRows([]string{"order_number"})
-	mock.ExpectQuery(regexp.QuoteMeta(EXIST_INBOUND)).WithArgs(ordernumber).WillReturnRows(row)
-	repository := NewRepository(db)
+	mock.ExpectQuery(regexp.QuoteMeta(EXIST_INBOUND)).WithArgs(ordernumber, "MLA").WillReturnRows(row)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
 	//Act
-	result := repository.ExistsInboundOrder(context.TODO(), ordernumber)
+	result, err := repository.ExistsInboundOrder(ctx, ordernumber)
 	//Assert
+	assert.NoError(t, err)
 	assert.False(t, result)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func Test_Get(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		//Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(GET)).WithArgs(1, "MLA").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "site_id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id", "status"}).
+				AddRow(1, "MLA", "2021-04-04", "order#1", 4, 0, 1, domain.InboundOrderPartiallyReceived))
+		mock.ExpectQuery(regexp.QuoteMeta(GET_LINES)).WithArgs(1, "MLA").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "products_id", "batch_number", "expected_quantity", "received_quantity"}).
+				AddRow(1, 7, 12, 10, 4).
+				AddRow(2, 8, 0, 5, 0))
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+
+		//Act
+		result, err := repository.Get(ctx, 1)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Inbound_order{
+			ID: 1, SiteID: "MLA", Order_date: "2021-04-04", Order_number: "order#1", Employee_id: 4, Warehouse_id: 1,
+			Status: domain.InboundOrderPartiallyReceived,
+			Lines: []domain.InboundOrderLine{
+				{ID: 1, ProductID: 7, BatchNumber: 12, ExpectedQuantity: 10, ReceivedQuantity: 4},
+				{ID: 2, ProductID: 8, ExpectedQuantity: 5},
+			},
+		}, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("not found", func(t *testing.T) {
+		//Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(GET)).WithArgs(9, "MLA").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+
+		//Act
+		_, err = repository.Get(ctx, 9)
+
+		//Assert
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_ReportDiscrepancies(t *testing.T) {
+	//Arrange
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(REPORT_DISCREPANCIES)).WithArgs(2, 2, "MLA").WillReturnRows(
+		sqlmock.NewRows([]string{"seller_id", "orders_count", "expected", "received", "missing", "excess"}).AddRow(2, 3, 30, 27, 4, 1))
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+
+	//Act
+	result, err := repository.ReportDiscrepancies(ctx, 2)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.InboundDiscrepancyReport{
+		{SellerID: 2, OrdersCount: 3, ExpectedQuantity: 30, ReceivedQuantity: 27, MissingQuantity: 4, ExcessQuantity: 1},
+	}, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
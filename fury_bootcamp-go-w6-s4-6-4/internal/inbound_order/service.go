@@ -2,52 +2,313 @@ package inboundorder
 
 import (
 	"context"
-	"errors"
+	"log/slog"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 var (
-	ErrNotFound         = errors.New("Inbound_order not found")
-	ErrAlreadyExist     = errors.New("The order_number already exists")
-	ErrEmployeeNotExist = errors.New("The employee not exists")
+	ErrNotFound              = apperrors.NotFound("Inbound_order not found")
+	ErrAlreadyExist          = apperrors.Conflict("The order_number already exists")
+	ErrEmployeeNotExist      = apperrors.ForeignKeyViolation("The employee not exists")
+	ErrWarehouseNotExist     = apperrors.ForeignKeyViolation("The warehouse not exists")
+	ErrProductBatchNotExist  = apperrors.ForeignKeyViolation("The product_batch not exists")
+	ErrProductNotExist       = apperrors.ForeignKeyViolation("The product not exists")
+	ErrSectionNotExist       = apperrors.ForeignKeyViolation("The section not exists")
+	ErrEmployeeWarehouse     = apperrors.Validation("The employee works at a different warehouse")
+	ErrProductBatchWarehouse = apperrors.Validation("The product_batch is stored in a different warehouse")
+	ErrProductBatchRequired  = apperrors.Validation("The inbound order needs a product_batch_id or lines")
+	ErrNoLines               = apperrors.Validation("The inbound order has no lines to receive")
+	ErrLineNotExist          = apperrors.Validation("The line is not on the inbound order")
+	ErrReceiptTarget         = apperrors.Validation("Each line received needs a product_batch_id or a section_id")
+	ErrProductBatchProduct   = apperrors.Validation("The product_batch holds a different product than the line")
+	ErrSectionWarehouse      = apperrors.Validation("The section is in a different warehouse")
 )
 
 type Service interface {
 	GetAll_inboundOrders(ctx context.Context) ([]domain.Inbound_order, error)
-	Save(ctx context.Context, order_date string, order_number string, employee_id int, product_batch_id int, wharehouse_id int) (domain.Inbound_order, error)
+	Get(ctx context.Context, id int) (domain.Inbound_order, error)
+	// Save creates an order expecting lines, or one recording the batch
+	// product_batch_id, already received, when it has none.
+	Save(ctx context.Context, order_date string, order_number string, employee_id int, product_batch_id int, wharehouse_id int, lines []domain.InboundOrderLine) (domain.Inbound_order, error)
+	// Receive credits the quantities of lines to the lines of the order id
+	// and to the batches they're stored in, and updates its status.
+	Receive(ctx context.Context, id int, lines []domain.InboundReceiptLine) (domain.InboundReceipt, error)
+	ReportDiscrepancies(ctx context.Context, id int) ([]domain.InboundDiscrepancyReport, error)
 }
 
 type service struct {
 	repository Repository
+	outbox     events.Outbox
+	logger     *slog.Logger
 }
 
-func NewService(r Repository) Service {
-	return &service{repository: r}
+func NewService(r Repository, outbox events.Outbox, logger *slog.Logger) Service {
+	return &service{repository: r, outbox: outbox, logger: logger}
 }
 
 func (s *service) GetAll_inboundOrders(ctx context.Context) ([]domain.Inbound_order, error) {
 	return s.repository.GetAll(ctx)
 }
 
-func (s *service) Save(ctx context.Context, order_date string, order_number string, employee_id int, product_batch_id int, warehouse_id int) (domain.Inbound_order, error) {
-	if !s.repository.ExistsInboundOrder(ctx, order_number) {
-		if s.repository.ExistsEmployee(ctx, employee_id) {
-			newInBoundOrder := domain.Inbound_order{
-				Order_date:       order_date,
-				Order_number:     order_number,
-				Employee_id:      employee_id,
-				Product_batch_id: product_batch_id,
-				Warehouse_id:     warehouse_id,
-			}
-			id, err := s.repository.Save(ctx, newInBoundOrder)
+func (s *service) Get(ctx context.Context, id int) (domain.Inbound_order, error) {
+	return s.repository.Get(ctx, id)
+}
+
+func (s *service) Save(ctx context.Context, order_date string, order_number string, employee_id int, product_batch_id int, warehouse_id int, lines []domain.InboundOrderLine) (domain.Inbound_order, error) {
+	exists, err := s.repository.ExistsInboundOrder(ctx, order_number)
+	if err != nil {
+		return domain.Inbound_order{}, err
+	}
+	if exists {
+		return domain.Inbound_order{}, ErrAlreadyExist
+	}
+	if product_batch_id == 0 && len(lines) == 0 {
+		return domain.Inbound_order{}, ErrProductBatchRequired
+	}
+	if err := s.checkReferences(ctx, employee_id, product_batch_id, warehouse_id); err != nil {
+		return domain.Inbound_order{}, err
+	}
+	for _, line := range lines {
+		exists, err := s.repository.ExistsProduct(ctx, line.ProductID)
+		if err != nil {
+			return domain.Inbound_order{}, err
+		}
+		if !exists {
+			return domain.Inbound_order{}, ErrProductNotExist
+		}
+	}
+
+	newInBoundOrder := domain.Inbound_order{
+		Order_date:       order_date,
+		Order_number:     order_number,
+		Employee_id:      employee_id,
+		Product_batch_id: product_batch_id,
+		Warehouse_id:     warehouse_id,
+		Status:           domain.InboundOrderClosed,
+	}
+	if len(lines) > 0 {
+		newInBoundOrder.Status = domain.InboundOrderOpen
+	}
+	err = s.outbox.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		id, err := s.repository.Save(ctx, newInBoundOrder)
+		if err != nil {
+			return nil, err
+		}
+		newInBoundOrder.ID = id
+		if len(lines) > 0 {
+			ids, err := s.repository.SaveLines(ctx, id, lines)
 			if err != nil {
-				return domain.Inbound_order{}, err
+				return nil, err
+			}
+			newInBoundOrder.Lines = make([]domain.InboundOrderLine, len(lines))
+			for i, line := range lines {
+				line.ID, line.ReceivedQuantity = ids[i], 0
+				newInBoundOrder.Lines[i] = line
+			}
+		}
+		return []events.Event{{
+			Type:        events.InboundOrderCreated,
+			AggregateID: id,
+			WarehouseID: warehouse_id,
+			Data:        newInBoundOrder,
+		}}, nil
+	})
+	if err != nil {
+		return domain.Inbound_order{}, err
+	}
+	s.logger.InfoContext(ctx, "inbound order created", "inbound_order_id", newInBoundOrder.ID)
+	return newInBoundOrder, nil
+}
+
+// checkReferences checks that the employee, the warehouse and the batch, if
+// any, exist, and that the employee works at the warehouse and the batch is
+// stored in one of its sections.
+func (s *service) checkReferences(ctx context.Context, employee_id int, product_batch_id int, warehouse_id int) error {
+	employeeWarehouse, ok, err := s.repository.EmployeeWarehouse(ctx, employee_id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrEmployeeNotExist
+	}
+	if ok, err = s.repository.ExistsWarehouse(ctx, warehouse_id); err != nil {
+		return err
+	}
+	if !ok {
+		return ErrWarehouseNotExist
+	}
+	batchWarehouse := warehouse_id
+	if product_batch_id != 0 {
+		if batchWarehouse, ok, err = s.repository.ProductBatchWarehouse(ctx, product_batch_id); err != nil {
+			return err
+		}
+		if !ok {
+			return ErrProductBatchNotExist
+		}
+	}
+	if employeeWarehouse != warehouse_id {
+		return ErrEmployeeWarehouse
+	}
+	if batchWarehouse != warehouse_id {
+		return ErrProductBatchWarehouse
+	}
+	return nil
+}
+
+func (s *service) Receive(ctx context.Context, id int, lines []domain.InboundReceiptLine) (domain.InboundReceipt, error) {
+	order, err := s.repository.Get(ctx, id)
+	if err != nil {
+		return domain.InboundReceipt{}, err
+	}
+	if len(order.Lines) == 0 {
+		return domain.InboundReceipt{}, ErrNoLines
+	}
+	receipt := domain.InboundReceipt{InboundOrderID: id, Lines: make([]domain.InboundReceiptLine, len(lines))}
+	for i, received := range lines {
+		line, ok := findLine(order.Lines, received.LineID)
+		if !ok {
+			return domain.InboundReceipt{}, ErrLineNotExist
+		}
+		if received, err = s.checkReceived(ctx, order.Warehouse_id, line, received); err != nil {
+			return domain.InboundReceipt{}, err
+		}
+		receipt.Lines[i] = received
+	}
+
+	err = s.outbox.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		// The lines are read again, locked, so receipts of the order made
+		// meanwhile are counted.
+		locked, err := s.repository.LockLines(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		var changes []events.Event
+		for i, received := range receipt.Lines {
+			line := &locked[indexLine(locked, received.LineID)]
+			if received.ProductBatchID == 0 {
+				pb := domain.Product_batches{
+					BatchNumber:       line.BatchNumber,
+					CurrentQuantity:   received.Quantity,
+					InitialQuantity:   received.Quantity,
+					ManufacturingDate: received.ManufacturingDate,
+					DueDate:           received.DueDate,
+					SectionId:         received.SectionID,
+					ProductId:         line.ProductID,
+				}
+				if pb.ID, err = s.repository.CreateProductBatch(ctx, pb); err != nil {
+					return nil, err
+				}
+				receipt.Lines[i].ProductBatchID = pb.ID
+				changes = append(changes, events.Event{Type: events.ProductBatchReceived, AggregateID: pb.ID, WarehouseID: order.Warehouse_id, Data: pb})
+			} else if err := s.repository.CreditProductBatch(ctx, received.ProductBatchID, received.Quantity); err != nil {
+				return nil, err
 			}
-			newInBoundOrder.ID = id
-			return newInBoundOrder, nil
+			line.ReceivedQuantity += received.Quantity
+			changes = append(changes, events.Event{Type: events.StockChanged, AggregateID: received.SectionID, WarehouseID: order.Warehouse_id, Data: events.StockChange{
+				WarehouseID:    order.Warehouse_id,
+				SectionID:      received.SectionID,
+				ProductID:      line.ProductID,
+				ProductBatchID: receipt.Lines[i].ProductBatchID,
+				Quantity:       received.Quantity,
+			}})
 		}
-		return domain.Inbound_order{}, ErrEmployeeNotExist
+
+		receipt.Status = receivedStatus(locked)
+		if receipt.ID, err = s.repository.SaveReceipt(ctx, receipt); err != nil {
+			return nil, err
+		}
+		return changes, s.repository.UpdateReceived(ctx, id, locked, receipt.Status)
+	})
+	if err != nil {
+		return domain.InboundReceipt{}, err
 	}
-	return domain.Inbound_order{}, ErrAlreadyExist
+	s.logger.InfoContext(ctx, "inbound order received", "inbound_order_id", id, "receipt_id", receipt.ID, "status", receipt.Status)
+	return receipt, nil
+}
+
+// checkReceived checks that what's received of line goes to a batch of its
+// product, or to a section, in the warehouse of the order, and returns
+// received with the section of its batch.
+func (s *service) checkReceived(ctx context.Context, warehouse_id int, line domain.InboundOrderLine, received domain.InboundReceiptLine) (domain.InboundReceiptLine, error) {
+	switch {
+	case received.ProductBatchID != 0:
+		product, ok, err := s.repository.ProductBatchProduct(ctx, received.ProductBatchID)
+		if err != nil {
+			return received, err
+		}
+		if !ok {
+			return received, ErrProductBatchNotExist
+		}
+		if product != line.ProductID {
+			return received, ErrProductBatchProduct
+		}
+		if received.SectionID, _, err = s.repository.ProductBatchSection(ctx, received.ProductBatchID); err != nil {
+			return received, err
+		}
+		warehouse, _, err := s.repository.SectionWarehouse(ctx, received.SectionID)
+		if err != nil {
+			return received, err
+		}
+		if warehouse != warehouse_id {
+			return received, ErrProductBatchWarehouse
+		}
+	case received.SectionID != 0:
+		warehouse, ok, err := s.repository.SectionWarehouse(ctx, received.SectionID)
+		if err != nil {
+			return received, err
+		}
+		if !ok {
+			return received, ErrSectionNotExist
+		}
+		if warehouse != warehouse_id {
+			return received, ErrSectionWarehouse
+		}
+	default:
+		return received, ErrReceiptTarget
+	}
+	return received, nil
+}
+
+// receivedStatus is the status of an order with lines: over received when
+// any line got more than expected, closed when every line got what was
+// expected, partially received when some did and open when none got any.
+func receivedStatus(lines []domain.InboundOrderLine) string {
+	received, short := false, false
+	for _, l := range lines {
+		if l.ReceivedQuantity > l.ExpectedQuantity {
+			return domain.InboundOrderOverReceived
+		}
+		received = received || l.ReceivedQuantity > 0
+		short = short || l.ReceivedQuantity < l.ExpectedQuantity
+	}
+	switch {
+	case !received:
+		return domain.InboundOrderOpen
+	case short:
+		return domain.InboundOrderPartiallyReceived
+	}
+	return domain.InboundOrderClosed
+}
+
+func findLine(lines []domain.InboundOrderLine, id int) (domain.InboundOrderLine, bool) {
+	if i := indexLine(lines, id); i >= 0 {
+		return lines[i], true
+	}
+	return domain.InboundOrderLine{}, false
+}
+
+func indexLine(lines []domain.InboundOrderLine, id int) int {
+	for i, l := range lines {
+		if l.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *service) ReportDiscrepancies(ctx context.Context, id int) ([]domain.InboundDiscrepancyReport, error) {
+	return s.repository.ReportDiscrepancies(ctx, id)
 }
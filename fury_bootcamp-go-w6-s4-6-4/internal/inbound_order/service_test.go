@@ -5,6 +5,9 @@ import (
 	"testing"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
 	inboundorder "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/inbound_order"
 	"github.com/stretchr/testify/assert"
 )
@@ -34,7 +37,7 @@ func Test_GetAll_Ok_Service(t *testing.T) {
 	ibos = append(ibos, data...)
 
 	myMockR := inboundorder.MockRepositoryIBO{DataMock: ibos}
-	service := NewService(&myMockR)
+	service := NewService(&myMockR, events.Discard, logger.Discard())
 
 	//Act
 	results, err := service.GetAll_inboundOrders(context.TODO())
@@ -52,7 +55,7 @@ func Test_GetAll_Fail_Service(t *testing.T) {
 	errorExpected := "Error inGetAll_inboundOrders"
 
 	myMockR := inboundorder.MockRepositoryIBO{DataMock: ibos, Err: errorExpected}
-	service := NewService(&myMockR)
+	service := NewService(&myMockR, events.Discard, logger.Discard())
 
 	//Act
 	results, err := service.GetAll_inboundOrders(context.TODO())
@@ -74,6 +77,7 @@ func Test_Save_ok_Service(t *testing.T) {
 		Employee_id:      1,
 		Product_batch_id: 1,
 		Warehouse_id:     1,
+		Status:           domain.InboundOrderClosed,
 	}
 	emp := []domain.Employee{
 		{ID: 1,
@@ -84,11 +88,16 @@ func Test_Save_ok_Service(t *testing.T) {
 		},
 	}
 
-	myMockR := inboundorder.MockRepositoryIBO{DataMockEmp: emp}
-	service := NewService(&myMockR)
+	myMockR := inboundorder.MockRepositoryIBO{
+		DataMockEmp: emp,
+		DataMockWh:  []domain.Warehouse{{ID: 1}},
+		DataMockPB:  []domain.Product_batches{{ID: 1, SectionId: 1}},
+		DataMockSec: []domain.Section{{ID: 1, WarehouseID: 1}},
+	}
+	service := NewService(&myMockR, events.Discard, logger.Discard())
 
 	//Act
-	result, err := service.Save(context.TODO(), newIBO.Order_date, newIBO.Order_number, newIBO.Employee_id, newIBO.Product_batch_id, newIBO.Warehouse_id)
+	result, err := service.Save(context.TODO(), newIBO.Order_date, newIBO.Order_number, newIBO.Employee_id, newIBO.Product_batch_id, newIBO.Warehouse_id, nil)
 
 	//Assert
 	assert.True(t, myMockR.MethodCalled)
@@ -108,10 +117,10 @@ func Test_Save_Fail_Service(t *testing.T) {
 
 	t.Run("employee does not exist", func(t *testing.T) {
 		myMockR := inboundorder.MockRepositoryIBO{}
-		service := NewService(&myMockR)
+		service := NewService(&myMockR, events.Discard, logger.Discard())
 
 		//Act
-		result, err := service.Save(context.TODO(), newIBO.Order_date, newIBO.Order_number, newIBO.Employee_id, newIBO.Product_batch_id, newIBO.Warehouse_id)
+		result, err := service.Save(context.TODO(), newIBO.Order_date, newIBO.Order_number, newIBO.Employee_id, newIBO.Product_batch_id, newIBO.Warehouse_id, nil)
 
 		//Assert
 		assert.True(t, myMockR.MethodCalled)
@@ -119,13 +128,46 @@ func Test_Save_Fail_Service(t *testing.T) {
 		assert.Equal(t, ErrEmployeeNotExist, err)
 		assert.Empty(t, result)
 	})
+	t.Run("references", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			warehouse int
+			batch     int
+			err       error
+		}{
+			{name: "warehouse does not exist", warehouse: 9, batch: 1, err: ErrWarehouseNotExist},
+			{name: "product batch does not exist", warehouse: 1, batch: 9, err: ErrProductBatchNotExist},
+			{name: "employee at a different warehouse", warehouse: 2, batch: 2, err: ErrEmployeeWarehouse},
+			{name: "product batch at a different warehouse", warehouse: 1, batch: 2, err: ErrProductBatchWarehouse},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				//Arrange
+				myMockR := inboundorder.MockRepositoryIBO{
+					DataMockEmp: []domain.Employee{{ID: 4, WarehouseID: 1}},
+					DataMockWh:  []domain.Warehouse{{ID: 1}, {ID: 2}},
+					DataMockPB:  []domain.Product_batches{{ID: 1, SectionId: 1}, {ID: 2, SectionId: 2}},
+					DataMockSec: []domain.Section{{ID: 1, WarehouseID: 1}, {ID: 2, WarehouseID: 2}},
+				}
+				service := NewService(&myMockR, events.Discard, logger.Discard())
+
+				//Act
+				result, err := service.Save(context.TODO(), newIBO.Order_date, newIBO.Order_number, newIBO.Employee_id, tt.batch, tt.warehouse, nil)
+
+				//Assert
+				assert.ErrorIs(t, err, tt.err)
+				assert.Empty(t, result)
+				assert.Empty(t, myMockR.DataMock)
+			})
+		}
+	})
 	t.Run("already exists", func(t *testing.T) {
 		//Arrange
 		myMockR := inboundorder.MockRepositoryIBO{DataMock: []domain.Inbound_order{newIBO}}
-		service := NewService(&myMockR)
+		service := NewService(&myMockR, events.Discard, logger.Discard())
 
 		//Act
-		result, err := service.Save(context.TODO(), newIBO.Order_date, newIBO.Order_number, newIBO.Employee_id, newIBO.Product_batch_id, newIBO.Warehouse_id)
+		result, err := service.Save(context.TODO(), newIBO.Order_date, newIBO.Order_number, newIBO.Employee_id, newIBO.Product_batch_id, newIBO.Warehouse_id, nil)
 
 		//Assert
 		assert.True(t, myMockR.MethodCalled)
@@ -133,4 +175,153 @@ func Test_Save_Fail_Service(t *testing.T) {
 		assert.Equal(t, ErrAlreadyExist, err)
 		assert.Empty(t, result)
 	})
+	t.Run("lookup fails", func(t *testing.T) {
+		//Arrange
+		myMockR := inboundorder.MockRepositoryIBO{LookupErr: apperrors.Unavailable("database unavailable")}
+		service := NewService(&myMockR, events.Discard, logger.Discard())
+
+		//Act
+		result, err := service.Save(context.TODO(), newIBO.Order_date, newIBO.Order_number, newIBO.Employee_id, newIBO.Product_batch_id, newIBO.Warehouse_id, nil)
+
+		//Assert
+		assert.ErrorIs(t, err, apperrors.ErrUnavailable)
+		assert.Empty(t, result)
+		assert.Empty(t, myMockR.DataMock)
+	})
+}
+
+func receivingMock() *inboundorder.MockRepositoryIBO {
+	return &inboundorder.MockRepositoryIBO{
+		DataMockEmp:  []domain.Employee{{ID: 4, WarehouseID: 1}},
+		DataMockWh:   []domain.Warehouse{{ID: 1}, {ID: 2}},
+		DataMockPB:   []domain.Product_batches{{ID: 1, SectionId: 1, ProductId: 7, CurrentQuantity: 5}, {ID: 2, SectionId: 2, ProductId: 7}, {ID: 3, SectionId: 1, ProductId: 8}},
+		DataMockSec:  []domain.Section{{ID: 1, WarehouseID: 1}, {ID: 2, WarehouseID: 2}},
+		DataMockProd: []domain.Product{{ID: 7}, {ID: 8}},
+	}
+}
+
+func Test_Save_Lines_Service(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		//Arrange
+		myMockR := receivingMock()
+		service := NewService(myMockR, events.Discard, logger.Discard())
+		lines := []domain.InboundOrderLine{{ProductID: 7, BatchNumber: 12, ExpectedQuantity: 10}, {ProductID: 8, ExpectedQuantity: 5}}
+
+		//Act
+		result, err := service.Save(context.TODO(), "2021-04-04", "order#1", 4, 0, 1, lines)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, domain.InboundOrderOpen, result.Status)
+		assert.Equal(t, []domain.InboundOrderLine{{ID: 1, ProductID: 7, BatchNumber: 12, ExpectedQuantity: 10}, {ID: 2, ProductID: 8, ExpectedQuantity: 5}}, result.Lines)
+		assert.Equal(t, result.Lines, myMockR.DataMock[0].Lines)
+	})
+	t.Run("no batch nor lines", func(t *testing.T) {
+		myMockR := receivingMock()
+		service := NewService(myMockR, events.Discard, logger.Discard())
+
+		_, err := service.Save(context.TODO(), "2021-04-04", "order#1", 4, 0, 1, nil)
+
+		assert.ErrorIs(t, err, ErrProductBatchRequired)
+		assert.Empty(t, myMockR.DataMock)
+	})
+	t.Run("product does not exist", func(t *testing.T) {
+		myMockR := receivingMock()
+		service := NewService(myMockR, events.Discard, logger.Discard())
+
+		_, err := service.Save(context.TODO(), "2021-04-04", "order#1", 4, 0, 1, []domain.InboundOrderLine{{ProductID: 9, ExpectedQuantity: 1}})
+
+		assert.ErrorIs(t, err, ErrProductNotExist)
+		assert.Empty(t, myMockR.DataMock)
+	})
+}
+
+func Test_Receive_Service(t *testing.T) {
+	//Arrange
+	myMockR := receivingMock()
+	service := NewService(myMockR, events.Discard, logger.Discard())
+	order, err := service.Save(context.TODO(), "2021-04-04", "order#1", 4, 0, 1,
+		[]domain.InboundOrderLine{{ProductID: 7, BatchNumber: 12, ExpectedQuantity: 10}, {ProductID: 8, ExpectedQuantity: 5}})
+	assert.NoError(t, err)
+
+	//Act & Assert
+	receipt, err := service.Receive(context.TODO(), order.ID, []domain.InboundReceiptLine{{LineID: 1, Quantity: 4, SectionID: 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.InboundOrderPartiallyReceived, receipt.Status)
+	assert.Equal(t, []domain.InboundReceiptLine{{LineID: 1, Quantity: 4, ProductBatchID: 4, SectionID: 1}}, receipt.Lines)
+	assert.Equal(t, domain.Product_batches{ID: 4, BatchNumber: 12, CurrentQuantity: 4, InitialQuantity: 4, SectionId: 1, ProductId: 7}, myMockR.DataMockPB[3])
+
+	receipt, err = service.Receive(context.TODO(), order.ID, []domain.InboundReceiptLine{{LineID: 1, Quantity: 6, ProductBatchID: 1}, {LineID: 2, Quantity: 5, ProductBatchID: 3}})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.InboundOrderClosed, receipt.Status)
+	assert.Equal(t, 11, myMockR.DataMockPB[0].CurrentQuantity)
+
+	receipt, err = service.Receive(context.TODO(), order.ID, []domain.InboundReceiptLine{{LineID: 2, Quantity: 1, ProductBatchID: 3}})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.InboundOrderOverReceived, receipt.Status)
+
+	got, err := service.Get(context.TODO(), order.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.InboundOrderOverReceived, got.Status)
+	assert.Equal(t, 10, got.Lines[0].ReceivedQuantity)
+	assert.Equal(t, 6, got.Lines[1].ReceivedQuantity)
+	assert.Len(t, myMockR.Receipts, 3)
+}
+
+func Test_Receive_Fail_Service(t *testing.T) {
+	tests := []struct {
+		name  string
+		order int
+		line  domain.InboundReceiptLine
+		err   error
+	}{
+		{name: "order does not exist", order: 9, line: domain.InboundReceiptLine{LineID: 1, Quantity: 1, SectionID: 1}, err: apperrors.ErrNotFound},
+		{name: "order without lines", order: 1, line: domain.InboundReceiptLine{LineID: 1, Quantity: 1, SectionID: 1}, err: ErrNoLines},
+		{name: "line not on the order", order: 2, line: domain.InboundReceiptLine{LineID: 9, Quantity: 1, SectionID: 1}, err: ErrLineNotExist},
+		{name: "no batch nor section", order: 2, line: domain.InboundReceiptLine{LineID: 1, Quantity: 1}, err: ErrReceiptTarget},
+		{name: "product batch does not exist", order: 2, line: domain.InboundReceiptLine{LineID: 1, Quantity: 1, ProductBatchID: 9}, err: ErrProductBatchNotExist},
+		{name: "product batch of another product", order: 2, line: domain.InboundReceiptLine{LineID: 1, Quantity: 1, ProductBatchID: 3}, err: ErrProductBatchProduct},
+		{name: "product batch at a different warehouse", order: 2, line: domain.InboundReceiptLine{LineID: 1, Quantity: 1, ProductBatchID: 2}, err: ErrProductBatchWarehouse},
+		{name: "section does not exist", order: 2, line: domain.InboundReceiptLine{LineID: 1, Quantity: 1, SectionID: 9}, err: ErrSectionNotExist},
+		{name: "section at a different warehouse", order: 2, line: domain.InboundReceiptLine{LineID: 1, Quantity: 1, SectionID: 2}, err: ErrSectionWarehouse},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			myMockR := receivingMock()
+			service := NewService(myMockR, events.Discard, logger.Discard())
+			_, err := service.Save(context.TODO(), "2021-04-04", "order#1", 4, 1, 1, nil)
+			assert.NoError(t, err)
+			_, err = service.Save(context.TODO(), "2021-04-05", "order#2", 4, 0, 1, []domain.InboundOrderLine{{ProductID: 7, ExpectedQuantity: 10}})
+			assert.NoError(t, err)
+
+			//Act
+			result, err := service.Receive(context.TODO(), tt.order, []domain.InboundReceiptLine{tt.line})
+
+			//Assert
+			assert.ErrorIs(t, err, tt.err)
+			assert.Empty(t, result)
+			assert.Empty(t, myMockR.Receipts)
+		})
+	}
+}
+
+func Test_ReportDiscrepancies_Service(t *testing.T) {
+	//Arrange
+	reports := []domain.InboundDiscrepancyReport{
+		{SellerID: 1, OrdersCount: 2, ExpectedQuantity: 20, ReceivedQuantity: 16, MissingQuantity: 4},
+		{SellerID: 2, OrdersCount: 1, ExpectedQuantity: 5, ReceivedQuantity: 6, ExcessQuantity: 1},
+	}
+	myMockR := inboundorder.MockRepositoryIBO{Reports: reports}
+	service := NewService(&myMockR, events.Discard, logger.Discard())
+
+	//Act
+	all, err := service.ReportDiscrepancies(context.TODO(), 0)
+	one, errOne := service.ReportDiscrepancies(context.TODO(), 2)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, reports, all)
+	assert.NoError(t, errOne)
+	assert.Equal(t, reports[1:], one)
 }
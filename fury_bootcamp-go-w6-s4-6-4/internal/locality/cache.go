@@ -0,0 +1,48 @@
+package locality
+
+import (
+	"context"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/cache"
+)
+
+// The tables each report counts.
+var (
+	sellersReportTags = []string{"localities", "sellers"}
+	carriesReportTags = []string{"localities", "carries"}
+)
+
+type cachedService struct {
+	Service
+	cache *cache.Cache
+}
+
+// WithCache returns s with the sellers and carries reports cached in c, and
+// new localities invalidating them. A nil c leaves s as is.
+func WithCache(s Service, c *cache.Cache) Service {
+	if c == nil {
+		return s
+	}
+	return &cachedService{Service: s, cache: c}
+}
+
+func (s *cachedService) GetAllSellersByLocality(ctx context.Context, localityID string) ([]domain.ResponseLocality, error) {
+	return cache.Load(ctx, s.cache, "localities.report_sellers:"+localityID, sellersReportTags, func(ctx context.Context) ([]domain.ResponseLocality, error) {
+		return s.Service.GetAllSellersByLocality(ctx, localityID)
+	})
+}
+
+func (s *cachedService) GetCarriesReport(ctx context.Context, id string) ([]domain.CarriesReport, error) {
+	return cache.Load(ctx, s.cache, "localities.report_carries:"+id, carriesReportTags, func(ctx context.Context) ([]domain.CarriesReport, error) {
+		return s.Service.GetCarriesReport(ctx, id)
+	})
+}
+
+func (s *cachedService) Create(ctx context.Context, l domain.Locality) (domain.Locality, error) {
+	l, err := s.Service.Create(ctx, l)
+	if err == nil {
+		s.cache.Invalidate(ctx, "localities")
+	}
+	return l, err
+}
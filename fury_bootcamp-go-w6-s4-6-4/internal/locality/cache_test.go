@@ -0,0 +1,69 @@
+package locality
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/seller"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/cache"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/locality"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/sellers"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingService counts the sellers of locality 1 as the reports it
+// computed.
+type countingService struct {
+	locality.MockService
+	reports int
+}
+
+func (s *countingService) GetAllSellersByLocality(ctx context.Context, localityID string) ([]domain.ResponseLocality, error) {
+	s.reports++
+	return []domain.ResponseLocality{{ID: 1, LocalityName: "San Justo", SellersCount: s.reports}}, nil
+}
+
+func TestWithCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should serve the sellers report cached until a seller is created", func(t *testing.T) {
+		c := cache.New(cache.NewMemory(10), time.Minute, logger.Discard())
+		counting := &countingService{}
+		service := WithCache(counting, c)
+		sellerService := seller.WithCache(&sellers.MockService{}, c)
+
+		service.GetAllSellersByLocality(ctx, "1")
+		cached, _ := service.GetAllSellersByLocality(ctx, "1")
+		sellerService.Save(ctx, 10, 1, "Meli", "Av. Siempreviva 742", "555-1234")
+		refreshed, _ := service.GetAllSellersByLocality(ctx, "1")
+
+		assert.Equal(t, 1, cached[0].SellersCount)
+		assert.Equal(t, 2, refreshed[0].SellersCount)
+	})
+	t.Run("should keep each locality apart", func(t *testing.T) {
+		c := cache.New(cache.NewMemory(10), time.Minute, logger.Discard())
+		counting := &countingService{}
+		service := WithCache(counting, c)
+
+		service.GetAllSellersByLocality(ctx, "1")
+		service.GetAllSellersByLocality(ctx, "2")
+		service.GetAllSellersByLocality(ctx, "")
+
+		assert.Equal(t, 3, counting.reports)
+	})
+	t.Run("should not invalidate on writes that failed", func(t *testing.T) {
+		c := cache.New(cache.NewMemory(10), time.Minute, logger.Discard())
+		counting := &countingService{}
+		service := WithCache(counting, c)
+		sellerService := seller.WithCache(&sellers.MockService{}, c)
+
+		service.GetAllSellersByLocality(ctx, "1")
+		sellerService.Save(ctx, 10, 1, "", "", "")
+		service.GetAllSellersByLocality(ctx, "1")
+
+		assert.Equal(t, 1, counting.reports)
+	})
+}
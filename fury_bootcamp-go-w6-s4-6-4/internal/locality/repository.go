@@ -4,63 +4,109 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"log/slog"
 	"strconv"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 )
 
 const (
-	GET_SELLERS_BY_ID = "SELECT l.id, l.locality_name, COUNT(l.id) FROM seller s INNER JOIN locality l ON s.locality_id = l.id WHERE l.id=? GROUP BY id;"
-	GET_SELLERS       = "SELECT l.id, l.locality_name, COUNT(l.id) FROM seller s INNER JOIN locality l ON s.locality_id = l.id GROUP BY id;"
-	EXIST_LOCALITY    = "SELECT id FROM locality WHERE id=?;"
-	GET_LOCALITY      = "SELECT id, locality_name, province_name, country_name FROM locality WHERE id =?;"
-	CREATE_LOCALITY   = "INSERT INTO locality (id, locality_name, province_name, country_name) VALUES (?, ?, ?, ?)"
+	GET_SELLERS_BY_ID = "SELECT l.id, l.locality_name, COUNT(l.id) FROM seller s INNER JOIN locality l ON s.locality_id = l.id WHERE l.id=? AND l.site_id = COALESCE(?, l.site_id) GROUP BY id;"
+	GET_SELLERS       = "SELECT l.id, l.locality_name, COUNT(l.id) FROM seller s INNER JOIN locality l ON s.locality_id = l.id WHERE l.site_id = COALESCE(?, l.site_id) GROUP BY id;"
+	EXIST_LOCALITY    = "SELECT id FROM locality WHERE id=? AND site_id = COALESCE(?, site_id);"
+	EXIST_PROVINCE    = "SELECT id FROM provinces WHERE id=? AND site_id = COALESCE(?, site_id);"
+	GET_LOCALITY      = "SELECT l.id, l.site_id, l.locality_name, l.province_id, p.province_name, c.country_name FROM locality l JOIN provinces p ON p.site_id = l.site_id AND p.id = l.province_id JOIN countries c ON c.site_id = p.site_id AND c.id = p.country_id WHERE l.id =? AND l.site_id = COALESCE(?, l.site_id);"
+	CREATE_LOCALITY   = "INSERT INTO locality (id, site_id, locality_name, province_id) VALUES (?, ?, ?, ?)"
 )
 
 type Repository interface {
 	Exists(ctx context.Context, id int) bool
+	ProvinceExists(ctx context.Context, provinceID int) bool
+	// Get returns the locality with the names of its province and country.
+	Get(ctx context.Context, id int) (domain.Locality, error)
+	// Create stores l under its ID, an official code, or under a new one
+	// when it's 0.
 	Create(ctx context.Context, l domain.Locality) (int, error)
 	GetAllSellersByLocality(ctx context.Context, localityID string) ([]domain.ResponseLocality, error)
 	GetCarriesReport(ctx context.Context, id string) ([]domain.CarriesReport, error)
 }
 
 type repository struct {
-	db *sql.DB
+	db     *database.Router
+	stmts  *database.Statements
+	logger *slog.Logger
 }
 
-func NewRepository(db *sql.DB) Repository {
+func NewRepository(db *database.Router, stmts *database.Statements, logger *slog.Logger) Repository {
+	stmts.Register(CREATE_LOCALITY)
 	return &repository{
-		db: db,
+		db:     db,
+		stmts:  stmts,
+		logger: logger,
 	}
 }
 
 func (r *repository) Exists(ctx context.Context, id int) bool {
-	row := r.db.QueryRow(EXIST_LOCALITY, id)
+	row := r.db.Writer().QueryRowContext(ctx, EXIST_LOCALITY, id, tenant.Filter(ctx))
 	err := row.Scan(&id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", "Exists", "error", err)
+	}
+	return err == nil
+}
+
+func (r *repository) ProvinceExists(ctx context.Context, provinceID int) bool {
+	row := r.db.Writer().QueryRowContext(ctx, EXIST_PROVINCE, provinceID, tenant.Filter(ctx))
+	err := row.Scan(&provinceID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", "ProvinceExists", "error", err)
+	}
 	return err == nil
 }
 
+func (r *repository) Get(ctx context.Context, id int) (domain.Locality, error) {
+	var l domain.Locality
+	row := r.db.Writer().QueryRowContext(ctx, GET_LOCALITY, id, tenant.Filter(ctx))
+	err := row.Scan(&l.ID, &l.SiteID, &l.LocalityName, &l.ProvinceID, &l.ProvinceName, &l.CountryName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Locality{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.Locality{}, apperrors.FromDB(err)
+	}
+	return l, nil
+}
+
 func (r *repository) Create(ctx context.Context, l domain.Locality) (int, error) {
-	stmt, err := r.db.Prepare(CREATE_LOCALITY)
+	site, err := tenant.Site(ctx)
 	if err != nil {
 		return 0, err
 	}
 
-	if r.Exists(ctx, l.ID) {
-		return 0, errors.New("id already exists")
+	stmt, err := r.stmts.Stmt(ctx, CREATE_LOCALITY)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
 	}
 
-	res, err := stmt.Exec(l.ID, l.LocalityName, l.ProvinceName, l.CountryName)
+	if l.ID != 0 && r.Exists(ctx, l.ID) {
+		return 0, ErrExists
+	}
+
+	id := sql.NullInt64{Int64: int64(l.ID), Valid: l.ID != 0}
+	res, err := stmt.ExecContext(ctx, id, site, l.LocalityName, l.ProvinceID)
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
 	}
 
-	id, err := res.LastInsertId()
+	lastID, err := res.LastInsertId()
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
 	}
 
-	return int(id), nil
+	return int(lastID), nil
 }
 
 func (r *repository) GetAllSellersByLocality(ctx context.Context, localityID string) ([]domain.ResponseLocality, error) {
@@ -68,19 +114,20 @@ func (r *repository) GetAllSellersByLocality(ctx context.Context, localityID str
 	var err error
 
 	if localityID == "" {
-		rows, err = r.db.Query(GET_SELLERS)
+		rows, err = r.db.Reader(ctx).QueryContext(ctx, GET_SELLERS, tenant.Filter(ctx))
 	} else {
 		localityID, _ := strconv.Atoi(localityID)
 		if r.Exists(ctx, localityID) {
-			rows, err = r.db.Query(GET_SELLERS_BY_ID, localityID)
+			rows, err = r.db.Reader(ctx).QueryContext(ctx, GET_SELLERS_BY_ID, localityID, tenant.Filter(ctx))
 		} else {
-			return []domain.ResponseLocality{}, errors.New("locality_id not found")
+			return []domain.ResponseLocality{}, ErrNotFound
 		}
 	}
 
 	if err != nil {
-		return []domain.ResponseLocality{}, err
+		return []domain.ResponseLocality{}, apperrors.FromDB(err)
 	}
+	defer rows.Close()
 
 	var localities []domain.ResponseLocality
 
@@ -88,40 +135,41 @@ func (r *repository) GetAllSellersByLocality(ctx context.Context, localityID str
 		var l domain.ResponseLocality
 		err := rows.Scan(&l.ID, &l.LocalityName, &l.SellersCount)
 		if err != nil {
-			return []domain.ResponseLocality{}, err
+			return []domain.ResponseLocality{}, apperrors.FromDB(err)
 		}
 		localities = append(localities, l)
 	}
 
-	return localities, nil
+	return localities, apperrors.FromDB(rows.Err())
 }
 
 func (r *repository) GetCarriesReport(ctx context.Context, id string) (carriesReports []domain.CarriesReport, err error) {
 	var rows *sql.Rows
 	if id == "" {
-		query := "SELECT locality.id, locality.locality_name, COUNT(*) AS carries_count FROM carries RIGHT JOIN locality on carries.locality_id = locality.id GROUP BY id;"
-		rows, err = r.db.Query(query)
+		query := "SELECT locality.id, locality.locality_name, COUNT(*) AS carries_count FROM carries RIGHT JOIN locality on carries.locality_id = locality.id WHERE locality.site_id = COALESCE(?, locality.site_id) GROUP BY id;"
+		rows, err = r.db.Reader(ctx).QueryContext(ctx, query, tenant.Filter(ctx))
 	} else {
 		intId, _ := strconv.Atoi(id)
 		if !r.Exists(ctx, intId) {
-			return nil, errors.New("id does not exist")
+			return nil, ErrNotFound
 		}
-		query := "SELECT locality.id, locality.locality_name, COUNT(*) AS carries_count FROM carries right join locality on carries.locality_id = locality.id WHERE locality.id = ? GROUP BY id;"
-		rows, err = r.db.Query(query, intId)
+		query := "SELECT locality.id, locality.locality_name, COUNT(*) AS carries_count FROM carries right join locality on carries.locality_id = locality.id WHERE locality.id = ? AND locality.site_id = COALESCE(?, locality.site_id) GROUP BY id;"
+		rows, err = r.db.Reader(ctx).QueryContext(ctx, query, intId, tenant.Filter(ctx))
 	}
 
 	if err != nil {
-		return nil, err
+		return nil, apperrors.FromDB(err)
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var report domain.CarriesReport
 		err = rows.Scan(&report.LocalityID, &report.LocalityName, &report.CarriesCount)
 		if err != nil {
-			return nil, err
+			return nil, apperrors.FromDB(err)
 		}
 		carriesReports = append(carriesReports, report)
 	}
 
-	return carriesReports, nil
+	return carriesReports, apperrors.FromDB(rows.Err())
 }
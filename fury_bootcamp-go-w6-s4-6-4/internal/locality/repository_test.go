@@ -2,6 +2,7 @@ package locality
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"regexp"
 	"testing"
@@ -9,6 +10,9 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,19 +28,18 @@ func TestExistLocalityOK(t *testing.T) {
 	locality_test := domain.Locality{
 		ID:           1759,
 		LocalityName: "Gonzalez Catan",
-		ProvinceName: "Buenos Aires",
-		CountryName:  "Argentina",
+		ProvinceID:   6,
 	}
 
 	columns := []string{"id"}
 	rows := sqlmock.NewRows(columns)
 
 	rows.AddRow(locality_test.ID)
-	mock.ExpectQuery(regexp.QuoteMeta(EXIST_LOCALITY)).WithArgs(1).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(EXIST_LOCALITY)).WithArgs(1, "MLA").WillReturnRows(rows)
 
-	repo := NewRepository(db)
+	repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
-	resp := repo.Exists(context.TODO(), 1)
+	resp := repo.Exists(tenant.NewContext(context.TODO(), "MLA"), 1)
 
 	assert.True(t, resp)
 }
@@ -49,11 +52,11 @@ func TestExistLocalityFail(t *testing.T) {
 	columns := []string{"id"}
 	rows := sqlmock.NewRows(columns)
 
-	mock.ExpectQuery(regexp.QuoteMeta(EXIST_LOCALITY)).WithArgs(2).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(EXIST_LOCALITY)).WithArgs(2, "MLA").WillReturnRows(rows)
 
-	repo := NewRepository(db)
+	repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
-	resp := repo.Exists(context.TODO(), 2)
+	resp := repo.Exists(tenant.NewContext(context.TODO(), "MLA"), 2)
 
 	assert.False(t, resp)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -67,8 +70,7 @@ func TestCreateLocality(t *testing.T) {
 	locality_test := domain.Locality{
 		ID:           1759,
 		LocalityName: "Gonzalez Catan",
-		ProvinceName: "Buenos Aires",
-		CountryName:  "Argentina",
+		ProvinceID:   6,
 	}
 
 	t.Run("create ok", func(t *testing.T) {
@@ -76,8 +78,8 @@ func TestCreateLocality(t *testing.T) {
 		mock.ExpectPrepare(regexp.QuoteMeta(CREATE_LOCALITY))
 		mock.ExpectExec(regexp.QuoteMeta(CREATE_LOCALITY)).WillReturnResult(sqlmock.NewResult(1, 1))
 
-		repository := NewRepository(db)
-		ctx := context.TODO()
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		newID, err := repository.Create(ctx, locality_test)
 		assert.NoError(t, err)
@@ -95,8 +97,8 @@ func TestCreateLocality(t *testing.T) {
 		mock.ExpectPrepare(regexp.QuoteMeta(CREATE_LOCALITY))
 		mock.ExpectExec(regexp.QuoteMeta(CREATE_LOCALITY)).WillReturnError(ErrForzado)
 
-		repository := NewRepository(db)
-		ctx := context.TODO()
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		id, err := repository.Create(ctx, locality_test)
 
@@ -111,19 +113,11 @@ func TestCreateLocality(t *testing.T) {
 
 		defer db.Close()
 
-		columns := []string{"id", "locality_name", "province_name", "country_name"}
-		rows := sqlmock.NewRows(columns)
-		localities := []domain.Locality{locality_test}
-
-		for _, locality := range localities {
-			rows.AddRow(locality.ID, locality.LocalityName, locality.ProvinceName, locality.CountryName)
-		}
-
 		mock.ExpectPrepare(regexp.QuoteMeta(CREATE_LOCALITY))
-		mock.ExpectExec(regexp.QuoteMeta(CREATE_LOCALITY)).WithArgs(locality_test.ID, locality_test.LocalityName, locality_test.ProvinceName, locality_test.CountryName).WillReturnError(ErrExists)
+		mock.ExpectExec(regexp.QuoteMeta(CREATE_LOCALITY)).WithArgs(sql.NullInt64{Int64: int64(locality_test.ID), Valid: true}, "MLA", locality_test.LocalityName, locality_test.ProvinceID).WillReturnError(ErrExists)
 
-		repository := NewRepository(db)
-		ctx := context.TODO()
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		id, err := repository.Create(ctx, locality_test)
 
@@ -131,6 +125,42 @@ func TestCreateLocality(t *testing.T) {
 		assert.Empty(t, id)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
+
+	t.Run("create with a generated id", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectPrepare(regexp.QuoteMeta(CREATE_LOCALITY))
+		mock.ExpectExec(regexp.QuoteMeta(CREATE_LOCALITY)).WithArgs(sql.NullInt64{}, "MLA", "Moron", 6).WillReturnResult(sqlmock.NewResult(1760, 1))
+
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		ctx := tenant.NewContext(context.TODO(), "MLA")
+
+		id, err := repository.Create(ctx, domain.Locality{LocalityName: "Moron", ProvinceID: 6})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1760, id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetLocality(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	columns := []string{"id", "site_id", "locality_name", "province_id", "province_name", "country_name"}
+	rows := sqlmock.NewRows(columns).AddRow(1759, "MLA", "Gonzalez Catan", 6, "Buenos Aires", "Argentina")
+	mock.ExpectQuery(regexp.QuoteMeta(GET_LOCALITY)).WithArgs(1759, "MLA").WillReturnRows(rows)
+
+	repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+
+	l, err := repo.Get(tenant.NewContext(context.TODO(), "MLA"), 1759)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Locality{ID: 1759, SiteID: "MLA", LocalityName: "Gonzalez Catan", ProvinceID: 6, ProvinceName: "Buenos Aires", CountryName: "Argentina"}, l)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestGetSellers(t *testing.T) {
@@ -148,10 +178,10 @@ func TestGetSellers(t *testing.T) {
 			rows.AddRow(l.ID, l.LocalityName, l.SellersCount)
 		}
 
-		mock.ExpectQuery(regexp.QuoteMeta(GET_SELLERS)).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(GET_SELLERS)).WithArgs("MLA").WillReturnRows(rows)
 
-		repo := NewRepository(db)
-		result, err := repo.GetAllSellersByLocality(context.TODO(), "")
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		result, err := repo.GetAllSellersByLocality(tenant.NewContext(context.TODO(), "MLA"), "")
 
 		assert.NoError(t, err)
 		assert.Equal(t, localities, result)
@@ -168,12 +198,12 @@ func TestGetSellers(t *testing.T) {
 			rows.AddRow(l.ID, l.LocalityName, l.SellersCount)
 		}
 
-		mock.ExpectQuery(regexp.QuoteMeta(GET_SELLERS_BY_ID)).WithArgs("1759")
+		mock.ExpectQuery(regexp.QuoteMeta(GET_SELLERS_BY_ID)).WithArgs("1759", "MLA")
 
-		repo := NewRepository(db)
-		result, err := repo.GetAllSellersByLocality(context.TODO(), "1759")
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		result, err := repo.GetAllSellersByLocality(tenant.NewContext(context.TODO(), "MLA"), "1759")
 
-		assert.EqualError(t, err, "locality_id not found")
+		assert.ErrorIs(t, err, ErrNotFound)
 		assert.Empty(t, result)
 	})
 
@@ -194,10 +224,10 @@ func TestGetCarries(t *testing.T) {
 
 	t.Run("get ok", func(t *testing.T) {
 
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT locality.id, locality.locality_name, COUNT(*) AS carries_count FROM carries RIGHT JOIN locality on carries.locality_id = locality.id GROUP BY id;")).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT locality.id, locality.locality_name, COUNT(*) AS carries_count FROM carries RIGHT JOIN locality on carries.locality_id = locality.id WHERE locality.site_id = COALESCE(?, locality.site_id) GROUP BY id;")).WithArgs("MLA").WillReturnRows(rows)
 
-		repo := NewRepository(db)
-		result, err := repo.GetCarriesReport(context.TODO(), "")
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		result, err := repo.GetCarriesReport(tenant.NewContext(context.TODO(), "MLA"), "")
 
 		assert.NoError(t, err)
 		assert.Equal(t, carries, result)
@@ -206,12 +236,12 @@ func TestGetCarries(t *testing.T) {
 
 	t.Run("get fail with id", func(t *testing.T) {
 
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT locality.id, locality.locality_name, COUNT(*) AS carries_count FROM carries right join locality on carries.locality_id = locality.id WHERE locality.id = ? GROUP BY id;")).WithArgs(1759)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT locality.id, locality.locality_name, COUNT(*) AS carries_count FROM carries right join locality on carries.locality_id = locality.id WHERE locality.id = ? AND locality.site_id = COALESCE(?, locality.site_id) GROUP BY id;")).WithArgs(1759, "MLA")
 
-		repo := NewRepository(db)
-		result, err := repo.GetCarriesReport(context.TODO(), "1759")
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		result, err := repo.GetCarriesReport(tenant.NewContext(context.TODO(), "MLA"), "1759")
 
-		assert.EqualError(t, err, "id does not exist")
+		assert.ErrorIs(t, err, ErrNotFound)
 		assert.Empty(t, result)
 	})
 
@@ -2,20 +2,24 @@ package locality
 
 import (
 	"context"
-	"errors"
+	"log/slog"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 // Errors
 var (
-	ErrNotFound = errors.New("seller not found")
-	ErrRequired = errors.New("field required")
-	ErrExists   = errors.New("id already exists")
-	ErrRequest  = errors.New("incorrec field content")
+	ErrNotFound         = apperrors.NotFound("locality_id not found")
+	ErrRequired         = apperrors.Validation("field required")
+	ErrExists           = apperrors.Conflict("id already exists")
+	ErrRequest          = apperrors.Validation("incorrect field content")
+	ErrProvinceNotFound = apperrors.ForeignKeyViolation("province id not found")
 )
 
 type Service interface {
+	// Create stores l under its ID, or under a new one when it's 0, and
+	// returns it with the names of its province and country.
 	Create(ctx context.Context, l domain.Locality) (domain.Locality, error)
 	GetAllSellersByLocality(ctx context.Context, localityID string) ([]domain.ResponseLocality, error)
 	GetCarriesReport(ctx context.Context, id string) ([]domain.CarriesReport, error)
@@ -23,20 +27,25 @@ type Service interface {
 
 type service struct {
 	repository Repository
+	logger     *slog.Logger
 }
 
-func NewService(repository Repository) Service {
-	return &service{repository}
+func NewService(repository Repository, logger *slog.Logger) Service {
+	return &service{repository, logger}
 }
 
 func (s *service) Create(ctx context.Context, l domain.Locality) (domain.Locality, error) {
+	if !s.repository.ProvinceExists(ctx, l.ProvinceID) {
+		return domain.Locality{}, ErrProvinceNotFound
+	}
 
-	_, err := s.repository.Create(ctx, l)
+	id, err := s.repository.Create(ctx, l)
 	if err != nil {
 		return domain.Locality{}, err
 	}
+	s.logger.InfoContext(ctx, "locality created", "locality_id", id)
 
-	return l, nil
+	return s.repository.Get(ctx, id)
 }
 
 func (s *service) GetAllSellersByLocality(ctx context.Context, localityID string) ([]domain.ResponseLocality, error) {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/locality"
 	"github.com/stretchr/testify/assert"
 )
@@ -14,23 +15,22 @@ func TestCreateLocalityS(t *testing.T) {
 	database := []domain.Locality{{
 		ID:           1754,
 		LocalityName: "San Justo",
-		ProvinceName: "Buenos Aires",
-		CountryName:  "Argentina",
+		ProvinceID:   6,
 	}}
 
 	locality_test := domain.Locality{
 		ID:           1759,
 		LocalityName: "Gonzalez Catan",
-		ProvinceName: "Buenos Aires",
-		CountryName:  "Argentina",
+		ProvinceID:   6,
 	}
 
 	mockRepository := locality.MockRepository{
-		DataMock: database,
-		Error:    "",
+		DataMock:  database,
+		Provinces: []int{6},
+		Error:     "",
 	}
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, logger.Discard())
 
 	t.Run("create ok", func(t *testing.T) {
 		//Act
@@ -41,6 +41,24 @@ func TestCreateLocalityS(t *testing.T) {
 		assert.Equal(t, locality_test, results)
 	})
 
+	t.Run("create with a generated id", func(t *testing.T) {
+		//Act
+		results, err := service.Create(context.TODO(), domain.Locality{LocalityName: "Moron", ProvinceID: 6})
+
+		//Assert
+		assert.Nil(t, err)
+		assert.Equal(t, 1760, results.ID)
+	})
+
+	t.Run("create province not found", func(t *testing.T) {
+		//Act
+		results, err := service.Create(context.TODO(), domain.Locality{LocalityName: "Moron", ProvinceID: 9})
+
+		//Assert
+		assert.ErrorIs(t, err, ErrProvinceNotFound)
+		assert.Empty(t, results)
+	})
+
 	t.Run("create id exist", func(t *testing.T) {
 		locality_test.ID = 1754
 
@@ -63,7 +81,7 @@ func TestGetSellerS(t *testing.T) {
 		Error:    "",
 	}
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, logger.Discard())
 
 	//Act
 	_, err := service.GetAllSellersByLocality(context.TODO(), "1759")
@@ -81,7 +99,7 @@ func TestGetCarriesS(t *testing.T) {
 		Error:    "",
 	}
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, logger.Discard())
 
 	//Act
 	_, err := service.GetCarriesReport(context.TODO(), "1759")
@@ -0,0 +1,121 @@
+// Package metrics exposes gauges about the state of the warehouses, computed
+// from the repositories on a refresh interval rather than on every scrape.
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	productbatches "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/product_batches"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/purchase_orders"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/section"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/warehouse"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/health"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "melisprint"
+
+// Domain refreshes the domain gauges from the repositories.
+type Domain struct {
+	warehouses     warehouse.Repository
+	sections       section.Repository
+	productBatches productbatches.Repository
+	purchaseOrders purchase_orders.Repository
+	logger         *slog.Logger
+
+	stockOnHand          *prometheus.GaugeVec
+	sectionUtilization   *prometheus.GaugeVec
+	temperatureIncidents prometheus.Gauge
+	purchaseOrdersStatus *prometheus.GaugeVec
+
+	heartbeat *health.Heartbeat
+}
+
+func NewDomain(reg prometheus.Registerer, w warehouse.Repository, s section.Repository, pb productbatches.Repository, po purchase_orders.Repository, logger *slog.Logger) *Domain {
+	d := &Domain{
+		warehouses:     w,
+		sections:       s,
+		productBatches: pb,
+		purchaseOrders: po,
+		logger:         logger,
+		heartbeat:      health.NewHeartbeat("metrics refresher"),
+		stockOnHand: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "warehouse_stock_on_hand",
+			Help:      "Quantity of product stored in the sections of the warehouse.",
+		}, []string{"warehouse_id"}),
+		sectionUtilization: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "section_utilization_ratio",
+			Help:      "Current capacity of the section over its maximum capacity.",
+		}, []string{"section_id", "warehouse_id"}),
+		temperatureIncidents: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "temperature_incidents_open",
+			Help:      "Product batches in stock below their minimum temperature.",
+		}),
+		purchaseOrdersStatus: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "purchase_orders",
+			Help:      "Number of purchase orders by status.",
+		}, []string{"status"}),
+	}
+	reg.MustRegister(d.stockOnHand, d.sectionUtilization, d.temperatureIncidents, d.purchaseOrdersStatus)
+	return d
+}
+
+// Run refreshes the gauges right away and then every interval, until ctx is
+// done.
+func (d *Domain) Run(ctx context.Context, interval time.Duration) {
+	d.heartbeat.Run(ctx, interval, d.Refresh)
+}
+
+// Refresh recomputes every gauge. A gauge whose source fails keeps its last
+// value.
+func (d *Domain) Refresh(ctx context.Context) {
+	if stock, err := d.warehouses.StockOnHand(ctx); err != nil {
+		d.logger.ErrorContext(ctx, "refreshing stock on hand", "error", err)
+	} else {
+		d.stockOnHand.Reset()
+		for warehouseID, quantity := range stock {
+			d.stockOnHand.WithLabelValues(strconv.Itoa(warehouseID)).Set(float64(quantity))
+		}
+	}
+
+	if sections, err := d.sections.GetAll(ctx); err != nil {
+		d.logger.ErrorContext(ctx, "refreshing section utilization", "error", err)
+	} else {
+		d.sectionUtilization.Reset()
+		for _, s := range sections {
+			if s.MaximumCapacity <= 0 {
+				continue
+			}
+			d.sectionUtilization.WithLabelValues(strconv.Itoa(s.ID), strconv.Itoa(s.WarehouseID)).
+				Set(float64(s.CurrentCapacity) / float64(s.MaximumCapacity))
+		}
+	}
+
+	if count, err := d.productBatches.CountTemperatureIncidents(ctx); err != nil {
+		d.logger.ErrorContext(ctx, "refreshing temperature incidents", "error", err)
+	} else {
+		d.temperatureIncidents.Set(float64(count))
+	}
+
+	if counts, err := d.purchaseOrders.CountByStatus(ctx); err != nil {
+		d.logger.ErrorContext(ctx, "refreshing purchase orders by status", "error", err)
+	} else {
+		d.purchaseOrdersStatus.Reset()
+		for status, count := range counts {
+			d.purchaseOrdersStatus.WithLabelValues(strconv.Itoa(status)).Set(float64(count))
+		}
+	}
+}
+
+// Healthy reports an error when Run is not refreshing the gauges, either
+// because it never started or because it missed two intervals in a row.
+func (d *Domain) Healthy(ctx context.Context) error {
+	return d.heartbeat.Check(ctx)
+}
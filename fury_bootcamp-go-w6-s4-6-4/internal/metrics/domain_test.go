@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	productbatches "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/product_batches"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/purchase_orders"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/section"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/warehouse"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefresh(t *testing.T) {
+	sections := &section.MockRepository{DataMock: []domain.Section{
+		{ID: 1, WarehouseID: 1, CurrentCapacity: 25, MaximumCapacity: 100},
+		{ID: 2, WarehouseID: 1, CurrentCapacity: 10, MaximumCapacity: 0},
+	}}
+	batches := &productbatches.MockRepository{DataMockPB: []domain.Product_batches{
+		{ID: 1, CurrentQuantity: 5, CurrentTemperature: -2, MinimumTemperature: 0},
+		{ID: 2, CurrentQuantity: 0, CurrentTemperature: -2, MinimumTemperature: 0},
+		{ID: 3, CurrentQuantity: 5, CurrentTemperature: 4, MinimumTemperature: 0},
+	}}
+	orders := &purchase_orders.MockRepository{DataMock: []domain.PurchaseOrders{
+		{ID: 1, OrderStatusID: 1},
+		{ID: 2, OrderStatusID: 1},
+		{ID: 3, OrderStatusID: 2},
+	}}
+
+	d := NewDomain(
+		prometheus.NewRegistry(),
+		warehouse.NewRepositoryWarehouse([]domain.Warehouse{{ID: 1}}),
+		sections,
+		batches,
+		orders,
+		logger.Discard(),
+	)
+	d.Refresh(context.Background())
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(d.stockOnHand.WithLabelValues("1")))
+	assert.Equal(t, 1, testutil.CollectAndCount(d.sectionUtilization))
+	assert.Equal(t, 0.25, testutil.ToFloat64(d.sectionUtilization.WithLabelValues("1", "1")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(d.temperatureIncidents))
+	assert.Equal(t, float64(2), testutil.ToFloat64(d.purchaseOrdersStatus.WithLabelValues("1")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(d.purchaseOrdersStatus.WithLabelValues("2")))
+
+	t.Run("keeps the last value when the source fails", func(t *testing.T) {
+		batches.Error = "connection refused"
+		d.Refresh(context.Background())
+
+		assert.Equal(t, float64(1), testutil.ToFloat64(d.temperatureIncidents))
+	})
+}
+
+func TestHealthy(t *testing.T) {
+	d := NewDomain(prometheus.NewRegistry(), nil, nil, nil, nil, logger.Discard())
+	assert.EqualError(t, d.Healthy(context.Background()), "metrics refresher not running")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	d.heartbeat.Run(ctx, time.Minute, func(context.Context) {})
+	assert.NoError(t, d.Healthy(context.Background()))
+}
@@ -0,0 +1,55 @@
+package product
+
+import (
+	"context"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/cache"
+)
+
+// reportTags are the tables the records report counts.
+var reportTags = []string{"products", "product_records"}
+
+type cachedService struct {
+	Service
+	cache *cache.Cache
+}
+
+// WithCache returns s with the records report cached in c, and its writes to
+// the products invalidating it. A nil c leaves s as is.
+func WithCache(s Service, c *cache.Cache) Service {
+	if c == nil {
+		return s
+	}
+	return &cachedService{Service: s, cache: c}
+}
+
+func (s *cachedService) GetProductRecords(ctx context.Context, id string) ([]domain.ProductRecordsReport, error) {
+	return cache.Load(ctx, s.cache, "products.report_records:"+id, reportTags, func(ctx context.Context) ([]domain.ProductRecordsReport, error) {
+		return s.Service.GetProductRecords(ctx, id)
+	})
+}
+
+func (s *cachedService) Save(ctx context.Context, description string, expiration_rate int, freezing_rate int, height float32, length float32, netweight float32, product_code string, recommended_freezing_temperature float32, width float32, product_type_id int, seller_id int) (domain.Product, error) {
+	p, err := s.Service.Save(ctx, description, expiration_rate, freezing_rate, height, length, netweight, product_code, recommended_freezing_temperature, width, product_type_id, seller_id)
+	if err == nil {
+		s.cache.Invalidate(ctx, "products")
+	}
+	return p, err
+}
+
+func (s *cachedService) Update(ctx context.Context, id int, description string, expiration_rate *int, freezing_rate *int, height *float32, length *float32, netweight *float32, product_code string, recommended_freezing_temperature *float32, width *float32, product_type_id *int, seller_id *int) (domain.Product, error) {
+	p, err := s.Service.Update(ctx, id, description, expiration_rate, freezing_rate, height, length, netweight, product_code, recommended_freezing_temperature, width, product_type_id, seller_id)
+	if err == nil {
+		s.cache.Invalidate(ctx, "products")
+	}
+	return p, err
+}
+
+func (s *cachedService) Delete(ctx context.Context, id int) error {
+	err := s.Service.Delete(ctx, id)
+	if err == nil {
+		s.cache.Invalidate(ctx, "products")
+	}
+	return err
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"log/slog"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	pkgdb "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 )
 
 // Repository encapsulates the storage of a Product.
@@ -20,101 +24,119 @@ type Repository interface {
 }
 
 type repository struct {
-	db *sql.DB
+	db     *pkgdb.Router
+	stmts  *pkgdb.Statements
+	logger *slog.Logger
 }
 
-func NewRepository(db *sql.DB) Repository {
+func NewRepository(db *pkgdb.Router, stmts *pkgdb.Statements, logger *slog.Logger) Repository {
+	stmts.Register(SAVE_PRODUCT, UPDATE_PRODUCT, DELETE_PRODUCT)
 	return &repository{
-		db: db,
+		db:     db,
+		stmts:  stmts,
+		logger: logger,
 	}
 }
 
 const (
-	GET_ALL_PRODUCTS = "SELECT * FROM products;"
+	GET_ALL_PRODUCTS = "SELECT id, site_id, description, expiration_rate, freezing_rate, height, length, netweight, product_code, recommended_freezing_temperature, width, product_type_id, seller_id FROM products WHERE site_id = COALESCE(?, site_id);"
 
-	GET_PRODUCT_BY_ID = "SELECT * FROM products WHERE id=?;"
+	GET_PRODUCT_BY_ID = "SELECT id, site_id, description, expiration_rate, freezing_rate, height, length, netweight, product_code, recommended_freezing_temperature, width, product_type_id, seller_id FROM products WHERE id=? AND site_id = COALESCE(?, site_id);"
 
-	EXISTS_PRODUCT = "SELECT product_code FROM products WHERE product_code=?;"
+	EXISTS_PRODUCT = "SELECT product_code FROM products WHERE product_code=? AND site_id = COALESCE(?, site_id);"
 
-	SAVE_PRODUCT = "INSERT INTO products(description,expiration_rate,freezing_rate,height,length,netweight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id) VALUES (?,?,?,?,?,?,?,?,?,?,?)"
+	SAVE_PRODUCT = "INSERT INTO products(site_id,description,expiration_rate,freezing_rate,height,length,netweight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id) VALUES (?,?,?,?,?,?,?,?,?,?,?,?)"
 	
-	UPDATE_PRODUCT = "UPDATE products SET description=?, expiration_rate=?, freezing_rate=?, height=?, length=?, netweight=?, product_code=?, recommended_freezing_temperature=?, width=?, product_type_id=?, seller_id=?  WHERE id=?"
+	UPDATE_PRODUCT = "UPDATE products SET description=?, expiration_rate=?, freezing_rate=?, height=?, length=?, netweight=?, product_code=?, recommended_freezing_temperature=?, width=?, product_type_id=?, seller_id=?  WHERE id=? AND site_id = COALESCE(?, site_id)"
 	
-	DELETE_PRODUCT = "DELETE FROM products WHERE id=?"
+	DELETE_PRODUCT = "DELETE FROM products WHERE id=? AND site_id = COALESCE(?, site_id)"
 
-	GET_PRODUCT_RECORDS_BY_PRODUCT_WITHOUT_ID = "SELECT p.id, product_code, COUNT(product_records.product_id) AS report_products_count FROM products p LEFT JOIN product_records on p.id = product_records.products_id GROUP BY id"
+	GET_PRODUCT_RECORDS_BY_PRODUCT_WITHOUT_ID = "SELECT p.id, product_code, COUNT(product_records.product_id) AS report_products_count FROM products p LEFT JOIN product_records on p.id = product_records.products_id WHERE p.site_id = COALESCE(?, p.site_id) GROUP BY id"
 
-	GET_PRODUCT_RECORDS_BY_PRODUCT_WITH_ID = "SELECT p.id, product_code, COUNT(product_records.product_id) AS report_products_count FROM products p LEFT JOIN product_records on p.id = product_records.products_id WHERE p.id = ? GROUP BY id"
+	GET_PRODUCT_RECORDS_BY_PRODUCT_WITH_ID = "SELECT p.id, product_code, COUNT(product_records.product_id) AS report_products_count FROM products p LEFT JOIN product_records on p.id = product_records.products_id WHERE p.id = ? AND p.site_id = COALESCE(?, p.site_id) GROUP BY id"
 )
 
 func (r *repository) GetAll(ctx context.Context) ([]domain.Product, error) {
-	rows, err := r.db.Query(GET_ALL_PRODUCTS)
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, GET_ALL_PRODUCTS, tenant.Filter(ctx))
 	if err != nil {
-		return nil, err
+		return nil, apperrors.FromDB(err)
 	}
+	defer rows.Close()
 
 	var products []domain.Product
 
 	for rows.Next() {
 		p := domain.Product{}
-		_ = rows.Scan(&p.ID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID)
+		if err := rows.Scan(&p.ID, &p.SiteID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
 		products = append(products, p)
 	}
 
-	return products, nil
+	return products, apperrors.FromDB(rows.Err())
 }
 
 func (r *repository) Get(ctx context.Context, id int) (domain.Product, error) {
-	row := r.db.QueryRow(GET_PRODUCT_BY_ID, id)
+	row := r.db.Writer().QueryRowContext(ctx, GET_PRODUCT_BY_ID, id, tenant.Filter(ctx))
 	p := domain.Product{}
-	err := row.Scan(&p.ID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID)
+	err := row.Scan(&p.ID, &p.SiteID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Product{}, ErrNotFound
+	}
 	if err != nil {
-		return domain.Product{}, err
+		return domain.Product{}, apperrors.FromDB(err)
 	}
 
 	return p, nil
 }
 
 func (r *repository) Exists(ctx context.Context, productID string) bool {
-	row := r.db.QueryRow(EXISTS_PRODUCT, productID)
+	row := r.db.Writer().QueryRowContext(ctx, EXISTS_PRODUCT, productID, tenant.Filter(ctx))
 	err := row.Scan(&productID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", "Exists", "error", err)
+	}
 	return err == nil
 }
 
 func (r *repository) Save(ctx context.Context, p domain.Product) (int, error) {
-	stmt, err := r.db.Prepare(SAVE_PRODUCT)
+	site, err := tenant.Site(ctx)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID)
+	stmt, err := r.stmts.Stmt(ctx, SAVE_PRODUCT)
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
+	}
+
+	res, err := stmt.ExecContext(ctx, site, p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
 	}
 
 	id, err := res.LastInsertId()
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
 	}
 
 	return int(id), nil
 }
 
 func (r *repository) Update(ctx context.Context, p domain.Product) error {
-	stmt, err := r.db.Prepare(UPDATE_PRODUCT)
+	stmt, err := r.stmts.Stmt(ctx, UPDATE_PRODUCT)
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
-	defer stmt.Close()
 
-	res, err := stmt.Exec(p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID, p.ID)
+	res, err := stmt.ExecContext(ctx, p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID, p.ID, tenant.Filter(ctx))
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	affected, err := res.RowsAffected()
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 	if affected < 1 {
 		return errors.New("error: no affected rows")
@@ -123,19 +145,19 @@ func (r *repository) Update(ctx context.Context, p domain.Product) error {
 }
 
 func (r *repository) Delete(ctx context.Context, id int) error {
-	stmt, err := r.db.Prepare(DELETE_PRODUCT)
+	stmt, err := r.stmts.Stmt(ctx, DELETE_PRODUCT)
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
-	res, err := stmt.Exec(id)
+	res, err := stmt.ExecContext(ctx, id, tenant.Filter(ctx))
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	affect, err := res.RowsAffected()
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	if affect < 1 {
@@ -148,28 +170,29 @@ func (r *repository) Delete(ctx context.Context, id int) error {
 func (r *repository) GetProductRecords(ctx context.Context, id string) (product_records_report []domain.ProductRecordsReport, err error) {
 	var rows *sql.Rows
 	if id == "" {
-		rows, err = r.db.Query(GET_PRODUCT_RECORDS_BY_PRODUCT_WITHOUT_ID)
+		rows, err = r.db.Reader(ctx).QueryContext(ctx, GET_PRODUCT_RECORDS_BY_PRODUCT_WITHOUT_ID, tenant.Filter(ctx))
 	} else {
 		if !r.Exists(ctx, id) {
-			return nil, errors.New("id does not exist")
+			return nil, ErrNotFound
 		}
-		rows, err = r.db.Query(GET_PRODUCT_RECORDS_BY_PRODUCT_WITH_ID, id)
+		rows, err = r.db.Reader(ctx).QueryContext(ctx, GET_PRODUCT_RECORDS_BY_PRODUCT_WITH_ID, id, tenant.Filter(ctx))
 	}
 
 	if err != nil {
-		return nil, err
+		return nil, apperrors.FromDB(err)
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var report domain.ProductRecordsReport
 		err = rows.Scan(&report.ProductID, &report.ProductDescription, &report.ProductRecordsCount)
 		if err != nil {
-			return nil, err
+			return nil, apperrors.FromDB(err)
 		}
 		product_records_report = append(product_records_report, report)
 	}
 
-	return product_records_report, nil
+	return product_records_report, apperrors.FromDB(rows.Err())
 }
 
 
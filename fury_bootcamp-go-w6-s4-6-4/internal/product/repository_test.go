@@ -10,11 +10,14 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	pkgdb "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 	"github.com/stretchr/testify/assert"
 )
 
 var (
-	c context.Context
+	c   = tenant.NewContext(context.Background(), "MLA")
 	Err = errors.New("Error")
 )
 
@@ -44,13 +47,13 @@ func TestRepositorySave(t *testing.T) {
 		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_PRODUCT))
 		mock.ExpectExec(regexp.QuoteMeta(SAVE_PRODUCT)).WillReturnResult(sqlmock.NewResult(1, 1))
 
-		columns := []string{"id", "description", "expiration_rate", "freezing_rate", "height", "length", "netweight", "product_code", "recommended_freezing_temperature", "width", "product_type_id", "seller_id"}
+		columns := []string{"id", "site_id", "description", "expiration_rate", "freezing_rate", "height", "length", "netweight", "product_code", "recommended_freezing_temperature", "width", "product_type_id", "seller_id"}
 		rows := sqlmock.NewRows(columns)
-		rows.AddRow(product_test.ID, product_test.Description, product_test.ExpirationRate, product_test.FreezingRate, product_test.Height, product_test.Length, product_test.Netweight, product_test.ProductCode, product_test.RecomFreezTemp, product_test.Width, product_test.ProductTypeID, product_test.SellerID)
-		mock.ExpectQuery(regexp.QuoteMeta(GET_PRODUCT_BY_ID)).WithArgs(1).WillReturnRows(rows)
+		rows.AddRow(product_test.ID, product_test.SiteID, product_test.Description, product_test.ExpirationRate, product_test.FreezingRate, product_test.Height, product_test.Length, product_test.Netweight, product_test.ProductCode, product_test.RecomFreezTemp, product_test.Width, product_test.ProductTypeID, product_test.SellerID)
+		mock.ExpectQuery(regexp.QuoteMeta(GET_PRODUCT_BY_ID)).WithArgs(1, "MLA").WillReturnRows(rows)
 
-		repository := NewRepository(db)
-		ctx := context.TODO()
+		repository := NewRepository(pkgdb.NewRouter(db, nil, logger.Discard()), pkgdb.NewStatements(db), logger.Discard())
+		ctx := tenant.NewContext(tenant.NewContext(context.TODO(), "MLA"), "MLA")
 
 		newID, err := repository.Save(ctx, product_test)
 		assert.NoError(t, err)
@@ -71,8 +74,8 @@ func TestRepositorySave(t *testing.T) {
 		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_PRODUCT))
 		mock.ExpectExec(regexp.QuoteMeta(SAVE_PRODUCT)).WillReturnError(Err)
 
-		repository := NewRepository(db)
-		ctx := context.TODO()
+		repository := NewRepository(pkgdb.NewRouter(db, nil, logger.Discard()), pkgdb.NewStatements(db), logger.Discard())
+		ctx := tenant.NewContext(tenant.NewContext(context.TODO(), "MLA"), "MLA")
 
 		id, err := repository.Save(ctx, product_test)
 
@@ -88,7 +91,7 @@ func TestGetAllOK(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	columns := []string{"id", "description", "expiration_rate", "freezing_rate", "height", "length", "netweight", "product_code", "recommended_freezing_temperature", "width", "product_type_id", "seller_id"}
+	columns := []string{"id", "site_id", "description", "expiration_rate", "freezing_rate", "height", "length", "netweight", "product_code", "recommended_freezing_temperature", "width", "product_type_id", "seller_id"}
 	rows := sqlmock.NewRows(columns)
 	products := []domain.Product{{
 		ID: 1,
@@ -107,13 +110,13 @@ func TestGetAllOK(t *testing.T) {
 	}
 
 	for _, product := range products {
-		rows.AddRow(product.ID, product.Description, product.ExpirationRate, product.FreezingRate, product.Height, product.Length, product.Netweight, product.ProductCode, product.RecomFreezTemp, product.Width, product.ProductTypeID, product.SellerID)
+		rows.AddRow(product.ID, product.SiteID, product.Description, product.ExpirationRate, product.FreezingRate, product.Height, product.Length, product.Netweight, product.ProductCode, product.RecomFreezTemp, product.Width, product.ProductTypeID, product.SellerID)
 	}
 	
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM products")).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(GET_ALL_PRODUCTS)).WithArgs("MLA").WillReturnRows(rows)
 
-	repository := NewRepository(db)
-	resultProducts, err := repository.GetAll(context.TODO())
+	repository := NewRepository(pkgdb.NewRouter(db, nil, logger.Discard()), pkgdb.NewStatements(db), logger.Discard())
+	resultProducts, err := repository.GetAll(tenant.NewContext(context.TODO(), "MLA"))
 
 	assert.NoError(t, err)
 	assert.Equal(t, products, resultProducts)
@@ -126,12 +129,12 @@ func TestGetAllConflict(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM products")).WillReturnError(sql.ErrConnDone)
+	mock.ExpectQuery(regexp.QuoteMeta(GET_ALL_PRODUCTS)).WillReturnError(sql.ErrConnDone)
 
-	repository := NewRepository(db)
+	repository := NewRepository(pkgdb.NewRouter(db, nil, logger.Discard()), pkgdb.NewStatements(db), logger.Discard())
 	result, err := repository.GetAll(c)
 
-	assert.Equal(t, sql.ErrConnDone, err)
+	assert.ErrorIs(t, err, sql.ErrConnDone)
 	assert.Empty(t, result)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -142,7 +145,7 @@ func TestGetOneOk(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	rows := sqlmock.NewRows([]string{"id", "description", "expiration_rate", "freezing_rate", "height", "length", "netweight", "product_code", "recommended_freezing_temperature", "width", "product_type_id", "seller_id"})
+	rows := sqlmock.NewRows([]string{"id", "site_id", "description", "expiration_rate", "freezing_rate", "height", "length", "netweight", "product_code", "recommended_freezing_temperature", "width", "product_type_id", "seller_id"})
 	product := domain.Product{
 		ID: 1,
 		Description: "producto congelado",
@@ -157,10 +160,10 @@ func TestGetOneOk(t *testing.T) {
 		ProductTypeID:2,
 		SellerID:1,
 	}
-	rows.AddRow(product.ID, product.Description, product.ExpirationRate, product.FreezingRate, product.Height, product.Length, product.Netweight, product.ProductCode, product.RecomFreezTemp, product.Width, product.ProductTypeID, product.SellerID)
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM products WHERE id=?")).WithArgs(product.ID).WillReturnRows(rows)
+	rows.AddRow(product.ID, product.SiteID, product.Description, product.ExpirationRate, product.FreezingRate, product.Height, product.Length, product.Netweight, product.ProductCode, product.RecomFreezTemp, product.Width, product.ProductTypeID, product.SellerID)
+	mock.ExpectQuery(regexp.QuoteMeta(GET_PRODUCT_BY_ID)).WithArgs(product.ID, "MLA").WillReturnRows(rows)
 
-	repository := NewRepository(db)
+	repository := NewRepository(pkgdb.NewRouter(db, nil, logger.Discard()), pkgdb.NewStatements(db), logger.Discard())
 	result, err := repository.Get(c, product.ID)
 	assert.Equal(t, product, result)
 	assert.NoError(t, err)
@@ -175,16 +178,16 @@ func TestDeleteOK(t *testing.T) {
 	id := 1
 
 	mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM products WHERE id=?"))
-	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM products WHERE id=?")).WithArgs(id).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM products WHERE id=?")).WithArgs(id, "MLA").WillReturnResult(sqlmock.NewResult(1, 1))
 
-	repository := NewRepository(db)
+	repository := NewRepository(pkgdb.NewRouter(db, nil, logger.Discard()), pkgdb.NewStatements(db), logger.Discard())
 	err = repository.Delete(c, id)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM products WHERE id=?")).WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(regexp.QuoteMeta(GET_PRODUCT_BY_ID)).WillReturnError(sql.ErrNoRows)
 	_, err = repository.Get(c, id)
-	assert.ErrorContains(t, sql.ErrNoRows, err.Error())
+	assert.ErrorIs(t, err, ErrNotFound)
 }
 
 func Test_DeleteFail(t *testing.T) {
@@ -208,12 +211,12 @@ func Test_DeleteFail(t *testing.T) {
 		SellerID:1,
 	}
 
-	mock.ExpectPrepare(regexp.QuoteMeta(DELETE_PRODUCT)).ExpectExec().WithArgs(product_test.ID).WillReturnError(Err)
+	mock.ExpectPrepare(regexp.QuoteMeta(DELETE_PRODUCT)).ExpectExec().WithArgs(product_test.ID, "MLA").WillReturnError(Err)
 
-	repo := NewRepository(db)
+	repo := NewRepository(pkgdb.NewRouter(db, nil, logger.Discard()), pkgdb.NewStatements(db), logger.Discard())
 
 	// act
-	err = repo.Delete(context.TODO(), int(product_test.ID))
+	err = repo.Delete(tenant.NewContext(context.TODO(), "MLA"), int(product_test.ID))
 
 	// assert
 	assert.EqualError(t, err, Err.Error())
@@ -243,10 +246,10 @@ func Test_UpdateOK(t *testing.T) {
 	product := product_test
 
 	mock.ExpectPrepare(regexp.QuoteMeta(UPDATE_PRODUCT)).
-		ExpectExec().WithArgs(product.Description, product.ExpirationRate, product.FreezingRate, product.Height, product.Length, product.Netweight, product.ProductCode, product.RecomFreezTemp, product.Width, product.ProductTypeID, product.SellerID, product.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		ExpectExec().WithArgs(product.Description, product.ExpirationRate, product.FreezingRate, product.Height, product.Length, product.Netweight, product.ProductCode, product.RecomFreezTemp, product.Width, product.ProductTypeID, product.SellerID, product.ID, "MLA").WillReturnResult(sqlmock.NewResult(0, 1))
 
-	repo := NewRepository(db)
-	err = repo.Update(context.TODO(), product)
+	repo := NewRepository(pkgdb.NewRouter(db, nil, logger.Discard()), pkgdb.NewStatements(db), logger.Discard())
+	err = repo.Update(tenant.NewContext(context.TODO(), "MLA"), product)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -256,11 +259,11 @@ func TestRepositoryGetWithTimeout(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 	productId := 1
-	columns := []string{"id", "description", "expiration_rate", "freezing_rate", "height", "length", "netweight", "product_code", "recommended_freezing_temperature", "width", "product_type_id", "seller_id"}
+	columns := []string{"id", "site_id", "description", "expiration_rate", "freezing_rate", "height", "length", "netweight", "product_code", "recommended_freezing_temperature", "width", "product_type_id", "seller_id"}
 	rows := sqlmock.NewRows(columns)
-	rows.AddRow(productId, "producto congelado", 2, 3, 20.1, 30.2, 15.2, "j3l4k5", 20.0, 30.6, 2, 1)
-	mock.ExpectQuery("select id, description, expiration_rate, freezing_rate, height, length, netweight, product_code, recommended_freezing_temperature, width, product_type_id, seller_id").WillDelayFor(10 * time.Second).WillReturnRows(rows)
-	repository := NewRepository(db)
+	rows.AddRow(productId, "MLA", "producto congelado", 2, 3, 20.1, 30.2, 15.2, "j3l4k5", 20.0, 30.6, 2, 1)
+	mock.ExpectQuery("select id, site_id, description, expiration_rate, freezing_rate, height, length, netweight, product_code, recommended_freezing_temperature, width, product_type_id, seller_id").WillDelayFor(10 * time.Second).WillReturnRows(rows)
+	repository := NewRepository(pkgdb.NewRouter(db, nil, logger.Discard()), pkgdb.NewStatements(db), logger.Discard())
 	c, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -274,7 +277,7 @@ func Test_Repository_Save_Prepare_Fail(t *testing.T) {
 	assert.NoError(t, err)
 
 	mock.ExpectPrepare("INSERT INTO products").WillReturnError(errors.New("insert prepare error"))
-	repository := NewRepository(db)
+	repository := NewRepository(pkgdb.NewRouter(db, nil, logger.Discard()), pkgdb.NewStatements(db), logger.Discard())
 	product := domain.Product{
 		ID: 1,
 		Description: "producto congelado",
@@ -289,7 +292,7 @@ func Test_Repository_Save_Prepare_Fail(t *testing.T) {
 		ProductTypeID:2,
 		SellerID:1,
 	}
-	id, err := repository.Save(context.Background(), product)
+	id, err := repository.Save(tenant.NewContext(context.Background(), "MLA"), product)
 	assert.Error(t, err)
 	assert.Equal(t, id, 0)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -301,7 +304,7 @@ func Test_Repository_Save_Exec_Fail(t *testing.T) {
 
 	mock.ExpectPrepare("INSERT INTO products")
 	mock.ExpectExec("INSERT INTO products").WillReturnError(errors.New("insert exec error"))
-	repository := NewRepository(db)
+	repository := NewRepository(pkgdb.NewRouter(db, nil, logger.Discard()), pkgdb.NewStatements(db), logger.Discard())
 	product := domain.Product{
 		ID: 1,
 		Description: "producto congelado",
@@ -316,7 +319,7 @@ func Test_Repository_Save_Exec_Fail(t *testing.T) {
 		ProductTypeID:2,
 		SellerID:1,
 	}
-	id, err := repository.Save(context.Background(), product)
+	id, err := repository.Save(tenant.NewContext(context.Background(), "MLA"), product)
 	assert.Error(t, err)
 	assert.Equal(t, id, 0)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -343,12 +346,12 @@ func Test_DeleteFailRowsAffected(t *testing.T) {
 		SellerID:1,
 	}
 
-	mock.ExpectPrepare(regexp.QuoteMeta(DELETE_PRODUCT)).ExpectExec().WithArgs(product_test.ID).WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectPrepare(regexp.QuoteMeta(DELETE_PRODUCT)).ExpectExec().WithArgs(product_test.ID, "MLA").WillReturnResult(sqlmock.NewResult(1, 2))
 
-	repo := NewRepository(db)
+	repo := NewRepository(pkgdb.NewRouter(db, nil, logger.Discard()), pkgdb.NewStatements(db), logger.Discard())
 
 	// act
-	err = repo.Delete(context.TODO(), int(product_test.ID))
+	err = repo.Delete(tenant.NewContext(context.TODO(), "MLA"), int(product_test.ID))
 
 	// assert
 	assert.Nil(t, err, nil)
@@ -374,10 +377,10 @@ func Test_UpdateFail(t *testing.T) {
 		SellerID:1,
 	}
 	product := product_test
-	mock.ExpectPrepare(regexp.QuoteMeta(UPDATE_PRODUCT)).ExpectExec().WithArgs(product.Description, product.ExpirationRate, product.FreezingRate, product.Height, product.Length, product.Netweight, product.ProductCode, product.RecomFreezTemp, product.Width, product.ProductTypeID, product.SellerID, product.ID).WillReturnError(Err)
+	mock.ExpectPrepare(regexp.QuoteMeta(UPDATE_PRODUCT)).ExpectExec().WithArgs(product.Description, product.ExpirationRate, product.FreezingRate, product.Height, product.Length, product.Netweight, product.ProductCode, product.RecomFreezTemp, product.Width, product.ProductTypeID, product.SellerID, product.ID, "MLA").WillReturnError(Err)
 		
-	repo := NewRepository(db)
-	err = repo.Update(context.TODO(), product_test)
+	repo := NewRepository(pkgdb.NewRouter(db, nil, logger.Discard()), pkgdb.NewStatements(db), logger.Discard())
+	err = repo.Update(tenant.NewContext(context.TODO(), "MLA"), product_test)
 
 	assert.EqualError(t, err, Err.Error())
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -2,14 +2,16 @@ package product
 
 import (
 	"context"
-	"errors"
+	"log/slog"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 // Errors
 var (
-	ErrNotFound = errors.New("product not found")
+	ErrNotFound          = apperrors.NotFound("product not found")
+	ErrProductCodeExists = apperrors.Conflict("product_code already exists")
 )
 
 // Paso 1. Se debe generar la interface Service con todos sus métodos.
@@ -25,12 +27,14 @@ type Service interface {
 // Paso 2. Se debe generar la estructura service que contenga el repositorio.
 type service struct {
 	repository Repository
+	logger     *slog.Logger
 }
 
 // Paso 3. Se debe generar una función que devuelva el Servicio.
-func NewService(r Repository) Service {
+func NewService(r Repository, logger *slog.Logger) Service {
 	return &service{
 		repository: r,
+		logger:     logger,
 	}
 }
 
@@ -67,10 +71,11 @@ func (s *service) Save(ctx context.Context, description string, expiration_rate
 			return domain.Product{}, err
 		}
 		newProduct.ID = id
+		s.logger.InfoContext(ctx, "product created", "product_id", id)
 		return newProduct, nil
 	}
 
-	return domain.Product{}, errors.New("product_code already exists")
+	return domain.Product{}, ErrProductCodeExists
 
 }
 
@@ -78,7 +83,7 @@ func (s *service) Save(ctx context.Context, description string, expiration_rate
 func (s *service) Get(ctx context.Context, id int) (domain.Product, error) {
 	pr, err := s.repository.Get(ctx, id)
 	if err != nil {
-		return domain.Product{}, ErrNotFound
+		return domain.Product{}, err
 	}
 	return pr, nil
 }
@@ -87,7 +92,7 @@ func (s *service) Get(ctx context.Context, id int) (domain.Product, error) {
 func (s *service) Delete(ctx context.Context, id int) error {
 	_, err := s.repository.Get(ctx, id)
 	if err != nil {
-		return ErrNotFound
+		return err
 	}
 
 	return s.repository.Delete(ctx, id)
@@ -97,7 +102,7 @@ func (s *service) Delete(ctx context.Context, id int) error {
 func (s *service) Update(ctx context.Context, id int, description string, expiration_rate *int, freezing_rate *int, height *float32, length *float32, netweight *float32, product_code string, recommended_freezing_temperature *float32, width *float32, product_type_id *int, seller_id *int) (domain.Product, error) {
 	p, err := s.repository.Get(ctx, id)
 	if err != nil {
-		return domain.Product{}, ErrNotFound
+		return domain.Product{}, err
 	}
 	if description != "" {
 		p.Description = description
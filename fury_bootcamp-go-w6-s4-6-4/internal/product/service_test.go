@@ -2,16 +2,16 @@ package product
 
 import (
 	"context"
-	"errors"
 	"testing"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/products"
 	"github.com/stretchr/testify/assert"
 )
 
 //Declaración de variables globales
-var ctx context.Context
+var ctx = context.Background()
 var database = []domain.Product{
     {
         ID: 1,
@@ -67,7 +67,7 @@ func TestCreateOk(t *testing.T) {
     mockRepository := products.MockRepositoryProduct{}
 
     //Act
-    service := NewService(&mockRepository)
+    service := NewService(&mockRepository, logger.Discard())
     result, err := service.Save(ctx, p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID)
     
     //Assert
@@ -96,11 +96,11 @@ func TestCreateConflict(t *testing.T) {
         ProductTypeID: 12345,
         SellerID: 7,
     }
-    expectedError := errors.New("product_code already exists")
+    expectedError := ErrProductCodeExists
     mockRepository := products.MockRepositoryProduct{DataMock: database}
 
     //Act
-    service := NewService(&mockRepository)
+    service := NewService(&mockRepository, logger.Discard())
     result, err := service.Save(ctx, p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID)
 
     //Assert
@@ -123,7 +123,7 @@ func TestFindAll(t *testing.T) {
     }
 	
 	// Act
-    service := NewService(&mockRepository)
+    service := NewService(&mockRepository, logger.Discard())
     result, err := service.GetAll(ctx)
 
 	// Assert
@@ -137,14 +137,14 @@ func TestFindAll(t *testing.T) {
 func TestFindByIdNonExistent(t *testing.T) {
     
     //Arrange
-    expectedError := errors.New("product not found")
+    expectedError := ErrNotFound
     
     mockRepository := products.MockRepositoryProduct{
         DataMock: database,
     }
 
     //Act
-    service := NewService(&mockRepository)
+    service := NewService(&mockRepository, logger.Discard())
     result, err := service.Get(ctx, 5)
 
     //Assert
@@ -165,7 +165,7 @@ func TestFindByIdExistent(t *testing.T) {
     }
 
     //Act
-    service := NewService(&mockRepository)
+    service := NewService(&mockRepository, logger.Discard())
     result, err := service.Get(ctx, pr.ID)
 
     //Assert
@@ -199,7 +199,7 @@ func TestUpdateExistent(t *testing.T) {
     }
 
     //Act
-    service := NewService(&mockRepository)
+    service := NewService(&mockRepository, logger.Discard())
     result, err := service.Update(ctx, p.ID, p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID)
 
     //Assert
@@ -214,7 +214,7 @@ func TestUpdateExistent(t *testing.T) {
 func TestUpdateNonExistent(t *testing.T) {
     
     //Arrange
-    expectedError := errors.New("product not found")
+    expectedError := ErrNotFound
 
     p := domain.Product{
         ID: 8,
@@ -235,7 +235,7 @@ func TestUpdateNonExistent(t *testing.T) {
         DataMock: database,
     }
     //Act
-    service := NewService(&mockRepository)
+    service := NewService(&mockRepository, logger.Discard())
     result, err := service.Update(ctx, p.ID, p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID)
 
     //Assert
@@ -250,7 +250,7 @@ func TestUpdateNonExistent(t *testing.T) {
 func TestDeleteNonExistent(t *testing.T) {
     
     //Arrange
-    expectedError := errors.New("product not found")
+    expectedError := ErrNotFound
 
     p := domain.Product {
         ID: 8,
@@ -271,7 +271,7 @@ func TestDeleteNonExistent(t *testing.T) {
     }
 
     //Act
-    service := NewService(&mockRepository)
+    service := NewService(&mockRepository, logger.Discard())
     err := service.Delete(ctx, p.ID)
 
     //Assert
@@ -301,14 +301,14 @@ func TestDeleteOk(t *testing.T) {
         SellerID: 6,
     }
 
-    expectedError := errors.New("product not found")
+    expectedError := ErrNotFound
 
     mockRepository := products.MockRepositoryProduct{
         DataMock: database,
     }
 
     //Act
-    service := NewService(&mockRepository)
+    service := NewService(&mockRepository, logger.Discard())
     err := service.Delete(ctx, p.ID)
     result, errService := service.Get(ctx, p.ID)
 
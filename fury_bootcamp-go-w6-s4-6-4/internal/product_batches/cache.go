@@ -0,0 +1,40 @@
+package productbatches
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/cache"
+)
+
+// reportTags are the tables the products report sums.
+var reportTags = []string{"sections", "product_batches"}
+
+type cachedService struct {
+	Service
+	cache *cache.Cache
+}
+
+// WithCache returns s with the products report of each section cached in c,
+// and new batches invalidating it. A nil c leaves s as is.
+func WithCache(s Service, c *cache.Cache) Service {
+	if c == nil {
+		return s
+	}
+	return &cachedService{Service: s, cache: c}
+}
+
+func (s *cachedService) ReadPB(ctx context.Context, id int) (domain.ReportProduct, error) {
+	return cache.Load(ctx, s.cache, "sections.report_products:"+strconv.Itoa(id), reportTags, func(ctx context.Context) (domain.ReportProduct, error) {
+		return s.Service.ReadPB(ctx, id)
+	})
+}
+
+func (s *cachedService) CreatePB(ctx context.Context, pb domain.Product_batches) (int, error) {
+	id, err := s.Service.CreatePB(ctx, pb)
+	if err == nil {
+		s.cache.Invalidate(ctx, "product_batches")
+	}
+	return id, err
+}
@@ -3,25 +3,34 @@ package productbatches
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"log/slog"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 )
 
 
 const(
 	//SAVE_MOVIE = "INSERT INTO movies (title, rating, awards, length, genre_id) VALUES (?, ?, ?, ?, ?);"
 
-	CREATE_PRODUCT_BATCH = `INSERT INTO product_batches(batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minimum_temperature, sections_id, products_id) VALUES(?,?,?,?,?,?,?,?,?,?);`
+	CREATE_PRODUCT_BATCH = `INSERT INTO product_batches(site_id, batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minimum_temperature, sections_id, products_id) VALUES(?,?,?,?,?,?,?,?,?,?,?);`
 
-	GET_PRODUCT_BATCH = `SELECT * FROM product_batches WHERE id = ?;`
+	GET_PRODUCT_BATCH = `SELECT id, site_id, batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minimum_temperature, sections_id, products_id FROM product_batches WHERE id = ? AND site_id = COALESCE(?, site_id);`
 
-	READ_PRODUCT_BATCH = `SELECT p.sections_id, s.section_number, SUM(p.current_quantity) cq FROM melisprint.product_batches p INNER JOIN melisprint.sections s ON p.sections_id = s.id WHERE s.id=? GROUP BY p.sections_id;`
+	READ_PRODUCT_BATCH = `SELECT p.sections_id, s.section_number, SUM(p.current_quantity) cq FROM melisprint.product_batches p INNER JOIN melisprint.sections s ON p.sections_id = s.id WHERE s.id=? AND s.site_id = COALESCE(?, s.site_id) GROUP BY p.sections_id;`
 
-	EXISTS_SECTION_ID = `SELECT sections_id FROM melisprint.product_batches WHERE sections_id=?;`
+	EXISTS_SECTION_ID = `SELECT sections_id FROM melisprint.product_batches WHERE sections_id=? AND site_id = COALESCE(?, site_id);`
 
-	EXISTS_PRODUCT_ID = `SELECT products_id FROM melisprint.product_batches WHERE products_id=?;`
+	EXISTS_PRODUCT_ID = `SELECT products_id FROM melisprint.product_batches WHERE products_id=? AND site_id = COALESCE(?, site_id);`
 
-	EXISTS = `SELECT batch_number FROM melisprint.product_batches WHERE batch_number =?;`
+	EXISTS = `SELECT batch_number FROM melisprint.product_batches WHERE batch_number =? AND site_id = COALESCE(?, site_id);`
+
+	SECTION_WAREHOUSE = `SELECT warehouse_id FROM sections WHERE id=? AND site_id = COALESCE(?, site_id);`
+
+	COUNT_TEMPERATURE_INCIDENTS = `SELECT COUNT(*) FROM product_batches WHERE current_quantity > 0 AND current_temperature < minimum_temperature AND site_id = COALESCE(?, site_id);`
 )
 
 
@@ -32,31 +41,44 @@ type Repository interface {
 	ExistenceProductId(ctx context.Context, product_id int) bool
 	GetPB(ctx context.Context, id int) (domain.Product_batches, error)
 	ExistsProductBatches(ctx context.Context, batch_number int) bool
+	// SectionWarehouse returns the warehouse the section section_id is in.
+	SectionWarehouse(ctx context.Context, section_id int) (int, error)
+	CountTemperatureIncidents(ctx context.Context) (int, error)
 }
 
-type repository struct{
-	db *sql.DB
+type repository struct {
+	db     *database.Router
+	stmts  *database.Statements
+	logger *slog.Logger
 }
 
 
-func NewRepository(db *sql.DB) Repository{
+func NewRepository(db *database.Router, stmts *database.Statements, logger *slog.Logger) Repository {
+	stmts.Register(CREATE_PRODUCT_BATCH)
 	return &repository{
-		db: db,
+		db:     db,
+		stmts:  stmts,
+		logger: logger,
 	}
 }
 
 
 func (r *repository) CreatePB(ctx context.Context, pb domain.Product_batches) (int, error){
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return 0, err
+	}
+
 	query := CREATE_PRODUCT_BATCH
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.stmts.Stmt(ctx, query)
 	if err != nil{
-		return 0, err
+		return 0, apperrors.FromDB(err)
 	}
 
-	res, err := stmt.Exec(&pb.BatchNumber, &pb.CurrentQuantity, &pb.CurrentTemperature, &pb.DueDate, &pb.InitialQuantity, &pb.ManufacturingDate, &pb.ManufacturingHour, &pb.MinimumTemperature, &pb.SectionId, &pb.ProductId)
+	res, err := stmt.ExecContext(ctx, site, &pb.BatchNumber, &pb.CurrentQuantity, &pb.CurrentTemperature, &pb.DueDate, &pb.InitialQuantity, &pb.ManufacturingDate, &pb.ManufacturingHour, &pb.MinimumTemperature, &pb.SectionId, &pb.ProductId)
 	if err != nil{
 		
-		return 0, err
+		return 0, apperrors.FromDB(err)
 		
 	}
 	
@@ -71,14 +93,17 @@ func (r *repository) CreatePB(ctx context.Context, pb domain.Product_batches) (i
 
 func (r *repository) ReadPB(ctx context.Context, id int) (domain.ReportProduct, error) {
 	query := READ_PRODUCT_BATCH
-	row := r.db.QueryRow(query,id)
+	row := r.db.Reader(ctx).QueryRowContext(ctx, query, id, tenant.Filter(ctx))
 	//s := domain.Section{}
 	//p := domain.Product_batches{}
 	data := domain.ReportProduct{}
 	
 	err := row.Scan(&data.SectionId, &data.SectionNumber, &data.CurrentQuantity)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ReportProduct{}, ErrNotFound
+	}
 	if err != nil {
-		return domain.ReportProduct{}, err
+		return domain.ReportProduct{}, apperrors.FromDB(err)
 	}
 	
 	
@@ -87,34 +112,64 @@ func (r *repository) ReadPB(ctx context.Context, id int) (domain.ReportProduct,
 
 func (r *repository) GetPB(ctx context.Context, id int) (domain.Product_batches, error){
 	query := GET_PRODUCT_BATCH
-	row := r.db.QueryRow(query, id)
+	row := r.db.Writer().QueryRowContext(ctx, query, id, tenant.Filter(ctx))
 	pb := domain.Product_batches{}
-	err := row.Scan(&pb.ID, &pb.BatchNumber, &pb.CurrentQuantity, &pb.CurrentTemperature, &pb.DueDate, &pb.InitialQuantity, &pb.ManufacturingDate, &pb.ManufacturingHour, &pb.MinimumTemperature, &pb.SectionId, &pb.ProductId)
+	err := row.Scan(&pb.ID, &pb.SiteID, &pb.BatchNumber, &pb.CurrentQuantity, &pb.CurrentTemperature, &pb.DueDate, &pb.InitialQuantity, &pb.ManufacturingDate, &pb.ManufacturingHour, &pb.MinimumTemperature, &pb.SectionId, &pb.ProductId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return pb, ErrNotFound
+	}
 	if err != nil{
-		return pb, err
+		return pb, apperrors.FromDB(err)
 	}
 	return pb, nil
 }
 
 func (r *repository) ExistenceSectionId(ctx context.Context, section_id int) bool { 
 	query := EXISTS_SECTION_ID
-	row := r.db.QueryRow(query, section_id)
+	row := r.db.Writer().QueryRowContext(ctx, query, section_id, tenant.Filter(ctx))
 	err := row.Scan(&section_id)
-
-
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", "ExistenceSectionId", "error", err)
+	}
 	return err == nil
 }
 
 func (r *repository) ExistenceProductId(ctx context.Context, product_id int) bool { 
 	query := EXISTS_PRODUCT_ID
-	row := r.db.QueryRow(query, product_id)
+	row := r.db.Writer().QueryRowContext(ctx, query, product_id, tenant.Filter(ctx))
 	err := row.Scan(&product_id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", "ExistenceProductId", "error", err)
+	}
 	return err == nil
 }
 
 func (r *repository) ExistsProductBatches(ctx context.Context, batch_number int) bool {
 	query := EXISTS
-	row := r.db.QueryRow(query, batch_number)
+	row := r.db.Writer().QueryRowContext(ctx, query, batch_number, tenant.Filter(ctx))
 	err := row.Scan(&batch_number)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", "ExistsProductBatches", "error", err)
+	}
 	return err == nil
-}
\ No newline at end of file
+}
+
+func (r *repository) SectionWarehouse(ctx context.Context, section_id int) (int, error) {
+	var warehouseID int
+	err := r.db.Writer().QueryRowContext(ctx, SECTION_WAREHOUSE, section_id, tenant.Filter(ctx)).Scan(&warehouseID)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	return warehouseID, nil
+}
+
+// CountTemperatureIncidents counts the batches still in stock whose current
+// temperature is below their minimum temperature.
+func (r *repository) CountTemperatureIncidents(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.Reader(ctx).QueryRowContext(ctx, COUNT_TEMPERATURE_INCIDENTS, tenant.Filter(ctx)).Scan(&count)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	return count, nil
+}
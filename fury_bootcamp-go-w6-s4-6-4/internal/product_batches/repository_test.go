@@ -9,12 +9,16 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 	"github.com/stretchr/testify/assert"
 )
 
 var (
 	errorExec    = errors.New("error in Exec")
 	errorPrepare = errors.New("error in Prepare")
+	ctx          = tenant.NewContext(context.TODO(), "MLA")
 )
 
 var FakeProductBatches = domain.Product_batches{
@@ -59,17 +63,17 @@ func TestCreateProductBatches(t *testing.T) {
 		mock.ExpectPrepare(regexp.QuoteMeta(CREATE_PRODUCT_BATCH))
 		mock.ExpectExec(regexp.QuoteMeta(CREATE_PRODUCT_BATCH)).WillReturnResult(sqlmock.NewResult(1, 1))
 
-		columns := []string{"id", "batch_number", "current_quantity", "current_temperature", "due_date", "initial_quantity", "manufacturing_date", "manufacturing_hour", "minimum_temperature", "product_id", "section_id"}
+		columns := []string{"id", "site_id", "batch_number", "current_quantity", "current_temperature", "due_date", "initial_quantity", "manufacturing_date", "manufacturing_hour", "minimum_temperature", "product_id", "section_id"}
 		rows := sqlmock.NewRows(columns)
-		rows.AddRow(FakeProductBatches.ID, FakeProductBatches.BatchNumber, FakeProductBatches.CurrentQuantity, FakeProductBatches.CurrentTemperature, FakeProductBatches.DueDate, FakeProductBatches.InitialQuantity, FakeProductBatches.ManufacturingDate, FakeProductBatches.ManufacturingHour, FakeProductBatches.MinimumTemperature, FakeProductBatches.ProductId, FakeProductBatches.SectionId)
+		rows.AddRow(FakeProductBatches.ID, "MLA", FakeProductBatches.BatchNumber, FakeProductBatches.CurrentQuantity, FakeProductBatches.CurrentTemperature, FakeProductBatches.DueDate, FakeProductBatches.InitialQuantity, FakeProductBatches.ManufacturingDate, FakeProductBatches.ManufacturingHour, FakeProductBatches.MinimumTemperature, FakeProductBatches.ProductId, FakeProductBatches.SectionId)
 
-		mock.ExpectQuery(regexp.QuoteMeta(GET_PRODUCT_BATCH)).WithArgs(1).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(GET_PRODUCT_BATCH)).WithArgs(1, "MLA").WillReturnRows(rows)
 
-		repo := NewRepository(db)
-		id, err := repo.CreatePB(context.Background(), FakeProductBatches)
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		id, err := repo.CreatePB(ctx, FakeProductBatches)
 		assert.NoError(t, err)
 
-		productBatches, err := repo.GetPB(context.TODO(), id)
+		productBatches, err := repo.GetPB(ctx, id)
 		assert.NoError(t, err)
 
 		assert.NotNil(t, productBatches)
@@ -82,9 +86,9 @@ func TestCreateProductBatches(t *testing.T) {
 		mock.ExpectPrepare(regexp.QuoteMeta(CREATE_PRODUCT_BATCH))
 		mock.ExpectExec(regexp.QuoteMeta(CREATE_PRODUCT_BATCH)).WillReturnError(errorExec)
 
-		repo := NewRepository(db)
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
-		id, err := repo.CreatePB(context.Background(), FakeProductBatches)
+		id, err := repo.CreatePB(ctx, FakeProductBatches)
 
 		assert.EqualError(t, err, errorExec.Error())
 		assert.Equal(t, 0, id)
@@ -94,8 +98,8 @@ func TestCreateProductBatches(t *testing.T) {
 	t.Run("Fail Prepare", func(t *testing.T) {
 		mock.ExpectPrepare(regexp.QuoteMeta(CREATE_PRODUCT_BATCH)).WillReturnError(errorPrepare)
 
-		repo := NewRepository(db)
-		id, err := repo.CreatePB(context.Background(), FakeProductBatches)
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		id, err := repo.CreatePB(ctx, FakeProductBatches)
 
 		assert.EqualError(t, err, errorPrepare.Error())
 		assert.Equal(t, 0, id)
@@ -116,10 +120,10 @@ func TestReadReportProduct(t *testing.T) {
 
 		rows.AddRow(&FakeProductBatches.SectionId, &FakeSection.SectionNumber, &FakeProductBatches.CurrentQuantity)
 
-		mock.ExpectQuery(regexp.QuoteMeta(READ_PRODUCT_BATCH)).WithArgs(1).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(READ_PRODUCT_BATCH)).WithArgs(1, "MLA").WillReturnRows(rows)
 
-		repo := NewRepository(db)
-		exist, err := repo.ReadPB(context.TODO(), 1)
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		exist, err := repo.ReadPB(ctx, 1)
 		log.Println()
 
 		assert.Nil(t, err)
@@ -138,11 +142,11 @@ func TestProductIdExistsOk(t *testing.T) {
 	rows := sqlmock.NewRows(colums)
 
 	rows.AddRow(FakeProductBatches.ProductId) // El 1 es el argumento que pide en la query de ExistenceProductId (id=?)
-	mock.ExpectQuery(regexp.QuoteMeta(EXISTS_PRODUCT_ID)).WithArgs(1).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(EXISTS_PRODUCT_ID)).WithArgs(1, "MLA").WillReturnRows(rows)
 
 	//instanciamos el repositorio
-	repo := NewRepository(db)
-	exist := repo.ExistenceProductId(context.TODO(), 1)
+	repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+	exist := repo.ExistenceProductId(ctx, 1)
 
 	assert.True(t, exist, "El productoId existe")
 
@@ -157,12 +161,25 @@ func TestSectionIdExistsOk(t *testing.T) {
 	rows := sqlmock.NewRows(colums)
 
 	rows.AddRow(FakeProductBatches.SectionId) // El 1 es el argumento que pide en la query de ExistenceProductId (id=?)
-	mock.ExpectQuery(regexp.QuoteMeta(EXISTS_SECTION_ID)).WithArgs(1).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(EXISTS_SECTION_ID)).WithArgs(1, "MLA").WillReturnRows(rows)
 
 	//instanciamos el repositorio
-	repo := NewRepository(db)
-	exist := repo.ExistenceSectionId(context.TODO(), 1)
+	repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+	exist := repo.ExistenceSectionId(ctx, 1)
 
 	assert.True(t, exist, "La seccionId existe")
 
 }
+
+func TestCreateProductBatchesWithoutSite(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+	id, err := repo.CreatePB(tenant.AllSites(context.TODO()), FakeProductBatches)
+
+	assert.ErrorIs(t, err, tenant.ErrSiteRequired)
+	assert.Equal(t, 0, id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,16 +3,19 @@ package productbatches
 import (
 	"context"
 	"errors"
-	"log"
+	"log/slog"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 // Errors
 var (
-	ErrNotFoundSectionID = errors.New("section_id not found")
-	ErrNotFoundProductID = errors.New("product_id not found")
-	ErrExists            = errors.New("product_batches already exists")
+	ErrNotFound          = apperrors.NotFound("product_batches not found")
+	ErrNotFoundSectionID = apperrors.ForeignKeyViolation("section_id not found")
+	ErrNotFoundProductID = apperrors.ForeignKeyViolation("product_id not found")
+	ErrExists            = apperrors.Conflict("product_batches already exists")
 )
 
 type Service interface {
@@ -25,18 +28,21 @@ type Service interface {
 
 type service struct {
 	repository Repository
+	outbox     events.Outbox
+	logger     *slog.Logger
 }
 
-func NewService(r Repository) Service {
+func NewService(r Repository, outbox events.Outbox, logger *slog.Logger) Service {
 	return &service{
 		repository: r,
+		outbox:     outbox,
+		logger:     logger,
 	}
 }
 
 func (s *service) CreatePB(ctx context.Context, pb domain.Product_batches) (int, error) {
 	existsSectionId := s.repository.ExistenceSectionId(ctx, pb.SectionId)
-	log.Println("Section_id", pb.SectionId)
-	log.Println("Product_id", pb.ProductId)
+	s.logger.DebugContext(ctx, "creating product batch", "section_id", pb.SectionId, "product_id", pb.ProductId)
 
 	if !existsSectionId {
 		return 0, ErrNotFoundSectionID
@@ -53,7 +59,38 @@ func (s *service) CreatePB(ctx context.Context, pb domain.Product_batches) (int,
 		return 0, ErrExists
 	}
 
-	return s.repository.CreatePB(ctx, pb)
+	warehouseID, err := s.repository.SectionWarehouse(ctx, pb.SectionId)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return 0, ErrNotFoundSectionID
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	err = s.outbox.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		var err error
+		id, err = s.repository.CreatePB(ctx, pb)
+		if err != nil {
+			return nil, err
+		}
+		pb.ID = id
+		return []events.Event{
+			{Type: events.ProductBatchReceived, AggregateID: id, WarehouseID: warehouseID, Data: pb},
+			{Type: events.StockChanged, AggregateID: pb.SectionId, WarehouseID: warehouseID, Data: events.StockChange{
+				WarehouseID:    warehouseID,
+				SectionID:      pb.SectionId,
+				ProductID:      pb.ProductId,
+				ProductBatchID: id,
+				Quantity:       pb.CurrentQuantity,
+			}},
+		}, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	s.logger.InfoContext(ctx, "product batch created", "product_batch_id", id)
+	return id, nil
 }
 
 func (s *service) ReadPB(ctx context.Context, id int) (domain.ReportProduct, error) {
@@ -6,6 +6,9 @@ import (
 	"testing"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	eventsmock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/events"
 	productbatches "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/product_batches"
 	"github.com/stretchr/testify/assert"
 )
@@ -40,7 +43,7 @@ func TestCreatePBService(t *testing.T) {
 
 		//Act
 		//Actuar
-		service := NewService(&mockRepository)
+		service := NewService(&mockRepository, events.Discard, logger.Discard())
 		result, err := service.CreatePB(context.Background(), domain.Product_batches{
 				
 			ID:             		2,
@@ -69,7 +72,7 @@ func TestCreatePBService(t *testing.T) {
 	mockRepository.Error = "product batch not found"
 	//Act
 	//Actuar
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, events.Discard, logger.Discard())
 	result, err := service.CreatePB(context.Background(), domain.Product_batches{
 		ID:             		1,
 		BatchNumber:    		1,
@@ -92,6 +95,25 @@ func TestCreatePBService(t *testing.T) {
 
 }
 
+func TestCreatePBRecordsEvents(t *testing.T) {
+	mockRepository := productbatches.MockRepository{
+		DataMockPB: []domain.Product_batches{{ID: 1, BatchNumber: 1, ProductId: 4, SectionId: 2}},
+		Warehouses: map[int]int{2: 3},
+	}
+	outbox := eventsmock.MockOutbox{}
+	service := NewService(&mockRepository, &outbox, logger.Discard())
+	pb := domain.Product_batches{ID: 5, BatchNumber: 5, CurrentQuantity: 40, InitialQuantity: 40, ProductId: 4, SectionId: 2}
+
+	_, err := service.CreatePB(context.Background(), pb)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []events.Event{
+		{Type: events.ProductBatchReceived, AggregateID: 5, WarehouseID: 3, Data: pb},
+		{Type: events.StockChanged, AggregateID: 2, WarehouseID: 3, Data: events.StockChange{
+			WarehouseID: 3, SectionID: 2, ProductID: 4, ProductBatchID: 5, Quantity: 40,
+		}},
+	}, outbox.Events)
+}
 
 func TestReadPBService(t *testing.T){
 	
@@ -103,7 +125,7 @@ func TestReadPBService(t *testing.T){
 	mockRepository.Error = "product batch not found"
 	//Act
 	//Actuar
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, events.Discard, logger.Discard())
 	_, err := service.ReadPB(context.Background(), 1)
 
 	//Assert
@@ -0,0 +1,30 @@
+package product_records
+
+import (
+	"context"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/cache"
+)
+
+type cachedService struct {
+	Service
+	cache *cache.Cache
+}
+
+// WithCache returns s with new records invalidating the records report of
+// the products cached in c. A nil c leaves s as is.
+func WithCache(s Service, c *cache.Cache) Service {
+	if c == nil {
+		return s
+	}
+	return &cachedService{Service: s, cache: c}
+}
+
+func (s *cachedService) Save(ctx context.Context, last_update_date string, purchase_price float64, sale_price float64, products_id int) (domain.ProductRecords, error) {
+	v, err := s.Service.Save(ctx, last_update_date, purchase_price, sale_price, products_id)
+	if err == nil {
+		s.cache.Invalidate(ctx, "product_records")
+	}
+	return v, err
+}
@@ -3,8 +3,13 @@ package product_records
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"log/slog"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 )
 
 type Repository interface {
@@ -14,52 +19,68 @@ type Repository interface {
 }
 
 type repository struct {
-	db *sql.DB
+	db     *database.Router
+	stmts  *database.Statements
+	logger *slog.Logger
 }
 
-func NewRepository(db *sql.DB) Repository {
+func NewRepository(db *database.Router, stmts *database.Statements, logger *slog.Logger) Repository {
+	stmts.Register(SAVE_PRODUCT_RECORD)
 	return &repository{
-		db: db,
+		db:     db,
+		stmts:  stmts,
+		logger: logger,
 	}
 }
 
 const (
-	SAVE_PRODUCT_RECORD = "INSERT INTO product_records (last_update_date, purchase_price, sale_price, products_id) VALUES (?, ?, ?, ?);"
+	SAVE_PRODUCT_RECORD = "INSERT INTO product_records (site_id, last_update_date, purchase_price, sale_price, products_id) VALUES (?, ?, ?, ?, ?);"
 
-	EXIST_PRODUCT_RECORD = "SELECT pr.id FROM product_records pr WHERE pr.id=?"
+	EXIST_PRODUCT_RECORD = "SELECT pr.id FROM product_records pr WHERE pr.id=? AND pr.site_id = COALESCE(?, pr.site_id)"
 
-	UNIQUE_PRODUCT = "SELECT p.id FROM products p WHERE p.id=?"
+	UNIQUE_PRODUCT = "SELECT p.id FROM products p WHERE p.id=? AND p.site_id = COALESCE(?, p.site_id)"
 )
 
 func (r *repository) ExistsProductRecord(ctx context.Context, id int) bool {
-	row := r.db.QueryRow(EXIST_PRODUCT_RECORD, id)
+	row := r.db.Writer().QueryRowContext(ctx, EXIST_PRODUCT_RECORD, id, tenant.Filter(ctx))
 	err := row.Scan(&id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", "ExistsProductRecord", "error", err)
+	}
 	return err == nil
 }
 
 func (r *repository) UniqueProduct(ctx context.Context, productID int) bool {
-	row := r.db.QueryRow(UNIQUE_PRODUCT, productID)
+	row := r.db.Writer().QueryRowContext(ctx, UNIQUE_PRODUCT, productID, tenant.Filter(ctx))
 	err := row.Scan(&productID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", "UniqueProduct", "error", err)
+	}
 	return err == nil
 }
 
 func (r *repository) Save(ctx context.Context, pr domain.ProductRecords) (int, error) {
-	stm, err := r.db.Prepare(SAVE_PRODUCT_RECORD)
-
+	site, err := tenant.Site(ctx)
 	if err != nil {
 		return 0, err
 	}
 
-	result, err := stm.Exec(pr.LastUpdateDate, pr.PurchasePrice, pr.SalePrice, pr.ProductID)
+	stm, err := r.stmts.Stmt(ctx, SAVE_PRODUCT_RECORD)
 
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
+	}
+
+	result, err := stm.ExecContext(ctx, site, pr.LastUpdateDate, pr.PurchasePrice, pr.SalePrice, pr.ProductID)
+
+	if err != nil {
+		return 0, apperrors.FromDB(err)
 	}
 
 	id, err := result.LastInsertId()
 
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
 	}
 
 	return int(id), nil
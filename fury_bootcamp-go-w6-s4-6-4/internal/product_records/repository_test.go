@@ -8,10 +8,13 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 	"github.com/stretchr/testify/assert"
 )
 
-var ctx context.Context
+var ctx = tenant.NewContext(context.Background(), "MLA")
 
 func TestRepositoryStoreOK(t *testing.T) {
 
@@ -23,7 +26,7 @@ func TestRepositoryStoreOK(t *testing.T) {
 	mock.ExpectExec("INSERT INTO product").WillReturnResult(sqlmock.NewResult(1, 1))
 	productId := 1
 
-	repository := NewRepository(db)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 	product_record := domain.ProductRecords{
 		ID:             productId,
 		LastUpdateDate: "2022-11-17",
@@ -56,12 +59,12 @@ func TestRepositoryStoreFail(t *testing.T) {
 
 	mock.ExpectPrepare(regexp.QuoteMeta(SAVE_PRODUCT_RECORD))
 	mock.ExpectExec(regexp.QuoteMeta(SAVE_PRODUCT_RECORD)).
-		WithArgs(pr.LastUpdateDate, pr.PurchasePrice, pr.SalePrice, pr.ProductID).
+		WithArgs("MLA", pr.LastUpdateDate, pr.PurchasePrice, pr.SalePrice, pr.ProductID).
 		WillReturnError(errors.New("INSERT ERROR"))
 
-	repository := NewRepository(db)
+	repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
-	id, err := repository.Save(context.TODO(), pr)
+	id, err := repository.Save(ctx, pr)
 
 	t.Log(err)
 
@@ -87,11 +90,11 @@ func TestExistProductRecordOK(t *testing.T) {
 	rows := sqlmock.NewRows(columns)
 
 	rows.AddRow(product_record_test.ID)
-	mock.ExpectQuery(regexp.QuoteMeta(EXIST_PRODUCT_RECORD)).WithArgs(1).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(EXIST_PRODUCT_RECORD)).WithArgs(1, "MLA").WillReturnRows(rows)
 
-	repo := NewRepository(db)
+	repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
-	resp := repo.ExistsProductRecord(context.TODO(), 1)
+	resp := repo.ExistsProductRecord(ctx, 1)
 
 	assert.True(t, resp)
 }
@@ -104,11 +107,11 @@ func TestExistProductRecordFail(t *testing.T) {
 	columns := []string{"id"}
 	rows := sqlmock.NewRows(columns)
 
-	mock.ExpectQuery(regexp.QuoteMeta(EXIST_PRODUCT_RECORD)).WithArgs(2).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(EXIST_PRODUCT_RECORD)).WithArgs(2, "MLA").WillReturnRows(rows)
 
-	repo := NewRepository(db)
+	repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
-	resp := repo.ExistsProductRecord(context.TODO(), 2)
+	resp := repo.ExistsProductRecord(ctx, 2)
 
 	assert.False(t, resp)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -138,11 +141,11 @@ func TestUniqueProductOK(t *testing.T) {
 	rows := sqlmock.NewRows(columns)
 
 	rows.AddRow(product_test.ID)
-	mock.ExpectQuery(regexp.QuoteMeta(UNIQUE_PRODUCT)).WithArgs(1).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(UNIQUE_PRODUCT)).WithArgs(1, "MLA").WillReturnRows(rows)
 
-	repo := NewRepository(db)
+	repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
-	resp := repo.UniqueProduct(context.TODO(), 1)
+	resp := repo.UniqueProduct(ctx, 1)
 
 	assert.True(t, resp)
 }
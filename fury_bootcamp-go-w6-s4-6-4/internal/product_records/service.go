@@ -2,9 +2,16 @@ package product_records
 
 import (
 	"context"
-	"errors"
+	"log/slog"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+// Errors
+var (
+	ErrExists          = apperrors.Conflict("error: product_records id already exists")
+	ErrProductNotFound = apperrors.ForeignKeyViolation("error: product id doesn't exists")
 )
 
 type Service interface {
@@ -12,12 +19,14 @@ type Service interface {
 }
 
 type service struct {
-	repo Repository
+	repo   Repository
+	logger *slog.Logger
 }
 
-func NewService(repo Repository) Service {
+func NewService(repo Repository, logger *slog.Logger) Service {
 	return &service{
-		repo: repo,
+		repo:   repo,
+		logger: logger,
 	}
 }
 
@@ -26,11 +35,11 @@ func (s *service) Save(ctx context.Context, last_update_date string, purchase_pr
 	var newProductRecord domain.ProductRecords
 
 	if s.repo.ExistsProductRecord(ctx, newProductRecord.ID) {
-		return domain.ProductRecords{}, errors.New("error: product_records id already exists")
+		return domain.ProductRecords{}, ErrExists
 	} 
 	
 	if !s.repo.UniqueProduct(ctx, products_id) {
-		return domain.ProductRecords{}, errors.New("error: product id doesn't exists")
+		return domain.ProductRecords{}, ErrProductNotFound
 	}
 	
 	newProductRecord = domain.ProductRecords{
@@ -46,5 +55,6 @@ func (s *service) Save(ctx context.Context, last_update_date string, purchase_pr
 	}
 
 	newProductRecord.ID = int(product_records_id)
+	s.logger.InfoContext(ctx, "product record created", "product_record_id", newProductRecord.ID)
 	return newProductRecord, nil
 }
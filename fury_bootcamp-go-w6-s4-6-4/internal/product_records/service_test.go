@@ -4,6 +4,7 @@ import (
 	"testing"
 	//"errors"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/productRecords"
 	"github.com/stretchr/testify/assert"
 )
@@ -24,7 +25,7 @@ func TestCreateOk(t *testing.T) {
 	}
 
 	//Act
-	service := NewService(&mockService)
+	service := NewService(&mockService, logger.Discard())
 	result, err := service.Save(ctx, product_record_test.LastUpdateDate, product_record_test.PurchasePrice, product_record_test.SalePrice, product_record_test.ProductID)
 
 	//Assert
@@ -55,7 +56,7 @@ func TestCreateOk(t *testing.T) {
 // 	}
 
 // 	expectedError := errors.New("product_records id already exists")
-// 	service := NewService(&mockService)
+// 	service := NewService(&mockService, logger.Discard())
 // 	result, err := service.Save(ctx, product_record_test.LastUpdateDate, product_record_test.PurchasePrice, product_record_test.SalePrice, product_record_test.ProductID)
 // 	assert.Nil(t, err)
 // 	assert.NotEqual(t, product_record_test_fail, result)
@@ -0,0 +1,56 @@
+package province
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/cache"
+)
+
+// reportTags are the tables the report goes through.
+var reportTags = []string{"provinces", "localities", "sellers", "carries"}
+
+type cachedService struct {
+	Service
+	cache *cache.Cache
+}
+
+// WithCache returns s with the report cached in c, and the writes to the
+// countries invalidating it. A nil c leaves s as is.
+func WithCache(s Service, c *cache.Cache) Service {
+	if c == nil {
+		return s
+	}
+	return &cachedService{Service: s, cache: c}
+}
+
+func (s *cachedService) Report(ctx context.Context, id int) ([]domain.ProvinceReport, error) {
+	return cache.Load(ctx, s.cache, "provinces.report:"+strconv.Itoa(id), reportTags, func(ctx context.Context) ([]domain.ProvinceReport, error) {
+		return s.Service.Report(ctx, id)
+	})
+}
+
+func (s *cachedService) Save(ctx context.Context, p domain.Province) (domain.Province, error) {
+	p, err := s.Service.Save(ctx, p)
+	if err == nil {
+		s.cache.Invalidate(ctx, "provinces")
+	}
+	return p, err
+}
+
+func (s *cachedService) Update(ctx context.Context, p domain.Province, id int) (domain.Province, error) {
+	p, err := s.Service.Update(ctx, p, id)
+	if err == nil {
+		s.cache.Invalidate(ctx, "provinces")
+	}
+	return p, err
+}
+
+func (s *cachedService) Delete(ctx context.Context, id int) error {
+	err := s.Service.Delete(ctx, id)
+	if err == nil {
+		s.cache.Invalidate(ctx, "provinces")
+	}
+	return err
+}
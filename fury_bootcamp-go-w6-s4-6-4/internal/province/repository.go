@@ -0,0 +1,183 @@
+package province
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+)
+
+// Repository encapsulates the storage of the provinces.
+type Repository interface {
+	GetAll(ctx context.Context) ([]domain.Province, error)
+	Get(ctx context.Context, id int) (domain.Province, error)
+	Exists(ctx context.Context, id int) bool
+	// ExistsName reports whether the country already has a province named
+	// name.
+	ExistsName(ctx context.Context, countryID int, name string) bool
+	CountryExists(ctx context.Context, countryID int) bool
+	// Save stores p under its ID, or under a new one when it's 0.
+	Save(ctx context.Context, p domain.Province) (int, error)
+	Update(ctx context.Context, p domain.Province) error
+	Delete(ctx context.Context, id int) error
+	// Report counts the sellers and the carries of every province, or only
+	// of the province id unless it's 0.
+	Report(ctx context.Context, id int) ([]domain.ProvinceReport, error)
+}
+
+type repository struct {
+	db     *database.Router
+	stmts  *database.Statements
+	logger *slog.Logger
+}
+
+func NewRepository(db *database.Router, stmts *database.Statements, logger *slog.Logger) Repository {
+	stmts.Register(SAVE_PROVINCE, UPDATE_PROVINCE, DELETE_PROVINCE)
+	return &repository{
+		db:     db,
+		stmts:  stmts,
+		logger: logger,
+	}
+}
+
+const (
+	GET_PROVINCES        = "SELECT id, site_id, province_name, country_id FROM provinces WHERE site_id = COALESCE(?, site_id) ORDER BY id"
+	GET_PROVINCE         = "SELECT id, site_id, province_name, country_id FROM provinces WHERE id=? AND site_id = COALESCE(?, site_id)"
+	EXISTS_PROVINCE      = "SELECT id FROM provinces WHERE id=? AND site_id = COALESCE(?, site_id)"
+	EXISTS_PROVINCE_NAME = "SELECT id FROM provinces WHERE country_id=? AND province_name=? AND site_id = COALESCE(?, site_id)"
+	EXISTS_COUNTRY       = "SELECT id FROM countries WHERE id=? AND site_id = COALESCE(?, site_id)"
+	SAVE_PROVINCE        = "INSERT INTO provinces (id, site_id, province_name, country_id) VALUES (?, ?, ?, ?)"
+	UPDATE_PROVINCE      = "UPDATE provinces SET province_name=?, country_id=? WHERE id=? AND site_id = COALESCE(?, site_id)"
+	DELETE_PROVINCE      = "DELETE FROM provinces WHERE id=? AND site_id = COALESCE(?, site_id)"
+
+	// The counts go through the localities of each province; a province
+	// without any counts zero.
+	GET_PROVINCES_REPORT = "SELECT p.id, p.province_name, " +
+		"(SELECT COUNT(*) FROM seller s JOIN locality l ON l.site_id = s.site_id AND l.id = s.locality_id WHERE l.site_id = p.site_id AND l.province_id = p.id), " +
+		"(SELECT COUNT(*) FROM carries c JOIN locality l ON l.site_id = c.site_id AND l.id = c.locality_id WHERE l.site_id = p.site_id AND l.province_id = p.id) " +
+		"FROM provinces p WHERE (? = 0 OR p.id = ?) AND p.site_id = COALESCE(?, p.site_id) ORDER BY p.id"
+)
+
+func (r *repository) GetAll(ctx context.Context) ([]domain.Province, error) {
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, GET_PROVINCES, tenant.Filter(ctx))
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+
+	provinces := []domain.Province{}
+	for rows.Next() {
+		var p domain.Province
+		if err := rows.Scan(&p.ID, &p.SiteID, &p.ProvinceName, &p.CountryID); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		provinces = append(provinces, p)
+	}
+	return provinces, apperrors.FromDB(rows.Err())
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.Province, error) {
+	var p domain.Province
+	err := r.db.Writer().QueryRowContext(ctx, GET_PROVINCE, id, tenant.Filter(ctx)).Scan(&p.ID, &p.SiteID, &p.ProvinceName, &p.CountryID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Province{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.Province{}, apperrors.FromDB(err)
+	}
+	return p, nil
+}
+
+func (r *repository) Exists(ctx context.Context, id int) bool {
+	return r.exists(ctx, "Exists", EXISTS_PROVINCE, id)
+}
+
+func (r *repository) ExistsName(ctx context.Context, countryID int, name string) bool {
+	return r.exists(ctx, "ExistsName", EXISTS_PROVINCE_NAME, countryID, name)
+}
+
+func (r *repository) CountryExists(ctx context.Context, countryID int) bool {
+	return r.exists(ctx, "CountryExists", EXISTS_COUNTRY, countryID)
+}
+
+func (r *repository) exists(ctx context.Context, check, query string, args ...interface{}) bool {
+	var id int
+	err := r.db.Writer().QueryRowContext(ctx, query, append(args, tenant.Filter(ctx))...).Scan(&id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", check, "error", err)
+	}
+	return err == nil
+}
+
+func (r *repository) Save(ctx context.Context, p domain.Province) (int, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt, err := r.stmts.Stmt(ctx, SAVE_PROVINCE)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	id := sql.NullInt64{Int64: int64(p.ID), Valid: p.ID != 0}
+	res, err := stmt.ExecContext(ctx, id, site, p.ProvinceName, p.CountryID)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	return int(lastID), nil
+}
+
+func (r *repository) Update(ctx context.Context, p domain.Province) error {
+	stmt, err := r.stmts.Stmt(ctx, UPDATE_PROVINCE)
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	_, err = stmt.ExecContext(ctx, p.ProvinceName, p.CountryID, p.ID, tenant.Filter(ctx))
+	return apperrors.FromDB(err)
+}
+
+func (r *repository) Delete(ctx context.Context, id int) error {
+	stmt, err := r.stmts.Stmt(ctx, DELETE_PROVINCE)
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	res, err := stmt.ExecContext(ctx, id, tenant.Filter(ctx))
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	if affected < 1 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *repository) Report(ctx context.Context, id int) ([]domain.ProvinceReport, error) {
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, GET_PROVINCES_REPORT, id, id, tenant.Filter(ctx))
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+
+	reports := []domain.ProvinceReport{}
+	for rows.Next() {
+		var report domain.ProvinceReport
+		if err := rows.Scan(&report.ProvinceID, &report.ProvinceName, &report.SellersCount, &report.CarriesCount); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, apperrors.FromDB(rows.Err())
+}
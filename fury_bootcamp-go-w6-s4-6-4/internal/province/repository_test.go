@@ -0,0 +1,76 @@
+package province
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRepository(t *testing.T) (Repository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	return NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard()), mock
+}
+
+func TestRepositorySave(t *testing.T) {
+	ctx := tenant.NewContext(context.TODO(), "MLA")
+
+	t.Run("should let the database generate the id when it's 0", func(t *testing.T) {
+		repo, mock := newTestRepository(t)
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_PROVINCE))
+		mock.ExpectExec(regexp.QuoteMeta(SAVE_PROVINCE)).
+			WithArgs(sql.NullInt64{}, "MLA", "Buenos Aires", 1).
+			WillReturnResult(sqlmock.NewResult(4, 1))
+
+		id, err := repo.Save(ctx, domain.Province{ProvinceName: "Buenos Aires", CountryID: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should keep an official id", func(t *testing.T) {
+		repo, mock := newTestRepository(t)
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_PROVINCE))
+		mock.ExpectExec(regexp.QuoteMeta(SAVE_PROVINCE)).
+			WithArgs(sql.NullInt64{Int64: 6, Valid: true}, "MLA", "Buenos Aires", 1).
+			WillReturnResult(sqlmock.NewResult(6, 1))
+
+		id, err := repo.Save(ctx, domain.Province{ID: 6, ProvinceName: "Buenos Aires", CountryID: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 6, id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepositoryExistsName(t *testing.T) {
+	repo, mock := newTestRepository(t)
+	mock.ExpectQuery(regexp.QuoteMeta(EXISTS_PROVINCE_NAME)).
+		WithArgs(1, "Buenos Aires", "MLA").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(6))
+
+	exists := repo.ExistsName(tenant.NewContext(context.TODO(), "MLA"), 1, "Buenos Aires")
+
+	assert.True(t, exists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepositoryReport(t *testing.T) {
+	repo, mock := newTestRepository(t)
+	rows := sqlmock.NewRows([]string{"id", "province_name", "sellers", "carries"}).AddRow(6, "Buenos Aires", 3, 2)
+	mock.ExpectQuery(regexp.QuoteMeta(GET_PROVINCES_REPORT)).WithArgs(6, 6, "MLA").WillReturnRows(rows)
+
+	reports, err := repo.Report(tenant.NewContext(context.TODO(), "MLA"), 6)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.ProvinceReport{{ProvinceID: 6, ProvinceName: "Buenos Aires", SellersCount: 3, CarriesCount: 2}}, reports)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,104 @@
+package province
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+// Errors
+var (
+	ErrNotFound        = apperrors.NotFound("province not found")
+	ErrExists          = apperrors.Conflict("id already exists")
+	ErrNameExists      = apperrors.Conflict("province_name already exists in the country")
+	ErrCountryNotFound = apperrors.ForeignKeyViolation("country id not found")
+)
+
+type Service interface {
+	Get(ctx context.Context, id int) (domain.Province, error)
+	GetAll(ctx context.Context) ([]domain.Province, error)
+	Save(ctx context.Context, p domain.Province) (domain.Province, error)
+	Update(ctx context.Context, p domain.Province, id int) (domain.Province, error)
+	Delete(ctx context.Context, id int) error
+	// Report counts the sellers and the carries of every province, or only
+	// of the province id unless it's 0.
+	Report(ctx context.Context, id int) ([]domain.ProvinceReport, error)
+}
+
+type service struct {
+	repository Repository
+	logger     *slog.Logger
+}
+
+func NewService(repository Repository, logger *slog.Logger) Service {
+	return &service{repository, logger}
+}
+
+func (s *service) Get(ctx context.Context, id int) (domain.Province, error) {
+	return s.repository.Get(ctx, id)
+}
+
+func (s *service) GetAll(ctx context.Context) ([]domain.Province, error) {
+	return s.repository.GetAll(ctx)
+}
+
+func (s *service) Save(ctx context.Context, p domain.Province) (domain.Province, error) {
+	if !s.repository.CountryExists(ctx, p.CountryID) {
+		return domain.Province{}, ErrCountryNotFound
+	}
+	if p.ID != 0 && s.repository.Exists(ctx, p.ID) {
+		return domain.Province{}, ErrExists
+	}
+	if s.repository.ExistsName(ctx, p.CountryID, p.ProvinceName) {
+		return domain.Province{}, ErrNameExists
+	}
+
+	id, err := s.repository.Save(ctx, p)
+	if err != nil {
+		return domain.Province{}, err
+	}
+	s.logger.InfoContext(ctx, "province created", "province_id", id)
+	return s.repository.Get(ctx, id)
+}
+
+func (s *service) Update(ctx context.Context, p domain.Province, id int) (domain.Province, error) {
+	original, err := s.repository.Get(ctx, id)
+	if err != nil {
+		return domain.Province{}, err
+	}
+	if p.ProvinceName == "" {
+		p.ProvinceName = original.ProvinceName
+	}
+	if p.CountryID == 0 {
+		p.CountryID = original.CountryID
+	}
+	p.ID, p.SiteID = original.ID, original.SiteID
+	if p == original {
+		return original, nil
+	}
+
+	if p.CountryID != original.CountryID && !s.repository.CountryExists(ctx, p.CountryID) {
+		return domain.Province{}, ErrCountryNotFound
+	}
+	if s.repository.ExistsName(ctx, p.CountryID, p.ProvinceName) {
+		return domain.Province{}, ErrNameExists
+	}
+	return p, s.repository.Update(ctx, p)
+}
+
+func (s *service) Delete(ctx context.Context, id int) error {
+	return s.repository.Delete(ctx, id)
+}
+
+func (s *service) Report(ctx context.Context, id int) ([]domain.ProvinceReport, error) {
+	reports, err := s.repository.Report(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if id != 0 && len(reports) == 0 {
+		return nil, ErrNotFound
+	}
+	return reports, nil
+}
@@ -0,0 +1,103 @@
+package province
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/province"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestService() (Service, *province.MockRepository) {
+	repo := &province.MockRepository{
+		DataMock: []domain.Province{
+			{ID: 6, ProvinceName: "Buenos Aires", CountryID: 1},
+			{ID: 14, ProvinceName: "Córdoba", CountryID: 1},
+		},
+		Countries: []int{1, 2},
+	}
+	return NewService(repo, logger.Discard()), repo
+}
+
+func TestServiceSave(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should generate the id", func(t *testing.T) {
+		service, _ := newTestService()
+
+		p, err := service.Save(ctx, domain.Province{ProvinceName: "Mendoza", CountryID: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Province{ID: 15, ProvinceName: "Mendoza", CountryID: 1}, p)
+	})
+	t.Run("should allow the same name in another country", func(t *testing.T) {
+		service, _ := newTestService()
+
+		_, err := service.Save(ctx, domain.Province{ProvinceName: "Córdoba", CountryID: 2})
+
+		assert.NoError(t, err)
+	})
+	t.Run("should not repeat a name in the country", func(t *testing.T) {
+		service, _ := newTestService()
+
+		_, err := service.Save(ctx, domain.Province{ProvinceName: "Córdoba", CountryID: 1})
+
+		assert.ErrorIs(t, err, ErrNameExists)
+	})
+	t.Run("should not reuse an id", func(t *testing.T) {
+		service, _ := newTestService()
+
+		_, err := service.Save(ctx, domain.Province{ID: 6, ProvinceName: "Mendoza", CountryID: 1})
+
+		assert.ErrorIs(t, err, ErrExists)
+	})
+	t.Run("should require an existing country", func(t *testing.T) {
+		service, _ := newTestService()
+
+		_, err := service.Save(ctx, domain.Province{ProvinceName: "Mendoza", CountryID: 9})
+
+		assert.ErrorIs(t, err, ErrCountryNotFound)
+	})
+}
+
+func TestServiceUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should keep the empty fields", func(t *testing.T) {
+		service, repo := newTestService()
+
+		p, err := service.Update(ctx, domain.Province{CountryID: 2}, 6)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Province{ID: 6, ProvinceName: "Buenos Aires", CountryID: 2}, p)
+		assert.Equal(t, p, repo.DataMock[0])
+	})
+	t.Run("should require an existing country", func(t *testing.T) {
+		service, _ := newTestService()
+
+		_, err := service.Update(ctx, domain.Province{CountryID: 9}, 6)
+
+		assert.ErrorIs(t, err, ErrCountryNotFound)
+	})
+	t.Run("should not take the name of another province of the country", func(t *testing.T) {
+		service, _ := newTestService()
+
+		_, err := service.Update(ctx, domain.Province{ProvinceName: "Córdoba"}, 6)
+
+		assert.ErrorIs(t, err, ErrNameExists)
+	})
+}
+
+func TestServiceReport(t *testing.T) {
+	service, repo := newTestService()
+	repo.Reports = []domain.ProvinceReport{{ProvinceID: 6, ProvinceName: "Buenos Aires", SellersCount: 3}}
+
+	reports, err := service.Report(context.Background(), 6)
+	assert.NoError(t, err)
+	assert.Equal(t, repo.Reports, reports)
+
+	_, err = service.Report(context.Background(), 14)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,41 @@
+package purchase_orders
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/cache"
+)
+
+// reportTags are the tables the purchase orders report counts.
+var reportTags = []string{"buyers", "purchase_orders"}
+
+type cachedService struct {
+	Service
+	cache *cache.Cache
+}
+
+// WithCache returns s with the purchase orders report cached in c, and new
+// purchase orders invalidating it. A nil c leaves s as is.
+func WithCache(s Service, c *cache.Cache) Service {
+	if c == nil {
+		return s
+	}
+	return &cachedService{Service: s, cache: c}
+}
+
+func (s *cachedService) GetAllByBuyerID(ctx context.Context, id int) ([]domain.ReportPurchaseOrders, error) {
+	return cache.Load(ctx, s.cache, "buyers.report_purchase_orders:"+strconv.Itoa(id), reportTags, func(ctx context.Context) ([]domain.ReportPurchaseOrders, error) {
+		return s.Service.GetAllByBuyerID(ctx, id)
+	})
+}
+
+func (s *cachedService) Save(ctx context.Context, orderNumber, trackingCode string, buyerID, productRecordID, orderStatusID int, orderDate *time.Time) (domain.PurchaseOrders, error) {
+	po, err := s.Service.Save(ctx, orderNumber, trackingCode, buyerID, productRecordID, orderStatusID, orderDate)
+	if err == nil {
+		s.cache.Invalidate(ctx, "purchase_orders")
+	}
+	return po, err
+}
@@ -3,9 +3,13 @@ package purchase_orders
 import (
 	"context"
 	"database/sql"
-	"log"
+	"errors"
+	"log/slog"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 )
 
 // Repository encapsulates the storage of the purchase orders.
@@ -14,51 +18,65 @@ type Repository interface {
 	ExistsBuyersID(ctx context.Context, buyerID int) bool
 	ExistsProductRecordsID(ctx context.Context, productRecordID int) bool
 	Save(ctx context.Context, p domain.PurchaseOrders) (int, error)
+	CountByStatus(ctx context.Context) (map[int]int, error)
 }
 
 type repository struct {
-	db *sql.DB
+	db     *database.Router
+	stmts  *database.Statements
+	logger *slog.Logger
 }
 
-func NewRepository(db *sql.DB) Repository {
+func NewRepository(db *database.Router, stmts *database.Statements, logger *slog.Logger) Repository {
+	stmts.Register(SAVE_BUYER)
 	return &repository{
-		db: db,
+		db:     db,
+		stmts:  stmts,
+		logger: logger,
 	}
 }
 
 const (
         SAVE_BUYER = `
                 INSERT INTO purchase_orders(
-                        order_number, order_date, tracking_code, buyers_id, product_records_id, order_status_id)
-                VALUES (?,?,?,?,?,?);`
-        EXISTS_PRODUCT_RECORD_ID =  `SELECT id FROM purchase_orders WHERE id=?;`
-        EXISTS_BUYER_ID =  `SELECT id FROM buyers WHERE id=?;`
+                        site_id, order_number, order_date, tracking_code, buyers_id, product_records_id, order_status_id)
+                VALUES (?,?,?,?,?,?,?);`
+        EXISTS_PRODUCT_RECORD_ID =  `SELECT id FROM purchase_orders WHERE id=? AND site_id = COALESCE(?, site_id);`
+        EXISTS_BUYER_ID =  `SELECT id FROM buyers WHERE id=? AND site_id = COALESCE(?, site_id);`
+        COUNT_BY_STATUS = `SELECT COALESCE(order_status_id, 0), COUNT(*) FROM purchase_orders WHERE site_id = COALESCE(?, site_id) GROUP BY order_status_id;`
         GET_REPORT_PURCHASEORDERS_BY_BUYERID = `
                 SELECT b.id, b.card_number_id, b.first_name, b.last_name, COUNT(*) AS purchase_orders_count
                 FROM purchase_orders p
-                LEFT JOIN buyers b ON p.buyers_id = b.id
-                WHERE b.id = ?
+                LEFT JOIN buyers b ON p.site_id = b.site_id AND p.buyers_id = b.id
+                WHERE b.id = ? AND p.site_id = COALESCE(?, p.site_id)
                 GROUP BY b.id;`
         GET_REPORT_PURCHASEORDERS = `
                 SELECT b.id, b.card_number_id, b.first_name, b.last_name, COUNT(*) AS purchase_orders_count
                 FROM purchase_orders p
-                LEFT JOIN buyers b ON p.buyers_id = b.id
+                LEFT JOIN buyers b ON p.site_id = b.site_id AND p.buyers_id = b.id
+                WHERE p.site_id = COALESCE(?, p.site_id)
                 GROUP BY b.id;`
 )
 
 
 func (r *repository) ExistsBuyersID(ctx context.Context, buyerID int) bool {
 	query := EXISTS_BUYER_ID
-	row := r.db.QueryRow(query, buyerID)
+	row := r.db.Writer().QueryRowContext(ctx, query, buyerID, tenant.Filter(ctx))
 	err := row.Scan(&buyerID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", "ExistsBuyersID", "error", err)
+	}
 	return err == nil
 }
 
 
 func (r *repository) ExistsProductRecordsID(ctx context.Context, productRecordID int) bool {
 	query := EXISTS_PRODUCT_RECORD_ID
-	row := r.db.QueryRow(query, productRecordID)
+	row := r.db.Writer().QueryRowContext(ctx, query, productRecordID, tenant.Filter(ctx))
 	err := row.Scan(&productRecordID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", "ExistsProductRecordsID", "error", err)
+	}
 	return err == nil
 }
 
@@ -72,48 +90,73 @@ func (r *repository) Get(ctx context.Context, id int) ([]domain.ReportPurchaseOr
 
         if id != 0 {
                 query = GET_REPORT_PURCHASEORDERS_BY_BUYERID
-	        rows, err = r.db.Query(query, id)
+	        rows, err = r.db.Reader(ctx).QueryContext(ctx, query, id, tenant.Filter(ctx))
         } else {
                 query = GET_REPORT_PURCHASEORDERS
-	        rows, err = r.db.Query(query)
+	        rows, err = r.db.Reader(ctx).QueryContext(ctx, query, tenant.Filter(ctx))
         }
 
 	if err != nil {
-		return nil, err
+		return nil, apperrors.FromDB(err)
 	}
+	defer rows.Close()
 
         var report []domain.ReportPurchaseOrders
 
 	for rows.Next() {
 		p := domain.ReportPurchaseOrders{}
-		_ = rows.Scan(&p.ID, &p.CardNumberID, &p.FirstName, &p.LastName, &p.PurchaseOrdersCount)
+		if err := rows.Scan(&p.ID, &p.CardNumberID, &p.FirstName, &p.LastName, &p.PurchaseOrdersCount); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
 		report = append(report, p)
 	}
 
-	return report, nil
+	return report, apperrors.FromDB(rows.Err())
 }
 
 
 func (r *repository) Save(ctx context.Context, p domain.PurchaseOrders) (int, error) {
-
-        query := SAVE_BUYER
-	stmt, err := r.db.Prepare(query)
+	site, err := tenant.Site(ctx)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(&p.OrderNumber, &p.OrderDate, &p.TrackingCode, &p.BuyerID, &p.ProductRecordID, &p.OrderStatusID)
+        query := SAVE_BUYER
+	stmt, err := r.stmts.Stmt(ctx, query)
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
 	}
 
-        log.Print(res)
+	res, err := stmt.ExecContext(ctx, site, &p.OrderNumber, &p.OrderDate, &p.TrackingCode, &p.BuyerID, &p.ProductRecordID, &p.OrderStatusID)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
 
         id, err := res.LastInsertId()
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
 	}
 
 	return int(id), nil
 }
 
+// CountByStatus returns the number of purchase orders by order status id.
+// Orders without a status are counted under status 0.
+func (r *repository) CountByStatus(ctx context.Context) (map[int]int, error) {
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, COUNT_BY_STATUS, tenant.Filter(ctx))
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+
+	counts := map[int]int{}
+	for rows.Next() {
+		var status, count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		counts[status] += count
+	}
+
+	return counts, apperrors.FromDB(rows.Err())
+}
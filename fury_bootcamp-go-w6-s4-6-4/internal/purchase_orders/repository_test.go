@@ -12,6 +12,9 @@ import (
 
 	// "database/sql"
 	"github.com/DATA-DOG/go-sqlmock"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
@@ -37,9 +40,9 @@ func (s *createDataBaseRepoSuite) SetupTest() {
 
         // defer db.Close()
 
-        s.context = context.TODO()
+        s.context = tenant.NewContext(context.TODO(), "MLA")
 	s.sqlMock = mock
-	s.dbRepository = NewRepository(db)
+	s.dbRepository = NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 }
 
 func TestCreateDataBaseSuite(t *testing.T) {
@@ -64,7 +67,7 @@ func (s *createDataBaseRepoSuite) Test_SavePurchaseOrdersOK() {
         params := testPurchaseOrders
 	s.sqlMock.ExpectPrepare(stmt).WillReturnError(nil)
 	s.sqlMock.ExpectExec(stmt).
-                WithArgs(params.OrderNumber, params.OrderDate, params.TrackingCode, params.BuyerID, params.ProductRecordID, params.OrderStatusID).
+                WithArgs("MLA", params.OrderNumber, params.OrderDate, params.TrackingCode, params.BuyerID, params.ProductRecordID, params.OrderStatusID).
                 WillReturnResult(sqlmock.NewResult(1, 1))
 
 	// Act
@@ -85,7 +88,7 @@ func (s *createDataBaseRepoSuite) Test_SavePurchaseOrdersFail() {
         params := testpurchaseorders
 	s.sqlMock.ExpectPrepare(stmt).WillReturnError(nil)
 	s.sqlMock.ExpectExec(stmt).
-                WithArgs(params.OrderNumber, params.OrderDate, params.TrackingCode, params.BuyerID, params.ProductRecordID, params.OrderStatusID).
+                WithArgs("MLA", params.OrderNumber, params.OrderDate, params.TrackingCode, params.BuyerID, params.ProductRecordID, params.OrderStatusID).
                 WillReturnError(ErrForzadoPurchaseOrders)
 
 	// Act
@@ -104,7 +107,7 @@ func (s *createDataBaseRepoSuite) Test_ExistBuyersID() {
         columns := []string{"id"}
         rows := s.sqlMock.NewRows(columns)
         rows.AddRow(1)
-	s.sqlMock.ExpectQuery(stmt).WithArgs(params).WillReturnRows(rows)
+	s.sqlMock.ExpectQuery(stmt).WithArgs(params, "MLA").WillReturnRows(rows)
 
         // Act
         exist := s.dbRepository.ExistsBuyersID(s.context, 1)
@@ -122,7 +125,7 @@ func (s *createDataBaseRepoSuite) Test_ExistProductRecordID() {
         columns := []string{"id"}
         rows := s.sqlMock.NewRows(columns)
         rows.AddRow(1)
-	s.sqlMock.ExpectQuery(stmt).WithArgs(params).WillReturnRows(rows)
+	s.sqlMock.ExpectQuery(stmt).WithArgs(params, "MLA").WillReturnRows(rows)
 
         // Act
         exist := s.dbRepository.ExistsProductRecordsID(s.context, 1)
@@ -155,7 +158,7 @@ func (s *createDataBaseRepoSuite) Test_GetPurchaseOrdersWithQueryStringOK() {
 
         stmt := regexp.QuoteMeta(GET_REPORT_PURCHASEORDERS_BY_BUYERID)
         s.sqlMock.ExpectQuery(stmt).
-                WithArgs(1).
+                WithArgs(1, "MLA").
                 WillReturnRows(rows)
 
 	// Act
@@ -195,7 +198,7 @@ func (s *createDataBaseRepoSuite) Test_GetPurchaseOrdersWithoutQueryStringOK() {
         }
 
         stmt := regexp.QuoteMeta(GET_REPORT_PURCHASEORDERS)
-        s.sqlMock.ExpectQuery(stmt).WillReturnRows(rows)
+        s.sqlMock.ExpectQuery(stmt).WithArgs("MLA").WillReturnRows(rows)
 
 	// Act
 	reports, err := s.dbRepository.Get(s.context, 0)
@@ -229,7 +232,7 @@ func (s *createDataBaseRepoSuite) Test_GetPurchaseOrdersWithQueryStringFail() {
 
         stmt := regexp.QuoteMeta(GET_REPORT_PURCHASEORDERS_BY_BUYERID)
         s.sqlMock.ExpectQuery(stmt).
-                WithArgs(1).
+                WithArgs(1, "MLA").
                 WillReturnError(ErrForzadoPurchaseOrders)
 
 	// Act
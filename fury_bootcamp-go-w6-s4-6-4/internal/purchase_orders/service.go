@@ -3,17 +3,19 @@ package purchase_orders
 import (
 	// "fmt"
 	"context"
-	"errors"
+	"log/slog"
 	"time"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 // Errors
 var (
-	ErrNotFoundPurchaseOrder = errors.New("buyer not found")
-        ErrNotExistsBuyerID = errors.New("buyer_id doesn't exists")
-        ErrNotExistsProductRecordsID = errors.New("product_records_id doesn't exists")
+	ErrNotFoundPurchaseOrder = apperrors.NotFound("buyer not found")
+        ErrNotExistsBuyerID = apperrors.ForeignKeyViolation("buyer_id doesn't exists")
+        ErrNotExistsProductRecordsID = apperrors.ForeignKeyViolation("product_records_id doesn't exists")
 )
 
 type Service interface {
@@ -26,18 +28,22 @@ type Service interface {
 
 type service struct{
         repository Repository
+        outbox     events.Outbox
+        logger     *slog.Logger
 }
 
-func NewService(r Repository) Service {
+func NewService(r Repository, outbox events.Outbox, logger *slog.Logger) Service {
 	return &service{
                 repository: r,
+                outbox:     outbox,
+                logger:     logger,
         }
 }
 
 func (s *service) GetAllByBuyerID(ctx context.Context, buyerID int) ([]domain.ReportPurchaseOrders, error) {
 
         if buyerID != 0 && !s.repository.ExistsBuyersID(ctx, buyerID) {
-		return nil, ErrNotExistsBuyerID
+		return nil, ErrNotFoundPurchaseOrder
         }
 
         return s.repository.Get(ctx, buyerID)
@@ -61,12 +67,29 @@ func (s *service) Save(ctx context.Context, orderNumber, trackingCode string, bu
         purchaseOrders.OrderStatusID = orderStatusID
         purchaseOrders.OrderDate = orderDate
 
-        id, err := s.repository.Save(ctx, purchaseOrders)
+        // Placing an order is its first status change.
+        err := s.outbox.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+                id, err := s.repository.Save(ctx, purchaseOrders)
+                if err != nil {
+                        return nil, err
+                }
+                purchaseOrders.ID = id
+                return []events.Event{{
+                        Type:        events.PurchaseOrderStatusChanged,
+                        AggregateID: id,
+                        Data: events.PurchaseOrderStatus{
+                                PurchaseOrderID: id,
+                                OrderNumber:     purchaseOrders.OrderNumber,
+                                BuyerID:         purchaseOrders.BuyerID,
+                                StatusID:        purchaseOrders.OrderStatusID,
+                        },
+                }}, nil
+        })
 	if err != nil {
 		return domain.PurchaseOrders{}, err
 	}
 
-        purchaseOrders.ID = id
+        s.logger.InfoContext(ctx, "purchase order created", "purchase_order_id", purchaseOrders.ID)
 
         return purchaseOrders, nil
 }
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/purchase_orders"
 	"github.com/stretchr/testify/assert"
 )
@@ -56,7 +58,7 @@ func TestServiceCreateOk(t *testing.T) {
                 productIDExpected := 1
 
                 // Act
-                service := NewService(&mockRepository)
+                service := NewService(&mockRepository, events.Discard, logger.Discard())
                 ctx := context.Background()
                 result, err := service.Save(ctx, 
                         newPurchaseOrders.OrderNumber, 
@@ -100,7 +102,7 @@ func TestServiceCreateOk(t *testing.T) {
                 productIDExpected := 0
 
                 // Act
-                service := NewService(&mockRepository)
+                service := NewService(&mockRepository, events.Discard, logger.Discard())
                 ctx := context.Background()
                 result, err := service.Save(ctx, 
                         newPurchaseOrders.OrderNumber, 
@@ -162,7 +164,7 @@ func TestGetAllByBuyerIDOk(t *testing.T) {
                 lengthReports := 1
 
                 // Act
-                service := NewService(&mockRepository)
+                service := NewService(&mockRepository, events.Discard, logger.Discard())
                 ctx := context.Background()
                 result, err := service.GetAllByBuyerID(ctx, buyerIDToGet) 
 
@@ -200,7 +202,7 @@ func TestGetAllByBuyerIDOk(t *testing.T) {
                 buyerIDToGet := 4
 
                 // Act
-                service := NewService(&mockRepository)
+                service := NewService(&mockRepository, events.Discard, logger.Discard())
                 ctx := context.Background()
                 result, err := service.GetAllByBuyerID(ctx, buyerIDToGet) 
 
@@ -0,0 +1,35 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/cache"
+)
+
+type cachedService struct {
+	Service
+	cache *cache.Cache
+}
+
+// WithCache returns s with the reports cached in c until a write to one of
+// the tables they read. A nil c leaves s as is.
+func WithCache(s Service, c *cache.Cache) Service {
+	if c == nil {
+		return s
+	}
+	return &cachedService{Service: s, cache: c}
+}
+
+func (s *cachedService) Run(ctx context.Context, name string, q domain.ReportQuery) (domain.ReportResult, error) {
+	def, ok := definitions[name]
+	if !ok {
+		return domain.ReportResult{}, ErrNotFound
+	}
+	key := fmt.Sprintf("reports.%s:%s:%s:%s:%v", name, formatDate(q.From), formatDate(q.To), strings.Join(q.GroupBy, ","), q.IDs)
+	return cache.Load(ctx, s.cache, key, def.tags, func(ctx context.Context) (domain.ReportResult, error) {
+		return s.Service.Run(ctx, name, q)
+	})
+}
@@ -0,0 +1,89 @@
+// Package report serves the reports as time series: the rows of a table
+// counted per period of their date and per the entities they belong to, so
+// every report takes the same date range, grouping and ids.
+package report
+
+// Dimensions of the reports. The periods apply to the date of the reports
+// that have one; the other dimensions are the ids of an entity.
+const (
+	Day       = "day"
+	Week      = "week"
+	Month     = "month"
+	Warehouse = "warehouse"
+	Locality  = "locality"
+	Seller    = "seller"
+)
+
+// periodFormats format the date of a row as the first day of its period;
+// weeks start on Monday. The day of month is a literal, so it's kept apart
+// from the format verbs the queries are built with.
+var periodFormats = map[string]string{
+	Day:   "DATE_FORMAT(%[1]s, '%%Y-%%m-%%d')",
+	Week:  "DATE_FORMAT(DATE_SUB(%[1]s, INTERVAL WEEKDAY(%[1]s) DAY), '%%Y-%%m-%%d')",
+	Month: "DATE_FORMAT(%[1]s, '%%Y-%%m-01')",
+}
+
+// dimension is the id of an entity a report can be grouped by, read by
+// expr once the first joins of the report are made.
+type dimension struct {
+	expr  string
+	joins int
+}
+
+// definition describes a report: the table it counts, the date its periods
+// and range apply to, if any, and the entities it reaches through joins.
+type definition struct {
+	from       string
+	date       string
+	joins      []string
+	dimensions map[string]dimension
+	// tags are the tables the report reads, which invalidate its cache.
+	tags []string
+}
+
+// definitions are the reports, by name.
+var definitions = map[string]definition{
+	"inbound_orders": {
+		from: "inbound_orders io",
+		date: "io.order_date",
+		joins: []string{
+			"JOIN product_batches pb ON pb.site_id = io.site_id AND pb.id = io.product_batch_id",
+			"JOIN products p ON p.site_id = pb.site_id AND p.id = pb.products_id",
+			"JOIN seller s ON s.site_id = p.site_id AND s.id = p.seller_id",
+		},
+		dimensions: map[string]dimension{
+			Warehouse: {expr: "io.warehouse_id"},
+			Seller:    {expr: "p.seller_id", joins: 2},
+			Locality:  {expr: "s.locality_id", joins: 3},
+		},
+		tags: []string{"inbound_orders", "product_batches", "products", "sellers"},
+	},
+	"purchase_orders": {
+		from: "purchase_orders po",
+		date: "po.order_date",
+		joins: []string{
+			"JOIN product_records pr ON pr.site_id = po.site_id AND pr.id = po.product_records_id",
+			"JOIN products p ON p.site_id = pr.site_id AND p.id = pr.products_id",
+			"JOIN seller s ON s.site_id = p.site_id AND s.id = p.seller_id",
+		},
+		dimensions: map[string]dimension{
+			Seller:   {expr: "p.seller_id", joins: 2},
+			Locality: {expr: "s.locality_id", joins: 3},
+		},
+		tags: []string{"purchase_orders", "product_records", "products", "sellers"},
+	},
+	"sellers": {
+		from: "seller s",
+		dimensions: map[string]dimension{
+			Locality: {expr: "s.locality_id"},
+		},
+		tags: []string{"sellers"},
+	},
+	"carries": {
+		from: "carries c",
+		dimensions: map[string]dimension{
+			Locality: {expr: "c.locality_id"},
+		},
+		tags: []string{"carries"},
+	},
+}
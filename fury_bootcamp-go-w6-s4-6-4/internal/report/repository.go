@@ -0,0 +1,122 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+)
+
+// Repository counts the rows of the reports.
+type Repository interface {
+	// Count answers the report name for q, which must have been checked
+	// against its definition. Rows come sorted by entities, then period.
+	Count(ctx context.Context, name string, q domain.ReportQuery) ([]domain.ReportRow, error)
+}
+
+type repository struct {
+	db *database.Router
+}
+
+// NewRepository returns a Repository reading from db. The queries depend on
+// the grouping, so unlike the other repositories it doesn't prepare
+// statements.
+func NewRepository(db *database.Router) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Count(ctx context.Context, name string, q domain.ReportQuery) ([]domain.ReportRow, error) {
+	query, args := build(definitions[name], q, tenant.Filter(ctx))
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+
+	entities := len(q.GroupBy)
+	if hasPeriod(q.GroupBy) {
+		entities--
+	}
+	counts := []domain.ReportRow{}
+	for rows.Next() {
+		row := domain.ReportRow{IDs: make([]int, entities)}
+		dest := []any{&row.Period}
+		for i := range row.IDs {
+			dest = append(dest, &row.IDs[i])
+		}
+		if err := rows.Scan(append(dest, &row.Count)...); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		counts = append(counts, row)
+	}
+	return counts, apperrors.FromDB(rows.Err())
+}
+
+// build returns the query of def for q, filtered by site, and its
+// arguments. It selects the period, empty without one, the ids of the
+// entities and the count.
+func build(def definition, q domain.ReportQuery, site any) (string, []any) {
+	alias := def.from[strings.LastIndex(def.from, " ")+1:]
+	period := "''"
+	var entities []string
+	joins := 0
+	for _, g := range q.GroupBy {
+		if format, ok := periodFormats[g]; ok {
+			period = fmt.Sprintf(format, def.date)
+			continue
+		}
+		d := def.dimensions[g]
+		entities = append(entities, d.expr)
+		joins = max(joins, d.joins)
+	}
+
+	where := []string{alias + ".site_id = COALESCE(?, " + alias + ".site_id)"}
+	args := []any{site}
+	if period != "''" {
+		where = append(where, def.date+" IS NOT NULL")
+	}
+	if !q.From.IsZero() {
+		where = append(where, def.date+" >= ?")
+		args = append(args, q.From.Format(dateLayout))
+	}
+	if !q.To.IsZero() {
+		where = append(where, def.date+" < ?")
+		args = append(args, q.To.AddDate(0, 0, 1).Format(dateLayout))
+	}
+	if len(q.IDs) > 0 {
+		where = append(where, entities[0]+" IN (?"+strings.Repeat(", ?", len(q.IDs)-1)+")")
+		for _, id := range q.IDs {
+			args = append(args, id)
+		}
+	}
+
+	groups := entities
+	if period != "''" {
+		groups = append(append([]string{}, entities...), period)
+	}
+	var b strings.Builder
+	b.WriteString("SELECT " + strings.Join(append(append([]string{period}, entities...), "COUNT(*)"), ", "))
+	b.WriteString(" FROM " + def.from)
+	for _, join := range def.joins[:joins] {
+		b.WriteString(" " + join)
+	}
+	b.WriteString(" WHERE " + strings.Join(where, " AND "))
+	if len(groups) > 0 {
+		b.WriteString(" GROUP BY " + strings.Join(groups, ", "))
+		b.WriteString(" ORDER BY " + strings.Join(groups, ", "))
+	}
+	return b.String(), args
+}
+
+func hasPeriod(groupBy []string) bool {
+	for _, g := range groupBy {
+		if _, ok := periodFormats[g]; ok {
+			return true
+		}
+	}
+	return false
+}
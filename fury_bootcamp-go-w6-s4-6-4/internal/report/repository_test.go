@@ -0,0 +1,95 @@
+package report
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name   string
+		report string
+		q      domain.ReportQuery
+		query  string
+		args   []any
+	}{
+		{
+			name:   "total",
+			report: "carries",
+			query:  "SELECT '', COUNT(*) FROM carries c WHERE c.site_id = COALESCE(?, c.site_id)",
+			args:   []any{"MLA"},
+		},
+		{
+			name:   "by entity",
+			report: "sellers",
+			q:      domain.ReportQuery{GroupBy: []string{Locality}, IDs: []int{1, 2}},
+			query:  "SELECT '', s.locality_id, COUNT(*) FROM seller s WHERE s.site_id = COALESCE(?, s.site_id) AND s.locality_id IN (?, ?) GROUP BY s.locality_id ORDER BY s.locality_id",
+			args:   []any{"MLA", 1, 2},
+		},
+		{
+			name:   "by period within a range",
+			report: "inbound_orders",
+			q:      domain.ReportQuery{From: date("2024-01-01"), To: date("2024-01-31"), GroupBy: []string{Month, Warehouse}},
+			query: "SELECT DATE_FORMAT(io.order_date, '%Y-%m-01'), io.warehouse_id, COUNT(*) FROM inbound_orders io " +
+				"WHERE io.site_id = COALESCE(?, io.site_id) AND io.order_date IS NOT NULL AND io.order_date >= ? AND io.order_date < ? " +
+				"GROUP BY io.warehouse_id, DATE_FORMAT(io.order_date, '%Y-%m-01') ORDER BY io.warehouse_id, DATE_FORMAT(io.order_date, '%Y-%m-01')",
+			args: []any{"MLA", "2024-01-01", "2024-02-01"},
+		},
+		{
+			name:   "joining up to the entity",
+			report: "purchase_orders",
+			q:      domain.ReportQuery{GroupBy: []string{Seller}},
+			query: "SELECT '', p.seller_id, COUNT(*) FROM purchase_orders po " +
+				"JOIN product_records pr ON pr.site_id = po.site_id AND pr.id = po.product_records_id " +
+				"JOIN products p ON p.site_id = pr.site_id AND p.id = pr.products_id " +
+				"WHERE po.site_id = COALESCE(?, po.site_id) GROUP BY p.seller_id ORDER BY p.seller_id",
+			args: []any{"MLA"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := build(definitions[tt.report], tt.q, "MLA")
+
+			assert.Equal(t, tt.query, query)
+			assert.Equal(t, tt.args, args)
+		})
+	}
+}
+
+func TestRepositoryCount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewRepository(database.NewRouter(db, nil, logger.Discard()))
+	q := domain.ReportQuery{GroupBy: []string{Day, Warehouse}}
+	query, _ := build(definitions["inbound_orders"], q, "MLA")
+	rows := sqlmock.NewRows([]string{"period", "warehouse_id", "count"}).
+		AddRow("2024-01-01", 1, 3).
+		AddRow("2024-01-03", 1, 2)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("MLA").WillReturnRows(rows)
+
+	counts, err := repo.Count(tenant.NewContext(context.TODO(), "MLA"), "inbound_orders", q)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.ReportRow{
+		{Period: "2024-01-01", IDs: []int{1}, Count: 3},
+		{Period: "2024-01-03", IDs: []int{1}, Count: 2},
+	}, counts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func date(s string) time.Time {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
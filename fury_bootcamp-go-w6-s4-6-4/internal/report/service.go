@@ -0,0 +1,237 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+const dateLayout = "2006-01-02"
+
+// MaxPoints bounds the periods of a series, so a wide range by day can't
+// make a response of thousands of points.
+const MaxPoints = 1000
+
+// MaxIDs bounds the ids a report is restricted to, each an argument of its
+// query.
+const MaxIDs = 100
+
+// Errors
+var (
+	ErrNotFound      = apperrors.NotFound("report not found")
+	ErrRange         = apperrors.Validation("from must not be after to")
+	ErrUndated       = apperrors.Validation("the report has no date to filter or group by")
+	ErrPeriods       = apperrors.Validation("group_by takes a single period")
+	ErrIDs           = apperrors.Validation("ids need the report grouped by a single entity")
+	ErrTooManyIDs    = apperrors.Validation(fmt.Sprintf("ids takes at most %d ids", MaxIDs))
+	ErrTooManyPoints = apperrors.Validation(fmt.Sprintf("more than %d periods; narrow the range or group by a longer period", MaxPoints))
+)
+
+type Service interface {
+	// Run answers the report name for q as time series.
+	Run(ctx context.Context, name string, q domain.ReportQuery) (domain.ReportResult, error)
+}
+
+type service struct {
+	repository Repository
+	logger     *slog.Logger
+}
+
+func NewService(repository Repository, logger *slog.Logger) Service {
+	return &service{repository, logger}
+}
+
+func (s *service) Run(ctx context.Context, name string, q domain.ReportQuery) (domain.ReportResult, error) {
+	def, ok := definitions[name]
+	if !ok {
+		return domain.ReportResult{}, ErrNotFound
+	}
+	period, entities, err := check(name, def, q)
+	if err != nil {
+		return domain.ReportResult{}, err
+	}
+	if period != "" && !q.From.IsZero() && !q.To.IsZero() && periods(period, q.From, q.To) > MaxPoints {
+		return domain.ReportResult{}, ErrTooManyPoints
+	}
+
+	rows, err := s.repository.Count(ctx, name, q)
+	if err != nil {
+		return domain.ReportResult{}, err
+	}
+	result := domain.ReportResult{
+		Report:  name,
+		GroupBy: append([]string{}, q.GroupBy...),
+		Series:  series(rows, entities),
+	}
+	result.From, result.To = formatDate(q.From), formatDate(q.To)
+	if period != "" {
+		if err := fill(result.Series, period, q.From, q.To); err != nil {
+			return domain.ReportResult{}, err
+		}
+	}
+	return result, nil
+}
+
+// check validates q against def and returns the period it's grouped by, if
+// any, and the entities.
+func check(name string, def definition, q domain.ReportQuery) (string, []string, error) {
+	if !q.From.IsZero() && !q.To.IsZero() && q.From.After(q.To) {
+		return "", nil, ErrRange
+	}
+	if def.date == "" && (!q.From.IsZero() || !q.To.IsZero()) {
+		return "", nil, ErrUndated
+	}
+
+	var period string
+	var entities []string
+	seen := map[string]bool{}
+	for _, g := range q.GroupBy {
+		if seen[g] {
+			return "", nil, apperrors.Validation(fmt.Sprintf("group_by repeats %s", g))
+		}
+		seen[g] = true
+		if _, ok := periodFormats[g]; ok {
+			if def.date == "" {
+				return "", nil, ErrUndated
+			}
+			if period != "" {
+				return "", nil, ErrPeriods
+			}
+			period = g
+			continue
+		}
+		if _, ok := def.dimensions[g]; !ok {
+			return "", nil, apperrors.Validation(fmt.Sprintf("%s can't be grouped by %s", name, g))
+		}
+		entities = append(entities, g)
+	}
+	if len(q.IDs) > 0 && len(entities) != 1 {
+		return "", nil, ErrIDs
+	}
+	if len(q.IDs) > MaxIDs {
+		return "", nil, ErrTooManyIDs
+	}
+	return period, entities, nil
+}
+
+// series splits rows, sorted by entities, into a series per combination of
+// them.
+func series(rows []domain.ReportRow, entities []string) []domain.ReportSeries {
+	all := []domain.ReportSeries{}
+	var last []int
+	for i, row := range rows {
+		if i == 0 || !equal(row.IDs, last) {
+			dimensions := map[string]int{}
+			for j, entity := range entities {
+				dimensions[entity] = row.IDs[j]
+			}
+			all = append(all, domain.ReportSeries{Dimensions: dimensions, Points: []domain.ReportPoint{}})
+			last = row.IDs
+		}
+		points := &all[len(all)-1].Points
+		*points = append(*points, domain.ReportPoint{Period: row.Period, Count: row.Count})
+	}
+	return all
+}
+
+// fill gives every series a point for each period between from and to, or
+// between the first and the last period counted when they're open, with
+// the periods nothing was counted in at zero.
+func fill(all []domain.ReportSeries, period string, from, to time.Time) error {
+	var first, last time.Time
+	counts := make([]map[time.Time]int, len(all))
+	for i, s := range all {
+		counts[i] = map[time.Time]int{}
+		for _, p := range s.Points {
+			start, err := time.Parse(dateLayout, p.Period)
+			if err != nil {
+				return fmt.Errorf("parsing period %q: %w", p.Period, err)
+			}
+			counts[i][start] = p.Count
+			if first.IsZero() || start.Before(first) {
+				first = start
+			}
+			if start.After(last) {
+				last = start
+			}
+		}
+	}
+	if !from.IsZero() {
+		first = from
+	}
+	if !to.IsZero() {
+		last = to
+	}
+	if first.IsZero() || last.IsZero() {
+		return nil
+	}
+	if periods(period, first, last) > MaxPoints {
+		return ErrTooManyPoints
+	}
+
+	for i := range all {
+		points := []domain.ReportPoint{}
+		for start := startOf(period, first); !start.After(last); start = next(period, start) {
+			points = append(points, domain.ReportPoint{Period: start.Format(dateLayout), Count: counts[i][start]})
+		}
+		all[i].Points = points
+	}
+	return nil
+}
+
+// periods counts the periods between from and to, both included.
+func periods(period string, from, to time.Time) int {
+	n := 0
+	for start := startOf(period, from); !start.After(to); start = next(period, start) {
+		if n++; n > MaxPoints {
+			break
+		}
+	}
+	return n
+}
+
+// startOf returns the first day of the period of t; weeks start on Monday.
+func startOf(period string, t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case Week:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case Month:
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	return day
+}
+
+func next(period string, start time.Time) time.Time {
+	switch period {
+	case Week:
+		return start.AddDate(0, 0, 7)
+	case Month:
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// formatDate formats t as a date, or as empty when it's zero.
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(dateLayout)
+}
+
+func equal(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,106 @@
+package report
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	reportmock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceRun(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should fill the periods without counts", func(t *testing.T) {
+		repo := &reportmock.MockRepository{Rows: []domain.ReportRow{
+			{Period: "2024-01-02", IDs: []int{1}, Count: 3},
+			{Period: "2024-01-04", IDs: []int{1}, Count: 1},
+			{Period: "2024-01-03", IDs: []int{2}, Count: 2},
+		}}
+		q := domain.ReportQuery{From: date("2024-01-01"), To: date("2024-01-04"), GroupBy: []string{Day, Warehouse}}
+
+		result, err := NewService(repo, logger.Discard()).Run(ctx, "inbound_orders", q)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ReportResult{
+			Report:  "inbound_orders",
+			From:    "2024-01-01",
+			To:      "2024-01-04",
+			GroupBy: []string{Day, Warehouse},
+			Series: []domain.ReportSeries{
+				{Dimensions: map[string]int{Warehouse: 1}, Points: []domain.ReportPoint{
+					{Period: "2024-01-01"}, {Period: "2024-01-02", Count: 3}, {Period: "2024-01-03"}, {Period: "2024-01-04", Count: 1},
+				}},
+				{Dimensions: map[string]int{Warehouse: 2}, Points: []domain.ReportPoint{
+					{Period: "2024-01-01"}, {Period: "2024-01-02"}, {Period: "2024-01-03", Count: 2}, {Period: "2024-01-04"},
+				}},
+			},
+		}, result)
+	})
+	t.Run("should span the periods counted when the range is open", func(t *testing.T) {
+		repo := &reportmock.MockRepository{Rows: []domain.ReportRow{
+			{Period: "2024-01-01", Count: 3},
+			{Period: "2024-03-01", Count: 1},
+		}}
+
+		result, err := NewService(repo, logger.Discard()).Run(ctx, "purchase_orders", domain.ReportQuery{GroupBy: []string{Month}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.ReportSeries{{Dimensions: map[string]int{}, Points: []domain.ReportPoint{
+			{Period: "2024-01-01", Count: 3}, {Period: "2024-02-01"}, {Period: "2024-03-01", Count: 1},
+		}}}, result.Series)
+	})
+	t.Run("should start the weeks on Monday", func(t *testing.T) {
+		repo := &reportmock.MockRepository{}
+		q := domain.ReportQuery{From: date("2024-01-03"), To: date("2024-01-10"), GroupBy: []string{Week}}
+		repo.Rows = []domain.ReportRow{{Period: "2024-01-08", Count: 4}}
+
+		result, err := NewService(repo, logger.Discard()).Run(ctx, "inbound_orders", q)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.ReportPoint{{Period: "2024-01-01"}, {Period: "2024-01-08", Count: 4}}, result.Series[0].Points)
+	})
+	t.Run("should count without a period", func(t *testing.T) {
+		repo := &reportmock.MockRepository{Rows: []domain.ReportRow{{Count: 5}}}
+
+		result, err := NewService(repo, logger.Discard()).Run(ctx, "carries", domain.ReportQuery{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.ReportSeries{{Dimensions: map[string]int{}, Points: []domain.ReportPoint{{Count: 5}}}}, result.Series)
+	})
+}
+
+func TestServiceRunValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		report string
+		q      domain.ReportQuery
+		err    error
+	}{
+		{name: "unknown report", report: "buyers", err: ErrNotFound},
+		{name: "range backwards", report: "inbound_orders", q: domain.ReportQuery{From: date("2024-02-01"), To: date("2024-01-01")}, err: ErrRange},
+		{name: "range of an undated report", report: "sellers", q: domain.ReportQuery{From: date("2024-01-01")}, err: ErrUndated},
+		{name: "period of an undated report", report: "carries", q: domain.ReportQuery{GroupBy: []string{Day}}, err: ErrUndated},
+		{name: "two periods", report: "inbound_orders", q: domain.ReportQuery{GroupBy: []string{Day, Month}}, err: ErrPeriods},
+		{name: "ids without an entity", report: "inbound_orders", q: domain.ReportQuery{IDs: []int{1}}, err: ErrIDs},
+		{name: "ids with two entities", report: "inbound_orders", q: domain.ReportQuery{GroupBy: []string{Warehouse, Seller}, IDs: []int{1}}, err: ErrIDs},
+		{name: "too many ids", report: "inbound_orders", q: domain.ReportQuery{GroupBy: []string{Warehouse}, IDs: make([]int, MaxIDs+1)}, err: ErrTooManyIDs},
+		{name: "too many days", report: "inbound_orders", q: domain.ReportQuery{From: date("2020-01-01"), To: date("2024-01-01"), GroupBy: []string{Day}}, err: ErrTooManyPoints},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewService(&reportmock.MockRepository{}, logger.Discard()).Run(context.Background(), tt.report, tt.q)
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	t.Run("dimension the report lacks", func(t *testing.T) {
+		_, err := NewService(&reportmock.MockRepository{}, logger.Discard()).Run(context.Background(), "sellers", domain.ReportQuery{GroupBy: []string{Warehouse}})
+
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+	})
+}
@@ -0,0 +1,46 @@
+package section
+
+import (
+	"context"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/cache"
+)
+
+type cachedService struct {
+	Service
+	cache *cache.Cache
+}
+
+// WithCache returns s with its writes invalidating the products report of
+// the sections cached in c. A nil c leaves s as is.
+func WithCache(s Service, c *cache.Cache) Service {
+	if c == nil {
+		return s
+	}
+	return &cachedService{Service: s, cache: c}
+}
+
+func (s *cachedService) Save(ctx context.Context, sec domain.Section) (int, error) {
+	v, err := s.Service.Save(ctx, sec)
+	if err == nil {
+		s.cache.Invalidate(ctx, "sections")
+	}
+	return v, err
+}
+
+func (s *cachedService) Update(ctx context.Context, ID int, SectionNumber int, CurrentTemperature int, MinimumTemperature int, CurrentCapacity int, MinimumCapacity int, MaximumCapacity int, WarehouseID int, ProductTypeID int) (domain.Section, error) {
+	v, err := s.Service.Update(ctx, ID, SectionNumber, CurrentTemperature, MinimumTemperature, CurrentCapacity, MinimumCapacity, MaximumCapacity, WarehouseID, ProductTypeID)
+	if err == nil {
+		s.cache.Invalidate(ctx, "sections")
+	}
+	return v, err
+}
+
+func (s *cachedService) Delete(ctx context.Context, id int) error {
+	err := s.Service.Delete(ctx, id)
+	if err == nil {
+		s.cache.Invalidate(ctx, "sections")
+	}
+	return err
+}
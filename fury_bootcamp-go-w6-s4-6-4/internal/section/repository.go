@@ -3,8 +3,13 @@ package section
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"log/slog"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 )
 
 // Repository encapsulates the storage of a section.
@@ -18,62 +23,87 @@ type Repository interface {
 }
 
 type repository struct {
-	db *sql.DB
+	db     *database.Router
+	stmts  *database.Statements
+	logger *slog.Logger
 }
 
-func NewRepository(db *sql.DB) Repository {
+func NewRepository(db *database.Router, stmts *database.Statements, logger *slog.Logger) Repository {
+	stmts.Register(SAVE_SECTION, UPDATE_SECTION, DELETE_SECTION)
 	return &repository{
-		db: db,
+		db:     db,
+		stmts:  stmts,
+		logger: logger,
 	}
 }
 
+const (
+	SAVE_SECTION   = "INSERT INTO sections (site_id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);"
+	UPDATE_SECTION = "UPDATE sections SET section_number=?, current_temperature=?, minimum_temperature=?, current_capacity=?, minimum_capacity=?, maximum_capacity=?, warehouse_id=?, product_type_id=? WHERE id=? AND site_id = COALESCE(?, site_id);"
+	DELETE_SECTION = "DELETE FROM sections WHERE id=? AND site_id = COALESCE(?, site_id);"
+)
+
 func (r *repository) GetAll(ctx context.Context) ([]domain.Section, error) {
-	query := "SELECT * FROM sections;"
-	rows, err := r.db.Query(query)
+	query := "SELECT id, site_id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id FROM sections WHERE site_id = COALESCE(?, site_id);"
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, tenant.Filter(ctx))
 	if err != nil {
-		return nil, err
+		return nil, apperrors.FromDB(err)
 	}
+	defer rows.Close()
 
 	var sections []domain.Section
 
 	for rows.Next() {
 		s := domain.Section{}
-		_ = rows.Scan(&s.ID, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID)
+		if err := rows.Scan(&s.ID, &s.SiteID, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
 		sections = append(sections, s)
 	}
 
-	return sections, nil
+	return sections, apperrors.FromDB(rows.Err())
 }
 
 func (r *repository) Get(ctx context.Context, id int) (domain.Section, error) {
-	query := "SELECT * FROM sections WHERE id=?;"
-	row := r.db.QueryRow(query, id)
+	query := "SELECT id, site_id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id FROM sections WHERE id=? AND site_id = COALESCE(?, site_id);"
+	row := r.db.Writer().QueryRowContext(ctx, query, id, tenant.Filter(ctx))
 	s := domain.Section{}
-	err := row.Scan(&s.ID, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID)
+	err := row.Scan(&s.ID, &s.SiteID, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Section{}, ErrNotFound
+	}
 	if err != nil {
-		return domain.Section{}, err
+		return domain.Section{}, apperrors.FromDB(err)
 	}
 
 	return s, nil
 }
 
 func (r *repository) Exists(ctx context.Context, sectionNumber int) bool {
-	query := "SELECT section_number FROM sections WHERE section_number=?;"
-	row := r.db.QueryRow(query, sectionNumber)
+	query := "SELECT section_number FROM sections WHERE section_number=? AND site_id = COALESCE(?, site_id);"
+	row := r.db.Writer().QueryRowContext(ctx, query, sectionNumber, tenant.Filter(ctx))
 	err := row.Scan(&sectionNumber)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", "Exists", "error", err)
+	}
 	return err == nil
 }
 
 func (r *repository) Save(ctx context.Context, s domain.Section) (int, error) {
-	query := "INSERT INTO sections (section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?);"
-	stmt, err := r.db.Prepare(query)
+	site, err := tenant.Site(ctx)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(&s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID)
+	query := SAVE_SECTION
+	stmt, err := r.stmts.Stmt(ctx, query)
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
+	}
+
+	res, err := stmt.ExecContext(ctx, site, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
 	}
 
 	id, err := res.LastInsertId()
@@ -85,15 +115,15 @@ func (r *repository) Save(ctx context.Context, s domain.Section) (int, error) {
 }
 
 func (r *repository) Update(ctx context.Context, s domain.Section) error {
-	query := "UPDATE sections SET section_number=?, current_temperature=?, minimum_temperature=?, current_capacity=?, minimum_capacity=?, maximum_capacity=?, warehouse_id=?, product_type_id=? WHERE id=?;"
-	stmt, err := r.db.Prepare(query)
+	query := UPDATE_SECTION
+	stmt, err := r.stmts.Stmt(ctx, query)
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
-	res, err := stmt.Exec(&s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID, &s.ID)
+	res, err := stmt.ExecContext(ctx, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID, &s.ID, tenant.Filter(ctx))
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	_, err = res.RowsAffected()
@@ -105,15 +135,15 @@ func (r *repository) Update(ctx context.Context, s domain.Section) error {
 }
 
 func (r *repository) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM sections WHERE id=?;"
-	stmt, err := r.db.Prepare(query)
+	query := DELETE_SECTION
+	stmt, err := r.stmts.Stmt(ctx, query)
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
-	res, err := stmt.Exec(id)
+	res, err := stmt.ExecContext(ctx, id, tenant.Filter(ctx))
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	affect, err := res.RowsAffected()
@@ -8,6 +8,9 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -19,6 +22,7 @@ var (
 var FakeSection = []domain.Section{
 	{
 		ID:                 1,
+		SiteID:             "MLA",
 		SectionNumber:      1,
 		CurrentTemperature: 1,
 		MinimumTemperature: 1,
@@ -30,6 +34,7 @@ var FakeSection = []domain.Section{
 	},
 	{
 		ID:                 2,
+		SiteID:             "MLA",
 		SectionNumber:      2,
 		CurrentTemperature: 2,
 		MinimumTemperature: 2,
@@ -48,17 +53,17 @@ func TestGetAllSections(t *testing.T) {
 
 	t.Run("Get all sections", func(t *testing.T) {
 
-		colums := []string{"ID", "SectionNumber", "CurrentTemperature", "MinimumTemperature", "CurrentCapacity", "MinimumCapacity", "MaximumCapacity", "WarehouseID", "ProductTypeID"}
+		colums := []string{"ID", "SiteID", "SectionNumber", "CurrentTemperature", "MinimumTemperature", "CurrentCapacity", "MinimumCapacity", "MaximumCapacity", "WarehouseID", "ProductTypeID"}
 		rows := sqlmock.NewRows(colums)
 
-		rows.AddRow(FakeSection[0].ID, FakeSection[0].SectionNumber, FakeSection[0].CurrentTemperature, FakeSection[0].MinimumTemperature, FakeSection[0].CurrentCapacity, FakeSection[0].MinimumCapacity, FakeSection[0].MaximumCapacity, FakeSection[0].WarehouseID, FakeSection[0].ProductTypeID)
+		rows.AddRow(FakeSection[0].ID, FakeSection[0].SiteID, FakeSection[0].SectionNumber, FakeSection[0].CurrentTemperature, FakeSection[0].MinimumTemperature, FakeSection[0].CurrentCapacity, FakeSection[0].MinimumCapacity, FakeSection[0].MaximumCapacity, FakeSection[0].WarehouseID, FakeSection[0].ProductTypeID)
 
-		rows.AddRow(FakeSection[1].ID, FakeSection[1].SectionNumber, FakeSection[1].CurrentTemperature, FakeSection[1].MinimumTemperature, FakeSection[1].CurrentCapacity, FakeSection[1].MinimumCapacity, FakeSection[1].MaximumCapacity, FakeSection[1].WarehouseID, FakeSection[1].ProductTypeID)
+		rows.AddRow(FakeSection[1].ID, FakeSection[1].SiteID, FakeSection[1].SectionNumber, FakeSection[1].CurrentTemperature, FakeSection[1].MinimumTemperature, FakeSection[1].CurrentCapacity, FakeSection[1].MinimumCapacity, FakeSection[1].MaximumCapacity, FakeSection[1].WarehouseID, FakeSection[1].ProductTypeID)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM sections`)).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, site_id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id FROM sections WHERE site_id = COALESCE(?, site_id);`)).WithArgs("MLA").WillReturnRows(rows)
 
-		repo := NewRepository(db)
-		sections, err := repo.GetAll(context.Background())
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		sections, err := repo.GetAll(tenant.NewContext(context.Background(), "MLA"))
 
 		assert.Nil(t, err)
 		t.Log("total sections", sections)
@@ -73,17 +78,17 @@ func TestGetByIdSect(t *testing.T) {
 	defer db.Close()
 
 	t.Run("Get section by id", func(t *testing.T) {
-		colums := []string{"ID", "SectionNumber", "CurrentTemperature", "MinimumTemperature", "CurrentCapacity", "MinimumCapacity", "MaximumCapacity", "WarehouseID", "ProductTypeID"}
+		colums := []string{"ID", "SiteID", "SectionNumber", "CurrentTemperature", "MinimumTemperature", "CurrentCapacity", "MinimumCapacity", "MaximumCapacity", "WarehouseID", "ProductTypeID"}
 		rows := sqlmock.NewRows(colums)
 
-		rows.AddRow(FakeSection[0].ID, FakeSection[0].SectionNumber, FakeSection[0].CurrentTemperature, FakeSection[0].MinimumTemperature, FakeSection[0].CurrentCapacity, FakeSection[0].MinimumCapacity, FakeSection[0].MaximumCapacity, FakeSection[0].WarehouseID, FakeSection[0].ProductTypeID)
+		rows.AddRow(FakeSection[0].ID, FakeSection[0].SiteID, FakeSection[0].SectionNumber, FakeSection[0].CurrentTemperature, FakeSection[0].MinimumTemperature, FakeSection[0].CurrentCapacity, FakeSection[0].MinimumCapacity, FakeSection[0].MaximumCapacity, FakeSection[0].WarehouseID, FakeSection[0].ProductTypeID)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM sections WHERE id=?;`)).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, site_id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id FROM sections WHERE id=? AND site_id = COALESCE(?, site_id);`)).WillReturnRows(rows)
 
 		t.Log("section", FakeSection[0].ID)
 
-		repo := NewRepository(db)
-		section, err := repo.Get(context.Background(), 1)
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		section, err := repo.Get(tenant.NewContext(context.Background(), "MLA"), 1)
 
 		t.Log("section", section)
 		assert.Nil(t, err)
@@ -97,15 +102,15 @@ func TestExistsSect(t *testing.T) {
 	defer db.Close()
 
 	t.Run("Get section by id", func(t *testing.T) {
-		colums := []string{"ID", "SectionNumber", "CurrentTemperature", "MinimumTemperature", "CurrentCapacity", "MinimumCapacity", "MaximumCapacity", "WarehouseID", "ProductTypeID"}
+		colums := []string{"ID", "SiteID", "SectionNumber", "CurrentTemperature", "MinimumTemperature", "CurrentCapacity", "MinimumCapacity", "MaximumCapacity", "WarehouseID", "ProductTypeID"}
 		rows := sqlmock.NewRows(colums)
 
-		rows.AddRow(FakeSection[0].ID, FakeSection[0].SectionNumber, FakeSection[0].CurrentTemperature, FakeSection[0].MinimumTemperature, FakeSection[0].CurrentCapacity, FakeSection[0].MinimumCapacity, FakeSection[0].MaximumCapacity, FakeSection[0].WarehouseID, FakeSection[0].ProductTypeID)
+		rows.AddRow(FakeSection[0].ID, FakeSection[0].SiteID, FakeSection[0].SectionNumber, FakeSection[0].CurrentTemperature, FakeSection[0].MinimumTemperature, FakeSection[0].CurrentCapacity, FakeSection[0].MinimumCapacity, FakeSection[0].MaximumCapacity, FakeSection[0].WarehouseID, FakeSection[0].ProductTypeID)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT section_number FROM sections WHERE section_number=?;`)).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT section_number FROM sections WHERE section_number=? AND site_id = COALESCE(?, site_id);`)).WithArgs(1, "MLA").WillReturnRows(rows)
 
-		repo := NewRepository(db)
-		section := repo.Exists(context.Background(), 1)
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		section := repo.Exists(tenant.NewContext(context.Background(), "MLA"), 1)
 		assert.False(t, section)
 	})
 }
@@ -116,20 +121,20 @@ func TestSaveSect(t *testing.T) {
 	defer db.Close()
 
 	t.Run("Save section Ok", func(t *testing.T) {
-		mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO sections (section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?);`))
-		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO sections (section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?);`)).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_SECTION))
+		mock.ExpectExec(regexp.QuoteMeta(SAVE_SECTION)).WillReturnResult(sqlmock.NewResult(1, 1))
 
-		columns := []string{"ID", "SectionNumber", "CurrentTemperature", "MinimumTemperature", "CurrentCapacity", "MinimumCapacity", "MaximumCapacity", "WarehouseID", "ProductTypeID"}
+		columns := []string{"ID", "SiteID", "SectionNumber", "CurrentTemperature", "MinimumTemperature", "CurrentCapacity", "MinimumCapacity", "MaximumCapacity", "WarehouseID", "ProductTypeID"}
 		rows := sqlmock.NewRows(columns)
-		rows.AddRow(FakeSection[0].ID, FakeSection[0].SectionNumber, FakeSection[0].CurrentTemperature, FakeSection[0].MinimumTemperature, FakeSection[0].CurrentCapacity, FakeSection[0].MinimumCapacity, FakeSection[0].MaximumCapacity, FakeSection[0].WarehouseID, FakeSection[0].ProductTypeID)
+		rows.AddRow(FakeSection[0].ID, FakeSection[0].SiteID, FakeSection[0].SectionNumber, FakeSection[0].CurrentTemperature, FakeSection[0].MinimumTemperature, FakeSection[0].CurrentCapacity, FakeSection[0].MinimumCapacity, FakeSection[0].MaximumCapacity, FakeSection[0].WarehouseID, FakeSection[0].ProductTypeID)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM sections WHERE id=?;`)).WithArgs(1).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, site_id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id FROM sections WHERE id=? AND site_id = COALESCE(?, site_id);`)).WithArgs(1, "MLA").WillReturnRows(rows)
 
-		repo := NewRepository(db)
-		id, err := repo.Save(context.Background(), FakeSection[0])
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		id, err := repo.Save(tenant.NewContext(context.Background(), "MLA"), FakeSection[0])
 		assert.NoError(t, err)
 
-		section, err := repo.Get(context.TODO(), id)
+		section, err := repo.Get(tenant.NewContext(context.TODO(), "MLA"), id)
 		assert.NoError(t, err)
 
 		assert.NotNil(t, section)
@@ -137,22 +142,22 @@ func TestSaveSect(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 	t.Run("Fail Exec", func(t *testing.T) {
-		mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO sections (section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?);`))
-		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO sections (section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?);`)).WillReturnError(errorExec)
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_SECTION))
+		mock.ExpectExec(regexp.QuoteMeta(SAVE_SECTION)).WillReturnError(errorExec)
 
-		repo := NewRepository(db)
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
-		id, err := repo.Save(context.Background(), FakeSection[0])
+		id, err := repo.Save(tenant.NewContext(context.Background(), "MLA"), FakeSection[0])
 
 		assert.EqualError(t, err, errorExec.Error())
 		assert.Equal(t, 0, id)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 	t.Run("Fail Prepare", func(t *testing.T) {
-		mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO sections (section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?);`)).WillReturnError(errorPrepare)
+		mock.ExpectPrepare(regexp.QuoteMeta(SAVE_SECTION)).WillReturnError(errorPrepare)
 
-		repo := NewRepository(db)
-		id, err := repo.Save(context.Background(), FakeSection[0])
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		id, err := repo.Save(tenant.NewContext(context.Background(), "MLA"), FakeSection[0])
 
 		assert.EqualError(t, err, errorPrepare.Error())
 		assert.Equal(t, 0, id)
@@ -179,13 +184,13 @@ func TestUpdateSect(t *testing.T) {
 			ProductTypeID:      1,
 		}
 
-		query := regexp.QuoteMeta("UPDATE sections SET section_number=?, current_temperature=?, minimum_temperature=?, current_capacity=?, minimum_capacity=?, maximum_capacity=?, warehouse_id=?, product_type_id=? WHERE id=?;")
+		query := regexp.QuoteMeta(UPDATE_SECTION)
 
 		mock.ExpectPrepare(query)
 		mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(1, 1))
-		repository := NewRepository(db)
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
-		err = repository.Update(context.TODO(), expected)
+		err = repository.Update(tenant.NewContext(context.TODO(), "MLA"), expected)
 
 		assert.NoError(t, err)
 	})
@@ -202,10 +207,10 @@ func TestUpdateSect(t *testing.T) {
 			ProductTypeID:      1,
 		}
 
-		query := regexp.QuoteMeta("UPDATE sections SET section_number=?, current_temperature=?, minimum_temperature=?, current_capacity=?, minimum_capacity=?, maximum_capacity=?, warehouse_id=?, product_type_id=? WHERE id=?;")
+		query := regexp.QuoteMeta(UPDATE_SECTION)
 		mock.ExpectPrepare(query).WillReturnError(errors.New(""))
-		repository := NewRepository(db)
-		err = repository.Update(context.TODO(), expected)
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		err = repository.Update(tenant.NewContext(context.TODO(), "MLA"), expected)
 		assert.Error(t, err)
 	})
 	t.Run("Fail Exec", func(t *testing.T) {
@@ -224,9 +229,9 @@ func TestUpdateSect(t *testing.T) {
 		query := regexp.QuoteMeta("UPDATE employees SET first_name=?, last_name=?, warehouse_id=?  WHERE id=?")
 		mock.ExpectPrepare(query)
 		mock.ExpectExec(query).WillReturnError(errors.New(""))
-		repository := NewRepository(db)
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
-		err = repository.Update(context.TODO(), expected)
+		err = repository.Update(tenant.NewContext(context.TODO(), "MLA"), expected)
 
 		assert.Error(t, err)
 	})
@@ -240,34 +245,34 @@ func TestErrors(t *testing.T) {
 
 	t.Run("Delete Execute Conflict", func(t *testing.T) {
 
-		query := regexp.QuoteMeta("DELETE FROM sections WHERE id=?;")
+		query := regexp.QuoteMeta(DELETE_SECTION)
 		mock.ExpectPrepare(query)
 		mock.ExpectExec(query).WillReturnError(errors.New(""))
-		repository := NewRepository(db)
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
-		err = repository.Delete(context.TODO(), 1)
+		err = repository.Delete(tenant.NewContext(context.TODO(), "MLA"), 1)
 
 		assert.Error(t, err)
 
 	})
 	t.Run("Delete Prepare Conflict", func(t *testing.T) {
 
-		query := regexp.QuoteMeta("DELETE FROM sections WHERE id=?")
+		query := regexp.QuoteMeta(DELETE_SECTION)
 		mock.ExpectPrepare(query).WillReturnError(errors.New(""))
-		repository := NewRepository(db)
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
-		err = repository.Delete(context.TODO(), 1)
+		err = repository.Delete(tenant.NewContext(context.TODO(), "MLA"), 1)
 
 		assert.Error(t, err)
 	})
 	t.Run("Delete Row Affected Conflict", func(t *testing.T) {
 
-		query := regexp.QuoteMeta("DELETE FROM sections WHERE id=?;")
+		query := regexp.QuoteMeta(DELETE_SECTION)
 		mock.ExpectPrepare(query)
 		mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(1, 0))
-		repository := NewRepository(db)
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
-		err = repository.Delete(context.TODO(), 1)
+		err = repository.Delete(tenant.NewContext(context.TODO(), "MLA"), 1)
 
 		assert.Error(t, err)
 	})
@@ -2,15 +2,17 @@ package section
 
 import (
 	"context"
-	"errors"
+	"log/slog"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 // Errors
 var (
-	ErrNotFound = errors.New("section not found")
-	ErrExists   = errors.New("section already exists")
+	ErrNotFound = apperrors.NotFound("section not found")
+	ErrExists   = apperrors.Conflict("section already exists")
 )
 
 // Paso 1. Se debe generar la interface Service con todos sus métodos.
@@ -26,12 +28,16 @@ type Service interface {
 // Paso 2. Se debe generar la estructura service que contenga el repositorio.
 type service struct {
 	repository Repository
+	outbox     events.Outbox
+	logger     *slog.Logger
 }
 
 // Paso 3. Se debe generar una función que devuelva el Servicio.
-func NewService(r Repository) Service {
+func NewService(r Repository, outbox events.Outbox, logger *slog.Logger) Service {
 	return &service{
 		repository: r,
+		outbox:     outbox,
+		logger:     logger,
 	}
 }
 
@@ -65,9 +71,23 @@ func (r *service) Save(ctx context.Context, s domain.Section) (int, error) {
 	exists := r.Exists(ctx, s.SectionNumber)
 
 	if exists {
-		return 0, ErrExists //"section already exists"
+		return 0, ErrExists
 	}
-	return r.repository.Save(ctx, s)
+	var id int
+	err := r.outbox.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		var err error
+		id, err = r.repository.Save(ctx, s)
+		if err != nil {
+			return nil, err
+		}
+		s.ID = id
+		return breaches(domain.Section{}, s), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	r.logger.InfoContext(ctx, "section created", "section_id", id)
+	return id, nil
 
 }
 
@@ -88,6 +108,7 @@ func (r *service) Update(ctx context.Context, ID int, SectionNumber int, Current
 	if err != nil {
 		return domain.Section{}, err
 	}
+	before := sect
 	if SectionNumber != 0 {
 		sect.SectionNumber = SectionNumber
 	}
@@ -112,6 +133,36 @@ func (r *service) Update(ctx context.Context, ID int, SectionNumber int, Current
 	if ProductTypeID != 0 {
 		sect.ProductTypeID = ProductTypeID
 	}
-	return sect, r.repository.Update(ctx, sect)
+	err = r.outbox.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		if err := r.repository.Update(ctx, sect); err != nil {
+			return nil, err
+		}
+		return breaches(before, sect), nil
+	})
+	return sect, err
+
+}
 
+// breaches returns a SectionTemperatureBreach event when after is colder
+// than its minimum and before wasn't, so a section that stays below its
+// minimum is only reported once.
+func breaches(before, after domain.Section) []events.Event {
+	if after.CurrentTemperature >= after.MinimumTemperature {
+		return nil
+	}
+	if before.ID != 0 && before.CurrentTemperature < before.MinimumTemperature {
+		return nil
+	}
+	return []events.Event{{
+		Type:        events.SectionTemperatureBreach,
+		AggregateID: after.ID,
+		WarehouseID: after.WarehouseID,
+		Data: events.TemperatureBreach{
+			SectionID:          after.ID,
+			SectionNumber:      after.SectionNumber,
+			WarehouseID:        after.WarehouseID,
+			CurrentTemperature: after.CurrentTemperature,
+			MinimumTemperature: after.MinimumTemperature,
+		},
+	}}
 }
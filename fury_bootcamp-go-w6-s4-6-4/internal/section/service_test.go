@@ -7,6 +7,9 @@ import (
 	"testing"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	eventsmock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/events"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/section"
 	"github.com/stretchr/testify/assert"
 )
@@ -44,7 +47,7 @@ func TestGetAll(t *testing.T) {
 
 		//Act
 		//Actuar
-		service := NewService(&mockRepository)
+		service := NewService(&mockRepository, events.Discard, logger.Discard())
 		result, err := service.GetAll(context.Background())
 
 		//Assert
@@ -61,7 +64,7 @@ func TestGetAll(t *testing.T) {
 
 		//Act
 		//Actuar
-		service := NewService(&mockRepository)
+		service := NewService(&mockRepository, events.Discard, logger.Discard())
 		_, err := service.GetAll(context.Background())
 
 		//Assert
@@ -103,7 +106,7 @@ func TestFind_by_id_existent(t *testing.T) {
 	//Act
 	//Actuar
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, events.Discard, logger.Discard())
 	result, err := service.Get(context.Background(), 2)
 
 	//Assert
@@ -147,7 +150,7 @@ func TestFind_by_id_not_existent(t *testing.T) {
 	//Act
 	//Actuar
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, events.Discard, logger.Discard())
 	result, err := service.Get(context.Background(), 3)
 
 	//Assert
@@ -199,7 +202,7 @@ func TestDelete_ok(t *testing.T) {
 		},
 	}
 
-	service := NewService(&mocKRepository)
+	service := NewService(&mocKRepository, events.Discard, logger.Discard())
 	err := service.Delete(context.Background(), 1)
 
 	//Assert
@@ -231,7 +234,7 @@ func TestDelete_non_existent(t *testing.T) {
 
 	//Act
 	//Actuar
-	service := NewService(&mocKRepository)
+	service := NewService(&mocKRepository, events.Discard, logger.Discard())
 	err := service.Delete(context.Background(), 2)
 
 	//Assert
@@ -276,7 +279,7 @@ func TestCreate_ok(t *testing.T) {
 	//Act
 	//Actuar
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, events.Discard, logger.Discard())
 	result, err := service.Save(context.Background(), newSection)
 
 	//Assert
@@ -320,7 +323,7 @@ func TestCreate_conflict(t *testing.T) {
 
 	//Act
 	//Actuar
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, events.Discard, logger.Discard())
 	result, err := service.Save(context.Background(), newSection)
 
 	assert.Error(t, err)
@@ -363,7 +366,7 @@ func TestUpdate_Existent(t *testing.T) {
 	//Act
 	//Actuar
 	// iniciamos el newService para poder usar los metodos de service
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, events.Discard, logger.Discard())
 	result, err := service.Update(context.Background(), expectedUpdate.ID, expectedUpdate.SectionNumber, expectedUpdate.CurrentTemperature, expectedUpdate.MinimumTemperature, expectedUpdate.CurrentCapacity, expectedUpdate.MinimumCapacity, expectedUpdate.MaximumCapacity, expectedUpdate.WarehouseID, expectedUpdate.ProductTypeID)
 
 	//Assert
@@ -399,7 +402,7 @@ func TestUpdate_non_existent(t *testing.T) {
 	//Act
 	//Actuar
 	// iniciamos el newService para poder usar los metodos de service
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, events.Discard, logger.Discard())
 	result, err := service.Update(context.Background(), mockRepository.DataMock[0].ID+1, 2, 2, 2, 2, 2, 2, 2, 2)
 
 	//Assert
@@ -410,3 +413,45 @@ func TestUpdate_non_existent(t *testing.T) {
 	fmt.Println(domain.Section{})
 
 }
+
+func TestUpdate_temperature_breach(t *testing.T) {
+	tests := []struct {
+		name       string
+		current    int
+		update     int
+		wantBreach bool
+	}{
+		{name: "drops below the minimum", current: 5, update: -2, wantBreach: true},
+		{name: "stays above the minimum", current: 5, update: 3},
+		{name: "already below the minimum", current: -1, update: -3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepository := section.MockRepository{DataMock: []domain.Section{
+				{ID: 1, SectionNumber: 1, CurrentTemperature: tt.current, MinimumTemperature: 1, WarehouseID: 3},
+			}}
+			outbox := eventsmock.MockOutbox{}
+			service := NewService(&mockRepository, &outbox, logger.Discard())
+
+			_, err := service.Update(context.Background(), 1, 0, tt.update, 0, 0, 0, 0, 0, 0)
+
+			assert.NoError(t, err)
+			if !tt.wantBreach {
+				assert.Empty(t, outbox.Events)
+				return
+			}
+			assert.Equal(t, []events.Event{{
+				Type:        events.SectionTemperatureBreach,
+				AggregateID: 1,
+				WarehouseID: 3,
+				Data: events.TemperatureBreach{
+					SectionID:          1,
+					SectionNumber:      1,
+					WarehouseID:        3,
+					CurrentTemperature: tt.update,
+					MinimumTemperature: 1,
+				},
+			}}, outbox.Events)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package seller
+
+import (
+	"context"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/cache"
+)
+
+type cachedService struct {
+	Service
+	cache *cache.Cache
+}
+
+// WithCache returns s with its writes invalidating the sellers report of the
+// localities cached in c. A nil c leaves s as is.
+func WithCache(s Service, c *cache.Cache) Service {
+	if c == nil {
+		return s
+	}
+	return &cachedService{Service: s, cache: c}
+}
+
+func (s *cachedService) Save(ctx context.Context, cid, locality int, companyName, address, telephone string) (int, error) {
+	v, err := s.Service.Save(ctx, cid, locality, companyName, address, telephone)
+	if err == nil {
+		s.cache.Invalidate(ctx, "sellers")
+	}
+	return v, err
+}
+
+func (s *cachedService) Update(ctx context.Context, new domain.Seller) (domain.Seller, error) {
+	v, err := s.Service.Update(ctx, new)
+	if err == nil {
+		s.cache.Invalidate(ctx, "sellers")
+	}
+	return v, err
+}
+
+func (s *cachedService) Delete(ctx context.Context, id int) error {
+	err := s.Service.Delete(ctx, id)
+	if err == nil {
+		s.cache.Invalidate(ctx, "sellers")
+	}
+	return err
+}
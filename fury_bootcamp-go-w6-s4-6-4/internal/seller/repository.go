@@ -3,8 +3,13 @@ package seller
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"log/slog"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 )
 
 // Repository encapsulates the storage of a Seller.
@@ -19,114 +24,142 @@ type Repository interface {
 }
 
 type repository struct {
-	db *sql.DB
+	db     *database.Router
+	stmts  *database.Statements
+	logger *slog.Logger
 }
 
-func NewRepository(db *sql.DB) Repository {
+func NewRepository(db *database.Router, stmts *database.Statements, logger *slog.Logger) Repository {
+	stmts.Register(SAVE_SELLER, UPDATE_SELLER, DELETE_SELLER)
 	return &repository{
-		db: db,
+		db:     db,
+		stmts:  stmts,
+		logger: logger,
 	}
 }
 
+const (
+	SAVE_SELLER   = "INSERT INTO seller (site_id, cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?, ?)"
+	UPDATE_SELLER = "UPDATE seller SET cid=?, company_name=?, address=?, telephone=?, locality_id=? WHERE id=? AND site_id = COALESCE(?, site_id)"
+	DELETE_SELLER = "DELETE FROM seller WHERE id=? AND site_id = COALESCE(?, site_id)"
+)
+
 func (r *repository) GetAll(ctx context.Context) ([]domain.Seller, error) {
-	query := "SELECT * FROM seller"
-	rows, err := r.db.Query(query)
+	query := "SELECT id, site_id, cid, company_name, address, telephone, locality_id FROM seller WHERE site_id = COALESCE(?, site_id)"
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, tenant.Filter(ctx))
 	if err != nil {
-		return nil, err
+		return nil, apperrors.FromDB(err)
 	}
+	defer rows.Close()
 
 	var sellers []domain.Seller
 
 	for rows.Next() {
 		s := domain.Seller{}
-		_ = rows.Scan(&s.ID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.LocalityID)
+		if err := rows.Scan(&s.ID, &s.SiteID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.LocalityID); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
 		sellers = append(sellers, s)
 	}
 
-	return sellers, nil
+	return sellers, apperrors.FromDB(rows.Err())
 }
 
 func (r *repository) Get(ctx context.Context, id int) (domain.Seller, error) {
-	query := "SELECT * FROM seller WHERE id=?;"
-	row := r.db.QueryRow(query, id)
+	query := "SELECT id, site_id, cid, company_name, address, telephone, locality_id FROM seller WHERE id=? AND site_id = COALESCE(?, site_id);"
+	row := r.db.Writer().QueryRowContext(ctx, query, id, tenant.Filter(ctx))
 	s := domain.Seller{}
-	err := row.Scan(&s.ID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.LocalityID)
+	err := row.Scan(&s.ID, &s.SiteID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.LocalityID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Seller{}, ErrNotFound
+	}
 	if err != nil {
-		return domain.Seller{}, err
+		return domain.Seller{}, apperrors.FromDB(err)
 	}
 
 	return s, nil
 }
 
 func (r *repository) Exists(ctx context.Context, cid int) bool {
-	query := "SELECT cid FROM seller WHERE cid=?;"
-	row := r.db.QueryRow(query, cid)
+	query := "SELECT cid FROM seller WHERE cid=? AND site_id = COALESCE(?, site_id);"
+	row := r.db.Writer().QueryRowContext(ctx, query, cid, tenant.Filter(ctx))
 	err := row.Scan(&cid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", "Exists", "error", err)
+	}
 	return err == nil
 }
 
 func (r *repository) LocalityExists(ctx context.Context, locality int) bool {
-	query := "SELECT id FROM locality WHERE id=?;"
-	row := r.db.QueryRow(query, locality)
+	query := "SELECT id FROM locality WHERE id=? AND site_id = COALESCE(?, site_id);"
+	row := r.db.Writer().QueryRowContext(ctx, query, locality, tenant.Filter(ctx))
 	err := row.Scan(&locality)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", "LocalityExists", "error", err)
+	}
 	return err == nil
 }
 
 func (r *repository) Save(ctx context.Context, s domain.Seller) (int, error) {
-	query := "INSERT INTO seller (cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?)"
-	stmt, err := r.db.Prepare(query)
+	site, err := tenant.Site(ctx)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID)
+	query := SAVE_SELLER
+	stmt, err := r.stmts.Stmt(ctx, query)
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
+	}
+
+	res, err := stmt.ExecContext(ctx, site, s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
 	}
 
 	id, err := res.LastInsertId()
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
 	}
 
 	return int(id), nil
 }
 
 func (r *repository) Update(ctx context.Context, s domain.Seller) error {
-	query := "UPDATE seller SET cid=?, company_name=?, address=?, telephone=?, locality_id=? WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	query := UPDATE_SELLER
+	stmt, err := r.stmts.Stmt(ctx, query)
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
-	res, err := stmt.Exec(s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID, s.ID)
+	res, err := stmt.ExecContext(ctx, s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID, s.ID, tenant.Filter(ctx))
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	_, err = res.RowsAffected()
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	return nil
 }
 
 func (r *repository) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM seller WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	query := DELETE_SELLER
+	stmt, err := r.stmts.Stmt(ctx, query)
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
-	res, err := stmt.Exec(id)
+	res, err := stmt.ExecContext(ctx, id, tenant.Filter(ctx))
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	affect, err := res.RowsAffected()
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	if affect < 1 {
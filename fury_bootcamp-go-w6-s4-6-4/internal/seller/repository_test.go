@@ -9,6 +9,9 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -34,11 +37,11 @@ func TestExist(t *testing.T) {
 		rows := sqlmock.NewRows(columns)
 
 		rows.AddRow(s.CID)
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT cid FROM seller WHERE cid=?;")).WithArgs(1).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT cid FROM seller WHERE cid=? AND site_id = COALESCE(?, site_id);")).WithArgs(1, "MLA").WillReturnRows(rows)
 
-		repo := NewRepository(db)
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
-		resp := repo.Exists(context.TODO(), 1)
+		resp := repo.Exists(tenant.NewContext(context.TODO(), "MLA"), 1)
 
 		assert.True(t, resp)
 	})
@@ -48,11 +51,11 @@ func TestExist(t *testing.T) {
 		rows := sqlmock.NewRows(columns)
 
 		rows.AddRow(s.LocalityID)
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM locality WHERE id=?;")).WithArgs(s.LocalityID).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM locality WHERE id=? AND site_id = COALESCE(?, site_id);")).WithArgs(s.LocalityID, "MLA").WillReturnRows(rows)
 
-		repo := NewRepository(db)
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 
-		resp := repo.LocalityExists(context.TODO(), s.LocalityID)
+		resp := repo.LocalityExists(tenant.NewContext(context.TODO(), "MLA"), s.LocalityID)
 
 		assert.True(t, resp)
 	})
@@ -73,11 +76,11 @@ func TestCreate(t *testing.T) {
 
 	t.Run("create ok", func(t *testing.T) {
 
-		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO seller (cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?)"))
-		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO seller (cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?)")).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO seller (site_id, cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?, ?)"))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO seller (site_id, cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?, ?)")).WillReturnResult(sqlmock.NewResult(1, 1))
 
-		repository := NewRepository(db)
-		ctx := context.TODO()
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		newID, err := repository.Save(ctx, s)
 		assert.NoError(t, err)
@@ -92,11 +95,11 @@ func TestCreate(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
-		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO seller (cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?)"))
-		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO seller (cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?)")).WillReturnError(ErrForzado)
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO seller (site_id, cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?, ?)"))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO seller (site_id, cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?, ?)")).WillReturnError(ErrForzado)
 
-		repository := NewRepository(db)
-		ctx := context.TODO()
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		id, err := repository.Save(ctx, s)
 
@@ -111,19 +114,19 @@ func TestCreate(t *testing.T) {
 
 		defer db.Close()
 
-		columns := []string{"id", "cid", "company_name", "address", "telephone", "locality_id"}
+		columns := []string{"id", "site_id", "cid", "company_name", "address", "telephone", "locality_id"}
 		rows := sqlmock.NewRows(columns)
 		sellers := []domain.Seller{s}
 
 		for _, element := range sellers {
-			rows.AddRow(element.ID, element.CID, element.CompanyName, element.Address, element.Telephone, element.LocalityID)
+			rows.AddRow(element.ID, element.SiteID, element.CID, element.CompanyName, element.Address, element.Telephone, element.LocalityID)
 		}
 
-		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO seller (cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?)"))
-		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO seller (cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?)")).WithArgs(s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID).WillReturnError(ErrCidExists)
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO seller (site_id, cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?, ?)"))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO seller (site_id, cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?, ?)")).WithArgs("MLA", s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID).WillReturnError(ErrCidExists)
 
-		repository := NewRepository(db)
-		ctx := context.TODO()
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		id, err := repository.Save(ctx, s)
 
@@ -140,22 +143,23 @@ func TestGet(t *testing.T) {
 
 	t.Run("get ok", func(t *testing.T) {
 
-		columns := []string{"id", "cid", "company_name", "address", "telephone", "locality_id"}
+		columns := []string{"id", "site_id", "cid", "company_name", "address", "telephone", "locality_id"}
 		rows := sqlmock.NewRows(columns)
 		s := domain.Seller{
 			ID:          1,
+			SiteID:      "MLA",
 			CID:         19,
 			CompanyName: "LG",
 			Address:     "Avenida 11122",
 			Telephone:   "0303456",
 		}
 
-		rows.AddRow(s.ID, s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID)
+		rows.AddRow(s.ID, s.SiteID, s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID)
 
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM seller WHERE id=?;")).WithArgs(1).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, site_id, cid, company_name, address, telephone, locality_id FROM seller WHERE id=? AND site_id = COALESCE(?, site_id);")).WithArgs(1, "MLA").WillReturnRows(rows)
 
-		repo := NewRepository(db)
-		result, err := repo.Get(context.TODO(), 1)
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		result, err := repo.Get(tenant.NewContext(context.TODO(), "MLA"), 1)
 
 		assert.NoError(t, err)
 		assert.Equal(t, s, result)
@@ -164,7 +168,7 @@ func TestGet(t *testing.T) {
 
 	t.Run("get all ok", func(t *testing.T) {
 
-		columns := []string{"id", "cid", "company_name", "address", "telephone", "locality_id"}
+		columns := []string{"id", "site_id", "cid", "company_name", "address", "telephone", "locality_id"}
 		rows := sqlmock.NewRows(columns)
 		sellers := []domain.Seller{
 			{
@@ -177,13 +181,13 @@ func TestGet(t *testing.T) {
 		}
 
 		for _, s := range sellers {
-			rows.AddRow(s.ID, s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID)
+			rows.AddRow(s.ID, s.SiteID, s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID)
 		}
 
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM seller")).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, site_id, cid, company_name, address, telephone, locality_id FROM seller WHERE site_id = COALESCE(?, site_id)")).WithArgs(nil).WillReturnRows(rows)
 
-		repo := NewRepository(db)
-		result, err := repo.GetAll(context.TODO())
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		result, err := repo.GetAll(tenant.AllSites(context.TODO()))
 
 		assert.NoError(t, err)
 		assert.Equal(t, sellers, result)
@@ -207,16 +211,16 @@ func TestDelete(t *testing.T) {
 
 	t.Run("delete ok", func(t *testing.T) {
 
-		columns := []string{"id", "cid", "company_name", "address", "telephone", "locality_id"}
+		columns := []string{"id", "site_id", "cid", "company_name", "address", "telephone", "locality_id"}
 		rows := sqlmock.NewRows(columns)
 
-		rows.AddRow(s.ID, s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID)
+		rows.AddRow(s.ID, s.SiteID, s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM seller WHERE id=?"))
-		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM seller WHERE id=?")).WithArgs(s.ID).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM seller WHERE id=? AND site_id = COALESCE(?, site_id)"))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM seller WHERE id=? AND site_id = COALESCE(?, site_id)")).WithArgs(s.ID, "MLA").WillReturnResult(sqlmock.NewResult(1, 1))
 
-		repo := NewRepository(db)
-		err := repo.Delete(context.TODO(), s.ID)
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		err := repo.Delete(tenant.NewContext(context.TODO(), "MLA"), s.ID)
 
 		assert.NoError(t, err)
 	})
@@ -238,16 +242,16 @@ func TestUpdate(t *testing.T) {
 
 	t.Run("update ok", func(t *testing.T) {
 
-		columns := []string{"id", "cid", "company_name", "address", "telephone", "locality_id"}
+		columns := []string{"id", "site_id", "cid", "company_name", "address", "telephone", "locality_id"}
 		rows := sqlmock.NewRows(columns)
 
-		rows.AddRow(s.ID, s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID)
+		rows.AddRow(s.ID, s.SiteID, s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE seller SET cid=?, company_name=?, address=?, telephone=?, locality_id=? WHERE id=?"))
-		mock.ExpectExec(regexp.QuoteMeta("UPDATE seller SET cid=?, company_name=?, address=?, telephone=?, locality_id=? WHERE id=?")).WithArgs(s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID, s.ID).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE seller SET cid=?, company_name=?, address=?, telephone=?, locality_id=? WHERE id=? AND site_id = COALESCE(?, site_id)"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE seller SET cid=?, company_name=?, address=?, telephone=?, locality_id=? WHERE id=? AND site_id = COALESCE(?, site_id)")).WithArgs(s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID, s.ID, "MLA").WillReturnResult(sqlmock.NewResult(1, 1))
 
-		repo := NewRepository(db)
-		err := repo.Update(context.TODO(), s)
+		repo := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		err := repo.Update(tenant.NewContext(context.TODO(), "MLA"), s)
 
 		assert.NoError(t, err)
 	})
@@ -2,57 +2,61 @@ package seller
 
 import (
 	"context"
-	"errors"
+	"log/slog"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 // Errors
 var (
-	ErrNotFound         = errors.New("seller not found")
-	ErrRequired         = errors.New("field required")
-	ErrCidExists        = errors.New("cid already exists")
-	ErrLocalityNotFound = errors.New("locality id not found")
-	ErrRequest          = errors.New("incorrect field content")
+	ErrNotFound         = apperrors.NotFound("seller not found")
+	ErrRequired         = apperrors.Validation("field required")
+	ErrCidExists        = apperrors.Conflict("cid already exists")
+	ErrLocalityNotFound = apperrors.ForeignKeyViolation("locality id not found")
+	ErrRequest          = apperrors.Validation("incorrect field content")
 )
 
 type Service interface {
-	GetAll() ([]domain.Seller, error)
-	Get(id int) (domain.Seller, error)
-	Exists(cid int) bool
-	Save(cid, locality int, companyName, address, telephone string) (int, error)
-	Update(new domain.Seller) (domain.Seller, error)
-	Delete(id int) error
+	GetAll(ctx context.Context) ([]domain.Seller, error)
+	Get(ctx context.Context, id int) (domain.Seller, error)
+	Exists(ctx context.Context, cid int) bool
+	Save(ctx context.Context, cid, locality int, companyName, address, telephone string) (int, error)
+	Update(ctx context.Context, new domain.Seller) (domain.Seller, error)
+	Delete(ctx context.Context, id int) error
 }
 
 type service struct {
 	repository Repository
+	outbox     events.Outbox
+	logger     *slog.Logger
 }
 
-func NewService(repository Repository) Service {
-	return &service{repository}
+func NewService(repository Repository, outbox events.Outbox, logger *slog.Logger) Service {
+	return &service{repository, outbox, logger}
 }
 
-func (s *service) GetAll() ([]domain.Seller, error) {
-	return s.repository.GetAll(context.Background())
+func (s *service) GetAll(ctx context.Context) ([]domain.Seller, error) {
+	return s.repository.GetAll(ctx)
 }
 
-func (s *service) Get(id int) (domain.Seller, error) {
-	return s.repository.Get(context.Background(), id)
+func (s *service) Get(ctx context.Context, id int) (domain.Seller, error) {
+	return s.repository.Get(ctx, id)
 }
 
-func (s *service) Exists(cid int) bool {
-	return s.repository.Exists(context.Background(), cid)
+func (s *service) Exists(ctx context.Context, cid int) bool {
+	return s.repository.Exists(ctx, cid)
 }
 
-func (s *service) Save(cid, locality int, companyName, address, telephone string) (int, error) {
+func (s *service) Save(ctx context.Context, cid, locality int, companyName, address, telephone string) (int, error) {
 	var seller domain.Seller
 
-	if s.repository.Exists(context.Background(), cid) {
+	if s.repository.Exists(ctx, cid) {
 		return 0, ErrCidExists
 	}
 
-	if !s.repository.LocalityExists(context.Background(), locality) {
+	if !s.repository.LocalityExists(ctx, locality) {
 		return 0, ErrLocalityNotFound
 	}
 
@@ -79,13 +83,27 @@ func (s *service) Save(cid, locality int, companyName, address, telephone string
 		return 0, ErrRequest
 	}
 
-	return s.repository.Save(context.Background(), seller)
+	var id int
+	err := s.outbox.Record(ctx, func(ctx context.Context) ([]events.Event, error) {
+		var err error
+		id, err = s.repository.Save(ctx, seller)
+		if err != nil {
+			return nil, err
+		}
+		seller.ID = id
+		return []events.Event{{Type: events.SellerCreated, AggregateID: id, Data: seller}}, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	s.logger.InfoContext(ctx, "seller created", "seller_id", id)
+	return id, nil
 }
 
-func (s *service) Update(new domain.Seller) (domain.Seller, error) {
-	anterior, err := s.repository.Get(context.Background(), new.ID)
+func (s *service) Update(ctx context.Context, new domain.Seller) (domain.Seller, error) {
+	anterior, err := s.repository.Get(ctx, new.ID)
 	if err != nil {
-		return domain.Seller{}, ErrNotFound
+		return domain.Seller{}, err
 	}
 
 	if new.Address == "" {
@@ -104,9 +122,9 @@ func (s *service) Update(new domain.Seller) (domain.Seller, error) {
 		new.LocalityID = anterior.LocalityID
 	}
 
-	return new, s.repository.Update(context.Background(), new)
+	return new, s.repository.Update(ctx, new)
 }
 
-func (s *service) Delete(id int) error {
-	return s.repository.Delete(context.Background(), id)
+func (s *service) Delete(ctx context.Context, id int) error {
+	return s.repository.Delete(ctx, id)
 }
@@ -1,9 +1,13 @@
 package seller
 
 import (
+	"context"
 	"testing"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	eventsmock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/events"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/sellers"
 	"github.com/stretchr/testify/assert"
 )
@@ -19,16 +23,31 @@ func TestCreateOK(t *testing.T) {
 		Error:    "",
 	}
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, events.Discard, logger.Discard())
 
 	//Act
-	results, err := service.Save(89, 6700, "Empresa", "Direccion", "92388372")
+	results, err := service.Save(context.Background(), 89, 6700, "Empresa", "Direccion", "92388372")
 
 	//Assert
 	assert.Nil(t, err)
 	assert.Equal(t, id, results)
 }
 
+func TestCreateRecordsEvent(t *testing.T) {
+	mockRepository := sellers.MockRepository{DataMock: []domain.Seller{}}
+	outbox := eventsmock.MockOutbox{}
+	service := NewService(&mockRepository, &outbox, logger.Discard())
+
+	id, err := service.Save(context.Background(), 89, 6700, "Empresa", "Direccion", "92388372")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []events.Event{{
+		Type:        events.SellerCreated,
+		AggregateID: id,
+		Data:        domain.Seller{ID: id, CID: 89, CompanyName: "Empresa", Address: "Direccion", Telephone: "92388372", LocalityID: 6700},
+	}}, outbox.Events)
+}
+
 func TestCreateConflict(t *testing.T) {
 	//Arrange
 	database := []domain.Seller{
@@ -46,15 +65,15 @@ func TestCreateConflict(t *testing.T) {
 		Error:    "",
 	}
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, events.Discard, logger.Discard())
 
 	//Act
-	_, err := service.Save(19, 6700, "Empresa", "Direccion", "92388372")
-	_, err2 := service.Save(0, 6700, "Empresa", "Direccion", "92388372")
-	_, err3 := service.Save(11, 6700, "", "Direccion", "92388372")
-	_, err4 := service.Save(11, 6700, "Empresa", "", "92388372")
-	_, err5 := service.Save(11, 6700, "Empresa", "Direccion", "")
-	_, err6 := service.Save(-10, 6700, "Empresa", "Direccion", "92388372")
+	_, err := service.Save(context.Background(), 19, 6700, "Empresa", "Direccion", "92388372")
+	_, err2 := service.Save(context.Background(), 0, 6700, "Empresa", "Direccion", "92388372")
+	_, err3 := service.Save(context.Background(), 11, 6700, "", "Direccion", "92388372")
+	_, err4 := service.Save(context.Background(), 11, 6700, "Empresa", "", "92388372")
+	_, err5 := service.Save(context.Background(), 11, 6700, "Empresa", "Direccion", "")
+	_, err6 := service.Save(context.Background(), -10, 6700, "Empresa", "Direccion", "92388372")
 
 	//Assert
 	assert.ErrorContains(t, err, "cid already exists")
@@ -89,10 +108,10 @@ func TestServiceGetAll(t *testing.T) {
 		Error:    "",
 	}
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, events.Discard, logger.Discard())
 
 	// Act.
-	results, err := service.GetAll()
+	results, err := service.GetAll(context.Background())
 
 	// Assert.
 	assert.Nil(t, err)
@@ -116,10 +135,10 @@ func TestFindByIdNonExistent(t *testing.T) {
 		Error:    "",
 	}
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, events.Discard, logger.Discard())
 
 	// Act.
-	results, err := service.Get(2)
+	results, err := service.Get(context.Background(), 2)
 
 	// Assert.
 	assert.NotNil(t, err)
@@ -143,10 +162,10 @@ func TestFindByIdExistent(t *testing.T) {
 		Error:    "",
 	}
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, events.Discard, logger.Discard())
 
 	// Act.
-	results, err := service.Get(1)
+	results, err := service.Get(context.Background(), 1)
 
 	// Assert.
 	assert.Nil(t, err)
@@ -170,7 +189,7 @@ func TestUpdateOK(t *testing.T) {
 		Error:    "",
 	}
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, events.Discard, logger.Discard())
 
 	new := domain.Seller{ID: 1, CID: 34}
 	esperado := domain.Seller{
@@ -182,7 +201,7 @@ func TestUpdateOK(t *testing.T) {
 	}
 
 	// Act.
-	results, err := service.Update(new)
+	results, err := service.Update(context.Background(), new)
 
 	// Assert.
 	assert.Nil(t, err)
@@ -206,12 +225,12 @@ func TestUpdateNonExistent(t *testing.T) {
 		Error:    "",
 	}
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, events.Discard, logger.Discard())
 
 	new := domain.Seller{ID: 2}
 
 	// Act.
-	results, err := service.Update(new)
+	results, err := service.Update(context.Background(), new)
 
 	// Assert.
 	assert.NotNil(t, err)
@@ -235,10 +254,10 @@ func TestDeleteNonExistent(t *testing.T) {
 		Error:    "",
 	}
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, events.Discard, logger.Discard())
 
 	// Act.
-	err := service.Delete(2)
+	err := service.Delete(context.Background(), 2)
 
 	// Assert.
 	assert.NotNil(t, err)
@@ -278,10 +297,10 @@ func TestDeleteOK(t *testing.T) {
 		Error:    "",
 	}
 
-	service := NewService(&mockRepository)
+	service := NewService(&mockRepository, events.Discard, logger.Discard())
 
 	// Act.
-	err := service.Delete(2)
+	err := service.Delete(context.Background(), 2)
 
 	// Assert.
 	assert.Nil(t, err)
@@ -0,0 +1,53 @@
+package warehouse
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+)
+
+const (
+	EMPLOYEE_WAREHOUSE = "SELECT warehouse_id FROM employees WHERE id=? AND site_id = COALESCE(?, site_id)"
+	SECTION_WAREHOUSE  = "SELECT warehouse_id FROM sections WHERE id=? AND site_id = COALESCE(?, site_id)"
+)
+
+// Assignments finds the warehouse employees work at and sections are in. The
+// repositories of the documents tied to a warehouse embed it, so their
+// services check that the employees and sections they name belong to it.
+type Assignments struct {
+	db *database.Router
+}
+
+func NewAssignments(db *database.Router) *Assignments {
+	return &Assignments{db: db}
+}
+
+// EmployeeWarehouse returns the warehouse the employee works at, and false
+// when there's no such employee.
+func (a *Assignments) EmployeeWarehouse(ctx context.Context, id int) (int, bool, error) {
+	return a.lookup(ctx, EMPLOYEE_WAREHOUSE, id)
+}
+
+// SectionWarehouse returns the warehouse of the section, and false when
+// there's no such section.
+func (a *Assignments) SectionWarehouse(ctx context.Context, id int) (int, bool, error) {
+	return a.lookup(ctx, SECTION_WAREHOUSE, id)
+}
+
+// lookup reads the writer, as the checks come before writes that must not
+// miss a row just created.
+func (a *Assignments) lookup(ctx context.Context, query string, id int) (int, bool, error) {
+	var warehouse int
+	err := a.db.Writer().QueryRowContext(ctx, query, id, tenant.Filter(ctx)).Scan(&warehouse)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, apperrors.FromDB(err)
+	}
+	return warehouse, true, nil
+}
@@ -0,0 +1,57 @@
+package warehouse
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Assignments(t *testing.T) {
+	ctx := tenant.NewContext(context.TODO(), "MLA")
+	newAssignments := func(t *testing.T) (*Assignments, sqlmock.Sqlmock) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+		return NewAssignments(database.NewRouter(db, nil, logger.Discard())), mock
+	}
+
+	t.Run("should return the warehouse of the employee", func(t *testing.T) {
+		assignments, mock := newAssignments(t)
+		mock.ExpectQuery(regexp.QuoteMeta(EMPLOYEE_WAREHOUSE)).WithArgs(1, "MLA").WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}).AddRow(2))
+
+		warehouse, found, err := assignments.EmployeeWarehouse(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, 2, warehouse)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should not find a missing section", func(t *testing.T) {
+		assignments, mock := newAssignments(t)
+		mock.ExpectQuery(regexp.QuoteMeta(SECTION_WAREHOUSE)).WithArgs(9, "MLA").WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}))
+
+		_, found, err := assignments.SectionWarehouse(ctx, 9)
+
+		assert.NoError(t, err)
+		assert.False(t, found)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("should return the failure of the database", func(t *testing.T) {
+		assignments, mock := newAssignments(t)
+		mock.ExpectQuery(regexp.QuoteMeta(EMPLOYEE_WAREHOUSE)).WithArgs(1, "MLA").WillReturnError(sql.ErrConnDone)
+
+		_, found, err := assignments.EmployeeWarehouse(ctx, 1)
+
+		assert.ErrorIs(t, err, apperrors.ErrUnavailable)
+		assert.False(t, found)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -3,8 +3,13 @@ package warehouse
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"log/slog"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 )
 
 // Repository encapsulates the storage of a warehouse.
@@ -15,111 +20,137 @@ type Repository interface {
 	Save(ctx context.Context, w domain.Warehouse) (int, error)
 	Update(ctx context.Context, w domain.Warehouse) error
 	Delete(ctx context.Context, id int) error
+	StockOnHand(ctx context.Context) (map[int]int, error)
 }
 
 type repository struct {
-	db *sql.DB
+	db     *database.Router
+	stmts  *database.Statements
+	logger *slog.Logger
 }
 
-func NewRepository(db *sql.DB) Repository {
+func NewRepository(db *database.Router, stmts *database.Statements, logger *slog.Logger) Repository {
+	stmts.Register(SAVE_WAREHOUSE, UPDATE_WAREHOUSE, DELETE_WAREHOUSE)
 	return &repository{
-		db: db,
+		db:     db,
+		stmts:  stmts,
+		logger: logger,
 	}
 }
 
+const (
+	SAVE_WAREHOUSE   = "INSERT INTO warehouses (site_id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature) VALUES (?, ?, ?, ?, ?, ?)"
+	UPDATE_WAREHOUSE = "UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=? WHERE id=? AND site_id = COALESCE(?, site_id)"
+	DELETE_WAREHOUSE = "DELETE FROM warehouses WHERE id=? AND site_id = COALESCE(?, site_id)"
+)
+
 func (r *repository) GetAll(ctx context.Context) ([]domain.Warehouse, error) {
-	query := "SELECT * FROM warehouses"
-	rows, err := r.db.Query(query)
+	query := "SELECT id, site_id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature FROM warehouses WHERE site_id = COALESCE(?, site_id)"
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, tenant.Filter(ctx))
 	if err != nil {
-		return nil, err
+		return nil, apperrors.FromDB(err)
 	}
+	defer rows.Close()
 
 	var warehouses []domain.Warehouse
 
 	for rows.Next() {
 		w := domain.Warehouse{}
-		_ = rows.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature)
+		if err := rows.Scan(&w.ID, &w.SiteID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
 		warehouses = append(warehouses, w)
 	}
 
-	return warehouses, nil
+	return warehouses, apperrors.FromDB(rows.Err())
 }
 
 func (r *repository) Get(ctx context.Context, id int) (domain.Warehouse, error) {
-	query := "SELECT * FROM warehouses WHERE id=?;"
-	row := r.db.QueryRow(query, id)
+	query := "SELECT id, site_id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature FROM warehouses WHERE id=? AND site_id = COALESCE(?, site_id);"
+	row := r.db.Writer().QueryRowContext(ctx, query, id, tenant.Filter(ctx))
 	w := domain.Warehouse{}
-	err := row.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature)
+	err := row.Scan(&w.ID, &w.SiteID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Warehouse{}, ErrNotFound
+	}
 	if err != nil {
-		return domain.Warehouse{}, err
+		return domain.Warehouse{}, apperrors.FromDB(err)
 	}
 
 	return w, nil
 }
 
 func (r *repository) Exists(ctx context.Context, warehouseCode string) bool {
-	query := "SELECT warehouse_code FROM warehouses WHERE warehouse_code=?;"
-	row := r.db.QueryRow(query, warehouseCode)
+	query := "SELECT warehouse_code FROM warehouses WHERE warehouse_code=? AND site_id = COALESCE(?, site_id);"
+	row := r.db.Writer().QueryRowContext(ctx, query, warehouseCode, tenant.Filter(ctx))
 	err := row.Scan(&warehouseCode)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "existence check failed", "check", "Exists", "error", err)
+	}
 	return err == nil
 }
 
 func (r *repository) Save(ctx context.Context, w domain.Warehouse) (int, error) {
-	query := "INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature) VALUES (?, ?, ?, ?, ?)"
-
-	stmt, err := r.db.Prepare(query)
+	site, err := tenant.Site(ctx)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(&w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature)
+	query := SAVE_WAREHOUSE
+
+	stmt, err := r.stmts.Stmt(ctx, query)
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
+	}
+
+	res, err := stmt.ExecContext(ctx, site, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
 	}
 
 	id, err := res.LastInsertId()
 	if err != nil {
-		return 0, err
+		return 0, apperrors.FromDB(err)
 	}
 
 	return int(id), nil
 }
 
 func (r *repository) Update(ctx context.Context, w domain.Warehouse) error {
-	query := "UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=? WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	query := UPDATE_WAREHOUSE
+	stmt, err := r.stmts.Stmt(ctx, query)
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
-	res, err := stmt.Exec(&w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, &w.ID)
+	res, err := stmt.ExecContext(ctx, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, &w.ID, tenant.Filter(ctx))
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	_, err = res.RowsAffected()
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	return nil
 }
 
 func (r *repository) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM warehouses WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	query := DELETE_WAREHOUSE
+	stmt, err := r.stmts.Stmt(ctx, query)
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
-	res, err := stmt.Exec(id)
+	res, err := stmt.ExecContext(ctx, id, tenant.Filter(ctx))
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	affect, err := res.RowsAffected()
 	if err != nil {
-		return err
+		return apperrors.FromDB(err)
 	}
 
 	if affect < 1 {
@@ -128,3 +159,25 @@ func (r *repository) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+// StockOnHand returns the quantity of product stored in the sections of each
+// warehouse, by warehouse id.
+func (r *repository) StockOnHand(ctx context.Context) (map[int]int, error) {
+	query := "SELECT s.warehouse_id, COALESCE(SUM(pb.current_quantity), 0) FROM sections s LEFT JOIN product_batches pb ON pb.sections_id = s.id WHERE s.site_id = COALESCE(?, s.site_id) GROUP BY s.warehouse_id;"
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, tenant.Filter(ctx))
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+
+	stock := map[int]int{}
+	for rows.Next() {
+		var warehouseID, quantity int
+		if err := rows.Scan(&warehouseID, &quantity); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		stock[warehouseID] = quantity
+	}
+
+	return stock, apperrors.FromDB(rows.Err())
+}
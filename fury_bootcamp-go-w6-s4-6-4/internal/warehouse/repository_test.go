@@ -9,6 +9,9 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -22,9 +25,9 @@ func Test_Repository_Exists(t *testing.T) {
 		rows := sqlmock.NewRows(columns)
 		rows.AddRow("1")
 
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT warehouse_code FROM warehouses WHERE warehouse_code=?;")).WithArgs("1").WillReturnRows(rows)
-		repository := NewRepository(db)
-		ctx := context.TODO()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT warehouse_code FROM warehouses WHERE warehouse_code=? AND site_id = COALESCE(?, site_id);")).WithArgs("1", "MLA").WillReturnRows(rows)
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		exists := repository.Exists(ctx, "1")
 		assert.True(t, exists)
@@ -39,9 +42,9 @@ func Test_Repository_Exists(t *testing.T) {
 		columns := []string{"warehouse_code"}
 		rows := sqlmock.NewRows(columns)
 
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT warehouse_code FROM warehouses WHERE warehouse_code=?;")).WithArgs("1").WillReturnRows(rows)
-		repository := NewRepository(db)
-		ctx := context.TODO()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT warehouse_code FROM warehouses WHERE warehouse_code=? AND site_id = COALESCE(?, site_id);")).WithArgs("1", "MLA").WillReturnRows(rows)
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		exists := repository.Exists(ctx, "1")
 		assert.False(t, exists)
@@ -57,7 +60,7 @@ func Test_Repository_Save(t *testing.T) {
 
 		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO warehouses"))
 		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO warehouses")).WillReturnResult(sqlmock.NewResult(1, 1))
-		repository := NewRepository(db)
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 		defaultNumber := 1
 		warehouse := domain.Warehouse{
 			Address:            "address",
@@ -66,7 +69,7 @@ func Test_Repository_Save(t *testing.T) {
 			MinimumCapacity:    &defaultNumber,
 			MinimumTemperature: &defaultNumber,
 		}
-		ctx := context.TODO()
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		id, err := repository.Save(ctx, warehouse)
 		assert.NoError(t, err)
@@ -80,7 +83,7 @@ func Test_Repository_Save(t *testing.T) {
 
 		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO warehouses"))
 		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO warehouses")).WillReturnError(errors.New("insert exec error"))
-		repository := NewRepository(db)
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 		defaultNumber := 1
 		warehouse := domain.Warehouse{
 			Address:            "address",
@@ -89,7 +92,7 @@ func Test_Repository_Save(t *testing.T) {
 			MinimumCapacity:    &defaultNumber,
 			MinimumTemperature: &defaultNumber,
 		}
-		ctx := context.TODO()
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		_, err = repository.Save(ctx, warehouse)
 		assert.Error(t, err)
@@ -101,7 +104,7 @@ func Test_Repository_Save(t *testing.T) {
 		assert.NoError(t, err)
 
 		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO warehouses")).WillReturnError(errors.New("prepare error"))
-		repository := NewRepository(db)
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 		defaultNumber := 1
 		warehouse := domain.Warehouse{
 			Address:            "address",
@@ -110,7 +113,7 @@ func Test_Repository_Save(t *testing.T) {
 			MinimumCapacity:    &defaultNumber,
 			MinimumTemperature: &defaultNumber,
 		}
-		ctx := context.TODO()
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		_, err = repository.Save(ctx, warehouse)
 		assert.Error(t, err)
@@ -125,17 +128,18 @@ func Test_Repository_Get(t *testing.T) {
 		db, mock, err := sqlmock.New()
 		assert.NoError(t, err)
 
-		columns := []string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature"}
+		columns := []string{"id", "site_id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature"}
 		rows := sqlmock.NewRows(columns)
-		rows.AddRow(1, "address", "telephone", "warehouseCode", 1, 1)
+		rows.AddRow(1, "MLA", "address", "telephone", "warehouseCode", 1, 1)
 
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM warehouses WHERE id=?;")).WithArgs(1).WillReturnRows(rows)
-		repository := NewRepository(db)
-		ctx := context.TODO()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, site_id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature FROM warehouses WHERE id=? AND site_id = COALESCE(?, site_id);")).WithArgs(1, "MLA").WillReturnRows(rows)
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		warehouse, err := repository.Get(ctx, 1)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, warehouse.ID)
+		assert.Equal(t, "MLA", warehouse.SiteID)
 		assert.Equal(t, "address", warehouse.Address)
 		assert.Equal(t, "telephone", warehouse.Telephone)
 		assert.Equal(t, "warehouseCode", warehouse.WarehouseCode)
@@ -148,9 +152,9 @@ func Test_Repository_Get(t *testing.T) {
 		db, mock, err := sqlmock.New()
 		assert.NoError(t, err)
 
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM warehouses WHERE id=?;")).WithArgs(1).WillReturnError(errors.New("query error"))
-		repository := NewRepository(db)
-		ctx := context.TODO()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, site_id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature FROM warehouses WHERE id=? AND site_id = COALESCE(?, site_id);")).WithArgs(1, "MLA").WillReturnError(errors.New("query error"))
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		_, err = repository.Get(ctx, 1)
 		assert.Error(t, err)
@@ -164,14 +168,14 @@ func Test_Repository_GetALL(t *testing.T) {
 		db, mock, err := sqlmock.New()
 		assert.NoError(t, err)
 
-		columns := []string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature"}
+		columns := []string{"id", "site_id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature"}
 		rows := sqlmock.NewRows(columns)
-		rows.AddRow(1, "address", "telephone", "warehouseCode", 1, 1)
-		rows.AddRow(2, "address", "telephone", "warehouseCode", 1, 1)
+		rows.AddRow(1, "MLA", "address", "telephone", "warehouseCode", 1, 1)
+		rows.AddRow(2, "MLA", "address", "telephone", "warehouseCode", 1, 1)
 
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM warehouses")).WillReturnRows(rows)
-		repository := NewRepository(db)
-		ctx := context.TODO()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, site_id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature FROM warehouses WHERE site_id = COALESCE(?, site_id)")).WithArgs("MLA").WillReturnRows(rows)
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		warehouses, err := repository.GetAll(ctx)
 		assert.NoError(t, err)
@@ -183,9 +187,9 @@ func Test_Repository_GetALL(t *testing.T) {
 		db, mock, err := sqlmock.New()
 		assert.NoError(t, err)
 
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM warehouses")).WillReturnError(errors.New("query error"))
-		repository := NewRepository(db)
-		ctx := context.TODO()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, site_id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature FROM warehouses WHERE site_id = COALESCE(?, site_id)")).WithArgs("MLA").WillReturnError(errors.New("query error"))
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		_, err = repository.GetAll(ctx)
 		assert.Error(t, err)
@@ -200,8 +204,8 @@ func Test_Repository_Update(t *testing.T) {
 		assert.NoError(t, err)
 
 		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE warehouses"))
-		mock.ExpectExec(regexp.QuoteMeta("UPDATE warehouses")).WithArgs("address", "telephone", "warehouseCode", 1, 1, 1).WillReturnResult(sqlmock.NewResult(1, 1))
-		repository := NewRepository(db)
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE warehouses")).WithArgs("address", "telephone", "warehouseCode", 1, 1, 1, "MLA").WillReturnResult(sqlmock.NewResult(1, 1))
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 		defaultNumber := 1
 		warehouse := domain.Warehouse{
 			ID:                 1,
@@ -211,7 +215,7 @@ func Test_Repository_Update(t *testing.T) {
 			MinimumCapacity:    &defaultNumber,
 			MinimumTemperature: &defaultNumber,
 		}
-		ctx := context.TODO()
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		err = repository.Update(ctx, warehouse)
 		assert.NoError(t, err)
@@ -223,8 +227,8 @@ func Test_Repository_Update(t *testing.T) {
 		assert.NoError(t, err)
 
 		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE warehouses"))
-		mock.ExpectExec(regexp.QuoteMeta("UPDATE warehouses")).WithArgs("address", "telephone", "warehouseCode", 1, 1, 1).WillReturnError(errors.New("update exec error"))
-		repository := NewRepository(db)
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE warehouses")).WithArgs("address", "telephone", "warehouseCode", 1, 1, 1, "MLA").WillReturnError(errors.New("update exec error"))
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 		defaultNumber := 1
 		warehouse := domain.Warehouse{
 			ID:                 1,
@@ -234,7 +238,7 @@ func Test_Repository_Update(t *testing.T) {
 			MinimumCapacity:    &defaultNumber,
 			MinimumTemperature: &defaultNumber,
 		}
-		ctx := context.TODO()
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		err = repository.Update(ctx, warehouse)
 		assert.Error(t, err)
@@ -246,7 +250,7 @@ func Test_Repository_Update(t *testing.T) {
 		assert.NoError(t, err)
 
 		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE warehouses")).WillReturnError(errors.New("prepare error"))
-		repository := NewRepository(db)
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
 		defaultNumber := 1
 		warehouse := domain.Warehouse{
 			ID:                 1,
@@ -256,7 +260,7 @@ func Test_Repository_Update(t *testing.T) {
 			MinimumCapacity:    &defaultNumber,
 			MinimumTemperature: &defaultNumber,
 		}
-		ctx := context.TODO()
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		err = repository.Update(ctx, warehouse)
 		assert.Error(t, err)
@@ -271,9 +275,9 @@ func Test_Repository_Delete(t *testing.T) {
 		assert.NoError(t, err)
 
 		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM warehouses"))
-		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM warehouses")).WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
-		repository := NewRepository(db)
-		ctx := context.TODO()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM warehouses")).WithArgs(1, "MLA").WillReturnResult(sqlmock.NewResult(1, 1))
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		err = repository.Delete(ctx, 1)
 		assert.NoError(t, err)
@@ -285,9 +289,9 @@ func Test_Repository_Delete(t *testing.T) {
 		assert.NoError(t, err)
 
 		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM warehouses"))
-		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM warehouses")).WithArgs(1).WillReturnError(errors.New("delete exec error"))
-		repository := NewRepository(db)
-		ctx := context.TODO()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM warehouses")).WithArgs(1, "MLA").WillReturnError(errors.New("delete exec error"))
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		err = repository.Delete(ctx, 1)
 		assert.Error(t, err)
@@ -299,8 +303,8 @@ func Test_Repository_Delete(t *testing.T) {
 		assert.NoError(t, err)
 
 		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM warehouses")).WillReturnError(errors.New("prepare error"))
-		repository := NewRepository(db)
-		ctx := context.TODO()
+		repository := NewRepository(database.NewRouter(db, nil, logger.Discard()), database.NewStatements(db), logger.Discard())
+		ctx := tenant.NewContext(context.TODO(), "MLA")
 
 		err = repository.Delete(ctx, 1)
 		assert.Error(t, err)
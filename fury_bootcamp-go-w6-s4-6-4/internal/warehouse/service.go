@@ -2,51 +2,59 @@ package warehouse
 
 import (
 	"context"
-	"errors"
+	"log/slog"
 	"reflect"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 // Errors
 var (
-	ErrNotFound = errors.New("warehouse not found")
+	ErrNotFound   = apperrors.NotFound("warehouse not found")
+	ErrCodeExists = apperrors.Conflict("warehouse code already exists")
 )
 
 type Service interface {
-	Get(id int) (domain.Warehouse, error)
-	GetAll() ([]domain.Warehouse, error)
-	Save(w domain.Warehouse) (int, error)
-	Update(w domain.Warehouse, id int) (domain.Warehouse, error)
-	Delete(id int) error
+	Get(ctx context.Context, id int) (domain.Warehouse, error)
+	GetAll(ctx context.Context) ([]domain.Warehouse, error)
+	Save(ctx context.Context, w domain.Warehouse) (int, error)
+	Update(ctx context.Context, w domain.Warehouse, id int) (domain.Warehouse, error)
+	Delete(ctx context.Context, id int) error
 }
 
 type service struct {
 	repository Repository
+	logger     *slog.Logger
 }
 
-func NewService(repository Repository) Service {
-	return &service{repository}
+func NewService(repository Repository, logger *slog.Logger) Service {
+	return &service{repository, logger}
 }
 
-func (s *service) Get(id int) (domain.Warehouse, error) {
-	return s.repository.Get(context.Background(), id)
+func (s *service) Get(ctx context.Context, id int) (domain.Warehouse, error) {
+	return s.repository.Get(ctx, id)
 }
 
-func (s *service) GetAll() ([]domain.Warehouse, error) {
-	return s.repository.GetAll(context.Background())
+func (s *service) GetAll(ctx context.Context) ([]domain.Warehouse, error) {
+	return s.repository.GetAll(ctx)
 }
 
-func (s *service) Save(w domain.Warehouse) (int, error) {
-	if s.repository.Exists(context.Background(), w.WarehouseCode) {
-		return 0, errors.New("warehouse code already exists")
+func (s *service) Save(ctx context.Context, w domain.Warehouse) (int, error) {
+	if s.repository.Exists(ctx, w.WarehouseCode) {
+		return 0, ErrCodeExists
 	}
 
-	return s.repository.Save(context.Background(), w)
+	id, err := s.repository.Save(ctx, w)
+	if err != nil {
+		return 0, err
+	}
+	s.logger.InfoContext(ctx, "warehouse created", "warehouse_id", id)
+	return id, nil
 }
 
-func (s *service) Update(w domain.Warehouse, id int) (domain.Warehouse, error) {
-	originalWarehouse, err := s.Get(id)
+func (s *service) Update(ctx context.Context, w domain.Warehouse, id int) (domain.Warehouse, error) {
+	originalWarehouse, err := s.Get(ctx, id)
 	if err != nil {
 		return domain.Warehouse{}, err
 	}
@@ -57,9 +65,9 @@ func (s *service) Update(w domain.Warehouse, id int) (domain.Warehouse, error) {
 			reflect.ValueOf(&w).Elem().Field(i).Set(value)
 		}
 	}
-	return w, s.repository.Update(context.Background(), w)
+	return w, s.repository.Update(ctx, w)
 }
 
-func (s *service) Delete(id int) error {
-	return s.repository.Delete(context.Background(), id)
+func (s *service) Delete(ctx context.Context, id int) error {
+	return s.repository.Delete(ctx, id)
 }
@@ -1,16 +1,18 @@
 package warehouse
 
 import (
+	"context"
 	"testing"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/warehouse"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestCreate(t *testing.T) {
 	repo := warehouse.NewRepositoryWarehouse([]domain.Warehouse{})
-	service := NewService(repo)
+	service := NewService(repo, logger.Discard())
 	t.Run("should create a new warehouse", func(t *testing.T) {
 		// Arrange
 		minCapacity := 10
@@ -24,7 +26,7 @@ func TestCreate(t *testing.T) {
 		}
 
 		// Act
-		id, err := service.Save(warehouse)
+		id, err := service.Save(context.Background(), warehouse)
 
 		// Assert
 		assert.Equal(t, err, nil)
@@ -43,7 +45,7 @@ func TestCreate(t *testing.T) {
 		}
 
 		// Act
-		id, err := service.Save(warehouse)
+		id, err := service.Save(context.Background(), warehouse)
 
 		// Assert
 		assert.Equal(t, "warehouse code already exists", err.Error())
@@ -73,11 +75,11 @@ func TestRead(t *testing.T) {
 	}
 
 	repo := warehouse.NewRepositoryWarehouse(warehouses)
-	service := NewService(repo)
+	service := NewService(repo, logger.Discard())
 	t.Run("should get a warehouse", func(t *testing.T) {
 
 		// Act
-		result, err := service.GetAll()
+		result, err := service.GetAll(context.Background())
 
 		// Assert
 		assert.Equal(t, err, nil)
@@ -86,7 +88,7 @@ func TestRead(t *testing.T) {
 	t.Run("should get a warehouse by id", func(t *testing.T) {
 
 		// Act
-		result, err := service.Get(1)
+		result, err := service.Get(context.Background(), 1)
 
 		// Assert
 		assert.Equal(t, err, nil)
@@ -95,7 +97,7 @@ func TestRead(t *testing.T) {
 	t.Run("should not get a warehouse by id", func(t *testing.T) {
 
 		// Act
-		result, err := service.Get(3)
+		result, err := service.Get(context.Background(), 3)
 
 		// Assert
 		assert.Equal(t, "warehouse not found", err.Error())
@@ -125,7 +127,7 @@ func TestUpdate(t *testing.T) {
 	}
 
 	repo := warehouse.NewRepositoryWarehouse(warehouses)
-	service := NewService(repo)
+	service := NewService(repo, logger.Discard())
 	t.Run("should update a warehouse", func(t *testing.T) {
 		// Arrange
 		minCapacity := 10
@@ -140,7 +142,7 @@ func TestUpdate(t *testing.T) {
 		}
 
 		// Act
-		result, err := service.Update(warehouse, 1)
+		result, err := service.Update(context.Background(), warehouse, 1)
 
 		// Assert
 		assert.Equal(t, err, nil)
@@ -164,7 +166,7 @@ func TestUpdate(t *testing.T) {
 			MinimumTemperature: &minTem,
 		}
 		// Act
-		result, err := service.Update(warehouse, 2)
+		result, err := service.Update(context.Background(), warehouse, 2)
 
 		// Assert
 		assert.Equal(t, err, nil)
@@ -184,7 +186,7 @@ func TestUpdate(t *testing.T) {
 		}
 
 		// Act
-		result, err := service.Update(warehouse, 3)
+		result, err := service.Update(context.Background(), warehouse, 3)
 
 		// Assert
 		assert.Equal(t, "warehouse not found", err.Error())
@@ -206,10 +208,10 @@ func TestDelete(t *testing.T) {
 	}
 
 	repo := warehouse.NewRepositoryWarehouse(warehouses)
-	service := NewService(repo)
+	service := NewService(repo, logger.Discard())
 	t.Run("should not delete a non-existent warehouse", func(t *testing.T) {
 		// Act
-		err := service.Delete(3)
+		err := service.Delete(context.Background(), 3)
 
 		// Assert
 		assert.Equal(t, "warehouse not found", err.Error())
@@ -219,8 +221,8 @@ func TestDelete(t *testing.T) {
 		expected := []domain.Warehouse{}
 
 		// Act
-		err := service.Delete(1)
-		result, _ := service.GetAll()
+		err := service.Delete(context.Background(), 1)
+		result, _ := service.GetAll(context.Background())
 		// Assert
 		assert.Equal(t, err, nil)
 		assert.Equal(t, expected, result)
@@ -0,0 +1,223 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/health"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+)
+
+// maxErrorLength is the room webhook_deliveries.last_error has.
+const maxErrorLength = 1024
+
+// Options tune the dispatcher.
+type Options struct {
+	// BatchSize bounds the events fanned out and the deliveries attempted
+	// per round.
+	BatchSize int
+	// MaxAttempts is how many times a delivery is tried before it's dead.
+	MaxAttempts int
+	// BackoffBase is the wait after the first failure, doubled after each
+	// further one up to BackoffMax.
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	// Timeout bounds each request to a subscriber.
+	Timeout time.Duration
+}
+
+// Dispatcher fans the outbox events out to the subscriptions and sends the
+// due deliveries. Several dispatchers can share a database: each delivery is
+// claimed before it's sent, so it's sent by one of them.
+type Dispatcher struct {
+	repo   Repository
+	opts   Options
+	client *http.Client
+	logger *slog.Logger
+	now    func() time.Time
+
+	heartbeat *health.Heartbeat
+}
+
+func NewDispatcher(repo Repository, opts Options, logger *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		repo:   repo,
+		opts:   opts,
+		client: &http.Client{Timeout: opts.Timeout},
+		logger: logger,
+		now:    time.Now,
+
+		heartbeat: health.NewHeartbeat("webhook dispatcher"),
+	}
+}
+
+// Run dispatches right away and then every interval, until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	d.heartbeat.Run(ctx, interval, d.Dispatch)
+}
+
+// Dispatch runs one round: it fans out the pending events and then attempts
+// the due deliveries, including the ones just created. It acts on the
+// events and subscriptions of every site.
+func (d *Dispatcher) Dispatch(ctx context.Context) {
+	ctx = tenant.AllSites(ctx)
+	if err := d.fanOut(ctx); err != nil {
+		d.logger.ErrorContext(ctx, "fanning out events", "error", err)
+	}
+	if err := d.deliver(ctx); err != nil {
+		d.logger.ErrorContext(ctx, "delivering events", "error", err)
+	}
+}
+
+// Healthy reports an error when Run isn't dispatching, either because it
+// never started or because a round has taken over two intervals.
+func (d *Dispatcher) Healthy(ctx context.Context) error {
+	return d.heartbeat.Check(ctx)
+}
+
+func (d *Dispatcher) fanOut(ctx context.Context) error {
+	pending, err := d.repo.PendingEvents(ctx, d.opts.BatchSize)
+	if err != nil || len(pending) == 0 {
+		return err
+	}
+	subscriptions, err := d.repo.Subscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := d.now().UTC()
+	for _, e := range pending {
+		var ids []int
+		for _, s := range subscriptions {
+			if subscribed(s, e) {
+				ids = append(ids, s.ID)
+			}
+		}
+		if err := d.repo.FanOut(ctx, e.ID, ids, now); err != nil {
+			return fmt.Errorf("event %d: %w", e.ID, err)
+		}
+	}
+	return nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context) error {
+	now := d.now().UTC()
+	due, err := d.repo.DueAttempts(ctx, now, d.opts.BatchSize)
+	if err != nil {
+		return err
+	}
+	for _, a := range due {
+		// The lease outlasts the request, so the delivery is only attempted
+		// again if this dispatcher dies before recording the outcome.
+		claimed, err := d.repo.Claim(ctx, a.Delivery.ID, now, now.Add(2*d.opts.Timeout))
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			continue
+		}
+		if err := d.attempt(ctx, a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// attempt sends a claimed delivery and records its outcome.
+func (d *Dispatcher) attempt(ctx context.Context, a domain.WebhookAttempt) error {
+	status, sendErr := d.send(ctx, a)
+
+	delivery := a.Delivery
+	delivery.Attempts++
+	if status != 0 {
+		delivery.LastStatus = &status
+	}
+	now := d.now().UTC()
+	switch {
+	case sendErr == nil:
+		delivery.Status = domain.DeliveryDelivered
+		delivery.LastError = nil
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= d.opts.MaxAttempts:
+		delivery.Status = domain.DeliveryDead
+		delivery.LastError = truncate(sendErr.Error())
+		d.logger.WarnContext(ctx, "webhook delivery dead-lettered", "delivery_id", delivery.ID, "attempts", delivery.Attempts, "error", sendErr)
+	default:
+		delivery.LastError = truncate(sendErr.Error())
+		delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+	}
+	return d.repo.UpdateDelivery(ctx, delivery)
+}
+
+// send posts the event to the subscription, returning the status it
+// answered with, if any. Anything but a 2xx fails the attempt.
+func (d *Dispatcher) send(ctx context.Context, a domain.WebhookAttempt) (int, error) {
+	body, err := json.Marshal(a.Event)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.Delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := d.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventID, strconv.FormatInt(a.Event.ID, 10))
+	req.Header.Set(HeaderEventType, a.Event.Type)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(a.Secret, timestamp, body))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("subscriber answered %s", res.Status)
+	}
+	return res.StatusCode, nil
+}
+
+// backoff is the wait after the given number of failed attempts.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.opts.BackoffBase
+	for i := 1; i < attempts && wait < d.opts.BackoffMax; i++ {
+		wait *= 2
+	}
+	if wait > d.opts.BackoffMax {
+		return d.opts.BackoffMax
+	}
+	return wait
+}
+
+func subscribed(s domain.WebhookSubscription, e domain.OutboxEvent) bool {
+	if s.SiteID != "" && s.SiteID != e.SiteID {
+		return false
+	}
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range s.EventTypes {
+		if t == e.Type {
+			return true
+		}
+	}
+	return false
+}
+
+func truncate(s string) *string {
+	if len(s) > maxErrorLength {
+		s = s[:maxErrorLength]
+	}
+	return &s
+}
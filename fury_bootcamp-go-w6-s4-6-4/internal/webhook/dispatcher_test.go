@@ -0,0 +1,197 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	webhookmock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "0123456789abcdef"
+
+// receiver is a subscriber that verifies the signature of what it receives
+// and answers with the next of its statuses, then 200 once they run out.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	received []domain.OutboxEvent
+	headers  []http.Header
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	if err := Verify(testSecret, r.Header.Get(HeaderSignature), r.Header.Get(HeaderTimestamp), body, time.Hour, time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	var e domain.OutboxEvent
+	json.Unmarshal(body, &e)
+	rc.received = append(rc.received, e)
+	rc.headers = append(rc.headers, r.Header)
+	status := http.StatusOK
+	if len(rc.statuses) > 0 {
+		status, rc.statuses = rc.statuses[0], rc.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+// clock is a time the tests move by hand.
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time          { return c.t }
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestDispatcher(repo Repository, c *clock) *Dispatcher {
+	d := NewDispatcher(repo, Options{
+		BatchSize:   10,
+		MaxAttempts: 3,
+		BackoffBase: time.Second,
+		BackoffMax:  90 * time.Second,
+		Timeout:     time.Second,
+	}, logger.Discard())
+	d.now = c.now
+	return d
+}
+
+func outboxEvent(id int64, eventType string) domain.OutboxEvent {
+	return domain.OutboxEvent{
+		ID:          id,
+		Type:        eventType,
+		AggregateID: 7,
+		OccurredAt:  time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		Data:        json.RawMessage(`{"id":7}`),
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	t.Run("should deliver signed events to the subscriptions of their type", func(t *testing.T) {
+		rc := &receiver{}
+		srv := httptest.NewServer(rc)
+		defer srv.Close()
+		repo := &webhookmock.MockRepository{
+			Subs: []domain.WebhookSubscription{
+				{ID: 1, URL: srv.URL, Secret: testSecret, EventTypes: []string{events.SellerCreated}},
+				{ID: 2, URL: srv.URL + "/all", Secret: testSecret, EventTypes: []string{}},
+			},
+			Events: []domain.OutboxEvent{outboxEvent(1, events.SellerCreated), outboxEvent(2, events.ProductBatchReceived)},
+		}
+		c := &clock{time.Now().UTC()}
+
+		newTestDispatcher(repo, c).Dispatch(context.Background())
+
+		require.Len(t, rc.received, 3)
+		assert.Equal(t, outboxEvent(1, events.SellerCreated), rc.received[0])
+		assert.Equal(t, "1", rc.headers[0].Get(HeaderEventID))
+		assert.Equal(t, events.SellerCreated, rc.headers[0].Get(HeaderEventType))
+		deliveries, _ := repo.Deliveries(context.Background(), domain.DeliveryDelivered, 10)
+		assert.Len(t, deliveries, 3)
+		assert.Equal(t, 1, deliveries[0].Attempts)
+		assert.Equal(t, http.StatusOK, *deliveries[0].LastStatus)
+		assert.True(t, repo.Dispatched[1] && repo.Dispatched[2])
+	})
+	t.Run("should retry with exponential backoff and dead-letter after the last attempt", func(t *testing.T) {
+		rc := &receiver{statuses: []int{http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusBadGateway}}
+		srv := httptest.NewServer(rc)
+		defer srv.Close()
+		repo := &webhookmock.MockRepository{
+			Subs:   []domain.WebhookSubscription{{ID: 1, URL: srv.URL, Secret: testSecret}},
+			Events: []domain.OutboxEvent{outboxEvent(1, events.SectionTemperatureBreach)},
+		}
+		c := &clock{time.Now().UTC()}
+		d := newTestDispatcher(repo, c)
+
+		d.Dispatch(context.Background())
+		delivery, _ := repo.Delivery(context.Background(), 1)
+		assert.Equal(t, domain.DeliveryPending, delivery.Status)
+		assert.Equal(t, c.t.Add(time.Second), delivery.NextAttemptAt)
+
+		// Not due yet: nothing is sent.
+		d.Dispatch(context.Background())
+		assert.Len(t, rc.received, 1)
+
+		c.advance(time.Second)
+		d.Dispatch(context.Background())
+		delivery, _ = repo.Delivery(context.Background(), 1)
+		assert.Equal(t, 2, delivery.Attempts)
+		assert.Equal(t, c.t.Add(2*time.Second), delivery.NextAttemptAt)
+
+		c.advance(2 * time.Second)
+		d.Dispatch(context.Background())
+		delivery, _ = repo.Delivery(context.Background(), 1)
+		assert.Equal(t, domain.DeliveryDead, delivery.Status)
+		assert.Equal(t, 3, delivery.Attempts)
+		assert.Equal(t, http.StatusBadGateway, *delivery.LastStatus)
+		assert.Equal(t, "subscriber answered 502 Bad Gateway", *delivery.LastError)
+
+		c.advance(time.Hour)
+		d.Dispatch(context.Background())
+		assert.Len(t, rc.received, 3)
+	})
+	t.Run("should record unreachable subscribers as failed attempts", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		srv.Close()
+		repo := &webhookmock.MockRepository{
+			Subs:   []domain.WebhookSubscription{{ID: 1, URL: srv.URL, Secret: testSecret}},
+			Events: []domain.OutboxEvent{outboxEvent(1, events.SellerCreated)},
+		}
+
+		newTestDispatcher(repo, &clock{time.Now().UTC()}).Dispatch(context.Background())
+
+		delivery, _ := repo.Delivery(context.Background(), 1)
+		assert.Equal(t, domain.DeliveryPending, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Nil(t, delivery.LastStatus)
+		assert.NotNil(t, delivery.LastError)
+	})
+	t.Run("should deliver the events of a site only to its subscriptions and those to every site", func(t *testing.T) {
+		rc := &receiver{}
+		srv := httptest.NewServer(rc)
+		defer srv.Close()
+		mla, mlb := outboxEvent(1, events.SellerCreated), outboxEvent(2, events.SellerCreated)
+		mla.SiteID, mlb.SiteID = "MLA", "MLB"
+		repo := &webhookmock.MockRepository{
+			Subs: []domain.WebhookSubscription{
+				{ID: 1, SiteID: "MLA", URL: srv.URL, Secret: testSecret},
+				{ID: 2, URL: srv.URL + "/all", Secret: testSecret},
+			},
+			Events: []domain.OutboxEvent{mla, mlb},
+		}
+
+		newTestDispatcher(repo, &clock{time.Now().UTC()}).Dispatch(context.Background())
+
+		deliveries, _ := repo.Deliveries(context.Background(), domain.DeliveryDelivered, 10)
+		require.Len(t, deliveries, 3)
+		for _, d := range deliveries {
+			assert.False(t, d.SubscriptionID == 1 && d.EventID == 2, "MLB event delivered to an MLA subscription")
+		}
+		assert.Equal(t, "MLA", rc.received[0].SiteID)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	d := newTestDispatcher(nil, &clock{})
+
+	var waits []time.Duration
+	for attempts := 1; attempts <= 9; attempts++ {
+		waits = append(waits, d.backoff(attempts))
+	}
+
+	assert.Equal(t, []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second,
+		32 * time.Second, 64 * time.Second, 90 * time.Second, 90 * time.Second,
+	}, waits)
+}
@@ -0,0 +1,350 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	database "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/db"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+)
+
+// Repository encapsulates the storage of the subscriptions and of the
+// deliveries of the outbox events to them.
+type Repository interface {
+	SaveSubscription(ctx context.Context, s domain.WebhookSubscription) (int, error)
+	Subscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int) error
+
+	// PendingEvents returns up to limit events not fanned out yet, oldest
+	// first.
+	PendingEvents(ctx context.Context, limit int) ([]domain.OutboxEvent, error)
+	// FanOut creates a pending delivery of event to each subscription and
+	// marks it dispatched, atomically. Deliveries that already exist are
+	// kept, so fanning out an event twice is harmless.
+	FanOut(ctx context.Context, eventID int64, subscriptionIDs []int, at time.Time) error
+	// DueAttempts returns up to limit pending deliveries due at now.
+	DueAttempts(ctx context.Context, now time.Time, limit int) ([]domain.WebhookAttempt, error)
+	// Claim postpones the delivery id to until if it's still due at now,
+	// reporting whether it did. Only the dispatcher that claims a delivery
+	// sends it; if it dies, the delivery is due again at until.
+	Claim(ctx context.Context, id int64, now, until time.Time) (bool, error)
+
+	Deliveries(ctx context.Context, status string, limit int) ([]domain.WebhookDelivery, error)
+	Delivery(ctx context.Context, id int64) (domain.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, d domain.WebhookDelivery) error
+}
+
+type repository struct {
+	db     *database.Router
+	stmts  *database.Statements
+	logger *slog.Logger
+}
+
+func NewRepository(db *database.Router, stmts *database.Statements, logger *slog.Logger) Repository {
+	stmts.Register(SAVE_SUBSCRIPTION, DELETE_SUBSCRIPTION, INSERT_DELIVERY, MARK_DISPATCHED, CLAIM_DELIVERY, UPDATE_DELIVERY)
+	return &repository{
+		db:     db,
+		stmts:  stmts,
+		logger: logger,
+	}
+}
+
+const deliveryColumns = "d.id, d.event_id, e.event_type, d.subscription_id, s.url, d.status, d.attempts, d.next_attempt_at, d.last_status, d.last_error, d.delivered_at"
+
+const (
+	SAVE_SUBSCRIPTION   = "INSERT INTO webhook_subscriptions (site_id, url, secret, event_types, created_at) VALUES (?, ?, ?, ?, ?)"
+	DELETE_SUBSCRIPTION = "DELETE FROM webhook_subscriptions WHERE id=? AND site_id <=> COALESCE(?, site_id)"
+	GET_SUBSCRIPTIONS   = "SELECT id, site_id, url, secret, event_types, created_at FROM webhook_subscriptions WHERE site_id <=> COALESCE(?, site_id) ORDER BY id"
+
+	PENDING_EVENTS  = "SELECT id, site_id, event_type, aggregate_id, payload, occurred_at FROM outbox_events WHERE dispatched_at IS NULL ORDER BY id LIMIT ?"
+	INSERT_DELIVERY = "INSERT IGNORE INTO webhook_deliveries (event_id, subscription_id, status, attempts, next_attempt_at) VALUES (?, ?, 'pending', 0, ?)"
+	MARK_DISPATCHED = "UPDATE outbox_events SET dispatched_at=? WHERE id=?"
+
+	DUE_ATTEMPTS = "SELECT " + deliveryColumns + ", e.site_id, e.aggregate_id, e.payload, e.occurred_at, s.secret FROM webhook_deliveries d " +
+		"JOIN outbox_events e ON e.id = d.event_id JOIN webhook_subscriptions s ON s.id = d.subscription_id " +
+		"WHERE d.status = 'pending' AND d.next_attempt_at <= ? ORDER BY d.next_attempt_at LIMIT ?"
+	CLAIM_DELIVERY = "UPDATE webhook_deliveries SET next_attempt_at=? WHERE id=? AND status='pending' AND next_attempt_at <= ?"
+
+	GET_DELIVERIES = "SELECT " + deliveryColumns + " FROM webhook_deliveries d " +
+		"JOIN outbox_events e ON e.id = d.event_id JOIN webhook_subscriptions s ON s.id = d.subscription_id " +
+		"WHERE s.site_id <=> COALESCE(?, s.site_id) AND (? = '' OR d.status = ?) ORDER BY d.id DESC LIMIT ?"
+	GET_DELIVERY = "SELECT " + deliveryColumns + " FROM webhook_deliveries d " +
+		"JOIN outbox_events e ON e.id = d.event_id JOIN webhook_subscriptions s ON s.id = d.subscription_id " +
+		"WHERE d.id=? AND s.site_id <=> COALESCE(?, s.site_id)"
+	UPDATE_DELIVERY = "UPDATE webhook_deliveries SET status=?, attempts=?, next_attempt_at=?, last_status=?, last_error=?, delivered_at=? WHERE id=?"
+)
+
+func (r *repository) SaveSubscription(ctx context.Context, s domain.WebhookSubscription) (int, error) {
+	stmt, err := r.stmts.Stmt(ctx, SAVE_SUBSCRIPTION)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	var site sql.NullString
+	if s.SiteID != "" {
+		site = sql.NullString{String: s.SiteID, Valid: true}
+	}
+	res, err := stmt.ExecContext(ctx, site, s.URL, s.Secret, strings.Join(s.EventTypes, ","), s.CreatedAt)
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, apperrors.FromDB(err)
+	}
+	return int(id), nil
+}
+
+// Subscriptions reads from the writer: the dispatcher fans out with them, and
+// a replica lagging behind would skip the events of a new subscription. A
+// site only sees its own subscriptions, not those to every site.
+func (r *repository) Subscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	rows, err := r.db.Writer().QueryContext(ctx, GET_SUBSCRIPTIONS, tenant.Filter(ctx))
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+
+	subscriptions := []domain.WebhookSubscription{}
+	for rows.Next() {
+		var s domain.WebhookSubscription
+		var site, createdAt sql.NullString
+		var eventTypes string
+		if err := rows.Scan(&s.ID, &site, &s.URL, &s.Secret, &eventTypes, &createdAt); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		s.SiteID = site.String
+		s.EventTypes = splitTypes(eventTypes)
+		if s.CreatedAt, err = parseTime(createdAt); err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	return subscriptions, nil
+}
+
+func (r *repository) DeleteSubscription(ctx context.Context, id int) error {
+	stmt, err := r.stmts.Stmt(ctx, DELETE_SUBSCRIPTION)
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	res, err := stmt.ExecContext(ctx, id, tenant.Filter(ctx))
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	if affected < 1 {
+		return ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+func (r *repository) PendingEvents(ctx context.Context, limit int) ([]domain.OutboxEvent, error) {
+	rows, err := r.db.Writer().QueryContext(ctx, PENDING_EVENTS, limit)
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+
+	var events []domain.OutboxEvent
+	for rows.Next() {
+		var e domain.OutboxEvent
+		var payload []byte
+		var occurredAt sql.NullString
+		if err := rows.Scan(&e.ID, &e.SiteID, &e.Type, &e.AggregateID, &payload, &occurredAt); err != nil {
+			return nil, apperrors.FromDB(err)
+		}
+		e.Data = payload
+		if e.OccurredAt, err = parseTime(occurredAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	return events, nil
+}
+
+func (r *repository) FanOut(ctx context.Context, eventID int64, subscriptionIDs []int, at time.Time) error {
+	err := r.db.Transact(ctx, func(ctx context.Context) error {
+		insert, err := r.stmts.Stmt(ctx, INSERT_DELIVERY)
+		if err != nil {
+			return err
+		}
+		for _, id := range subscriptionIDs {
+			if _, err := insert.ExecContext(ctx, eventID, id, at); err != nil {
+				return err
+			}
+		}
+		mark, err := r.stmts.Stmt(ctx, MARK_DISPATCHED)
+		if err != nil {
+			return err
+		}
+		_, err = mark.ExecContext(ctx, at, eventID)
+		return err
+	})
+	return apperrors.FromDB(err)
+}
+
+func (r *repository) DueAttempts(ctx context.Context, now time.Time, limit int) ([]domain.WebhookAttempt, error) {
+	rows, err := r.db.Writer().QueryContext(ctx, DUE_ATTEMPTS, now, limit)
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+
+	var attempts []domain.WebhookAttempt
+	for rows.Next() {
+		var a domain.WebhookAttempt
+		var payload []byte
+		var occurredAt sql.NullString
+		err := scanDelivery(rows, &a.Delivery, &a.Event.SiteID, &a.Event.AggregateID, &payload, &occurredAt, &a.Secret)
+		if err != nil {
+			return nil, err
+		}
+		a.Event.ID = a.Delivery.EventID
+		a.Event.Type = a.Delivery.EventType
+		a.Event.Data = payload
+		if a.Event.OccurredAt, err = parseTime(occurredAt); err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	return attempts, nil
+}
+
+func (r *repository) Claim(ctx context.Context, id int64, now, until time.Time) (bool, error) {
+	stmt, err := r.stmts.Stmt(ctx, CLAIM_DELIVERY)
+	if err != nil {
+		return false, apperrors.FromDB(err)
+	}
+	res, err := stmt.ExecContext(ctx, until, id, now)
+	if err != nil {
+		return false, apperrors.FromDB(err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, apperrors.FromDB(err)
+	}
+	return affected == 1, nil
+}
+
+func (r *repository) Deliveries(ctx context.Context, status string, limit int) ([]domain.WebhookDelivery, error) {
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, GET_DELIVERIES, tenant.Filter(ctx), status, status, limit)
+	if err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+
+	deliveries := []domain.WebhookDelivery{}
+	for rows.Next() {
+		var d domain.WebhookDelivery
+		if err := scanDelivery(rows, &d); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.FromDB(err)
+	}
+	return deliveries, nil
+}
+
+func (r *repository) Delivery(ctx context.Context, id int64) (domain.WebhookDelivery, error) {
+	rows, err := r.db.Writer().QueryContext(ctx, GET_DELIVERY, id, tenant.Filter(ctx))
+	if err != nil {
+		return domain.WebhookDelivery{}, apperrors.FromDB(err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return domain.WebhookDelivery{}, apperrors.FromDB(err)
+		}
+		return domain.WebhookDelivery{}, ErrDeliveryNotFound
+	}
+	var d domain.WebhookDelivery
+	if err := scanDelivery(rows, &d); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	return d, nil
+}
+
+func (r *repository) UpdateDelivery(ctx context.Context, d domain.WebhookDelivery) error {
+	stmt, err := r.stmts.Stmt(ctx, UPDATE_DELIVERY)
+	if err != nil {
+		return apperrors.FromDB(err)
+	}
+	_, err = stmt.ExecContext(ctx, d.Status, d.Attempts, d.NextAttemptAt, d.LastStatus, d.LastError, d.DeliveredAt, d.ID)
+	return apperrors.FromDB(err)
+}
+
+// scanDelivery scans the deliveryColumns of rows into d, followed by extra.
+func scanDelivery(rows *sql.Rows, d *domain.WebhookDelivery, extra ...interface{}) error {
+	var nextAttemptAt, deliveredAt sql.NullString
+	var lastStatus sql.NullInt64
+	var lastError sql.NullString
+	dest := append([]interface{}{
+		&d.ID, &d.EventID, &d.EventType, &d.SubscriptionID, &d.URL, &d.Status, &d.Attempts,
+		&nextAttemptAt, &lastStatus, &lastError, &deliveredAt,
+	}, extra...)
+	if err := rows.Scan(dest...); err != nil {
+		return apperrors.FromDB(err)
+	}
+
+	var err error
+	if d.NextAttemptAt, err = parseTime(nextAttemptAt); err != nil {
+		return err
+	}
+	if lastStatus.Valid {
+		status := int(lastStatus.Int64)
+		d.LastStatus = &status
+	}
+	if lastError.Valid {
+		d.LastError = &lastError.String
+	}
+	if deliveredAt.Valid {
+		t, err := parseTime(deliveredAt)
+		if err != nil {
+			return err
+		}
+		d.DeliveredAt = &t
+	}
+	return nil
+}
+
+// parseTime parses a DATETIME(3) column, which comes back as text since the
+// DSN doesn't set parseTime. A NULL is the zero time.
+func parseTime(s sql.NullString) (time.Time, error) {
+	if !s.Valid {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{time.DateTime, time.RFC3339Nano} {
+		if t, err := time.ParseInLocation(layout, s.String, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unexpected datetime %q", s.String)
+}
+
+func splitTypes(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, ",")
+}
@@ -0,0 +1,141 @@
+// Package webhook delivers the domain events recorded in the outbox to the
+// URLs subscribed to them, signing each delivery and retrying failed ones
+// with exponential backoff until they are dead-lettered.
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+)
+
+// minSecretLength keeps subscribers from signing with guessable secrets.
+const minSecretLength = 16
+
+// listLimit bounds the deliveries listed at once.
+const listLimit = 100
+
+// Errors
+var (
+	ErrSubscriptionNotFound = apperrors.NotFound("webhook subscription not found")
+	ErrDeliveryNotFound     = apperrors.NotFound("webhook delivery not found")
+	ErrInvalidURL           = apperrors.Validation("url must be an absolute http or https URL")
+	ErrShortSecret          = apperrors.Validation(fmt.Sprintf("secret must be at least %d characters", minSecretLength))
+	ErrNotDead              = apperrors.Conflict("only dead deliveries can be retried")
+)
+
+type Service interface {
+	// Subscribe subscribes rawURL to the events of the site of ctx, or of
+	// every site when an admin subscribes across sites.
+	Subscribe(ctx context.Context, rawURL, secret string, eventTypes []string) (domain.WebhookSubscription, error)
+	Subscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	Unsubscribe(ctx context.Context, id int) error
+	// Deliveries lists the latest deliveries, only those in status unless
+	// it's empty.
+	Deliveries(ctx context.Context, status string) ([]domain.WebhookDelivery, error)
+	// Retry schedules a dead delivery to be attempted again right away, with
+	// its attempts reset.
+	Retry(ctx context.Context, id int64) (domain.WebhookDelivery, error)
+}
+
+type service struct {
+	repository Repository
+	logger     *slog.Logger
+	now        func() time.Time
+}
+
+func NewService(r Repository, logger *slog.Logger) Service {
+	return &service{
+		repository: r,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+func (s *service) Subscribe(ctx context.Context, rawURL, secret string, eventTypes []string) (domain.WebhookSubscription, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return domain.WebhookSubscription{}, ErrInvalidURL
+	}
+	if len(secret) < minSecretLength {
+		return domain.WebhookSubscription{}, ErrShortSecret
+	}
+	for _, t := range eventTypes {
+		if !knownType(t) {
+			return domain.WebhookSubscription{}, apperrors.Validation(fmt.Sprintf("unknown event type %q", t))
+		}
+	}
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	sub := domain.WebhookSubscription{
+		SiteID:     tenant.Filter(ctx).String,
+		URL:        rawURL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		CreatedAt:  s.now().UTC().Truncate(time.Millisecond),
+	}
+	sub.ID, err = s.repository.SaveSubscription(ctx, sub)
+	if err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	s.logger.InfoContext(ctx, "webhook subscription created", "subscription_id", sub.ID)
+	return sub, nil
+}
+
+func (s *service) Subscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return s.repository.Subscriptions(ctx)
+}
+
+func (s *service) Unsubscribe(ctx context.Context, id int) error {
+	if err := s.repository.DeleteSubscription(ctx, id); err != nil {
+		return err
+	}
+	s.logger.InfoContext(ctx, "webhook subscription deleted", "subscription_id", id)
+	return nil
+}
+
+func (s *service) Deliveries(ctx context.Context, status string) ([]domain.WebhookDelivery, error) {
+	switch status {
+	case "", domain.DeliveryPending, domain.DeliveryDelivered, domain.DeliveryDead:
+	default:
+		return nil, apperrors.Validation(fmt.Sprintf("unknown delivery status %q", status))
+	}
+	return s.repository.Deliveries(ctx, status, listLimit)
+}
+
+func (s *service) Retry(ctx context.Context, id int64) (domain.WebhookDelivery, error) {
+	d, err := s.repository.Delivery(ctx, id)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	if d.Status != domain.DeliveryDead {
+		return domain.WebhookDelivery{}, ErrNotDead
+	}
+
+	d.Status = domain.DeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = s.now().UTC()
+	if err := s.repository.UpdateDelivery(ctx, d); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	s.logger.InfoContext(ctx, "webhook delivery retried", "delivery_id", id)
+	return d, nil
+}
+
+func knownType(t string) bool {
+	for _, known := range events.Types {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	webhookmock "github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/test/mocks/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribe(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		secret     string
+		eventTypes []string
+		wantErr    error
+	}{
+		{name: "every event", url: "https://example.com/hooks", secret: testSecret},
+		{name: "some events", url: "http://localhost:9999/", secret: testSecret, eventTypes: []string{events.SellerCreated}},
+		{name: "relative url", url: "/hooks", secret: testSecret, wantErr: ErrInvalidURL},
+		{name: "other scheme", url: "ftp://example.com", secret: testSecret, wantErr: ErrInvalidURL},
+		{name: "short secret", url: "https://example.com", secret: "shh", wantErr: ErrShortSecret},
+		{name: "unknown event type", url: "https://example.com", secret: testSecret, eventTypes: []string{"seller.deleted"}, wantErr: apperrors.ErrValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &webhookmock.MockRepository{}
+			service := NewService(repo, logger.Discard())
+
+			sub, err := service.Subscribe(context.Background(), tt.url, tt.secret, tt.eventTypes)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, repo.Subs)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 1, sub.ID)
+			assert.NotNil(t, sub.EventTypes)
+			assert.Equal(t, []domain.WebhookSubscription{sub}, repo.Subs)
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	deliveries := func() map[int64]domain.WebhookDelivery {
+		return map[int64]domain.WebhookDelivery{
+			1: {ID: 1, Status: domain.DeliveryDead, Attempts: 8},
+			2: {ID: 2, Status: domain.DeliveryDelivered, Attempts: 1},
+		}
+	}
+
+	t.Run("should reschedule a dead delivery", func(t *testing.T) {
+		repo := &webhookmock.MockRepository{DeliveriesMap: deliveries()}
+		now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+		service := &service{repository: repo, logger: logger.Discard(), now: func() time.Time { return now }}
+
+		d, err := service.Retry(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.WebhookDelivery{ID: 1, Status: domain.DeliveryPending, NextAttemptAt: now}, d)
+		assert.Equal(t, d, repo.DeliveriesMap[1])
+	})
+	t.Run("should only retry dead deliveries", func(t *testing.T) {
+		service := NewService(&webhookmock.MockRepository{DeliveriesMap: deliveries()}, logger.Discard())
+
+		_, err := service.Retry(context.Background(), 2)
+		assert.ErrorIs(t, err, ErrNotDead)
+
+		_, err = service.Retry(context.Background(), 3)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+}
+
+func TestDeliveries(t *testing.T) {
+	service := NewService(&webhookmock.MockRepository{}, logger.Discard())
+
+	_, err := service.Deliveries(context.Background(), "lost")
+
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+}
@@ -0,0 +1,39 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	const secret = "0123456789abcdef"
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"id":1}`)
+	signature := Sign(secret, now.Unix(), body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		timestamp string
+		body      []byte
+		want      error
+	}{
+		{name: "valid", secret: secret, signature: signature, timestamp: "1700000000", body: body},
+		{name: "other secret", secret: "fedcba9876543210", signature: signature, timestamp: "1700000000", body: body, want: ErrBadSignature},
+		{name: "tampered body", secret: secret, signature: signature, timestamp: "1700000000", body: []byte(`{"id":2}`), want: ErrBadSignature},
+		{name: "other timestamp", secret: secret, signature: signature, timestamp: "1700000001", body: body, want: ErrBadSignature},
+		{name: "stale timestamp", secret: secret, signature: signature, timestamp: "1699999000", body: body, want: ErrStale},
+		{name: "malformed timestamp", secret: secret, signature: signature, timestamp: "soon", body: body, want: ErrStale},
+		{name: "missing prefix", secret: secret, signature: signature[len(signaturePrefix):], timestamp: "1700000000", body: body, want: ErrBadSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.signature, tt.timestamp, tt.body, 5*time.Minute, now)
+
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}
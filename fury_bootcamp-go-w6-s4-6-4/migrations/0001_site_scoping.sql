@@ -0,0 +1,220 @@
+  -- -----------------------------------------------------
+  -- 0001: scope every row to a site
+  --
+  -- Adds `site_id` to every table and rebuilds the foreign keys on
+  -- (`site_id`, id), so a row can't reference another site's. The rows
+  -- already stored belong to @site, the `tenancy.default_site` of the
+  -- deployment they were written by; set it before running.
+  -- Webhook subscriptions keep a NULL site, which gets the events of every
+  -- site as before.
+  -- -----------------------------------------------------
+  USE `bgow6s464` ;
+
+  SET @site = 'MLA';
+
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`schema_migrations` (
+    `version` INT NOT NULL,
+    `applied_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`version`))
+  ENGINE = InnoDB;
+
+
+  -- -----------------------------------------------------
+  -- The foreign keys on the ids alone go first, as their
+  -- indexes are replaced.
+  -- -----------------------------------------------------
+  ALTER TABLE `bgow6s464`.`employees` DROP FOREIGN KEY `fk_employees_warehouses1`;
+  ALTER TABLE `bgow6s464`.`seller` DROP FOREIGN KEY `fk_sellers_locality`;
+  ALTER TABLE `bgow6s464`.`products` DROP FOREIGN KEY `fk_products_seller1`;
+  ALTER TABLE `bgow6s464`.`carries` DROP FOREIGN KEY `fk_carries_locality1`;
+  ALTER TABLE `bgow6s464`.`product_batches`
+    DROP FOREIGN KEY `fk_product_batches_sections1`,
+    DROP FOREIGN KEY `fk_product_batches_products1`;
+  ALTER TABLE `bgow6s464`.`product_records` DROP FOREIGN KEY `fk_product_records_products1`;
+  ALTER TABLE `bgow6s464`.`inbound_orders`
+    DROP FOREIGN KEY `fk_inbound_orders_employees1`,
+    DROP FOREIGN KEY `fk_inbound_orders_warehouses1`,
+    DROP FOREIGN KEY `fk_inbound_orders_product_batches1`;
+  ALTER TABLE `bgow6s464`.`purchase_orders`
+    DROP FOREIGN KEY `fk_purchase_orders_buyers1`,
+    DROP FOREIGN KEY `fk_purchase_orders_product_records1`;
+
+
+  -- -----------------------------------------------------
+  -- The site of the rows already stored.
+  -- -----------------------------------------------------
+  ALTER TABLE `bgow6s464`.`buyers` ADD COLUMN `site_id` CHAR(3) NULL AFTER `id`;
+  ALTER TABLE `bgow6s464`.`warehouses` ADD COLUMN `site_id` CHAR(3) NULL AFTER `id`;
+  ALTER TABLE `bgow6s464`.`employees` ADD COLUMN `site_id` CHAR(3) NULL AFTER `id`;
+  ALTER TABLE `bgow6s464`.`locality` ADD COLUMN `site_id` CHAR(3) NULL AFTER `id`;
+  ALTER TABLE `bgow6s464`.`seller` ADD COLUMN `site_id` CHAR(3) NULL AFTER `id`;
+  ALTER TABLE `bgow6s464`.`products` ADD COLUMN `site_id` CHAR(3) NULL AFTER `id`;
+  ALTER TABLE `bgow6s464`.`sections` ADD COLUMN `site_id` CHAR(3) NULL AFTER `id`;
+  ALTER TABLE `bgow6s464`.`carries` ADD COLUMN `site_id` CHAR(3) NULL AFTER `id`;
+  ALTER TABLE `bgow6s464`.`product_batches` ADD COLUMN `site_id` CHAR(3) NULL AFTER `id`;
+  ALTER TABLE `bgow6s464`.`product_records` ADD COLUMN `site_id` CHAR(3) NULL AFTER `id`;
+  ALTER TABLE `bgow6s464`.`inbound_orders` ADD COLUMN `site_id` CHAR(3) NULL AFTER `id`;
+  ALTER TABLE `bgow6s464`.`purchase_orders` ADD COLUMN `site_id` CHAR(3) NULL AFTER `id`;
+  ALTER TABLE `bgow6s464`.`outbox_events` ADD COLUMN `site_id` CHAR(3) NULL AFTER `id`;
+  ALTER TABLE `bgow6s464`.`webhook_subscriptions` ADD COLUMN `site_id` CHAR(3) NULL AFTER `id`;
+
+  UPDATE `bgow6s464`.`buyers` SET `site_id` = @site;
+  UPDATE `bgow6s464`.`warehouses` SET `site_id` = @site;
+  UPDATE `bgow6s464`.`employees` SET `site_id` = @site;
+  UPDATE `bgow6s464`.`locality` SET `site_id` = @site;
+  UPDATE `bgow6s464`.`seller` SET `site_id` = @site;
+  UPDATE `bgow6s464`.`products` SET `site_id` = @site;
+  UPDATE `bgow6s464`.`sections` SET `site_id` = @site;
+  UPDATE `bgow6s464`.`carries` SET `site_id` = @site;
+  UPDATE `bgow6s464`.`product_batches` SET `site_id` = @site;
+  UPDATE `bgow6s464`.`product_records` SET `site_id` = @site;
+  UPDATE `bgow6s464`.`inbound_orders` SET `site_id` = @site;
+  UPDATE `bgow6s464`.`purchase_orders` SET `site_id` = @site;
+  UPDATE `bgow6s464`.`outbox_events` SET `site_id` = @site;
+
+
+  -- -----------------------------------------------------
+  -- The keys on (`site_id`, id), the referenced tables first.
+  -- -----------------------------------------------------
+  ALTER TABLE `bgow6s464`.`buyers`
+    MODIFY `site_id` CHAR(3) NOT NULL,
+    ADD UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE;
+
+  ALTER TABLE `bgow6s464`.`warehouses`
+    MODIFY `site_id` CHAR(3) NOT NULL,
+    ADD UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE;
+
+  ALTER TABLE `bgow6s464`.`employees`
+    MODIFY `site_id` CHAR(3) NOT NULL,
+    ADD UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    DROP INDEX `fk_employees_warehouses1_idx`,
+    ADD INDEX `fk_employees_warehouses1_idx` (`site_id` ASC, `warehouse_id` ASC) VISIBLE,
+    ADD CONSTRAINT `fk_employees_warehouses1`
+      FOREIGN KEY (`site_id`, `warehouse_id`)
+      REFERENCES `bgow6s464`.`warehouses` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE;
+
+  ALTER TABLE `bgow6s464`.`locality`
+    MODIFY `site_id` CHAR(3) NOT NULL,
+    ADD UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE;
+
+  ALTER TABLE `bgow6s464`.`seller`
+    MODIFY `site_id` CHAR(3) NOT NULL,
+    ADD UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    DROP INDEX `fk_sellers_locality_idx`,
+    ADD INDEX `fk_sellers_locality_idx` (`site_id` ASC, `locality_id` ASC) VISIBLE,
+    ADD CONSTRAINT `fk_sellers_locality`
+      FOREIGN KEY (`site_id`, `locality_id`)
+      REFERENCES `bgow6s464`.`locality` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE;
+
+  ALTER TABLE `bgow6s464`.`products`
+    MODIFY `site_id` CHAR(3) NOT NULL,
+    ADD UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    DROP INDEX `fk_products_sellers1_idx`,
+    ADD INDEX `fk_products_sellers1_idx` (`site_id` ASC, `seller_id` ASC) VISIBLE,
+    ADD CONSTRAINT `fk_products_seller1`
+      FOREIGN KEY (`site_id`, `seller_id`)
+      REFERENCES `bgow6s464`.`seller` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE;
+
+  ALTER TABLE `bgow6s464`.`sections`
+    MODIFY `site_id` CHAR(3) NOT NULL,
+    ADD UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    ADD INDEX `fk_sections_warehouses1_idx` (`site_id` ASC, `warehouse_id` ASC) VISIBLE,
+    ADD CONSTRAINT `fk_sections_warehouses1`
+      FOREIGN KEY (`site_id`, `warehouse_id`)
+      REFERENCES `bgow6s464`.`warehouses` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE;
+
+  ALTER TABLE `bgow6s464`.`carries`
+    MODIFY `site_id` CHAR(3) NOT NULL,
+    ADD UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    DROP INDEX `fk_carries_locality1_idx`,
+    ADD INDEX `fk_carries_locality1_idx` (`site_id` ASC, `locality_id` ASC) VISIBLE,
+    ADD CONSTRAINT `fk_carries_locality1`
+      FOREIGN KEY (`site_id`, `locality_id`)
+      REFERENCES `bgow6s464`.`locality` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE;
+
+  ALTER TABLE `bgow6s464`.`product_batches`
+    MODIFY `site_id` CHAR(3) NOT NULL,
+    ADD UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    DROP INDEX `fk_product_batches_sections1_idx`,
+    DROP INDEX `fk_product_batches_products1_idx`,
+    ADD INDEX `fk_product_batches_sections1_idx` (`site_id` ASC, `sections_id` ASC) VISIBLE,
+    ADD INDEX `fk_product_batches_products1_idx` (`site_id` ASC, `products_id` ASC) VISIBLE,
+    ADD CONSTRAINT `fk_product_batches_sections1`
+      FOREIGN KEY (`site_id`, `sections_id`)
+      REFERENCES `bgow6s464`.`sections` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE,
+    ADD CONSTRAINT `fk_product_batches_products1`
+      FOREIGN KEY (`site_id`, `products_id`)
+      REFERENCES `bgow6s464`.`products` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE;
+
+  ALTER TABLE `bgow6s464`.`product_records`
+    MODIFY `site_id` CHAR(3) NOT NULL,
+    ADD UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    DROP INDEX `fk_product_records_products1_idx`,
+    ADD INDEX `fk_product_records_products1_idx` (`site_id` ASC, `products_id` ASC) VISIBLE,
+    ADD CONSTRAINT `fk_product_records_products1`
+      FOREIGN KEY (`site_id`, `products_id`)
+      REFERENCES `bgow6s464`.`products` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE;
+
+  ALTER TABLE `bgow6s464`.`inbound_orders`
+    MODIFY `site_id` CHAR(3) NOT NULL,
+    ADD UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    DROP INDEX `fk_inbound_orders_employees1_idx`,
+    DROP INDEX `fk_inbound_orders_warehouses1_idx`,
+    DROP INDEX `fk_inbound_orders_product_batches1_idx`,
+    ADD INDEX `fk_inbound_orders_employees1_idx` (`site_id` ASC, `employee_id` ASC) VISIBLE,
+    ADD INDEX `fk_inbound_orders_warehouses1_idx` (`site_id` ASC, `warehouse_id` ASC) VISIBLE,
+    ADD INDEX `fk_inbound_orders_product_batches1_idx` (`site_id` ASC, `product_batch_id` ASC) VISIBLE,
+    ADD CONSTRAINT `fk_inbound_orders_employees1`
+      FOREIGN KEY (`site_id`, `employee_id`)
+      REFERENCES `bgow6s464`.`employees` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE,
+    ADD CONSTRAINT `fk_inbound_orders_warehouses1`
+      FOREIGN KEY (`site_id`, `warehouse_id`)
+      REFERENCES `bgow6s464`.`warehouses` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE,
+    ADD CONSTRAINT `fk_inbound_orders_product_batches1`
+      FOREIGN KEY (`site_id`, `product_batch_id`)
+      REFERENCES `bgow6s464`.`product_batches` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE;
+
+  ALTER TABLE `bgow6s464`.`purchase_orders`
+    MODIFY `site_id` CHAR(3) NOT NULL,
+    ADD UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    DROP INDEX `fk_purchase_orders_buyers1_idx`,
+    DROP INDEX `fk_purchase_orders_product_records1_idx`,
+    ADD INDEX `fk_purchase_orders_buyers1_idx` (`site_id` ASC, `buyers_id` ASC) VISIBLE,
+    ADD INDEX `fk_purchase_orders_product_records1_idx` (`site_id` ASC, `product_records_id` ASC) VISIBLE,
+    ADD CONSTRAINT `fk_purchase_orders_buyers1`
+      FOREIGN KEY (`site_id`, `buyers_id`)
+      REFERENCES `bgow6s464`.`buyers` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE,
+    ADD CONSTRAINT `fk_purchase_orders_product_records1`
+      FOREIGN KEY (`site_id`, `product_records_id`)
+      REFERENCES `bgow6s464`.`product_records` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE;
+
+  ALTER TABLE `bgow6s464`.`outbox_events` MODIFY `site_id` CHAR(3) NOT NULL;
+
+
+  INSERT INTO `bgow6s464`.`schema_migrations` (`version`) VALUES (1);
@@ -0,0 +1,82 @@
+  -- -----------------------------------------------------
+  -- 0002: countries and provinces of the localities
+  --
+  -- Moves the `country_name` and `province_name` of each locality to
+  -- a country and a province of its site, and references the province
+  -- by `province_id`. A locality without them gets one named
+  -- 'Unknown', to be fixed through the API.
+  -- -----------------------------------------------------
+  USE `bgow6s464` ;
+
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`countries` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
+    `country_name` VARCHAR(45) NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    UNIQUE INDEX `site_id_country_name_UNIQUE` (`site_id` ASC, `country_name` ASC) VISIBLE)
+  ENGINE = InnoDB
+  DEFAULT CHARACTER SET = utf8mb4
+  COLLATE = utf8mb4_0900_ai_ci;
+
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`provinces` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
+    `province_name` VARCHAR(45) NOT NULL,
+    `country_id` INT NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    UNIQUE INDEX `country_id_province_name_UNIQUE` (`site_id` ASC, `country_id` ASC, `province_name` ASC) VISIBLE,
+    CONSTRAINT `fk_provinces_countries1`
+      FOREIGN KEY (`site_id`, `country_id`)
+      REFERENCES `bgow6s464`.`countries` (`site_id`, `id`)
+      ON DELETE RESTRICT
+      ON UPDATE CASCADE)
+  ENGINE = InnoDB
+  DEFAULT CHARACTER SET = utf8mb4
+  COLLATE = utf8mb4_0900_ai_ci;
+
+
+  INSERT INTO `bgow6s464`.`countries` (`site_id`, `country_name`)
+    SELECT DISTINCT `site_id`, COALESCE(`country_name`, 'Unknown')
+    FROM `bgow6s464`.`locality`;
+
+  INSERT INTO `bgow6s464`.`provinces` (`site_id`, `province_name`, `country_id`)
+    SELECT DISTINCT l.`site_id`, COALESCE(l.`province_name`, 'Unknown'), c.`id`
+    FROM `bgow6s464`.`locality` l
+    JOIN `bgow6s464`.`countries` c
+      ON c.`site_id` = l.`site_id` AND c.`country_name` = COALESCE(l.`country_name`, 'Unknown');
+
+  ALTER TABLE `bgow6s464`.`locality` ADD COLUMN `province_id` INT NULL AFTER `locality_name`;
+
+  UPDATE `bgow6s464`.`locality` l
+    JOIN `bgow6s464`.`countries` c
+      ON c.`site_id` = l.`site_id` AND c.`country_name` = COALESCE(l.`country_name`, 'Unknown')
+    JOIN `bgow6s464`.`provinces` p
+      ON p.`site_id` = l.`site_id` AND p.`country_id` = c.`id` AND p.`province_name` = COALESCE(l.`province_name`, 'Unknown')
+    SET l.`province_id` = p.`id`;
+
+
+  -- -----------------------------------------------------
+  -- Localities get their ids generated when they're created
+  -- without one. The sellers and carries reference the id,
+  -- which keeps its values.
+  -- -----------------------------------------------------
+  SET @OLD_FOREIGN_KEY_CHECKS=@@FOREIGN_KEY_CHECKS, FOREIGN_KEY_CHECKS=0;
+
+  ALTER TABLE `bgow6s464`.`locality`
+    MODIFY `id` INT NOT NULL AUTO_INCREMENT,
+    MODIFY `province_id` INT NOT NULL,
+    DROP COLUMN `province_name`,
+    DROP COLUMN `country_name`,
+    ADD INDEX `fk_locality_provinces1_idx` (`site_id` ASC, `province_id` ASC) VISIBLE,
+    ADD CONSTRAINT `fk_locality_provinces1`
+      FOREIGN KEY (`site_id`, `province_id`)
+      REFERENCES `bgow6s464`.`provinces` (`site_id`, `id`)
+      ON DELETE RESTRICT
+      ON UPDATE CASCADE;
+
+  SET FOREIGN_KEY_CHECKS=@OLD_FOREIGN_KEY_CHECKS;
+
+
+  INSERT INTO `bgow6s464`.`schema_migrations` (`version`) VALUES (2);
@@ -0,0 +1,95 @@
+  -- -----------------------------------------------------
+  -- 0003: inbound orders received in receipts against their lines
+  --
+  -- The orders already stored name the batch they brought and
+  -- have no lines, so they're closed.
+  -- -----------------------------------------------------
+  USE `bgow6s464` ;
+
+  ALTER TABLE `bgow6s464`.`inbound_orders`
+    MODIFY `product_batch_id` INT NULL,
+    ADD COLUMN `status` VARCHAR(20) NOT NULL DEFAULT 'open' AFTER `product_batch_id`;
+
+  UPDATE `bgow6s464`.`inbound_orders` SET `status` = 'closed';
+
+
+  -- -----------------------------------------------------
+  -- Table `bgow6s464`.`inbound_order_lines`
+  -- -----------------------------------------------------
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`inbound_order_lines` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
+    `inbound_order_id` INT NOT NULL,
+    `products_id` INT NOT NULL,
+    `batch_number` VARCHAR(45) NULL,
+    `expected_quantity` INT NOT NULL,
+    `received_quantity` INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    INDEX `fk_inbound_order_lines_inbound_orders1_idx` (`site_id` ASC, `inbound_order_id` ASC) VISIBLE,
+    INDEX `fk_inbound_order_lines_products1_idx` (`site_id` ASC, `products_id` ASC) VISIBLE,
+    CONSTRAINT `fk_inbound_order_lines_inbound_orders1`
+      FOREIGN KEY (`site_id`, `inbound_order_id`)
+      REFERENCES `bgow6s464`.`inbound_orders` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE,
+    CONSTRAINT `fk_inbound_order_lines_products1`
+      FOREIGN KEY (`site_id`, `products_id`)
+      REFERENCES `bgow6s464`.`products` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE)
+  ENGINE = InnoDB;
+
+
+  -- -----------------------------------------------------
+  -- Table `bgow6s464`.`inbound_order_receipts`
+  -- -----------------------------------------------------
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`inbound_order_receipts` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
+    `inbound_order_id` INT NOT NULL,
+    `received_at` DATETIME NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `site_id_id_UNIQUE` (`site_id` ASC, `id` ASC) VISIBLE,
+    INDEX `fk_inbound_order_receipts_inbound_orders1_idx` (`site_id` ASC, `inbound_order_id` ASC) VISIBLE,
+    CONSTRAINT `fk_inbound_order_receipts_inbound_orders1`
+      FOREIGN KEY (`site_id`, `inbound_order_id`)
+      REFERENCES `bgow6s464`.`inbound_orders` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE)
+  ENGINE = InnoDB;
+
+
+  -- -----------------------------------------------------
+  -- Table `bgow6s464`.`inbound_order_receipt_lines`
+  -- -----------------------------------------------------
+  CREATE TABLE IF NOT EXISTS `bgow6s464`.`inbound_order_receipt_lines` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `site_id` CHAR(3) NOT NULL,
+    `receipt_id` INT NOT NULL,
+    `line_id` INT NOT NULL,
+    `product_batch_id` INT NOT NULL,
+    `quantity` INT NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `fk_inbound_order_receipt_lines_receipts1_idx` (`site_id` ASC, `receipt_id` ASC) VISIBLE,
+    INDEX `fk_inbound_order_receipt_lines_lines1_idx` (`site_id` ASC, `line_id` ASC) VISIBLE,
+    INDEX `fk_inbound_order_receipt_lines_product_batches1_idx` (`site_id` ASC, `product_batch_id` ASC) VISIBLE,
+    CONSTRAINT `fk_inbound_order_receipt_lines_receipts1`
+      FOREIGN KEY (`site_id`, `receipt_id`)
+      REFERENCES `bgow6s464`.`inbound_order_receipts` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE,
+    CONSTRAINT `fk_inbound_order_receipt_lines_lines1`
+      FOREIGN KEY (`site_id`, `line_id`)
+      REFERENCES `bgow6s464`.`inbound_order_lines` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE,
+    CONSTRAINT `fk_inbound_order_receipt_lines_product_batches1`
+      FOREIGN KEY (`site_id`, `product_batch_id`)
+      REFERENCES `bgow6s464`.`product_batches` (`site_id`, `id`)
+      ON DELETE CASCADE
+      ON UPDATE CASCADE)
+  ENGINE = InnoDB;
+
+
+  INSERT INTO `bgow6s464`.`schema_migrations` (`version`) VALUES (3);
@@ -0,0 +1,7 @@
+// Package api holds the request and response bodies of the HTTP API,
+// generated from the schemas of docs/openapi.yaml. Their binding tags check
+// what the schemas allow, so handlers binding them validate bodies the same
+// way with the request_validation feature off.
+package api
+
+//go:generate go run ../../cmd/openapigen -o api.gen.go ../../docs/openapi.yaml
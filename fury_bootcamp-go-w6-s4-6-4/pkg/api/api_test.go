@@ -0,0 +1,19 @@
+package api
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/docs"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedFromSpec(t *testing.T) {
+	want, err := openapi.Generate(docs.OpenAPI, "api")
+	require.NoError(t, err)
+	got, err := os.ReadFile("api.gen.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got), "api.gen.go is out of date, run go generate ./pkg/api")
+}
@@ -0,0 +1,42 @@
+package apperrors
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQL server error numbers with a domain meaning.
+const (
+	mysqlDuplicateEntry  = 1062
+	mysqlRowIsReferenced = 1451
+	mysqlNoReferencedRow = 1452
+)
+
+// FromDB classifies an error returned by database/sql. Errors without a
+// domain meaning are returned unchanged.
+func FromDB(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var mysqlErr *mysql.MySQLError
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return Wrap(ErrNotFound, err, "resource not found")
+	case errors.As(err, &mysqlErr):
+		switch mysqlErr.Number {
+		case mysqlDuplicateEntry:
+			return Wrap(ErrConflict, err, "resource already exists")
+		case mysqlRowIsReferenced:
+			return Wrap(ErrReferenced, err, "resource is still referenced by other resources")
+		case mysqlNoReferencedRow:
+			return Wrap(ErrForeignKeyViolation, err, "referenced resource does not exist")
+		}
+	case errors.Is(err, driver.ErrBadConn), errors.Is(err, mysql.ErrInvalidConn), errors.Is(err, sql.ErrConnDone):
+		return Wrap(ErrUnavailable, err, "database unavailable")
+	}
+	return err
+}
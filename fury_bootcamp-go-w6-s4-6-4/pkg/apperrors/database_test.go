@@ -0,0 +1,55 @@
+package apperrors
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromDB(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		kind error
+	}{
+		{"no rows", sql.ErrNoRows, ErrNotFound},
+		{"duplicate entry", &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1' for key 'PRIMARY'"}, ErrConflict},
+		{"row is referenced", &mysql.MySQLError{Number: 1451}, ErrReferenced},
+		{"no referenced row", &mysql.MySQLError{Number: 1452}, ErrForeignKeyViolation},
+		{"bad connection", driver.ErrBadConn, ErrUnavailable},
+		{"wrapped invalid connection", fmt.Errorf("query: %w", mysql.ErrInvalidConn), ErrUnavailable},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := FromDB(c.err)
+			assert.ErrorIs(t, err, c.kind)
+			assert.ErrorIs(t, err, c.err)
+			assert.Equal(t, c.kind, KindOf(err))
+		})
+	}
+
+	t.Run("unclassified", func(t *testing.T) {
+		errUnknown := errors.New("error in Exec")
+		assert.Equal(t, errUnknown, FromDB(errUnknown))
+		assert.Nil(t, KindOf(errUnknown))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		assert.NoError(t, FromDB(nil))
+	})
+}
+
+func TestErrorIs(t *testing.T) {
+	errSectionNotFound := NotFound("section not found")
+
+	assert.ErrorIs(t, errSectionNotFound, ErrNotFound)
+	assert.NotErrorIs(t, errSectionNotFound, ErrConflict)
+	assert.ErrorIs(t, fmt.Errorf("get: %w", errSectionNotFound), errSectionNotFound)
+	assert.EqualError(t, errSectionNotFound, "section not found")
+}
@@ -0,0 +1,98 @@
+// Package apperrors defines the error taxonomy shared by repositories,
+// services and handlers. Every domain error carries one of the kinds below,
+// so callers classify errors with errors.Is instead of comparing messages.
+package apperrors
+
+import (
+	"errors"
+)
+
+// Kinds of domain error.
+var (
+	ErrNotFound            = errors.New("not found")
+	ErrConflict            = errors.New("conflict")
+	ErrValidation          = errors.New("validation failed")
+	ErrForeignKeyViolation = errors.New("foreign key violation")
+	ErrReferenced          = errors.New("still referenced")
+	ErrUnavailable         = errors.New("service unavailable")
+	ErrForbidden           = errors.New("forbidden")
+	ErrUnauthorized        = errors.New("unauthorized")
+)
+
+// Error is a domain error of a given kind. Message is what clients see and
+// Err, when set, is the underlying cause.
+type Error struct {
+	Kind    error
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.Kind.Error()
+}
+
+// Is reports whether target is the kind of e.
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func NotFound(message string) *Error {
+	return &Error{Kind: ErrNotFound, Message: message}
+}
+
+func Conflict(message string) *Error {
+	return &Error{Kind: ErrConflict, Message: message}
+}
+
+func Validation(message string) *Error {
+	return &Error{Kind: ErrValidation, Message: message}
+}
+
+func ForeignKeyViolation(message string) *Error {
+	return &Error{Kind: ErrForeignKeyViolation, Message: message}
+}
+
+func Referenced(message string) *Error {
+	return &Error{Kind: ErrReferenced, Message: message}
+}
+
+func Unavailable(message string) *Error {
+	return &Error{Kind: ErrUnavailable, Message: message}
+}
+
+func Forbidden(message string) *Error {
+	return &Error{Kind: ErrForbidden, Message: message}
+}
+
+func Unauthorized(message string) *Error {
+	return &Error{Kind: ErrUnauthorized, Message: message}
+}
+
+// Wrap returns err classified as kind. The message of err is kept unless
+// message is not empty.
+func Wrap(kind error, err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Message: message, Err: err}
+}
+
+// KindOf returns the kind of err, or nil when err is not a domain error.
+func KindOf(err error) error {
+	for _, kind := range []error{ErrNotFound, ErrConflict, ErrValidation, ErrForeignKeyViolation, ErrReferenced, ErrUnavailable, ErrForbidden, ErrUnauthorized} {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Package cache keeps the results of expensive reads, such as the reports,
+// until the data they were computed from changes.
+//
+// Every entry depends on tags, usually the tables it was read from. Writers
+// invalidate the tags they touch, which bumps their versions: entries are
+// looked up by the versions of their tags, so the ones computed before the
+// write are never served again and are left for the store to evict.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+)
+
+// Store keeps the encoded entries and the versions of the tags. Entries may
+// be evicted at any time, but a version must outlive the entries looked up
+// with it, or they would be served again once it restarts.
+type Store interface {
+	// Get returns the entry under key, reporting whether there was one.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set keeps value under key for ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Versions returns the version of each tag, 0 for one never bumped.
+	Versions(ctx context.Context, tags ...string) ([]int64, error)
+	// Bump increments the version of each tag.
+	Bump(ctx context.Context, tags ...string) error
+}
+
+// Cache keeps the entries in a store for a ttl, which bounds how stale
+// they get when a write doesn't go through Invalidate, such as one made
+// by another instance with a store of its own.
+type Cache struct {
+	store  Store
+	ttl    time.Duration
+	logger *slog.Logger
+}
+
+func New(store Store, ttl time.Duration, logger *slog.Logger) *Cache {
+	return &Cache{
+		store:  store,
+		ttl:    ttl,
+		logger: logger,
+	}
+}
+
+// Load returns the value cached under key for the current versions of tags,
+// or calls load and caches what it returns. Errors aren't cached. A store
+// that fails is logged and treated as empty, so the value is loaded.
+//
+// Entries are kept apart by the site of ctx, since the same report reads
+// different rows on each. The tags are shared: a write on any site makes
+// the entries of every site stale.
+func Load[T any](ctx context.Context, c *Cache, key string, tags []string, load func(context.Context) (T, error)) (T, error) {
+	versions, err := c.store.Versions(ctx, tags...)
+	if err != nil {
+		c.logger.WarnContext(ctx, "reading cache tag versions", "key", key, "error", err)
+		return load(ctx)
+	}
+	key = versionedKey(tenant.FromContext(ctx)+":"+key, tags, versions)
+
+	var v T
+	if b, ok, err := c.store.Get(ctx, key); err != nil {
+		c.logger.WarnContext(ctx, "reading cache entry", "key", key, "error", err)
+	} else if ok {
+		if err := json.Unmarshal(b, &v); err == nil {
+			return v, nil
+		}
+		c.logger.WarnContext(ctx, "decoding cache entry", "key", key, "error", err)
+	}
+
+	v, err = load(ctx)
+	if err != nil {
+		return v, err
+	}
+	b, err := json.Marshal(v)
+	if err == nil {
+		err = c.store.Set(ctx, key, b, c.ttl)
+	}
+	if err != nil {
+		c.logger.WarnContext(ctx, "writing cache entry", "key", key, "error", err)
+	}
+	return v, nil
+}
+
+// Invalidate makes stale the entries that depend on any of tags. Call it
+// once the write is committed: an entry loaded in between would otherwise
+// be cached under the new versions with the old data.
+func (c *Cache) Invalidate(ctx context.Context, tags ...string) {
+	if err := c.store.Bump(ctx, tags...); err != nil {
+		c.logger.ErrorContext(ctx, "invalidating cache entries", "tags", tags, "error", err)
+	}
+}
+
+func versionedKey(key string, tags []string, versions []int64) string {
+	var b strings.Builder
+	b.WriteString(key)
+	for i, tag := range tags {
+		b.WriteString("|" + tag + "@" + strconv.FormatInt(versions[i], 10))
+	}
+	return b.String()
+}
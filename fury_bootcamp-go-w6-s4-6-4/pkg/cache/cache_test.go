@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingStore is a Store that's down.
+type failingStore struct{}
+
+func (failingStore) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, errors.New("store unavailable")
+}
+func (failingStore) Set(context.Context, string, []byte, time.Duration) error {
+	return errors.New("store unavailable")
+}
+func (failingStore) Versions(context.Context, ...string) ([]int64, error) {
+	return nil, errors.New("store unavailable")
+}
+func (failingStore) Bump(context.Context, ...string) error {
+	return errors.New("store unavailable")
+}
+
+func TestLoad(t *testing.T) {
+	ctx := context.Background()
+	tags := []string{"employees", "inbound_orders"}
+
+	counter := func(loads *int) func(context.Context) ([]string, error) {
+		return func(context.Context) ([]string, error) {
+			*loads++
+			return []string{"report", string(rune('0' + *loads))}, nil
+		}
+	}
+
+	t.Run("should serve the cached value until a tag is invalidated", func(t *testing.T) {
+		c := New(NewMemory(10), time.Minute, logger.Discard())
+		loads := 0
+
+		first, _ := Load(ctx, c, "report", tags, counter(&loads))
+		cached, _ := Load(ctx, c, "report", tags, counter(&loads))
+		c.Invalidate(ctx, "inbound_orders")
+		reloaded, _ := Load(ctx, c, "report", tags, counter(&loads))
+
+		assert.Equal(t, 2, loads)
+		assert.Equal(t, first, cached)
+		assert.Equal(t, []string{"report", "2"}, reloaded)
+	})
+	t.Run("should keep the entries of the other tags", func(t *testing.T) {
+		c := New(NewMemory(10), time.Minute, logger.Discard())
+		loads := 0
+
+		Load(ctx, c, "report", tags, counter(&loads))
+		c.Invalidate(ctx, "sellers")
+		Load(ctx, c, "report", tags, counter(&loads))
+
+		assert.Equal(t, 1, loads)
+	})
+	t.Run("should keep the entries of each site apart", func(t *testing.T) {
+		c := New(NewMemory(10), time.Minute, logger.Discard())
+		loads := 0
+
+		mla, _ := Load(tenant.NewContext(ctx, "MLA"), c, "report", tags, counter(&loads))
+		mlb, _ := Load(tenant.NewContext(ctx, "MLB"), c, "report", tags, counter(&loads))
+
+		assert.Equal(t, 2, loads)
+		assert.NotEqual(t, mla, mlb)
+	})
+	t.Run("should not cache errors", func(t *testing.T) {
+		c := New(NewMemory(10), time.Minute, logger.Discard())
+		loads := 0
+		failing := func(context.Context) ([]string, error) {
+			loads++
+			return nil, errors.New("database unavailable")
+		}
+
+		Load(ctx, c, "report", tags, failing)
+		_, err := Load(ctx, c, "report", tags, failing)
+
+		assert.Error(t, err)
+		assert.Equal(t, 2, loads)
+	})
+	t.Run("should load when the store fails", func(t *testing.T) {
+		c := New(failingStore{}, time.Minute, logger.Discard())
+		loads := 0
+
+		v, err := Load(ctx, c, "report", tags, counter(&loads))
+		c.Invalidate(ctx, "inbound_orders")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"report", "1"}, v)
+	})
+}
+
+func TestMemory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should evict the least recently used entry", func(t *testing.T) {
+		m := NewMemory(2)
+		m.Set(ctx, "a", []byte("a"), time.Minute)
+		m.Set(ctx, "b", []byte("b"), time.Minute)
+		m.Get(ctx, "a")
+
+		m.Set(ctx, "c", []byte("c"), time.Minute)
+
+		_, hasA, _ := m.Get(ctx, "a")
+		_, hasB, _ := m.Get(ctx, "b")
+		assert.True(t, hasA)
+		assert.False(t, hasB)
+	})
+	t.Run("should expire the entries after their ttl", func(t *testing.T) {
+		m := NewMemory(2)
+		now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+		m.now = func() time.Time { return now }
+		m.Set(ctx, "a", []byte("a"), time.Minute)
+
+		now = now.Add(time.Minute)
+		_, ok, _ := m.Get(ctx, "a")
+
+		assert.False(t, ok)
+		assert.Empty(t, m.entries)
+	})
+	t.Run("should keep the versions when entries are evicted", func(t *testing.T) {
+		m := NewMemory(1)
+		m.Bump(ctx, "sellers", "sellers")
+		m.Set(ctx, "a", []byte("a"), time.Minute)
+		m.Set(ctx, "b", []byte("b"), time.Minute)
+
+		versions, _ := m.Versions(ctx, "sellers", "localities")
+
+		assert.Equal(t, []int64{2, 0}, versions)
+	})
+}
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory is a Store in memory that evicts the least recently used entry
+// once it holds its capacity. Each instance of the API has its own.
+type Memory struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	// lru has the most recently used entry at the front.
+	lru      *list.List
+	versions map[string]int64
+	now      func() time.Time
+}
+
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func NewMemory(capacity int) *Memory {
+	return &Memory{
+		capacity: capacity,
+		entries:  map[string]*list.Element{},
+		lru:      list.New(),
+		versions: map[string]int64{},
+		now:      time.Now,
+	}
+}
+
+func (m *Memory) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	e := el.Value.(*memoryEntry)
+	if !m.now().Before(e.expiresAt) {
+		m.remove(el)
+		return nil, false, nil
+	}
+	m.lru.MoveToFront(el)
+	return e.value, true, nil
+}
+
+func (m *Memory) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expiresAt := m.now().Add(ttl)
+	if el, ok := m.entries[key]; ok {
+		e := el.Value.(*memoryEntry)
+		e.value, e.expiresAt = value, expiresAt
+		m.lru.MoveToFront(el)
+		return nil
+	}
+	m.entries[key] = m.lru.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+	for m.lru.Len() > m.capacity {
+		m.remove(m.lru.Back())
+	}
+	return nil
+}
+
+// Versions are kept apart from the entries, so evicting never resets them.
+func (m *Memory) Versions(ctx context.Context, tags ...string) ([]int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	versions := make([]int64, len(tags))
+	for i, tag := range tags {
+		versions[i] = m.versions[tag]
+	}
+	return versions, nil
+}
+
+func (m *Memory) Bump(ctx context.Context, tags ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, tag := range tags {
+		m.versions[tag]++
+	}
+	return nil
+}
+
+func (m *Memory) remove(el *list.Element) {
+	m.lru.Remove(el)
+	delete(m.entries, el.Value.(*memoryEntry).key)
+}
@@ -0,0 +1,241 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"log/slog"
+	"time"
+)
+
+// OpenLogged opens a database on drv that logs every statement it runs at
+// debug level, with its latency and the number of rows read or affected.
+// The attributes carried by the context of the call are added to each record.
+func OpenLogged(drv driver.Driver, dsn string, logger *slog.Logger) (*sql.DB, error) {
+	var c driver.Connector = dsnConnector{dsn: dsn, driver: drv}
+	if dc, ok := drv.(driver.DriverContext); ok {
+		var err error
+		if c, err = dc.OpenConnector(dsn); err != nil {
+			return nil, err
+		}
+	}
+	return sql.OpenDB(&loggedConnector{Connector: c, logger: logger}), nil
+}
+
+// dsnConnector is the connector of drivers that don't implement
+// driver.DriverContext, as sql.Open does.
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+type loggedConnector struct {
+	driver.Connector
+	logger *slog.Logger
+}
+
+func (c *loggedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &loggedConn{Conn: conn, logger: c.logger}, nil
+}
+
+func logStatement(ctx context.Context, logger *slog.Logger, query string, start time.Time, rows int64, err error) {
+	attrs := []slog.Attr{
+		slog.String("query", query),
+		slog.Duration("latency", time.Since(start)),
+		slog.Int64("rows", rows),
+	}
+	if err != nil && err != io.EOF {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	logger.LogAttrs(ctx, slog.LevelDebug, "sql statement", attrs...)
+}
+
+// loggedConn forwards to the optional interfaces of the wrapped connection
+// so database/sql uses it as it would use the driver's own.
+type loggedConn struct {
+	driver.Conn
+	logger *slog.Logger
+}
+
+func (c *loggedConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *loggedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if pc, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = pc.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &loggedStmt{Stmt: stmt, query: query, logger: c.logger}, nil
+}
+
+func (c *loggedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := q.QueryContext(ctx, query, args)
+	if err != nil {
+		if err != driver.ErrSkip {
+			logStatement(ctx, c.logger, query, start, 0, err)
+		}
+		return nil, err
+	}
+	return &loggedRows{Rows: rows, ctx: ctx, query: query, start: start, logger: c.logger}, nil
+}
+
+func (c *loggedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	res, err := e.ExecContext(ctx, query, args)
+	if err != driver.ErrSkip {
+		logStatement(ctx, c.logger, query, start, rowsAffected(res), err)
+	}
+	return res, err
+}
+
+func (c *loggedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *loggedConn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *loggedConn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *loggedConn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+func (c *loggedConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if n, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return n.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+type loggedStmt struct {
+	driver.Stmt
+	query  string
+	logger *slog.Logger
+}
+
+func (s *loggedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	var res driver.Result
+	var err error
+	if e, ok := s.Stmt.(driver.StmtExecContext); ok {
+		res, err = e.ExecContext(ctx, args)
+	} else {
+		res, err = s.Stmt.Exec(values(args))
+	}
+	logStatement(ctx, s.logger, s.query, start, rowsAffected(res), err)
+	return res, err
+}
+
+func (s *loggedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	var rows driver.Rows
+	var err error
+	if q, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = q.QueryContext(ctx, args)
+	} else {
+		rows, err = s.Stmt.Query(values(args))
+	}
+	if err != nil {
+		logStatement(ctx, s.logger, s.query, start, 0, err)
+		return nil, err
+	}
+	return &loggedRows{Rows: rows, ctx: ctx, query: s.query, start: start, logger: s.logger}, nil
+}
+
+func (s *loggedStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if n, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return n.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// loggedRows logs its statement once closed, when the number of rows read is
+// known.
+type loggedRows struct {
+	driver.Rows
+	ctx    context.Context
+	query  string
+	start  time.Time
+	logger *slog.Logger
+	count  int64
+	err    error
+}
+
+func (r *loggedRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	if err == nil {
+		r.count++
+	} else {
+		r.err = err
+	}
+	return err
+}
+
+func (r *loggedRows) Close() error {
+	logStatement(r.ctx, r.logger, r.query, r.start, r.count, r.err)
+	return r.Rows.Close()
+}
+
+func rowsAffected(res driver.Result) int64 {
+	if res == nil {
+		return 0
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+func values(args []driver.NamedValue) []driver.Value {
+	vs := make([]driver.Value, len(args))
+	for i, arg := range args {
+		vs[i] = arg.Value
+	}
+	return vs
+}
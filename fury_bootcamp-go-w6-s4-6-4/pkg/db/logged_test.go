@@ -0,0 +1,81 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/requestid"
+	"github.com/stretchr/testify/assert"
+)
+
+func records(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var recs []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		rec := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &rec))
+		recs = append(recs, rec)
+	}
+	return recs
+}
+
+func TestOpenLogged(t *testing.T) {
+	mockDB, mock, err := sqlmock.NewWithDSN("logged_test")
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	var buf bytes.Buffer
+	db, err := OpenLogged(mockDB.Driver(), "logged_test", logger.New(&buf, slog.LevelDebug))
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM sections")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM sections WHERE id=?")).
+		ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ctx := requestid.NewContext(context.Background(), "req-1")
+
+	rows, err := db.QueryContext(ctx, "SELECT id FROM sections")
+	assert.NoError(t, err)
+	for rows.Next() {
+	}
+	assert.NoError(t, rows.Close())
+
+	stmt, err := db.PrepareContext(ctx, "DELETE FROM sections WHERE id=?")
+	assert.NoError(t, err)
+	_, err = stmt.ExecContext(ctx, 1)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	recs := records(t, &buf)
+	assert.Len(t, recs, 2)
+	assert.Equal(t, "DEBUG", recs[0]["level"])
+	assert.Equal(t, "SELECT id FROM sections", recs[0]["query"])
+	assert.Equal(t, float64(2), recs[0]["rows"])
+	assert.Equal(t, "req-1", recs[0]["request_id"])
+	assert.Contains(t, recs[0], "latency")
+	assert.Equal(t, "DELETE FROM sections WHERE id=?", recs[1]["query"])
+	assert.Equal(t, float64(1), recs[1]["rows"])
+}
+
+func TestOpenLoggedAboveDebug(t *testing.T) {
+	mockDB, mock, err := sqlmock.NewWithDSN("logged_test_info")
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	var buf bytes.Buffer
+	db, err := OpenLogged(mockDB.Driver(), "logged_test_info", logger.New(&buf, slog.LevelInfo))
+	assert.NoError(t, err)
+
+	mock.ExpectExec("DELETE FROM sections").WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err = db.Exec("DELETE FROM sections")
+
+	assert.NoError(t, err)
+	assert.Empty(t, buf.String())
+}
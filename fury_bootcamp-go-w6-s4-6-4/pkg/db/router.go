@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// Router sends writes to the writer and spreads reads that tolerate
+// replication lag across the readers. Readers start unhealthy and only take
+// reads once a check has reached them; while none is healthy, reads go to
+// the writer.
+type Router struct {
+	writer  *sql.DB
+	readers []*reader
+	next    atomic.Uint64
+	logger  *slog.Logger
+}
+
+type reader struct {
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+func NewRouter(writer *sql.DB, readers []*sql.DB, logger *slog.Logger) *Router {
+	r := &Router{writer: writer, logger: logger}
+	for _, db := range readers {
+		r.readers = append(r.readers, &reader{db: db})
+	}
+	return r
+}
+
+// Writer returns the database that takes writes, and the reads that must see
+// them.
+func (r *Router) Writer() *sql.DB {
+	return r.writer
+}
+
+// Reader returns a healthy reader, or the writer when there is none or when
+// ctx already wrote, so a request reads its own writes.
+func (r *Router) Reader(ctx context.Context) *sql.DB {
+	if wrote(ctx) || len(r.readers) == 0 {
+		return r.writer
+	}
+	start := r.next.Add(1)
+	for i := range r.readers {
+		rd := r.readers[(start+uint64(i))%uint64(len(r.readers))]
+		if rd.healthy.Load() {
+			return rd.db
+		}
+	}
+	return r.writer
+}
+
+// Readers returns every reader, healthy or not.
+func (r *Router) Readers() []*sql.DB {
+	dbs := make([]*sql.DB, len(r.readers))
+	for i, rd := range r.readers {
+		dbs[i] = rd.db
+	}
+	return dbs
+}
+
+// CheckReaders pings every reader, taking the ones that fail out of
+// rotation and putting back the ones that recovered.
+func (r *Router) CheckReaders(ctx context.Context, timeout time.Duration) error {
+	var errs []error
+	for i, rd := range r.readers {
+		pingCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := rd.db.PingContext(pingCtx)
+		cancel()
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("reader %d: %w", i, err))
+			if rd.healthy.Swap(false) {
+				r.logger.WarnContext(ctx, "reader out of rotation", "reader", i, "error", err)
+			}
+			continue
+		}
+		if !rd.healthy.Swap(true) {
+			r.logger.InfoContext(ctx, "reader in rotation", "reader", i)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run checks the readers right away and then every interval, until ctx is
+// done.
+func (r *Router) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		r.CheckReaders(ctx, interval)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+type sessionKey struct{}
+
+// WithSession returns a copy of ctx that records whether a write was made
+// with it. Reads made afterwards with the same ctx go to the writer.
+func WithSession(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sessionKey{}, new(atomic.Bool))
+}
+
+func markWritten(ctx context.Context) {
+	if s, ok := ctx.Value(sessionKey{}).(*atomic.Bool); ok {
+		s.Store(true)
+	}
+}
+
+func wrote(ctx context.Context) bool {
+	s, ok := ctx.Value(sessionKey{}).(*atomic.Bool)
+	return ok && s.Load()
+}
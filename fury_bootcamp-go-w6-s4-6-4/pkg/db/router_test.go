@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db, mock
+}
+
+func TestRouter(t *testing.T) {
+	writer, _ := newMockDB(t)
+	first, firstMock := newMockDB(t)
+	second, secondMock := newMockDB(t)
+	router := NewRouter(writer, []*sql.DB{first, second}, logger.Discard())
+	ctx := context.Background()
+
+	t.Run("reads from the writer until a reader is checked", func(t *testing.T) {
+		assert.Same(t, writer, router.Reader(ctx))
+	})
+
+	firstMock.ExpectPing()
+	secondMock.ExpectPing()
+	assert.NoError(t, router.CheckReaders(ctx, time.Second))
+
+	t.Run("spreads reads across the healthy readers", func(t *testing.T) {
+		seen := map[*sql.DB]bool{}
+		for i := 0; i < 4; i++ {
+			seen[router.Reader(ctx)] = true
+		}
+		assert.Equal(t, map[*sql.DB]bool{first: true, second: true}, seen)
+	})
+	t.Run("takes a failing reader out of rotation", func(t *testing.T) {
+		firstMock.ExpectPing().WillReturnError(errors.New("connection refused"))
+		secondMock.ExpectPing()
+
+		assert.EqualError(t, router.CheckReaders(ctx, time.Second), "reader 0: connection refused")
+		for i := 0; i < 4; i++ {
+			assert.Same(t, second, router.Reader(ctx))
+		}
+	})
+	t.Run("falls back to the writer when every reader fails", func(t *testing.T) {
+		firstMock.ExpectPing().WillReturnError(errors.New("connection refused"))
+		secondMock.ExpectPing().WillReturnError(errors.New("connection refused"))
+
+		assert.Error(t, router.CheckReaders(ctx, time.Second))
+		assert.Same(t, writer, router.Reader(ctx))
+	})
+	t.Run("reads from the writer after a write in the session", func(t *testing.T) {
+		firstMock.ExpectPing()
+		secondMock.ExpectPing()
+		assert.NoError(t, router.CheckReaders(ctx, time.Second))
+
+		session := WithSession(ctx)
+		assert.NotSame(t, writer, router.Reader(session))
+		markWritten(session)
+		assert.Same(t, writer, router.Reader(session))
+		assert.NotSame(t, writer, router.Reader(ctx))
+	})
+
+	assert.NoError(t, firstMock.ExpectationsWereMet())
+	assert.NoError(t, secondMock.ExpectationsWereMet())
+}
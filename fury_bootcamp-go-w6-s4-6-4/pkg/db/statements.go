@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Statements prepares each query once and shares the prepared statement
+// between requests. Repositories register their queries when they are built
+// and PrepareAll prepares them at boot, so a query the schema can't satisfy
+// fails the start instead of the first request using it.
+//
+// The statements are prepared on the writer and meant for writes: using one
+// makes later reads in the same session go to the writer too.
+type Statements struct {
+	db    *sql.DB
+	mu    sync.Mutex
+	stmts map[string]*sql.Stmt
+	order []string
+}
+
+func NewStatements(db *sql.DB) *Statements {
+	return &Statements{db: db, stmts: map[string]*sql.Stmt{}}
+}
+
+// Register adds queries to be prepared by PrepareAll.
+func (s *Statements) Register(queries ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, q := range queries {
+		if _, ok := s.stmts[q]; !ok {
+			s.stmts[q] = nil
+			s.order = append(s.order, q)
+		}
+	}
+}
+
+// PrepareAll prepares the registered queries that aren't prepared yet and
+// reports every one that failed.
+func (s *Statements) PrepareAll(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	for _, q := range s.order {
+		if s.stmts[q] != nil {
+			continue
+		}
+		stmt, err := s.db.PrepareContext(ctx, q)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("preparing %q: %w", q, err))
+			continue
+		}
+		s.stmts[q] = stmt
+	}
+	return errors.Join(errs...)
+}
+
+// Stmt returns the prepared statement for query, preparing it first when
+// PrepareAll didn't. Inside Router.Transact the statement runs in the
+// transaction and is closed when it ends.
+func (s *Statements) Stmt(ctx context.Context, query string) (*sql.Stmt, error) {
+	markWritten(ctx)
+
+	stmt, err := s.prepared(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if tx, ok := txFrom(ctx); ok {
+		return tx.StmtContext(ctx, stmt), nil
+	}
+	return stmt, nil
+}
+
+func (s *Statements) prepared(ctx context.Context, query string) (*sql.Stmt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stmt := s.stmts[query]; stmt != nil {
+		return stmt, nil
+	}
+	stmt, err := s.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.stmts[query]; !ok {
+		s.order = append(s.order, query)
+	}
+	s.stmts[query] = stmt
+	return stmt, nil
+}
+
+// Close closes every prepared statement.
+func (s *Statements) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	for q, stmt := range s.stmts {
+		if stmt != nil {
+			errs = append(errs, stmt.Close())
+			s.stmts[q] = nil
+		}
+	}
+	return errors.Join(errs...)
+}
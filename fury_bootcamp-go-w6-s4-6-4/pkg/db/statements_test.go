@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatements(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	const insert = "INSERT INTO sections (section_number) VALUES (?)"
+	const remove = "DELETE FROM sections WHERE id=?"
+	stmts := NewStatements(db)
+	stmts.Register(insert, remove, insert)
+
+	mock.ExpectPrepare(regexp.QuoteMeta(insert))
+	mock.ExpectPrepare(regexp.QuoteMeta(remove)).WillReturnError(errors.New("Table 'sections' doesn't exist"))
+	err = stmts.PrepareAll(context.Background())
+	assert.EqualError(t, err, `preparing "DELETE FROM sections WHERE id=?": Table 'sections' doesn't exist`)
+
+	t.Run("reuses the statement prepared at boot", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(insert)).WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(insert)).WithArgs(2).WillReturnResult(sqlmock.NewResult(2, 1))
+
+		for i := 1; i <= 2; i++ {
+			stmt, err := stmts.Stmt(context.Background(), insert)
+			assert.NoError(t, err)
+			_, err = stmt.Exec(i)
+			assert.NoError(t, err)
+		}
+	})
+	t.Run("prepares on first use what failed at boot", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(remove))
+
+		_, err := stmts.Stmt(context.Background(), remove)
+		assert.NoError(t, err)
+		assert.NoError(t, stmts.PrepareAll(context.Background()))
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
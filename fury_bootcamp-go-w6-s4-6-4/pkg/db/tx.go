@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+type txKey struct{}
+
+// Transact runs fn in a transaction on the writer, committing it when fn
+// succeeds and rolling it back otherwise. The statements taken with
+// Statements.Stmt and the queries made with Exec using the context fn gets
+// run in the transaction. Nested calls join the outer transaction.
+func (r *Router) Transact(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := txFrom(ctx); ok {
+		return fn(ctx)
+	}
+
+	markWritten(ctx)
+	tx, err := r.writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+			return errors.Join(err, fmt.Errorf("rolling back: %w", rbErr))
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// Exec runs query on the transaction of ctx, or on the writer outside of
+// one. It's meant for writes whose number of arguments varies, which can't
+// be prepared once.
+func (r *Router) Exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx, ok := txFrom(ctx); ok {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	markWritten(ctx)
+	return r.writer.ExecContext(ctx, query, args...)
+}
+
+// QueryRow runs query on the transaction of ctx, or on Reader outside of
+// one, so a transaction reads the rows it wrote before committing them.
+func (r *Router) QueryRow(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx, ok := txFrom(ctx); ok {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return r.Reader(ctx).QueryRowContext(ctx, query, args...)
+}
+
+// Query is QueryRow for queries returning any number of rows.
+func (r *Router) Query(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx, ok := txFrom(ctx); ok {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return r.Reader(ctx).QueryContext(ctx, query, args...)
+}
+
+func txFrom(ctx context.Context) (*sql.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	return tx, ok
+}
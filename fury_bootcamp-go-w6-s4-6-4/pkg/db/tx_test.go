@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransact(t *testing.T) {
+	const insert = "INSERT INTO seller (cid) VALUES (?)"
+	const outbox = "INSERT INTO outbox_events (event_type) VALUES (?)"
+	db, mock := newMockDB(t)
+	router := NewRouter(db, nil, logger.Discard())
+	stmts := NewStatements(db)
+	stmts.Register(insert)
+	mock.ExpectPrepare(regexp.QuoteMeta(insert))
+	assert.NoError(t, stmts.PrepareAll(context.Background()))
+
+	write := func(ctx context.Context) error {
+		stmt, err := stmts.Stmt(ctx, insert)
+		if err != nil {
+			return err
+		}
+		if _, err := stmt.ExecContext(ctx, 1); err != nil {
+			return err
+		}
+		_, err = router.Exec(ctx, outbox, "seller.created")
+		return err
+	}
+
+	t.Run("commits the writes of fn together", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(insert)).WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(outbox)).WithArgs("seller.created").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		session := WithSession(context.Background())
+		assert.NoError(t, router.Transact(session, write))
+		assert.True(t, wrote(session))
+	})
+	t.Run("rolls back the writes when fn fails", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(insert)).WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(outbox)).WillReturnError(errors.New("Table 'outbox_events' doesn't exist"))
+		mock.ExpectRollback()
+
+		err := router.Transact(context.Background(), write)
+
+		assert.EqualError(t, err, "Table 'outbox_events' doesn't exist")
+	})
+	t.Run("joins the transaction it runs in", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(insert)).WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(outbox)).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := router.Transact(context.Background(), func(ctx context.Context) error {
+			return router.Transact(ctx, write)
+		})
+
+		assert.NoError(t, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryRow(t *testing.T) {
+	const query = "SELECT status FROM inbound_orders WHERE id=?"
+	writer, writerMock := newMockDB(t)
+	reader, readerMock := newMockDB(t)
+	router := NewRouter(writer, []*sql.DB{reader}, logger.Discard())
+	readerMock.ExpectPing()
+	assert.NoError(t, router.CheckReaders(context.Background(), time.Second))
+
+	t.Run("reads a reader outside of a transaction", func(t *testing.T) {
+		readerMock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("closed"))
+
+		var status string
+		assert.NoError(t, router.QueryRow(context.Background(), query, 1).Scan(&status))
+		assert.Equal(t, "closed", status)
+	})
+	t.Run("reads the writes of the transaction it runs in", func(t *testing.T) {
+		writerMock.ExpectBegin()
+		writerMock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("open"))
+		writerMock.ExpectCommit()
+
+		var status string
+		err := router.Transact(context.Background(), func(ctx context.Context) error {
+			return router.QueryRow(ctx, query, 2).Scan(&status)
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "open", status)
+	})
+
+	assert.NoError(t, writerMock.ExpectationsWereMet())
+	assert.NoError(t, readerMock.ExpectationsWereMet())
+}
@@ -0,0 +1,175 @@
+// Package health runs the checks that decide whether the service is ready to
+// take traffic.
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Check reports an error when the dependency it covers is not usable.
+type Check func(ctx context.Context) error
+
+// Result is the outcome of a single check.
+type Result struct {
+	Status  string `json:"status"`
+	Latency string `json:"latency"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Report is the outcome of every registered check. Status is down when any
+// check failed or the service is draining.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Registry holds the readiness checks. Each check runs with its own timeout.
+type Registry struct {
+	mu       sync.RWMutex
+	checks   []namedCheck
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{timeout: timeout}
+}
+
+func (r *Registry) Register(name string, check Check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, namedCheck{name: name, check: check})
+}
+
+// Drain makes every following report down, so load balancers stop routing
+// to the instance while in-flight requests finish.
+func (r *Registry) Drain() {
+	r.draining.Store(true)
+}
+
+// Run runs the checks concurrently and collects their results.
+func (r *Registry) Run(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := make([]namedCheck, len(r.checks))
+	copy(checks, r.checks)
+	r.mu.RUnlock()
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c namedCheck) {
+			defer wg.Done()
+			results[i] = r.run(ctx, c.check)
+		}(i, c)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Checks: make(map[string]Result, len(checks))}
+	if r.draining.Load() {
+		report.Status = StatusDown
+	}
+	for i, c := range checks {
+		report.Checks[c.name] = results[i]
+		if results[i].Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+func (r *Registry) run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	res := Result{Status: StatusUp, Latency: time.Since(start).String()}
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+	}
+	return res
+}
+
+// Ping checks that the database answers.
+func Ping(db *sql.DB) Check {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// SchemaVersion checks that the schema has every migration up to version
+// applied, as recorded in schema_migrations by db.sql and by each script of
+// migrations/. A database created before a migration has the tables it
+// alters, so only the version tells it's pending.
+func SchemaVersion(db *sql.DB, version int) Check {
+	return func(ctx context.Context) error {
+		var applied sql.NullInt64
+		if err := db.QueryRowContext(ctx, "SELECT MAX(version) FROM schema_migrations").Scan(&applied); err != nil {
+			return err
+		}
+		if applied.Int64 < int64(version) {
+			return fmt.Errorf("schema at version %d, migrations up to %d pending", applied.Int64, version)
+		}
+		return nil
+	}
+}
+
+// Tables checks that every table exists in the current schema, which is how
+// a table db.sql creates when missing shows up.
+func Tables(db *sql.DB, tables ...string) Check {
+	query := "SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name IN (?" +
+		strings.Repeat(", ?", len(tables)-1) + ")"
+	args := make([]interface{}, len(tables))
+	for i, t := range tables {
+		args[i] = t
+	}
+
+	return func(ctx context.Context) error {
+		rows, err := db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		found := map[string]bool{}
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				return err
+			}
+			found[strings.ToLower(name)] = true
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		var missing []string
+		for _, t := range tables {
+			if !found[strings.ToLower(t)] {
+				missing = append(missing, t)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("missing tables: %s", strings.Join(missing, ", "))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,92 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	r := NewRegistry(50 * time.Millisecond)
+	r.Register("ok", func(ctx context.Context) error { return nil })
+
+	report := r.Run(context.Background())
+	assert.Equal(t, StatusUp, report.Status)
+	assert.Equal(t, StatusUp, report.Checks["ok"].Status)
+
+	t.Run("a failing check takes the report down", func(t *testing.T) {
+		r.Register("broken", func(ctx context.Context) error { return errors.New("connection refused") })
+
+		report := r.Run(context.Background())
+		assert.Equal(t, StatusDown, report.Status)
+		assert.Equal(t, StatusUp, report.Checks["ok"].Status)
+		assert.Equal(t, Result{Status: StatusDown, Latency: report.Checks["broken"].Latency, Error: "connection refused"}, report.Checks["broken"])
+	})
+
+	t.Run("a slow check times out", func(t *testing.T) {
+		r := NewRegistry(10 * time.Millisecond)
+		r.Register("slow", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		report := r.Run(context.Background())
+		assert.Equal(t, StatusDown, report.Status)
+		assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["slow"].Error)
+	})
+
+	t.Run("a draining registry is down", func(t *testing.T) {
+		r := NewRegistry(time.Second)
+		r.Register("ok", func(ctx context.Context) error { return nil })
+		r.Drain()
+
+		assert.Equal(t, StatusDown, r.Run(context.Background()).Status)
+	})
+}
+
+func TestSchemaVersion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	query := regexp.QuoteMeta("SELECT MAX(version) FROM schema_migrations")
+	check := SchemaVersion(db, 3)
+
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+	assert.NoError(t, check(context.Background()))
+
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	assert.EqualError(t, check(context.Background()), "schema at version 1, migrations up to 3 pending")
+
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(nil))
+	assert.EqualError(t, check(context.Background()), "schema at version 0, migrations up to 3 pending")
+
+	mock.ExpectQuery(query).WillReturnError(errors.New("Table 'bgow6s464.schema_migrations' doesn't exist"))
+	assert.Error(t, check(context.Background()))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTables(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	query := regexp.QuoteMeta("SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name IN (?, ?)")
+	check := Tables(db, "buyers", "sections")
+
+	mock.ExpectQuery(query).WithArgs("buyers", "sections").
+		WillReturnRows(sqlmock.NewRows([]string{"table_name"}).AddRow("buyers").AddRow("sections"))
+	assert.NoError(t, check(context.Background()))
+
+	mock.ExpectQuery(query).WithArgs("buyers", "sections").
+		WillReturnRows(sqlmock.NewRows([]string{"table_name"}).AddRow("buyers"))
+	assert.EqualError(t, check(context.Background()), "missing tables: sections")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,54 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Heartbeat runs a background loop and checks that it keeps running.
+type Heartbeat struct {
+	name string
+
+	// interval and lastRound are set by Run and read by Check.
+	interval  atomic.Int64
+	lastRound atomic.Int64
+}
+
+// NewHeartbeat returns the heartbeat of the loop called name in the errors
+// of Check.
+func NewHeartbeat(name string) *Heartbeat {
+	return &Heartbeat{name: name}
+}
+
+// Run calls round right away and then every interval, until ctx is done.
+func (h *Heartbeat) Run(ctx context.Context, interval time.Duration, round func(ctx context.Context)) {
+	h.interval.Store(int64(interval))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		round(ctx)
+		h.lastRound.Store(time.Now().UnixNano())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check reports an error when Run isn't running rounds, either because it
+// never started or because a round has taken over two intervals.
+func (h *Heartbeat) Check(ctx context.Context) error {
+	last := h.lastRound.Load()
+	if last == 0 {
+		return errors.New(h.name + " not running")
+	}
+	if since := time.Since(time.Unix(0, last)); since > 2*time.Duration(h.interval.Load()) {
+		return fmt.Errorf("%s stalled, last round %s ago", h.name, since.Round(time.Second))
+	}
+	return nil
+}
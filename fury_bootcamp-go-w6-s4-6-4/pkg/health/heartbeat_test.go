@@ -0,0 +1,24 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeartbeat(t *testing.T) {
+	h := NewHeartbeat("refresher")
+	assert.EqualError(t, h.Check(context.Background()), "refresher not running")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rounds := 0
+	h.Run(ctx, time.Minute, func(context.Context) { rounds++ })
+	assert.Equal(t, 1, rounds)
+	assert.NoError(t, h.Check(context.Background()))
+
+	h.lastRound.Store(time.Now().Add(-3 * time.Minute).UnixNano())
+	assert.EqualError(t, h.Check(context.Background()), "refresher stalled, last round 3m0s ago")
+}
@@ -0,0 +1,63 @@
+// Package interceptor holds the gRPC interceptors shared by every service,
+// the counterparts of the gin middlewares in package web.
+package interceptor
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorCodes maps each error kind to its gRPC status code.
+var errorCodes = []struct {
+	kind error
+	code codes.Code
+}{
+	{apperrors.ErrNotFound, codes.NotFound},
+	{apperrors.ErrConflict, codes.AlreadyExists},
+	{apperrors.ErrValidation, codes.InvalidArgument},
+	{apperrors.ErrForeignKeyViolation, codes.FailedPrecondition},
+	{apperrors.ErrReferenced, codes.FailedPrecondition},
+	{apperrors.ErrUnavailable, codes.Unavailable},
+	{apperrors.ErrForbidden, codes.PermissionDenied},
+	{apperrors.ErrUnauthorized, codes.Unauthenticated},
+	{context.DeadlineExceeded, codes.DeadlineExceeded},
+	{context.Canceled, codes.Canceled},
+}
+
+// Status returns err as a status error with the code of its kind. Errors
+// that already are status errors are returned unchanged and errors without
+// a kind are internal errors.
+func Status(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	for _, e := range errorCodes {
+		if errors.Is(err, e.kind) {
+			return status.Error(e.code, err.Error())
+		}
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// UnaryErrors converts the errors returned by handlers with Status.
+func UnaryErrors() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		return resp, Status(err)
+	}
+}
+
+// StreamErrors converts the errors returned by stream handlers with Status.
+func StreamErrors() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return Status(handler(srv, ss))
+	}
+}
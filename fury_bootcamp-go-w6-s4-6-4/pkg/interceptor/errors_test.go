@@ -0,0 +1,42 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+		msg  string
+	}{
+		{"not found", apperrors.NotFound("seller not found"), codes.NotFound, "seller not found"},
+		{"conflict", apperrors.Conflict("cid already exists"), codes.AlreadyExists, "cid already exists"},
+		{"validation", apperrors.Validation("missing required fields: cid"), codes.InvalidArgument, "missing required fields: cid"},
+		{"unauthorized", apperrors.Unauthorized("the supervisor approving the cycle count must be identified"), codes.Unauthenticated, "the supervisor approving the cycle count must be identified"},
+		{"wrapped kind", fmt.Errorf("saving: %w", apperrors.NotFound("locality not found")), codes.NotFound, "saving: locality not found"},
+		{"deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), codes.DeadlineExceeded, "query: context deadline exceeded"},
+		{"status error", status.Error(codes.PermissionDenied, "denied"), codes.PermissionDenied, "denied"},
+		{"no kind", errors.New("boom"), codes.Internal, "boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Status(tt.err)
+
+			assert.Equal(t, tt.code, status.Code(err))
+			assert.Equal(t, tt.msg, status.Convert(err).Message())
+		})
+	}
+	t.Run("nil", func(t *testing.T) {
+		assert.NoError(t, Status(nil))
+	})
+}
@@ -0,0 +1,65 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// userKey is the metadata key the gateway sets with the id of the caller,
+// the X-User-ID header of HTTP requests.
+const userKey = "x-user-id"
+
+// UnaryLogger attaches the method and the caller of the call to its
+// context, so every record logged while serving it carries them, and logs
+// the call once served.
+func UnaryLogger(l *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		ctx = withCallAttrs(ctx, info.FullMethod)
+		resp, err := handler(ctx, req)
+		logCall(ctx, l, start, err)
+		return resp, err
+	}
+}
+
+// StreamLogger is UnaryLogger for streams, logged once the stream ends.
+func StreamLogger(l *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := withCallAttrs(ss.Context(), info.FullMethod)
+		err := handler(srv, withContext(ss, ctx))
+		logCall(ctx, l, start, err)
+		return err
+	}
+}
+
+func withCallAttrs(ctx context.Context, method string) context.Context {
+	attrs := []slog.Attr{slog.String("grpc_method", method)}
+	if user := firstValue(ctx, userKey); user != "" {
+		attrs = append(attrs, slog.String("user", user))
+	}
+	return logger.WithAttrs(ctx, attrs...)
+}
+
+func logCall(ctx context.Context, l *slog.Logger, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.Internal, codes.Unknown, codes.Unavailable, codes.DataLoss, codes.Unimplemented:
+		level = slog.LevelError
+	}
+	record := []slog.Attr{
+		slog.String("grpc_code", code.String()),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if err != nil {
+		record = append(record, slog.String("error", err.Error()))
+	}
+	l.LogAttrs(ctx, level, "call served", record...)
+}
@@ -0,0 +1,48 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/requestid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// requestIDKey is the metadata key of the request id. gRPC metadata keys
+// are lowercase.
+var requestIDKey = strings.ToLower(requestid.Header)
+
+// UnaryRequestID reuses the request id sent by the client in the metadata,
+// or generates one, puts it into the context and sends it back as a header.
+func UnaryRequestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(withRequestID(ctx), req)
+	}
+}
+
+// StreamRequestID is UnaryRequestID for streams.
+func StreamRequestID() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, withContext(ss, withRequestID(ss.Context())))
+	}
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	id := firstValue(ctx, requestIDKey)
+	if !requestid.Valid(id) {
+		id = requestid.New()
+	}
+	// Only fails when the headers were already sent, which can't happen
+	// before the handler runs.
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id))
+	return requestid.NewContext(ctx, id)
+}
+
+// firstValue returns the first value of key in the incoming metadata.
+func firstValue(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
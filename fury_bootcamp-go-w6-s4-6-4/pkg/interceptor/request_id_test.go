@@ -0,0 +1,39 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/requestid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestUnaryRequestID(t *testing.T) {
+	served := func(ctx context.Context) string {
+		var id string
+		_, _ = UnaryRequestID()(ctx, nil, nil, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			id = requestid.FromContext(ctx)
+			return nil, nil
+		})
+		return id
+	}
+
+	t.Run("should reuse a valid request id", func(t *testing.T) {
+		want := requestid.New()
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestid.Header, want))
+
+		assert.Equal(t, want, served(ctx))
+	})
+	t.Run("should replace an invalid request id", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestid.Header, "bad id\n"))
+
+		id := served(ctx)
+
+		assert.NotEqual(t, "bad id\n", id)
+		assert.True(t, requestid.Valid(id))
+	})
+	t.Run("should generate a request id when none is sent", func(t *testing.T) {
+		assert.True(t, requestid.Valid(served(context.Background())))
+	})
+}
@@ -0,0 +1,50 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+	"google.golang.org/grpc"
+)
+
+// The metadata keys of the site and the roles of the caller.
+var (
+	siteKey  = strings.ToLower(tenant.Header)
+	rolesKey = strings.ToLower(tenant.RolesHeader)
+)
+
+// UnaryTenant scopes the context of the call to the site in its metadata,
+// as web.Tenant does for HTTP requests. A site that can't be resolved fails
+// the call before the handler runs.
+func UnaryTenant(defaultSite string, sites []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := withTenant(ctx, defaultSite, sites)
+		if err != nil {
+			return nil, Status(err)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamTenant is UnaryTenant for streams.
+func StreamTenant(defaultSite string, sites []string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := withTenant(ss.Context(), defaultSite, sites)
+		if err != nil {
+			return Status(err)
+		}
+		return handler(srv, withContext(ss, ctx))
+	}
+}
+
+func withTenant(ctx context.Context, defaultSite string, sites []string) (context.Context, error) {
+	site, err := tenant.Resolve(firstValue(ctx, siteKey), firstValue(ctx, rolesKey), defaultSite, sites)
+	if err != nil {
+		return ctx, err
+	}
+	ctx = tenant.NewContext(ctx, site)
+	return logger.WithAttrs(ctx, slog.String("site", site)), nil
+}
@@ -0,0 +1,74 @@
+// Package logger builds the structured logger of the application. Records are
+// written as JSON and enriched with the attributes attached to the context
+// they are logged with, such as the request id, the route or entity ids.
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/requestid"
+)
+
+// New returns a JSON logger writing records of at least level to w.
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(&contextHandler{slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level})})
+}
+
+// Discard returns a logger that drops every record, meant for tests.
+func Discard() *slog.Logger {
+	return New(io.Discard, slog.LevelError+1)
+}
+
+// ParseLevel parses one of debug, info, warn or error, case-insensitively.
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return 0, fmt.Errorf("invalid log level %q", s)
+	}
+	return level, nil
+}
+
+type attrsKey struct{}
+
+// WithAttrs returns a copy of ctx carrying attrs besides the ones ctx already
+// carries. Every record logged with the returned context includes them.
+func WithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	prev := attrsFrom(ctx)
+	merged := make([]slog.Attr, 0, len(prev)+len(attrs))
+	merged = append(merged, prev...)
+	merged = append(merged, attrs...)
+	return context.WithValue(ctx, attrsKey{}, merged)
+}
+
+func attrsFrom(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	return attrs
+}
+
+// contextHandler adds the request id and the attributes carried by the
+// context to each record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		if id := requestid.FromContext(ctx); id != "" {
+			r.AddAttrs(slog.String("request_id", id))
+		}
+		r.AddAttrs(attrsFrom(ctx)...)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,44 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/requestid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, slog.LevelInfo).With("component", "section.service")
+
+	ctx := requestid.NewContext(context.Background(), "req-1")
+	ctx = WithAttrs(ctx, slog.String("route", "/api/v1/sections/:id"))
+	ctx = WithAttrs(ctx, slog.String("id", "7"))
+	l.InfoContext(ctx, "section deleted")
+	l.DebugContext(ctx, "dropped")
+
+	rec := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &rec))
+	assert.Equal(t, "section deleted", rec["msg"])
+	assert.Equal(t, "section.service", rec["component"])
+	assert.Equal(t, "req-1", rec["request_id"])
+	assert.Equal(t, "/api/v1/sections/:id", rec["route"])
+	assert.Equal(t, "7", rec["id"])
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("DEBUG")
+	assert.NoError(t, err)
+	assert.Equal(t, slog.LevelDebug, level)
+
+	level, err = ParseLevel("warn")
+	assert.NoError(t, err)
+	assert.Equal(t, slog.LevelWarn, level)
+
+	_, err = ParseLevel("verbose")
+	assert.EqualError(t, err, `invalid log level "verbose"`)
+}
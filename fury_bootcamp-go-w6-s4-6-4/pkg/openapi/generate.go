@@ -0,0 +1,494 @@
+package openapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// initialisms are the words written in capitals in Go names.
+var initialisms = map[string]string{
+	"api": "API", "asn": "ASN", "cid": "CID", "eta": "ETA", "http": "HTTP",
+	"id": "ID", "json": "JSON", "uri": "URI", "url": "URL",
+}
+
+// schema holds the keywords of a JSON Schema the generated types are made of.
+type schema struct {
+	Type                 typeList   `yaml:"type"`
+	Ref                  string     `yaml:"$ref"`
+	Description          string     `yaml:"description"`
+	Properties           properties `yaml:"properties"`
+	Required             []string   `yaml:"required"`
+	Items                *schema    `yaml:"items"`
+	AdditionalProperties *schema    `yaml:"additionalProperties"`
+	Enum                 []string   `yaml:"enum"`
+	Minimum              *string    `yaml:"minimum"`
+	Maximum              *string    `yaml:"maximum"`
+	MinLength            *string    `yaml:"minLength"`
+	MaxLength            *string    `yaml:"maxLength"`
+	MinItems             *string    `yaml:"minItems"`
+	MaxItems             *string    `yaml:"maxItems"`
+	Not                  *schema    `yaml:"not"`
+	Const                *string    `yaml:"const"`
+	AllOf                []*schema  `yaml:"allOf"`
+	If                   *schema    `yaml:"if"`
+	Then                 *schema    `yaml:"then"`
+
+	// conditions are the if and then of s and of the schemas it's combined
+	// with, gathered by merge.
+	conditions []*schema
+}
+
+// typeList is the type keyword, a single type or a list of them.
+type typeList []string
+
+func (t *typeList) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		*t = typeList{n.Value}
+		return nil
+	}
+	return n.Decode((*[]string)(t))
+}
+
+// properties keeps the properties of a schema in the order they're written.
+type properties []property
+
+type property struct {
+	name   string
+	schema *schema
+}
+
+func (p *properties) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind != yaml.MappingNode {
+		return errors.New("properties must be a mapping")
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		s := &schema{}
+		if err := n.Content[i+1].Decode(s); err != nil {
+			return fmt.Errorf("%s: %w", n.Content[i].Value, err)
+		}
+		*p = append(*p, property{name: n.Content[i].Value, schema: s})
+	}
+	return nil
+}
+
+// generator writes the types of the schemas of a document.
+type generator struct {
+	schemas map[string]*schema
+	buf     bytes.Buffer
+	// inline are the types of the objects defined inside the schema being
+	// written, written after it.
+	inline []property
+}
+
+// Generate returns the source of package pkg with a Go type for every schema
+// in the components of an OpenAPI 3.1 document, in the order they're written.
+//
+// Objects are structs with a field for each property, named in Go style,
+// and the objects defined inside them are structs named after the property.
+// Schemas combined with allOf are a single struct with the properties of
+// all of them. A string schema with an enum is a string type with a
+// constant for each value.
+//
+// Fields carry the binding tags that make gin check what the schema allows:
+// required, min and max, oneof for an enum, ne for a const it must not be,
+// required_if for a property an if and then require when another is a
+// const, and dive into arrays of objects. A required number whose minimum admits
+// zero and a required boolean are pointers, so binding tells a zero from a
+// missing value; a required number without a minimum isn't checked to be
+// present.
+func Generate(doc []byte, pkg string) ([]byte, error) {
+	var root struct {
+		OpenAPI    string `yaml:"openapi"`
+		Components struct {
+			Schemas yaml.Node `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(doc, &root); err != nil {
+		return nil, fmt.Errorf("parsing spec: %w", err)
+	}
+	if !strings.HasPrefix(root.OpenAPI, "3.1.") {
+		return nil, fmt.Errorf("unsupported openapi version %q, want 3.1", root.OpenAPI)
+	}
+	var all properties
+	if root.Components.Schemas.Kind != 0 {
+		if err := root.Components.Schemas.Decode(&all); err != nil {
+			return nil, fmt.Errorf("parsing schemas: %w", err)
+		}
+	}
+
+	g := &generator{schemas: map[string]*schema{}}
+	for _, p := range all {
+		g.schemas[p.name] = p.schema
+	}
+	fmt.Fprintf(&g.buf, "// Code generated by openapigen. DO NOT EDIT.\n\npackage %s\n", pkg)
+	for _, p := range all {
+		if err := g.named(p.name, p.schema); err != nil {
+			return nil, fmt.Errorf("%s: %w", p.name, err)
+		}
+		for len(g.inline) > 0 {
+			next := g.inline[0]
+			g.inline = g.inline[1:]
+			if err := g.named(next.name, next.schema); err != nil {
+				return nil, fmt.Errorf("%s: %w", next.name, err)
+			}
+		}
+	}
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting: %w", err)
+	}
+	return src, nil
+}
+
+// named writes the type called name.
+func (g *generator) named(name string, s *schema) error {
+	s, err := g.merge(s)
+	if err != nil {
+		return err
+	}
+	g.buf.WriteString("\n")
+	comment(&g.buf, s.Description)
+	typ, _ := s.types()
+	switch {
+	case typ == "object" && len(s.Properties) > 0:
+		return g.structType(name, s)
+	case typ == "string" || typ == "" && len(s.Enum) > 0:
+		fmt.Fprintf(&g.buf, "type %s string\n", name)
+		if len(s.Enum) > 0 {
+			g.buf.WriteString("\nconst (\n")
+			for _, v := range s.Enum {
+				fmt.Fprintf(&g.buf, "%s%s %s = %q\n", name, goName(v), name, v)
+			}
+			g.buf.WriteString(")\n")
+		}
+		return nil
+	}
+	goType, err := g.goType(name, s)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(&g.buf, "type %s %s\n", name, goType)
+	return nil
+}
+
+func (g *generator) structType(name string, s *schema) error {
+	fmt.Fprintf(&g.buf, "type %s struct {\n", name)
+	for _, p := range s.Properties {
+		prop, err := g.merge(p.schema)
+		if err != nil {
+			return fmt.Errorf("%s: %w", p.name, err)
+		}
+		goType, err := g.goType(name+goName(p.name), prop)
+		if err != nil {
+			return fmt.Errorf("%s: %w", p.name, err)
+		}
+		required := slices.Contains(s.Required, p.name)
+		pointer, checked := presence(prop, required)
+		if pointer {
+			goType = "*" + goType
+		}
+
+		tags := `json:"` + p.name
+		if !required {
+			tags += ",omitempty"
+		}
+		tags += `"`
+		binding := g.binding(prop, required, checked)
+		if conditions := requiredIf(s, p.name); conditions != "" {
+			binding = strings.TrimSuffix(conditions+","+binding, ",")
+		}
+		if binding != "" {
+			tags += ` binding:"` + binding + `"`
+		}
+		comment(&g.buf, prop.Description)
+		fmt.Fprintf(&g.buf, "%s %s `%s`\n", goName(p.name), goType, tags)
+	}
+	g.buf.WriteString("}\n")
+	return nil
+}
+
+// goType returns the Go type of s, with name the name of its struct when
+// it's an object defined in place.
+func (g *generator) goType(name string, s *schema) (string, error) {
+	if s.Ref != "" {
+		ref, _, err := g.ref(s.Ref)
+		return ref, err
+	}
+	typ, nullable := s.types()
+	var goType string
+	switch typ {
+	case "object":
+		switch {
+		case len(s.Properties) > 0:
+			g.inline = append(g.inline, property{name: name, schema: s})
+			goType = name
+		case s.AdditionalProperties != nil:
+			values, err := g.goType(singular(name), s.AdditionalProperties)
+			if err != nil {
+				return "", err
+			}
+			return "map[string]" + values, nil
+		default:
+			return "map[string]any", nil
+		}
+	case "array":
+		if s.Items == nil {
+			return "[]any", nil
+		}
+		items, err := g.goType(singular(name), s.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + items, nil
+	case "string":
+		goType = "string"
+	case "integer":
+		goType = "int"
+	case "number":
+		goType = "float64"
+	case "boolean":
+		goType = "bool"
+	case "":
+		if len(s.Enum) == 0 {
+			return "any", nil
+		}
+		goType = "string"
+	default:
+		return "", fmt.Errorf("unsupported type %q", typ)
+	}
+	if nullable {
+		return "*" + goType, nil
+	}
+	return goType, nil
+}
+
+// presence tells whether the field of a property of schema s is a pointer,
+// and whether binding checks it's present. Binding can't tell a missing
+// number or boolean from its zero, so a required one whose zero the schema
+// admits is a pointer when the schema says so with its minimum, or for a
+// boolean, and isn't checked otherwise.
+func presence(s *schema, required bool) (pointer, checked bool) {
+	typ, nullable := s.types()
+	if !required || nullable {
+		return false, false
+	}
+	switch typ {
+	case "boolean":
+		return true, true
+	case "integer", "number":
+		if s.Minimum == nil {
+			return false, s.Not != nil && s.Not.Const != nil && isZero(*s.Not.Const)
+		}
+		min, err := strconv.ParseFloat(*s.Minimum, 64)
+		return err == nil && min <= 0, err == nil
+	}
+	return false, true
+}
+
+// binding returns the binding tag of a field of schema s, with required
+// whether it's checked to be present.
+func (g *generator) binding(s *schema, required, checked bool) string {
+	var rules []string
+	for _, bound := range []struct {
+		rule  string
+		value *string
+	}{
+		{"min", s.Minimum}, {"max", s.Maximum},
+		{"min", s.MinLength}, {"max", s.MaxLength},
+		{"min", s.MinItems}, {"max", s.MaxItems},
+	} {
+		if bound.value != nil {
+			rules = append(rules, bound.rule+"="+*bound.value)
+		}
+	}
+	if len(s.Enum) > 0 {
+		rules = append(rules, "oneof="+strings.Join(s.Enum, " "))
+	}
+	if s.Not != nil && s.Not.Const != nil {
+		rules = append(rules, "ne="+*s.Not.Const)
+	}
+	switch {
+	case checked:
+		rules = append([]string{"required"}, rules...)
+	case !required && len(rules) > 0:
+		rules = append([]string{"omitempty"}, rules...)
+	}
+	if typ, _ := s.types(); typ == "array" && s.Items != nil && g.isObject(s.Items) {
+		rules = append(rules, "dive")
+	}
+	return strings.Join(rules, ",")
+}
+
+// requiredIf returns the required_if rules of the property name of s: the
+// conditions of s whose then requires it and whose if requires another
+// property to be a const.
+func requiredIf(s *schema, name string) string {
+	var rules []string
+	for _, c := range s.conditions {
+		if c.Then == nil || !slices.Contains(c.Then.Required, name) {
+			continue
+		}
+		for _, p := range c.If.Properties {
+			if p.schema.Const != nil && slices.Contains(c.If.Required, p.name) {
+				rules = append(rules, "required_if="+goName(p.name)+" "+*p.schema.Const)
+			}
+		}
+	}
+	return strings.Join(rules, ",")
+}
+
+// merge returns s with the schemas it's combined with by allOf merged into
+// it: their properties in order, later ones adding keywords to the earlier
+// ones of the same name, and all their required properties.
+func (g *generator) merge(s *schema) (*schema, error) {
+	if len(s.AllOf) == 0 {
+		if s.If != nil {
+			merged := *s
+			merged.conditions = []*schema{s}
+			return &merged, nil
+		}
+		return s, nil
+	}
+	merged := *s
+	merged.AllOf = nil
+	if s.If != nil {
+		merged.conditions = append(merged.conditions, s)
+	}
+	for _, part := range s.AllOf {
+		if part.Ref != "" {
+			_, target, err := g.ref(part.Ref)
+			if err != nil {
+				return nil, err
+			}
+			part = target
+		}
+		part, err := g.merge(part)
+		if err != nil {
+			return nil, err
+		}
+		if len(merged.Type) == 0 {
+			merged.Type = part.Type
+		}
+		merged.conditions = append(merged.conditions, part.conditions...)
+		merged.Required = append(merged.Required, part.Required...)
+		for _, p := range part.Properties {
+			i := slices.IndexFunc(merged.Properties, func(m property) bool { return m.name == p.name })
+			if i < 0 {
+				merged.Properties = append(merged.Properties, p)
+				continue
+			}
+			overlaid := overlay(*merged.Properties[i].schema, *p.schema)
+			merged.Properties = append(properties{}, merged.Properties...)
+			merged.Properties[i] = property{name: p.name, schema: &overlaid}
+		}
+	}
+	return &merged, nil
+}
+
+// overlay returns base with the keywords set in s.
+func overlay(base, s schema) schema {
+	if len(s.Type) > 0 {
+		base.Type = s.Type
+	}
+	if s.Description != "" {
+		base.Description = s.Description
+	}
+	if len(s.Enum) > 0 {
+		base.Enum = s.Enum
+	}
+	for _, kw := range []struct{ dst, src **string }{
+		{&base.Minimum, &s.Minimum}, {&base.Maximum, &s.Maximum},
+		{&base.MinLength, &s.MinLength}, {&base.MaxLength, &s.MaxLength},
+		{&base.MinItems, &s.MinItems}, {&base.MaxItems, &s.MaxItems},
+	} {
+		if *kw.src != nil {
+			*kw.dst = *kw.src
+		}
+	}
+	if s.Not != nil {
+		base.Not = s.Not
+	}
+	return base
+}
+
+// ref returns the name and the schema a reference to a component schema
+// points to.
+func (g *generator) ref(ref string) (string, *schema, error) {
+	name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+	if !ok {
+		return "", nil, fmt.Errorf("only references to component schemas are supported, got %q", ref)
+	}
+	s, ok := g.schemas[name]
+	if !ok {
+		return "", nil, fmt.Errorf("unresolved reference %q", ref)
+	}
+	return name, s, nil
+}
+
+func (g *generator) isObject(s *schema) bool {
+	if s.Ref != "" {
+		_, target, err := g.ref(s.Ref)
+		if err != nil {
+			return false
+		}
+		s = target
+	}
+	typ, _ := s.types()
+	return typ == "object" || len(s.AllOf) > 0
+}
+
+// types returns the type of s other than null, and whether it may be null.
+func (s *schema) types() (typ string, nullable bool) {
+	for _, t := range s.Type {
+		if t == "null" {
+			nullable = true
+			continue
+		}
+		typ = t
+	}
+	return typ, nullable
+}
+
+// goName turns a property name, in snake or camel case, or an enum value
+// into an exported Go name.
+func goName(s string) string {
+	var b strings.Builder
+	words := strings.FieldsFunc(s, func(r rune) bool { return r == '_' || r == '.' || r == '-' || r == ' ' })
+	for _, w := range words {
+		if upper, ok := initialisms[strings.ToLower(w)]; ok {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+// singular names the element of a list named name.
+func singular(name string) string {
+	if strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss") {
+		return name[:len(name)-1]
+	}
+	return name
+}
+
+// comment writes description as a comment.
+func comment(b *bytes.Buffer, description string) {
+	for _, line := range strings.Split(strings.TrimSpace(description), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			b.WriteString("// " + line + "\n")
+		}
+	}
+}
+
+func isZero(number string) bool {
+	n, err := strconv.ParseFloat(number, 64)
+	return err == nil && n == 0
+}
@@ -0,0 +1,104 @@
+package openapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const generateSpec = `
+openapi: 3.1.0
+info:
+  title: test
+  version: "1"
+paths: {}
+components:
+  schemas:
+    Reason:
+      type: string
+      enum: [damaged, receiving_error]
+    CountUpdate:
+      type: object
+      properties:
+        section_id:
+          type: integer
+          minimum: 1
+        note:
+          type: string
+          maxLength: 45
+    CountCreate:
+      allOf:
+        - $ref: "#/components/schemas/CountUpdate"
+        - required: [section_id, selection, approved, lines]
+          properties:
+            selection:
+              type: string
+              enum: [all, abc]
+            class:
+              type: string
+            approved:
+              type: boolean
+            lines:
+              type: array
+              minItems: 1
+              items:
+                type: object
+                required: [counted_quantity]
+                properties:
+                  counted_quantity:
+                    type: integer
+                    minimum: 0
+                  reason:
+                    $ref: "#/components/schemas/Reason"
+                  due_date:
+                    type: [string, "null"]
+        - if:
+            required: [selection]
+            properties:
+              selection:
+                const: abc
+          then:
+            required: [class]
+`
+
+func TestGenerate(t *testing.T) {
+	src, err := Generate([]byte(generateSpec), "api")
+	require.NoError(t, err)
+
+	want := "// Code generated by openapigen. DO NOT EDIT.\n\npackage api\n\n" +
+		"type Reason string\n\n" +
+		"const (\n" +
+		"\tReasonDamaged        Reason = \"damaged\"\n" +
+		"\tReasonReceivingError Reason = \"receiving_error\"\n" +
+		")\n\n" +
+		"type CountUpdate struct {\n" +
+		"\tSectionID int    `json:\"section_id,omitempty\" binding:\"omitempty,min=1\"`\n" +
+		"\tNote      string `json:\"note,omitempty\" binding:\"omitempty,max=45\"`\n" +
+		"}\n\n" +
+		"type CountCreate struct {\n" +
+		"\tSectionID int               `json:\"section_id\" binding:\"required,min=1\"`\n" +
+		"\tNote      string            `json:\"note,omitempty\" binding:\"omitempty,max=45\"`\n" +
+		"\tSelection string            `json:\"selection\" binding:\"required,oneof=all abc\"`\n" +
+		"\tClass     string            `json:\"class,omitempty\" binding:\"required_if=Selection abc\"`\n" +
+		"\tApproved  *bool             `json:\"approved\" binding:\"required\"`\n" +
+		"\tLines     []CountCreateLine `json:\"lines\" binding:\"required,min=1,dive\"`\n" +
+		"}\n\n" +
+		"type CountCreateLine struct {\n" +
+		"\tCountedQuantity *int    `json:\"counted_quantity\" binding:\"required,min=0\"`\n" +
+		"\tReason          Reason  `json:\"reason,omitempty\"`\n" +
+		"\tDueDate         *string `json:\"due_date,omitempty\"`\n" +
+		"}\n"
+	assert.Equal(t, want, string(src))
+
+	t.Run("only 3.1 documents", func(t *testing.T) {
+		_, err := Generate([]byte(strings.Replace(generateSpec, "3.1.0", "3.0.3", 1)), "api")
+		assert.EqualError(t, err, `unsupported openapi version "3.0.3", want 3.1`)
+	})
+
+	t.Run("references to component schemas only", func(t *testing.T) {
+		_, err := Generate([]byte(strings.Replace(generateSpec, "#/components/schemas/Reason", "reason.yaml", 1)), "api")
+		assert.EqualError(t, err, `CountCreateLine: reason: only references to component schemas are supported, got "reason.yaml"`)
+	})
+}
@@ -0,0 +1,504 @@
+// Package openapi checks requests and responses against an OpenAPI 3.1
+// description of the API. Schemas are JSON Schema 2020-12, as the 3.1
+// specification defines them.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"gopkg.in/yaml.v3"
+)
+
+// specURL names the document inside the schema compiler, so references
+// between its schemas resolve.
+const specURL = "urn:melisprint:openapi"
+
+var methods = []string{
+	http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+	http.MethodOptions, http.MethodHead, http.MethodPatch, http.MethodTrace,
+}
+
+// ErrUndocumented is returned for operations the spec doesn't describe.
+var ErrUndocumented = errors.New("operation not documented")
+
+var printer = message.NewPrinter(language.English)
+
+// Spec holds the operations of an OpenAPI document with their schemas
+// compiled. Operations are keyed by method and by their path in gin syntax,
+// "/sellers/:id" for "/sellers/{id}", so they can be found from the route
+// that matched a request.
+type Spec struct {
+	ops map[string]*operation
+}
+
+type operation struct {
+	params       []parameter
+	body         *jsonschema.Schema
+	bodyRequired bool
+	// bodyMedia are the media types other than JSON the body may come in,
+	// such as multipart/form-data for uploads. They're left to the handler
+	// to check.
+	bodyMedia []string
+	// responses maps a status code, or "default", to the schema of each media
+	// type. A media type without a schema maps to nil.
+	responses map[string]map[string]*jsonschema.Schema
+}
+
+type parameter struct {
+	name     string
+	in       string
+	required bool
+	typ      string
+	schema   *jsonschema.Schema
+}
+
+// Operation identifies an operation of the spec.
+type Operation struct {
+	Method string
+	Path   string
+}
+
+// Error lists what made a request or a response not match its operation.
+type Error struct {
+	// In is "parameters", "body" or "response".
+	In     string
+	Detail string
+	Fields []Field
+}
+
+// Field is a parameter or a location in a body that failed validation.
+type Field struct {
+	Name   string
+	Reason string
+}
+
+func (e *Error) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Detail
+	}
+	reasons := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		reasons[i] = f.Name + ": " + f.Reason
+	}
+	return e.Detail + ": " + strings.Join(reasons, "; ")
+}
+
+// Load parses an OpenAPI 3.1 document, in YAML or JSON, and compiles the
+// schemas of its parameters, request bodies and responses.
+func Load(doc []byte) (*Spec, error) {
+	var raw any
+	if err := yaml.Unmarshal(doc, &raw); err != nil {
+		return nil, fmt.Errorf("parsing spec: %w", err)
+	}
+	// Round trip through JSON so the compiler gets the types it expects.
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing spec: %w", err)
+	}
+	v, err := jsonschema.UnmarshalJSON(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("parsing spec: %w", err)
+	}
+	root, _ := v.(map[string]any)
+	if version, _ := root["openapi"].(string); !strings.HasPrefix(version, "3.1.") {
+		return nil, fmt.Errorf("unsupported openapi version %q, want 3.1", version)
+	}
+
+	c := jsonschema.NewCompiler()
+	c.DefaultDraft(jsonschema.Draft2020)
+	c.AssertFormat()
+	if err := c.AddResource(specURL, v); err != nil {
+		return nil, err
+	}
+	l := loader{root: root, compiler: c}
+
+	s := &Spec{ops: map[string]*operation{}}
+	paths, _ := root["paths"].(map[string]any)
+	for path, item := range paths {
+		item, _ := item.(map[string]any)
+		itemPtr := "/paths/" + escape(path)
+		for _, method := range methods {
+			op, ok := item[strings.ToLower(method)].(map[string]any)
+			if !ok {
+				continue
+			}
+			compiled, err := l.operation(item, itemPtr, op, itemPtr+"/"+strings.ToLower(method))
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			s.ops[method+" "+ginPath(path)] = compiled
+		}
+	}
+	return s, nil
+}
+
+// Operations returns every operation of the spec, sorted by path and method.
+func (s *Spec) Operations() []Operation {
+	ops := make([]Operation, 0, len(s.ops))
+	for key := range s.ops {
+		method, path, _ := strings.Cut(key, " ")
+		ops = append(ops, Operation{Method: method, Path: path})
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].Path != ops[j].Path {
+			return ops[i].Path < ops[j].Path
+		}
+		return ops[i].Method < ops[j].Method
+	})
+	return ops
+}
+
+// ValidateRequest checks the parameters and the body of r against the
+// operation of route, given in gin syntax, with pathParams the values of its
+// path parameters. The body is read and put back so handlers can read it,
+// unless it's of a media type other than JSON the operation accepts, which
+// is left to the handler.
+func (s *Spec) ValidateRequest(route string, r *http.Request, pathParams map[string]string) error {
+	op, ok := s.ops[r.Method+" "+route]
+	if !ok {
+		return fmt.Errorf("%w: %s %s", ErrUndocumented, r.Method, route)
+	}
+
+	var fields []Field
+	query := r.URL.Query()
+	for _, p := range op.params {
+		var value string
+		var present bool
+		switch p.in {
+		case "path":
+			value, present = pathParams[p.name]
+		case "query":
+			present = query.Has(p.name)
+			value = query.Get(p.name)
+		case "header":
+			value = r.Header.Get(p.name)
+			present = value != ""
+		}
+		if !present {
+			if p.required {
+				fields = append(fields, Field{Name: p.name, Reason: "is required"})
+			}
+			continue
+		}
+		fields = append(fields, p.validate(value)...)
+	}
+	if len(fields) > 0 {
+		return &Error{In: "parameters", Detail: "invalid parameters", Fields: fields}
+	}
+
+	if op.body == nil && len(op.bodyMedia) == 0 {
+		return nil
+	}
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil && slices.Contains(op.bodyMedia, mediaType) {
+		return nil
+	}
+	if op.body == nil {
+		if r.ContentLength == 0 && !op.bodyRequired {
+			return nil
+		}
+		return &Error{In: "body", Detail: "request body must be " + strings.Join(op.bodyMedia, " or ")}
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return &Error{In: "body", Detail: "reading request body: " + err.Error()}
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if len(bytes.TrimSpace(body)) == 0 {
+		if op.bodyRequired {
+			return &Error{In: "body", Detail: "request body is required"}
+		}
+		return nil
+	}
+	v, err := jsonschema.UnmarshalJSON(bytes.NewReader(body))
+	if err != nil {
+		return &Error{In: "body", Detail: "request body is not valid JSON: " + err.Error()}
+	}
+	if fields := validate(op.body, v); len(fields) > 0 {
+		return &Error{In: "body", Detail: "request body doesn't match its schema", Fields: fields}
+	}
+	return nil
+}
+
+// ValidateResponse checks that status is documented for the operation of
+// method and route, and that the body matches the schema of its content type.
+func (s *Spec) ValidateResponse(method, route string, status int, header http.Header, body []byte) error {
+	op, ok := s.ops[method+" "+route]
+	if !ok {
+		return fmt.Errorf("%w: %s %s", ErrUndocumented, method, route)
+	}
+	content, ok := op.responses[strconv.Itoa(status)]
+	if !ok {
+		if content, ok = op.responses["default"]; !ok {
+			return &Error{In: "response", Detail: fmt.Sprintf("status %d is not documented", status)}
+		}
+	}
+
+	if len(content) == 0 {
+		if len(body) > 0 {
+			return &Error{In: "response", Detail: fmt.Sprintf("status %d documents no body, got %d bytes", status, len(body))}
+		}
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return &Error{In: "response", Detail: "invalid content type: " + err.Error()}
+	}
+	schema, ok := content[mediaType]
+	if !ok {
+		return &Error{In: "response", Detail: fmt.Sprintf("content type %q is not documented for status %d", mediaType, status)}
+	}
+	if schema == nil {
+		return nil
+	}
+
+	var v any = string(body)
+	if isJSON(mediaType) {
+		if v, err = jsonschema.UnmarshalJSON(bytes.NewReader(body)); err != nil {
+			return &Error{In: "response", Detail: "body is not valid JSON: " + err.Error()}
+		}
+	}
+	if fields := validate(schema, v); len(fields) > 0 {
+		return &Error{In: "response", Detail: "body doesn't match its schema", Fields: fields}
+	}
+	return nil
+}
+
+// validate parses value as the type of p before checking it against its
+// schema, as parameters always arrive as strings.
+func (p parameter) validate(value string) []Field {
+	var v any = value
+	switch p.typ {
+	case "integer":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return []Field{{Name: p.name, Reason: "must be an integer"}}
+		}
+		v = n
+	case "number":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return []Field{{Name: p.name, Reason: "must be a number"}}
+		}
+		v = n
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return []Field{{Name: p.name, Reason: "must be a boolean"}}
+		}
+		v = b
+	}
+	fields := validate(p.schema, v)
+	for i := range fields {
+		fields[i].Name = p.name
+	}
+	return fields
+}
+
+// validate returns the leaf errors of validating v against schema, named by
+// the location of the value that failed and sorted by it.
+func validate(schema *jsonschema.Schema, v any) []Field {
+	err := schema.Validate(v)
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		return nil
+	}
+	var fields []Field
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) > 0 {
+			for _, cause := range e.Causes {
+				walk(cause)
+			}
+			return
+		}
+		if required, ok := e.ErrorKind.(*kind.Required); ok {
+			for _, name := range required.Missing {
+				fields = append(fields, Field{Name: fieldName(append(e.InstanceLocation, name)), Reason: "is required"})
+			}
+			return
+		}
+		fields = append(fields, Field{Name: fieldName(e.InstanceLocation), Reason: e.ErrorKind.LocalizedString(printer)})
+	}
+	walk(verr)
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+	return fields
+}
+
+// fieldName joins the tokens of an instance location with dots, the way
+// clients name nested fields. The whole value is named "body".
+func fieldName(location []string) string {
+	if len(location) == 0 {
+		return "body"
+	}
+	return strings.Join(location, ".")
+}
+
+// loader walks the document, following local references, and compiles the
+// schemas it finds.
+type loader struct {
+	root     map[string]any
+	compiler *jsonschema.Compiler
+}
+
+func (l loader) operation(item map[string]any, itemPtr string, op map[string]any, opPtr string) (*operation, error) {
+	compiled := &operation{responses: map[string]map[string]*jsonschema.Schema{}}
+
+	// Operation parameters override the path item ones with the same name
+	// and location.
+	params := map[string]parameter{}
+	var order []string
+	for _, list := range []struct {
+		values []any
+		ptr    string
+	}{
+		{asSlice(item["parameters"]), itemPtr + "/parameters"},
+		{asSlice(op["parameters"]), opPtr + "/parameters"},
+	} {
+		for i, value := range list.values {
+			p, err := l.parameter(value, fmt.Sprintf("%s/%d", list.ptr, i))
+			if err != nil {
+				return nil, err
+			}
+			key := p.in + " " + p.name
+			if _, ok := params[key]; !ok {
+				order = append(order, key)
+			}
+			params[key] = p
+		}
+	}
+	for _, key := range order {
+		compiled.params = append(compiled.params, params[key])
+	}
+
+	if value, ok := op["requestBody"]; ok {
+		body, ptr, err := l.resolve(value, opPtr+"/requestBody")
+		if err != nil {
+			return nil, err
+		}
+		compiled.bodyRequired, _ = body["required"].(bool)
+		content, _ := body["content"].(map[string]any)
+		for mediaType := range content {
+			if mediaType != "application/json" {
+				compiled.bodyMedia = append(compiled.bodyMedia, mediaType)
+				continue
+			}
+			if compiled.body, err = l.compile(ptr + "/content/application~1json/schema"); err != nil {
+				return nil, err
+			}
+		}
+		if len(content) == 0 {
+			return nil, errors.New("request body documents no media type")
+		}
+		sort.Strings(compiled.bodyMedia)
+	}
+
+	responses, _ := op["responses"].(map[string]any)
+	for status, value := range responses {
+		resp, ptr, err := l.resolve(value, opPtr+"/responses/"+escape(status))
+		if err != nil {
+			return nil, err
+		}
+		media := map[string]*jsonschema.Schema{}
+		content, _ := resp["content"].(map[string]any)
+		for mediaType, m := range content {
+			m, _ := m.(map[string]any)
+			if _, ok := m["schema"]; !ok {
+				media[mediaType] = nil
+				continue
+			}
+			if media[mediaType], err = l.compile(ptr + "/content/" + escape(mediaType) + "/schema"); err != nil {
+				return nil, err
+			}
+		}
+		compiled.responses[status] = media
+	}
+	return compiled, nil
+}
+
+func (l loader) parameter(value any, ptr string) (parameter, error) {
+	p, ptr, err := l.resolve(value, ptr)
+	if err != nil {
+		return parameter{}, err
+	}
+	param := parameter{}
+	param.name, _ = p["name"].(string)
+	param.in, _ = p["in"].(string)
+	param.required, _ = p["required"].(bool)
+	if schema, ok := p["schema"].(map[string]any); ok {
+		param.typ, _ = schema["type"].(string)
+	}
+	if param.schema, err = l.compile(ptr + "/schema"); err != nil {
+		return parameter{}, err
+	}
+	return param, nil
+}
+
+// resolve returns the object at value, following its $ref when it has one,
+// and the pointer to where the object is.
+func (l loader) resolve(value any, ptr string) (map[string]any, string, error) {
+	obj, _ := value.(map[string]any)
+	ref, ok := obj["$ref"].(string)
+	if !ok {
+		return obj, ptr, nil
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, "", fmt.Errorf("only local references are supported, got %q", ref)
+	}
+	var target any = l.root
+	for _, token := range strings.Split(ref[2:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		m, _ := target.(map[string]any)
+		if target, ok = m[token]; !ok {
+			return nil, "", fmt.Errorf("unresolved reference %q", ref)
+		}
+	}
+	return l.resolve(target, ref[1:])
+}
+
+func (l loader) compile(ptr string) (*jsonschema.Schema, error) {
+	return l.compiler.Compile(specURL + "#" + ptr)
+}
+
+func asSlice(v any) []any {
+	s, _ := v.([]any)
+	return s
+}
+
+// escape escapes a JSON pointer token.
+func escape(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// ginPath turns an OpenAPI path template into a gin route.
+func ginPath(path string) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(path, '{')
+		end := strings.IndexByte(path, '}')
+		if start < 0 || end < start {
+			b.WriteString(path)
+			return b.String()
+		}
+		b.WriteString(path[:start])
+		b.WriteString(":" + path[start+1:end])
+		path = path[end+1:]
+	}
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,219 @@
+package openapi
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `
+openapi: 3.1.0
+info:
+  title: test
+  version: "1"
+paths:
+  /sections/{id}:
+    parameters:
+      - $ref: "#/components/parameters/ID"
+    patch:
+      parameters:
+        - name: dry_run
+          in: query
+          schema:
+            type: boolean
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Section"
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [data]
+                properties:
+                  data:
+                    $ref: "#/components/schemas/Section"
+        "204":
+          description: nothing to update
+        default:
+          description: error
+          content:
+            application/problem+json:
+              schema:
+                type: object
+                required: [status]
+components:
+  parameters:
+    ID:
+      name: id
+      in: path
+      required: true
+      schema:
+        type: integer
+        minimum: 1
+  schemas:
+    Section:
+      type: object
+      required: [section_number]
+      properties:
+        section_number:
+          type: integer
+        due_date:
+          type: [string, "null"]
+          format: date
+`
+
+func loadTestSpec(t *testing.T) *Spec {
+	spec, err := Load([]byte(testSpec))
+	require.NoError(t, err)
+	return spec
+}
+
+func TestLoad(t *testing.T) {
+	spec := loadTestSpec(t)
+	assert.Equal(t, []Operation{{Method: http.MethodPatch, Path: "/sections/:id"}}, spec.Operations())
+
+	t.Run("only 3.1 documents", func(t *testing.T) {
+		_, err := Load([]byte(strings.Replace(testSpec, "3.1.0", "3.0.3", 1)))
+		assert.EqualError(t, err, `unsupported openapi version "3.0.3", want 3.1`)
+	})
+	t.Run("unresolved references", func(t *testing.T) {
+		_, err := Load([]byte(strings.Replace(testSpec, "parameters/ID\"", "parameters/Missing\"", 1)))
+		assert.ErrorContains(t, err, `unresolved reference "#/components/parameters/Missing"`)
+	})
+}
+
+func TestValidateRequest(t *testing.T) {
+	spec := loadTestSpec(t)
+	tests := []struct {
+		name   string
+		target string
+		id     string
+		body   string
+		err    string
+	}{
+		{name: "valid", target: "/sections/1?dry_run=true", id: "1", body: `{"section_number": 3, "due_date": "2024-01-31"}`},
+		{name: "path parameter of the wrong type", target: "/sections/x", id: "x", body: `{}`, err: "invalid parameters: id: must be an integer"},
+		{name: "path parameter out of range", target: "/sections/0", id: "0", body: `{}`, err: "invalid parameters: id: minimum: got 0, want 1"},
+		{name: "query parameter of the wrong type", target: "/sections/1?dry_run=maybe", id: "1", body: `{}`, err: "invalid parameters: dry_run: must be a boolean"},
+		{name: "missing body", target: "/sections/1", id: "1", err: "request body is required"},
+		{name: "malformed body", target: "/sections/1", id: "1", body: `{"section_number":`, err: "request body is not valid JSON: unexpected EOF"},
+		{name: "missing property", target: "/sections/1", id: "1", body: `{}`, err: "request body doesn't match its schema: section_number: is required"},
+		{
+			name: "invalid properties", target: "/sections/1", id: "1", body: `{"section_number": "3", "due_date": "31/01/2024"}`,
+			err: "request body doesn't match its schema: due_date: '31/01/2024' is not valid date: parsing time \"31/01/2024\" as \"2006-01-02\": cannot parse \"31/01/2024\" as \"2006\"; section_number: got string, want integer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, tt.target, strings.NewReader(tt.body))
+
+			err := spec.ValidateRequest("/sections/:id", req, map[string]string{"id": tt.id})
+
+			if tt.err == "" {
+				assert.NoError(t, err)
+				body, _ := io.ReadAll(req.Body)
+				assert.Equal(t, tt.body, string(body), "the body is put back for the handler")
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+
+	t.Run("undocumented operation", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/sections/1", nil)
+		err := spec.ValidateRequest("/sections/:id", req, map[string]string{"id": "1"})
+		assert.ErrorIs(t, err, ErrUndocumented)
+	})
+}
+
+// uploadSpec documents an operation that takes files only.
+const uploadSpec = `
+openapi: 3.1.0
+info:
+  title: test
+  version: "1"
+paths:
+  /files:
+    post:
+      requestBody:
+        required: true
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+      responses:
+        "201":
+          description: created
+`
+
+func TestValidateUpload(t *testing.T) {
+	spec, err := Load([]byte(uploadSpec))
+	require.NoError(t, err)
+
+	t.Run("leaves the body to the handler", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/files", strings.NewReader("--b--"))
+		req.Header.Set("Content-Type", "multipart/form-data; boundary=b")
+
+		assert.NoError(t, spec.ValidateRequest("/files", req, nil))
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, "--b--", string(body))
+	})
+	t.Run("rejects other media types", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/files", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		assert.EqualError(t, spec.ValidateRequest("/files", req, nil), "request body must be multipart/form-data")
+	})
+	t.Run("requires the body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/files", nil)
+
+		assert.EqualError(t, spec.ValidateRequest("/files", req, nil), "request body must be multipart/form-data")
+	})
+}
+
+func TestValidateResponse(t *testing.T) {
+	spec := loadTestSpec(t)
+	jsonHeader := http.Header{"Content-Type": {"application/json; charset=utf-8"}}
+	problemHeader := http.Header{"Content-Type": {"application/problem+json"}}
+	tests := []struct {
+		name   string
+		status int
+		header http.Header
+		body   string
+		err    string
+	}{
+		{name: "documented status", status: 200, header: jsonHeader, body: `{"data": {"section_number": 1, "due_date": null}}`},
+		{name: "status without body", status: 204, header: http.Header{}},
+		{name: "default response", status: 500, header: problemHeader, body: `{"status": 500}`},
+		{name: "body that doesn't match", status: 200, header: jsonHeader, body: `{"data": {}}`, err: "body doesn't match its schema: data.section_number: is required"},
+		{name: "undocumented content type", status: 200, header: problemHeader, body: `{}`, err: `content type "application/problem+json" is not documented for status 200`},
+		{name: "unexpected body", status: 204, header: jsonHeader, body: `{}`, err: "status 204 documents no body, got 2 bytes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := spec.ValidateResponse(http.MethodPatch, "/sections/:id", tt.status, tt.header, []byte(tt.body))
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestGinPath(t *testing.T) {
+	assert.Equal(t, "/sellers", ginPath("/sellers"))
+	assert.Equal(t, "/sellers/:id", ginPath("/sellers/{id}"))
+	assert.Equal(t, "/a/:x/b/:y", ginPath("/a/{x}/b/{y}"))
+}
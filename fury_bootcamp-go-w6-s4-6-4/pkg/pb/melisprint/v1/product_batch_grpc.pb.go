@@ -0,0 +1,182 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: melisprint/v1/product_batch.proto
+
+package melisprintv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ProductBatchService_CreateProductBatch_FullMethodName = "/melisprint.v1.ProductBatchService/CreateProductBatch"
+	ProductBatchService_ReportProducts_FullMethodName     = "/melisprint.v1.ProductBatchService/ReportProducts"
+)
+
+// ProductBatchServiceClient is the client API for ProductBatchService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ProductBatchServiceClient interface {
+	// CreateProductBatch fails with ALREADY_EXISTS when the batch number is
+	// taken.
+	CreateProductBatch(ctx context.Context, in *CreateProductBatchRequest, opts ...grpc.CallOption) (*ProductBatch, error)
+	// ReportProducts streams how many products each of the given sections
+	// holds, or every section holding batches when none is given.
+	ReportProducts(ctx context.Context, in *ReportProductsRequest, opts ...grpc.CallOption) (ProductBatchService_ReportProductsClient, error)
+}
+
+type productBatchServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProductBatchServiceClient(cc grpc.ClientConnInterface) ProductBatchServiceClient {
+	return &productBatchServiceClient{cc}
+}
+
+func (c *productBatchServiceClient) CreateProductBatch(ctx context.Context, in *CreateProductBatchRequest, opts ...grpc.CallOption) (*ProductBatch, error) {
+	out := new(ProductBatch)
+	err := c.cc.Invoke(ctx, ProductBatchService_CreateProductBatch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productBatchServiceClient) ReportProducts(ctx context.Context, in *ReportProductsRequest, opts ...grpc.CallOption) (ProductBatchService_ReportProductsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ProductBatchService_ServiceDesc.Streams[0], ProductBatchService_ReportProducts_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &productBatchServiceReportProductsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ProductBatchService_ReportProductsClient interface {
+	Recv() (*SectionProductsReport, error)
+	grpc.ClientStream
+}
+
+type productBatchServiceReportProductsClient struct {
+	grpc.ClientStream
+}
+
+func (x *productBatchServiceReportProductsClient) Recv() (*SectionProductsReport, error) {
+	m := new(SectionProductsReport)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ProductBatchServiceServer is the server API for ProductBatchService service.
+// All implementations must embed UnimplementedProductBatchServiceServer
+// for forward compatibility
+type ProductBatchServiceServer interface {
+	// CreateProductBatch fails with ALREADY_EXISTS when the batch number is
+	// taken.
+	CreateProductBatch(context.Context, *CreateProductBatchRequest) (*ProductBatch, error)
+	// ReportProducts streams how many products each of the given sections
+	// holds, or every section holding batches when none is given.
+	ReportProducts(*ReportProductsRequest, ProductBatchService_ReportProductsServer) error
+	mustEmbedUnimplementedProductBatchServiceServer()
+}
+
+// UnimplementedProductBatchServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedProductBatchServiceServer struct {
+}
+
+func (UnimplementedProductBatchServiceServer) CreateProductBatch(context.Context, *CreateProductBatchRequest) (*ProductBatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProductBatch not implemented")
+}
+func (UnimplementedProductBatchServiceServer) ReportProducts(*ReportProductsRequest, ProductBatchService_ReportProductsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReportProducts not implemented")
+}
+func (UnimplementedProductBatchServiceServer) mustEmbedUnimplementedProductBatchServiceServer() {}
+
+// UnsafeProductBatchServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProductBatchServiceServer will
+// result in compilation errors.
+type UnsafeProductBatchServiceServer interface {
+	mustEmbedUnimplementedProductBatchServiceServer()
+}
+
+func RegisterProductBatchServiceServer(s grpc.ServiceRegistrar, srv ProductBatchServiceServer) {
+	s.RegisterService(&ProductBatchService_ServiceDesc, srv)
+}
+
+func _ProductBatchService_CreateProductBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProductBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductBatchServiceServer).CreateProductBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductBatchService_CreateProductBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductBatchServiceServer).CreateProductBatch(ctx, req.(*CreateProductBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductBatchService_ReportProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReportProductsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProductBatchServiceServer).ReportProducts(m, &productBatchServiceReportProductsServer{stream})
+}
+
+type ProductBatchService_ReportProductsServer interface {
+	Send(*SectionProductsReport) error
+	grpc.ServerStream
+}
+
+type productBatchServiceReportProductsServer struct {
+	grpc.ServerStream
+}
+
+func (x *productBatchServiceReportProductsServer) Send(m *SectionProductsReport) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// ProductBatchService_ServiceDesc is the grpc.ServiceDesc for ProductBatchService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ProductBatchService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "melisprint.v1.ProductBatchService",
+	HandlerType: (*ProductBatchServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateProductBatch",
+			Handler:    _ProductBatchService_CreateProductBatch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReportProducts",
+			Handler:       _ProductBatchService_ReportProducts_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "melisprint/v1/product_batch.proto",
+}
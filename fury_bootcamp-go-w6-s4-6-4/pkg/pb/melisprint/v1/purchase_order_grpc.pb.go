@@ -0,0 +1,182 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: melisprint/v1/purchase_order.proto
+
+package melisprintv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	PurchaseOrderService_CreatePurchaseOrder_FullMethodName  = "/melisprint.v1.PurchaseOrderService/CreatePurchaseOrder"
+	PurchaseOrderService_ReportPurchaseOrders_FullMethodName = "/melisprint.v1.PurchaseOrderService/ReportPurchaseOrders"
+)
+
+// PurchaseOrderServiceClient is the client API for PurchaseOrderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PurchaseOrderServiceClient interface {
+	// CreatePurchaseOrder fails with FAILED_PRECONDITION when the buyer or the
+	// product record doesn't exist.
+	CreatePurchaseOrder(ctx context.Context, in *CreatePurchaseOrderRequest, opts ...grpc.CallOption) (*PurchaseOrder, error)
+	// ReportPurchaseOrders streams how many orders each buyer placed, or only
+	// the given buyer when buyer_id is set.
+	ReportPurchaseOrders(ctx context.Context, in *ReportPurchaseOrdersRequest, opts ...grpc.CallOption) (PurchaseOrderService_ReportPurchaseOrdersClient, error)
+}
+
+type purchaseOrderServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPurchaseOrderServiceClient(cc grpc.ClientConnInterface) PurchaseOrderServiceClient {
+	return &purchaseOrderServiceClient{cc}
+}
+
+func (c *purchaseOrderServiceClient) CreatePurchaseOrder(ctx context.Context, in *CreatePurchaseOrderRequest, opts ...grpc.CallOption) (*PurchaseOrder, error) {
+	out := new(PurchaseOrder)
+	err := c.cc.Invoke(ctx, PurchaseOrderService_CreatePurchaseOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purchaseOrderServiceClient) ReportPurchaseOrders(ctx context.Context, in *ReportPurchaseOrdersRequest, opts ...grpc.CallOption) (PurchaseOrderService_ReportPurchaseOrdersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PurchaseOrderService_ServiceDesc.Streams[0], PurchaseOrderService_ReportPurchaseOrders_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &purchaseOrderServiceReportPurchaseOrdersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PurchaseOrderService_ReportPurchaseOrdersClient interface {
+	Recv() (*PurchaseOrdersReport, error)
+	grpc.ClientStream
+}
+
+type purchaseOrderServiceReportPurchaseOrdersClient struct {
+	grpc.ClientStream
+}
+
+func (x *purchaseOrderServiceReportPurchaseOrdersClient) Recv() (*PurchaseOrdersReport, error) {
+	m := new(PurchaseOrdersReport)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PurchaseOrderServiceServer is the server API for PurchaseOrderService service.
+// All implementations must embed UnimplementedPurchaseOrderServiceServer
+// for forward compatibility
+type PurchaseOrderServiceServer interface {
+	// CreatePurchaseOrder fails with FAILED_PRECONDITION when the buyer or the
+	// product record doesn't exist.
+	CreatePurchaseOrder(context.Context, *CreatePurchaseOrderRequest) (*PurchaseOrder, error)
+	// ReportPurchaseOrders streams how many orders each buyer placed, or only
+	// the given buyer when buyer_id is set.
+	ReportPurchaseOrders(*ReportPurchaseOrdersRequest, PurchaseOrderService_ReportPurchaseOrdersServer) error
+	mustEmbedUnimplementedPurchaseOrderServiceServer()
+}
+
+// UnimplementedPurchaseOrderServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPurchaseOrderServiceServer struct {
+}
+
+func (UnimplementedPurchaseOrderServiceServer) CreatePurchaseOrder(context.Context, *CreatePurchaseOrderRequest) (*PurchaseOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePurchaseOrder not implemented")
+}
+func (UnimplementedPurchaseOrderServiceServer) ReportPurchaseOrders(*ReportPurchaseOrdersRequest, PurchaseOrderService_ReportPurchaseOrdersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReportPurchaseOrders not implemented")
+}
+func (UnimplementedPurchaseOrderServiceServer) mustEmbedUnimplementedPurchaseOrderServiceServer() {}
+
+// UnsafePurchaseOrderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PurchaseOrderServiceServer will
+// result in compilation errors.
+type UnsafePurchaseOrderServiceServer interface {
+	mustEmbedUnimplementedPurchaseOrderServiceServer()
+}
+
+func RegisterPurchaseOrderServiceServer(s grpc.ServiceRegistrar, srv PurchaseOrderServiceServer) {
+	s.RegisterService(&PurchaseOrderService_ServiceDesc, srv)
+}
+
+func _PurchaseOrderService_CreatePurchaseOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePurchaseOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurchaseOrderServiceServer).CreatePurchaseOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PurchaseOrderService_CreatePurchaseOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurchaseOrderServiceServer).CreatePurchaseOrder(ctx, req.(*CreatePurchaseOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PurchaseOrderService_ReportPurchaseOrders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReportPurchaseOrdersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PurchaseOrderServiceServer).ReportPurchaseOrders(m, &purchaseOrderServiceReportPurchaseOrdersServer{stream})
+}
+
+type PurchaseOrderService_ReportPurchaseOrdersServer interface {
+	Send(*PurchaseOrdersReport) error
+	grpc.ServerStream
+}
+
+type purchaseOrderServiceReportPurchaseOrdersServer struct {
+	grpc.ServerStream
+}
+
+func (x *purchaseOrderServiceReportPurchaseOrdersServer) Send(m *PurchaseOrdersReport) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// PurchaseOrderService_ServiceDesc is the grpc.ServiceDesc for PurchaseOrderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PurchaseOrderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "melisprint.v1.PurchaseOrderService",
+	HandlerType: (*PurchaseOrderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreatePurchaseOrder",
+			Handler:    _PurchaseOrderService_CreatePurchaseOrder_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReportPurchaseOrders",
+			Handler:       _PurchaseOrderService_ReportPurchaseOrders_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "melisprint/v1/purchase_order.proto",
+}
@@ -0,0 +1,330 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: melisprint/v1/section.proto
+
+package melisprintv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	SectionService_ListSections_FullMethodName      = "/melisprint.v1.SectionService/ListSections"
+	SectionService_GetSection_FullMethodName        = "/melisprint.v1.SectionService/GetSection"
+	SectionService_CreateSection_FullMethodName     = "/melisprint.v1.SectionService/CreateSection"
+	SectionService_UpdateSection_FullMethodName     = "/melisprint.v1.SectionService/UpdateSection"
+	SectionService_DeleteSection_FullMethodName     = "/melisprint.v1.SectionService/DeleteSection"
+	SectionService_WatchTemperatures_FullMethodName = "/melisprint.v1.SectionService/WatchTemperatures"
+)
+
+// SectionServiceClient is the client API for SectionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SectionServiceClient interface {
+	ListSections(ctx context.Context, in *ListSectionsRequest, opts ...grpc.CallOption) (*ListSectionsResponse, error)
+	GetSection(ctx context.Context, in *GetSectionRequest, opts ...grpc.CallOption) (*Section, error)
+	// CreateSection fails with ALREADY_EXISTS when the section number is taken.
+	CreateSection(ctx context.Context, in *CreateSectionRequest, opts ...grpc.CallOption) (*Section, error)
+	// UpdateSection changes the fields that aren't zero and keeps the rest.
+	UpdateSection(ctx context.Context, in *UpdateSectionRequest, opts ...grpc.CallOption) (*Section, error)
+	DeleteSection(ctx context.Context, in *DeleteSectionRequest, opts ...grpc.CallOption) (*DeleteSectionResponse, error)
+	// WatchTemperatures streams a reading of the temperature of a section every
+	// interval, starting right away, until the client cancels.
+	WatchTemperatures(ctx context.Context, in *WatchTemperaturesRequest, opts ...grpc.CallOption) (SectionService_WatchTemperaturesClient, error)
+}
+
+type sectionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSectionServiceClient(cc grpc.ClientConnInterface) SectionServiceClient {
+	return &sectionServiceClient{cc}
+}
+
+func (c *sectionServiceClient) ListSections(ctx context.Context, in *ListSectionsRequest, opts ...grpc.CallOption) (*ListSectionsResponse, error) {
+	out := new(ListSectionsResponse)
+	err := c.cc.Invoke(ctx, SectionService_ListSections_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sectionServiceClient) GetSection(ctx context.Context, in *GetSectionRequest, opts ...grpc.CallOption) (*Section, error) {
+	out := new(Section)
+	err := c.cc.Invoke(ctx, SectionService_GetSection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sectionServiceClient) CreateSection(ctx context.Context, in *CreateSectionRequest, opts ...grpc.CallOption) (*Section, error) {
+	out := new(Section)
+	err := c.cc.Invoke(ctx, SectionService_CreateSection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sectionServiceClient) UpdateSection(ctx context.Context, in *UpdateSectionRequest, opts ...grpc.CallOption) (*Section, error) {
+	out := new(Section)
+	err := c.cc.Invoke(ctx, SectionService_UpdateSection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sectionServiceClient) DeleteSection(ctx context.Context, in *DeleteSectionRequest, opts ...grpc.CallOption) (*DeleteSectionResponse, error) {
+	out := new(DeleteSectionResponse)
+	err := c.cc.Invoke(ctx, SectionService_DeleteSection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sectionServiceClient) WatchTemperatures(ctx context.Context, in *WatchTemperaturesRequest, opts ...grpc.CallOption) (SectionService_WatchTemperaturesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SectionService_ServiceDesc.Streams[0], SectionService_WatchTemperatures_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sectionServiceWatchTemperaturesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SectionService_WatchTemperaturesClient interface {
+	Recv() (*TemperatureReading, error)
+	grpc.ClientStream
+}
+
+type sectionServiceWatchTemperaturesClient struct {
+	grpc.ClientStream
+}
+
+func (x *sectionServiceWatchTemperaturesClient) Recv() (*TemperatureReading, error) {
+	m := new(TemperatureReading)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SectionServiceServer is the server API for SectionService service.
+// All implementations must embed UnimplementedSectionServiceServer
+// for forward compatibility
+type SectionServiceServer interface {
+	ListSections(context.Context, *ListSectionsRequest) (*ListSectionsResponse, error)
+	GetSection(context.Context, *GetSectionRequest) (*Section, error)
+	// CreateSection fails with ALREADY_EXISTS when the section number is taken.
+	CreateSection(context.Context, *CreateSectionRequest) (*Section, error)
+	// UpdateSection changes the fields that aren't zero and keeps the rest.
+	UpdateSection(context.Context, *UpdateSectionRequest) (*Section, error)
+	DeleteSection(context.Context, *DeleteSectionRequest) (*DeleteSectionResponse, error)
+	// WatchTemperatures streams a reading of the temperature of a section every
+	// interval, starting right away, until the client cancels.
+	WatchTemperatures(*WatchTemperaturesRequest, SectionService_WatchTemperaturesServer) error
+	mustEmbedUnimplementedSectionServiceServer()
+}
+
+// UnimplementedSectionServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSectionServiceServer struct {
+}
+
+func (UnimplementedSectionServiceServer) ListSections(context.Context, *ListSectionsRequest) (*ListSectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSections not implemented")
+}
+func (UnimplementedSectionServiceServer) GetSection(context.Context, *GetSectionRequest) (*Section, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSection not implemented")
+}
+func (UnimplementedSectionServiceServer) CreateSection(context.Context, *CreateSectionRequest) (*Section, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSection not implemented")
+}
+func (UnimplementedSectionServiceServer) UpdateSection(context.Context, *UpdateSectionRequest) (*Section, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSection not implemented")
+}
+func (UnimplementedSectionServiceServer) DeleteSection(context.Context, *DeleteSectionRequest) (*DeleteSectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSection not implemented")
+}
+func (UnimplementedSectionServiceServer) WatchTemperatures(*WatchTemperaturesRequest, SectionService_WatchTemperaturesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTemperatures not implemented")
+}
+func (UnimplementedSectionServiceServer) mustEmbedUnimplementedSectionServiceServer() {}
+
+// UnsafeSectionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SectionServiceServer will
+// result in compilation errors.
+type UnsafeSectionServiceServer interface {
+	mustEmbedUnimplementedSectionServiceServer()
+}
+
+func RegisterSectionServiceServer(s grpc.ServiceRegistrar, srv SectionServiceServer) {
+	s.RegisterService(&SectionService_ServiceDesc, srv)
+}
+
+func _SectionService_ListSections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SectionServiceServer).ListSections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SectionService_ListSections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SectionServiceServer).ListSections(ctx, req.(*ListSectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SectionService_GetSection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SectionServiceServer).GetSection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SectionService_GetSection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SectionServiceServer).GetSection(ctx, req.(*GetSectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SectionService_CreateSection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SectionServiceServer).CreateSection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SectionService_CreateSection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SectionServiceServer).CreateSection(ctx, req.(*CreateSectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SectionService_UpdateSection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SectionServiceServer).UpdateSection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SectionService_UpdateSection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SectionServiceServer).UpdateSection(ctx, req.(*UpdateSectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SectionService_DeleteSection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SectionServiceServer).DeleteSection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SectionService_DeleteSection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SectionServiceServer).DeleteSection(ctx, req.(*DeleteSectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SectionService_WatchTemperatures_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTemperaturesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SectionServiceServer).WatchTemperatures(m, &sectionServiceWatchTemperaturesServer{stream})
+}
+
+type SectionService_WatchTemperaturesServer interface {
+	Send(*TemperatureReading) error
+	grpc.ServerStream
+}
+
+type sectionServiceWatchTemperaturesServer struct {
+	grpc.ServerStream
+}
+
+func (x *sectionServiceWatchTemperaturesServer) Send(m *TemperatureReading) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// SectionService_ServiceDesc is the grpc.ServiceDesc for SectionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SectionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "melisprint.v1.SectionService",
+	HandlerType: (*SectionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSections",
+			Handler:    _SectionService_ListSections_Handler,
+		},
+		{
+			MethodName: "GetSection",
+			Handler:    _SectionService_GetSection_Handler,
+		},
+		{
+			MethodName: "CreateSection",
+			Handler:    _SectionService_CreateSection_Handler,
+		},
+		{
+			MethodName: "UpdateSection",
+			Handler:    _SectionService_UpdateSection_Handler,
+		},
+		{
+			MethodName: "DeleteSection",
+			Handler:    _SectionService_DeleteSection_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTemperatures",
+			Handler:       _SectionService_WatchTemperatures_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "melisprint/v1/section.proto",
+}
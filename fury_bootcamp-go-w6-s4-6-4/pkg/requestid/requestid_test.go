@@ -0,0 +1,32 @@
+package requestid
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext(t *testing.T) {
+	ctx := NewContext(context.Background(), "abc-123")
+
+	assert.Equal(t, "abc-123", FromContext(ctx))
+	assert.Equal(t, "", FromContext(context.Background()))
+}
+
+func TestNew(t *testing.T) {
+	id := New()
+
+	assert.Len(t, id, 32)
+	assert.True(t, Valid(id))
+	assert.NotEqual(t, id, New())
+}
+
+func TestValid(t *testing.T) {
+	assert.True(t, Valid("3f2a9c1e-client-id"))
+	assert.False(t, Valid(""))
+	assert.False(t, Valid("with space"))
+	assert.False(t, Valid("line\nbreak"))
+	assert.False(t, Valid(strings.Repeat("a", maxLength+1)))
+}
@@ -0,0 +1,111 @@
+// Package tenant carries the site, the country operation of the marketplace
+// (MLA, MLB, ...), a request acts on. Every row belongs to a site and the
+// repositories only see the rows of the site in the context, so operations
+// sharing a deployment can't read or reference each other's data.
+package tenant
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+const (
+	// Header is the HTTP header with the site of the request. The gateway
+	// sets it from the site claim of the caller's token.
+	Header = "X-Site-ID"
+	// RolesHeader is the HTTP header with the caller's roles, comma
+	// separated, also set by the gateway.
+	RolesHeader = "X-User-Roles"
+	// AdminRole may act on every site at once.
+	AdminRole = "admin"
+	// SupervisorRole may approve the inventory adjustments of a cycle count.
+	SupervisorRole = "supervisor"
+	// Wildcard is the site an admin asks for to read across sites.
+	Wildcard = "*"
+)
+
+var (
+	// ErrSiteRequired is returned by writes made without a single site,
+	// since the rows they create must belong to one.
+	ErrSiteRequired = apperrors.Validation("a single site is required to write")
+	// ErrAllSites is returned when a caller that isn't an admin asks for
+	// every site.
+	ErrAllSites = apperrors.Forbidden("only admins can act on every site")
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx scoped to site, which may be Wildcard.
+func NewContext(ctx context.Context, site string) context.Context {
+	return context.WithValue(ctx, contextKey{}, site)
+}
+
+// FromContext returns the site ctx is scoped to, or "" when it isn't.
+func FromContext(ctx context.Context) string {
+	site, _ := ctx.Value(contextKey{}).(string)
+	return site
+}
+
+// AllSites returns a copy of ctx scoped to every site, for the background
+// jobs that act on all of them on purpose rather than on behalf of a
+// request.
+func AllSites(ctx context.Context) context.Context {
+	return NewContext(ctx, Wildcard)
+}
+
+// Filter returns the site to filter the rows read with ctx by, meant for
+// "site_id = COALESCE(?, site_id)". It's NULL, which matches every site,
+// only when ctx is scoped to all of them. When ctx isn't scoped at all it's
+// a site no row belongs to, so a read that missed its scope finds nothing
+// instead of the rows of every site.
+func Filter(ctx context.Context) sql.NullString {
+	site := FromContext(ctx)
+	if site == Wildcard {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: site, Valid: true}
+}
+
+// Site returns the single site the rows written with ctx belong to.
+func Site(ctx context.Context) (string, error) {
+	site := FromContext(ctx)
+	if site == "" || site == Wildcard {
+		return "", ErrSiteRequired
+	}
+	return site, nil
+}
+
+// Resolve returns the site a request asking for site on behalf of a caller
+// with roles acts on: defaultSite when it doesn't ask for one, Wildcard
+// only for admins, or else one of sites.
+func Resolve(site, roles, defaultSite string, sites []string) (string, error) {
+	site = strings.ToUpper(strings.TrimSpace(site))
+	switch site {
+	case "":
+		return defaultSite, nil
+	case Wildcard:
+		if !HasRole(roles, AdminRole) {
+			return "", ErrAllSites
+		}
+		return Wildcard, nil
+	}
+	for _, s := range sites {
+		if s == site {
+			return site, nil
+		}
+	}
+	return "", apperrors.Validation("unknown site " + site)
+}
+
+// HasRole reports whether the comma separated roles have role.
+func HasRole(roles, role string) bool {
+	for _, r := range strings.Split(roles, ",") {
+		if strings.EqualFold(strings.TrimSpace(r), role) {
+			return true
+		}
+	}
+	return false
+}
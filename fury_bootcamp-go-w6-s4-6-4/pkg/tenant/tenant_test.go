@@ -0,0 +1,63 @@
+package tenant
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	sites := []string{"MLA", "MLB"}
+	cases := []struct {
+		name  string
+		site  string
+		roles string
+		want  string
+		kind  error
+	}{
+		{"default site", "", "", "MLA", nil},
+		{"known site", " mlb ", "", "MLB", nil},
+		{"unknown site", "MLM", "", "", apperrors.ErrValidation},
+		{"all sites for an admin", "*", "operator, Admin", Wildcard, nil},
+		{"all sites for anyone else", "*", "operator", "", apperrors.ErrForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			site, err := Resolve(tc.site, tc.roles, "MLA", sites)
+
+			assert.Equal(t, tc.want, site)
+			assert.Equal(t, tc.kind, apperrors.KindOf(err))
+		})
+	}
+}
+
+func TestScope(t *testing.T) {
+	t.Run("should filter by a single site", func(t *testing.T) {
+		ctx := NewContext(context.Background(), "MLB")
+
+		site, err := Site(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "MLB", site)
+		assert.Equal(t, sql.NullString{String: "MLB", Valid: true}, Filter(ctx))
+	})
+	t.Run("should not filter across sites nor write there", func(t *testing.T) {
+		ctx := AllSites(context.Background())
+
+		_, err := Site(ctx)
+
+		assert.ErrorIs(t, err, ErrSiteRequired)
+		assert.False(t, Filter(ctx).Valid)
+	})
+	t.Run("should filter out every site without one", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := Site(ctx)
+
+		assert.ErrorIs(t, err, ErrSiteRequired)
+		assert.Equal(t, sql.NullString{Valid: true}, Filter(ctx))
+	})
+}
@@ -0,0 +1,68 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+// statusClientClosedRequest is the non-standard status logged for requests
+// whose client went away before the response was written.
+const statusClientClosedRequest = 499
+
+// errorStatus maps each error kind to its HTTP status, response code and
+// problem title.
+var errorStatus = []struct {
+	kind   error
+	status int
+	code   string
+	title  string
+}{
+	{apperrors.ErrNotFound, http.StatusNotFound, "not_found", "Resource not found"},
+	{apperrors.ErrConflict, http.StatusConflict, "conflict", "Resource already exists"},
+	{apperrors.ErrValidation, http.StatusUnprocessableEntity, "validation_error", "Invalid request"},
+	{apperrors.ErrForeignKeyViolation, http.StatusConflict, "foreign_key_violation", "Referenced resource does not exist"},
+	{apperrors.ErrReferenced, http.StatusConflict, "still_referenced", "Resource is still referenced"},
+	{apperrors.ErrUnavailable, http.StatusServiceUnavailable, "service_unavailable", "Service unavailable"},
+	{apperrors.ErrForbidden, http.StatusForbidden, "forbidden", "Forbidden"},
+	{apperrors.ErrUnauthorized, http.StatusUnauthorized, "unauthorized", "Unauthorized"},
+	{context.DeadlineExceeded, http.StatusGatewayTimeout, "timeout", "Request timed out"},
+	{context.Canceled, statusClientClosedRequest, "client_closed_request", "Client closed request"},
+}
+
+// ErrorHandler renders the last error attached to the request with c.Error,
+// unless the handler already wrote a response.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		renderProblem(c, problemFor(c, c.Errors.Last().Err))
+	}
+}
+
+// problemFor returns the problem describing err. Errors without a kind are
+// internal server errors, whose detail only points to the request: their
+// cause is logged with the request by Logger, never sent to the client.
+func problemFor(c *gin.Context, err error) Problem {
+	for _, e := range errorStatus {
+		if errors.Is(err, e.kind) {
+			p := newProblem(c, e.status, err.Error())
+			p.Type = problemTypeBase + e.code
+			p.Title = e.title
+			p.Code = e.code
+			return p
+		}
+	}
+	p := newProblem(c, http.StatusInternalServerError, "An unexpected error occurred.")
+	if p.RequestID != "" {
+		p.Detail += " Quote request " + p.RequestID + " when reporting it."
+	}
+	return p
+}
@@ -0,0 +1,118 @@
+package web
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ETag tags the successful responses to GET requests on the routes in
+// routes with the hash of their body and the Cache-Control set for the
+// route. A request whose If-None-Match has the tag gets 304 Not Modified
+// without the body, so polling an unchanged report costs no transfer.
+//
+// The response is held until the handler is done, so routes must not
+// stream. routes is read on every request, so it may be filled after the
+// middleware is installed, as long as that happens before the server
+// starts.
+func ETag(routes map[string]string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cacheControl, ok := routes[c.FullPath()]
+		if !ok || c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		w := &bufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		if !w.written {
+			if w.status != 0 {
+				c.Writer.WriteHeader(w.status)
+			}
+			return
+		}
+		if w.Status() != http.StatusOK {
+			c.Writer.WriteHeader(w.Status())
+			c.Writer.Write(w.body.Bytes())
+			return
+		}
+
+		sum := sha256.Sum256(w.body.Bytes())
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+		c.Header("ETag", etag)
+		c.Header("Cache-Control", cacheControl)
+		if matchesETag(c.GetHeader("If-None-Match"), etag) {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.WriteHeader(http.StatusNotModified)
+			c.Writer.WriteHeaderNow()
+			return
+		}
+		c.Writer.WriteHeader(http.StatusOK)
+		c.Writer.Write(w.body.Bytes())
+	}
+}
+
+// matchesETag reports whether the If-None-Match header has etag, comparing
+// weakly as RFC 9110 asks for it.
+func matchesETag(ifNoneMatch, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == etag || tag == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedWriter holds the response instead of sending it.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status  int
+	written bool
+	body    bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if !w.written {
+		w.status = code
+	}
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.body.Write(b)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.written
+}
+
+func (w *bufferedWriter) Size() int {
+	return w.body.Len()
+}
+
+// Flush does nothing: the response is only sent once it's complete.
+func (w *bufferedWriter) Flush() {}
@@ -0,0 +1,82 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	count := 1
+	r := gin.New()
+	r.Use(ETag(map[string]string{
+		"/report":         "private, no-cache",
+		"/missing_report": "private, no-cache",
+	}), ErrorHandler())
+	r.GET("/report", func(c *gin.Context) {
+		Success(c, http.StatusOK, gin.H{"count": count})
+	})
+	r.GET("/missing_report", func(c *gin.Context) {
+		c.Error(apperrors.NotFound("employee not found"))
+	})
+	r.GET("/sellers", func(c *gin.Context) {
+		Success(c, http.StatusOK, gin.H{})
+	})
+	get := func(target, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("should tag the response", func(t *testing.T) {
+		w := get("/report", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"data":{"count":1}}`, w.Body.String())
+		assert.NotEmpty(t, w.Header().Get("ETag"))
+		assert.Equal(t, "private, no-cache", w.Header().Get("Cache-Control"))
+	})
+	t.Run("should answer 304 while the body is the same", func(t *testing.T) {
+		etag := get("/report", "").Header().Get("ETag")
+
+		w := get("/report", `"other", W/`+etag)
+
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.Equal(t, etag, w.Header().Get("ETag"))
+	})
+	t.Run("should send the body once it changes", func(t *testing.T) {
+		etag := get("/report", "").Header().Get("ETag")
+		count++
+		defer func() { count-- }()
+
+		w := get("/report", etag)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"data":{"count":2}}`, w.Body.String())
+		assert.NotEqual(t, etag, w.Header().Get("ETag"))
+	})
+	t.Run("should not tag errors", func(t *testing.T) {
+		w := get("/missing_report", "*")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Empty(t, w.Header().Get("ETag"))
+		assert.Contains(t, w.Body.String(), "employee not found")
+	})
+	t.Run("should not tag the other routes", func(t *testing.T) {
+		w := get("/sellers", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("ETag"))
+	})
+}
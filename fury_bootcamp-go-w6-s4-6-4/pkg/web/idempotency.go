@@ -0,0 +1,199 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+)
+
+const (
+	// HeaderIdempotencyKey makes a POST request safe to retry: the response
+	// to the first request with a key is replayed to the later ones.
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed marks a replayed response.
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+// maxIdempotencyKeyLength is the room idempotency_keys has for a key.
+const maxIdempotencyKeyLength = 255
+
+// idempotencyStoreTimeout bounds storing the outcome of a request, which
+// happens even when the request's own deadline is over.
+const idempotencyStoreTimeout = 2 * time.Second
+
+// IdempotentResponse is what's kept for an idempotency key: the hash of the
+// request that claimed it and, once it's answered, its response.
+type IdempotentResponse struct {
+	RequestHash string
+	// Status is 0 while the request is in progress.
+	Status      int
+	ContentType string
+	Body        []byte
+}
+
+// IdempotencyStore keeps the responses to the requests made with an
+// idempotency key, per client.
+type IdempotencyStore interface {
+	// Claim reserves key for the request hashed as requestHash until
+	// expiresAt. When the key is already reserved and not expired at now,
+	// it returns what's kept for it instead, with claimed false.
+	Claim(ctx context.Context, client, key, requestHash string, now, expiresAt time.Time) (stored IdempotentResponse, claimed bool, err error)
+	// Complete keeps the response to the request that claimed key.
+	Complete(ctx context.Context, client, key string, r IdempotentResponse) error
+	// Release forgets key, so the request can be made again.
+	Release(ctx context.Context, client, key string) error
+}
+
+// Client returns who makes the request: the user the gateway authenticated,
+// or else the client IP. It's never a value the client picks, so a client
+// can't pass for another or get a fresh quota by changing it.
+func Client(c *gin.Context) string {
+	if user := c.GetHeader(UserHeader); user != "" {
+		return "user:" + user
+	}
+	return c.ClientIP()
+}
+
+// Idempotency replays the response to a POST request made with an
+// Idempotency-Key the client already used within ttl, instead of serving
+// it again. A key reused for a different request is unprocessable, and one
+// whose request is still in progress is a conflict. Server errors and
+// panics aren't kept, so the request can be retried. Bodies are read whole
+// to hash them, so one larger than maxBody is too large.
+//
+// It must come before ErrorHandler, to keep the errors it renders, and
+// after Tenant.
+func Idempotency(store IdempotencyStore, ttl time.Duration, maxBody int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(HeaderIdempotencyKey)
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			Error(c, http.StatusBadRequest, "%s must not be longer than %d characters", HeaderIdempotencyKey, maxIdempotencyKeyLength)
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBody))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				Error(c, http.StatusRequestEntityTooLarge, "the body of a request with an %s must not be larger than %d bytes", HeaderIdempotencyKey, maxBody)
+			} else {
+				Error(c, http.StatusBadRequest, "reading the body: %s", err)
+			}
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		hash := requestHash(tenant.FromContext(c.Request.Context()), c.Request.Method, c.Request.URL.RequestURI(), body)
+
+		client := Client(c)
+		now := time.Now().UTC()
+		stored, claimed, err := store.Claim(c.Request.Context(), client, key, hash, now, now.Add(ttl))
+		if err != nil {
+			renderProblem(c, problemFor(c, err))
+			c.Abort()
+			return
+		}
+		if !claimed {
+			replay(c, stored, hash)
+			c.Abort()
+			return
+		}
+
+		rec := &recorder{ResponseWriter: c.Writer}
+		c.Writer = rec
+		defer func() {
+			// A panic leaves no response to keep, and Recovery answers it
+			// outside this middleware.
+			if v := recover(); v != nil {
+				c.Writer = rec.ResponseWriter
+				ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), idempotencyStoreTimeout)
+				defer cancel()
+				if err := store.Release(ctx, client, key); err != nil {
+					c.Error(err)
+				}
+				panic(v)
+			}
+		}()
+		c.Next()
+		c.Writer = rec.ResponseWriter
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), idempotencyStoreTimeout)
+		defer cancel()
+		if status := rec.Status(); status >= http.StatusInternalServerError || status == statusClientClosedRequest {
+			err = store.Release(ctx, client, key)
+		} else {
+			err = store.Complete(ctx, client, key, IdempotentResponse{
+				RequestHash: hash,
+				Status:      status,
+				ContentType: rec.Header().Get("Content-Type"),
+				Body:        rec.body.Bytes(),
+			})
+		}
+		if err != nil {
+			// The response is already sent; a retry gets a conflict until
+			// the key expires.
+			c.Error(err)
+		}
+	}
+}
+
+// replay answers with the response kept for the key, when the request is
+// the one that claimed it.
+func replay(c *gin.Context, stored IdempotentResponse, hash string) {
+	if stored.RequestHash != hash {
+		p := newProblem(c, http.StatusUnprocessableEntity, HeaderIdempotencyKey+" was already used for a different request")
+		p.Type = problemTypeBase + "idempotency_key_reused"
+		p.Title = "Idempotency key reused"
+		p.Code = "idempotency_key_reused"
+		renderProblem(c, p)
+		return
+	}
+	if stored.Status == 0 {
+		p := newProblem(c, http.StatusConflict, "the request with this "+HeaderIdempotencyKey+" is still in progress")
+		p.Type = problemTypeBase + "idempotency_key_in_use"
+		p.Title = "Idempotency key in use"
+		p.Code = "idempotency_key_in_use"
+		renderProblem(c, p)
+		return
+	}
+	c.Header(HeaderIdempotentReplayed, "true")
+	c.Data(stored.Status, stored.ContentType, stored.Body)
+}
+
+// requestHash identifies a request by its site too, so a key reused on
+// another site is a different request rather than a replay of the first.
+func requestHash(site, method, uri string, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, site+" "+method+" "+uri+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recorder keeps a copy of the body written.
+type recorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *recorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
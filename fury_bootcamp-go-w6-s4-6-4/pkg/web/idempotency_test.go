@@ -0,0 +1,195 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStore is an IdempotencyStore in memory.
+type memoryStore struct {
+	mu        sync.Mutex
+	responses map[string]IdempotentResponse
+	expires   map[string]time.Time
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{responses: map[string]IdempotentResponse{}, expires: map[string]time.Time{}}
+}
+
+func (m *memoryStore) Claim(ctx context.Context, client, key, requestHash string, now, expiresAt time.Time) (IdempotentResponse, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id := client + "/" + key
+	if stored, ok := m.responses[id]; ok && now.Before(m.expires[id]) {
+		return stored, false, nil
+	}
+	m.responses[id] = IdempotentResponse{RequestHash: requestHash}
+	m.expires[id] = expiresAt
+	return IdempotentResponse{}, true, nil
+}
+
+func (m *memoryStore) Complete(ctx context.Context, client, key string, r IdempotentResponse) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.responses[client+"/"+key] = r
+	return nil
+}
+
+func (m *memoryStore) Release(ctx context.Context, client, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.responses, client+"/"+key)
+	return nil
+}
+
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newServer := func(store IdempotencyStore) (*gin.Engine, *int) {
+		served := 0
+		r := gin.New()
+		r.Use(gin.Recovery(), Idempotency(store, time.Hour, 64), ErrorHandler())
+		r.POST("/orders", func(c *gin.Context) {
+			served++
+			Success(c, http.StatusCreated, gin.H{"id": served})
+		})
+		r.POST("/duplicates", func(c *gin.Context) {
+			served++
+			c.Error(apperrors.Conflict("order_number already exists"))
+		})
+		r.POST("/failures", func(c *gin.Context) {
+			served++
+			c.Error(apperrors.Unavailable("database unavailable"))
+		})
+		r.POST("/panics", func(c *gin.Context) {
+			served++
+			panic("nil map")
+		})
+		return r, &served
+	}
+	post := func(r *gin.Engine, target, key, client, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set(HeaderIdempotencyKey, key)
+		}
+		if client != "" {
+			req.Header.Set(UserHeader, client)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	problemCode := func(w *httptest.ResponseRecorder) string {
+		var p Problem
+		json.Unmarshal(w.Body.Bytes(), &p)
+		return p.Code
+	}
+
+	t.Run("should replay the first response to a retry", func(t *testing.T) {
+		r, served := newServer(newMemoryStore())
+
+		first := post(r, "/orders", "k1", "", `{"order_number":"PO-1"}`)
+		retry := post(r, "/orders", "k1", "", `{"order_number":"PO-1"}`)
+
+		assert.Equal(t, 1, *served)
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, first.Body.String(), retry.Body.String())
+		assert.Equal(t, first.Header().Get("Content-Type"), retry.Header().Get("Content-Type"))
+		assert.Equal(t, "true", retry.Header().Get(HeaderIdempotentReplayed))
+		assert.Empty(t, first.Header().Get(HeaderIdempotentReplayed))
+	})
+	t.Run("should replay the errors rendered", func(t *testing.T) {
+		r, served := newServer(newMemoryStore())
+
+		post(r, "/duplicates", "k1", "", `{}`)
+		retry := post(r, "/duplicates", "k1", "", `{}`)
+
+		assert.Equal(t, 1, *served)
+		assert.Equal(t, http.StatusConflict, retry.Code)
+		assert.Equal(t, "conflict", problemCode(retry))
+	})
+	t.Run("should reject a key reused for a different request", func(t *testing.T) {
+		r, served := newServer(newMemoryStore())
+
+		post(r, "/orders", "k1", "", `{"order_number":"PO-1"}`)
+		w := post(r, "/orders", "k1", "", `{"order_number":"PO-2"}`)
+
+		assert.Equal(t, 1, *served)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, "idempotency_key_reused", problemCode(w))
+	})
+	t.Run("should reject a retry while the request is in progress", func(t *testing.T) {
+		store := newMemoryStore()
+		r, served := newServer(store)
+		store.responses["192.0.2.1/k1"] = IdempotentResponse{RequestHash: requestHash("", http.MethodPost, "/orders", []byte(`{}`))}
+		store.expires["192.0.2.1/k1"] = time.Now().Add(time.Hour)
+
+		w := post(r, "/orders", "k1", "", `{}`)
+
+		assert.Equal(t, 0, *served)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, "idempotency_key_in_use", problemCode(w))
+	})
+	t.Run("should let a request that failed be retried", func(t *testing.T) {
+		r, served := newServer(newMemoryStore())
+
+		post(r, "/failures", "k1", "", `{}`)
+		w := post(r, "/failures", "k1", "", `{}`)
+
+		assert.Equal(t, 2, *served)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+	t.Run("should scope keys to the user", func(t *testing.T) {
+		r, served := newServer(newMemoryStore())
+
+		post(r, "/orders", "k1", "41", `{}`)
+		post(r, "/orders", "k1", "42", `{}`)
+
+		assert.Equal(t, 2, *served)
+	})
+	t.Run("should serve every request without a key", func(t *testing.T) {
+		r, served := newServer(newMemoryStore())
+
+		post(r, "/orders", "", "", `{}`)
+		post(r, "/orders", "", "", `{}`)
+
+		assert.Equal(t, 2, *served)
+	})
+	t.Run("should let a request that panicked be retried", func(t *testing.T) {
+		r, served := newServer(newMemoryStore())
+
+		first := post(r, "/panics", "k1", "", `{}`)
+		retry := post(r, "/panics", "k1", "", `{}`)
+
+		assert.Equal(t, http.StatusInternalServerError, first.Code)
+		assert.Equal(t, http.StatusInternalServerError, retry.Code)
+		assert.Equal(t, 2, *served)
+	})
+	t.Run("should reject bodies too large to hash", func(t *testing.T) {
+		r, served := newServer(newMemoryStore())
+
+		w := post(r, "/orders", "k1", "", `{"order_number":"`+strings.Repeat("1", 64)+`"}`)
+
+		assert.Equal(t, 0, *served)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+	t.Run("should reject keys too long to keep", func(t *testing.T) {
+		r, served := newServer(newMemoryStore())
+
+		w := post(r, "/orders", strings.Repeat("k", 256), "", `{}`)
+
+		assert.Equal(t, 0, *served)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
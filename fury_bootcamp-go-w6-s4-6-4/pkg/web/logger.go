@@ -0,0 +1,52 @@
+package web
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+)
+
+// UserHeader is the header the gateway sets with the id of the caller.
+const UserHeader = "X-User-ID"
+
+// Logger attaches the route, the caller and the path parameters of the
+// request to its context, so every record logged while serving it carries
+// them, and logs the request once served.
+func Logger(l *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+		}
+		if user := c.GetHeader(UserHeader); user != "" {
+			attrs = append(attrs, slog.String("user", user))
+		}
+		for _, p := range c.Params {
+			attrs = append(attrs, slog.String(p.Key, p.Value))
+		}
+		ctx := logger.WithAttrs(c.Request.Context(), attrs...)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		level := slog.LevelInfo
+		if c.Writer.Status() >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		record := []slog.Attr{
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", c.Writer.Status()),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if len(c.Errors) > 0 {
+			record = append(record, slog.String("error", c.Errors.Last().Error()))
+		}
+		l.LogAttrs(ctx, level, "request served", record...)
+	}
+}
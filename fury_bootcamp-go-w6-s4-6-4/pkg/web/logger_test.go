@@ -0,0 +1,54 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/requestid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := logger.New(&buf, slog.LevelInfo)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.ContextWithFallback = true
+	r.Use(RequestID(), Logger(l), ErrorHandler())
+	r.DELETE("/sections/:id", func(c *gin.Context) {
+		l.InfoContext(c, "deleting section")
+		c.Error(apperrors.NotFound("section not found"))
+	})
+
+	req := httptest.NewRequest(http.MethodDelete, "/sections/7", nil)
+	req.Header.Set(requestid.Header, "req-1")
+	req.Header.Set(UserHeader, "42")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+
+	for _, line := range lines {
+		rec := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &rec))
+		assert.Equal(t, "req-1", rec["request_id"])
+		assert.Equal(t, "/sections/:id", rec["route"])
+		assert.Equal(t, "7", rec["id"])
+		assert.Equal(t, "42", rec["user"])
+	}
+
+	rec := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &rec))
+	assert.Equal(t, "request served", rec["msg"])
+	assert.Equal(t, float64(http.StatusNotFound), rec["status"])
+	assert.Equal(t, "section not found", rec["error"])
+}
@@ -0,0 +1,89 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/requestid"
+)
+
+// ProblemContentType is the media type of error responses (RFC 7807).
+const ProblemContentType = "application/problem+json"
+
+// problemTypeBase prefixes the type of the problems defined by this API.
+// Errors that only carry an HTTP status use "about:blank" instead.
+const problemTypeBase = "urn:melisprint:problem:"
+
+// Problem is the body of every error response. Code, RequestID and
+// InvalidParams are extension members.
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	Code          string         `json:"code"`
+	RequestID     string         `json:"request_id,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid_params,omitempty"`
+}
+
+// InvalidParam describes a request field that failed validation.
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// newProblem returns a problem of the request in c, identified only by its
+// HTTP status.
+func newProblem(c *gin.Context, status int, detail string) Problem {
+	return Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  c.Request.URL.RequestURI(),
+		Code:      strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_"),
+		RequestID: requestid.FromContext(c.Request.Context()),
+	}
+}
+
+func renderProblem(c *gin.Context, p Problem) {
+	c.Header("Content-Type", ProblemContentType)
+	Response(c, p.Status, p)
+}
+
+// ValidationError renders err, returned while binding the request body, with
+// the fields that failed validation listed in invalid_params.
+func ValidationError(c *gin.Context, status int, err error) {
+	p := newProblem(c, status, err.Error())
+	p.InvalidParams = invalidParams(err)
+	renderProblem(c, p)
+}
+
+func invalidParams(err error) []InvalidParam {
+	var fieldErrs validator.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		params := make([]InvalidParam, 0, len(fieldErrs))
+		for _, fe := range fieldErrs {
+			params = append(params, InvalidParam{
+				Name:   fe.Field(),
+				Reason: fmt.Sprintf("failed on the '%s' rule", fe.Tag()),
+			})
+		}
+		return params
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return []InvalidParam{{
+			Name:   typeErr.Field,
+			Reason: fmt.Sprintf("must be of type %s", typeErr.Type),
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/requestid"
+	"github.com/stretchr/testify/assert"
+)
+
+func createServerProblem() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestID(), ErrorHandler())
+	r.GET("/sections/:id", func(c *gin.Context) {
+		c.Error(apperrors.NotFound("section not found"))
+	})
+	r.DELETE("/sections/:id", func(c *gin.Context) {
+		c.Error(apperrors.FromDB(&mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row"}))
+	})
+	r.GET("/fail", func(c *gin.Context) {
+		c.Error(errors.New("connection refused"))
+	})
+	r.POST("/sections", func(c *gin.Context) {
+		var req struct {
+			SectionNumber int `json:"section_number" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			ValidationError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+		Success(c, http.StatusCreated, req)
+	})
+	return r
+}
+
+func decodeProblem(t *testing.T, rr *httptest.ResponseRecorder) Problem {
+	var p Problem
+	assert.Equal(t, ProblemContentType, rr.Header().Get("Content-Type"))
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &p))
+	return p
+}
+
+func TestErrorHandlerDomainError(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/sections/7", nil)
+	req.Header.Set(requestid.Header, "client-id-1")
+	rr := httptest.NewRecorder()
+
+	createServerProblem().ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "client-id-1", rr.Header().Get(requestid.Header))
+	assert.Equal(t, Problem{
+		Type:      "urn:melisprint:problem:not_found",
+		Title:     "Resource not found",
+		Status:    http.StatusNotFound,
+		Detail:    "section not found",
+		Instance:  "/sections/7",
+		Code:      "not_found",
+		RequestID: "client-id-1",
+	}, decodeProblem(t, rr))
+}
+
+func TestErrorHandlerStillReferenced(t *testing.T) {
+	req := httptest.NewRequest(http.MethodDelete, "/sections/7", nil)
+	req.Header.Set(requestid.Header, "client-id-1")
+	rr := httptest.NewRecorder()
+
+	createServerProblem().ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Equal(t, Problem{
+		Type:      "urn:melisprint:problem:still_referenced",
+		Title:     "Resource is still referenced",
+		Status:    http.StatusConflict,
+		Detail:    "resource is still referenced by other resources",
+		Instance:  "/sections/7",
+		Code:      "still_referenced",
+		RequestID: "client-id-1",
+	}, decodeProblem(t, rr))
+}
+
+func TestErrorHandlerInternalError(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+	rr := httptest.NewRecorder()
+
+	createServerProblem().ServeHTTP(rr, req)
+
+	p := decodeProblem(t, rr)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, "about:blank", p.Type)
+	assert.Equal(t, "internal_server_error", p.Code)
+	assert.Len(t, p.RequestID, 32)
+	assert.Equal(t, p.RequestID, rr.Header().Get(requestid.Header))
+	assert.Equal(t, "An unexpected error occurred. Quote request "+p.RequestID+" when reporting it.", p.Detail)
+}
+
+func TestValidationError(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/sections", strings.NewReader(`{}`))
+	rr := httptest.NewRecorder()
+
+	createServerProblem().ServeHTTP(rr, req)
+
+	p := decodeProblem(t, rr)
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.Equal(t, []InvalidParam{{Name: "SectionNumber", Reason: "failed on the 'required' rule"}}, p.InvalidParams)
+
+	req = httptest.NewRequest(http.MethodPost, "/sections", strings.NewReader(`{"section_number":"one"}`))
+	rr = httptest.NewRecorder()
+
+	createServerProblem().ServeHTTP(rr, req)
+
+	p = decodeProblem(t, rr)
+	assert.Equal(t, []InvalidParam{{Name: "section_number", Reason: "must be of type int"}}, p.InvalidParams)
+}
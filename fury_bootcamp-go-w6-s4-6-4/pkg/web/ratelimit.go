@@ -0,0 +1,195 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// HeaderRateLimitLimit is the number of requests a client can make at
+	// once on the route.
+	HeaderRateLimitLimit = "RateLimit-Limit"
+	// HeaderRateLimitRemaining is how many of them the client has left.
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	// HeaderRateLimitReset is the number of seconds until the client has
+	// them all again.
+	HeaderRateLimitReset = "RateLimit-Reset"
+)
+
+// Limit is the quota of a client on a route: a bucket of Burst tokens, one
+// taken by each request, that refills at Rate tokens per second.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// PerMinute returns the limit of n requests a minute, up to burst at once.
+func PerMinute(n, burst int) Limit {
+	return Limit{Rate: float64(n) / 60, Burst: burst}
+}
+
+// RateLimitGroup is a quota shared by a group of routes: a client's
+// requests to any of them take tokens from the same bucket.
+type RateLimitGroup struct {
+	Name  string
+	Limit Limit
+}
+
+// RateLimitResult is the outcome of taking a token from a bucket.
+type RateLimitResult struct {
+	Allowed   bool
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next token, when none was left.
+	RetryAfter time.Duration
+}
+
+// RateLimitStore keeps the token buckets of the clients.
+type RateLimitStore interface {
+	// Take takes a token from the bucket named key, refilled as limit says
+	// up to now.
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (RateLimitResult, error)
+}
+
+// RateLimit takes a token from the bucket of the client, as Client names
+// it, for the group of the matched route in routes, which are keyed by
+// method and path, as in "GET /api/v1/sellers". Without one the request
+// is answered with 429 Too Many Requests. Routes that aren't in routes
+// aren't limited.
+//
+// Requests are served when the store fails, so an outage of the counters
+// doesn't take the API down with them.
+//
+// routes is read on every request, so it may be filled after the
+// middleware is installed, as long as that happens before the server
+// starts.
+func RateLimit(store RateLimitStore, routes map[string]RateLimitGroup) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		group, ok := routes[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			c.Next()
+			return
+		}
+
+		key := group.Name + "/" + Client(c)
+		res, err := store.Take(c.Request.Context(), key, group.Limit, time.Now())
+		if err != nil {
+			c.Next()
+			c.Error(fmt.Errorf("rate limiting %s: %w", key, err))
+			return
+		}
+
+		c.Header(HeaderRateLimitLimit, strconv.Itoa(group.Limit.Burst))
+		c.Header(HeaderRateLimitRemaining, strconv.Itoa(res.Remaining))
+		c.Header(HeaderRateLimitReset, strconv.Itoa(seconds(res.Reset)))
+		if !res.Allowed {
+			c.Header("Retry-After", strconv.Itoa(seconds(res.RetryAfter)))
+			p := newProblem(c, http.StatusTooManyRequests, fmt.Sprintf("the %s quota of %d requests is used up, retry in %d seconds", group.Name, group.Limit.Burst, seconds(res.RetryAfter)))
+			p.Type = problemTypeBase + "rate_limited"
+			p.Title = "Rate limit exceeded"
+			p.Code = "rate_limited"
+			renderProblem(c, p)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// seconds rounds d up to whole seconds, so a client waiting that long
+// doesn't come back too early.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// memorySweepInterval is how often MemoryRateLimitStore forgets the
+// buckets that are full again.
+const memorySweepInterval = time.Minute
+
+// MemoryRateLimitStore keeps the buckets in memory, so each instance of
+// the API counts the requests it serves on its own. It keeps up to
+// maxBuckets of them: past that, the buckets that are full again are
+// forgotten at once, and then the one used least recently.
+type MemoryRateLimitStore struct {
+	mu         sync.Mutex
+	buckets    map[string]*bucket
+	maxBuckets int
+	lastSweep  time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// full is when the bucket is full again if no token is taken, at which
+	// point it can be forgotten.
+	full time.Time
+}
+
+func NewMemoryRateLimitStore(maxBuckets int) *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{buckets: map[string]*bucket{}, maxBuckets: maxBuckets}
+}
+
+func (m *MemoryRateLimitStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (RateLimitResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.buckets[key]; (!ok && len(m.buckets) >= m.maxBuckets) || now.Sub(m.lastSweep) >= memorySweepInterval {
+		m.sweep(now)
+	}
+
+	burst := float64(limit.Burst)
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, updated: now}
+		m.buckets[key] = b
+	}
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(burst, b.tokens+elapsed.Seconds()*limit.Rate)
+		b.updated = now
+	}
+
+	var res RateLimitResult
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = refill(1-b.tokens, limit.Rate)
+	}
+	res.Remaining = int(b.tokens)
+	res.Reset = refill(burst-b.tokens, limit.Rate)
+	b.full = now.Add(res.Reset)
+	return res, nil
+}
+
+// sweep forgets the buckets that are full again and, if that leaves no
+// room for another, the one used least recently.
+func (m *MemoryRateLimitStore) sweep(now time.Time) {
+	var oldest string
+	for k, b := range m.buckets {
+		if !now.Before(b.full) {
+			delete(m.buckets, k)
+		} else if oldest == "" || b.updated.Before(m.buckets[oldest].updated) {
+			oldest = k
+		}
+	}
+	if len(m.buckets) >= m.maxBuckets {
+		delete(m.buckets, oldest)
+	}
+	m.lastSweep = now
+}
+
+// refill returns the time it takes to regain tokens at rate.
+func refill(tokens, rate float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+	return time.Duration(tokens / rate * float64(time.Second))
+}
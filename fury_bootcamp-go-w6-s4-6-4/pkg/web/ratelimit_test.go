@@ -0,0 +1,168 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingStore is a RateLimitStore that's down.
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (RateLimitResult, error) {
+	return RateLimitResult{}, errors.New("store unavailable")
+}
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newServer := func(store RateLimitStore) *gin.Engine {
+		r := gin.New()
+		limits := map[string]RateLimitGroup{}
+		r.Use(RateLimit(store, limits))
+		ok := func(c *gin.Context) { Success(c, http.StatusOK, gin.H{}) }
+		r.GET("/sellers", ok)
+		r.GET("/sellers/:id", ok)
+		r.GET("/health", ok)
+		// Filled once the routes are there, as routes.go does.
+		reads := RateLimitGroup{Name: "reads", Limit: PerMinute(60, 2)}
+		limits["GET /sellers"] = reads
+		limits["GET /sellers/:id"] = reads
+		return r
+	}
+	get := func(r *gin.Engine, target, client string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if client != "" {
+			req.Header.Set(UserHeader, client)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("should reject the requests over the burst", func(t *testing.T) {
+		r := newServer(NewMemoryRateLimitStore(10))
+
+		first := get(r, "/sellers", "")
+		get(r, "/sellers", "")
+		w := get(r, "/sellers", "")
+
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, "2", first.Header().Get(HeaderRateLimitLimit))
+		assert.Equal(t, "1", first.Header().Get(HeaderRateLimitRemaining))
+		assert.Equal(t, "1", first.Header().Get(HeaderRateLimitReset))
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "0", w.Header().Get(HeaderRateLimitRemaining))
+		assert.Equal(t, "1", w.Header().Get("Retry-After"))
+		var p Problem
+		json.Unmarshal(w.Body.Bytes(), &p)
+		assert.Equal(t, "rate_limited", p.Code)
+		assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+	})
+	t.Run("should share the quota across the routes of a group", func(t *testing.T) {
+		r := newServer(NewMemoryRateLimitStore(10))
+
+		get(r, "/sellers", "")
+		get(r, "/sellers/1", "")
+		w := get(r, "/sellers/2", "")
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	})
+	t.Run("should keep a quota per user", func(t *testing.T) {
+		r := newServer(NewMemoryRateLimitStore(10))
+
+		get(r, "/sellers", "41")
+		get(r, "/sellers", "41")
+		w := get(r, "/sellers", "42")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+	t.Run("should ignore a client id picked by the client", func(t *testing.T) {
+		r := newServer(NewMemoryRateLimitStore(10))
+
+		var w *httptest.ResponseRecorder
+		for _, id := range []string{"a", "b", "c"} {
+			req := httptest.NewRequest(http.MethodGet, "/sellers", nil)
+			req.Header.Set("X-Client-ID", id)
+			w = httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+		}
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	})
+	t.Run("should not limit the routes without a group", func(t *testing.T) {
+		r := newServer(NewMemoryRateLimitStore(10))
+
+		for i := 0; i < 3; i++ {
+			w := get(r, "/health", "")
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Empty(t, w.Header().Get(HeaderRateLimitLimit))
+		}
+	})
+	t.Run("should serve the requests when the store fails", func(t *testing.T) {
+		r := newServer(failingStore{})
+
+		w := get(r, "/sellers", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get(HeaderRateLimitLimit))
+	})
+}
+
+func TestMemoryRateLimitStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	limit := Limit{Rate: 1, Burst: 2}
+
+	t.Run("should refill the bucket over time", func(t *testing.T) {
+		store := NewMemoryRateLimitStore(10)
+		store.Take(ctx, "k", limit, now)
+		store.Take(ctx, "k", limit, now)
+
+		denied, _ := store.Take(ctx, "k", limit, now.Add(500*time.Millisecond))
+		allowed, _ := store.Take(ctx, "k", limit, now.Add(time.Second))
+
+		assert.False(t, denied.Allowed)
+		assert.Equal(t, 500*time.Millisecond, denied.RetryAfter)
+		assert.True(t, allowed.Allowed)
+		assert.Equal(t, 0, allowed.Remaining)
+		assert.Equal(t, 2*time.Second, allowed.Reset)
+	})
+	t.Run("should not fill the bucket over the burst", func(t *testing.T) {
+		store := NewMemoryRateLimitStore(10)
+		store.Take(ctx, "k", limit, now)
+
+		res, _ := store.Take(ctx, "k", limit, now.Add(time.Hour))
+
+		assert.Equal(t, 1, res.Remaining)
+	})
+	t.Run("should forget the buckets that are full again", func(t *testing.T) {
+		store := NewMemoryRateLimitStore(10)
+		store.Take(ctx, "idle", limit, now)
+		store.Take(ctx, "busy", limit, now)
+
+		store.Take(ctx, "busy", limit, now.Add(memorySweepInterval))
+
+		assert.NotContains(t, store.buckets, "idle")
+		assert.Contains(t, store.buckets, "busy")
+	})
+	t.Run("should forget the bucket used least recently past the most kept", func(t *testing.T) {
+		store := NewMemoryRateLimitStore(2)
+		store.Take(ctx, "first", limit, now)
+		store.Take(ctx, "second", limit, now.Add(time.Millisecond))
+
+		store.Take(ctx, "third", limit, now.Add(2*time.Millisecond))
+
+		assert.Len(t, store.buckets, 2)
+		assert.NotContains(t, store.buckets, "first")
+		assert.Contains(t, store.buckets, "third")
+	})
+}
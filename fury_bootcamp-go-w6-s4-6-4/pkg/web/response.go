@@ -2,8 +2,6 @@ package web
 
 import (
 	"fmt"
-	"net/http"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -12,12 +10,6 @@ type response struct {
 	Data interface{} `json:"data"`
 }
 
-type errorResponse struct {
-	Status  int    `json:"-"`
-	Code    string `json:"code"`
-	Message string `json:"message"`
-}
-
 func Response(c *gin.Context, status int, data interface{}) {
 	c.JSON(status, data)
 }
@@ -26,14 +18,8 @@ func Success(c *gin.Context, status int, data interface{}) {
 	Response(c, status, response{Data: data})
 }
 
-// NewErrorf creates a new error with the given status code and the message
+// Error renders a problem with the given status code and the detail
 // formatted according to args and format.
 func Error(c *gin.Context, status int, format string, args ...interface{}) {
-	err := errorResponse{
-		Code:    strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_"),
-		Message: fmt.Sprintf(format, args...),
-		Status:  status,
-	}
-
-	Response(c, status, err)
+	renderProblem(c, newProblem(c, status, fmt.Sprintf(format, args...)))
 }
@@ -0,0 +1,31 @@
+package web
+
+import (
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/logger"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/tenant"
+)
+
+// Tenant scopes the request context to the site in its X-Site-ID header,
+// resolved with tenant.Resolve against the roles in X-User-Roles, and
+// echoes it in the response, which varies by it. A site that can't be
+// resolved is answered with its problem.
+func Tenant(defaultSite string, sites []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		site, err := tenant.Resolve(c.GetHeader(tenant.Header), c.GetHeader(tenant.RolesHeader), defaultSite, sites)
+		if err != nil {
+			renderProblem(c, problemFor(c, err))
+			c.Abort()
+			return
+		}
+
+		ctx := tenant.NewContext(c.Request.Context(), site)
+		ctx = logger.WithAttrs(ctx, slog.String("site", site))
+		c.Request = c.Request.WithContext(ctx)
+		c.Header(tenant.Header, site)
+		c.Writer.Header().Add("Vary", tenant.Header)
+		c.Next()
+	}
+}
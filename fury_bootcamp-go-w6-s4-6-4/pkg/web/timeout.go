@@ -0,0 +1,28 @@
+package web
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Timeout bounds the request context with the deadline set for the matched
+// route in routes, or with global when the route has none. Work that honors
+// the context, such as queries run with it, is canceled once it expires.
+//
+// routes is read on every request, so it may be filled after the middleware
+// is installed, as long as that happens before the server starts.
+func Timeout(global time.Duration, routes map[string]time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		d, ok := routes[c.FullPath()]
+		if !ok {
+			d = global
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
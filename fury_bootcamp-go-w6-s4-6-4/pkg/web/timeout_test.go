@@ -0,0 +1,53 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.ContextWithFallback = true
+	r.Use(Timeout(10*time.Millisecond, map[string]time.Duration{"/reports": time.Minute}), ErrorHandler())
+
+	deadline := func(c *gin.Context) {
+		d, ok := c.Deadline()
+		assert.True(t, ok)
+		c.String(http.StatusOK, time.Until(d).Round(time.Minute).String())
+	}
+	r.GET("/sections", deadline)
+	r.GET("/reports", deadline)
+	r.GET("/slow", func(c *gin.Context) {
+		<-c.Done()
+		c.Error(c.Err())
+	})
+
+	t.Run("should apply the global deadline", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/sections", nil))
+
+		assert.Equal(t, "0s", w.Body.String())
+	})
+	t.Run("should apply the deadline of the route", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/reports", nil))
+
+		assert.Equal(t, "1m0s", w.Body.String())
+	})
+	t.Run("should answer 504 when the deadline expires", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+		problem := Problem{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		assert.Equal(t, "timeout", problem.Code)
+	})
+}
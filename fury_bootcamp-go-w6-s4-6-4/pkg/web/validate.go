@@ -0,0 +1,47 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/openapi"
+)
+
+// Validate rejects requests that don't match their operation in spec before
+// they reach the handler. Invalid parameters and malformed bodies are bad
+// requests; bodies that don't match their schema are unprocessable. Routes the
+// spec doesn't describe are let through.
+func Validate(spec *openapi.Spec) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			c.Next()
+			return
+		}
+
+		params := make(map[string]string, len(c.Params))
+		for _, p := range c.Params {
+			params[p.Key] = p.Value
+		}
+		err := spec.ValidateRequest(route, c.Request, params)
+		var specErr *openapi.Error
+		if !errors.As(err, &specErr) {
+			c.Next()
+			return
+		}
+
+		p := newProblem(c, http.StatusBadRequest, specErr.Detail)
+		if specErr.In == "body" && len(specErr.Fields) > 0 {
+			p = newProblem(c, http.StatusUnprocessableEntity, specErr.Detail)
+			p.Type = problemTypeBase + "validation_error"
+			p.Title = "Invalid request"
+			p.Code = "validation_error"
+		}
+		for _, f := range specErr.Fields {
+			p.InvalidParams = append(p.InvalidParams, InvalidParam{Name: f.Name, Reason: f.Reason})
+		}
+		renderProblem(c, p)
+		c.Abort()
+	}
+}
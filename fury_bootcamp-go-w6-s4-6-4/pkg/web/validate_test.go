@@ -0,0 +1,98 @@
+package web
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validateSpec = `
+openapi: 3.1.0
+info:
+  title: test
+  version: "1"
+paths:
+  /sections/{id}:
+    patch:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [section_number]
+              properties:
+                section_number:
+                  type: integer
+      responses:
+        "200":
+          description: ok
+`
+
+func TestValidate(t *testing.T) {
+	spec, err := openapi.Load([]byte(validateSpec))
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Validate(spec))
+	echo := func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	}
+	r.PATCH("/sections/:id", echo)
+	r.PATCH("/undocumented", echo)
+
+	t.Run("should let valid requests through", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPatch, "/sections/1", strings.NewReader(`{"section_number": 2}`)))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `{"section_number": 2}`, w.Body.String())
+	})
+	t.Run("should let undocumented routes through", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPatch, "/undocumented", strings.NewReader(`x`)))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+	t.Run("should reject invalid parameters", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPatch, "/sections/one", strings.NewReader(`{"section_number": 2}`)))
+
+		p := decodeProblem(t, w)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "bad_request", p.Code)
+		assert.Equal(t, []InvalidParam{{Name: "id", Reason: "must be an integer"}}, p.InvalidParams)
+	})
+	t.Run("should reject malformed bodies", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPatch, "/sections/1", strings.NewReader(`{`)))
+
+		p := decodeProblem(t, w)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "request body is not valid JSON: unexpected EOF", p.Detail)
+	})
+	t.Run("should reject bodies that don't match their schema", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPatch, "/sections/1", strings.NewReader(`{"section_number": "2"}`)))
+
+		p := decodeProblem(t, w)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, "urn:melisprint:problem:validation_error", p.Type)
+		assert.Equal(t, []InvalidParam{{Name: "section_number", Reason: "got string, want integer"}}, p.InvalidParams)
+	})
+}
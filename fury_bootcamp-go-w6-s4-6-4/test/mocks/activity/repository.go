@@ -0,0 +1,88 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+)
+
+// MockRepository is an outbox kept in memory. Appending an event after one
+// with a higher id is how a late commit looks. Append is safe to call while
+// the broker polls.
+type MockRepository struct {
+	mu         sync.Mutex
+	Events     []domain.OutboxEvent
+	Warehouses []int
+	Error      string
+}
+
+func (m *MockRepository) Append(events ...domain.OutboxEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Events = append(m.Events, events...)
+}
+
+func (m *MockRepository) LastID(ctx context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Error != "" {
+		return 0, fmt.Errorf(m.Error)
+	}
+	if len(m.Events) == 0 {
+		return 0, nil
+	}
+	return m.Events[len(m.Events)-1].ID, nil
+}
+
+func (m *MockRepository) After(ctx context.Context, afterID int64, limit int) ([]domain.OutboxEvent, error) {
+	return m.filter(func(e domain.OutboxEvent) bool { return e.ID > afterID }, limit)
+}
+
+func (m *MockRepository) ByID(ctx context.Context, ids []int64) ([]domain.OutboxEvent, error) {
+	wanted := map[int64]bool{}
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	return m.filter(func(e domain.OutboxEvent) bool { return wanted[e.ID] }, 0)
+}
+
+func (m *MockRepository) Replay(ctx context.Context, warehouseID int, afterID int64, limit int) ([]domain.OutboxEvent, error) {
+	events, err := m.filter(func(e domain.OutboxEvent) bool { return e.WarehouseID == warehouseID && e.ID > afterID }, 0)
+	if len(events) > limit {
+		events = events[len(events)-limit:]
+	}
+	return events, err
+}
+
+func (m *MockRepository) ExistsWarehouse(ctx context.Context, id int) (bool, error) {
+	if m.Error != "" {
+		return false, fmt.Errorf(m.Error)
+	}
+	for _, w := range m.Warehouses {
+		if w == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// filter returns the events matching keep, up to limit unless it's 0.
+func (m *MockRepository) filter(keep func(domain.OutboxEvent) bool, limit int) ([]domain.OutboxEvent, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Error != "" {
+		return nil, fmt.Errorf(m.Error)
+	}
+	var events []domain.OutboxEvent
+	for _, e := range m.Events {
+		if keep(e) {
+			events = append(events, e)
+		}
+		if limit > 0 && len(events) == limit {
+			break
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,124 @@
+package asn
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+// MockRepository keeps the notices in Data. Err fails the writes.
+type MockRepository struct {
+	Data       []domain.ASN
+	Sellers    []domain.Seller
+	Warehouses []domain.Warehouse
+	Products   []domain.Product
+	Err        string
+	// Today is the date ReportOverdue was last asked for.
+	Today string
+}
+
+func (m *MockRepository) GetAll(ctx context.Context) ([]domain.ASN, error) {
+	return m.Data, nil
+}
+
+func (m *MockRepository) Get(ctx context.Context, id int) (domain.ASN, error) {
+	for _, a := range m.Data {
+		if a.ID == id {
+			a.Lines = append([]domain.ASNLine{}, a.Lines...)
+			return a, nil
+		}
+	}
+	return domain.ASN{}, apperrors.NotFound("asn not found")
+}
+
+func (m *MockRepository) ExistsNumber(ctx context.Context, seller_id int, asn_number string) (bool, error) {
+	for _, a := range m.Data {
+		if a.SellerID == seller_id && a.ASNNumber == asn_number {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockRepository) ExistsSeller(ctx context.Context, id int) (bool, error) {
+	for _, s := range m.Sellers {
+		if s.ID == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockRepository) ExistsWarehouse(ctx context.Context, id int) (bool, error) {
+	for _, w := range m.Warehouses {
+		if w.ID == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockRepository) ProductSeller(ctx context.Context, id int) (int, bool, error) {
+	for _, p := range m.Products {
+		if p.ID == id {
+			return p.SellerID, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// Save numbers the lines after every line of Data.
+func (m *MockRepository) Save(ctx context.Context, a domain.ASN) (int, []int, error) {
+	if m.Err != "" {
+		return 0, nil, errors.New(m.Err)
+	}
+	a.ID = len(m.Data) + 1
+	next := 1
+	for _, saved := range m.Data {
+		next += len(saved.Lines)
+	}
+	a.Lines = append([]domain.ASNLine{}, a.Lines...)
+	ids := make([]int, len(a.Lines))
+	for i := range a.Lines {
+		a.Lines[i].ID = next + i
+		ids[i] = a.Lines[i].ID
+	}
+	m.Data = append(m.Data, a)
+	return a.ID, ids, nil
+}
+
+func (m *MockRepository) LockStatus(ctx context.Context, id int) (string, error) {
+	a, err := m.Get(ctx, id)
+	return a.Status, err
+}
+
+func (m *MockRepository) MarkReceived(ctx context.Context, id int, inbound_order_id int, lines []domain.ASNLine) error {
+	for i := range m.Data {
+		if m.Data[i].ID == id {
+			m.Data[i].Status = domain.ASNReceived
+			m.Data[i].InboundOrderID = inbound_order_id
+			m.Data[i].Lines = append([]domain.ASNLine{}, lines...)
+		}
+	}
+	return nil
+}
+
+// ReportOverdue lists the pending notices of Data due before today, without
+// counting the days.
+func (m *MockRepository) ReportOverdue(ctx context.Context, today string, id int) ([]domain.ASNOverdueReport, error) {
+	m.Today = today
+	reports := []domain.ASNOverdueReport{}
+	for _, a := range m.Data {
+		if a.Status != domain.ASNPending || a.ETA >= today || (id != 0 && a.SellerID != id) {
+			continue
+		}
+		o := domain.ASNOverdueReport{ID: a.ID, ASNNumber: a.ASNNumber, SellerID: a.SellerID, WarehouseID: a.WarehouseID, ETA: a.ETA}
+		for _, l := range a.Lines {
+			o.Quantity += l.Quantity
+		}
+		reports = append(reports, o)
+	}
+	return reports, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 type MockRepository struct {
@@ -28,7 +29,7 @@ func (m *MockRepository) Get(ctx context.Context, id int) (domain.Buyer, error)
 	m.GetWasCalled = true
 
 	if m.Error != "" {
-		return domain.Buyer{}, fmt.Errorf(m.Error)
+		return domain.Buyer{}, apperrors.NotFound(m.Error)
 	}
 
 	var buyer domain.Buyer
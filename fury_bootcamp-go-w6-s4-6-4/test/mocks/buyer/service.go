@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 type MockService struct {
@@ -25,7 +26,7 @@ func (m *MockService) GetAll(ctx context.Context) ([]domain.Buyer, error) {
 func (m *MockService) Get(ctx context.Context, id int) (domain.Buyer, error) {
 
 	if m.Error != "" {
-		return domain.Buyer{}, fmt.Errorf(m.Error)
+		return domain.Buyer{}, apperrors.NotFound(m.Error)
 	}
 
 	var buyer domain.Buyer
@@ -41,7 +42,7 @@ func (m *MockService) Get(ctx context.Context, id int) (domain.Buyer, error) {
 func (m *MockService) Save(ctx context.Context, cardNumberID, firstName, lastName string) (domain.Buyer, error) {
 
 	if m.Error != "" {
-		return domain.Buyer{}, fmt.Errorf(m.Error)
+		return domain.Buyer{}, apperrors.Conflict(m.Error)
 	}
 
 	var lastID int
@@ -67,7 +68,7 @@ func (m *MockService) Save(ctx context.Context, cardNumberID, firstName, lastNam
 func (m *MockService) Delete(ctx context.Context, id int) error {
 
 	if m.Error != "" {
-		return fmt.Errorf(m.Error)
+		return apperrors.NotFound(m.Error)
 	}
 
 	var pos int
@@ -90,7 +91,7 @@ func (m *MockService) Delete(ctx context.Context, id int) error {
 func (m *MockService) Update(ctx context.Context, id int, firstName, lastName string) (domain.Buyer, error) {
 
 	if m.Error != "" {
-		return domain.Buyer{}, fmt.Errorf(m.Error)
+		return domain.Buyer{}, apperrors.NotFound(m.Error)
 	}
 
 	// no patch method
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 type mockRepositoryCarry struct {
@@ -44,11 +45,11 @@ func (m *mockRepositoryCarry) ExistsLocality(ctx context.Context, localityCode i
 }
 func (m *mockRepositoryCarry) Save(ctx context.Context, carry domain.Carry) (int, error) {
 	if m.Exists(ctx, carry.CID) {
-		return 0, fmt.Errorf("carry with code %s already exists", carry.CID)
+		return 0, apperrors.Conflict(fmt.Sprintf("carry with code %s already exists", carry.CID))
 	}
 
 	if !m.ExistsLocality(ctx, carry.Locality_id) {
-		return 0, fmt.Errorf("locality with code %d not exists", carry.Locality_id)
+		return 0, apperrors.ForeignKeyViolation(fmt.Sprintf("locality with code %d not exists", carry.Locality_id))
 	}
 
 	carry.ID = m.GlobalId
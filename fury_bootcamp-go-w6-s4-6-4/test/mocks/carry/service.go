@@ -1,9 +1,11 @@
 package carry
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 type mockServiceCarry struct {
@@ -24,10 +26,10 @@ func NewServiceCarry(data []domain.Carry) *mockServiceCarry {
 	}
 }
 
-func (m *mockServiceCarry) Save(carry domain.Carry) (int, error) {
+func (m *mockServiceCarry) Save(ctx context.Context, carry domain.Carry) (int, error) {
 	for _, c := range m.dataMock {
 		if c.CID == carry.CID {
-			return 0, fmt.Errorf("carry with code %s already exists", carry.CID)
+			return 0, apperrors.Conflict(fmt.Sprintf("carry with code %s already exists", carry.CID))
 		}
 	}
 
@@ -40,7 +42,7 @@ func (m *mockServiceCarry) Save(carry domain.Carry) (int, error) {
 
 	}
 	if !exists {
-		return 0, fmt.Errorf("locality with code %d not exists", carry.Locality_id)
+		return 0, apperrors.ForeignKeyViolation(fmt.Sprintf("locality with code %d not exists", carry.Locality_id))
 	}
 	carry.ID = m.GlobalId
 	m.dataMock = append(m.dataMock, carry)
@@ -0,0 +1,112 @@
+package country
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+var ErrNotFound = apperrors.NotFound("country not found")
+
+// MockRepository keeps the countries in memory. Reports is what Report
+// answers, filtered by country.
+type MockRepository struct {
+	DataMock []domain.Country
+	Reports  []domain.CountryReport
+	Error    string
+}
+
+func (m *MockRepository) err() error {
+	if m.Error != "" {
+		return fmt.Errorf(m.Error)
+	}
+	return nil
+}
+
+func (m *MockRepository) GetAll(ctx context.Context) ([]domain.Country, error) {
+	if err := m.err(); err != nil {
+		return nil, err
+	}
+	return append([]domain.Country{}, m.DataMock...), nil
+}
+
+func (m *MockRepository) Get(ctx context.Context, id int) (domain.Country, error) {
+	if err := m.err(); err != nil {
+		return domain.Country{}, err
+	}
+	for _, c := range m.DataMock {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return domain.Country{}, ErrNotFound
+}
+
+func (m *MockRepository) Exists(ctx context.Context, id int) bool {
+	_, err := m.Get(ctx, id)
+	return err == nil
+}
+
+func (m *MockRepository) ExistsName(ctx context.Context, name string) bool {
+	for _, c := range m.DataMock {
+		if c.CountryName == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MockRepository) Save(ctx context.Context, c domain.Country) (int, error) {
+	if err := m.err(); err != nil {
+		return 0, err
+	}
+	if c.ID == 0 {
+		for _, saved := range m.DataMock {
+			c.ID = max(c.ID, saved.ID)
+		}
+		c.ID++
+	}
+	m.DataMock = append(m.DataMock, c)
+	return c.ID, nil
+}
+
+func (m *MockRepository) Update(ctx context.Context, c domain.Country) error {
+	if err := m.err(); err != nil {
+		return err
+	}
+	for i, saved := range m.DataMock {
+		if saved.ID == c.ID {
+			m.DataMock[i] = c
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (m *MockRepository) Delete(ctx context.Context, id int) error {
+	if err := m.err(); err != nil {
+		return err
+	}
+	for i, c := range m.DataMock {
+		if c.ID == id {
+			m.DataMock = append(m.DataMock[:i], m.DataMock[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (m *MockRepository) Report(ctx context.Context, id int) ([]domain.CountryReport, error) {
+	if err := m.err(); err != nil {
+		return nil, err
+	}
+	reports := []domain.CountryReport{}
+	for _, r := range m.Reports {
+		if id == 0 || r.CountryID == id {
+			reports = append(reports, r)
+		}
+	}
+	return reports, nil
+}
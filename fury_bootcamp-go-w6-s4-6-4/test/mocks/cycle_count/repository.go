@@ -0,0 +1,132 @@
+package cyclecount
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+// MockRepository keeps the counts in Data, and the batches they count, and
+// adjust, in Batches. Err fails the writes.
+type MockRepository struct {
+	Data      []domain.CycleCount
+	Employees []domain.Employee
+	Sections  []domain.Section
+	Batches   []domain.Product_batches
+	Err       string
+}
+
+func (m *MockRepository) GetAll(ctx context.Context) ([]domain.CycleCount, error) {
+	return m.Data, nil
+}
+
+func (m *MockRepository) Get(ctx context.Context, id int) (domain.CycleCount, error) {
+	for _, c := range m.Data {
+		if c.ID == id {
+			c.Lines = append([]domain.CycleCountLine{}, c.Lines...)
+			return c, nil
+		}
+	}
+	return domain.CycleCount{}, apperrors.NotFound("cycle count not found")
+}
+
+func (m *MockRepository) EmployeeWarehouse(ctx context.Context, id int) (int, bool, error) {
+	for _, e := range m.Employees {
+		if e.ID == id {
+			return e.WarehouseID, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+func (m *MockRepository) SectionWarehouse(ctx context.Context, id int) (int, bool, error) {
+	for _, s := range m.Sections {
+		if s.ID == id {
+			return s.WarehouseID, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+func (m *MockRepository) SectionBatches(ctx context.Context, id int) ([]domain.Product_batches, error) {
+	var batches []domain.Product_batches
+	for _, pb := range m.Batches {
+		if pb.SectionId == id {
+			batches = append(batches, pb)
+		}
+	}
+	return batches, nil
+}
+
+// Save numbers the lines after every line of Data.
+func (m *MockRepository) Save(ctx context.Context, c domain.CycleCount) (int, []int, error) {
+	if m.Err != "" {
+		return 0, nil, errors.New(m.Err)
+	}
+	c.ID = len(m.Data) + 1
+	next := 1
+	for _, saved := range m.Data {
+		next += len(saved.Lines)
+	}
+	c.Lines = append([]domain.CycleCountLine{}, c.Lines...)
+	ids := make([]int, len(c.Lines))
+	for i := range c.Lines {
+		c.Lines[i].ID = next + i
+		ids[i] = c.Lines[i].ID
+	}
+	m.Data = append(m.Data, c)
+	return c.ID, ids, nil
+}
+
+func (m *MockRepository) LockStatus(ctx context.Context, id int) (string, error) {
+	c, err := m.Get(ctx, id)
+	return c.Status, err
+}
+
+func (m *MockRepository) LockQuantity(ctx context.Context, id int) (int, error) {
+	for _, pb := range m.Batches {
+		if pb.ID == id {
+			return pb.CurrentQuantity, nil
+		}
+	}
+	return 0, apperrors.ForeignKeyViolation("product batch not exists")
+}
+
+func (m *MockRepository) Adjust(ctx context.Context, id int, quantity int) error {
+	if m.Err != "" {
+		return errors.New(m.Err)
+	}
+	for i := range m.Batches {
+		if m.Batches[i].ID == id {
+			m.Batches[i].CurrentQuantity += quantity
+		}
+	}
+	return nil
+}
+
+func (m *MockRepository) UpdateCounted(ctx context.Context, id int, status string, lines []domain.CycleCountLine) error {
+	if m.Err != "" {
+		return errors.New(m.Err)
+	}
+	for i := range m.Data {
+		if m.Data[i].ID == id {
+			m.Data[i].Status = status
+			m.Data[i].Lines = append([]domain.CycleCountLine{}, lines...)
+		}
+	}
+	return nil
+}
+
+func (m *MockRepository) UpdateStatus(ctx context.Context, id int, status string, approved_by string) error {
+	if m.Err != "" {
+		return errors.New(m.Err)
+	}
+	for i := range m.Data {
+		if m.Data[i].ID == id {
+			m.Data[i].Status, m.Data[i].ApprovedBy = status, approved_by
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package edi
+
+import (
+	"context"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+// MockService imports every file as Imported, and writes Receipts and
+// Notices, the interchanges of the inbound and purchase orders by id. Data,
+// EmployeeID and SectionID are what Import was last given.
+type MockService struct {
+	Imported []domain.ASN
+	Receipts map[int][]byte
+	Notices  map[int][]byte
+	Err      error
+
+	Data       []byte
+	EmployeeID int
+	SectionID  int
+}
+
+func (m *MockService) Import(ctx context.Context, data []byte, employee_id int, section_id int) ([]domain.ASN, error) {
+	m.Data, m.EmployeeID, m.SectionID = data, employee_id, section_id
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return m.Imported, nil
+}
+
+func (m *MockService) ReceiptAdvice(ctx context.Context, id int) ([]byte, error) {
+	written, ok := m.Receipts[id]
+	if !ok {
+		return nil, apperrors.NotFound("inbound order not found")
+	}
+	return written, nil
+}
+
+func (m *MockService) ShipNotice(ctx context.Context, id int, carry_id int) ([]byte, error) {
+	written, ok := m.Notices[id]
+	if !ok {
+		return nil, apperrors.NotFound("purchase order not found")
+	}
+	return written, nil
+}
@@ -49,7 +49,7 @@ func (m *MockRepositoryEmployee) Get(ctx context.Context, id int) (domain.Employ
 			return value, nil
 		}
 	}
-	return domain.Employee{}, errors.New("ID not found")
+	return domain.Employee{}, ErrNotFound
 }
 func (m *MockRepositoryEmployee) Update(ctx context.Context, e domain.Employee) error {
 	m.MethodCalled = true
@@ -71,7 +71,7 @@ func (m *MockRepositoryEmployee) Delete(ctx context.Context, id int) error {
 			return nil
 		}
 	}
-	return errors.New("Not eliminated")
+	return ErrNotFound
 }
 
 func (m *MockRepositoryEmployee) ReportInboundOrders(ctx context.Context) ([]domain.ReportInBO, error) {
@@ -6,6 +6,14 @@ import (
 	"strconv"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+// Errors
+var (
+	ErrNotFound           = apperrors.NotFound("employee not found")
+	ErrCardNumberIDExists = apperrors.Conflict("The card_number_id already exists")
+	ErrInvalidID          = apperrors.Validation("invalid id format")
 )
 
 type MockServiceEmployee struct {
@@ -19,7 +27,7 @@ func (ms *MockServiceEmployee) Save(ctx context.Context, cardNumberId string, na
 	ms.MethodCalled = true
 	for _, value := range ms.DataMock {
 		if value.CardNumberID == cardNumberId {
-			return domain.Employee{}, errors.New("The card_number_id already exists")
+			return domain.Employee{}, ErrCardNumberIDExists
 		}
 	}
 	if ms.Err != "" {
@@ -59,7 +67,7 @@ func (ms *MockServiceEmployee) GetEmployeeByID(ctx context.Context, id int) (dom
 			return value, nil
 		}
 	}
-	return domain.Employee{}, errors.New("employee not found")
+	return domain.Employee{}, ErrNotFound
 }
 
 func (ms *MockServiceEmployee) Update(ctx context.Context, id int, name string, lastname string, wharehouseId *int) (domain.Employee, error) {
@@ -73,7 +81,7 @@ func (ms *MockServiceEmployee) Update(ctx context.Context, id int, name string,
 		}
 	}
 	if !flag {
-		return domain.Employee{}, errors.New("employee not found")
+		return domain.Employee{}, ErrNotFound
 	}
 	if name != "" {
 		ms.DataMock[index].FirstName = name
@@ -98,7 +106,7 @@ func (ms *MockServiceEmployee) Delete(ctx context.Context, id int) error {
 		}
 	}
 	if !flag {
-		return errors.New("employee not found")
+		return ErrNotFound
 	}
 	ms.DataMock = append(ms.DataMock[:index], ms.DataMock[index+1:]...)
 	return nil
@@ -112,14 +120,14 @@ func (ms *MockServiceEmployee) Report_BO(ctx context.Context, id string) ([]doma
 	if id != "" {
 		id_e, err := strconv.Atoi(id)
 		if err != nil {
-			return []domain.ReportInBO{}, errors.New("invalid id format")
+			return []domain.ReportInBO{}, ErrInvalidID
 		}
 		for _, r := range ms.DatamockIBO {
 			if r.ID == id_e {
 				return []domain.ReportInBO{r}, nil
 			}
 		}
-		return []domain.ReportInBO{}, errors.New("employee not found")
+		return []domain.ReportInBO{}, ErrNotFound
 	}
 	return ms.DatamockIBO, nil
 }
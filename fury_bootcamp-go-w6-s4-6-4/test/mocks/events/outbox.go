@@ -0,0 +1,26 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/events"
+)
+
+// MockOutbox runs changes and keeps the events of the ones that succeed.
+type MockOutbox struct {
+	Events []events.Event
+	Error  string
+}
+
+func (m *MockOutbox) Record(ctx context.Context, change func(ctx context.Context) ([]events.Event, error)) error {
+	recorded, err := change(ctx)
+	if err != nil {
+		return err
+	}
+	if m.Error != "" {
+		return fmt.Errorf(m.Error)
+	}
+	m.Events = append(m.Events, recorded...)
+	return nil
+}
@@ -5,12 +5,23 @@ import (
 	"errors"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
+// MockRepositoryIBO keeps the orders, with their lines, in DataMock and the
+// receipts in Receipts. The batches received are added to DataMockPB. The
+// lookups fail with LookupErr when it's set.
 type MockRepositoryIBO struct {
 	DataMock     []domain.Inbound_order
 	DataMockEmp  []domain.Employee
+	DataMockWh   []domain.Warehouse
+	DataMockPB   []domain.Product_batches
+	DataMockSec  []domain.Section
+	DataMockProd []domain.Product
+	Receipts     []domain.InboundReceipt
+	Reports      []domain.InboundDiscrepancyReport
 	Err          string
+	LookupErr    error
 	MethodCalled bool
 }
 
@@ -21,23 +32,94 @@ func (m *MockRepositoryIBO) GetAll(ctx context.Context) ([]domain.Inbound_order,
 	}
 	return m.DataMock, nil
 }
-func (m *MockRepositoryIBO) ExistsEmployee(ctx context.Context, id_employee int) bool {
+func (m *MockRepositoryIBO) ExistsWarehouse(ctx context.Context, id_warehouse int) (bool, error) {
 	m.MethodCalled = true
+	if m.LookupErr != nil {
+		return false, m.LookupErr
+	}
+	for _, value := range m.DataMockWh {
+		if value.ID == id_warehouse {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+func (m *MockRepositoryIBO) ExistsProduct(ctx context.Context, id_product int) (bool, error) {
+	m.MethodCalled = true
+	if m.LookupErr != nil {
+		return false, m.LookupErr
+	}
+	for _, value := range m.DataMockProd {
+		if value.ID == id_product {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+func (m *MockRepositoryIBO) EmployeeWarehouse(ctx context.Context, id_employee int) (int, bool, error) {
+	m.MethodCalled = true
+	if m.LookupErr != nil {
+		return 0, false, m.LookupErr
+	}
 	for _, value := range m.DataMockEmp {
 		if value.ID == id_employee {
-			return true
+			return value.WarehouseID, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// ProductBatchWarehouse finds the section of the batch in DataMockSec.
+func (m *MockRepositoryIBO) ProductBatchWarehouse(ctx context.Context, id_product_batch int) (int, bool, error) {
+	section, ok, err := m.ProductBatchSection(ctx, id_product_batch)
+	if !ok || err != nil {
+		return 0, false, err
+	}
+	return m.SectionWarehouse(ctx, section)
+}
+func (m *MockRepositoryIBO) ProductBatchProduct(ctx context.Context, id_product_batch int) (int, bool, error) {
+	m.MethodCalled = true
+	if m.LookupErr != nil {
+		return 0, false, m.LookupErr
+	}
+	if i := m.batch(id_product_batch); i >= 0 {
+		return m.DataMockPB[i].ProductId, true, nil
+	}
+	return 0, false, nil
+}
+func (m *MockRepositoryIBO) ProductBatchSection(ctx context.Context, id_product_batch int) (int, bool, error) {
+	m.MethodCalled = true
+	if m.LookupErr != nil {
+		return 0, false, m.LookupErr
+	}
+	if i := m.batch(id_product_batch); i >= 0 {
+		return m.DataMockPB[i].SectionId, true, nil
+	}
+	return 0, false, nil
+}
+func (m *MockRepositoryIBO) SectionWarehouse(ctx context.Context, id_section int) (int, bool, error) {
+	m.MethodCalled = true
+	if m.LookupErr != nil {
+		return 0, false, m.LookupErr
+	}
+	for _, section := range m.DataMockSec {
+		if section.ID == id_section {
+			return section.WarehouseID, true, nil
 		}
 	}
-	return false
+	return 0, false, nil
 }
-func (m *MockRepositoryIBO) ExistsInboundOrder(ctx context.Context, order_number string) bool {
+func (m *MockRepositoryIBO) ExistsInboundOrder(ctx context.Context, order_number string) (bool, error) {
 	m.MethodCalled = true
+	if m.LookupErr != nil {
+		return false, m.LookupErr
+	}
 	for _, value := range m.DataMock {
 		if value.Order_number == order_number {
-			return true
+			return true, nil
 		}
 	}
-	return false
+	return false, nil
 }
 
 func (m *MockRepositoryIBO) Save(ctx context.Context, b_order domain.Inbound_order) (int, error) {
@@ -53,3 +135,98 @@ func (m *MockRepositoryIBO) Save(ctx context.Context, b_order domain.Inbound_ord
 	m.DataMock = append(m.DataMock, b_order)
 	return id, nil
 }
+
+func (m *MockRepositoryIBO) Get(ctx context.Context, id int) (domain.Inbound_order, error) {
+	m.MethodCalled = true
+	if i := m.order(id); i >= 0 {
+		o := m.DataMock[i]
+		o.Lines = append([]domain.InboundOrderLine{}, o.Lines...)
+		return o, nil
+	}
+	return domain.Inbound_order{}, apperrors.NotFound("Inbound_order not found")
+}
+
+// SaveLines numbers the lines after every line of DataMock.
+func (m *MockRepositoryIBO) SaveLines(ctx context.Context, id int, lines []domain.InboundOrderLine) ([]int, error) {
+	m.MethodCalled = true
+	i := m.order(id)
+	next := 1
+	for _, o := range m.DataMock {
+		next += len(o.Lines)
+	}
+	ids := make([]int, len(lines))
+	for j, line := range lines {
+		line.ID = next + j
+		ids[j] = line.ID
+		m.DataMock[i].Lines = append(m.DataMock[i].Lines, line)
+	}
+	return ids, nil
+}
+
+func (m *MockRepositoryIBO) LockLines(ctx context.Context, id int) ([]domain.InboundOrderLine, error) {
+	o, err := m.Get(ctx, id)
+	return o.Lines, err
+}
+
+func (m *MockRepositoryIBO) SaveReceipt(ctx context.Context, receipt domain.InboundReceipt) (int, error) {
+	m.MethodCalled = true
+	if m.Err != "" {
+		return 0, errors.New(m.Err)
+	}
+	receipt.ID = len(m.Receipts) + 1
+	m.Receipts = append(m.Receipts, receipt)
+	return receipt.ID, nil
+}
+
+func (m *MockRepositoryIBO) UpdateReceived(ctx context.Context, id int, lines []domain.InboundOrderLine, status string) error {
+	m.MethodCalled = true
+	i := m.order(id)
+	m.DataMock[i].Lines = append([]domain.InboundOrderLine{}, lines...)
+	m.DataMock[i].Status = status
+	return nil
+}
+
+func (m *MockRepositoryIBO) CreateProductBatch(ctx context.Context, pb domain.Product_batches) (int, error) {
+	m.MethodCalled = true
+	pb.ID = 1
+	if len(m.DataMockPB) > 0 {
+		pb.ID = m.DataMockPB[len(m.DataMockPB)-1].ID + 1
+	}
+	m.DataMockPB = append(m.DataMockPB, pb)
+	return pb.ID, nil
+}
+
+func (m *MockRepositoryIBO) CreditProductBatch(ctx context.Context, id_product_batch int, quantity int) error {
+	m.MethodCalled = true
+	m.DataMockPB[m.batch(id_product_batch)].CurrentQuantity += quantity
+	return nil
+}
+
+func (m *MockRepositoryIBO) ReportDiscrepancies(ctx context.Context, id int) ([]domain.InboundDiscrepancyReport, error) {
+	m.MethodCalled = true
+	reports := []domain.InboundDiscrepancyReport{}
+	for _, r := range m.Reports {
+		if id == 0 || r.SellerID == id {
+			reports = append(reports, r)
+		}
+	}
+	return reports, nil
+}
+
+func (m *MockRepositoryIBO) order(id int) int {
+	for i, o := range m.DataMock {
+		if o.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *MockRepositoryIBO) batch(id int) int {
+	for i, pb := range m.DataMockPB {
+		if pb.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -5,10 +5,12 @@ import (
 	"errors"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 type MockServiceIBO struct {
 	DataMock     []domain.Inbound_order
+	Reports      []domain.InboundDiscrepancyReport
 	Err          string
 	MethodCalled bool
 }
@@ -21,10 +23,20 @@ func (ms *MockServiceIBO) GetAll_inboundOrders(ctx context.Context) ([]domain.In
 	return ms.DataMock, nil
 }
 
-func (ms *MockServiceIBO) Save(ctx context.Context, order_date string, order_number string, employee_id int, product_batch_id int, warehouse_id int) (domain.Inbound_order, error) {
+func (ms *MockServiceIBO) Get(ctx context.Context, id int) (domain.Inbound_order, error) {
+	ms.MethodCalled = true
+	for _, o := range ms.DataMock {
+		if o.ID == id {
+			return o, nil
+		}
+	}
+	return domain.Inbound_order{}, apperrors.NotFound("Inbound_order not found")
+}
+
+func (ms *MockServiceIBO) Save(ctx context.Context, order_date string, order_number string, employee_id int, product_batch_id int, warehouse_id int, lines []domain.InboundOrderLine) (domain.Inbound_order, error) {
 	ms.MethodCalled = true
 	if ms.Err != "" {
-		return domain.Inbound_order{}, errors.New(ms.Err)
+		return domain.Inbound_order{}, apperrors.Conflict(ms.Err)
 	}
 	var newIBO domain.Inbound_order
 	var id int = 1
@@ -37,8 +49,41 @@ func (ms *MockServiceIBO) Save(ctx context.Context, order_date string, order_num
 	newIBO.Employee_id = employee_id
 	newIBO.Product_batch_id = product_batch_id
 	newIBO.Warehouse_id = warehouse_id
+	newIBO.Lines = lines
 
 	ms.DataMock = append(ms.DataMock, newIBO)
 
 	return newIBO, nil
 }
+
+// Receive credits lines to the order without checking them, and answers it
+// with the receipt.
+func (ms *MockServiceIBO) Receive(ctx context.Context, id int, lines []domain.InboundReceiptLine) (domain.InboundReceipt, error) {
+	ms.MethodCalled = true
+	if ms.Err != "" {
+		return domain.InboundReceipt{}, apperrors.Validation(ms.Err)
+	}
+	order, err := ms.Get(ctx, id)
+	if err != nil {
+		return domain.InboundReceipt{}, err
+	}
+	for _, received := range lines {
+		for i := range order.Lines {
+			if order.Lines[i].ID == received.LineID {
+				order.Lines[i].ReceivedQuantity += received.Quantity
+			}
+		}
+	}
+	return domain.InboundReceipt{ID: 1, InboundOrderID: id, Status: order.Status, Lines: lines}, nil
+}
+
+func (ms *MockServiceIBO) ReportDiscrepancies(ctx context.Context, id int) ([]domain.InboundDiscrepancyReport, error) {
+	ms.MethodCalled = true
+	reports := []domain.InboundDiscrepancyReport{}
+	for _, r := range ms.Reports {
+		if id == 0 || r.SellerID == id {
+			reports = append(reports, r)
+		}
+	}
+	return reports, nil
+}
@@ -2,14 +2,16 @@ package locality
 
 import (
 	"context"
-	"errors"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
 )
 
+// MockRepository keeps the localities in memory, in the provinces of
+// Provinces.
 type MockRepository struct {
-	DataMock []domain.Locality
-	Error    string
+	DataMock  []domain.Locality
+	Provinces []int
+	Error     string
 }
 
 func (m *MockRepository) Exists(ctx context.Context, id int) bool {
@@ -21,12 +23,37 @@ func (m *MockRepository) Exists(ctx context.Context, id int) bool {
 	return false
 }
 
+func (m *MockRepository) ProvinceExists(ctx context.Context, provinceID int) bool {
+	for _, id := range m.Provinces {
+		if id == provinceID {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MockRepository) Get(ctx context.Context, id int) (domain.Locality, error) {
+	for _, l := range m.DataMock {
+		if l.ID == id {
+			return l, nil
+		}
+	}
+	return domain.Locality{}, ErrNotFound
+}
+
 func (m *MockRepository) Create(ctx context.Context, l domain.Locality) (int, error) {
 	if m.Exists(ctx, l.ID) {
-		return 0, errors.New("id already exists")
+		return 0, ErrExists
+	}
+	if l.ID == 0 {
+		for _, saved := range m.DataMock {
+			l.ID = max(l.ID, saved.ID)
+		}
+		l.ID++
 	}
+	m.DataMock = append(m.DataMock, l)
 
-	return int(l.ID), nil
+	return l.ID, nil
 }
 
 func (m *MockRepository) GetAllSellersByLocality(ctx context.Context, localityID string) ([]domain.ResponseLocality, error) {
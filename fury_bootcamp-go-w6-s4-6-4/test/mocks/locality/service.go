@@ -2,18 +2,18 @@ package locality
 
 import (
 	"context"
-	"errors"
 	"strconv"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 // Errors
 var (
-	ErrNotFound = errors.New("seller not found")
-	ErrRequired = errors.New("field required")
-	ErrExists   = errors.New("id already exists")
-	ErrRequest  = errors.New("incorrec field content")
+	ErrNotFound = apperrors.NotFound("locality_id not found")
+	ErrRequired = apperrors.Validation("field required")
+	ErrExists   = apperrors.Conflict("id already exists")
+	ErrRequest  = apperrors.Validation("incorrect field content")
 )
 
 type MockService struct {
@@ -35,7 +35,7 @@ func (m *MockService) GetAllSellersByLocality(ctx context.Context, localityID st
 	id, _ := strconv.Atoi(localityID)
 	for _, element := range m.DataMock {
 		if element.ID == id {
-			return []domain.ResponseLocality{}, ErrExists
+			return []domain.ResponseLocality{}, ErrNotFound
 		}
 	}
 
@@ -46,7 +46,7 @@ func (m *MockService) GetCarriesReport(ctx context.Context, id string) ([]domain
 	i, _ := strconv.Atoi(id)
 	for _, element := range m.DataMock {
 		if element.ID == i {
-			return []domain.CarriesReport{}, ErrExists
+			return []domain.CarriesReport{}, ErrNotFound
 		}
 	}
 
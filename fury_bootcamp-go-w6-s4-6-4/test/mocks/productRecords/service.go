@@ -2,9 +2,9 @@ package productRecords
 
 import (
 	"context"
-	"errors"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 type MockServiceProductRecords struct {
@@ -14,7 +14,7 @@ type MockServiceProductRecords struct {
 
 func (m *MockServiceProductRecords) Save(ctx context.Context, last_update_date string, purchase_price float64, sale_price float64, products_id int) (domain.ProductRecords, error) {
 	if m.Error != "" {
-		return domain.ProductRecords{}, errors.New(m.Error)
+		return domain.ProductRecords{}, apperrors.ForeignKeyViolation(m.Error)
 	}
 
 	id := 1
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 type MockRepository struct {
@@ -13,6 +14,8 @@ type MockRepository struct {
 	Error      string
 	ExistsID   bool
 	ID         int
+	// Warehouses maps section ids to the warehouse they're in.
+	Warehouses map[int]int
 }
 
 func (m *MockRepository) CreatePB(ctx context.Context, pb domain.Product_batches) (int, error) {
@@ -33,7 +36,7 @@ func (m *MockRepository) ReadPB(ctx context.Context, id int) (domain.ReportProdu
 			return pb, nil
 		}
 	}
-	return domain.ReportProduct{}, fmt.Errorf("product batch not found")
+	return domain.ReportProduct{}, apperrors.NotFound("product batch not found")
 }
 
 func (m *MockRepository) GetPB(ctx context.Context, id int) (domain.Product_batches, error) {
@@ -45,7 +48,7 @@ func (m *MockRepository) GetPB(ctx context.Context, id int) (domain.Product_batc
 			return pb, nil
 		}
 	}
-	return domain.Product_batches{}, fmt.Errorf("product batch not found")
+	return domain.Product_batches{}, apperrors.NotFound("product batch not found")
 }
 
 func (m *MockRepository) ExistenceSectionId(ctx context.Context, section_id int) bool {
@@ -74,3 +77,23 @@ func (m *MockRepository) ExistsProductBatches(ctx context.Context, batch_number
 	}
 	return false
 }
+
+func (m *MockRepository) SectionWarehouse(ctx context.Context, section_id int) (int, error) {
+	if m.Error != "" {
+		return 0, fmt.Errorf(m.Error)
+	}
+	return m.Warehouses[section_id], nil
+}
+
+func (m *MockRepository) CountTemperatureIncidents(ctx context.Context) (int, error) {
+	if m.Error != "" {
+		return 0, fmt.Errorf(m.Error)
+	}
+	count := 0
+	for _, pb := range m.DataMockPB {
+		if pb.CurrentQuantity > 0 && pb.CurrentTemperature < pb.MinimumTemperature {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -47,9 +47,9 @@ func (m *MockRepositoryProduct) Get(ctx context.Context, id int) (domain.Product
 			return p, nil
 		}
 
-		return domain.Product{}, fmt.Errorf(m.Error)
+		return domain.Product{}, ErrNotFound
 	}
-	return domain.Product{}, fmt.Errorf(m.Error)
+	return domain.Product{}, ErrNotFound
 }
 
 func (m *MockRepositoryProduct) Exists(ctx context.Context, productCode string) bool {
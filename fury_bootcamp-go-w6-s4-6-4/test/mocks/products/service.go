@@ -6,6 +6,13 @@ import (
 	"fmt"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+// Errors
+var (
+	ErrNotFound          = apperrors.NotFound("product not found")
+	ErrProductCodeExists = apperrors.Conflict("product_code already exists")
 )
 
 type MockServiceProduct struct {
@@ -27,7 +34,7 @@ func (m *MockServiceProduct) Get(ctx context.Context, id int) (domain.Product, e
 		}
 	}
 
-	return domain.Product{}, errors.New("product not found")
+	return domain.Product{}, ErrNotFound
 }
 
 func (m *MockServiceProduct) Save(ctx context.Context, description string, expiration_rate int, freezing_rate int, height float32, length float32, netweight float32, product_code string, recommended_freezing_temperature float32, width float32, product_type_id int, seller_id int) (domain.Product, error) {
@@ -37,7 +44,7 @@ func (m *MockServiceProduct) Save(ctx context.Context, description string, expir
 
 	for _, value := range m.DataMock {
 		if value.ProductCode == product_code {
-			return domain.Product{}, errors.New("product_code already exists")
+			return domain.Product{}, ErrProductCodeExists
 		}
 	}
 
@@ -79,7 +86,7 @@ func (m *MockServiceProduct) Delete(ctx context.Context, id int) error {
 	}
 
 	if !found {
-		return errors.New("product not found")
+		return ErrNotFound
 	}
 
 	m.DataMock = append(m.DataMock[:idx], m.DataMock[idx+1:]...)
@@ -98,7 +105,7 @@ func (m *MockServiceProduct) Update(ctx context.Context, id int, description str
 	}
 
 	if !found {
-		return domain.Product{}, errors.New("product not found")
+		return domain.Product{}, ErrNotFound
 	}
 	if description != "" {
 		m.DataMock[idx].Description = description
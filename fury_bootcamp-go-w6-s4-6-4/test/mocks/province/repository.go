@@ -0,0 +1,122 @@
+package province
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+var ErrNotFound = apperrors.NotFound("province not found")
+
+// MockRepository keeps the provinces in memory, in the countries of
+// Countries. Reports is what Report answers, filtered by province.
+type MockRepository struct {
+	DataMock  []domain.Province
+	Countries []int
+	Reports   []domain.ProvinceReport
+	Error     string
+}
+
+func (m *MockRepository) err() error {
+	if m.Error != "" {
+		return fmt.Errorf(m.Error)
+	}
+	return nil
+}
+
+func (m *MockRepository) GetAll(ctx context.Context) ([]domain.Province, error) {
+	if err := m.err(); err != nil {
+		return nil, err
+	}
+	return append([]domain.Province{}, m.DataMock...), nil
+}
+
+func (m *MockRepository) Get(ctx context.Context, id int) (domain.Province, error) {
+	if err := m.err(); err != nil {
+		return domain.Province{}, err
+	}
+	for _, p := range m.DataMock {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return domain.Province{}, ErrNotFound
+}
+
+func (m *MockRepository) Exists(ctx context.Context, id int) bool {
+	_, err := m.Get(ctx, id)
+	return err == nil
+}
+
+func (m *MockRepository) ExistsName(ctx context.Context, countryID int, name string) bool {
+	for _, p := range m.DataMock {
+		if p.CountryID == countryID && p.ProvinceName == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MockRepository) CountryExists(ctx context.Context, countryID int) bool {
+	for _, id := range m.Countries {
+		if id == countryID {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MockRepository) Save(ctx context.Context, p domain.Province) (int, error) {
+	if err := m.err(); err != nil {
+		return 0, err
+	}
+	if p.ID == 0 {
+		for _, saved := range m.DataMock {
+			p.ID = max(p.ID, saved.ID)
+		}
+		p.ID++
+	}
+	m.DataMock = append(m.DataMock, p)
+	return p.ID, nil
+}
+
+func (m *MockRepository) Update(ctx context.Context, p domain.Province) error {
+	if err := m.err(); err != nil {
+		return err
+	}
+	for i, saved := range m.DataMock {
+		if saved.ID == p.ID {
+			m.DataMock[i] = p
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (m *MockRepository) Delete(ctx context.Context, id int) error {
+	if err := m.err(); err != nil {
+		return err
+	}
+	for i, p := range m.DataMock {
+		if p.ID == id {
+			m.DataMock = append(m.DataMock[:i], m.DataMock[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (m *MockRepository) Report(ctx context.Context, id int) ([]domain.ProvinceReport, error) {
+	if err := m.err(); err != nil {
+		return nil, err
+	}
+	reports := []domain.ProvinceReport{}
+	for _, r := range m.Reports {
+		if id == 0 || r.ProvinceID == id {
+			reports = append(reports, r)
+		}
+	}
+	return reports, nil
+}
@@ -91,3 +91,14 @@ func (m *MockRepository) ExistsProductRecordsID(ctx context.Context, productReco
 
 	return exists
 }
+
+func (m *MockRepository) CountByStatus(ctx context.Context) (map[int]int, error) {
+	if m.Error != "" {
+		return nil, fmt.Errorf(m.Error)
+	}
+	counts := map[int]int{}
+	for _, p := range m.DataMock {
+		counts[p.OrderStatusID]++
+	}
+	return counts, nil
+}
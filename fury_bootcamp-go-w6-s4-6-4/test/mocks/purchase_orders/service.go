@@ -2,10 +2,10 @@ package purchase_orders
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 type MockService struct {
@@ -19,7 +19,7 @@ type MockService struct {
 func (m *MockService) Save(ctx context.Context, orderNumber, trackingCode string, buyerID, productRecordID, orderStatusID int, orderDate *time.Time) (domain.PurchaseOrders, error) {
 
 	if m.Error != "" {
-		return domain.PurchaseOrders{}, fmt.Errorf(m.Error)
+		return domain.PurchaseOrders{}, apperrors.ForeignKeyViolation(m.Error)
 	}
 
 	// m.
@@ -51,7 +51,7 @@ func (m *MockService) Save(ctx context.Context, orderNumber, trackingCode string
 func (m *MockService) GetAllByBuyerID(ctx context.Context, buyerID int) ([]domain.ReportPurchaseOrders, error) {
 
 	if m.Error != "" {
-		return nil, fmt.Errorf(m.Error)
+		return nil, apperrors.NotFound(m.Error)
 	}
 
 	if buyerID == 0 {
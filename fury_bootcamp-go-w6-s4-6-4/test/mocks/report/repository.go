@@ -0,0 +1,25 @@
+package report
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+)
+
+// MockRepository answers every report with Rows and records the name and
+// the query it was last asked.
+type MockRepository struct {
+	Rows  []domain.ReportRow
+	Name  string
+	Query domain.ReportQuery
+	Error string
+}
+
+func (m *MockRepository) Count(ctx context.Context, name string, q domain.ReportQuery) ([]domain.ReportRow, error) {
+	if m.Error != "" {
+		return nil, fmt.Errorf(m.Error)
+	}
+	m.Name, m.Query = name, q
+	return append([]domain.ReportRow{}, m.Rows...), nil
+}
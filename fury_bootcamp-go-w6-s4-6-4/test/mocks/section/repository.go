@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 type MockRepository struct {
@@ -31,7 +32,7 @@ func (m *MockRepository) Get(ctx context.Context, id int) (domain.Section, error
 			return section, nil
 		}
 	}
-	return domain.Section{}, fmt.Errorf("section not found")
+	return domain.Section{}, apperrors.NotFound("section not found")
 }
 
 func (m *MockRepository) Exists(ctx context.Context, sectionNumber int) bool {
@@ -64,7 +65,7 @@ func (m *MockRepository) Update(ctx context.Context, s domain.Section) error {
 			return nil
 		}
 	}
-	return fmt.Errorf("section not found")
+	return apperrors.NotFound("section not found")
 }
 
 func (m *MockRepository) Delete(ctx context.Context, id int) error {
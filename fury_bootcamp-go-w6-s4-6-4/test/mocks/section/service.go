@@ -2,10 +2,14 @@ package section
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+var (
+	ErrNotFound = apperrors.NotFound("section not found")
 )
 
 type MockService struct {
@@ -20,7 +24,7 @@ func (s *MockService) Delete(ctx context.Context, id int) error {
 			return nil
 		}
 	}
-	return errors.New("section not found")
+	return ErrNotFound
 }
 func (s *MockService) GetAll(ctx context.Context) ([]domain.Section, error) {
 
@@ -40,7 +44,7 @@ func (s *MockService) Get(ctx context.Context, id int) (domain.Section, error) {
 			return section, nil
 		}
 	}
-	return domain.Section{}, fmt.Errorf(s.Db.Error)
+	return domain.Section{}, ErrNotFound
 }
 
 func (s *MockService) Exists(ctx context.Context, sectionNumber int) bool {
@@ -49,11 +53,10 @@ func (s *MockService) Exists(ctx context.Context, sectionNumber int) bool {
 
 func (s *MockService) Save(ctx context.Context, section domain.Section) (int, error) {
 	if s.Db.ExistsID {
-		return 0, fmt.Errorf(s.Db.Error)
+		return 0, apperrors.Conflict(s.Db.Error)
 	}
-	//id, err := MockRepository. .Save(ctx, section)
 	if s.Db.Error != "" {
-		return 0, fmt.Errorf(s.Db.Error)
+		return 0, apperrors.Conflict(s.Db.Error)
 	}
 	s.Db.ID++
 	return s.Db.ID, nil
@@ -69,7 +72,7 @@ func (s *MockService) Update(ctx context.Context, ID int, SectionNumber int, Cur
 		}
 	}
 	if !flag {
-		return domain.Section{}, errors.New("section not found")
+		return domain.Section{}, ErrNotFound
 	}
 
 	var copyDatamock = s.Db.DataMock[value]
@@ -2,7 +2,6 @@ package sellers
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
@@ -28,7 +27,7 @@ func (m *MockRepository) Get(ctx context.Context, id int) (domain.Seller, error)
 		}
 	}
 
-	return domain.Seller{}, fmt.Errorf(m.Error)
+	return domain.Seller{}, ErrNotFound
 }
 
 func (m *MockRepository) Exists(ctx context.Context, cid int) bool {
@@ -61,5 +60,5 @@ func (m *MockRepository) Delete(ctx context.Context, id int) error {
 		}
 	}
 
-	return errors.New("seller not found")
+	return ErrNotFound
 }
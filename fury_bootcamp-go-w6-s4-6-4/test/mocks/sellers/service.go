@@ -1,18 +1,19 @@
 package sellers
 
 import (
-	"errors"
+	"context"
 	"fmt"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
 )
 
 // Errors
 var (
-	ErrNotFound = errors.New("seller not found")
-	ErrRequired = errors.New("field required")
-	ErrExists   = errors.New("cid already exists")
-	ErrRequest  = errors.New("incorrec field content")
+	ErrNotFound = apperrors.NotFound("seller not found")
+	ErrRequired = apperrors.Validation("field required")
+	ErrExists   = apperrors.Conflict("cid already exists")
+	ErrRequest  = apperrors.Validation("incorrect field content")
 )
 
 type MockService struct {
@@ -20,24 +21,24 @@ type MockService struct {
 	Error    string
 }
 
-func (m *MockService) GetAll() ([]domain.Seller, error) {
+func (m *MockService) GetAll(ctx context.Context) ([]domain.Seller, error) {
 	if m.Error != "" {
 		return nil, fmt.Errorf(m.Error)
 	}
 	return m.DataMock, nil
 }
 
-func (m *MockService) Get(id int) (domain.Seller, error) {
+func (m *MockService) Get(ctx context.Context, id int) (domain.Seller, error) {
 	for i, elemento := range m.DataMock {
 		if elemento.ID == id {
 			return m.DataMock[i], nil
 		}
 	}
 
-	return domain.Seller{}, fmt.Errorf(m.Error)
+	return domain.Seller{}, ErrNotFound
 }
 
-func (m *MockService) Exists(cid int) bool {
+func (m *MockService) Exists(ctx context.Context, cid int) bool {
 	for _, elemento := range m.DataMock {
 		if elemento.CID == cid {
 			return true
@@ -46,10 +47,10 @@ func (m *MockService) Exists(cid int) bool {
 	return false
 }
 
-func (m *MockService) Save(cid, locality int, companyName, address, telephone string) (int, error) {
+func (m *MockService) Save(ctx context.Context, cid, locality int, companyName, address, telephone string) (int, error) {
 	var seller domain.Seller
 
-	if m.Exists(cid) {
+	if m.Exists(ctx, cid) {
 		return 0, ErrExists
 	}
 
@@ -84,8 +85,8 @@ func (m *MockService) Save(cid, locality int, companyName, address, telephone st
 	return 1, nil
 }
 
-func (m *MockService) Update(new domain.Seller) (domain.Seller, error) {
-	anterior, err := m.Get(new.ID)
+func (m *MockService) Update(ctx context.Context, new domain.Seller) (domain.Seller, error) {
+	anterior, err := m.Get(ctx, new.ID)
 	if err != nil {
 		return domain.Seller{}, ErrNotFound
 	}
@@ -108,8 +109,8 @@ func (m *MockService) Update(new domain.Seller) (domain.Seller, error) {
 	return new, nil
 }
 
-func (m *MockService) Delete(id int) error {
-	_, err := m.Get(id)
+func (m *MockService) Delete(ctx context.Context, id int) error {
+	_, err := m.Get(ctx, id)
 	if err != nil {
 		return ErrNotFound
 	}
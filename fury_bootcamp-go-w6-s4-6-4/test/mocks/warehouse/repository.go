@@ -2,7 +2,6 @@ package warehouse
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
 )
@@ -28,7 +27,7 @@ func (m *mockRepositoryWarehouse) Get(ctx context.Context, id int) (domain.Wareh
 			return w, nil
 		}
 	}
-	return domain.Warehouse{}, fmt.Errorf("warehouse not found")
+	return domain.Warehouse{}, ErrNotFound
 }
 
 func (m *mockRepositoryWarehouse) Exists(ctx context.Context, warehouseCode string) bool {
@@ -42,7 +41,7 @@ func (m *mockRepositoryWarehouse) Exists(ctx context.Context, warehouseCode stri
 
 func (m *mockRepositoryWarehouse) Save(ctx context.Context, w domain.Warehouse) (int, error) {
 	if m.Exists(ctx, w.WarehouseCode) {
-		return 0, fmt.Errorf("warehouse already exists")
+		return 0, ErrCodeExists
 	}
 	w.ID = m.GlobalId
 	m.DataMock = append(m.DataMock, w)
@@ -57,7 +56,7 @@ func (m *mockRepositoryWarehouse) Update(ctx context.Context, w domain.Warehouse
 			return nil
 		}
 	}
-	return fmt.Errorf("warehouse not found")
+	return ErrNotFound
 }
 
 func (m *mockRepositoryWarehouse) Delete(ctx context.Context, id int) error {
@@ -67,5 +66,13 @@ func (m *mockRepositoryWarehouse) Delete(ctx context.Context, id int) error {
 			return nil
 		}
 	}
-	return fmt.Errorf("warehouse not found")
+	return ErrNotFound
+}
+
+func (m *mockRepositoryWarehouse) StockOnHand(ctx context.Context) (map[int]int, error) {
+	stock := map[int]int{}
+	for _, w := range m.DataMock {
+		stock[w.ID] = 0
+	}
+	return stock, nil
 }
@@ -1,9 +1,16 @@
 package warehouse
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+// Errors
+var (
+	ErrNotFound   = apperrors.NotFound("warehouse not found")
+	ErrCodeExists = apperrors.Conflict("warehouse code already exists")
 )
 
 // type mockServiceWarehouse struct {
@@ -27,7 +34,7 @@ import (
 // }
 
 // func (m *mockServiceWarehouse) Update(w domain.Warehouse, id int) (domain.Warehouse, error) {
-// 	originalWarehouse, err := m.Get(id)
+// 	originalWarehouse, err := m.Get(ctx, id)
 // 	if err != nil {
 // 		return domain.Warehouse{}, err
 // 	}
@@ -72,23 +79,23 @@ func NewServiceWarehouse(data []domain.Warehouse) *mockServiceWarehouse {
 	}
 }
 
-func (m *mockServiceWarehouse) Get(id int) (domain.Warehouse, error) {
+func (m *mockServiceWarehouse) Get(ctx context.Context, id int) (domain.Warehouse, error) {
 	for _, w := range m.dataMock {
 		if w.ID == id {
 			return w, nil
 		}
 	}
-	return domain.Warehouse{}, fmt.Errorf("warehouse not found")
+	return domain.Warehouse{}, ErrNotFound
 }
 
-func (m *mockServiceWarehouse) GetAll() ([]domain.Warehouse, error) {
+func (m *mockServiceWarehouse) GetAll(ctx context.Context) ([]domain.Warehouse, error) {
 	return m.dataMock, nil
 }
 
-func (m *mockServiceWarehouse) Save(w domain.Warehouse) (int, error) {
+func (m *mockServiceWarehouse) Save(ctx context.Context, w domain.Warehouse) (int, error) {
 	for _, warehouse := range m.dataMock {
 		if warehouse.WarehouseCode == w.WarehouseCode {
-			return 0, fmt.Errorf("warehouse code already exists")
+			return 0, ErrCodeExists
 		}
 	}
 	w.ID = m.GlobalId
@@ -97,8 +104,8 @@ func (m *mockServiceWarehouse) Save(w domain.Warehouse) (int, error) {
 	return w.ID, nil
 }
 
-func (m *mockServiceWarehouse) Update(w domain.Warehouse, id int) (domain.Warehouse, error) {
-	originalWarehouse, err := m.Get(id)
+func (m *mockServiceWarehouse) Update(ctx context.Context, w domain.Warehouse, id int) (domain.Warehouse, error) {
+	originalWarehouse, err := m.Get(ctx, id)
 	if err != nil {
 		return domain.Warehouse{}, err
 	}
@@ -129,15 +136,15 @@ func (m *mockServiceWarehouse) Update(w domain.Warehouse, id int) (domain.Wareho
 			return w, nil
 		}
 	}
-	return domain.Warehouse{}, fmt.Errorf("warehouse not found")
+	return domain.Warehouse{}, ErrNotFound
 }
 
-func (m *mockServiceWarehouse) Delete(id int) error {
+func (m *mockServiceWarehouse) Delete(ctx context.Context, id int) error {
 	for i, w := range m.dataMock {
 		if w.ID == id {
 			m.dataMock = append(m.dataMock[:i], m.dataMock[i+1:]...)
 			return nil
 		}
 	}
-	return fmt.Errorf("warehouse not found")
+	return ErrNotFound
 }
@@ -0,0 +1,207 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w6-s4-6-4/pkg/apperrors"
+)
+
+// MockRepository keeps subscriptions, events and deliveries in memory.
+// Events holds the outbox; Dispatched records the events fanned out.
+type MockRepository struct {
+	mu            sync.Mutex
+	Subs          []domain.WebhookSubscription
+	Events        []domain.OutboxEvent
+	Dispatched    map[int64]bool
+	DeliveriesMap map[int64]domain.WebhookDelivery
+	Error         string
+	nextID        int64
+}
+
+func (m *MockRepository) err() error {
+	if m.Error != "" {
+		return fmt.Errorf(m.Error)
+	}
+	return nil
+}
+
+func (m *MockRepository) SaveSubscription(ctx context.Context, s domain.WebhookSubscription) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.err(); err != nil {
+		return 0, err
+	}
+	s.ID = len(m.Subs) + 1
+	m.Subs = append(m.Subs, s)
+	return s.ID, nil
+}
+
+func (m *MockRepository) Subscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.err(); err != nil {
+		return nil, err
+	}
+	return append([]domain.WebhookSubscription{}, m.Subs...), nil
+}
+
+func (m *MockRepository) DeleteSubscription(ctx context.Context, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.err(); err != nil {
+		return err
+	}
+	for i, s := range m.Subs {
+		if s.ID == id {
+			m.Subs = append(m.Subs[:i], m.Subs[i+1:]...)
+			return nil
+		}
+	}
+	return apperrors.NotFound("webhook subscription not found")
+}
+
+func (m *MockRepository) PendingEvents(ctx context.Context, limit int) ([]domain.OutboxEvent, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.err(); err != nil {
+		return nil, err
+	}
+	var pending []domain.OutboxEvent
+	for _, e := range m.Events {
+		if !m.Dispatched[e.ID] && len(pending) < limit {
+			pending = append(pending, e)
+		}
+	}
+	return pending, nil
+}
+
+func (m *MockRepository) FanOut(ctx context.Context, eventID int64, subscriptionIDs []int, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.err(); err != nil {
+		return err
+	}
+	if m.Dispatched == nil {
+		m.Dispatched = map[int64]bool{}
+	}
+	if m.DeliveriesMap == nil {
+		m.DeliveriesMap = map[int64]domain.WebhookDelivery{}
+	}
+	for _, subID := range subscriptionIDs {
+		m.nextID++
+		m.DeliveriesMap[m.nextID] = domain.WebhookDelivery{
+			ID:             m.nextID,
+			EventID:        eventID,
+			EventType:      m.event(eventID).Type,
+			SubscriptionID: subID,
+			URL:            m.sub(subID).URL,
+			Status:         domain.DeliveryPending,
+			NextAttemptAt:  at,
+		}
+	}
+	m.Dispatched[eventID] = true
+	return nil
+}
+
+func (m *MockRepository) DueAttempts(ctx context.Context, now time.Time, limit int) ([]domain.WebhookAttempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.err(); err != nil {
+		return nil, err
+	}
+	var due []domain.WebhookAttempt
+	for _, d := range m.sorted() {
+		if d.Status == domain.DeliveryPending && !d.NextAttemptAt.After(now) && len(due) < limit {
+			due = append(due, domain.WebhookAttempt{Delivery: d, Event: m.event(d.EventID), Secret: m.sub(d.SubscriptionID).Secret})
+		}
+	}
+	return due, nil
+}
+
+func (m *MockRepository) Claim(ctx context.Context, id int64, now, until time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.err(); err != nil {
+		return false, err
+	}
+	d, ok := m.DeliveriesMap[id]
+	if !ok || d.Status != domain.DeliveryPending || d.NextAttemptAt.After(now) {
+		return false, nil
+	}
+	d.NextAttemptAt = until
+	m.DeliveriesMap[id] = d
+	return true, nil
+}
+
+func (m *MockRepository) Deliveries(ctx context.Context, status string, limit int) ([]domain.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.err(); err != nil {
+		return nil, err
+	}
+	deliveries := []domain.WebhookDelivery{}
+	for _, d := range m.sorted() {
+		if (status == "" || d.Status == status) && len(deliveries) < limit {
+			deliveries = append(deliveries, d)
+		}
+	}
+	return deliveries, nil
+}
+
+func (m *MockRepository) Delivery(ctx context.Context, id int64) (domain.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.err(); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	d, ok := m.DeliveriesMap[id]
+	if !ok {
+		return domain.WebhookDelivery{}, apperrors.NotFound("webhook delivery not found")
+	}
+	return d, nil
+}
+
+func (m *MockRepository) UpdateDelivery(ctx context.Context, d domain.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.err(); err != nil {
+		return err
+	}
+	if _, ok := m.DeliveriesMap[d.ID]; !ok {
+		return apperrors.NotFound("webhook delivery not found")
+	}
+	m.DeliveriesMap[d.ID] = d
+	return nil
+}
+
+func (m *MockRepository) sorted() []domain.WebhookDelivery {
+	deliveries := make([]domain.WebhookDelivery, 0, len(m.DeliveriesMap))
+	for _, d := range m.DeliveriesMap {
+		deliveries = append(deliveries, d)
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID < deliveries[j].ID })
+	return deliveries
+}
+
+func (m *MockRepository) event(id int64) domain.OutboxEvent {
+	for _, e := range m.Events {
+		if e.ID == id {
+			return e
+		}
+	}
+	return domain.OutboxEvent{}
+}
+
+func (m *MockRepository) sub(id int) domain.WebhookSubscription {
+	for _, s := range m.Subs {
+		if s.ID == id {
+			return s
+		}
+	}
+	return domain.WebhookSubscription{}
+}